@@ -0,0 +1,254 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/analysis"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+type analyzeOptions struct {
+	filenamesFlag                  []string
+	againstClusterFlag             bool
+	enableChecksFlag               []string
+	disableChecksFlag              []string
+	outputFlag                     string
+	quietFlag                      bool
+	includeIngressFlag             bool
+	certificateExpiryThresholdFlag string
+}
+
+func NewAnalyzeCommand(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	o := &analyzeOptions{}
+	cmd := &cobra.Command{
+		Use:   "analyze -f FILENAME --against-cluster",
+		Short: "Analyze how the HTTPRoutes within files would behave once applied to the cluster",
+		Long: `Analyze how the HTTPRoutes within files would behave once applied to the cluster.
+
+The objects within the files are overlaid on top of the live objects of the
+cluster, replacing the live objects with the same kind, namespace and name. For
+each HTTPRoute within the files, this reports the listeners and hostnames it
+would attach to, the existing HTTPRoutes defining the same matches, whether its
+Backends exist, and the effective policies it would inherit.
+
+Across the whole cluster, this also reports the hostnames served by more than
+one Gateway, and the wildcard hostnames of a Gateway which overlap with
+hostnames served by another Gateway.
+
+The HTTPRoutes, and the Gateways they reference, are also checked for features
+which their GatewayClass does not list in its status.supportedFeatures.
+GatewayClasses which do not report any feature are not checked.
+
+HTTPRoutes without hostnames which attach to an HTTP or HTTPS listener without a
+hostname are reported as well, since they serve requests for any hostname,
+which may not be intended.
+
+Every ReferenceGrant of the cluster is checked for the references it
+authorizes. ReferenceGrants which authorize no reference, and ReferenceGrants
+which expose all resources of some kind while only one of them is referenced,
+are reported.
+
+The certificates referenced by the listeners of every Gateway are read from
+their Secrets, and those which expired or expire within
+--certificate-expiry-threshold are reported.
+
+With --include-ingress, the networking.k8s.io/v1 Ingresses of the cluster are
+listed as well, and the hosts of their rules which overlap a hostname served by
+some HTTPRoute are reported, since which of them receives the requests depends
+on the controllers. This helps while migrating from Ingresses to HTTPRoutes.
+
+Each finding comes from a check, which can be enabled or disabled by its ID:
+` + analysisCheckIDs() + `.
+
+With -o json or yaml, each finding includes the ID of the template of its
+message and the values of the parameters of the template. Unlike the wording of
+messages, the IDs of templates and the names of their parameters never change,
+so tools should key off them. With --quiet, only the ID of the check and the
+resource of each finding are printed, one finding per line.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			runAnalyze(f, out, o)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&o.filenamesFlag, "filename", "f", nil, "Files containing the objects to analyze. May be repeated.")
+	cmd.Flags().BoolVar(&o.againstClusterFlag, "against-cluster", false, "If present, analyze the objects against the live objects of the cluster.")
+	cmd.Flags().StringSliceVar(&o.enableChecksFlag, "enable-checks", nil, "IDs of the only checks to run. All checks run if not specified.")
+	cmd.Flags().StringSliceVar(&o.disableChecksFlag, "disable-checks", nil, "IDs of the checks which do not run.")
+	cmd.Flags().StringVarP(&o.outputFlag, "output", "o", "", "Output format. Only the findings are printed with json or yaml. Must be one of (json, yaml)")
+	cmd.Flags().BoolVarP(&o.quietFlag, "quiet", "q", false, "If present, only print the ID of the check and the resource of each finding, one finding per line.")
+	cmd.Flags().StringVar(&o.certificateExpiryThresholdFlag, "certificate-expiry-threshold", "30d", "Certificates of listeners which expire within this duration are reported, e.g. 30d or 72h.")
+	cmd.Flags().BoolVar(&o.includeIngressFlag, "include-ingress", false, "If present, also list the Ingresses of the cluster, and report their hosts which are also served by HTTPRoutes. Requires permission to list Ingresses.")
+	return cmd
+}
+
+func runAnalyze(f cmdutils.Factory, out io.Writer, o *analyzeOptions) {
+	if len(o.filenamesFlag) == 0 {
+		fmt.Fprintf(os.Stderr, "must specify at least one file with -f\n")
+		os.Exit(1)
+	}
+	if !o.againstClusterFlag {
+		fmt.Fprintf(os.Stderr, "analyzing files without the cluster is not supported; use --against-cluster\n")
+		os.Exit(1)
+	}
+	outputFormat, err := cmdutils.ValidateAndReturnOutputFormat(o.outputFlag)
+	if err != nil || (outputFormat != cmdutils.OutputFormatTable && outputFormat != cmdutils.OutputFormatJSON && outputFormat != cmdutils.OutputFormatYAML) {
+		fmt.Fprintf(os.Stderr, "--output must be one of (json, yaml)\n")
+		os.Exit(1)
+	}
+	if o.quietFlag && outputFormat != cmdutils.OutputFormatTable {
+		fmt.Fprintf(os.Stderr, "--quiet cannot be used with --output\n")
+		os.Exit(1)
+	}
+	certificateExpiryThreshold, err := cmdutils.ParseDuration(o.certificateExpiryThresholdFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid value used in --certificate-expiry-threshold flag: %v\n", err)
+		os.Exit(1)
+	}
+	for _, id := range append(slices.Clone(o.enableChecksFlag), o.disableChecksFlag...) {
+		if _, ok := analysis.LookupCheck(id); !ok {
+			fmt.Fprintf(os.Stderr, "unknown check %q; must be one of (%v)\n", id, analysisCheckIDs())
+			os.Exit(1)
+		}
+	}
+
+	objects, sources, err := common.ReadObjectsFromFiles(o.filenamesFlag)
+	handleErrOrExitWithMsg(err, "")
+
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(k8sClients.DiscoveryClient))
+	overlayClients, err := common.NewOverlayK8sClients(k8sClients, mapper, objects)
+	handleErrOrExitWithMsg(err, "")
+
+	// The policies within the files need to be overlaid as well, so the
+	// PolicyManager of the factory cannot be used.
+	policyManager := policymanager.New(overlayClients.DC)
+	err = policyManager.Init(context.Background())
+	handleErrOrExitWithMsg(err, "failed to initialize policy manager")
+
+	var httpRoutes []unstructured.Unstructured
+	for _, obj := range objects {
+		if obj.GroupVersionKind().GroupKind() == (schema.GroupKind{Group: gatewayv1.GroupName, Kind: "HTTPRoute"}) {
+			httpRoutes = append(httpRoutes, obj)
+		}
+	}
+
+	if len(httpRoutes) == 0 {
+		fmt.Fprintf(os.Stderr, "no HTTPRoutes found in the files\n")
+		os.Exit(1)
+	}
+
+	discoverer := newDiscoverer(f, overlayClients, policyManager)
+	model, err := discoverAnalysisModel(discoverer, httpRoutes, o.includeIngressFlag, f.Progress())
+	handleErrOrExitWithMsg(err, "")
+	model.Sources = sources
+	findings := analysis.Run(model, analysis.Options{Enable: o.enableChecksFlag, Disable: o.disableChecksFlag, CertificateExpiryThreshold: certificateExpiryThreshold})
+	printAnalysis(f, out, o, outputFormat, model.DryRuns, findings, sources)
+}
+
+// discoverAnalysisModel dry-runs the HTTPRoutes and discovers the rest of the
+// model which the checks run against. The progress is done once it returns, so
+// that errors can be printed.
+func discoverAnalysisModel(discoverer resourcediscovery.Discoverer, httpRoutes []unstructured.Unstructured, includeIngress bool, progress common.Progress) (*analysis.Model, error) {
+	defer progress.Done()
+
+	model := &analysis.Model{}
+	for i, httpRoute := range httpRoutes {
+		namespace := httpRoute.GetNamespace()
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		dryRun, err := discoverer.DryRunHTTPRoute(namespace, httpRoute.GetName())
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze HTTPRoute %v/%v: %w", namespace, httpRoute.GetName(), err)
+		}
+		model.DryRuns = append(model.DryRuns, dryRun)
+		progress.Step(common.ProgressStep{Action: "evaluated", Done: i + 1, Total: len(httpRoutes), Unit: "HTTPRoutes"})
+	}
+
+	var err error
+	if model.ServedHostnames, err = discoverer.DiscoverServedHostnames(); err != nil {
+		return nil, fmt.Errorf("failed to discover the hostnames served by Gateways: %w", err)
+	}
+	if model.ListenerHostnames, err = discoverer.DiscoverListenerHostnames(); err != nil {
+		return nil, fmt.Errorf("failed to discover the hostnames of listeners: %w", err)
+	}
+	if model.ReferenceGrantCoverage, err = discoverer.DiscoverReferenceGrantCoverage(); err != nil {
+		return nil, fmt.Errorf("failed to discover the references authorized by ReferenceGrants: %w", err)
+	}
+	if model.Certificates, err = discoverer.DiscoverCertificates(); err != nil {
+		return nil, fmt.Errorf("failed to discover the certificates of listeners: %w", err)
+	}
+	// Ingresses are only listed when asked for, so that clusters which do not
+	// serve them, or where they cannot be listed, are unaffected otherwise.
+	if includeIngress {
+		if model.IngressHostnameCollisions, err = discoverer.DiscoverIngressHostnameCollisions(); err != nil {
+			return nil, fmt.Errorf("failed to discover the hosts of Ingresses which are also served by HTTPRoutes: %w", err)
+		}
+	}
+	return model, nil
+}
+
+// printAnalysis prints the dry runs and the findings of analyze. Findings with
+// a severity of Warning or Error are collected as warnings of the factory
+// whatever the output, so that --strict fails on them even with --quiet.
+func printAnalysis(f cmdutils.Factory, out io.Writer, o *analyzeOptions, outputFormat cmdutils.OutputFormat, dryRuns []*resourcediscovery.HTTPRouteDryRun, findings []analysis.Finding, sources common.ObjectSources) {
+	for _, finding := range findings {
+		if finding.Severity != analysis.SeverityInfo {
+			f.Warnings().Add(finding.Resource, finding.Message)
+		}
+	}
+
+	dryRunPrinter := &printer.DryRunPrinter{Writer: out, Sources: sources}
+	if o.quietFlag {
+		dryRunPrinter.PrintFindingIDs(findings)
+		return
+	}
+	if outputFormat != cmdutils.OutputFormatTable {
+		dryRunPrinter.PrintFindings(findings, outputFormat)
+		return
+	}
+	dryRunPrinter.Print(dryRuns, findings)
+}
+
+// analysisCheckIDs returns the IDs of all checks, separated by commas.
+func analysisCheckIDs() string {
+	var ids []string
+	for _, check := range analysis.Checks() {
+		ids = append(ids, check.ID)
+	}
+	return strings.Join(ids, ", ")
+}
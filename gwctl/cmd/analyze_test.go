@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/analysis"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestPrintAnalysis_QuietStrict(t *testing.T) {
+	httpRoute := common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "default", Name: "foo-httproute"}
+	findings := []analysis.Finding{
+		{CheckID: analysis.CheckHTTPRouteError, Severity: analysis.SeverityError, Resource: httpRoute, Message: "some error"},
+		{CheckID: analysis.CheckHTTPRouteAnyHostname, Severity: analysis.SeverityInfo, Resource: httpRoute, Message: "some info"},
+	}
+
+	f := &fakeFactory{}
+	buff := &bytes.Buffer{}
+	printAnalysis(f, buff, &analyzeOptions{quietFlag: true}, cmdutils.OutputFormatTable, nil, findings, nil)
+
+	want := `httproute-error HTTPRoute default/foo-httproute
+httproute-any-hostname HTTPRoute default/foo-httproute
+`
+	if diff := cmp.Diff(want, buff.String()); diff != "" {
+		t.Errorf("Unexpected output (-want +got):\n%v", diff)
+	}
+	// Findings are collected as warnings with --quiet as well, so that
+	// --strict exits with exitCodeWarnings.
+	wantWarnings := []common.Warning{{Resource: httpRoute, Message: "some error"}}
+	if diff := cmp.Diff(wantWarnings, f.Warnings().List()); diff != "" {
+		t.Errorf("Unexpected warnings (-want +got):\n%v", diff)
+	}
+	if got := reportWarnings(&bytes.Buffer{}, f.Warnings().List()); got != exitCodeWarnings {
+		t.Errorf("reportWarnings() = %v, want %v", got, exitCodeWarnings)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func NewAuthCommand(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Inspect the authorization of gwctl",
+	}
+	cmd.AddCommand(newCmdAuthCheck(f, out))
+	return cmd
+}
+
+type authCheckOptions struct {
+	namespaceFlag     string
+	allNamespacesFlag bool
+	outputFlag        string
+}
+
+func newCmdAuthCheck(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	o := &authCheckOptions{}
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check that the current user has the permissions needed by gwctl, and print RBAC rules granting the missing ones",
+		Args:  cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			runAuthCheck(f, out, o)
+		},
+	}
+	addNamespaceFlag(&o.namespaceFlag, cmd)
+	addAllNamespacesFlag(&o.allNamespacesFlag, cmd)
+	addOutputFormatFlag(&o.outputFlag, cmd)
+	return cmd
+}
+
+func runAuthCheck(f cmdutils.Factory, out io.Writer, o *authCheckOptions) {
+	outputFormat, err := cmdutils.ValidateAndReturnOutputFormat(o.outputFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	namespace := o.namespaceFlag
+	if o.allNamespacesFlag {
+		namespace = ""
+	}
+
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+
+	checks, err := common.CheckPermissions(context.Background(), k8sClients.Client, namespace)
+	handleErrOrExitWithMsg(err, "failed to check permissions")
+
+	permissionsPrinter := &printer.PermissionsPrinter{Writer: out}
+	permissionsPrinter.Print(checks, namespace, outputFormat)
+}
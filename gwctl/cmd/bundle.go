@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+type bundleOptions struct {
+	outputFlag string
+}
+
+func NewBundleCommand(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	o := &bundleOptions{}
+	cmd := &cobra.Command{
+		Use:   "bundle --output FILENAME",
+		Short: "Collect all Gateway API resources into a tarball to share for troubleshooting",
+		Long: `Collect all Gateway API resources into a gzipped tarball to share for troubleshooting.
+
+The tarball contains the resources of every Gateway API and Policy kind
+including their status, the events associated with them, and the CRDs of their
+kinds. Use "-" as the filename to write the tarball to stdout.`,
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			runBundle(f, out, o)
+		},
+	}
+	cmd.Flags().StringVarP(&o.outputFlag, "output", "o", "", "File to write the tarball to, e.g. bundle.tar.gz")
+	return cmd
+}
+
+func runBundle(f cmdutils.Factory, out io.Writer, o *bundleOptions) {
+	if o.outputFlag == "" {
+		fmt.Fprintf(os.Stderr, "must specify the file to write the bundle to with --output\n")
+		os.Exit(1)
+	}
+
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+	policyManager, err := f.PolicyManager()
+	handleErrOrExitWithMsg(err, "")
+
+	progress := f.Progress()
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
+	discoverer.Progress = progress
+	bundle, err := discoverer.DiscoverBundle()
+	progress.Done()
+	handleErrOrExitWithMsg(err, "failed to collect resources for the bundle")
+
+	if o.outputFlag == "-" {
+		handleErrOrExitWithMsg(printer.WriteBundle(out, bundle), "failed to write the bundle")
+		return
+	}
+	file, err := os.Create(o.outputFlag)
+	handleErrOrExitWithMsg(err, "failed to create the bundle")
+	if err := printer.WriteBundle(file, bundle); err != nil {
+		file.Close()
+		handleErrOrExitWithMsg(err, "failed to write the bundle")
+	}
+	handleErrOrExitWithMsg(file.Close(), "failed to write the bundle")
+	fmt.Fprintf(os.Stderr, "Wrote %d resources to %v\n", len(bundle.Objects), o.outputFlag)
+}
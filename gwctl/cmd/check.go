@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func NewCheckCommand(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check the data plane of resources",
+	}
+	cmd.AddCommand(newCmdCheckGateways(f, out))
+	return cmd
+}
+
+type checkGatewaysOptions struct {
+	probeFlag       bool
+	timeoutFlag     time.Duration
+	concurrencyFlag int
+	outputFlag      string
+}
+
+func newCmdCheckGateways(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	o := &checkGatewaysOptions{}
+	cmd := &cobra.Command{
+		Use:     "gateways [NAMESPACE/]NAME",
+		Aliases: resourceTypeAliases("gateways"),
+		Short:   "Check whether the listeners of a Gateway answer at its addresses",
+		Long: `Check whether the listeners of a Gateway answer at the addresses in its status.
+
+With --probe, each listener is probed at each address: a TCP connection is
+attempted, followed by a TLS handshake for HTTPS and TLS listeners, and an HTTP
+HEAD request for HTTP and HTTPS listeners. The server name sent as SNI and as the
+Host of the request is the hostname of the listener, or one served through the
+listener by an attached HTTPRoute. Redirects are never followed.
+
+The probes run from the machine gwctl runs on, not from within the cluster, so
+they only tell whether the listeners are reachable from that machine. Probes
+which fail are reported as results, and as warnings with --strict.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			if !o.probeFlag {
+				fmt.Fprintf(os.Stderr, "gateways can only be checked with --probe\n")
+				os.Exit(1)
+			}
+			if o.timeoutFlag <= 0 {
+				fmt.Fprintf(os.Stderr, "--timeout must be positive\n")
+				os.Exit(1)
+			}
+			if o.concurrencyFlag < 1 {
+				fmt.Fprintf(os.Stderr, "--concurrency must be at least 1\n")
+				os.Exit(1)
+			}
+			outputFormat, err := cmdutils.ValidateAndReturnOutputFormat(o.outputFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			runCheckGateways(f, out, o, args[0], outputFormat)
+		},
+	}
+	cmd.Flags().BoolVar(&o.probeFlag, "probe", false, "If present, probe each listener of the Gateway at each of its addresses from this machine.")
+	cmd.Flags().DurationVar(&o.timeoutFlag, "timeout", 5*time.Second, "Time after which the probe of a listener at an address is abandoned.")
+	cmd.Flags().IntVar(&o.concurrencyFlag, "concurrency", 8, "Maximum number of probes run at the same time.")
+	addOutputFormatFlag(&o.outputFlag, cmd)
+	return cmd
+}
+
+func runCheckGateways(f cmdutils.Factory, out io.Writer, o *checkGatewaysOptions, ref string, outputFormat cmdutils.OutputFormat) {
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+	policyManager, err := f.PolicyManager()
+	handleErrOrExitWithMsg(err, "")
+
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
+	gatewayNode := discoverSingleGateway(discoverer, ref)
+	gateway := common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: gatewayNode.Gateway.GetNamespace(), Name: gatewayNode.Gateway.GetName()}
+
+	origin, err := os.Hostname()
+	if err != nil {
+		origin = "an unknown host"
+	}
+	prober := common.Prober{Timeout: o.timeoutFlag, Concurrency: o.concurrencyFlag}
+	report := common.ProbeReport{
+		Gateway: gateway,
+		Origin:  origin,
+		Results: prober.Probe(context.Background(), resourcediscovery.ProbeTargetsForGateway(gatewayNode)),
+	}
+
+	warnings := f.Warnings()
+	if len(report.Results) == 0 {
+		warnings.Add(gateway, "Gateway has no addresses in its status to probe")
+	}
+	for _, result := range report.Results {
+		if result.Error != "" {
+			warnings.Add(gateway, fmt.Sprintf("Probe of listener %q at %v:%d: %v", result.Listener, result.Address, result.Port, printer.FormatProbeResult(result)))
+		}
+	}
+
+	probesPrinter := &printer.ProbesPrinter{Writer: out}
+	probesPrinter.Print(report, outputFormat)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func NewCompareCommand(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Show a side-by-side comparison of two resources",
+	}
+	cmd.AddCommand(newCmdCompareGateways(f, out))
+	return cmd
+}
+
+func newCmdCompareGateways(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	var outputFlag string
+	cmd := &cobra.Command{
+		Use:     "gateways [NAMESPACE/]NAME [NAMESPACE/]NAME",
+		Aliases: resourceTypeAliases("gateways"),
+		Short:   "Compare the listeners, routes, addresses and policies of two Gateways",
+		Args:    cobra.ExactArgs(2),
+		Run: func(_ *cobra.Command, args []string) {
+			outputFormat, err := cmdutils.ValidateAndReturnOutputFormat(outputFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			runCompareGateways(f, out, args[0], args[1], outputFormat)
+		},
+	}
+	addOutputFormatFlag(&outputFlag, cmd)
+	return cmd
+}
+
+func runCompareGateways(f cmdutils.Factory, out io.Writer, left, right string, outputFormat cmdutils.OutputFormat) {
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+	policyManager, err := f.PolicyManager()
+	handleErrOrExitWithMsg(err, "")
+
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
+	leftNode := discoverSingleGateway(discoverer, left)
+	rightNode := discoverSingleGateway(discoverer, right)
+
+	comparison := resourcediscovery.CompareGateways(leftNode, rightNode)
+	comparisonPrinter := &printer.GatewayComparisonPrinter{Writer: out}
+	comparisonPrinter.Print(comparison, outputFormat)
+}
+
+// discoverSingleGateway discovers the resources related to a Gateway referenced
+// as [NAMESPACE/]NAME and returns the node for that Gateway.
+func discoverSingleGateway(discoverer resourcediscovery.Discoverer, ref string) *resourcediscovery.GatewayNode {
+	namespace, name := metav1.NamespaceDefault, ref
+	if parts := strings.Split(ref, "/"); len(parts) == 2 {
+		namespace, name = parts[0], parts[1]
+	} else if len(parts) > 2 {
+		fmt.Fprintf(os.Stderr, "invalid Gateway reference %q; must be in the format [NAMESPACE/]NAME\n", ref)
+		os.Exit(1)
+	}
+
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{Namespace: namespace, Name: name})
+	handleErrOrExitWithMsg(err, fmt.Sprintf("failed to discover Gateway %v/%v", namespace, name))
+
+	gatewayNode, ok := resourceModel.Gateways[resourcediscovery.GatewayID(namespace, name)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Gateway %v/%v not found\n", namespace, name)
+		os.Exit(1)
+	}
+	return gatewayNode
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func init() {
+	for _, kind := range []printer.Kind{
+		{GroupKind: schema.GroupKind{Kind: "Namespace"}, Name: "namespaces", Aliases: []string{"namespace", "ns"}, NewCommand: kindCommand(newCmdNamespaces, commandNameGet, commandNameDescribe)},
+		{GroupKind: schema.GroupKind{Group: gatewayv1.GroupName, Kind: "GatewayClass"}, Name: "gatewayclasses", Aliases: []string{"gatewayclass", "gc"}, NewCommand: kindCommand(newCmdGatewayClasses, commandNameGet, commandNameDescribe)},
+		{GroupKind: schema.GroupKind{Group: gatewayv1.GroupName, Kind: "Gateway"}, Name: "gateways", Aliases: []string{"gateway", "gw", "gtw"}, NewCommand: kindCommand(newCmdGateways, commandNameGet, commandNameDescribe)},
+		{Name: "listeners", Aliases: []string{"listener"}, NewCommand: kindCommand(newCmdListeners, commandNameGet)},
+		{Name: "certificates", Aliases: []string{"certificate", "certs", "cert"}, NewCommand: kindCommand(newCmdCertificates, commandNameGet)},
+		{GroupKind: schema.GroupKind{Group: gatewayv1.GroupName, Kind: "HTTPRoute"}, Name: "httproutes", Aliases: []string{"httproute", "hr"}, NewCommand: kindCommand(newCmdHTTPRoutes, commandNameGet, commandNameDescribe)},
+		// ReferenceGrants have no subcommands, but can be referred to, like by
+		// the label command.
+		{GroupKind: schema.GroupKind{Group: gatewayv1.GroupName, Kind: "ReferenceGrant"}, Name: "referencegrants", Aliases: []string{"referencegrant", "refgrant", "refgrants"}},
+		{Name: "backends", Aliases: []string{"backend"}, NewCommand: kindCommand(newCmdBackends, commandNameGet, commandNameDescribe)},
+		{GroupKind: schema.GroupKind{Kind: "Service"}, Name: "services", Aliases: []string{"service", "svc"}, NewCommand: kindCommand(newCmdReferencedResourcesFor("services"), commandNameDescribe)},
+		{GroupKind: schema.GroupKind{Kind: "Secret"}, Name: "secrets", Aliases: []string{"secret"}, NewCommand: kindCommand(newCmdReferencedResourcesFor("secrets"), commandNameDescribe)},
+		{Name: "policies", Aliases: []string{"policy"}, NewCommand: kindCommand(newCmdPolicies, commandNameGet, commandNameDescribe)},
+		{Name: "policycrds", Aliases: []string{"policycrd"}, NewCommand: kindCommand(newCmdPolicyCRDs, commandNameGet, commandNameDescribe)},
+	} {
+		printer.RegisterKind(kind)
+	}
+}
+
+// kindCommand returns the NewCommand of a kind built into gwctl, which returns
+// the subcommand built by newCmd for the commands in cmdNames, and nil for the
+// other commands.
+func kindCommand(newCmd func(cmdutils.Factory, io.Writer, commandName) *cobra.Command, cmdNames ...commandName) func(cmdutils.Factory, io.Writer, string) *cobra.Command {
+	return func(f cmdutils.Factory, out io.Writer, name string) *cobra.Command {
+		if !slices.Contains(cmdNames, commandName(name)) {
+			return nil
+		}
+		return newCmd(f, out, commandName(name))
+	}
+}
+
+// newCmdReferencedResourcesFor returns a function building the subcommand
+// which describes what references the resources of the kind with the name.
+func newCmdReferencedResourcesFor(name string) func(cmdutils.Factory, io.Writer, commandName) *cobra.Command {
+	return func(f cmdutils.Factory, out io.Writer, cmdName commandName) *cobra.Command {
+		return newCmdReferencedResources(f, out, cmdName, name)
+	}
+}
+
+// newCmdForKind returns the subcommand of the get or describe command for the
+// kind, or nil if the command does not support the kind.
+func newCmdForKind(f cmdutils.Factory, out io.Writer, cmdName commandName, kind printer.Kind) *cobra.Command {
+	if kind.NewCommand != nil {
+		return kind.NewCommand(f, out, string(cmdName))
+	}
+	if !kind.Printable() || (cmdName == commandNameDescribe && kind.PrintDescribe == nil) {
+		return nil
+	}
+	return newCmdPrintableKind(f, out, cmdName, kind)
+}
+
+// newCmdPrintableKind returns the subcommand for a kind which discovers and
+// prints its resources itself, like a kind registered for the CRDs of a
+// vendor.
+func newCmdPrintableKind(f cmdutils.Factory, out io.Writer, cmdName commandName, kind printer.Kind) *cobra.Command {
+	o := &getOrDescribeOptions{out: out, cmdName: cmdName}
+	cmd := &cobra.Command{
+		Use:     kind.Name,
+		Aliases: kind.Aliases,
+		Short:   fmt.Sprintf("Display one or more %v", kind.Name),
+		Args:    cobra.RangeArgs(0, 1),
+		Run: func(_ *cobra.Command, args []string) {
+			o.parse(args)
+			runGetOrDescribePrintableKind(f, o, kind)
+		},
+	}
+	addNamespacesFlag(&o.namespaceFlag, cmd)
+	addAllNamespacesFlag(&o.allNamespacesFlag, cmd)
+	addLabelSelectorFlag(&o.labelSelectorFlag, cmd)
+	if cmdName == commandNameGet {
+		addOutputFormatFlag(&o.outputFlag, cmd)
+		addIgnoreNotFoundFlag(&o.ignoreNotFoundFlag, cmd)
+	} else {
+		addDescribeOutputFormatFlag(&o.outputFlag, cmd)
+	}
+	return cmd
+}
+
+func runGetOrDescribePrintableKind(f cmdutils.Factory, o *getOrDescribeOptions, kind printer.Kind) {
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+
+	// Kinds discover the resources of a single namespace, so they are
+	// discovered once for each of several namespaces.
+	namespaces := o.namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{o.namespace}
+	}
+	var objs []client.Object
+	for _, namespace := range namespaces {
+		filter := resourcediscovery.Filter{Namespace: namespace, Name: o.resourceName, Labels: o.labelSelector}
+		var namespaceObjs []client.Object
+		namespaceObjs, err = kind.Discover(context.Background(), k8sClients, filter)
+		if err != nil {
+			break
+		}
+		objs = append(objs, namespaceObjs...)
+	}
+	if err == nil && o.resourceName != "" && len(objs) == 0 {
+		err = apierrors.NewNotFound(schema.GroupResource{Group: kind.GroupKind.Group, Resource: kind.Name}, o.resourceName)
+	}
+	o.handleNotFound(err)
+	handleErrOrExitWithMsg(err, fmt.Sprintf("failed to discover %v", kind.Name))
+
+	realClock := clock.RealClock{}
+	if o.cmdName == commandNameGet {
+		printer.PrintKind(o.out, realClock, kind, objs, o.outputFormat)
+	} else {
+		printer.PrintKindDescribe(o.out, realClock, kind, objs, o.outputFormat)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+type fakeFactory struct {
+	k8sClients *common.K8sClients
+	warnings   common.Warnings
+}
+
+func (f *fakeFactory) K8sClients() (*common.K8sClients, error) { return f.k8sClients, nil }
+
+func (f *fakeFactory) PolicyManager() (*policymanager.PolicyManager, error) {
+	policyManager := policymanager.New(f.k8sClients.DC)
+	if err := policyManager.Init(context.Background()); err != nil {
+		return nil, err
+	}
+	return policyManager, nil
+}
+
+func (f *fakeFactory) Progress() common.Progress { return common.NoProgress{} }
+
+func (f *fakeFactory) Warnings() *common.Warnings { return &f.warnings }
+
+func (f *fakeFactory) ForContext(string) cmdutils.Factory { return f }
+
+func TestNewSubCommand_RegisteredKind(t *testing.T) {
+	printer.RegisterKind(printer.Kind{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Name:      "configmaps",
+		Aliases:   []string{"configmap", "cm"},
+		Discover: func(ctx context.Context, k8sClients *common.K8sClients, filter resourcediscovery.Filter) ([]client.Object, error) {
+			configMapList := &corev1.ConfigMapList{}
+			if err := k8sClients.Client.List(ctx, configMapList, client.InNamespace(filter.Namespace)); err != nil {
+				return nil, err
+			}
+			var result []client.Object
+			for i := range configMapList.Items {
+				if filter.Name == "" || configMapList.Items[i].Name == filter.Name {
+					result = append(result, &configMapList.Items[i])
+				}
+			}
+			return result, nil
+		},
+		PrintTable: func(w io.Writer, _ clock.PassiveClock, objs []client.Object, _ bool) {
+			fmt.Fprintln(w, "NAMESPACE NAME")
+			for _, obj := range objs {
+				fmt.Fprintf(w, "%v %v\n", obj.GetNamespace(), obj.GetName())
+			}
+		},
+	})
+	t.Cleanup(func() { printer.UnregisterKind("configmaps") })
+
+	objects := []runtime.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config-b", Namespace: "default"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config-a", Namespace: "default"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config-c", Namespace: "other"}},
+	}
+	f := &fakeFactory{k8sClients: common.MustClientsForTest(t, objects...)}
+
+	testcases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "list in namespace by alias",
+			args: []string{"cm"},
+			want: "NAMESPACE NAME\ndefault config-a\ndefault config-b\n",
+		},
+		{
+			name: "list in all namespaces",
+			args: []string{"configmaps", "-A"},
+			want: "NAMESPACE NAME\ndefault config-a\ndefault config-b\nother config-c\n",
+		},
+		{
+			name: "get by name",
+			args: []string{"configmap", "config-c", "-n", "other", "-o", "name"},
+			want: "configmap/config-c\n",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			getCmd := NewSubCommand(f, out, commandNameGet)
+			getCmd.SetArgs(tc.args)
+			if err := getCmd.Execute(); err != nil {
+				t.Fatalf("Execute() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want +got):\n%v", diff)
+			}
+		})
+	}
+
+	// The kind cannot be described without PrintDescribe.
+	describeCmd := NewSubCommand(f, io.Discard, commandNameDescribe)
+	for _, subCmd := range describeCmd.Commands() {
+		if subCmd.Name() == "configmaps" {
+			t.Errorf("describe has a subcommand for a kind without PrintDescribe")
+		}
+	}
+}
+
+func TestNewSubCommand_BuiltInKinds(t *testing.T) {
+	testcases := []struct {
+		cmdName commandName
+		want    []string
+	}{
+		{
+			cmdName: commandNameGet,
+			want:    []string{"backends", "certificates", "gatewayclasses", "gateways", "httproutes", "listeners", "namespaces", "policies", "policycrds"},
+		},
+		{
+			cmdName: commandNameDescribe,
+			want:    []string{"backends", "gatewayclasses", "gateways", "httproutes", "namespaces", "policies", "policycrds", "secrets", "services"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(string(tc.cmdName), func(t *testing.T) {
+			var got []string
+			for _, subCmd := range NewSubCommand(nil, io.Discard, tc.cmdName).Commands() {
+				got = append(got, subCmd.Name())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected subcommands (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
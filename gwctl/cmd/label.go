@@ -0,0 +1,231 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// metadataField is the field of the metadata changed by the label and annotate
+// commands.
+type metadataField string
+
+const (
+	metadataFieldLabels      metadataField = "labels"
+	metadataFieldAnnotations metadataField = "annotations"
+)
+
+type metadataChangeOptions struct {
+	field         metadataField
+	namespaceFlag string
+	overwriteFlag bool
+	yesFlag       bool
+}
+
+func NewLabelCommand(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	o := &metadataChangeOptions{field: metadataFieldLabels}
+	cmd := &cobra.Command{
+		Use:   "label TYPE NAME KEY=VALUE... [KEY-]...",
+		Short: "Update the labels of a Namespace or Gateway API resource",
+		Long: `Update the labels of a Namespace or Gateway API resource, like kubectl label.
+
+Before changing the labels of a Namespace, gwctl evaluates which listeners the
+HTTPRoutes, GRPCRoutes, TCPRoutes, TLSRoutes and UDPRoutes in the Namespace
+would start or stop attaching to, since listeners select the Namespaces they
+allow routes from by their labels. If any attachment changes, a summary is
+printed and the labels are only changed with --yes. Policies attach to their
+targets by name, so they are not affected by labels.`,
+		Example: `  # Allow the routes of team-a on listeners selecting internal Namespaces.
+  gwctl label namespaces team-a tier=internal
+
+  # Remove the tier label from the team-a Namespace.
+  gwctl label namespaces team-a tier-`,
+		Args: cobra.MinimumNArgs(3),
+		Run: func(_ *cobra.Command, args []string) {
+			runMetadataChange(f, out, o, args)
+		},
+	}
+	addMetadataChangeFlags(o, cmd)
+	cmd.Flags().BoolVar(&o.yesFlag, "yes", false, "If true, change the labels even if the routes attaching to listeners would change as a result.")
+	return cmd
+}
+
+func NewAnnotateCommand(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	o := &metadataChangeOptions{field: metadataFieldAnnotations}
+	cmd := &cobra.Command{
+		Use:   "annotate TYPE NAME KEY=VALUE... [KEY-]...",
+		Short: "Update the annotations of a Namespace or Gateway API resource",
+		Long: `Update the annotations of a Namespace or Gateway API resource, like kubectl
+annotate.`,
+		Example: `  # Annotate the Gateway foo-gateway with its owner.
+  gwctl annotate gateways foo-gateway owner=team-a`,
+		Args: cobra.MinimumNArgs(3),
+		Run: func(_ *cobra.Command, args []string) {
+			runMetadataChange(f, out, o, args)
+		},
+	}
+	addMetadataChangeFlags(o, cmd)
+	return cmd
+}
+
+func addMetadataChangeFlags(o *metadataChangeOptions, cmd *cobra.Command) {
+	addNamespaceFlag(&o.namespaceFlag, cmd)
+	cmd.Flags().BoolVar(&o.overwriteFlag, "overwrite", false, fmt.Sprintf("If true, allow existing %v to be overwritten.", o.field))
+}
+
+func runMetadataChange(f cmdutils.Factory, out io.Writer, o *metadataChangeOptions, args []string) {
+	ctx := context.Background()
+	resourceType, name := args[0], args[1]
+	changes, err := parseMetadataChanges(o.field, args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+	mapper := shortcutRESTMapper(k8sClients)
+	if rt, ok := lookupResourceType(resourceType); ok && !rt.GroupKind.Empty() {
+		resourceType = rt.Name
+	}
+	gvr, err := mapper.ResourceFor(schema.GroupVersionResource{Resource: strings.ToLower(resourceType)})
+	handleErrOrExitWithMsg(err, fmt.Sprintf("failed to resolve the resource type %q", resourceType))
+	isNamespace := gvr.GroupResource() == schema.GroupResource{Resource: "namespaces"}
+	if gvr.Group != gatewayv1.GroupName && !isNamespace {
+		fmt.Fprintf(os.Stderr, "unsupported resource type %q: only Namespaces and Gateway API resources are supported\n", resourceType)
+		os.Exit(1)
+	}
+	gvk, err := mapper.KindFor(gvr)
+	handleErrOrExitWithMsg(err, "")
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	handleErrOrExitWithMsg(err, "")
+
+	resourceInterface := k8sClients.DC.Resource(gvr).Namespace(o.namespaceFlag)
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		resourceInterface = k8sClients.DC.Resource(gvr)
+	}
+	obj, err := resourceInterface.Get(ctx, name, metav1.GetOptions{})
+	handleErrOrExitWithMsg(err, "")
+
+	current := obj.GetLabels()
+	if o.field == metadataFieldAnnotations {
+		current = obj.GetAnnotations()
+	}
+	updated, err := applyMetadataChanges(o.field, current, changes, o.overwriteFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if o.field == metadataFieldLabels && isNamespace {
+		policyManager, err := f.PolicyManager()
+		handleErrOrExitWithMsg(err, "")
+		discoverer := newDiscoverer(f, k8sClients, policyManager)
+		attachmentChanges, err := discoverer.NamespaceLabelChangeImpact(name, updated)
+		handleErrOrExitWithMsg(err, "failed to evaluate the impact of the change")
+		if len(attachmentChanges) != 0 {
+			fmt.Fprintf(os.Stderr, "Warning: changing the labels of Namespace %v changes which listeners its routes attach to:\n", name)
+			for _, attachmentChange := range attachmentChanges {
+				fmt.Fprintf(os.Stderr, "  - %v\n", attachmentChange)
+			}
+			if !o.yesFlag {
+				fmt.Fprintf(os.Stderr, "Re-run with --yes to change the labels anyway.\n")
+				os.Exit(1)
+			}
+		}
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{string(o.field): changes},
+	})
+	handleErrOrExitWithMsg(err, "")
+	_, err = resourceInterface.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	handleErrOrExitWithMsg(err, fmt.Sprintf("failed to update the %v", o.field))
+
+	verb := "labeled"
+	if o.field == metadataFieldAnnotations {
+		verb = "annotated"
+	}
+	fmt.Fprintf(out, "%v/%v %v\n", gvr.GroupResource(), name, verb)
+}
+
+// parseMetadataChanges parses arguments of the form KEY=VALUE, which set the
+// key to the value, and KEY-, which remove the key. Removed keys map to nil, as
+// required by JSON merge patches.
+func parseMetadataChanges(field metadataField, args []string) (map[string]*string, error) {
+	result := make(map[string]*string)
+	for _, arg := range args {
+		if key, ok := strings.CutSuffix(arg, "-"); ok && !strings.Contains(arg, "=") {
+			if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+				return nil, fmt.Errorf("invalid key %q: %v", key, strings.Join(errs, "; "))
+			}
+			result[key] = nil
+			continue
+		}
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid argument %q: must be KEY=VALUE or KEY-", arg)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid key %q: %v", key, strings.Join(errs, "; "))
+		}
+		if field == metadataFieldLabels {
+			if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+				return nil, fmt.Errorf("invalid value %q for label %q: %v", value, key, strings.Join(errs, "; "))
+			}
+		}
+		result[key] = &value
+	}
+	return result, nil
+}
+
+// applyMetadataChanges returns the labels or annotations resulting from the
+// changes. Keys which already have a different value are only changed if
+// overwrite is true.
+func applyMetadataChanges(field metadataField, current map[string]string, changes map[string]*string, overwrite bool) (map[string]string, error) {
+	result := maps.Clone(current)
+	if result == nil {
+		result = make(map[string]string)
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		if existing, ok := result[key]; ok && existing != *value && !overwrite {
+			return nil, fmt.Errorf("%v %q already has the value %q, and --overwrite is false", strings.TrimSuffix(string(field), "s"), key, existing)
+		}
+		result[key] = *value
+	}
+	return result, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/query"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+type queryOptions struct {
+	outputFlag string
+}
+
+func NewQueryCommand(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	o := &queryOptions{}
+	cmd := &cobra.Command{
+		Use:   "query QUERY",
+		Short: "Traverse the resource graph with a pipeline of stages",
+		Long: `Traverse the resource graph with a pipeline of stages, like:
+
+  gwctl query 'gateways | attachedRoutes | backends | where(kind=="Service") | name'
+
+A query starts with all the resources of a kind: gatewayclasses, gateways,
+httproutes, routes, backends, namespaces or policies. Each following stage,
+separated by "|", is one of:
+
+  attachedRoutes  the routes attached to each Gateway
+  parents         the Gateways of each route, the GatewayClass of each Gateway,
+                  the routes referencing each Backend and the target of each
+                  policy
+  backends        the Backends referenced by each route
+  policies        the policies directly attached to each resource
+  where(EXPR)     the resources matching EXPR, which compares fields with ==
+                  and != and combines comparisons with &&, || and !. Fields are
+                  kind, group, namespace, name, labels.KEY, labels["KEY"],
+                  annotations.KEY or any path in the resource, like
+                  spec.gatewayClassName
+  name            a final projection printing the namespaced name of each
+                  resource
+  json            a final projection printing each resource as JSON
+
+One line is printed per resource, or a JSON array with -o json.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			runQuery(f, out, o, args[0])
+		},
+	}
+	cmd.Flags().StringVarP(&o.outputFlag, "output", "o", "", `Output format. Must be json, in which case the results are printed as a JSON array`)
+	return cmd
+}
+
+func runQuery(f cmdutils.Factory, out io.Writer, o *queryOptions, queryString string) {
+	outputFormat, err := cmdutils.ValidateAndReturnOutputFormat(o.outputFlag)
+	if err != nil || (outputFormat != cmdutils.OutputFormatTable && outputFormat != cmdutils.OutputFormatJSON) {
+		fmt.Fprintf(os.Stderr, "output format %q is not supported by query; must be json\n", o.outputFlag)
+		os.Exit(1)
+	}
+	q, err := query.Parse(queryString)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid query: %v\n", err)
+		os.Exit(1)
+	}
+
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+	policyManager, err := f.PolicyManager()
+	handleErrOrExitWithMsg(err, "")
+
+	progress := f.Progress()
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
+	discoverer.Progress = progress
+	resourceModel, err := discoverer.DiscoverAllResources(resourcediscovery.Filter{Labels: labels.Everything()})
+	progress.Done()
+	handleErrOrExitWithMsg(err, "failed to discover resources")
+
+	queryPrinter := &printer.QueryPrinter{Writer: out}
+	queryPrinter.Print(q.Run(resourceModel), q.Projection(), outputFormat)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func NewQueryAddressCommand(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-address ADDRESS",
+		Short: "Show the data path served through an address of the Gateways",
+		Long: `Show the data path served through an address of the Gateways, which is a
+reverse lookup from an IP address or hostname, like one found in a log line:
+
+  gwctl query-address 203.0.113.7
+
+Each Gateway whose status lists the address is printed, along with its
+listeners and the HTTPRoutes served through each listener with the hostnames
+they serve there. IP addresses must match exactly, while addresses of type
+Hostname are matched case-insensitively.
+
+Whether an HTTPRoute is served through a listener is evaluated from the spec of
+the resources: its parentRefs must select the listener, the listener must allow
+it, and their hostnames must intersect.
+
+Exits with code 3 if no Gateway has the address.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			runQueryAddress(f, out, args[0])
+		},
+	}
+	return cmd
+}
+
+func runQueryAddress(f cmdutils.Factory, out io.Writer, address string) {
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+	policyManager, err := f.PolicyManager()
+	handleErrOrExitWithMsg(err, "")
+
+	progress := f.Progress()
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
+	discoverer.Progress = progress
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{Labels: labels.Everything()})
+	progress.Done()
+	handleErrOrExitWithMsg(err, "failed to discover Gateway resources")
+
+	lookups := resourcediscovery.LookupAddress(resourceModel, address)
+	if len(lookups) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no Gateway has the address %q\n", address)
+		os.Exit(exitCodeNotFound)
+	}
+	addressLookupPrinter := &printer.AddressLookupPrinter{Writer: out}
+	addressLookupPrinter.Print(lookups)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+)
+
+// lookupResourceType returns the kind registered with the printer package
+// with the name or alias, ignoring case.
+func lookupResourceType(s string) (printer.Kind, bool) {
+	return printer.LookupKind(s)
+}
+
+// mustLookupResourceType returns the resource type with the name. It panics if
+// the resource type is not registered, which is a programming error.
+func mustLookupResourceType(name string) printer.Kind {
+	rt, ok := lookupResourceType(name)
+	if !ok {
+		panic(fmt.Sprintf("resource type %q is not registered", name))
+	}
+	return rt
+}
+
+// resourceTypeAliases returns the aliases of the registered resource type,
+// for use as the aliases of its subcommands.
+func resourceTypeAliases(name string) []string {
+	return slices.Clone(mustLookupResourceType(name).Aliases)
+}
+
+// validResourceTypeArgs returns an error if the argument of a command with a
+// subcommand per resource type, like get, is not a resource type of one of its
+// subcommands. Arguments naming a subcommand never reach this, since cobra
+// runs the subcommand instead.
+func validResourceTypeArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	var names []string
+	for _, subCmd := range cmd.Commands() {
+		names = append(names, subCmd.Name())
+	}
+	return fmt.Errorf("unknown resource type %q; must be one of (%v)", args[0], strings.Join(names, ", "))
+}
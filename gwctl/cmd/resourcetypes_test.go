@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResourceTypeAliases(t *testing.T) {
+	rootCmd := newRootCmd(nil)
+	for _, cmdName := range []commandName{commandNameGet, commandNameDescribe} {
+		parentCmd, _, err := rootCmd.Find([]string{string(cmdName)})
+		if err != nil {
+			t.Fatalf("Find(%v) returned an unexpected error: %v", cmdName, err)
+		}
+		for _, subCmd := range parentCmd.Commands() {
+			rt, ok := lookupResourceType(subCmd.Name())
+			if !ok {
+				t.Errorf("%v %v is not a registered resource type", cmdName, subCmd.Name())
+				continue
+			}
+			for _, spelling := range append([]string{rt.Name}, rt.Aliases...) {
+				for _, arg := range []string{spelling, strings.ToUpper(spelling), strings.ToUpper(spelling[:1]) + spelling[1:]} {
+					args := expandShortNames(rootCmd, []string{string(cmdName), arg, "foo"})
+					got, gotArgs, err := rootCmd.Find(args)
+					if err != nil {
+						t.Errorf("Find(%v) returned an unexpected error: %v", args, err)
+						continue
+					}
+					if got != subCmd || len(gotArgs) != 1 || gotArgs[0] != "foo" {
+						t.Errorf("%v %v resolved to %q with args %v, want %q with args [foo]", cmdName, arg, got.CommandPath(), gotArgs, subCmd.CommandPath())
+					}
+				}
+			}
+		}
+	}
+
+	// Resource types without subcommands are still resolved, like by label.
+	if rt, ok := lookupResourceType("RefGrant"); !ok || rt.Name != "referencegrants" {
+		t.Errorf("lookupResourceType(RefGrant) = (%+v, %v), want referencegrants", rt, ok)
+	}
+}
+
+func TestValidResourceTypeArgs(t *testing.T) {
+	rootCmd := newRootCmd(nil)
+	getCmd, args, err := rootCmd.Find([]string{"get", "foo"})
+	if err != nil {
+		t.Fatalf("Find() returned an unexpected error: %v", err)
+	}
+	if getCmd.Name() != string(commandNameGet) {
+		t.Fatalf("Find() = %q, want get", getCmd.Name())
+	}
+
+	err = validResourceTypeArgs(getCmd, args)
+	want := `unknown resource type "foo"; must be one of (backends, certificates, gatewayclasses, gateways, httproutes, listeners, namespaces, policies, policycrds)`
+	if err == nil || err.Error() != want {
+		t.Errorf("validResourceTypeArgs() = %v, want %v", err, want)
+	}
+	if err := validResourceTypeArgs(getCmd, nil); err != nil {
+		t.Errorf("validResourceTypeArgs() returned an unexpected error without args: %v", err)
+	}
+}
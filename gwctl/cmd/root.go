@@ -19,16 +19,18 @@ package cmd
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
-	"path"
 
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
 )
 
-func newRootCmd() *cobra.Command {
+func newRootCmd(args []string) *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "gwctl",
 		Short: "gwctl is a command-line tool for exploring Gateway API resources.",
@@ -36,7 +38,11 @@ func newRootCmd() *cobra.Command {
 	}
 
 	var kubeConfigPath string
-	rootCmd.PersistentFlags().StringVar(&kubeConfigPath, "kubeconfig", "", "path to kubeconfig file (default is the KUBECONFIG environment variable and if it isn't set, falls back to $HOME/.kube/config)")
+	rootCmd.PersistentFlags().StringVar(&kubeConfigPath, "kubeconfig", "", "path to kubeconfig file (default is the KUBECONFIG environment variable, which may list multiple files to merge, and if it isn't set, falls back to $HOME/.kube/config)")
+
+	var impersonate rest.ImpersonationConfig
+	rootCmd.PersistentFlags().StringVar(&impersonate.UserName, "as", "", "Username to impersonate for the operation. User could be a regular user or a service account in a namespace. Commands which change resources print the impersonated identity on stderr.")
+	rootCmd.PersistentFlags().StringArrayVar(&impersonate.Groups, "as-group", nil, "Group to impersonate for the operation, this flag can be repeated to specify multiple groups. Requires --as.")
 
 	// Initialize flags for klog.
 	//
@@ -49,27 +55,66 @@ func newRootCmd() *cobra.Command {
 	klog.InitFlags(klogFlags)
 
 	cobra.OnInitialize(func() {
-		if kubeConfigPath == "" {
-			kubeConfigPath = os.Getenv("KUBECONFIG")
-			if kubeConfigPath == "" {
-				kubeConfigPath = path.Join(os.Getenv("HOME"), ".kube/config")
-			}
-		}
 		if err := klogFlags.Set("v", fmt.Sprintf("%v", verbosity)); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to configure verbosity for logging")
 		}
 	})
 
-	factory := utils.NewFactory(&kubeConfigPath)
+	var noProgress bool
+	rootCmd.PersistentFlags().BoolVar(&noProgress, "no-progress", false, "If present, never show the progress of slow operations. Progress is only shown on stderr when it is a terminal.")
+
+	var atResourceVersion string
+	rootCmd.PersistentFlags().StringVar(&atResourceVersion, "at-resource-version", "", "If present, list all resources at exactly this resourceVersion, so that resources of different types are consistent with each other, or to reproduce what was seen at that point. The API server only keeps recent resourceVersions. By default, resources are listed at a resourceVersion not older than that of the first list.")
+
+	var fromSnapshot string
+	rootCmd.PersistentFlags().StringVar(&fromSnapshot, "from-snapshot", "", "If present, run the command against the snapshot saved in this file by 'gwctl snapshot save' instead of against a cluster. Commands which change resources or need the cluster itself, like label and auth, cannot be run against a snapshot.")
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, _ []string) {
+		if len(impersonate.Groups) != 0 && impersonate.UserName == "" {
+			fmt.Fprintf(os.Stderr, "--as-group can only be used with --as\n")
+			os.Exit(1)
+		}
+		if fromSnapshot != "" && cmd.Annotations[annotationRequiresCluster] == "true" {
+			fmt.Fprintf(os.Stderr, "%v cannot be run with --from-snapshot since it needs a live cluster\n", cmd.CommandPath())
+			os.Exit(1)
+		}
+		if cmd.Annotations[annotationChangesResources] == "true" {
+			printImpersonationNotice(os.Stderr, impersonate)
+		}
+	}
+
+	factory := utils.NewFactory(&kubeConfigPath, &impersonate, &noProgress, &atResourceVersion, &fromSnapshot)
+
+	var strict bool
+	rootCmd.PersistentFlags().BoolVar(&strict, "strict", false, "If present, exit with code 4 if any warning was found with the resources, like references to resources which do not exist or are not permitted, or findings of analyze with a severity of Warning or Error. The warnings are listed on stderr. Intended for gating CI pipelines.")
+	rootCmd.PersistentPostRun = func(_ *cobra.Command, _ []string) {
+		if strict {
+			if code := reportWarnings(os.Stderr, factory.Warnings().List()); code != 0 {
+				os.Exit(code)
+			}
+		}
+	}
 
 	rootCmd.AddCommand(NewSubCommand(factory, os.Stdout, commandNameGet))
 	rootCmd.AddCommand(NewSubCommand(factory, os.Stdout, commandNameDescribe))
+	rootCmd.AddCommand(NewCompareCommand(factory, os.Stdout))
+	rootCmd.AddCommand(NewSummaryCommand(factory, os.Stdout))
+	rootCmd.AddCommand(NewAnalyzeCommand(factory, os.Stdout))
+	rootCmd.AddCommand(NewCheckCommand(factory, os.Stdout))
+	rootCmd.AddCommand(NewBundleCommand(factory, os.Stdout))
+	rootCmd.AddCommand(NewSnapshotCommand(factory, os.Stdout))
+	rootCmd.AddCommand(NewQueryCommand(factory, os.Stdout))
+	rootCmd.AddCommand(NewQueryAddressCommand(factory, os.Stdout))
+	rootCmd.AddCommand(NewSchemaCommand(factory, os.Stdout))
+	rootCmd.AddCommand(requiresCluster(NewAuthCommand(factory, os.Stdout)))
+	rootCmd.AddCommand(changesResources(requiresCluster(NewLabelCommand(factory, os.Stdout))))
+	rootCmd.AddCommand(changesResources(requiresCluster(NewAnnotateCommand(factory, os.Stdout))))
 
+	rootCmd.SetArgs(expandShortNames(rootCmd, args))
 	return rootCmd
 }
 
 func Execute() {
-	rootCmd := newRootCmd()
+	rootCmd := newRootCmd(os.Args[1:])
 	err := rootCmd.Execute()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to execute command: %v\n", err)
@@ -77,10 +122,75 @@ func Execute() {
 	}
 }
 
+// annotationRequiresCluster marks commands which cannot be run against a
+// snapshot, since they change resources or inspect the cluster itself.
+const annotationRequiresCluster = "gwctl.gateway.networking.k8s.io/requires-cluster"
+
+// requiresCluster marks the command and its subcommands as ones which cannot be
+// run with --from-snapshot.
+func requiresCluster(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[annotationRequiresCluster] = "true"
+	for _, subCmd := range cmd.Commands() {
+		requiresCluster(subCmd)
+	}
+	return cmd
+}
+
+// annotationChangesResources marks commands which change resources, and are
+// therefore recorded in the audit log of the API server under the identity
+// which gwctl acts as.
+const annotationChangesResources = "gwctl.gateway.networking.k8s.io/changes-resources"
+
+// changesResources marks the command as one which changes resources.
+func changesResources(cmd *cobra.Command) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[annotationChangesResources] = "true"
+	return cmd
+}
+
+// printImpersonationNotice prints the identity which is impersonated, if any,
+// so that the operator explicitly sees that changes are made as another
+// principal.
+func printImpersonationNotice(w io.Writer, impersonate rest.ImpersonationConfig) {
+	if impersonate.UserName == "" {
+		return
+	}
+	identity := fmt.Sprintf("user %q", impersonate.UserName)
+	if len(impersonate.Groups) != 0 {
+		identity += fmt.Sprintf(" in groups %q", impersonate.Groups)
+	}
+	fmt.Fprintf(w, "Notice: acting as %v. Changes are recorded in the audit log under this identity.\n", identity)
+}
+
+// exitCodeWarnings is the exit code with --strict when some warning was found.
+const exitCodeWarnings = 4
+
+// reportWarnings lists the warnings on w, and returns exitCodeWarnings if there
+// are any, or 0 otherwise.
+func reportWarnings(w io.Writer, warnings []common.Warning) int {
+	if len(warnings) == 0 {
+		return 0
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "Warning: %v\n", warning)
+	}
+	fmt.Fprintf(w, "Error: found %d warnings, which are treated as errors with --strict\n", len(warnings))
+	return exitCodeWarnings
+}
+
 func addNamespaceFlag(p *string, cmd *cobra.Command) {
 	cmd.Flags().StringVarP(p, "namespace", "n", "default", "")
 }
 
+func addNamespacesFlag(p *[]string, cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(p, "namespace", "n", []string{"default"}, "Namespaces of the requested resources. The flag may be repeated, or the namespaces separated by commas.")
+}
+
 func addAllNamespacesFlag(p *bool, cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(p, "all-namespaces", "A", false, "If present, list requested resources from all namespaces.")
 }
@@ -90,7 +200,147 @@ func addLabelSelectorFlag(p *string, cmd *cobra.Command) {
 }
 
 func addOutputFormatFlag(p *string, cmd *cobra.Command) {
-	cmd.Flags().StringVarP(p, "output", "o", "", `Output format. Must be one of (yaml, json)`)
+	cmd.Flags().StringVarP(p, "output", "o", "", `Output format. Must be one of (yaml, json, wide, name, helm-values). The name format only fetches the metadata of resources, which is much faster on large clusters. The helm-values format prints the resources as the values of a Helm chart, grouped by kind and keyed by name`)
+}
+
+func addDescribeOutputFormatFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVarP(p, "output", "o", "", `Output format. Must be yaml, in which case each resource is printed as a separate YAML document`)
+}
+
+func addSortByFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVar(p, "sort-by", "", `Sort the rows of the table output. Must be one of (name, policies). Sorting by policies lists resources with the most attached policies first`)
+}
+
+func addGroupByFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVar(p, "group-by", "", `Group the rows of the table output into sections. Must be one of (class). Grouping by class prints the Gateways of each GatewayClass under a header showing the class and its controllerName`)
+}
+
+func addLabelColumnsFlag(p *[]string, cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(p, "label-columns", nil, `Comma-separated list of label keys whose values are printed as additional columns of the table output. Keys may optionally be prefixed with 'label:'. Example: --label-columns=app,env`)
+}
+
+func addAnnotationColumnsFlag(p *[]string, cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(p, "annotation-columns", nil, `Comma-separated list of NAME=ANNOTATION pairs, each printed as an additional column of the table output named NAME, holding the value of the annotation. Overrides the annotationColumns of the configuration file ($GWCTL_CONFIG, or gwctl/config.yaml in the user configuration directory). Example: --annotation-columns=owner=example.com/owner`)
+}
+
+func addHighlightFlag(p *[]string, cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(p, "highlight", nil, `Highlight the rows of the table output for resources matching the expression, when printing to a terminal. Expressions compare the status of resources (e.g. 'status=NotAccepted' or 'STATUS=NotAccepted'), their age (e.g. 'age<10m' or 'age>7d') or their labels (e.g. 'label:env=prod'). May be repeated to highlight rows matching any of the expressions.`)
+}
+
+func addNoColorFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "no-color", false, "If present, do not use colors. Rows selected by --highlight are instead prefixed with '*', also when not printing to a terminal.")
+}
+
+func addCanonicalFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "canonical", false, "If present, print the resources in a canonical form for stable diffs, like when tracking them in git: without the fields populated by the API server, the status and the fields set to their defaults, and with sorted keys. Only supported for the json and yaml output formats.")
+}
+
+func addContextsFlag(p *[]string, cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(p, "contexts", nil, "Contexts of the kubeconfig whose clusters to list the resources of, like --contexts=ctx1,ctx2. The resources of all clusters are printed together, with a CLUSTER column for the table output formats and the gwctl.gateway.networking.k8s.io/cluster annotation for json and yaml.")
+}
+
+func addOutputDirFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVar(p, "output-dir", "", "If present, write the describe view of each resource to its own file in the directory, named <namespace>_<name>.txt, or .yaml with -o yaml, instead of printing them. Existing files are replaced.")
+}
+
+func addOutputDirConcurrencyFlag(p *int, cmd *cobra.Command) {
+	cmd.Flags().IntVar(p, "concurrency", 4, "Maximum number of resources described at the same time with --output-dir.")
+}
+
+func addWhereFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVar(p, "where", "", "CEL expression which resources must match, e.g. 'has(spec.hostnames) && spec.hostnames.size() > 1'. The expression can refer to apiVersion, kind, metadata, spec and status, or to the whole resource as object. Resources for which the expression fails to evaluate, like when it refers to a field they do not set, do not match and are reported in a warning.")
+}
+
+func addIgnoreNotFoundFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "ignore-not-found", false, "If the requested resource does not exist, print nothing and exit successfully instead of exiting with code 3.")
+}
+
+func addWithUsageFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "with-usage", false, "If present, add a usage field to the List printed with -o json or yaml, which counts for each GatewayClass its Gateways, their listeners, the routes attached to them and the distinct Services referenced by the HTTPRoutes. The GatewayClasses themselves are printed unchanged.")
+}
+
+func addValidateHostnamesFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "validate-hostnames", false, "If present, report hostnames which are not valid RFC 1123 DNS names.")
+}
+
+func addValidateRegexesFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "validate-regexes", false, "If present, report regular expressions used by path, header and query parameter matches which do not compile.")
+}
+
+func addUsesRegexFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "uses-regex", false, "If present, only show HTTPRoutes which use a regular expression in some path, header or query parameter match.")
+}
+
+func addStaleFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "stale", false, "If present, only list resources whose status has not observed the latest generation of their spec, meaning the controller has not reconciled the latest changes.")
+}
+
+func addEffectivePolicyKindFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVar(p, "effective-policy-kind", "", `If present, only show the effective policies of this kind, either as a Kind or as Kind.group (e.g. HealthCheckPolicy or HealthCheckPolicy.foo.com)`)
+}
+
+func addShowDriftFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "show-drift", false, "If present, show a diff of the live spec from the kubectl.kubernetes.io/last-applied-configuration annotation, to spot changes made outside of kubectl apply.")
+}
+
+func addSortHostnamesFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "sort-hostnames", false, "If present, sort the hostnames of each HTTPRoute alphabetically instead of showing them in the order of the spec.")
+}
+
+func addShowFieldOwnersFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "show-field-owners", false, "If present, show which field managers own each top-level field of the spec, from the managedFields of the resource, to find controllers which keep overwriting each other's changes.")
+}
+
+func addEffectiveSubtreeFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "effective-subtree", false, "If present, show the effective policies of each route attached to the Gateway and of each Backend of those routes, to see how the inheritance of policies resolves beneath the Gateway.")
+}
+
+func addOnlyIneffectiveFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "only-ineffective", false, "If present, only list the policies none of whose fields take effect on any resource, because they are completely shadowed by policies of higher precedence. Such policies are candidates for cleanup.")
+}
+
+func addShowPrecedenceFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "show-precedence", false, "If present, show the matches of the attached HTTPRoutes for each hostname in the order of precedence in which requests are matched, to find which HTTPRoute receives requests matched by several of them.")
+}
+
+func addEventLimitFlag(p *int, cmd *cobra.Command) {
+	cmd.Flags().IntVar(p, "event-limit", 10, "Maximum number of the most recent events to show for each resource. 0 shows all events.")
+}
+
+func addMaxListItemsFlag(p *int, cmd *cobra.Command) {
+	cmd.Flags().IntVar(p, "max-list-items", 50, "Maximum number of items to show in each repeating section of the describe output, like the attached routes, policies and events. 0 shows all items.")
+}
+
+func addExpiringWithinFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVar(p, "expiring-within", "", `If present, only list certificates which expire within this duration, e.g. 30d or 12h. Certificates which could not be read are always listed`)
+}
+
+func addReferencedByFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "referenced-by", false, "If present, show the Gateway API resources which reference this resource, and whether each reference is permitted by a ReferenceGrant.")
+}
+
+func addParentFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVar(p, "parent", "", `If present, restrict the parentRefs, status, attachment analysis and effective policies of HTTPRoutes to the specified parent. Format: gateway[/NAMESPACE]/NAME. Not specifying a NAMESPACE assumes the 'default' value. Example: gateway/infra/edge-gw`)
+}
+
+func addControllerFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVar(p, "controller", "", `If present, only show resources managed by the controller with this controllerName, as resolved through the GatewayClasses of Gateways. HTTPRoutes and Backends are shown if they are attached to some Gateway of the controller. Example: --controller=example.net/gateway-controller`)
+}
+
+func addNameRegexFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVar(p, "name-regex", "", `If present, only show resources whose whole name matches this regular expression, in the RE2 syntax. Example: --name-regex='.*-canary'`)
+}
+
+func addAddressFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVar(p, "address", "", `If present, only show Gateways whose status lists this address. IP addresses must match exactly, while addresses of type Hostname are matched case-insensitively. Example: --address=203.0.113.7`)
+}
+
+func addConditionsFlag(p *bool, cmd *cobra.Command) {
+	cmd.Flags().BoolVar(p, "conditions", false, "If present, print one row for every status condition of every parent of the HTTPRoutes, instead of one row per HTTPRoute. Only supported for the table output format.")
+}
+
+func addFilterTypeFlag(p *string, cmd *cobra.Command) {
+	cmd.Flags().StringVar(p, "filter-type", "", `If present, only show HTTPRoutes which use a filter of this type in some rule. Must be one of (RequestHeaderModifier, ResponseHeaderModifier, RequestMirror, RequestRedirect, URLRewrite, ExtensionRef). Example: --filter-type=RequestRedirect`)
 }
 
 func addForFlag(p *string, cmd *cobra.Command) {
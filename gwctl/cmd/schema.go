@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/schema"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func NewSchemaCommand(_ cmdutils.Factory, out io.Writer) *cobra.Command {
+	var outputNames []string
+	for _, output := range schema.Outputs {
+		outputNames = append(outputNames, "  "+output.Name)
+	}
+	cmd := &cobra.Command{
+		Use:   "schema [OUTPUT]",
+		Short: "Print the JSON schema of the enriched output of gwctl",
+		Long: `Print the JSON schema (draft 2020-12) of the enriched output of gwctl, which
+gwctl computes rather than fetches from the cluster, like the listeners of
+Gateways or the results of a query.
+
+Without OUTPUT, a single schema defining every output under $defs is printed.
+With OUTPUT, only the schema of that output is printed. OUTPUT is one of:
+
+` + strings.Join(outputNames, "\n"),
+		Args: cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			runSchema(out, args)
+		},
+	}
+	return cmd
+}
+
+func runSchema(out io.Writer, args []string) {
+	var s *schema.Schema
+	if len(args) == 0 {
+		s = schema.ForOutputs(schema.Outputs)
+	} else {
+		output, err := schema.LookupOutput(args[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		s = schema.For(output.Type)
+		s.Description = output.Description
+	}
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	handleErrOrExitWithMsg(err, "failed to marshal the schema")
+	fmt.Fprintln(out, string(b))
+}
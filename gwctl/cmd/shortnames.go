@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/klog/v2"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// expandShortNames returns the args with the resource type following get or
+// describe replaced by the name of its subcommand, if the resource type is not
+// already the name or an alias of a subcommand, but is a registered name of
+// the resource type in another case. The cluster is never contacted; names
+// which only the cluster knows are resolved by runClusterResourceType when the
+// command runs.
+func expandShortNames(rootCmd *cobra.Command, args []string) []string {
+	cmd, _, err := rootCmd.Find(args)
+	if err != nil || (cmd.Name() != string(commandNameGet) && cmd.Name() != string(commandNameDescribe)) {
+		return args
+	}
+	// The resource type must directly follow the get or describe command.
+	i := slices.Index(args, cmd.Name()) + 1
+	if i == 0 || i >= len(args) || strings.HasPrefix(args[i], "-") {
+		return args
+	}
+	// Names registered for the resource types of gwctl are resolved ignoring
+	// case.
+	if rt, ok := lookupResourceType(args[i]); ok {
+		if subCmd, _, err := cmd.Find([]string{rt.Name}); err == nil && subCmd != cmd {
+			result := slices.Clone(args)
+			result[i] = subCmd.Name()
+			return result
+		}
+	}
+	return args
+}
+
+// runClusterResourceType runs the subcommand of the get or describe cmd for
+// the resource type args[0], which is not known to gwctl but may be a name
+// which the cluster knows for the resource, like a short name declared by its
+// CRD. This lets users type the short names of resources even when gwctl does
+// not know them. The flags within args are only parsed once the subcommand is
+// known, since they are defined by the subcommand.
+func runClusterResourceType(f cmdutils.Factory, cmd *cobra.Command, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return cmd.Help()
+	}
+
+	// The kubeconfig may be specified anywhere within the args, and must be
+	// known before contacting the cluster.
+	persistentFlags := pflag.NewFlagSet(cmd.Root().Name(), pflag.ContinueOnError)
+	persistentFlags.AddFlagSet(cmd.Root().PersistentFlags())
+	persistentFlags.ParseErrorsWhitelist.UnknownFlags = true
+	persistentFlags.Usage = func() {}
+	if err := persistentFlags.Parse(args); err != nil {
+		return err
+	}
+
+	k8sClients, err := f.K8sClients()
+	if err != nil {
+		klog.V(1).ErrorS(err, "Failed to create clients to resolve the resource type", "resourceType", args[0])
+		return validResourceTypeArgs(cmd, args)
+	}
+	subCmd, ok := subCommandForResource(cmd, shortcutRESTMapper(k8sClients), args[0])
+	if !ok {
+		return validResourceTypeArgs(cmd, args)
+	}
+
+	subCmd.InitDefaultHelpFlag()
+	if err := subCmd.ParseFlags(args[1:]); err != nil {
+		return err
+	}
+	if help, _ := subCmd.Flags().GetBool("help"); help {
+		return subCmd.Help()
+	}
+	subArgs := subCmd.Flags().Args()
+	if err := subCmd.ValidateArgs(subArgs); err != nil {
+		return err
+	}
+	subCmd.Run(subCmd, subArgs)
+	return nil
+}
+
+// subCommandForResource returns the subcommand of cmd for the resource which
+// the mapper resolves resourceType to.
+func subCommandForResource(cmd *cobra.Command, mapper meta.RESTMapper, resourceType string) (*cobra.Command, bool) {
+	gvr, err := mapper.ResourceFor(schema.GroupVersionResource{Resource: strings.ToLower(resourceType)})
+	if err != nil {
+		klog.V(1).ErrorS(err, "Failed to resolve the resource type", "resourceType", resourceType)
+		return nil, false
+	}
+	// Only Gateway API resources and core resources like Namespaces and Services
+	// have subcommands. Resources of other groups may share the same name, like
+	// the gateways of some service meshes.
+	if gvr.Group != gatewayv1.GroupName && gvr.Group != "" {
+		return nil, false
+	}
+	for _, subCmd := range cmd.Commands() {
+		if subCmd.Name() == gvr.Resource {
+			klog.V(3).InfoS("Resolved the resource type", "resourceType", resourceType, "resource", gvr.GroupResource())
+			return subCmd, true
+		}
+	}
+	return nil, false
+}
+
+// shortcutRESTMapper returns a RESTMapper which resolves resources by any name
+// which the cluster knows for them, including their short names.
+func shortcutRESTMapper(k8sClients *common.K8sClients) meta.RESTMapper {
+	return restmapper.NewShortcutExpander(
+		restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(k8sClients.DiscoveryClient)),
+		k8sClients.DiscoveryClient,
+		func(warning string) { klog.V(1).Info(warning) },
+	)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// fakeShortcutMapper resolves resources by the names in resources.
+type fakeShortcutMapper struct {
+	meta.RESTMapper
+	resources map[string]schema.GroupVersionResource
+}
+
+func (m fakeShortcutMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	gvr, ok := m.resources[input.Resource]
+	if !ok {
+		return schema.GroupVersionResource{}, fmt.Errorf("the server doesn't have a resource type %q", input.Resource)
+	}
+	return gvr, nil
+}
+
+func TestSubCommandForResource(t *testing.T) {
+	mapper := fakeShortcutMapper{resources: map[string]schema.GroupVersionResource{
+		"gtw": {Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"},
+		"ns":  {Version: "v1", Resource: "namespaces"},
+		"gw":  {Group: "networking.istio.io", Version: "v1", Resource: "gateways"},
+		"cm":  {Version: "v1", Resource: "configmaps"},
+	}}
+	getCmd := NewSubCommand(nil, io.Discard, commandNameGet)
+
+	testcases := []struct {
+		resourceType string
+		want         string
+	}{
+		{resourceType: "gtw", want: "gateways"},
+		{resourceType: "GTW", want: "gateways"},
+		{resourceType: "ns", want: "namespaces"},
+		// Resources of other groups do not resolve to subcommands of the same
+		// name.
+		{resourceType: "gw"},
+		// Resources without subcommands.
+		{resourceType: "cm"},
+		// Resources unknown to the cluster.
+		{resourceType: "foo"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.resourceType, func(t *testing.T) {
+			got, ok := subCommandForResource(getCmd, mapper, tc.resourceType)
+			gotName := ""
+			if ok {
+				gotName = got.Name()
+			}
+			if gotName != tc.want {
+				t.Errorf("subCommandForResource(%v) = %q, want %q", tc.resourceType, gotName, tc.want)
+			}
+		})
+	}
+}
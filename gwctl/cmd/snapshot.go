@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func NewSnapshotCommand(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save the resources of the cluster to run commands against them later",
+	}
+	cmd.AddCommand(newCmdSnapshotSave(f, out))
+	return cmd
+}
+
+func newCmdSnapshotSave(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "save FILENAME",
+		Short: "Save the resources of the cluster to a file, to run read-only commands against it with --from-snapshot",
+		Long: `Save the resources of the cluster to a compressed file, to run read-only
+commands like get, describe, analyze and summary against it with
+--from-snapshot, without access to the cluster.
+
+The snapshot contains the resources of every Gateway API and Policy kind
+including their status, the CRDs of their kinds, the Namespaces, Services and
+EndpointSlices, and the events of all of them. Secrets are not saved, so the
+certificates of listeners cannot be inspected from a snapshot. Use "-" as the
+filename to write the snapshot to stdout.`,
+		Example: `  gwctl snapshot save cluster.gwsnap
+  gwctl describe httproutes --from-snapshot cluster.gwsnap`,
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			runSnapshotSave(f, out, args[0])
+		},
+	}
+	return cmd
+}
+
+func runSnapshotSave(f cmdutils.Factory, out io.Writer, filename string) {
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+	policyManager, err := f.PolicyManager()
+	handleErrOrExitWithMsg(err, "")
+
+	progress := f.Progress()
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
+	discoverer.Progress = progress
+	snapshot, err := discoverer.DiscoverClusterSnapshot(clock.RealClock{})
+	progress.Done()
+	handleErrOrExitWithMsg(err, "failed to collect resources for the snapshot")
+
+	if filename == "-" {
+		handleErrOrExitWithMsg(common.WriteClusterSnapshot(out, snapshot), "failed to write the snapshot")
+		return
+	}
+	file, err := os.Create(filename)
+	handleErrOrExitWithMsg(err, "failed to create the snapshot")
+	if err := common.WriteClusterSnapshot(file, snapshot); err != nil {
+		file.Close()
+		handleErrOrExitWithMsg(err, "failed to write the snapshot")
+	}
+	handleErrOrExitWithMsg(file.Close(), "failed to write the snapshot")
+	fmt.Fprintf(os.Stderr, "Wrote %d resources to %v\n", len(snapshot.Objects), filename)
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestSnapshotSave_ReplayDescribeHTTPRoutes(t *testing.T) {
+	crd := func(plural, kind, version string, scope apiextensionsv1.ResourceScope) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: plural + "." + gatewayv1.GroupName},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    scope,
+				Group:    gatewayv1.GroupName,
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: version, Served: true, Storage: true}},
+				Names:    apiextensionsv1.CustomResourceDefinitionNames{Plural: plural, Kind: kind},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		crd("gatewayclasses", "GatewayClass", "v1", apiextensionsv1.ClusterScoped),
+		crd("gateways", "Gateway", "v1", apiextensionsv1.NamespaceScoped),
+		crd("httproutes", "HTTPRoute", "v1", apiextensionsv1.NamespaceScoped),
+		crd("referencegrants", "ReferenceGrant", "v1beta1", apiextensionsv1.NamespaceScoped),
+		&gatewayv1.GatewayClass{
+			TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1.GroupVersion.String(), Kind: "GatewayClass"},
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-gatewayclass"},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: "example.net/gateway-controller"},
+		},
+		&gatewayv1.Gateway{
+			TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1.GroupVersion.String(), Kind: "Gateway"},
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-gateway", Namespace: "default", UID: "foo-gateway-uid"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+		},
+		&gatewayv1.HTTPRoute{
+			TypeMeta: metav1.TypeMeta{APIVersion: gatewayv1.GroupVersion.String(), Kind: "HTTPRoute"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-httproute",
+				Namespace: "default",
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					{
+						Manager:    "kubectl",
+						Operation:  metav1.ManagedFieldsOperationApply,
+						FieldsType: "FieldsV1",
+						FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:hostnames":{}}}`)},
+					},
+				},
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "foo-gateway"}},
+				},
+				Hostnames: []gatewayv1.Hostname{"example.com"},
+				Rules: []gatewayv1.HTTPRouteRule{{
+					BackendRefs: []gatewayv1.HTTPBackendRef{{
+						BackendRef: gatewayv1.BackendRef{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Kind: common.PtrTo(gatewayv1.Kind("Service")),
+								Name: "foo-svc",
+								Port: common.PtrTo(gatewayv1.PortNumber(8080)),
+							},
+						},
+					}},
+				}},
+			},
+		},
+		&corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-svc", Namespace: "default"},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "foo-gateway-event", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Gateway", Name: "foo-gateway", Namespace: "default", UID: "foo-gateway-uid"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Programmed",
+			Message:        "Gateway is programmed",
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "foo-svc-event", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Service", Name: "foo-svc", Namespace: "default"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "SyncFailed",
+			Message:        "Service failed to sync",
+		},
+	}
+	live := &fakeFactory{k8sClients: common.MustClientsForTest(t, objects...)}
+
+	snapshotFile := &bytes.Buffer{}
+	saveCmd := NewSnapshotCommand(live, snapshotFile)
+	saveCmd.SetArgs([]string{"save", "-"})
+	if err := saveCmd.Execute(); err != nil {
+		t.Fatalf("Execute() failed: %v", err)
+	}
+
+	snapshot, err := common.ReadClusterSnapshot(snapshotFile)
+	if err != nil {
+		t.Fatalf("ReadClusterSnapshot() failed: %v", err)
+	}
+	// Events of core objects are saved along with those of Gateway API objects.
+	savedEvents := map[string]bool{}
+	for _, obj := range snapshot.Objects {
+		if obj.GetKind() == "HTTPRoute" && len(obj.GetManagedFields()) == 0 {
+			t.Errorf("%v %v/%v was saved without its managedFields", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+		if obj.GetKind() == "Event" {
+			savedEvents[obj.GetName()] = true
+		}
+	}
+	for _, name := range []string{"foo-gateway-event", "foo-svc-event"} {
+		if !savedEvents[name] {
+			t.Errorf("Event %v was not saved in the snapshot", name)
+		}
+	}
+	snapshotClients, err := snapshot.K8sClients()
+	if err != nil {
+		t.Fatalf("K8sClients() failed: %v", err)
+	}
+	replayed := &fakeFactory{k8sClients: snapshotClients}
+
+	describe := func(f *fakeFactory, args []string) string {
+		out := &bytes.Buffer{}
+		describeCmd := NewSubCommand(f, out, commandNameDescribe)
+		describeCmd.SetArgs(args)
+		if err := describeCmd.Execute(); err != nil {
+			t.Fatalf("Execute() failed: %v", err)
+		}
+		return out.String()
+	}
+	testcases := []struct {
+		args         []string
+		wantContains []string
+	}{
+		{args: []string{"httproutes"}, wantContains: []string{"foo-httproute", "foo-svc"}},
+		// Gateways are described along with their events.
+		{args: []string{"gateways"}, wantContains: []string{"foo-gateway", "Gateway is programmed"}},
+		// Field owners are read from the managedFields kept in the snapshot.
+		{args: []string{"httproutes", "--show-field-owners"}, wantContains: []string{"kubectl"}},
+	}
+	for _, tc := range testcases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			want := describe(live, tc.args)
+			for _, s := range tc.wantContains {
+				if !strings.Contains(want, s) {
+					t.Fatalf("describe %v does not contain %q:\n%v", strings.Join(tc.args, " "), s, want)
+				}
+			}
+			if diff := cmp.Diff(want, describe(replayed, tc.args)); diff != "" {
+				t.Errorf("Unexpected diff in output replayed from the snapshot (-live +snapshot):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestReadClusterSnapshot_UnsupportedFormatVersion(t *testing.T) {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	if _, err := gzipWriter.Write([]byte(`{"formatVersion":2,"objects":[]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := common.ReadClusterSnapshot(buf)
+	want := "unsupported snapshot format version 2; this version of gwctl only supports version 1"
+	if err == nil || err.Error() != want {
+		t.Errorf("ReadClusterSnapshot() returned error %v, want %v", err, want)
+	}
+}
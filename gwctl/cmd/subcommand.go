@@ -20,11 +20,17 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -53,14 +59,19 @@ func NewSubCommand(f cmdutils.Factory, out io.Writer, cmdName commandName) *cobr
 	cmd := &cobra.Command{
 		Use:   string(cmdName),
 		Short: shortMsg,
+		// Resource types which are not the name or an alias of a subcommand are
+		// resolved through the cluster, and their flags are parsed by the
+		// subcommand they resolve to.
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClusterResourceType(f, cmd, args)
+		},
+	}
+	for _, kind := range printer.Kinds() {
+		if subCmd := newCmdForKind(f, out, cmdName, kind); subCmd != nil {
+			cmd.AddCommand(subCmd)
+		}
 	}
-	cmd.AddCommand(newCmdNamespaces(f, out, cmdName))
-	cmd.AddCommand(newCmdGatewayClasses(f, out, cmdName))
-	cmd.AddCommand(newCmdGateways(f, out, cmdName))
-	cmd.AddCommand(newCmdHTTPRoutes(f, out, cmdName))
-	cmd.AddCommand(newCmdBackends(f, out, cmdName))
-	cmd.AddCommand(newCmdPolicies(f, out, cmdName))
-	cmd.AddCommand(newCmdPolicyCRDs(f, out, cmdName))
 	return cmd
 }
 
@@ -68,7 +79,7 @@ func newCmdNamespaces(f cmdutils.Factory, out io.Writer, cmdName commandName) *c
 	o := &getOrDescribeOptions{out: out, cmdName: cmdName}
 	cmd := &cobra.Command{
 		Use:     "namespaces",
-		Aliases: []string{"namespace", "ns"},
+		Aliases: resourceTypeAliases("namespaces"),
 		Short:   "Display one or more Namespaces",
 		Args:    cobra.RangeArgs(0, 1),
 		Run: func(_ *cobra.Command, args []string) {
@@ -77,8 +88,19 @@ func newCmdNamespaces(f cmdutils.Factory, out io.Writer, cmdName commandName) *c
 		},
 	}
 	addLabelSelectorFlag(&o.labelSelectorFlag, cmd)
+	addWhereFlag(&o.whereFlag, cmd)
 	if cmdName == commandNameGet {
 		addOutputFormatFlag(&o.outputFlag, cmd)
+		addCanonicalFlag(&o.canonicalFlag, cmd)
+		addIgnoreNotFoundFlag(&o.ignoreNotFoundFlag, cmd)
+		addLabelColumnsFlag(&o.labelColumnsFlag, cmd)
+		addAnnotationColumnsFlag(&o.annotationColumnsFlag, cmd)
+		addHighlightFlag(&o.highlightFlag, cmd)
+		addNoColorFlag(&o.noColorFlag, cmd)
+	} else {
+		addDescribeOutputFormatFlag(&o.outputFlag, cmd)
+		addEventLimitFlag(&o.eventLimitFlag, cmd)
+		addMaxListItemsFlag(&o.maxListItemsFlag, cmd)
 	}
 	return cmd
 }
@@ -87,7 +109,7 @@ func newCmdGatewayClasses(f cmdutils.Factory, out io.Writer, cmdName commandName
 	o := &getOrDescribeOptions{out: out, cmdName: cmdName}
 	cmd := &cobra.Command{
 		Use:     "gatewayclasses",
-		Aliases: []string{"gatewayclass"},
+		Aliases: resourceTypeAliases("gatewayclasses"),
 		Short:   "Display one or more GatewayClasses",
 		Args:    cobra.RangeArgs(0, 1),
 		Run: func(_ *cobra.Command, args []string) {
@@ -96,9 +118,26 @@ func newCmdGatewayClasses(f cmdutils.Factory, out io.Writer, cmdName commandName
 		},
 	}
 	addLabelSelectorFlag(&o.labelSelectorFlag, cmd)
+	addWhereFlag(&o.whereFlag, cmd)
+	addControllerFlag(&o.controllerFlag, cmd)
+	addNameRegexFlag(&o.nameRegexFlag, cmd)
 	if cmdName == commandNameGet {
+		addStaleFlag(&o.staleFlag, cmd)
 		addForFlag(&o.forFlag, cmd)
 		addOutputFormatFlag(&o.outputFlag, cmd)
+		addCanonicalFlag(&o.canonicalFlag, cmd)
+		addIgnoreNotFoundFlag(&o.ignoreNotFoundFlag, cmd)
+		addLabelColumnsFlag(&o.labelColumnsFlag, cmd)
+		addAnnotationColumnsFlag(&o.annotationColumnsFlag, cmd)
+		addHighlightFlag(&o.highlightFlag, cmd)
+		addNoColorFlag(&o.noColorFlag, cmd)
+		addWithUsageFlag(&o.withUsageFlag, cmd)
+	} else {
+		addDescribeOutputFormatFlag(&o.outputFlag, cmd)
+		addShowDriftFlag(&o.showDriftFlag, cmd)
+		addShowFieldOwnersFlag(&o.showFieldOwnersFlag, cmd)
+		addEventLimitFlag(&o.eventLimitFlag, cmd)
+		addMaxListItemsFlag(&o.maxListItemsFlag, cmd)
 	}
 	return cmd
 }
@@ -107,7 +146,7 @@ func newCmdGateways(f cmdutils.Factory, out io.Writer, cmdName commandName) *cob
 	o := &getOrDescribeOptions{out: out, cmdName: cmdName}
 	cmd := &cobra.Command{
 		Use:     "gateways",
-		Aliases: []string{"gateway", "gw", "Gateways", "Gateway"},
+		Aliases: resourceTypeAliases("gateways"),
 		Short:   "Display one or more Gateways",
 		Args:    cobra.RangeArgs(0, 1),
 		Run: func(_ *cobra.Command, args []string) {
@@ -115,13 +154,107 @@ func newCmdGateways(f cmdutils.Factory, out io.Writer, cmdName commandName) *cob
 			runGetOrDescribeGateways(f, o)
 		},
 	}
-	addNamespaceFlag(&o.namespaceFlag, cmd)
+	addNamespacesFlag(&o.namespaceFlag, cmd)
 	addAllNamespacesFlag(&o.allNamespacesFlag, cmd)
 	addLabelSelectorFlag(&o.labelSelectorFlag, cmd)
+	addWhereFlag(&o.whereFlag, cmd)
+	addControllerFlag(&o.controllerFlag, cmd)
+	addNameRegexFlag(&o.nameRegexFlag, cmd)
+	addAddressFlag(&o.addressFlag, cmd)
 	if cmdName == commandNameGet {
+		addStaleFlag(&o.staleFlag, cmd)
 		addForFlag(&o.forFlag, cmd)
 		addOutputFormatFlag(&o.outputFlag, cmd)
+		addCanonicalFlag(&o.canonicalFlag, cmd)
+		addContextsFlag(&o.contextsFlag, cmd)
+		addIgnoreNotFoundFlag(&o.ignoreNotFoundFlag, cmd)
+		addLabelColumnsFlag(&o.labelColumnsFlag, cmd)
+		addAnnotationColumnsFlag(&o.annotationColumnsFlag, cmd)
+		addHighlightFlag(&o.highlightFlag, cmd)
+		addNoColorFlag(&o.noColorFlag, cmd)
+		addSortByFlag(&o.sortByFlag, cmd)
+		addGroupByFlag(&o.groupByFlag, cmd)
+	} else {
+		addDescribeOutputFormatFlag(&o.outputFlag, cmd)
+		addShowDriftFlag(&o.showDriftFlag, cmd)
+		addShowFieldOwnersFlag(&o.showFieldOwnersFlag, cmd)
+		addShowPrecedenceFlag(&o.showPrecedenceFlag, cmd)
+		addEffectivePolicyKindFlag(&o.effectivePolicyKindFlag, cmd)
+		addEffectiveSubtreeFlag(&o.effectiveSubtreeFlag, cmd)
+		addEventLimitFlag(&o.eventLimitFlag, cmd)
+		addMaxListItemsFlag(&o.maxListItemsFlag, cmd)
+		addOutputDirFlag(&o.outputDirFlag, cmd)
+		addOutputDirConcurrencyFlag(&o.concurrencyFlag, cmd)
+	}
+	return cmd
+}
+
+func newCmdListeners(f cmdutils.Factory, out io.Writer, cmdName commandName) *cobra.Command {
+	o := &getOrDescribeOptions{out: out, cmdName: cmdName}
+	cmd := &cobra.Command{
+		Use:     "listeners",
+		Aliases: resourceTypeAliases("listeners"),
+		Short:   "Display the listeners of one or more Gateways",
+		Args:    cobra.RangeArgs(0, 1),
+		Run: func(_ *cobra.Command, args []string) {
+			o.parse(args)
+			runGetListeners(f, o)
+		},
 	}
+	addNamespacesFlag(&o.namespaceFlag, cmd)
+	addAllNamespacesFlag(&o.allNamespacesFlag, cmd)
+	addLabelSelectorFlag(&o.labelSelectorFlag, cmd)
+	addOutputFormatFlag(&o.outputFlag, cmd)
+	addIgnoreNotFoundFlag(&o.ignoreNotFoundFlag, cmd)
+	addAnnotationColumnsFlag(&o.annotationColumnsFlag, cmd)
+	return cmd
+}
+
+func newCmdCertificates(f cmdutils.Factory, out io.Writer, cmdName commandName) *cobra.Command {
+	o := &getOrDescribeOptions{out: out, cmdName: cmdName}
+	cmd := &cobra.Command{
+		Use:     "certificates",
+		Aliases: resourceTypeAliases("certificates"),
+		Short:   "Display the TLS certificates referenced by the listeners of one or more Gateways",
+		Args:    cobra.RangeArgs(0, 1),
+		Run: func(_ *cobra.Command, args []string) {
+			o.parse(args)
+			runGetCertificates(f, o)
+		},
+	}
+	addNamespacesFlag(&o.namespaceFlag, cmd)
+	addAllNamespacesFlag(&o.allNamespacesFlag, cmd)
+	addLabelSelectorFlag(&o.labelSelectorFlag, cmd)
+	addOutputFormatFlag(&o.outputFlag, cmd)
+	addIgnoreNotFoundFlag(&o.ignoreNotFoundFlag, cmd)
+	addExpiringWithinFlag(&o.expiringWithinFlag, cmd)
+	addAnnotationColumnsFlag(&o.annotationColumnsFlag, cmd)
+	return cmd
+}
+
+// newCmdReferencedResources returns the command for resources which are not
+// Gateway API resources, but are referenced by them. Such resources can only
+// be described with --referenced-by, to show what references them.
+func newCmdReferencedResources(f cmdutils.Factory, out io.Writer, cmdName commandName, name string) *cobra.Command {
+	o := &getOrDescribeOptions{out: out, cmdName: cmdName}
+	kind := mustLookupResourceType(name).GroupKind.Kind
+	cmd := &cobra.Command{
+		Use:     name + " [NAMESPACE/]NAME",
+		Aliases: resourceTypeAliases(name),
+		Short:   fmt.Sprintf("Display the Gateway API resources which reference a %v", kind),
+		Args:    cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			o.parse(args)
+			if !o.referencedByFlag {
+				fmt.Fprintf(os.Stderr, "%v can only be described with --referenced-by\n", name)
+				os.Exit(1)
+			}
+			runDescribeReferencedBy(f, o, kind)
+		},
+	}
+	addNamespacesFlag(&o.namespaceFlag, cmd)
+	addDescribeOutputFormatFlag(&o.outputFlag, cmd)
+	addReferencedByFlag(&o.referencedByFlag, cmd)
 	return cmd
 }
 
@@ -129,7 +262,7 @@ func newCmdHTTPRoutes(f cmdutils.Factory, out io.Writer, cmdName commandName) *c
 	o := &getOrDescribeOptions{out: out, cmdName: cmdName}
 	cmd := &cobra.Command{
 		Use:     "httproutes",
-		Aliases: []string{"httproute"},
+		Aliases: resourceTypeAliases("httproutes"),
 		Short:   "Display one or more HTTPRoutes",
 		Args:    cobra.RangeArgs(0, 1),
 		Run: func(_ *cobra.Command, args []string) {
@@ -137,12 +270,39 @@ func newCmdHTTPRoutes(f cmdutils.Factory, out io.Writer, cmdName commandName) *c
 			runGetOrDescribeHTTPRoutes(f, o)
 		},
 	}
-	addNamespaceFlag(&o.namespaceFlag, cmd)
+	addNamespacesFlag(&o.namespaceFlag, cmd)
 	addAllNamespacesFlag(&o.allNamespacesFlag, cmd)
 	addLabelSelectorFlag(&o.labelSelectorFlag, cmd)
+	addWhereFlag(&o.whereFlag, cmd)
+	addControllerFlag(&o.controllerFlag, cmd)
+	addNameRegexFlag(&o.nameRegexFlag, cmd)
+	addValidateHostnamesFlag(&o.validateHostnames, cmd)
+	addFilterTypeFlag(&o.filterTypeFlag, cmd)
+	addValidateRegexesFlag(&o.validateRegexes, cmd)
+	addUsesRegexFlag(&o.usesRegexFlag, cmd)
+	addSortHostnamesFlag(&o.sortHostnamesFlag, cmd)
 	if cmdName == commandNameGet {
+		addStaleFlag(&o.staleFlag, cmd)
 		addForFlag(&o.forFlag, cmd)
 		addOutputFormatFlag(&o.outputFlag, cmd)
+		addCanonicalFlag(&o.canonicalFlag, cmd)
+		addContextsFlag(&o.contextsFlag, cmd)
+		addIgnoreNotFoundFlag(&o.ignoreNotFoundFlag, cmd)
+		addLabelColumnsFlag(&o.labelColumnsFlag, cmd)
+		addAnnotationColumnsFlag(&o.annotationColumnsFlag, cmd)
+		addHighlightFlag(&o.highlightFlag, cmd)
+		addNoColorFlag(&o.noColorFlag, cmd)
+		addSortByFlag(&o.sortByFlag, cmd)
+		addConditionsFlag(&o.conditionsFlag, cmd)
+	} else {
+		addDescribeOutputFormatFlag(&o.outputFlag, cmd)
+		addShowDriftFlag(&o.showDriftFlag, cmd)
+		addShowFieldOwnersFlag(&o.showFieldOwnersFlag, cmd)
+		addEffectivePolicyKindFlag(&o.effectivePolicyKindFlag, cmd)
+		addParentFlag(&o.parentFlag, cmd)
+		addMaxListItemsFlag(&o.maxListItemsFlag, cmd)
+		addOutputDirFlag(&o.outputDirFlag, cmd)
+		addOutputDirConcurrencyFlag(&o.concurrencyFlag, cmd)
 	}
 	return cmd
 }
@@ -151,7 +311,7 @@ func newCmdBackends(f cmdutils.Factory, out io.Writer, cmdName commandName) *cob
 	o := &getOrDescribeOptions{out: out, cmdName: cmdName}
 	cmd := &cobra.Command{
 		Use:     "backends",
-		Aliases: []string{"backend"},
+		Aliases: resourceTypeAliases("backends"),
 		Short:   "Display one or more Backends",
 		Args:    cobra.RangeArgs(0, 1),
 		Run: func(_ *cobra.Command, args []string) {
@@ -159,12 +319,30 @@ func newCmdBackends(f cmdutils.Factory, out io.Writer, cmdName commandName) *cob
 			runGetOrDescribeBackends(f, o)
 		},
 	}
-	addNamespaceFlag(&o.namespaceFlag, cmd)
+	addNamespacesFlag(&o.namespaceFlag, cmd)
 	addAllNamespacesFlag(&o.allNamespacesFlag, cmd)
 	addLabelSelectorFlag(&o.labelSelectorFlag, cmd)
+	addWhereFlag(&o.whereFlag, cmd)
+	addControllerFlag(&o.controllerFlag, cmd)
+	addNameRegexFlag(&o.nameRegexFlag, cmd)
 	if cmdName == commandNameGet {
 		addForFlag(&o.forFlag, cmd)
 		addOutputFormatFlag(&o.outputFlag, cmd)
+		addCanonicalFlag(&o.canonicalFlag, cmd)
+		addIgnoreNotFoundFlag(&o.ignoreNotFoundFlag, cmd)
+		addLabelColumnsFlag(&o.labelColumnsFlag, cmd)
+		addAnnotationColumnsFlag(&o.annotationColumnsFlag, cmd)
+		addHighlightFlag(&o.highlightFlag, cmd)
+		addNoColorFlag(&o.noColorFlag, cmd)
+	} else {
+		addDescribeOutputFormatFlag(&o.outputFlag, cmd)
+		addShowDriftFlag(&o.showDriftFlag, cmd)
+		addShowFieldOwnersFlag(&o.showFieldOwnersFlag, cmd)
+		addEffectivePolicyKindFlag(&o.effectivePolicyKindFlag, cmd)
+		addEventLimitFlag(&o.eventLimitFlag, cmd)
+		addMaxListItemsFlag(&o.maxListItemsFlag, cmd)
+		addOutputDirFlag(&o.outputDirFlag, cmd)
+		addOutputDirConcurrencyFlag(&o.concurrencyFlag, cmd)
 	}
 	return cmd
 }
@@ -173,7 +351,7 @@ func newCmdPolicies(f cmdutils.Factory, out io.Writer, cmdName commandName) *cob
 	o := &getOrDescribeOptions{out: out, cmdName: cmdName}
 	cmd := &cobra.Command{
 		Use:     "policies",
-		Aliases: []string{"policy"},
+		Aliases: resourceTypeAliases("policies"),
 		Short:   "Display one or more Policies",
 		Args:    cobra.RangeArgs(0, 1),
 		Run: func(_ *cobra.Command, args []string) {
@@ -184,6 +362,12 @@ func newCmdPolicies(f cmdutils.Factory, out io.Writer, cmdName commandName) *cob
 	if cmdName == commandNameGet {
 		addForFlag(&o.forFlag, cmd)
 		addOutputFormatFlag(&o.outputFlag, cmd)
+		addIgnoreNotFoundFlag(&o.ignoreNotFoundFlag, cmd)
+		addOnlyIneffectiveFlag(&o.onlyIneffectiveFlag, cmd)
+		addAnnotationColumnsFlag(&o.annotationColumnsFlag, cmd)
+	} else {
+		addDescribeOutputFormatFlag(&o.outputFlag, cmd)
+		addMaxListItemsFlag(&o.maxListItemsFlag, cmd)
 	}
 	return cmd
 }
@@ -192,7 +376,7 @@ func newCmdPolicyCRDs(f cmdutils.Factory, out io.Writer, cmdName commandName) *c
 	o := &getOrDescribeOptions{out: out, cmdName: cmdName}
 	cmd := &cobra.Command{
 		Use:     "policycrds",
-		Aliases: []string{"policycrd"},
+		Aliases: resourceTypeAliases("policycrds"),
 		Short:   "Display one or more Policy CRDs",
 		Args:    cobra.RangeArgs(0, 1),
 		Run: func(_ *cobra.Command, args []string) {
@@ -202,6 +386,10 @@ func newCmdPolicyCRDs(f cmdutils.Factory, out io.Writer, cmdName commandName) *c
 	}
 	if cmdName == commandNameGet {
 		addOutputFormatFlag(&o.outputFlag, cmd)
+		addIgnoreNotFoundFlag(&o.ignoreNotFoundFlag, cmd)
+		addAnnotationColumnsFlag(&o.annotationColumnsFlag, cmd)
+	} else {
+		addDescribeOutputFormatFlag(&o.outputFlag, cmd)
 	}
 	return cmd
 }
@@ -212,16 +400,20 @@ func runGetOrDescribeNamespaces(f cmdutils.Factory, o *getOrDescribeOptions) {
 	policyManager, err := f.PolicyManager()
 	handleErrOrExitWithMsg(err, "")
 
-	discoverer := resourcediscovery.NewDiscoverer(k8sClients, policyManager)
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
 	resourceModel, err := discoverer.DiscoverResourcesForNamespace(o.toResourceDiscoveryFilter())
+	o.handleNotFound(err)
 	handleErrOrExitWithMsg(err, "failed to discover Namespace resources")
+	if o.where != nil {
+		resourceModel.RestrictToWhereExpression("Namespace", o.where, discoverer.Warnings)
+	}
 
 	realClock := clock.RealClock{}
-	nsPrinter := &printer.NamespacesPrinter{Writer: o.out, Clock: realClock, EventFetcher: discoverer}
+	nsPrinter := &printer.NamespacesPrinter{Writer: o.out, Clock: realClock, EventFetcher: discoverer, EventLimit: o.eventLimitFlag, MaxListItems: o.maxListItemsFlag, LabelColumns: o.labelColumns, AnnotationColumns: o.annotationColumns, Highlighter: o.highlighter}
 	if o.cmdName == commandNameGet {
-		printer.Print(nsPrinter, resourceModel, o.outputFormat)
+		o.print(nsPrinter, resourceModel)
 	} else {
-		nsPrinter.PrintDescribeView(resourceModel)
+		printer.PrintDescribe(nsPrinter, resourceModel, o.outputFormat)
 	}
 }
 
@@ -231,7 +423,7 @@ func runGetOrDescribeGatewayClasses(f cmdutils.Factory, o *getOrDescribeOptions)
 	policyManager, err := f.PolicyManager()
 	handleErrOrExitWithMsg(err, "")
 
-	discoverer := resourcediscovery.NewDiscoverer(k8sClients, policyManager)
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
 	emptyObjRef := common.ObjRef{}
 	var resourceModel *resourcediscovery.ResourceModel
 	if o.cmdName == commandNameGet && o.forObjRef != emptyObjRef {
@@ -243,26 +435,73 @@ func runGetOrDescribeGatewayClasses(f cmdutils.Factory, o *getOrDescribeOptions)
 			os.Exit(1)
 		}
 	} else {
+		// Printing only names does not require the full objects.
+		discoverer.MetadataOnly = o.outputFormat == cmdutils.OutputFormatName && o.where == nil
+		// The describe view lists the HTTPRoutes affected by the GatewayClass.
+		discoverer.DiscoverUsage = o.withUsageFlag || o.cmdName == commandNameDescribe
 		resourceModel, err = discoverer.DiscoverResourcesForGatewayClass(o.toResourceDiscoveryFilter())
 	}
+	o.handleNotFound(err)
 	handleErrOrExitWithMsg(err, "failed to discover GatewayClass resources")
+	if o.where != nil {
+		resourceModel.RestrictToWhereExpression("GatewayClass", o.where, discoverer.Warnings)
+	}
 
 	realClock := clock.RealClock{}
-	gwcPrinter := &printer.GatewayClassesPrinter{Writer: o.out, Clock: realClock, EventFetcher: discoverer}
+	gwcPrinter := &printer.GatewayClassesPrinter{Writer: o.out, Clock: realClock, EventFetcher: discoverer, EventLimit: o.eventLimitFlag, MaxListItems: o.maxListItemsFlag, LabelColumns: o.labelColumns, AnnotationColumns: o.annotationColumns, Highlighter: o.highlighter, ShowDrift: o.showDriftFlag, ShowFieldOwners: o.showFieldOwnersFlag}
+	if o.withUsageFlag {
+		gwcPrinter.PrintWithUsage(resourceModel, o.outputFormat)
+		return
+	}
 	if o.cmdName == commandNameGet {
-		printer.Print(gwcPrinter, resourceModel, o.outputFormat)
+		o.print(gwcPrinter, resourceModel)
 	} else {
-		gwcPrinter.PrintDescribeView(resourceModel)
+		printer.PrintDescribe(gwcPrinter, resourceModel, o.outputFormat)
 	}
 }
 
 func runGetOrDescribeGateways(f cmdutils.Factory, o *getOrDescribeOptions) {
+	realClock := clock.RealClock{}
+	if len(o.contexts) != 0 {
+		gwPrinter := &printer.GatewaysPrinter{Writer: o.out, Clock: realClock, SortBy: o.sortBy, LabelColumns: o.labelColumns, AnnotationColumns: o.annotationColumns, Highlighter: o.highlighter}
+		printer.PrintClusters(gwPrinter, discoverForContexts(f, o.contexts, func(f cmdutils.Factory) (*resourcediscovery.ResourceModel, error) {
+			resourceModel, _, err := discoverGateways(f, o)
+			return resourceModel, err
+		}), o.outputFormat)
+		return
+	}
+
+	resourceModel, discoverer, err := discoverGateways(f, o)
+	o.handleNotFound(err)
+	handleErrOrExitWithMsg(err, "failed to discover Gateway resources")
+	gwPrinter := &printer.GatewaysPrinter{Writer: o.out, Clock: realClock, EventFetcher: discoverer, EventLimit: o.eventLimitFlag, MaxListItems: o.maxListItemsFlag, SortBy: o.sortBy, LabelColumns: o.labelColumns, AnnotationColumns: o.annotationColumns, Highlighter: o.highlighter, EffectivePolicyKind: o.effectivePolicyKindFlag, ShowDrift: o.showDriftFlag, ShowFieldOwners: o.showFieldOwnersFlag, ShowPrecedence: o.showPrecedenceFlag, GroupByClass: o.groupByClass, EffectiveSubtree: o.effectiveSubtreeFlag}
+	switch {
+	case o.cmdName == commandNameGet:
+		o.print(gwPrinter, resourceModel)
+	case o.outputDirFlag != "":
+		o.describeToDir("Gateway", resourceModel, func(w io.Writer) printer.DescribePrinter {
+			p := *gwPrinter
+			p.Writer = w
+			return &p
+		})
+	default:
+		printer.PrintDescribe(gwPrinter, resourceModel, o.outputFormat)
+	}
+}
+
+// discoverGateways discovers the Gateways matching the options through the
+// clients of the factory.
+func discoverGateways(f cmdutils.Factory, o *getOrDescribeOptions) (*resourcediscovery.ResourceModel, resourcediscovery.Discoverer, error) {
 	k8sClients, err := f.K8sClients()
-	handleErrOrExitWithMsg(err, "")
+	if err != nil {
+		return nil, resourcediscovery.Discoverer{}, err
+	}
 	policyManager, err := f.PolicyManager()
-	handleErrOrExitWithMsg(err, "")
+	if err != nil {
+		return nil, resourcediscovery.Discoverer{}, err
+	}
 
-	discoverer := resourcediscovery.NewDiscoverer(k8sClients, policyManager)
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
 	emptyObjRef := common.ObjRef{}
 	var resourceModel *resourcediscovery.ResourceModel
 	if o.cmdName == commandNameGet && o.forObjRef != emptyObjRef {
@@ -276,26 +515,138 @@ func runGetOrDescribeGateways(f cmdutils.Factory, o *getOrDescribeOptions) {
 			os.Exit(1)
 		}
 	} else {
+		// Printing only names does not require the full objects.
+		discoverer.MetadataOnly = o.outputFormat == cmdutils.OutputFormatName && o.where == nil && o.addressFlag == ""
 		resourceModel, err = discoverer.DiscoverResourcesForGateway(o.toResourceDiscoveryFilter())
 	}
+	if err != nil {
+		return nil, discoverer, err
+	}
+	if o.where != nil {
+		resourceModel.RestrictToWhereExpression("Gateway", o.where, discoverer.Warnings)
+	}
+	if o.addressFlag != "" {
+		resourceModel.RestrictToGatewayAddress(o.addressFlag)
+	}
+	return resourceModel, discoverer, nil
+}
+
+// runGetListeners prints the listeners of the Gateways matching the options. A
+// resource name, if provided, is the name of the Gateway.
+func runGetListeners(f cmdutils.Factory, o *getOrDescribeOptions) {
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+	policyManager, err := f.PolicyManager()
+	handleErrOrExitWithMsg(err, "")
+
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(o.toResourceDiscoveryFilter())
+	o.handleNotFound(err)
+	handleErrOrExitWithMsg(err, "failed to discover Gateway resources")
+
+	listenersPrinter := &printer.ListenersPrinter{Writer: o.out, AnnotationColumns: o.annotationColumns}
+	listenersPrinter.Print(resourceModel, o.outputFormat)
+}
+
+// runGetCertificates prints the certificates referenced by the listeners of
+// the Gateways matching the options. A resource name, if provided, is the name
+// of the Gateway.
+func runGetCertificates(f cmdutils.Factory, o *getOrDescribeOptions) {
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+	policyManager, err := f.PolicyManager()
+	handleErrOrExitWithMsg(err, "")
+
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(o.toResourceDiscoveryFilter())
+	o.handleNotFound(err)
 	handleErrOrExitWithMsg(err, "failed to discover Gateway resources")
 
 	realClock := clock.RealClock{}
-	gwPrinter := &printer.GatewaysPrinter{Writer: o.out, Clock: realClock, EventFetcher: discoverer}
-	if o.cmdName == commandNameGet {
-		printer.Print(gwPrinter, resourceModel, o.outputFormat)
-	} else {
-		gwPrinter.PrintDescribeView(resourceModel)
+	certificates := discoverer.CertificatesForGateways(resourceModel)
+	if o.expiringWithinFlag != "" {
+		expiringWithin, err := cmdutils.ParseDuration(o.expiringWithinFlag)
+		handleErrOrExitWithMsg(err, "failed to parse --expiring-within")
+		certificates = resourcediscovery.FilterCertificatesExpiringBefore(certificates, realClock.Now().Add(expiringWithin))
 	}
+
+	certificatesPrinter := &printer.CertificatesPrinter{Writer: o.out, Clock: realClock, AnnotationColumns: o.annotationColumns}
+	certificatesPrinter.Print(resourceModel, certificates, o.outputFormat)
 }
 
-func runGetOrDescribeHTTPRoutes(f cmdutils.Factory, o *getOrDescribeOptions) {
+// runDescribeReferencedBy prints the Gateway API resources which reference the
+// resource of the given kind. The resource name may be provided as
+// NAMESPACE/NAME.
+func runDescribeReferencedBy(f cmdutils.Factory, o *getOrDescribeOptions, kind string) {
 	k8sClients, err := f.K8sClients()
 	handleErrOrExitWithMsg(err, "")
 	policyManager, err := f.PolicyManager()
 	handleErrOrExitWithMsg(err, "")
 
-	discoverer := resourcediscovery.NewDiscoverer(k8sClients, policyManager)
+	target := common.ObjRef{Kind: kind, Namespace: o.namespace, Name: o.resourceName}
+	if namespace, name, ok := strings.Cut(o.resourceName, "/"); ok {
+		target.Namespace, target.Name = namespace, name
+	}
+
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
+	resourceModel, err := discoverer.DiscoverAllResources(resourcediscovery.Filter{Labels: labels.Everything()})
+	handleErrOrExitWithMsg(err, "failed to discover resources")
+	references, err := discoverer.ReferencesTo(resourceModel, target)
+	handleErrOrExitWithMsg(err, fmt.Sprintf("failed to find references to %v", kind))
+	referenceGrants, err := discoverer.ReferenceGrantCoverageFor(resourceModel, target)
+	handleErrOrExitWithMsg(err, fmt.Sprintf("failed to find the ReferenceGrants exposing the %v", kind))
+
+	referencesPrinter := &printer.ReferencesPrinter{Writer: o.out}
+	referencesPrinter.PrintDescribe(target, references, referenceGrants, o.outputFormat)
+}
+
+func runGetOrDescribeHTTPRoutes(f cmdutils.Factory, o *getOrDescribeOptions) {
+	realClock := clock.RealClock{}
+	if len(o.contexts) != 0 {
+		httpRoutesPrinter := &printer.HTTPRoutesPrinter{Writer: o.out, Clock: realClock, SortBy: o.sortBy, LabelColumns: o.labelColumns, AnnotationColumns: o.annotationColumns, Highlighter: o.highlighter, SortHostnames: o.sortHostnamesFlag}
+		printer.PrintClusters(httpRoutesPrinter, discoverForContexts(f, o.contexts, func(f cmdutils.Factory) (*resourcediscovery.ResourceModel, error) {
+			return discoverHTTPRoutes(f, o)
+		}), o.outputFormat)
+		return
+	}
+
+	resourceModel, err := discoverHTTPRoutes(f, o)
+	o.handleNotFound(err)
+	handleErrOrExitWithMsg(err, "failed to discover HTTPRoute resources")
+	httpRoutesPrinter := &printer.HTTPRoutesPrinter{Writer: o.out, Clock: realClock, SortBy: o.sortBy, LabelColumns: o.labelColumns, AnnotationColumns: o.annotationColumns, Highlighter: o.highlighter, EffectivePolicyKind: o.effectivePolicyKindFlag, ShowDrift: o.showDriftFlag, ShowFieldOwners: o.showFieldOwnersFlag, SortHostnames: o.sortHostnamesFlag, MaxListItems: o.maxListItemsFlag}
+	if o.conditionsFlag {
+		httpRoutesPrinter.PrintConditions(resourceModel)
+		return
+	}
+	switch {
+	case o.cmdName == commandNameGet:
+		o.print(httpRoutesPrinter, resourceModel)
+	case o.outputDirFlag != "":
+		o.describeToDir("HTTPRoute", resourceModel, func(w io.Writer) printer.DescribePrinter {
+			p := *httpRoutesPrinter
+			p.Writer = w
+			return &p
+		})
+	default:
+		printer.PrintDescribe(httpRoutesPrinter, resourceModel, o.outputFormat)
+	}
+}
+
+// discoverHTTPRoutes discovers the HTTPRoutes matching the options through the
+// clients of the factory.
+func discoverHTTPRoutes(f cmdutils.Factory, o *getOrDescribeOptions) (*resourcediscovery.ResourceModel, error) {
+	k8sClients, err := f.K8sClients()
+	if err != nil {
+		return nil, err
+	}
+	policyManager, err := f.PolicyManager()
+	if err != nil {
+		return nil, err
+	}
+
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
+	discoverer.ValidateHostnames = o.validateHostnames
+	discoverer.ValidateRegexes = o.validateRegexes
 	emptyObjRef := common.ObjRef{}
 	var resourceModel *resourcediscovery.ResourceModel
 	if o.cmdName == commandNameGet && o.forObjRef != emptyObjRef {
@@ -309,17 +660,30 @@ func runGetOrDescribeHTTPRoutes(f cmdutils.Factory, o *getOrDescribeOptions) {
 			os.Exit(1)
 		}
 	} else {
+		// Printing only names does not require the full objects, unless the
+		// filters of the rules are needed or the objects are filtered with an
+		// expression.
+		discoverer.MetadataOnly = o.outputFormat == cmdutils.OutputFormatName && o.filterType == "" && !o.usesRegexFlag && o.where == nil
 		resourceModel, err = discoverer.DiscoverResourcesForHTTPRoute(o.toResourceDiscoveryFilter())
 	}
-	handleErrOrExitWithMsg(err, "failed to discover HTTPRoute resources")
-
-	realClock := clock.RealClock{}
-	httpRoutesPrinter := &printer.HTTPRoutesPrinter{Writer: o.out, Clock: realClock}
-	if o.cmdName == commandNameGet {
-		printer.Print(httpRoutesPrinter, resourceModel, o.outputFormat)
-	} else {
-		httpRoutesPrinter.PrintDescribeView(resourceModel)
+	if err != nil {
+		return nil, err
+	}
+	if o.parentFlag != "" {
+		if err := resourceModel.RestrictHTTPRoutesToParent(o.parentObjRef); err != nil {
+			return nil, err
+		}
+	}
+	if o.filterType != "" {
+		resourceModel.RestrictHTTPRoutesToFilterType(o.filterType)
+	}
+	if o.usesRegexFlag {
+		resourceModel.RestrictHTTPRoutesToRegexMatches()
 	}
+	if o.where != nil {
+		resourceModel.RestrictToWhereExpression("HTTPRoute", o.where, discoverer.Warnings)
+	}
+	return resourceModel, nil
 }
 
 func runGetOrDescribeBackends(f cmdutils.Factory, o *getOrDescribeOptions) {
@@ -328,7 +692,7 @@ func runGetOrDescribeBackends(f cmdutils.Factory, o *getOrDescribeOptions) {
 	policyManager, err := f.PolicyManager()
 	handleErrOrExitWithMsg(err, "")
 
-	discoverer := resourcediscovery.NewDiscoverer(k8sClients, policyManager)
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
 	emptyObjRef := common.ObjRef{}
 	var resourceModel *resourcediscovery.ResourceModel
 	if o.cmdName == commandNameGet && o.forObjRef != emptyObjRef {
@@ -342,25 +706,41 @@ func runGetOrDescribeBackends(f cmdutils.Factory, o *getOrDescribeOptions) {
 			os.Exit(1)
 		}
 	} else {
+		// Printing only names does not require the full objects.
+		discoverer.MetadataOnly = o.outputFormat == cmdutils.OutputFormatName && o.where == nil
 		resourceModel, err = discoverer.DiscoverResourcesForBackend(o.toResourceDiscoveryFilter())
 	}
+	o.handleNotFound(err)
 	handleErrOrExitWithMsg(err, "failed to discover Backend resources")
+	if o.where != nil {
+		resourceModel.RestrictToWhereExpression("Backend", o.where, discoverer.Warnings)
+	}
 
 	realClock := clock.RealClock{}
-	backendsPrinter := &printer.BackendsPrinter{Writer: o.out, Clock: realClock, EventFetcher: discoverer}
-	if o.cmdName == commandNameGet {
-		printer.Print(backendsPrinter, resourceModel, o.outputFormat)
-	} else {
-		backendsPrinter.PrintDescribeView(resourceModel)
+	backendsPrinter := &printer.BackendsPrinter{Writer: o.out, Clock: realClock, EventFetcher: discoverer, EventLimit: o.eventLimitFlag, MaxListItems: o.maxListItemsFlag, LabelColumns: o.labelColumns, AnnotationColumns: o.annotationColumns, Highlighter: o.highlighter, EffectivePolicyKind: o.effectivePolicyKindFlag, ShowDrift: o.showDriftFlag, ShowFieldOwners: o.showFieldOwnersFlag}
+	switch {
+	case o.cmdName == commandNameGet:
+		o.print(backendsPrinter, resourceModel)
+	case o.outputDirFlag != "":
+		o.describeToDir("Backend", resourceModel, func(w io.Writer) printer.DescribePrinter {
+			p := *backendsPrinter
+			p.Writer = w
+			return &p
+		})
+	default:
+		printer.PrintDescribe(backendsPrinter, resourceModel, o.outputFormat)
 	}
 }
 
 func runGetOrDescribePolicies(f cmdutils.Factory, o *getOrDescribeOptions) {
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
 	policyManager, err := f.PolicyManager()
 	handleErrOrExitWithMsg(err, "")
 
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
 	realClock := clock.RealClock{}
-	policiesPrinter := &printer.PoliciesPrinter{Writer: o.out, Clock: realClock}
+	policiesPrinter := &printer.PoliciesPrinter{Writer: o.out, Clock: realClock, TargetFetcher: discoverer, PolicyCRDs: policyManager.GetCRDs(), MaxListItems: o.maxListItemsFlag, AnnotationColumns: o.annotationColumns}
 
 	var policyList []policymanager.Policy
 	emptyObjRef := common.ObjRef{}
@@ -375,15 +755,42 @@ func runGetOrDescribePolicies(f cmdutils.Factory, o *getOrDescribeOptions) {
 		if !found && o.resourceName == "default" {
 			policy, found = policyManager.GetPolicy("/" + o.resourceName)
 		}
-		if found {
-			policyList = []policymanager.Policy{policy}
+		if !found {
+			o.handleNotFound(apierrors.NewNotFound(schema.GroupResource{Resource: "policies"}, o.resourceName))
+		}
+		policyList = []policymanager.Policy{policy}
+	}
+
+	if o.cmdName == commandNameGet && (o.onlyIneffectiveFlag || o.outputFormat == cmdutils.OutputFormatWide) {
+		// Whether the fields of a policy survive depends on the effective
+		// policies of every resource which it may affect, so all resources are
+		// discovered. The wide table still lists the policies when that fails,
+		// with an EFFECTIVE of Unknown, since discovering everything needs more
+		// access than listing the policies.
+		resourceModel, err := discoverer.DiscoverAllResources(resourcediscovery.Filter{Labels: labels.Everything()})
+		switch {
+		case err == nil:
+			policiesPrinter.Effectiveness = resourceModel.PolicyEffectiveness(policyList)
+		case o.onlyIneffectiveFlag:
+			handleErrOrExitWithMsg(err, "failed to discover resources")
+		default:
+			f.Warnings().Add(common.ObjRef{Kind: "Policy"}, fmt.Sprintf("unable to compute the effectiveness of the policies: %v", common.ExplainAuthError(err)))
+		}
+		if o.onlyIneffectiveFlag {
+			var ineffectivePolicies []policymanager.Policy
+			for _, policy := range policyList {
+				if policiesPrinter.Effectiveness[policy.Name()] == policymanager.EffectivenessNone {
+					ineffectivePolicies = append(ineffectivePolicies, policy)
+				}
+			}
+			policyList = ineffectivePolicies
 		}
 	}
 
 	if o.cmdName == commandNameGet {
 		policiesPrinter.PrintPolicies(policyList, o.outputFormat)
 	} else {
-		policiesPrinter.PrintPoliciesDescribeView(policyList)
+		policiesPrinter.PrintPoliciesDescribe(policyList, o.outputFormat)
 	}
 }
 
@@ -392,7 +799,7 @@ func runGetOrDescribePolicyCRDs(f cmdutils.Factory, o *getOrDescribeOptions) {
 	handleErrOrExitWithMsg(err, "")
 
 	realClock := clock.RealClock{}
-	policiesPrinter := &printer.PoliciesPrinter{Writer: o.out, Clock: realClock}
+	policiesPrinter := &printer.PoliciesPrinter{Writer: o.out, Clock: realClock, AnnotationColumns: o.annotationColumns}
 
 	var policyCrdList []policymanager.PolicyCRD
 	if o.resourceName == "" {
@@ -401,8 +808,7 @@ func runGetOrDescribePolicyCRDs(f cmdutils.Factory, o *getOrDescribeOptions) {
 		var found bool
 		policyCrd, found := policyManager.GetCRD(o.resourceName)
 		if !found {
-			fmt.Fprintf(os.Stderr, "failed to find PolicyCrd: %v\n", err)
-			os.Exit(1)
+			o.handleNotFound(apierrors.NewNotFound(schema.GroupResource{Resource: "policycrds"}, o.resourceName))
 		}
 		policyCrdList = []policymanager.PolicyCRD{policyCrd}
 	}
@@ -410,38 +816,107 @@ func runGetOrDescribePolicyCRDs(f cmdutils.Factory, o *getOrDescribeOptions) {
 	if o.cmdName == commandNameGet {
 		policiesPrinter.PrintCRDs(policyCrdList, o.outputFormat)
 	} else {
-		policiesPrinter.PrintPolicyCRDsDescribeView(policyCrdList)
+		policiesPrinter.PrintPolicyCRDsDescribe(policyCrdList, o.outputFormat)
 	}
 }
 
 type getOrDescribeOptions struct {
 	cmdName commandName
 
-	namespaceFlag     string
-	allNamespacesFlag bool
-	labelSelectorFlag string
-	outputFlag        string
-	forFlag           string
+	namespaceFlag           []string
+	allNamespacesFlag       bool
+	labelSelectorFlag       string
+	outputFlag              string
+	forFlag                 string
+	sortByFlag              string
+	groupByFlag             string
+	labelColumnsFlag        []string
+	annotationColumnsFlag   []string
+	validateHostnames       bool
+	validateRegexes         bool
+	sortHostnamesFlag       bool
+	staleFlag               bool
+	effectivePolicyKindFlag string
+	effectiveSubtreeFlag    bool
+	onlyIneffectiveFlag     bool
+	showDriftFlag           bool
+	showFieldOwnersFlag     bool
+	showPrecedenceFlag      bool
+	expiringWithinFlag      string
+	referencedByFlag        bool
+	parentFlag              string
+	controllerFlag          string
+	nameRegexFlag           string
+	conditionsFlag          bool
+	filterTypeFlag          string
+	usesRegexFlag           bool
+	eventLimitFlag          int
+	whereFlag               string
+	addressFlag             string
+	ignoreNotFoundFlag      bool
+	maxListItemsFlag        int
+	withUsageFlag           bool
+	highlightFlag           []string
+	noColorFlag             bool
+	canonicalFlag           bool
+	contextsFlag            []string
+	outputDirFlag           string
+	concurrencyFlag         int
 
 	namespace     string
+	namespaces    []string
+	contexts      []string
 	resourceName  string
 	labelSelector labels.Selector
 	outputFormat  cmdutils.OutputFormat
 	forObjRef     common.ObjRef
+	parentObjRef  common.ObjRef
+	filterType    gatewayv1.HTTPRouteFilterType
+	sortBy        cmdutils.SortKey
+	groupByClass  bool
+	labelColumns  []string
+	// annotationColumns are the columns of --annotation-columns, or of the
+	// configuration file if the flag is not used.
+	annotationColumns []common.AnnotationColumn
+	where             *resourcediscovery.WhereExpression
+	nameRegex         *regexp.Regexp
+	highlighter       *printer.Highlighter
 
 	out io.Writer
 }
 
 func (o *getOrDescribeOptions) parse(args []string) {
-	o.namespace = o.namespaceFlag
-	if o.allNamespacesFlag {
-		o.namespace = metav1.NamespaceAll
-	}
-
 	if len(args) >= 1 {
 		o.resourceName = args[0]
 	}
 
+	// Parse `--namespace` flags. Resources are listed one namespace at a time
+	// when several namespaces are selected.
+	var namespaces []string
+	for _, namespace := range o.namespaceFlag {
+		if namespace == "" {
+			fmt.Fprintf(os.Stderr, "invalid value used in --namespace flag; namespaces must not be empty\n")
+			os.Exit(1)
+		}
+		if !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	switch {
+	case len(namespaces) > 1 && o.allNamespacesFlag:
+		fmt.Fprintf(os.Stderr, "multiple namespaces cannot be used with --all-namespaces\n")
+		os.Exit(1)
+	case len(namespaces) > 1 && o.resourceName != "":
+		fmt.Fprintf(os.Stderr, "multiple namespaces cannot be used with a resource name\n")
+		os.Exit(1)
+	case o.allNamespacesFlag:
+		o.namespace = metav1.NamespaceAll
+	case len(namespaces) > 1:
+		o.namespaces = namespaces
+	case len(namespaces) == 1:
+		o.namespace = namespaces[0]
+	}
+
 	var err error
 	o.labelSelector, err = labels.Parse(o.labelSelectorFlag)
 	if err != nil {
@@ -454,54 +929,338 @@ func (o *getOrDescribeOptions) parse(args []string) {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
+	if o.cmdName == commandNameDescribe && o.outputFormat != cmdutils.OutputFormatTable && o.outputFormat != cmdutils.OutputFormatYAML {
+		fmt.Fprintf(os.Stderr, "output format %q is not supported by describe; must be yaml\n", o.outputFlag)
+		os.Exit(1)
+	}
+
+	if o.eventLimitFlag < 0 {
+		fmt.Fprintf(os.Stderr, "--event-limit must not be negative\n")
+		os.Exit(1)
+	}
+
+	if o.maxListItemsFlag < 0 {
+		fmt.Fprintf(os.Stderr, "--max-list-items must not be negative\n")
+		os.Exit(1)
+	}
+
+	o.sortBy, err = cmdutils.ValidateAndReturnSortKey(o.sortByFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	// Parse `--group-by` flag. Only Gateways can be grouped, by their class.
+	if o.groupByFlag != "" {
+		if o.groupByFlag != "class" {
+			fmt.Fprintf(os.Stderr, "invalid value %q used in --group-by flag; must be one of (class)\n", o.groupByFlag)
+			os.Exit(1)
+		}
+		if o.outputFormat != cmdutils.OutputFormatTable && o.outputFormat != cmdutils.OutputFormatWide {
+			fmt.Fprintf(os.Stderr, "--group-by is only supported for the table and wide output formats\n")
+			os.Exit(1)
+		}
+		o.groupByClass = true
+	}
+
+	// Parse `--label-columns` flag. Columns may optionally be written as
+	// "label:KEY".
+	for _, column := range o.labelColumnsFlag {
+		key := strings.TrimPrefix(column, "label:")
+		if key == "" {
+			fmt.Fprintf(os.Stderr, "invalid value %q used in --label-columns flag; value must be a label key\n", column)
+			os.Exit(1)
+		}
+		o.labelColumns = append(o.labelColumns, key)
+	}
+
+	// Parse `--annotation-columns` flag, which takes precedence over the
+	// columns of the configuration file. A configuration file which cannot be
+	// read only loses its columns, rather than failing every table.
+	if o.outputFormat == cmdutils.OutputFormatTable || o.outputFormat == cmdutils.OutputFormatWide {
+		config, err := common.ReadConfigFile(common.ConfigPath())
+		if err != nil {
+			if o.annotationColumnsFlag == nil {
+				fmt.Fprintf(os.Stderr, "Warning: ignoring the configuration file: %v\n", err)
+			}
+			config = &common.Config{}
+		}
+		o.annotationColumns, err = annotationColumns(o.annotationColumnsFlag, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Parse `--highlight` flags. Highlighting is ignored if the output is not a
+	// terminal, unless --no-color is used to mark the highlighted rows instead.
+	if len(o.highlightFlag) != 0 {
+		if o.outputFormat != cmdutils.OutputFormatTable && o.outputFormat != cmdutils.OutputFormatWide {
+			fmt.Fprintf(os.Stderr, "--highlight is only supported for the table and wide output formats\n")
+			os.Exit(1)
+		}
+		highlighter := &printer.Highlighter{Style: printer.HighlightColor}
+		for _, expression := range o.highlightFlag {
+			predicate, err := resourcediscovery.ParseObjectPredicate(expression)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "invalid value used in --highlight flag: %v\n", err)
+				os.Exit(1)
+			}
+			highlighter.Predicates = append(highlighter.Predicates, predicate)
+		}
+		if o.noColorFlag {
+			highlighter.Style = printer.HighlightMarker
+			o.highlighter = highlighter
+		} else if isTerminal(o.out) {
+			o.highlighter = highlighter
+		}
+	}
 
 	// Parse `--for` flag
 	if o.forFlag != "" {
-		parts := strings.Split(o.forFlag, "/")
-		if len(parts) < 2 || len(parts) > 3 {
-			fmt.Fprintf(os.Stderr, "invalid value used in --for flag; value must be in the format TYPE[/NAMESPACE]/NAME\n")
+		if o.staleFlag {
+			fmt.Fprintf(os.Stderr, "--stale cannot be used with --for\n")
+			os.Exit(1)
+		}
+		o.forObjRef = parseObjRefFlag("for", o.forFlag)
+	}
+
+	if o.withUsageFlag && o.outputFormat != cmdutils.OutputFormatJSON && o.outputFormat != cmdutils.OutputFormatYAML {
+		fmt.Fprintf(os.Stderr, "--with-usage is only supported for the json and yaml output formats\n")
+		os.Exit(1)
+	}
+
+	if o.canonicalFlag {
+		if o.outputFormat != cmdutils.OutputFormatJSON && o.outputFormat != cmdutils.OutputFormatYAML {
+			fmt.Fprintf(os.Stderr, "--canonical is only supported for the json and yaml output formats\n")
+			os.Exit(1)
+		}
+		if o.withUsageFlag {
+			fmt.Fprintf(os.Stderr, "--canonical cannot be used with --with-usage\n")
+			os.Exit(1)
+		}
+	}
+
+	// Parse `--contexts` flag.
+	for _, context := range o.contextsFlag {
+		if context == "" {
+			fmt.Fprintf(os.Stderr, "invalid value used in --contexts flag; contexts must not be empty\n")
+			os.Exit(1)
+		}
+		if !slices.Contains(o.contexts, context) {
+			o.contexts = append(o.contexts, context)
+		}
+	}
+	if len(o.contexts) != 0 {
+		switch {
+		case o.resourceName != "":
+			fmt.Fprintf(os.Stderr, "--contexts cannot be used with a resource name\n")
+			os.Exit(1)
+		case o.forFlag != "":
+			fmt.Fprintf(os.Stderr, "--contexts cannot be used with --for\n")
+			os.Exit(1)
+		case o.groupByClass:
+			fmt.Fprintf(os.Stderr, "--contexts cannot be used with --group-by\n")
+			os.Exit(1)
+		case o.conditionsFlag:
+			fmt.Fprintf(os.Stderr, "--contexts cannot be used with --conditions\n")
+			os.Exit(1)
+		case o.canonicalFlag:
+			fmt.Fprintf(os.Stderr, "--contexts cannot be used with --canonical\n")
 			os.Exit(1)
 		}
-		if len(parts) == 2 {
-			o.forObjRef = common.ObjRef{Kind: parts[0], Namespace: metav1.NamespaceDefault, Name: parts[1]}
-		} else {
-			o.forObjRef = common.ObjRef{Kind: parts[0], Namespace: parts[1], Name: parts[2]}
-		}
-		switch strings.ToLower(o.forObjRef.Kind) {
-		case "gatewayclass", "gateawyclasses":
-			o.forObjRef.Group = gatewayv1.GroupVersion.Group
-			o.forObjRef.Kind = "GatewayClass"
-			o.forObjRef.Namespace = ""
-		case "gateway", "gateways":
-			o.forObjRef.Group = gatewayv1.GroupVersion.Group
-			o.forObjRef.Kind = "Gateway"
-		case "httproute", "httproutes":
-			o.forObjRef.Group = gatewayv1.GroupVersion.Group
-			o.forObjRef.Kind = "HTTPRoute"
-		case "service", "services":
-			o.forObjRef.Kind = "Service"
+		switch o.outputFormat {
+		case cmdutils.OutputFormatTable, cmdutils.OutputFormatWide, cmdutils.OutputFormatJSON, cmdutils.OutputFormatYAML:
 		default:
-			fmt.Fprintf(os.Stderr, "invalid type provided in --for flag; type must be one of [gatewayclass, gateway, httproute, service]\n")
+			fmt.Fprintf(os.Stderr, "--contexts is only supported for the table, wide, json and yaml output formats\n")
+			os.Exit(1)
+		}
+	}
+
+	if o.outputDirFlag != "" && o.concurrencyFlag < 1 {
+		fmt.Fprintf(os.Stderr, "--concurrency must be at least 1\n")
+		os.Exit(1)
+	}
+
+	if o.conditionsFlag && o.outputFormat != cmdutils.OutputFormatTable {
+		fmt.Fprintf(os.Stderr, "--conditions is only supported for the table output format\n")
+		os.Exit(1)
+	}
+
+	// Parse `--parent` flag
+	if o.parentFlag != "" {
+		o.parentObjRef = parseObjRefFlag("parent", o.parentFlag)
+		if o.parentObjRef.Kind != "Gateway" {
+			fmt.Fprintf(os.Stderr, "invalid type provided in --parent flag; type must be gateway\n")
+			os.Exit(1)
+		}
+	}
+
+	if o.filterTypeFlag != "" {
+		o.filterType, err = resourcediscovery.ParseHTTPRouteFilterType(o.filterTypeFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid value used in --filter-type flag: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Parse `--name-regex` flag. The regular expression must match the whole
+	// name.
+	if o.nameRegexFlag != "" {
+		if o.resourceName != "" {
+			fmt.Fprintf(os.Stderr, "--name-regex cannot be used with a resource name\n")
+			os.Exit(1)
+		}
+		o.nameRegex, err = regexp.Compile("^(?:" + o.nameRegexFlag + ")$")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid regular expression used in --name-regex flag: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if o.whereFlag != "" {
+		o.where, err = resourcediscovery.CompileWhereExpression(o.whereFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid expression used in --where flag: %v\n", err)
 			os.Exit(1)
 		}
 	}
 }
 
+// parseObjRefFlag parses the value of a flag in the format
+// TYPE[/NAMESPACE]/NAME. Not specifying a NAMESPACE assumes the 'default'
+// value.
+func parseObjRefFlag(flagName, value string) common.ObjRef {
+	var objRef common.ObjRef
+	parts := strings.Split(value, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		fmt.Fprintf(os.Stderr, "invalid value used in --%v flag; value must be in the format TYPE[/NAMESPACE]/NAME\n", flagName)
+		os.Exit(1)
+	}
+	if len(parts) == 2 {
+		objRef = common.ObjRef{Kind: parts[0], Namespace: metav1.NamespaceDefault, Name: parts[1]}
+	} else {
+		objRef = common.ObjRef{Kind: parts[0], Namespace: parts[1], Name: parts[2]}
+	}
+	rt, ok := lookupResourceType(objRef.Kind)
+	if !ok || !slices.Contains([]string{"GatewayClass", "Gateway", "HTTPRoute", "Service"}, rt.GroupKind.Kind) {
+		fmt.Fprintf(os.Stderr, "invalid type provided in --%v flag; type must be one of [gatewayclass, gateway, httproute, service]\n", flagName)
+		os.Exit(1)
+	}
+	objRef.Group, objRef.Kind = rt.GroupKind.Group, rt.GroupKind.Kind
+	if rt.GroupKind.Kind == "GatewayClass" {
+		objRef.Namespace = ""
+	}
+	return objRef
+}
+
 func (o *getOrDescribeOptions) toResourceDiscoveryFilter() resourcediscovery.Filter {
 	return resourcediscovery.Filter{
-		Name:      o.resourceName,
-		Namespace: o.namespace,
-		Labels:    o.labelSelector,
+		Name:       o.resourceName,
+		Namespace:  o.namespace,
+		Namespaces: o.namespaces,
+		Labels:     o.labelSelector,
+		Stale:      o.staleFlag,
+		Controller: o.controllerFlag,
+		NameRegex:  o.nameRegex,
 	}
 }
 
 func (o *getOrDescribeOptions) forObjRefToResourceDiscoveryFilter() resourcediscovery.Filter {
 	return resourcediscovery.Filter{
-		Name:      o.forObjRef.Name,
-		Namespace: o.forObjRef.Namespace,
+		Name:       o.forObjRef.Name,
+		Namespace:  o.forObjRef.Namespace,
+		Controller: o.controllerFlag,
+	}
+}
+
+// exitCodeNotFound is the exit code when the resource requested by name does
+// not exist.
+const exitCodeNotFound = 3
+
+// handleNotFound exits if err reports that the resource requested by name does
+// not exist: successfully and without output with --ignore-not-found, or with
+// exitCodeNotFound otherwise.
+func (o *getOrDescribeOptions) handleNotFound(err error) {
+	code, ok := notFoundExitCode(err, o.resourceName, o.ignoreNotFoundFlag)
+	if !ok {
+		return
+	}
+	if code == 0 {
+		klog.V(2).InfoS("Ignoring resource which was not found", "name", o.resourceName, "err", err)
+	} else {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	os.Exit(code)
+}
+
+// discoverForContexts runs discover with a factory for the cluster of each of
+// the contexts of the kubeconfig, one cluster at a time. A cluster for which
+// discover fails, like one which cannot be reached, is left out with a
+// warning, so that the other clusters are still listed. A cluster which does
+// not have the resource requested by name is left out without a warning.
+func discoverForContexts(f cmdutils.Factory, contexts []string, discover func(cmdutils.Factory) (*resourcediscovery.ResourceModel, error)) []printer.ClusterResourceModel {
+	var result []printer.ClusterResourceModel
+	for _, context := range contexts {
+		resourceModel, err := discover(f.ForContext(context))
+		if apierrors.IsNotFound(err) {
+			klog.V(2).InfoS("Skipping cluster without the resource", "context", context, "err", err)
+			continue
+		}
+		if err != nil {
+			f.Warnings().Add(common.ObjRef{Kind: "Context", Name: context}, fmt.Sprintf("skipped the cluster: %v", common.ExplainAuthError(err)))
+			continue
+		}
+		result = append(result, printer.ClusterResourceModel{Cluster: context, ResourceModel: resourceModel})
+	}
+	return result
+}
+
+// print prints the resources of the model for `get`, in their canonical form
+// with --canonical.
+func (o *getOrDescribeOptions) print(p printer.Printer, resourceModel *resourcediscovery.ResourceModel) {
+	if o.canonicalFlag {
+		printer.PrintCanonical(p, resourceModel, o.outputFormat)
+		return
+	}
+	printer.Print(p, resourceModel, o.outputFormat)
+}
+
+// describeToDir writes the describe view of each resource of the kind to its
+// own file in the --output-dir directory, and prints how many files were
+// written. The resources which could not be written are listed on stderr.
+func (o *getOrDescribeOptions) describeToDir(kind string, resourceModel *resourcediscovery.ResourceModel, newPrinter func(io.Writer) printer.DescribePrinter) {
+	written, errs := printer.DescribeToDir(newPrinter, kind, resourceModel, o.outputFormat, o.outputDirFlag, o.concurrencyFlag)
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	fmt.Fprintf(o.out, "wrote %d files, %d errors\n", written, len(errs))
+	if len(errs) != 0 {
+		os.Exit(1)
 	}
 }
 
+// notFoundExitCode returns the exit code for err, and whether err reports that
+// the resource requested by name does not exist. Resources which are listed
+// instead of requested by name are never reported as not found.
+func notFoundExitCode(err error, resourceName string, ignoreNotFound bool) (int, bool) {
+	if resourceName == "" || !apierrors.IsNotFound(err) {
+		return 0, false
+	}
+	if ignoreNotFound {
+		return 0, true
+	}
+	return exitCodeNotFound, true
+}
+
+// newDiscoverer returns a Discoverer for the clients and the PolicyManager,
+// which adds the warnings it finds to those of the command.
+func newDiscoverer(f cmdutils.Factory, k8sClients *common.K8sClients, policyManager *policymanager.PolicyManager) resourcediscovery.Discoverer {
+	discoverer := resourcediscovery.NewDiscoverer(k8sClients, policyManager)
+	discoverer.Warnings = f.Warnings()
+	return discoverer
+}
+
 func handleErrOrExitWithMsg(err error, msg string) {
 	if err == nil {
 		return
@@ -510,7 +1269,33 @@ func handleErrOrExitWithMsg(err error, msg string) {
 	if msg != "" {
 		str = msg + ": "
 	}
-	str += err.Error()
+	str += common.ExplainAuthError(err).Error()
 	fmt.Fprintf(os.Stderr, "Error: %s\n", str)
 	os.Exit(1)
 }
+
+// isTerminal returns true if the writer is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// annotationColumns returns the columns of the --annotation-columns flag,
+// written as NAME=ANNOTATION, or those of the configuration file if the flag is
+// not used. Using the flag with an empty value disables the columns of the
+// configuration file.
+func annotationColumns(annotationColumnsFlag []string, config *common.Config) ([]common.AnnotationColumn, error) {
+	// The flag is nil unless it is used, even with an empty value.
+	if annotationColumnsFlag == nil {
+		return config.AnnotationColumns, nil
+	}
+	var result []common.AnnotationColumn
+	for _, column := range annotationColumnsFlag {
+		name, annotation, ok := strings.Cut(column, "=")
+		if !ok || name == "" || annotation == "" {
+			return nil, fmt.Errorf("invalid value %q used in --annotation-columns flag; value must be NAME=ANNOTATION", column)
+		}
+		result = append(result, common.AnnotationColumn{Name: name, Annotation: annotation})
+	}
+	return result, nil
+}
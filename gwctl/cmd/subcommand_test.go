@@ -0,0 +1,392 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestGetSubCommands_IgnoreNotFoundFlag(t *testing.T) {
+	getCmd := NewSubCommand(nil, io.Discard, commandNameGet)
+	for _, subCmd := range getCmd.Commands() {
+		if subCmd.Flags().Lookup("ignore-not-found") == nil {
+			t.Errorf("get %v does not have the --ignore-not-found flag", subCmd.Name())
+		}
+	}
+}
+
+func TestNotFoundExitCode(t *testing.T) {
+	notFoundErr := apierrors.NewNotFound(schema.GroupResource{Group: "gateway.networking.k8s.io", Resource: "httproutes"}, "maybe-exists")
+
+	testcases := []struct {
+		name           string
+		err            error
+		resourceName   string
+		ignoreNotFound bool
+		wantCode       int
+		wantOK         bool
+	}{
+		{
+			name:         "named resource not found",
+			err:          notFoundErr,
+			resourceName: "maybe-exists",
+			wantCode:     exitCodeNotFound,
+			wantOK:       true,
+		},
+		{
+			name:           "named resource not found is ignored",
+			err:            notFoundErr,
+			resourceName:   "maybe-exists",
+			ignoreNotFound: true,
+			wantCode:       0,
+			wantOK:         true,
+		},
+		{
+			name: "listed resources are never not found",
+			err:  notFoundErr,
+		},
+		{
+			name:           "other errors are not ignored",
+			err:            errors.New("connection refused"),
+			resourceName:   "maybe-exists",
+			ignoreNotFound: true,
+		},
+		{
+			name:         "no error",
+			resourceName: "maybe-exists",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotCode, gotOK := notFoundExitCode(tc.err, tc.resourceName, tc.ignoreNotFound)
+			if gotCode != tc.wantCode || gotOK != tc.wantOK {
+				t.Errorf("notFoundExitCode() = (%v, %v), want (%v, %v)", gotCode, gotOK, tc.wantCode, tc.wantOK)
+			}
+		})
+	}
+}
+
+// TestGetSubCommands_NotFound runs get for resources which do not exist. Since
+// gwctl exits when a named resource is not found, each command is run by a
+// subprocess of the test, which gets the arguments of the command from the
+// GWCTL_TEST_GET_ARGS environment variable.
+func TestGetSubCommands_NotFound(t *testing.T) {
+	if args := os.Getenv("GWCTL_TEST_GET_ARGS"); args != "" {
+		f := &fakeFactory{k8sClients: common.MustClientsForTest(t, common.NamespaceForTest("default"))}
+		getCmd := NewSubCommand(f, os.Stdout, commandNameGet)
+		getCmd.SetArgs(strings.Fields(args))
+		if err := getCmd.Execute(); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	testcases := []struct {
+		args     string
+		wantCode int
+	}{
+		{args: "httproutes maybe-exists", wantCode: exitCodeNotFound},
+		{args: "httproutes maybe-exists --ignore-not-found", wantCode: 0},
+		{args: "gateways maybe-exists", wantCode: exitCodeNotFound},
+		{args: "gateways maybe-exists --ignore-not-found", wantCode: 0},
+		{args: "listeners maybe-exists", wantCode: exitCodeNotFound},
+		{args: "listeners maybe-exists --ignore-not-found", wantCode: 0},
+		{args: "certificates maybe-exists", wantCode: exitCodeNotFound},
+		{args: "certificates maybe-exists --ignore-not-found", wantCode: 0},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.args, func(t *testing.T) {
+			cmd := exec.Command(os.Args[0], "-test.run=^TestGetSubCommands_NotFound$")
+			cmd.Env = append(os.Environ(), "GWCTL_TEST_GET_ARGS="+tc.args)
+			stdout := &bytes.Buffer{}
+			cmd.Stdout = stdout
+
+			gotCode := 0
+			if err := cmd.Run(); err != nil {
+				var exitErr *exec.ExitError
+				if !errors.As(err, &exitErr) {
+					t.Fatalf("Failed to run get %v: %v", tc.args, err)
+				}
+				gotCode = exitErr.ExitCode()
+			}
+			if gotCode != tc.wantCode {
+				t.Errorf("get %v exited with %v, want %v", tc.args, gotCode, tc.wantCode)
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("get %v printed %q, want nothing", tc.args, stdout.String())
+			}
+		})
+	}
+}
+
+func TestGetListenersAndCertificates(t *testing.T) {
+	// A configuration file which cannot be read does not fail the tables.
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("annotationColumns: {"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(common.ConfigPathEnv, configPath)
+
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-gatewayclass"},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: "example.net/gateway-controller"},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-gateway", Namespace: "default", Annotations: map[string]string{"example.com/owner": "team-a"}},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+					{
+						Name:     "https",
+						Protocol: gatewayv1.HTTPSProtocolType,
+						Port:     443,
+						Hostname: common.PtrTo(gatewayv1.Hostname("example.com")),
+						TLS: &gatewayv1.GatewayTLSConfig{
+							CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "missing-cert"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	f := &fakeFactory{k8sClients: common.MustClientsForTest(t, objects...)}
+
+	testcases := []struct {
+		args []string
+		want string
+	}{
+		{
+			args: []string{"listeners", "foo-gateway"},
+			want: `
+GATEWAY              LISTENER NAME  PORT  PROTOCOL  HOSTNAME     ATTACHED ROUTES  PROGRAMMED
+default/foo-gateway  http           80    HTTP      *            0                Unknown
+default/foo-gateway  https          443   HTTPS     example.com  0                Unknown
+`,
+		},
+		{
+			args: []string{"certificates", "foo-gateway"},
+			want: `
+GATEWAY              LISTENER  SECRET                NOT AFTER  DAYS LEFT  ISSUER   ERROR
+default/foo-gateway  https     default/missing-cert  Unknown    Unknown    Unknown  failed to get Secret: secrets "missing-cert" not found
+`,
+		},
+		{
+			args: []string{"listeners", "foo-gateway", "--annotation-columns=owner=example.com/owner"},
+			want: `
+GATEWAY              LISTENER NAME  PORT  PROTOCOL  HOSTNAME     ATTACHED ROUTES  PROGRAMMED  OWNER
+default/foo-gateway  http           80    HTTP      *            0                Unknown     team-a
+default/foo-gateway  https          443   HTTPS     example.com  0                Unknown     team-a
+`,
+		},
+		{
+			args: []string{"certificates", "foo-gateway", "--annotation-columns=owner=example.com/owner"},
+			want: `
+GATEWAY              LISTENER  SECRET                NOT AFTER  DAYS LEFT  ISSUER   ERROR                                                   OWNER
+default/foo-gateway  https     default/missing-cert  Unknown    Unknown    Unknown  failed to get Secret: secrets "missing-cert" not found  team-a
+`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(strings.Join(tc.args, " "), func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			getCmd := NewSubCommand(f, buff, commandNameGet)
+			getCmd.SetArgs(tc.args)
+			if err := getCmd.Execute(); err != nil {
+				t.Fatalf("Failed to run get %v: %v", tc.args, err)
+			}
+			if diff := cmp.Diff(common.YamlString(tc.want), common.YamlString(buff.String()), common.YamlStringTransformer); diff != "" {
+				t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", buff.String(), tc.want, diff)
+			}
+		})
+	}
+}
+
+// fleetFactory is a fakeFactory which remembers the context it is for.
+type fleetFactory struct {
+	*fakeFactory
+	context string
+}
+
+func (f *fleetFactory) ForContext(context string) cmdutils.Factory {
+	return &fleetFactory{fakeFactory: f.fakeFactory, context: context}
+}
+
+func TestDiscoverForContexts(t *testing.T) {
+	f := &fleetFactory{fakeFactory: &fakeFactory{}}
+	notFoundErr := apierrors.NewNotFound(schema.GroupResource{Group: "gateway.networking.k8s.io", Resource: "gateways"}, "maybe-exists")
+	errs := map[string]error{
+		"unreachable": errors.New("dial tcp 10.0.0.1:443: i/o timeout"),
+		"not-found":   notFoundErr,
+	}
+
+	got := discoverForContexts(f, []string{"prod", "unreachable", "not-found", "staging"}, func(f cmdutils.Factory) (*resourcediscovery.ResourceModel, error) {
+		if err := errs[f.(*fleetFactory).context]; err != nil {
+			return nil, err
+		}
+		return &resourcediscovery.ResourceModel{}, nil
+	})
+
+	var gotClusters []string
+	for _, clusterModel := range got {
+		gotClusters = append(gotClusters, clusterModel.Cluster)
+	}
+	if diff := cmp.Diff([]string{"prod", "staging"}, gotClusters); diff != "" {
+		t.Errorf("discoverForContexts() returned unexpected clusters (-want +got):\n%v", diff)
+	}
+	wantWarnings := []common.Warning{{
+		Resource: common.ObjRef{Kind: "Context", Name: "unreachable"},
+		Message:  "skipped the cluster: dial tcp 10.0.0.1:443: i/o timeout",
+	}}
+	if diff := cmp.Diff(wantWarnings, f.Warnings().List()); diff != "" {
+		t.Errorf("discoverForContexts() added unexpected warnings (-want +got):\n%v", diff)
+	}
+}
+
+func TestReportWarnings(t *testing.T) {
+	warnings := &common.Warnings{}
+	if got := reportWarnings(io.Discard, warnings.List()); got != 0 {
+		t.Errorf("reportWarnings() = %v without warnings, want 0", got)
+	}
+
+	httpRoute := common.ObjRef{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: "default", Name: "foo-httproute"}
+	warnings.Add(httpRoute, `HTTPRoute "default/foo-httproute" references a non-existent Gateway "default/foo-gateway"`)
+	warnings.Add(common.ObjRef{Kind: "Gateway", Namespace: "default", Name: "bar-gateway"}, "some problem")
+	// Warnings found by several discoveries are only reported once.
+	warnings.Add(httpRoute, `HTTPRoute "default/foo-httproute" references a non-existent Gateway "default/foo-gateway"`)
+
+	buff := &bytes.Buffer{}
+	if got := reportWarnings(buff, warnings.List()); got != exitCodeWarnings {
+		t.Errorf("reportWarnings() = %v, want %v", got, exitCodeWarnings)
+	}
+	want := `Warning: Gateway default/bar-gateway: some problem
+Warning: HTTPRoute default/foo-httproute: HTTPRoute "default/foo-httproute" references a non-existent Gateway "default/foo-gateway"
+Error: found 2 warnings, which are treated as errors with --strict
+`
+	if diff := cmp.Diff(want, buff.String()); diff != "" {
+		t.Errorf("Unexpected output (-want +got):\n%v", diff)
+	}
+}
+
+func TestPrintImpersonationNotice(t *testing.T) {
+	testcases := []struct {
+		name        string
+		impersonate rest.ImpersonationConfig
+		want        string
+	}{
+		{
+			name: "not impersonating",
+			want: "",
+		},
+		{
+			name:        "user",
+			impersonate: rest.ImpersonationConfig{UserName: "system:serviceaccount:infra:deployer"},
+			want:        "Notice: acting as user \"system:serviceaccount:infra:deployer\". Changes are recorded in the audit log under this identity.\n",
+		},
+		{
+			name:        "user and groups",
+			impersonate: rest.ImpersonationConfig{UserName: "jane", Groups: []string{"sre", "oncall"}},
+			want:        "Notice: acting as user \"jane\" in groups [\"sre\" \"oncall\"]. Changes are recorded in the audit log under this identity.\n",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			printImpersonationNotice(buff, tc.impersonate)
+			if diff := cmp.Diff(tc.want, buff.String()); diff != "" {
+				t.Errorf("Unexpected output (-want +got):\n%v", diff)
+			}
+		})
+	}
+
+	// Only the commands which change resources print the notice.
+	rootCmd := newRootCmd(nil)
+	for _, subCmd := range rootCmd.Commands() {
+		want := subCmd.Name() == "label" || subCmd.Name() == "annotate"
+		if got := subCmd.Annotations[annotationChangesResources] == "true"; got != want {
+			t.Errorf("%v changes resources = %v, want %v", subCmd.Name(), got, want)
+		}
+	}
+}
+
+func TestAnnotationColumns(t *testing.T) {
+	config := &common.Config{AnnotationColumns: []common.AnnotationColumn{
+		{Name: "owner", Annotation: "example.com/owner"},
+	}}
+
+	testCases := []struct {
+		name    string
+		flag    []string
+		want    []common.AnnotationColumn
+		wantErr bool
+	}{
+		{
+			name: "configuration file is used without the flag",
+			want: []common.AnnotationColumn{{Name: "owner", Annotation: "example.com/owner"}},
+		},
+		{
+			name: "flag overrides the configuration file",
+			flag: []string{"ticket=example.com/ticket", "owner=team.example.com/owner"},
+			want: []common.AnnotationColumn{
+				{Name: "ticket", Annotation: "example.com/ticket"},
+				{Name: "owner", Annotation: "team.example.com/owner"},
+			},
+		},
+		{
+			name: "empty flag disables the configuration file",
+			flag: []string{},
+		},
+		{
+			name:    "flag without annotation",
+			flag:    []string{"owner"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := annotationColumns(tc.flag, config)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("annotationColumns(...) returned err=%v; wantErr=%v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("annotationColumns(...) returned unexpected diff (-want, +got):\n%v", diff)
+			}
+		})
+	}
+}
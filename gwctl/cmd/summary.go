@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	cmdutils "sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+type summaryOptions struct {
+	groupByLabelFlag     string
+	groupByNamespaceFlag bool
+	outputFlag           string
+}
+
+func NewSummaryCommand(f cmdutils.Factory, out io.Writer) *cobra.Command {
+	o := &summaryOptions{}
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "Show the number of Gateways, HTTPRoutes, Backends and Policies per label value or namespace",
+		Args:  cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			runSummary(f, out, o)
+		},
+	}
+	cmd.Flags().StringVar(&o.groupByLabelFlag, "group-by-label", "", "Group resources by the value of this label key. Resources without the label are grouped under (none)")
+	cmd.Flags().BoolVar(&o.groupByNamespaceFlag, "group-by-namespace", false, "Group resources by their namespace")
+	addOutputFormatFlag(&o.outputFlag, cmd)
+	return cmd
+}
+
+func runSummary(f cmdutils.Factory, out io.Writer, o *summaryOptions) {
+	outputFormat, err := cmdutils.ValidateAndReturnOutputFormat(o.outputFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	var groupBy resourcediscovery.SummaryGroupByFunc
+	var groupByName string
+	switch {
+	case o.groupByLabelFlag != "" && o.groupByNamespaceFlag:
+		fmt.Fprintf(os.Stderr, "only one of --group-by-label and --group-by-namespace can be specified\n")
+		os.Exit(1)
+	case o.groupByLabelFlag != "":
+		groupBy, groupByName = resourcediscovery.GroupByLabel(o.groupByLabelFlag), o.groupByLabelFlag
+	case o.groupByNamespaceFlag:
+		groupBy, groupByName = resourcediscovery.GroupByNamespace, "namespace"
+	default:
+		fmt.Fprintf(os.Stderr, "one of --group-by-label or --group-by-namespace must be specified\n")
+		os.Exit(1)
+	}
+
+	k8sClients, err := f.K8sClients()
+	handleErrOrExitWithMsg(err, "")
+	policyManager, err := f.PolicyManager()
+	handleErrOrExitWithMsg(err, "")
+
+	progress := f.Progress()
+	discoverer := newDiscoverer(f, k8sClients, policyManager)
+	discoverer.Progress = progress
+	resourceModel, err := discoverer.DiscoverAllResources(resourcediscovery.Filter{Labels: labels.Everything()})
+	progress.Done()
+	handleErrOrExitWithMsg(err, "failed to discover resources")
+
+	summaryPrinter := &printer.SummaryPrinter{Writer: out}
+	summaryPrinter.Print(resourcediscovery.Summarize(resourceModel, groupBy), groupByName, outputFormat)
+}
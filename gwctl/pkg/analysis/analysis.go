@@ -0,0 +1,316 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package analysis runs the checks of `gwctl analyze` on resources discovered
+// by the resourcediscovery package. It does not depend on the CLI, so the same
+// checks can be run by other programs, like admission webhooks.
+//
+// Each check has a stable ID and a severity, and analyzes the resources it
+// applies to. Checks can be enabled or disabled by their ID. The messages of
+// checks are rendered from the templates of the message catalog, so tools can
+// key off the IDs and parameters of messages rather than their wording.
+package analysis
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+)
+
+// Severity is how serious the problems found by a check are.
+type Severity string
+
+const (
+	// SeverityError is for problems which break traffic.
+	SeverityError Severity = "Error"
+	// SeverityWarning is for problems which likely break traffic, or are likely
+	// misconfigurations.
+	SeverityWarning Severity = "Warning"
+	// SeverityInfo is for configurations which deserve visibility, but may well
+	// be intentional.
+	SeverityInfo Severity = "Info"
+)
+
+// Finding is a problem found by a check with a resource. Message is rendered
+// from the template with MessageID and the Params.
+type Finding struct {
+	CheckID   string            `json:"checkID"`
+	Severity  Severity          `json:"severity"`
+	Resource  common.ObjRef     `json:"resource"`
+	Message   string            `json:"message"`
+	MessageID string            `json:"messageID"`
+	Params    map[string]string `json:"params,omitempty"`
+	// Source is the file which the resource was read from, if it was.
+	Source *common.ObjectSource `json:"source,omitempty"`
+}
+
+// Model holds the resources to analyze.
+type Model struct {
+	// DryRuns describe the HTTPRoutes to analyze.
+	DryRuns []*resourcediscovery.HTTPRouteDryRun
+	// ServedHostnames are the hostnames served by the Gateways of the cluster.
+	// Every Gateway serving some hostname is analyzed.
+	ServedHostnames resourcediscovery.ServedHostnames
+	// ReferenceGrantCoverage describes the references which the ReferenceGrants
+	// of the cluster authorize. Every ReferenceGrant is analyzed.
+	ReferenceGrantCoverage []resourcediscovery.ReferenceGrantCoverage
+	// IngressHostnameCollisions are the hosts of Ingresses which overlap a
+	// hostname served by some HTTPRoute. Every Ingress with a collision is
+	// analyzed. They are only discovered if asked for, since not all clusters
+	// serve Ingresses.
+	IngressHostnameCollisions []resourcediscovery.IngressHostnameCollision
+	// ListenerHostnames are the hostnames of the listeners of the cluster which
+	// allow HTTPRoutes, against which the hostnames of HTTPRoutes are matched.
+	// HTTPRoutes are not matched against listeners if it is nil.
+	ListenerHostnames resourcediscovery.ListenerHostnames
+	// Certificates are the certificates referenced by the listeners of the
+	// Gateways of the cluster. Every Gateway referencing some certificate is
+	// analyzed.
+	Certificates []resourcediscovery.CertificateView
+	// Sources are the sources of the resources which were read from files.
+	Sources common.ObjectSources
+}
+
+// Options configure which checks run.
+type Options struct {
+	// Enable lists the IDs of the checks to run. All checks run if it is empty.
+	Enable []string
+	// Disable lists the IDs of the checks which do not run.
+	Disable []string
+	// Clock is used by checks which depend on the current time. It defaults to
+	// the real clock.
+	Clock clock.PassiveClock
+	// CertificateExpiryThreshold is how soon certificates must expire to be
+	// reported. It defaults to DefaultCertificateExpiryThreshold.
+	CertificateExpiryThreshold time.Duration
+}
+
+// DefaultCertificateExpiryThreshold is the default of
+// Options.CertificateExpiryThreshold.
+const DefaultCertificateExpiryThreshold = 30 * 24 * time.Hour
+
+// Check finds problems with a single resource.
+type Check struct {
+	// ID identifies the check. It never changes once the check is released.
+	ID          string
+	Severity    Severity
+	Description string
+	// AppliesTo returns true if the check analyzes the resource.
+	AppliesTo func(resource common.ObjRef) bool
+	// Messages are the templates of the messages returned by Analyze.
+	Messages []MessageTemplate
+	// Analyze returns a message for each problem found with the resource.
+	Analyze func(model *Model, resource common.ObjRef, opts Options) []Message
+}
+
+var (
+	checksMu sync.RWMutex
+	// checks maps the ID of each registered check to the check.
+	checks = make(map[string]Check)
+)
+
+// Register registers the check and its message templates, so it is run by
+// Run. It panics if a check with the same ID or one of its templates is
+// already registered.
+func Register(check Check) {
+	checksMu.Lock()
+	defer checksMu.Unlock()
+	if _, ok := checks[check.ID]; ok {
+		panic(fmt.Sprintf("analysis check %q is already registered", check.ID))
+	}
+	registerMessageTemplates(check)
+	checks[check.ID] = check
+}
+
+// Checks returns all registered checks, sorted by their ID.
+func Checks() []Check {
+	checksMu.RLock()
+	defer checksMu.RUnlock()
+	result := make([]Check, 0, len(checks))
+	for _, check := range checks {
+		result = append(result, check)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// LookupCheck returns the registered check with the ID.
+func LookupCheck(id string) (Check, bool) {
+	checksMu.RLock()
+	defer checksMu.RUnlock()
+	check, ok := checks[id]
+	return check, ok
+}
+
+// Run runs the enabled checks on every resource of the model which they apply
+// to. The findings are ordered by resource, in the order of Resources, and then
+// by the ID of the check.
+func Run(model *Model, opts Options) []Finding {
+	if opts.Clock == nil {
+		opts.Clock = clock.RealClock{}
+	}
+	if opts.CertificateExpiryThreshold == 0 {
+		opts.CertificateExpiryThreshold = DefaultCertificateExpiryThreshold
+	}
+	var enabledChecks []Check
+	for _, check := range Checks() {
+		if len(opts.Enable) != 0 && !slices.Contains(opts.Enable, check.ID) {
+			continue
+		}
+		if slices.Contains(opts.Disable, check.ID) {
+			continue
+		}
+		enabledChecks = append(enabledChecks, check)
+	}
+
+	var result []Finding
+	for _, resource := range model.Resources() {
+		for _, check := range enabledChecks {
+			if !check.AppliesTo(resource) {
+				continue
+			}
+			for _, message := range check.Analyze(model, resource, opts) {
+				result = append(result, Finding{
+					CheckID:   check.ID,
+					Severity:  check.Severity,
+					Resource:  resource,
+					Message:   message.String(),
+					MessageID: message.ID,
+					Params:    message.Params,
+					Source:    model.Sources.Lookup(resource),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// Resources returns the resources of the model: the HTTPRoutes of the dry runs
+// in their order, followed by the Gateways serving some hostname, referenced
+// by the HTTPRoutes or referencing some certificate, sorted by their namespaced name, then the ReferenceGrants
+// in the order of their coverage, and then the Ingresses in the order of their
+// collisions.
+func (m *Model) Resources() []common.ObjRef {
+	var result []common.ObjRef
+	for _, dryRun := range m.DryRuns {
+		result = append(result, httpRouteRef(dryRun))
+	}
+
+	var gateways []common.ObjRef
+	for _, listeners := range m.ServedHostnames {
+		for _, listener := range listeners {
+			gateway := gatewayRef(listener)
+			if !slices.Contains(gateways, gateway) {
+				gateways = append(gateways, gateway)
+			}
+		}
+	}
+	for _, dryRun := range m.DryRuns {
+		for _, gatewayNode := range dryRun.HTTPRouteNode.Gateways {
+			gateway := common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Name: gatewayNode.Gateway.GetName(), Namespace: gatewayNode.Gateway.GetNamespace()}
+			if !slices.Contains(gateways, gateway) {
+				gateways = append(gateways, gateway)
+			}
+		}
+	}
+	for _, certificate := range m.Certificates {
+		if !slices.Contains(gateways, certificate.Gateway) {
+			gateways = append(gateways, certificate.Gateway)
+		}
+	}
+	sort.Slice(gateways, func(i, j int) bool {
+		if gateways[i].Namespace != gateways[j].Namespace {
+			return gateways[i].Namespace < gateways[j].Namespace
+		}
+		return gateways[i].Name < gateways[j].Name
+	})
+	result = append(result, gateways...)
+	for _, coverage := range m.ReferenceGrantCoverage {
+		result = append(result, coverage.ReferenceGrant)
+	}
+	for _, collision := range m.IngressHostnameCollisions {
+		if ingress := ingressRef(collision.Ingress); !slices.Contains(result, ingress) {
+			result = append(result, ingress)
+		}
+	}
+	return result
+}
+
+// dryRunFor returns the dry run of the HTTPRoute, or nil if the HTTPRoute is
+// not part of the model.
+func (m *Model) dryRunFor(resource common.ObjRef) *resourcediscovery.HTTPRouteDryRun {
+	for _, dryRun := range m.DryRuns {
+		if httpRouteRef(dryRun) == resource {
+			return dryRun
+		}
+	}
+	return nil
+}
+
+// gatewayNodeFor returns the node of the Gateway if it is referenced by the
+// HTTPRoute of some dry run, or nil otherwise.
+func (m *Model) gatewayNodeFor(resource common.ObjRef) *resourcediscovery.GatewayNode {
+	for _, dryRun := range m.DryRuns {
+		for _, gatewayNode := range dryRun.HTTPRouteNode.Gateways {
+			if gatewayNode.Gateway.GetNamespace() == resource.Namespace && gatewayNode.Gateway.GetName() == resource.Name {
+				return gatewayNode
+			}
+		}
+	}
+	return nil
+}
+
+// referenceGrantCoverageFor returns the coverage of the ReferenceGrant, if it
+// is part of the model.
+func (m *Model) referenceGrantCoverageFor(resource common.ObjRef) (resourcediscovery.ReferenceGrantCoverage, bool) {
+	for _, coverage := range m.ReferenceGrantCoverage {
+		if coverage.ReferenceGrant == resource {
+			return coverage, true
+		}
+	}
+	return resourcediscovery.ReferenceGrantCoverage{}, false
+}
+
+func httpRouteRef(dryRun *resourcediscovery.HTTPRouteDryRun) common.ObjRef {
+	httpRoute := dryRun.HTTPRouteNode.HTTPRoute
+	return common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Name: httpRoute.GetName(), Namespace: httpRoute.GetNamespace()}
+}
+
+func gatewayRef(listener resourcediscovery.GatewayListener) common.ObjRef {
+	return common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Name: listener.Gateway.Name, Namespace: listener.Gateway.Namespace}
+}
+
+func ingressRef(ingress types.NamespacedName) common.ObjRef {
+	return common.ObjRef{Group: networkingv1.GroupName, Kind: "Ingress", Name: ingress.Name, Namespace: ingress.Namespace}
+}
+
+func isKind(kind string) func(common.ObjRef) bool {
+	return func(resource common.ObjRef) bool {
+		return resource.Group == gatewayv1.GroupName && resource.Kind == kind
+	}
+}
@@ -0,0 +1,691 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+)
+
+func modelForTest() *Model {
+	httpRouteNode := resourcediscovery.NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "new-route",
+			Namespace: "default",
+		},
+	})
+	httpRouteNode.Errors = append(httpRouteNode.Errors, resourcediscovery.ReferenceToNonExistentResourceError{ReferenceFromTo: resourcediscovery.ReferenceFromTo{
+		ReferringObject: common.ObjRef{Kind: "HTTPRoute", Name: "new-route", Namespace: "default"},
+		ReferredObject:  common.ObjRef{Kind: "Service", Name: "missing-svc", Namespace: "default"},
+	}})
+	listenerA := resourcediscovery.GatewayListener{Gateway: types.NamespacedName{Namespace: "team-a", Name: "gateway-a"}, Listener: "https"}
+	listenerB := resourcediscovery.GatewayListener{Gateway: types.NamespacedName{Namespace: "team-b", Name: "gateway-b"}, Listener: "https"}
+	return &Model{
+		DryRuns: []*resourcediscovery.HTTPRouteDryRun{{
+			HTTPRouteNode: httpRouteNode,
+			Attachments: []resourcediscovery.ListenerAttachment{
+				{Gateway: listenerA.Gateway, Listener: "https", Hostnames: []string{"api.example.com"}},
+				{Gateway: types.NamespacedName{Namespace: "default", Name: "missing-gw"}, Reason: "Gateway does not exist"},
+			},
+		}},
+		ServedHostnames: resourcediscovery.ServedHostnames{
+			{Hostname: "api.example.com", Port: 443}: {listenerA},
+			{Hostname: "*.example.com", Port: 443}:   {listenerB},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	httpRoute := common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Name: "new-route", Namespace: "default"}
+	gatewayB := common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Name: "gateway-b", Namespace: "team-b"}
+	allFindings := []Finding{
+		{
+			CheckID:   CheckHTTPRouteError,
+			Severity:  SeverityError,
+			Resource:  httpRoute,
+			Message:   `HTTPRoute "default/new-route" references a non-existent Service "default/missing-svc"`,
+			MessageID: CheckHTTPRouteError,
+			Params:    map[string]string{"error": `HTTPRoute "default/new-route" references a non-existent Service "default/missing-svc"`},
+		},
+		{
+			CheckID:   CheckHTTPRouteNotAttached,
+			Severity:  SeverityWarning,
+			Resource:  httpRoute,
+			Message:   "HTTPRoute does not attach to Gateway default/missing-gw: Gateway does not exist",
+			MessageID: "httproute-not-attached.gateway",
+			Params:    map[string]string{"gateway": "default/missing-gw", "reason": "Gateway does not exist"},
+		},
+		{
+			CheckID:   CheckWildcardHostnameOverlap,
+			Severity:  SeverityInfo,
+			Resource:  gatewayB,
+			Message:   "wildcard hostname *.example.com matches hostname api.example.com served on port 443 by team-a/gateway-a/https",
+			MessageID: CheckWildcardHostnameOverlap,
+			Params:    map[string]string{"wildcard": "*.example.com", "hostname": "api.example.com", "listeners": "team-a/gateway-a/https", "port": "443"},
+		},
+	}
+
+	testcases := []struct {
+		name string
+		opts Options
+		want []Finding
+	}{
+		{
+			name: "all checks",
+			want: allFindings,
+		},
+		{
+			name: "enabled checks",
+			opts: Options{Enable: []string{CheckHTTPRouteError, CheckWildcardHostnameOverlap}},
+			want: []Finding{allFindings[0], allFindings[2]},
+		},
+		{
+			name: "disabled checks",
+			opts: Options{Disable: []string{CheckHTTPRouteError}},
+			want: allFindings[1:],
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Run(modelForTest(), tc.opts)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Run() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestFinding_JSON(t *testing.T) {
+	finding := Finding{
+		CheckID:   CheckDuplicateHostname,
+		Severity:  SeverityWarning,
+		Resource:  common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Name: "gateway-a", Namespace: "team-a"},
+		Message:   "hostname api.example.com is also served by team-b/gateway-b/https",
+		MessageID: CheckDuplicateHostname,
+		Params:    map[string]string{"hostname": "api.example.com", "listeners": "team-b/gateway-b/https"},
+	}
+	got, err := json.Marshal(finding)
+	if err != nil {
+		t.Fatalf("json.Marshal() returned an unexpected error: %v", err)
+	}
+	want := `{"checkID":"duplicate-hostname","severity":"Warning","resource":{"Group":"gateway.networking.k8s.io","Kind":"Gateway","Name":"gateway-a","Namespace":"team-a"},"message":"hostname api.example.com is also served by team-b/gateway-b/https","messageID":"duplicate-hostname","params":{"hostname":"api.example.com","listeners":"team-b/gateway-b/https"}}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("json.Marshal() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
+
+func TestRegister_DuplicateID(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register() did not panic for a check with a duplicate ID")
+		}
+	}()
+	Register(Check{ID: CheckHTTPRouteError})
+}
+
+func TestRun_UnadvertisedFeatures(t *testing.T) {
+	gatewayClassNode := resourcediscovery.NewGatewayClassNode(&gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-gatewayclass"},
+		Status: gatewayv1.GatewayClassStatus{
+			SupportedFeatures: []gatewayv1.SupportedFeature{"HTTPRouteMethodMatching"},
+		},
+	})
+	gatewayNode := resourcediscovery.NewGatewayNode(&gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-gateway", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "foo-gatewayclass",
+			Listeners:        []gatewayv1.Listener{{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 8080}},
+		},
+	})
+	gatewayNode.GatewayClass = gatewayClassNode
+	httpRouteNode := resourcediscovery.NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{{
+					Method:      common.PtrTo(gatewayv1.HTTPMethodGet),
+					QueryParams: []gatewayv1.HTTPQueryParamMatch{{Name: "version", Value: "2"}},
+				}},
+			}},
+		},
+	})
+	httpRouteNode.Gateways[gatewayNode.ID()] = gatewayNode
+	model := &Model{DryRuns: []*resourcediscovery.HTTPRouteDryRun{{HTTPRouteNode: httpRouteNode}}}
+
+	got := Run(model, Options{Enable: []string{CheckUnadvertisedFeature}})
+	want := []Finding{
+		{
+			CheckID:   CheckUnadvertisedFeature,
+			Severity:  SeverityWarning,
+			Resource:  common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Name: "foo-httproute", Namespace: "default"},
+			Message:   "uses feature HTTPRouteQueryParamMatching (spec.rules[].matches[].queryParams) not advertised by GatewayClass foo-gatewayclass",
+			MessageID: CheckUnadvertisedFeature,
+			Params:    map[string]string{"feature": "HTTPRouteQueryParamMatching", "field": "spec.rules[].matches[].queryParams", "gatewayClass": "foo-gatewayclass"},
+		},
+		{
+			CheckID:   CheckUnadvertisedFeature,
+			Severity:  SeverityWarning,
+			Resource:  common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Name: "foo-gateway", Namespace: "default"},
+			Message:   "uses feature GatewayPort8080 (spec.listeners[].port) not advertised by GatewayClass foo-gatewayclass",
+			MessageID: CheckUnadvertisedFeature,
+			Params:    map[string]string{"feature": "GatewayPort8080", "field": "spec.listeners[].port", "gatewayClass": "foo-gatewayclass"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() returned unexpected diff (-want +got):\n%v", diff)
+	}
+
+	// GatewayClasses which do not list any feature produce no findings.
+	gatewayClassNode.GatewayClass.Status.SupportedFeatures = nil
+	if got := Run(model, Options{Enable: []string{CheckUnadvertisedFeature}}); len(got) != 0 {
+		t.Errorf("Run() = %v, want no findings for a GatewayClass without supportedFeatures", got)
+	}
+}
+
+func TestRun_HTTPRouteAnyHostname(t *testing.T) {
+	gatewayNode := resourcediscovery.NewGatewayNode(&gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-gateway", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+				{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443, Hostname: common.PtrTo(gatewayv1.Hostname("example.com"))},
+			},
+		},
+	})
+	dryRun := func(hostnames ...gatewayv1.Hostname) *resourcediscovery.HTTPRouteDryRun {
+		httpRouteNode := resourcediscovery.NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+			Spec:       gatewayv1.HTTPRouteSpec{Hostnames: hostnames},
+		})
+		httpRouteNode.Gateways[gatewayNode.ID()] = gatewayNode
+		gateway := types.NamespacedName{Namespace: "default", Name: "foo-gateway"}
+		return &resourcediscovery.HTTPRouteDryRun{
+			HTTPRouteNode: httpRouteNode,
+			Attachments: []resourcediscovery.ListenerAttachment{
+				{Gateway: gateway, Listener: "http"},
+				{Gateway: gateway, Listener: "https"},
+			},
+		}
+	}
+
+	model := &Model{DryRuns: []*resourcediscovery.HTTPRouteDryRun{dryRun()}}
+	got := Run(model, Options{Enable: []string{CheckHTTPRouteAnyHostname}})
+	want := []Finding{
+		{
+			CheckID:   CheckHTTPRouteAnyHostname,
+			Severity:  SeverityInfo,
+			Resource:  common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Name: "foo-httproute", Namespace: "default"},
+			Message:   "HTTPRoute specifies no hostnames and listener http of Gateway default/foo-gateway specifies no hostname either, so the HTTPRoute serves requests for any hostname; set spec.hostnames if this is not intended",
+			MessageID: CheckHTTPRouteAnyHostname,
+			Params:    map[string]string{"listener": "http", "gateway": "default/foo-gateway"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() returned unexpected diff (-want +got):\n%v", diff)
+	}
+
+	// HTTPRoutes with hostnames produce no findings.
+	model = &Model{DryRuns: []*resourcediscovery.HTTPRouteDryRun{dryRun("foo.example.com")}}
+	if got := Run(model, Options{Enable: []string{CheckHTTPRouteAnyHostname}}); len(got) != 0 {
+		t.Errorf("Run() = %v, want no findings for an HTTPRoute with hostnames", got)
+	}
+}
+
+func TestRun_ReferenceGrantCoverage(t *testing.T) {
+	referenceGrant := func(name string) common.ObjRef {
+		return common.ObjRef{Group: gatewayv1.GroupName, Kind: "ReferenceGrant", Name: name, Namespace: "backends"}
+	}
+	reference := func(from, to string) resourcediscovery.CrossNamespaceReference {
+		return resourcediscovery.CrossNamespaceReference{
+			From: common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Name: from, Namespace: "frontends"},
+			To:   common.ObjRef{Kind: "Service", Name: to, Namespace: "backends"},
+		}
+	}
+
+	model := &Model{
+		ReferenceGrantCoverage: []resourcediscovery.ReferenceGrantCoverage{
+			{ReferenceGrant: referenceGrant("dead"), Wildcard: true},
+			{ReferenceGrant: referenceGrant("named"), References: []resourcediscovery.CrossNamespaceReference{reference("route-a", "svc-a")}},
+			{ReferenceGrant: referenceGrant("over-broad"), Wildcard: true, References: []resourcediscovery.CrossNamespaceReference{reference("route-a", "svc-a"), reference("route-b", "svc-a")}},
+			{ReferenceGrant: referenceGrant("wildcard"), Wildcard: true, References: []resourcediscovery.CrossNamespaceReference{reference("route-a", "svc-a"), reference("route-a", "svc-b")}},
+		},
+	}
+	got := Run(model, Options{Enable: []string{CheckReferenceGrantCoverage}})
+	want := []Finding{
+		{
+			CheckID:   CheckReferenceGrantCoverage,
+			Severity:  SeverityInfo,
+			Resource:  referenceGrant("dead"),
+			Message:   "ReferenceGrant does not authorize any reference; remove it if it is no longer needed",
+			MessageID: "referencegrant-coverage.unused",
+		},
+		{
+			CheckID:   CheckReferenceGrantCoverage,
+			Severity:  SeverityInfo,
+			Resource:  referenceGrant("over-broad"),
+			Message:   "ReferenceGrant exposes all resources of some kind, but only Service backends/svc-a is referenced through it (by 2 references); set its name in spec.to to grant no more than needed",
+			MessageID: "referencegrant-coverage.over-broad",
+			Params:    map[string]string{"targetKind": "Service", "target": "backends/svc-a", "references": "2"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
+
+func TestRun_IngressHostnameCollisions(t *testing.T) {
+	listener := resourcediscovery.GatewayListener{Gateway: types.NamespacedName{Namespace: "default", Name: "edge-gw"}, Listener: "https"}
+	model := &Model{
+		IngressHostnameCollisions: []resourcediscovery.IngressHostnameCollision{
+			{Ingress: types.NamespacedName{Namespace: "legacy", Name: "shop"}, Host: "shop.example.com", HTTPRoute: types.NamespacedName{Namespace: "default", Name: "catch-all"}, Listener: listener, Hostname: "*.example.com"},
+			{Ingress: types.NamespacedName{Namespace: "legacy", Name: "shop"}, Host: "shop.example.com", HTTPRoute: types.NamespacedName{Namespace: "default", Name: "shop"}, Listener: listener, Hostname: "shop.example.com"},
+		},
+	}
+	got := Run(model, Options{Enable: []string{CheckIngressHostnameCollision}})
+	ingress := common.ObjRef{Group: "networking.k8s.io", Kind: "Ingress", Name: "shop", Namespace: "legacy"}
+	want := []Finding{
+		{
+			CheckID:   CheckIngressHostnameCollision,
+			Severity:  SeverityWarning,
+			Resource:  ingress,
+			Message:   "host shop.example.com overlaps hostname *.example.com served by HTTPRoute default/catch-all through listener default/edge-gw/https; which of them receives the requests depends on the controllers",
+			MessageID: CheckIngressHostnameCollision,
+			Params:    map[string]string{"host": "shop.example.com", "hostname": "*.example.com", "httpRoute": "default/catch-all", "listener": "default/edge-gw/https"},
+		},
+		{
+			CheckID:   CheckIngressHostnameCollision,
+			Severity:  SeverityWarning,
+			Resource:  ingress,
+			Message:   "host shop.example.com overlaps hostname shop.example.com served by HTTPRoute default/shop through listener default/edge-gw/https; which of them receives the requests depends on the controllers",
+			MessageID: CheckIngressHostnameCollision,
+			Params:    map[string]string{"host": "shop.example.com", "hostname": "shop.example.com", "httpRoute": "default/shop", "listener": "default/edge-gw/https"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
+
+func TestRun_InvalidHostnames(t *testing.T) {
+	gatewayNode := resourcediscovery.NewGatewayNode(&gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-gateway", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+				{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443, Hostname: common.PtrTo(gatewayv1.Hostname("foo.*.example.com"))},
+			},
+		},
+	})
+	httpRouteNode := resourcediscovery.NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+		Spec:       gatewayv1.HTTPRouteSpec{Hostnames: []gatewayv1.Hostname{"example.com", "Example.com", "example.com.", "10.0.0.1"}},
+	})
+	httpRouteNode.Gateways[gatewayNode.ID()] = gatewayNode
+
+	model := &Model{DryRuns: []*resourcediscovery.HTTPRouteDryRun{{HTTPRouteNode: httpRouteNode}}}
+	got := Run(model, Options{Enable: []string{CheckInvalidHostname}})
+	httpRoute := common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Name: "foo-httproute", Namespace: "default"}
+	want := []Finding{
+		{
+			CheckID:   CheckInvalidHostname,
+			Severity:  SeverityError,
+			Resource:  httpRoute,
+			Message:   `spec.hostnames[1]: hostname "Example.com" is invalid: must be lowercase`,
+			MessageID: CheckInvalidHostname,
+			Params:    map[string]string{"field": "spec.hostnames[1]", "hostname": "Example.com", "reason": "must be lowercase"},
+		},
+		{
+			CheckID:   CheckInvalidHostname,
+			Severity:  SeverityError,
+			Resource:  httpRoute,
+			Message:   `spec.hostnames[2]: hostname "example.com." is invalid: must not end with a dot`,
+			MessageID: CheckInvalidHostname,
+			Params:    map[string]string{"field": "spec.hostnames[2]", "hostname": "example.com.", "reason": "must not end with a dot"},
+		},
+		{
+			CheckID:   CheckInvalidHostname,
+			Severity:  SeverityError,
+			Resource:  httpRoute,
+			Message:   `spec.hostnames[3]: hostname "10.0.0.1" is invalid: must not be an IP address`,
+			MessageID: CheckInvalidHostname,
+			Params:    map[string]string{"field": "spec.hostnames[3]", "hostname": "10.0.0.1", "reason": "must not be an IP address"},
+		},
+		{
+			CheckID:   CheckInvalidHostname,
+			Severity:  SeverityError,
+			Resource:  common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Name: "foo-gateway", Namespace: "default"},
+			Message:   `spec.listeners[1].hostname: hostname "foo.*.example.com" is invalid: wildcard must be the whole leftmost label, like *.example.com`,
+			MessageID: CheckInvalidHostname,
+			Params:    map[string]string{"field": "spec.listeners[1].hostname", "hostname": "foo.*.example.com", "reason": "wildcard must be the whole leftmost label, like *.example.com"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
+
+func TestRun_HTTPRouteHostnamesNoMatch(t *testing.T) {
+	dryRun := &resourcediscovery.HTTPRouteDryRun{
+		HTTPRouteNode: resourcediscovery.NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+			Spec:       gatewayv1.HTTPRouteSpec{Hostnames: []gatewayv1.Hostname{"api.example.com", "*.example.com", "api.example.net", "Invalid.example.net"}},
+		}),
+	}
+	listener := func(name gatewayv1.SectionName) resourcediscovery.GatewayListener {
+		return resourcediscovery.GatewayListener{Gateway: types.NamespacedName{Namespace: "default", Name: "foo-gateway"}, Listener: name}
+	}
+
+	model := &Model{
+		DryRuns: []*resourcediscovery.HTTPRouteDryRun{dryRun},
+		ListenerHostnames: resourcediscovery.ListenerHostnames{
+			listener("wildcard"): "*.example.com",
+			listener("web"):      "web.example.org",
+		},
+	}
+	got := Run(model, Options{Enable: []string{CheckHTTPRouteHostnameNoMatch}})
+	want := []Finding{
+		{
+			CheckID:   CheckHTTPRouteHostnameNoMatch,
+			Severity:  SeverityInfo,
+			Resource:  common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Name: "foo-httproute", Namespace: "default"},
+			Message:   "spec.hostnames[2]: hostname api.example.net matches the hostname of no listener in the cluster which allows HTTPRoutes, so the HTTPRoute never receives requests for it",
+			MessageID: CheckHTTPRouteHostnameNoMatch,
+			Params:    map[string]string{"field": "spec.hostnames[2]", "hostname": "api.example.net"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() returned unexpected diff (-want +got):\n%v", diff)
+	}
+
+	// A listener without hostname matches every hostname.
+	model.ListenerHostnames[listener("any")] = ""
+	if got := Run(model, Options{Enable: []string{CheckHTTPRouteHostnameNoMatch}}); len(got) != 0 {
+		t.Errorf("Run() = %v, want no findings with a listener matching any hostname", got)
+	}
+}
+
+func TestInvalidHTTPRouteMatches(t *testing.T) {
+	header := func(name, value string, matchType gatewayv1.HeaderMatchType) gatewayv1.HTTPHeaderMatch {
+		return gatewayv1.HTTPHeaderMatch{Name: gatewayv1.HTTPHeaderName(name), Value: value, Type: &matchType}
+	}
+	queryParam := func(name, value string, matchType gatewayv1.QueryParamMatchType) gatewayv1.HTTPQueryParamMatch {
+		return gatewayv1.HTTPQueryParamMatch{Name: gatewayv1.HTTPHeaderName(name), Value: value, Type: &matchType}
+	}
+
+	testcases := []struct {
+		name    string
+		matches []gatewayv1.HTTPRouteMatch
+		want    []string
+	}{
+		{
+			name: "valid matches",
+			matches: []gatewayv1.HTTPRouteMatch{
+				{
+					Path:        &gatewayv1.HTTPPathMatch{Type: common.PtrTo(gatewayv1.PathMatchRegularExpression), Value: common.PtrTo("/v[0-9]+/.*")},
+					Headers:     []gatewayv1.HTTPHeaderMatch{header("x-env", "prod", gatewayv1.HeaderMatchExact), header("x-version", "v[12]", gatewayv1.HeaderMatchRegularExpression)},
+					QueryParams: []gatewayv1.HTTPQueryParamMatch{queryParam("debug", "true", gatewayv1.QueryParamMatchExact)},
+				},
+				// The same header may be matched by different matches.
+				{Headers: []gatewayv1.HTTPHeaderMatch{header("x-env", "staging", gatewayv1.HeaderMatchExact)}},
+			},
+		},
+		{
+			name: "duplicate header names differing in case",
+			matches: []gatewayv1.HTTPRouteMatch{{
+				Headers: []gatewayv1.HTTPHeaderMatch{
+					header("X-Env", "prod", gatewayv1.HeaderMatchExact),
+					header("x-env", "staging", gatewayv1.HeaderMatchExact),
+					header("X-ENV", "dev", gatewayv1.HeaderMatchExact),
+				},
+			}},
+			want: []string{"rule 0, match 0: header x-env is matched more than once; only the first match is considered, and some implementations reject the HTTPRoute"},
+		},
+		{
+			name: "duplicate query parameter names",
+			matches: []gatewayv1.HTTPRouteMatch{
+				{},
+				{QueryParams: []gatewayv1.HTTPQueryParamMatch{
+					queryParam("page", "1", gatewayv1.QueryParamMatchExact),
+					queryParam("page", "2", gatewayv1.QueryParamMatchExact),
+				}},
+			},
+			want: []string{"rule 0, match 1: query parameter page is matched more than once; only the first match is considered, and some implementations reject the HTTPRoute"},
+		},
+		{
+			name: "query parameter names are case-sensitive",
+			matches: []gatewayv1.HTTPRouteMatch{{
+				QueryParams: []gatewayv1.HTTPQueryParamMatch{
+					queryParam("page", "1", gatewayv1.QueryParamMatchExact),
+					queryParam("Page", "2", gatewayv1.QueryParamMatchExact),
+				},
+			}},
+		},
+		{
+			name: "invalid regular expressions",
+			matches: []gatewayv1.HTTPRouteMatch{{
+				Path:        &gatewayv1.HTTPPathMatch{Type: common.PtrTo(gatewayv1.PathMatchRegularExpression), Value: common.PtrTo("/v[0-9+/.*")},
+				Headers:     []gatewayv1.HTTPHeaderMatch{header("x-version", "v(1|2", gatewayv1.HeaderMatchRegularExpression)},
+				QueryParams: []gatewayv1.HTTPQueryParamMatch{queryParam("id", "*", gatewayv1.QueryParamMatchRegularExpression)},
+			}},
+			want: []string{
+				"rule 0, match 0: regular expression \"/v[0-9+/.*\" of the path does not compile: error parsing regexp: missing closing ]: `[0-9+/.*`",
+				"rule 0, match 0: regular expression \"v(1|2\" of header x-version does not compile: error parsing regexp: missing closing ): `v(1|2`",
+				"rule 0, match 0: regular expression \"*\" of query parameter id does not compile: error parsing regexp: missing argument to repetition operator: `*`",
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpRoute := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+				Spec:       gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{{Matches: tc.matches}}},
+			}
+			got := renderMessages(invalidHTTPRouteMatches(httpRoute))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("invalidHTTPRouteMatches() returned unexpected diff (-want +got):\n%v", diff)
+			}
+
+			findings := Run(&Model{DryRuns: []*resourcediscovery.HTTPRouteDryRun{{HTTPRouteNode: resourcediscovery.NewHTTPRouteNode(httpRoute)}}}, Options{Enable: []string{CheckHTTPRouteInvalidMatch}})
+			if len(findings) != len(tc.want) {
+				t.Errorf("Run() returned %d findings, want %d", len(findings), len(tc.want))
+			}
+		})
+	}
+}
+
+func TestHTTPRouteInvalidMirrors(t *testing.T) {
+	httpRouteNode := resourcediscovery.NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+	})
+	httpRouteNode.MirrorSamplings[resourcediscovery.MirrorFilterIndex{Rule: 0, Filter: 0}] = resourcediscovery.MirrorSampling{Numerator: 10, Denominator: 100}
+	httpRouteNode.MirrorSamplingErrors[resourcediscovery.MirrorFilterIndex{Rule: 1, Filter: 0}] = errors.New("percent 120 must be between 0 and 100")
+	httpRouteNode.MirrorSamplingErrors[resourcediscovery.MirrorFilterIndex{Rule: 0, Filter: 2}] = errors.New("fraction denominator 0 must be greater than 0")
+
+	findings := Run(&Model{DryRuns: []*resourcediscovery.HTTPRouteDryRun{{HTTPRouteNode: httpRouteNode}}}, Options{Enable: []string{CheckHTTPRouteInvalidMirror}})
+	var got []string
+	for _, finding := range findings {
+		got = append(got, finding.Message)
+	}
+	want := []string{
+		"rule 0, filter 2: invalid RequestMirror sampling: fraction denominator 0 must be greater than 0",
+		"rule 1, filter 0: invalid RequestMirror sampling: percent 120 must be between 0 and 100",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Run() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
+
+func TestConflictingManagers(t *testing.T) {
+	entry := func(manager, fields string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{Manager: manager, Operation: metav1.ManagedFieldsOperationUpdate, FieldsV1: &metav1.FieldsV1{Raw: []byte(fields)}}
+	}
+	testcases := []struct {
+		name          string
+		managedFields []metav1.ManagedFieldsEntry
+		// field defaults to spec.rules.
+		field string
+		want  []string
+	}{
+		{
+			name: "single manager of the rules",
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry("kubectl", `{"f:spec":{"f:hostnames":{},"f:rules":{}}}`),
+				// Other fields may be owned by other managers.
+				entry("example-controller", `{"f:spec":{"f:hostnames":{}}}`),
+			},
+		},
+		{
+			name: "rules adopted by a controller",
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry("kubectl", `{"f:spec":{"f:rules":{}}}`),
+				entry("example-controller", `{"f:spec":{"f:rules":{}}}`),
+				{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:rules":{}}}`)}},
+			},
+			want: []string{"spec.rules is owned by 2 field managers (example-controller, kubectl), which may overwrite each other's changes"},
+		},
+		{
+			name: "different listeners owned by different managers",
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry("kubectl", `{"f:spec":{"f:listeners":{"k:{\"name\":\"http\"}":{".":{},"f:name":{},"f:port":{}}}}}`),
+				entry("example-controller", `{"f:spec":{"f:listeners":{"k:{\"name\":\"https\"}":{".":{},"f:name":{},"f:port":{}}}}}`),
+			},
+			field: "spec.listeners",
+		},
+		{
+			name: "same listener field owned by different managers",
+			managedFields: []metav1.ManagedFieldsEntry{
+				entry("kubectl", `{"f:spec":{"f:listeners":{"k:{\"name\":\"https\"}":{".":{},"f:name":{},"f:port":{},"f:hostname":{}}}}}`),
+				entry("example-controller", `{"f:spec":{"f:listeners":{"k:{\"name\":\"https\"}":{"f:port":{}}}}}`),
+			},
+			field: "spec.listeners",
+			want:  []string{"spec.listeners[name=https].port is owned by 2 field managers (example-controller, kubectl), which may overwrite each other's changes"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			field := tc.field
+			if field == "" {
+				field = "spec.rules"
+			}
+			obj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", ManagedFields: tc.managedFields}}
+			if diff := cmp.Diff(tc.want, renderMessages(conflictingManagers(obj, field))); diff != "" {
+				t.Errorf("conflictingManagers() returned unexpected problems (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func renderMessages(messages []Message) []string {
+	var result []string
+	for _, message := range messages {
+		result = append(result, message.String())
+	}
+	return result
+}
+
+func TestRun_CertificateExpiry(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	gateway := common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Name: "foo-gateway", Namespace: "default"}
+	certificate := func(listener string, notAfter *time.Time) resourcediscovery.CertificateView {
+		view := resourcediscovery.CertificateView{
+			Gateway:  gateway,
+			Listener: listener,
+			Secret:   common.ObjRef{Kind: "Secret", Name: listener + "-cert", Namespace: "default"},
+		}
+		if notAfter != nil {
+			view.NotAfter = &metav1.Time{Time: *notAfter}
+		}
+		return view
+	}
+
+	testcases := []struct {
+		name      string
+		notAfter  *time.Time
+		threshold time.Duration
+		want      []Finding
+	}{
+		{
+			name:     "expired",
+			notAfter: common.PtrTo(now.Add(-time.Hour)),
+			want: []Finding{{
+				CheckID:   CheckCertificateExpiry,
+				Severity:  SeverityWarning,
+				Resource:  gateway,
+				Message:   "certificate in Secret default/https-cert of listener https expired on 2024-05-31T23:00:00Z",
+				MessageID: messageCertificateExpired,
+				Params:    map[string]string{"secret": "default/https-cert", "listener": "https", "notAfter": "2024-05-31T23:00:00Z"},
+			}},
+		},
+		{
+			name:     "expires within the default threshold",
+			notAfter: common.PtrTo(now.Add(10 * 24 * time.Hour)),
+			want: []Finding{{
+				CheckID:   CheckCertificateExpiry,
+				Severity:  SeverityWarning,
+				Resource:  gateway,
+				Message:   "certificate in Secret default/https-cert of listener https expires on 2024-06-11T00:00:00Z, in 10 days",
+				MessageID: messageCertificateExpiring,
+				Params:    map[string]string{"secret": "default/https-cert", "listener": "https", "notAfter": "2024-06-11T00:00:00Z", "daysLeft": "10"},
+			}},
+		},
+		{
+			name:     "expires after the default threshold",
+			notAfter: common.PtrTo(now.Add(60 * 24 * time.Hour)),
+		},
+		{
+			name:      "expires within a custom threshold",
+			notAfter:  common.PtrTo(now.Add(60 * 24 * time.Hour)),
+			threshold: 90 * 24 * time.Hour,
+			want: []Finding{{
+				CheckID:   CheckCertificateExpiry,
+				Severity:  SeverityWarning,
+				Resource:  gateway,
+				Message:   "certificate in Secret default/https-cert of listener https expires on 2024-07-31T00:00:00Z, in 60 days",
+				MessageID: messageCertificateExpiring,
+				Params:    map[string]string{"secret": "default/https-cert", "listener": "https", "notAfter": "2024-07-31T00:00:00Z", "daysLeft": "60"},
+			}},
+		},
+		{
+			name: "unreadable certificate",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			model := &Model{Certificates: []resourcediscovery.CertificateView{certificate("https", tc.notAfter)}}
+			got := Run(model, Options{
+				Enable:                     []string{CheckCertificateExpiry},
+				Clock:                      testingclock.NewFakePassiveClock(now),
+				CertificateExpiryThreshold: tc.threshold,
+			})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Run() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// templateParamRegex matches the named parameters of a message template, like
+// "{hostname}".
+var templateParamRegex = regexp.MustCompile(`\{([A-Za-z][A-Za-z0-9]*)\}`)
+
+// MessageTemplate is the template of the messages of a check, with named
+// parameters like "hostname {hostname} is also served by {listeners}".
+//
+// Tools key off the ID and the parameters of messages rather than their
+// wording, so once released, neither the ID nor the names of the parameters of
+// a template change, and parameters are never removed. The wording of the
+// template may change.
+type MessageTemplate struct {
+	// ID identifies the template. It is either the ID of the check, or the ID
+	// of the check followed by a dot and a suffix for checks with several
+	// kinds of messages, like "httproute-not-attached.listener".
+	ID       string
+	Template string
+}
+
+// Params returns the names of the parameters of the template, in the order in
+// which they first appear.
+func (t MessageTemplate) Params() []string {
+	var result []string
+	for _, match := range templateParamRegex.FindAllStringSubmatch(t.Template, -1) {
+		if !slices.Contains(result, match[1]) {
+			result = append(result, match[1])
+		}
+	}
+	return result
+}
+
+// Message is a message found by a check: the ID of its template along with
+// the values of the parameters of the template.
+type Message struct {
+	ID     string
+	Params map[string]string
+}
+
+// NewMessage returns the message of the template with the ID, with the
+// parameters given as alternating names and values.
+func NewMessage(id string, namesAndValues ...string) Message {
+	if len(namesAndValues)%2 != 0 {
+		panic(fmt.Sprintf("parameters of message %q are not pairs of names and values", id))
+	}
+	message := Message{ID: id}
+	for i := 0; i < len(namesAndValues); i += 2 {
+		if message.Params == nil {
+			message.Params = make(map[string]string)
+		}
+		message.Params[namesAndValues[i]] = namesAndValues[i+1]
+	}
+	return message
+}
+
+// String renders the message with its template. Messages whose template is
+// not registered are rendered as their ID followed by their parameters.
+func (m Message) String() string {
+	checksMu.RLock()
+	template, ok := messageTemplates[m.ID]
+	checksMu.RUnlock()
+	if !ok {
+		var params []string
+		for name, value := range m.Params {
+			params = append(params, fmt.Sprintf("%v=%v", name, value))
+		}
+		sort.Strings(params)
+		return strings.TrimSpace(m.ID + " " + strings.Join(params, " "))
+	}
+	return templateParamRegex.ReplaceAllStringFunc(template.Template, func(param string) string {
+		value, ok := m.Params[strings.Trim(param, "{}")]
+		if !ok {
+			return "<missing>"
+		}
+		return value
+	})
+}
+
+// messageTemplates maps the ID of each template of the registered checks to
+// the template. It is guarded by checksMu.
+var messageTemplates = make(map[string]MessageTemplate)
+
+// registerMessageTemplates registers the templates of the check. It panics if
+// a template does not belong to the check, or is already registered. checksMu
+// must be held.
+func registerMessageTemplates(check Check) {
+	for _, template := range check.Messages {
+		if template.ID != check.ID && !strings.HasPrefix(template.ID, check.ID+".") {
+			panic(fmt.Sprintf("message template %q does not belong to analysis check %q", template.ID, check.ID))
+		}
+		if _, ok := messageTemplates[template.ID]; ok {
+			panic(fmt.Sprintf("message template %q is already registered", template.ID))
+		}
+		messageTemplates[template.ID] = template
+	}
+}
+
+// Catalog returns the message templates of all registered checks, sorted by
+// their ID.
+func Catalog() []MessageTemplate {
+	checksMu.RLock()
+	defer checksMu.RUnlock()
+	result := make([]MessageTemplate, 0, len(messageTemplates))
+	for _, template := range messageTemplates {
+		result = append(result, template)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
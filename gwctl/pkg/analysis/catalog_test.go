@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// catalogSnapshot is a snapshot of the IDs and parameters of the message
+// templates which have been released. Tools key off them, so entries must
+// never be removed or changed; new templates and parameters are appended.
+var catalogSnapshot = map[string][]string{
+	"conflicting-field-managers":                        {"field", "count", "managers"},
+	"conflicting-field-managers.invalid-managed-fields": {"error"},
+	"duplicate-hostname":                                {"hostname", "listeners", "port"},
+	"gateway-recently-degraded":                         {"condition"},
+	"httproute-any-hostname":                            {"listener", "gateway"},
+	"httproute-error":                                   {"error"},
+	"httproute-invalid-match.duplicate-header":          {"rule", "match", "header"},
+	"httproute-invalid-match.duplicate-query-param":     {"rule", "match", "queryParam"},
+	"httproute-invalid-match.header-regex":              {"rule", "match", "regex", "header", "error"},
+	"httproute-invalid-match.path-regex":                {"rule", "match", "regex", "error"},
+	"httproute-invalid-match.query-param-regex":         {"rule", "match", "regex", "queryParam", "error"},
+	"httproute-not-attached.gateway":                    {"gateway", "reason"},
+	"httproute-not-attached.listener":                   {"listener", "gateway", "reason"},
+	"httproute-overlap":                                 {"httpRoute", "hostname", "listener", "gateway"},
+	"referencegrant-coverage.over-broad":                {"targetKind", "target", "references"},
+	"referencegrant-coverage.unused":                    {},
+	"unadvertised-feature":                              {"feature", "field", "gatewayClass"},
+	"wildcard-hostname-overlap":                         {"wildcard", "hostname", "listeners", "port"},
+	"ingress-hostname-collision":                        {"host", "hostname", "httpRoute", "listener"},
+	"invalid-hostname":                                  {"field", "hostname", "reason"},
+	"httproute-hostname-no-match":                       {"field", "hostname"},
+	"certificate-expiry.expired":                        {"secret", "listener", "notAfter"},
+	"certificate-expiry.expiring":                       {"secret", "listener", "notAfter", "daysLeft"},
+	"httproute-invalid-mirror-sampling":                 {"rule", "filter", "error"},
+}
+
+func TestCatalog_AppendOnly(t *testing.T) {
+	catalog := make(map[string]MessageTemplate)
+	for _, template := range Catalog() {
+		catalog[template.ID] = template
+	}
+
+	for id, params := range catalogSnapshot {
+		template, ok := catalog[id]
+		if !ok {
+			t.Errorf("message template %q was removed from the catalog; released templates must be kept", id)
+			continue
+		}
+		for _, param := range params {
+			if !slices.Contains(template.Params(), param) {
+				t.Errorf("parameter %q was removed from message template %q; released parameters must be kept", param, id)
+			}
+		}
+	}
+
+	// Every template must be in the snapshot, so that later changes are
+	// checked against it.
+	for id, template := range catalog {
+		params, ok := catalogSnapshot[id]
+		if !ok {
+			t.Errorf("message template %q is not in catalogSnapshot; append it as %q: %#v,", id, id, template.Params())
+			continue
+		}
+		for _, param := range template.Params() {
+			if !slices.Contains(params, param) {
+				t.Errorf("parameter %q of message template %q is not in catalogSnapshot; append it to %q", param, id, id)
+			}
+		}
+	}
+}
+
+func TestCatalog_EveryCheckHasTemplates(t *testing.T) {
+	for _, check := range Checks() {
+		if len(check.Messages) == 0 {
+			t.Errorf("check %q has no message templates", check.ID)
+		}
+	}
+}
+
+func TestMessage_String(t *testing.T) {
+	testcases := []struct {
+		message Message
+		want    string
+	}{
+		{
+			message: NewMessage(CheckDuplicateHostname, "hostname", "example.com", "listeners", "default/foo-gateway/https", "port", "443"),
+			want:    "hostname example.com is also served on port 443 by default/foo-gateway/https",
+		},
+		{
+			message: NewMessage(CheckDuplicateHostname, "hostname", "example.com", "port", "443"),
+			want:    "hostname example.com is also served on port 443 by <missing>",
+		},
+		{
+			message: NewMessage("unknown-check", "b", "2", "a", "1"),
+			want:    "unknown-check a=1 b=2",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(fmt.Sprint(tc.message), func(t *testing.T) {
+			if got := tc.message.String(); got != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegister_ForeignMessageTemplate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register() did not panic for a message template of another check")
+		}
+	}()
+	Register(Check{ID: "foo-check", Messages: []MessageTemplate{{ID: CheckDuplicateHostname + ".other", Template: "other"}}})
+}
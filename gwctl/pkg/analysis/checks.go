@@ -0,0 +1,642 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/maps"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+)
+
+// IDs of the checks which are registered by default.
+const (
+	CheckHTTPRouteError           = "httproute-error"
+	CheckHTTPRouteNotAttached     = "httproute-not-attached"
+	CheckHTTPRouteOverlap         = "httproute-overlap"
+	CheckGatewayRecentlyDegraded  = "gateway-recently-degraded"
+	CheckDuplicateHostname        = "duplicate-hostname"
+	CheckWildcardHostnameOverlap  = "wildcard-hostname-overlap"
+	CheckUnadvertisedFeature      = "unadvertised-feature"
+	CheckHTTPRouteAnyHostname     = "httproute-any-hostname"
+	CheckReferenceGrantCoverage   = "referencegrant-coverage"
+	CheckHTTPRouteInvalidMatch    = "httproute-invalid-match"
+	CheckHTTPRouteInvalidMirror   = "httproute-invalid-mirror-sampling"
+	CheckConflictingManagers      = "conflicting-field-managers"
+	CheckIngressHostnameCollision = "ingress-hostname-collision"
+	CheckInvalidHostname          = "invalid-hostname"
+	CheckHTTPRouteHostnameNoMatch = "httproute-hostname-no-match"
+	CheckCertificateExpiry        = "certificate-expiry"
+)
+
+// IDs of the message templates of checks with several kinds of messages. The
+// other checks have a single template with the ID of the check.
+const (
+	messageHTTPRouteNotAttachedToGateway  = CheckHTTPRouteNotAttached + ".gateway"
+	messageHTTPRouteNotAttachedToListener = CheckHTTPRouteNotAttached + ".listener"
+	messageReferenceGrantUnused           = CheckReferenceGrantCoverage + ".unused"
+	messageReferenceGrantOverBroad        = CheckReferenceGrantCoverage + ".over-broad"
+	messageInvalidPathRegex               = CheckHTTPRouteInvalidMatch + ".path-regex"
+	messageDuplicateHeaderMatch           = CheckHTTPRouteInvalidMatch + ".duplicate-header"
+	messageInvalidHeaderRegex             = CheckHTTPRouteInvalidMatch + ".header-regex"
+	messageDuplicateQueryParamMatch       = CheckHTTPRouteInvalidMatch + ".duplicate-query-param"
+	messageInvalidQueryParamRegex         = CheckHTTPRouteInvalidMatch + ".query-param-regex"
+	messageInvalidManagedFields           = CheckConflictingManagers + ".invalid-managed-fields"
+	messageCertificateExpired             = CheckCertificateExpiry + ".expired"
+	messageCertificateExpiring            = CheckCertificateExpiry + ".expiring"
+)
+
+func init() {
+	Register(Check{
+		ID:          CheckHTTPRouteError,
+		Severity:    SeverityError,
+		Description: "HTTPRoute has errors, like references to Backends which do not exist or are not permitted",
+		AppliesTo:   isKind("HTTPRoute"),
+		Messages: []MessageTemplate{
+			{ID: CheckHTTPRouteError, Template: "{error}"},
+		},
+		Analyze: analyzeHTTPRouteErrors,
+	})
+	Register(Check{
+		ID:          CheckHTTPRouteNotAttached,
+		Severity:    SeverityWarning,
+		Description: "HTTPRoute does not attach to a listener selected by its parentRefs",
+		AppliesTo:   isKind("HTTPRoute"),
+		Messages: []MessageTemplate{
+			{ID: messageHTTPRouteNotAttachedToGateway, Template: "HTTPRoute does not attach to Gateway {gateway}: {reason}"},
+			{ID: messageHTTPRouteNotAttachedToListener, Template: "HTTPRoute does not attach to listener {listener} of Gateway {gateway}: {reason}"},
+		},
+		Analyze: analyzeHTTPRouteNotAttached,
+	})
+	Register(Check{
+		ID:          CheckHTTPRouteOverlap,
+		Severity:    SeverityWarning,
+		Description: "HTTPRoute defines a match which another HTTPRoute defines as well for the same listener and hostname",
+		AppliesTo:   isKind("HTTPRoute"),
+		Messages: []MessageTemplate{
+			{ID: CheckHTTPRouteOverlap, Template: "HTTPRoute {httpRoute} defines the same match for hostname {hostname} on listener {listener} of Gateway {gateway}; only the oldest HTTPRoute receives the requests"},
+		},
+		Analyze: analyzeHTTPRouteOverlaps,
+	})
+	Register(Check{
+		ID:          CheckGatewayRecentlyDegraded,
+		Severity:    SeverityWarning,
+		Description: "HTTPRoute attaches to a Gateway whose conditions recently transitioned to False",
+		AppliesTo:   isKind("HTTPRoute"),
+		Messages: []MessageTemplate{
+			{ID: CheckGatewayRecentlyDegraded, Template: "{condition}"},
+		},
+		Analyze: analyzeGatewaysRecentlyDegraded,
+	})
+	Register(Check{
+		ID:          CheckDuplicateHostname,
+		Severity:    SeverityWarning,
+		Description: "Gateway serves a hostname which another Gateway serves on the same port as well",
+		AppliesTo:   isKind("Gateway"),
+		Messages: []MessageTemplate{
+			{ID: CheckDuplicateHostname, Template: "hostname {hostname} is also served on port {port} by {listeners}"},
+		},
+		Analyze: analyzeDuplicateHostnames,
+	})
+	Register(Check{
+		ID:          CheckWildcardHostnameOverlap,
+		Severity:    SeverityInfo,
+		Description: "Gateway serves a wildcard hostname which matches a hostname served on the same port by another Gateway",
+		AppliesTo:   isKind("Gateway"),
+		Messages: []MessageTemplate{
+			{ID: CheckWildcardHostnameOverlap, Template: "wildcard hostname {wildcard} matches hostname {hostname} served on port {port} by {listeners}"},
+		},
+		Analyze: analyzeWildcardHostnameOverlaps,
+	})
+	Register(Check{
+		ID:          CheckUnadvertisedFeature,
+		Severity:    SeverityWarning,
+		Description: "HTTPRoute or Gateway uses a feature which the GatewayClass does not list in its supportedFeatures",
+		AppliesTo: func(resource common.ObjRef) bool {
+			return isKind("HTTPRoute")(resource) || isKind("Gateway")(resource)
+		},
+		Messages: []MessageTemplate{
+			{ID: CheckUnadvertisedFeature, Template: "uses feature {feature} ({field}) not advertised by GatewayClass {gatewayClass}"},
+		},
+		Analyze: analyzeUnadvertisedFeatures,
+	})
+	Register(Check{
+		ID:          CheckHTTPRouteAnyHostname,
+		Severity:    SeverityInfo,
+		Description: "HTTPRoute specifies no hostnames and attaches to an HTTP or HTTPS listener without a hostname, so it serves requests for any hostname",
+		AppliesTo:   isKind("HTTPRoute"),
+		Messages: []MessageTemplate{
+			{ID: CheckHTTPRouteAnyHostname, Template: "HTTPRoute specifies no hostnames and listener {listener} of Gateway {gateway} specifies no hostname either, so the HTTPRoute serves requests for any hostname; set spec.hostnames if this is not intended"},
+		},
+		Analyze: analyzeHTTPRouteAnyHostname,
+	})
+	Register(Check{
+		ID:          CheckReferenceGrantCoverage,
+		Severity:    SeverityInfo,
+		Description: "ReferenceGrant authorizes no references, or exposes all resources of some kind while only one of them is referenced",
+		AppliesTo:   isKind("ReferenceGrant"),
+		Messages: []MessageTemplate{
+			{ID: messageReferenceGrantUnused, Template: "ReferenceGrant does not authorize any reference; remove it if it is no longer needed"},
+			{ID: messageReferenceGrantOverBroad, Template: "ReferenceGrant exposes all resources of some kind, but only {targetKind} {target} is referenced through it (by {references} references); set its name in spec.to to grant no more than needed"},
+		},
+		Analyze: analyzeReferenceGrantCoverage,
+	})
+	Register(Check{
+		ID:          CheckHTTPRouteInvalidMatch,
+		Severity:    SeverityError,
+		Description: "HTTPRoute has a match which matches a header or query parameter more than once, or uses a regular expression which does not compile",
+		AppliesTo:   isKind("HTTPRoute"),
+		Messages: []MessageTemplate{
+			{ID: messageInvalidPathRegex, Template: `rule {rule}, match {match}: regular expression "{regex}" of the path does not compile: {error}`},
+			{ID: messageDuplicateHeaderMatch, Template: "rule {rule}, match {match}: header {header} is matched more than once; only the first match is considered, and some implementations reject the HTTPRoute"},
+			{ID: messageInvalidHeaderRegex, Template: `rule {rule}, match {match}: regular expression "{regex}" of header {header} does not compile: {error}`},
+			{ID: messageDuplicateQueryParamMatch, Template: "rule {rule}, match {match}: query parameter {queryParam} is matched more than once; only the first match is considered, and some implementations reject the HTTPRoute"},
+			{ID: messageInvalidQueryParamRegex, Template: `rule {rule}, match {match}: regular expression "{regex}" of query parameter {queryParam} does not compile: {error}`},
+		},
+		Analyze: analyzeHTTPRouteInvalidMatches,
+	})
+	Register(Check{
+		ID:          CheckHTTPRouteInvalidMirror,
+		Severity:    SeverityError,
+		Description: "HTTPRoute has a RequestMirror filter whose percent or fraction is out of range, so it is unknown which requests are mirrored",
+		AppliesTo:   isKind("HTTPRoute"),
+		Messages: []MessageTemplate{
+			{ID: CheckHTTPRouteInvalidMirror, Template: "rule {rule}, filter {filter}: invalid RequestMirror sampling: {error}"},
+		},
+		Analyze: analyzeHTTPRouteInvalidMirrors,
+	})
+	Register(Check{
+		ID:          CheckConflictingManagers,
+		Severity:    SeverityWarning,
+		Description: "fields of the listeners of a Gateway or of the rules of an HTTPRoute are owned by more than one field manager, which may overwrite each other",
+		AppliesTo: func(resource common.ObjRef) bool {
+			return isKind("HTTPRoute")(resource) || isKind("Gateway")(resource)
+		},
+		Messages: []MessageTemplate{
+			{ID: CheckConflictingManagers, Template: "{field} is owned by {count} field managers ({managers}), which may overwrite each other's changes"},
+			{ID: messageInvalidManagedFields, Template: "{error}"},
+		},
+		Analyze: analyzeConflictingManagers,
+	})
+	Register(Check{
+		ID:          CheckIngressHostnameCollision,
+		Severity:    SeverityWarning,
+		Description: "Ingress serves a host which an HTTPRoute serves as well through a Gateway, so which of them receives the requests depends on the controllers",
+		AppliesTo: func(resource common.ObjRef) bool {
+			return resource.Group == networkingv1.GroupName && resource.Kind == "Ingress"
+		},
+		Messages: []MessageTemplate{
+			{ID: CheckIngressHostnameCollision, Template: "host {host} overlaps hostname {hostname} served by HTTPRoute {httpRoute} through listener {listener}; which of them receives the requests depends on the controllers"},
+		},
+		Analyze: analyzeIngressHostnameCollisions,
+	})
+	Register(Check{
+		ID:          CheckInvalidHostname,
+		Severity:    SeverityError,
+		Description: "HTTPRoute or Gateway has a hostname which does not follow the hostname grammar of the Gateway API, like one with uppercase letters, a trailing dot, a wildcard which is not the leftmost label, or an IP address",
+		AppliesTo: func(resource common.ObjRef) bool {
+			return isKind("HTTPRoute")(resource) || isKind("Gateway")(resource)
+		},
+		Messages: []MessageTemplate{
+			{ID: CheckInvalidHostname, Template: `{field}: hostname "{hostname}" is invalid: {reason}`},
+		},
+		Analyze: analyzeInvalidHostnames,
+	})
+	Register(Check{
+		ID:          CheckHTTPRouteHostnameNoMatch,
+		Severity:    SeverityInfo,
+		Description: "HTTPRoute has a hostname which matches the hostname of no listener in the cluster which allows HTTPRoutes",
+		AppliesTo:   isKind("HTTPRoute"),
+		Messages: []MessageTemplate{
+			{ID: CheckHTTPRouteHostnameNoMatch, Template: "{field}: hostname {hostname} matches the hostname of no listener in the cluster which allows HTTPRoutes, so the HTTPRoute never receives requests for it"},
+		},
+		Analyze: analyzeHTTPRouteHostnamesNoMatch,
+	})
+	Register(Check{
+		ID:          CheckCertificateExpiry,
+		Severity:    SeverityWarning,
+		Description: "Gateway has a listener whose certificate expired or expires soon",
+		AppliesTo:   isKind("Gateway"),
+		Messages: []MessageTemplate{
+			{ID: messageCertificateExpired, Template: "certificate in Secret {secret} of listener {listener} expired on {notAfter}"},
+			{ID: messageCertificateExpiring, Template: "certificate in Secret {secret} of listener {listener} expires on {notAfter}, in {daysLeft} days"},
+		},
+		Analyze: analyzeCertificateExpiry,
+	})
+}
+
+func analyzeHTTPRouteErrors(model *Model, resource common.ObjRef, _ Options) []Message {
+	dryRun := model.dryRunFor(resource)
+	if dryRun == nil {
+		return nil
+	}
+	var result []Message
+	for _, err := range dryRun.HTTPRouteNode.Errors {
+		result = append(result, NewMessage(CheckHTTPRouteError, "error", err.Error()))
+	}
+	return result
+}
+
+func analyzeHTTPRouteNotAttached(model *Model, resource common.ObjRef, _ Options) []Message {
+	dryRun := model.dryRunFor(resource)
+	if dryRun == nil {
+		return nil
+	}
+	var result []Message
+	for _, attachment := range dryRun.Attachments {
+		switch {
+		case attachment.Reason == "":
+			continue
+		case attachment.Listener == "":
+			result = append(result, NewMessage(messageHTTPRouteNotAttachedToGateway,
+				"gateway", attachment.Gateway.String(), "reason", attachment.Reason))
+		default:
+			result = append(result, NewMessage(messageHTTPRouteNotAttachedToListener,
+				"listener", string(attachment.Listener), "gateway", attachment.Gateway.String(), "reason", attachment.Reason))
+		}
+	}
+	return result
+}
+
+func analyzeHTTPRouteOverlaps(model *Model, resource common.ObjRef, _ Options) []Message {
+	dryRun := model.dryRunFor(resource)
+	if dryRun == nil {
+		return nil
+	}
+	var result []Message
+	for _, overlap := range dryRun.Overlaps {
+		result = append(result, NewMessage(CheckHTTPRouteOverlap,
+			"httpRoute", fmt.Sprint(overlap.HTTPRoute), "hostname", fmt.Sprint(overlap.Hostname), "listener", fmt.Sprint(overlap.Listener), "gateway", fmt.Sprint(overlap.Gateway)))
+	}
+	return result
+}
+
+func analyzeGatewaysRecentlyDegraded(model *Model, resource common.ObjRef, opts Options) []Message {
+	dryRun := model.dryRunFor(resource)
+	if dryRun == nil {
+		return nil
+	}
+	var result []Message
+	for _, gatewayNode := range sortGatewayNodes(maps.Values(dryRun.HTTPRouteNode.Gateways)) {
+		for _, condition := range common.FindRecentlyDegradedGatewayConditions(opts.Clock, gatewayNode.Gateway) {
+			result = append(result, NewMessage(CheckGatewayRecentlyDegraded, "condition", condition))
+		}
+	}
+	return result
+}
+
+func analyzeDuplicateHostnames(model *Model, resource common.ObjRef, _ Options) []Message {
+	var result []Message
+	for _, duplicate := range model.ServedHostnames.Duplicates() {
+		if others, ok := otherGatewayListeners(duplicate.Listeners, resource); ok {
+			result = append(result, NewMessage(CheckDuplicateHostname, "hostname", duplicate.Hostname, "listeners", others, "port", strconv.Itoa(int(duplicate.Port))))
+		}
+	}
+	return result
+}
+
+func analyzeWildcardHostnameOverlaps(model *Model, resource common.ObjRef, _ Options) []Message {
+	var result []Message
+	for _, overlap := range model.ServedHostnames.WildcardOverlaps() {
+		if _, ok := otherGatewayListeners(overlap.WildcardListeners, resource); !ok {
+			continue
+		}
+		// A Gateway serving both the wildcard and the hostname does not overlap
+		// with itself, so only the listeners of other Gateways are reported.
+		if others, _ := otherGatewayListeners(overlap.Listeners, resource); others != "" {
+			result = append(result, NewMessage(CheckWildcardHostnameOverlap, "wildcard", overlap.Wildcard, "hostname", overlap.Hostname, "listeners", others, "port", strconv.Itoa(int(overlap.Port))))
+		}
+	}
+	return result
+}
+
+func analyzeUnadvertisedFeatures(model *Model, resource common.ObjRef, _ Options) []Message {
+	var usages []resourcediscovery.FeatureUsage
+	var gatewayNodes []*resourcediscovery.GatewayNode
+	if dryRun := model.dryRunFor(resource); dryRun != nil {
+		usages = resourcediscovery.HTTPRouteFeatureUsages(dryRun.HTTPRouteNode.HTTPRoute)
+		gatewayNodes = sortGatewayNodes(maps.Values(dryRun.HTTPRouteNode.Gateways))
+	} else if gatewayNode := model.gatewayNodeFor(resource); gatewayNode != nil {
+		usages = resourcediscovery.GatewayFeatureUsages(gatewayNode.Gateway)
+		gatewayNodes = []*resourcediscovery.GatewayNode{gatewayNode}
+	}
+
+	var result []Message
+	// Gateways of the same GatewayClass would report the same features.
+	seenGatewayClasses := make(map[string]bool)
+	for _, gatewayNode := range gatewayNodes {
+		if gatewayNode.GatewayClass == nil || seenGatewayClasses[gatewayNode.GatewayClass.GatewayClass.GetName()] {
+			continue
+		}
+		gatewayClass := gatewayNode.GatewayClass.GatewayClass
+		seenGatewayClasses[gatewayClass.GetName()] = true
+		for _, usage := range resourcediscovery.UnadvertisedFeatureUsages(usages, gatewayClass) {
+			result = append(result, NewMessage(CheckUnadvertisedFeature,
+				"feature", fmt.Sprint(usage.Feature), "field", usage.Field, "gatewayClass", gatewayClass.GetName()))
+		}
+	}
+	return result
+}
+
+func analyzeHTTPRouteAnyHostname(model *Model, resource common.ObjRef, _ Options) []Message {
+	dryRun := model.dryRunFor(resource)
+	if dryRun == nil {
+		return nil
+	}
+	httpRoute := dryRun.HTTPRouteNode.HTTPRoute
+	var result []Message
+	for _, attachment := range dryRun.Attachments {
+		if attachment.Reason != "" {
+			continue
+		}
+		listener, ok := resourcediscovery.AttachedListener(dryRun.HTTPRouteNode, attachment)
+		if !ok || !relations.RouteServesAnyHostname(*httpRoute, listener) {
+			continue
+		}
+		result = append(result, NewMessage(CheckHTTPRouteAnyHostname, "listener", string(attachment.Listener), "gateway", attachment.Gateway.String()))
+	}
+	return result
+}
+
+func analyzeReferenceGrantCoverage(model *Model, resource common.ObjRef, _ Options) []Message {
+	coverage, ok := model.referenceGrantCoverageFor(resource)
+	if !ok {
+		return nil
+	}
+	if len(coverage.References) == 0 {
+		return []Message{NewMessage(messageReferenceGrantUnused)}
+	}
+	if coverage.OverBroad() {
+		target := coverage.Targets()[0]
+		return []Message{NewMessage(messageReferenceGrantOverBroad,
+			"targetKind", target.Kind, "target", fmt.Sprintf("%v/%v", target.Namespace, target.Name), "references", strconv.Itoa(len(coverage.References)))}
+	}
+	return nil
+}
+
+// otherGatewayListeners returns the listeners which do not belong to the
+// Gateway, joined by commas, and whether any listener belongs to the Gateway.
+func otherGatewayListeners(listeners []resourcediscovery.GatewayListener, gateway common.ObjRef) (string, bool) {
+	gatewayName := types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}
+	var found bool
+	var others []string
+	for _, listener := range listeners {
+		if listener.Gateway == gatewayName {
+			found = true
+		} else {
+			others = append(others, listener.String())
+		}
+	}
+	return strings.Join(others, ", "), found
+}
+
+func sortGatewayNodes(gatewayNodes []*resourcediscovery.GatewayNode) []*resourcediscovery.GatewayNode {
+	sort.Slice(gatewayNodes, func(i, j int) bool {
+		return client.ObjectKeyFromObject(gatewayNodes[i].Gateway).String() < client.ObjectKeyFromObject(gatewayNodes[j].Gateway).String()
+	})
+	return gatewayNodes
+}
+
+func analyzeHTTPRouteInvalidMatches(model *Model, resource common.ObjRef, _ Options) []Message {
+	dryRun := model.dryRunFor(resource)
+	if dryRun == nil {
+		return nil
+	}
+	return invalidHTTPRouteMatches(dryRun.HTTPRouteNode.HTTPRoute)
+}
+
+// invalidHTTPRouteMatches returns the problems with the matches of the
+// HTTPRoute which the API server does not reject:
+//   - Headers matched more than once within a match, comparing their names
+//     case-insensitively. Only the first of them is considered, and some
+//     implementations reject the HTTPRoute instead.
+//   - Query parameters matched more than once within a match, likewise.
+//   - Regular expressions which do not compile in the RE2 syntax.
+func invalidHTTPRouteMatches(httpRoute *gatewayv1.HTTPRoute) []Message {
+	var result []Message
+	for i, rule := range httpRoute.Spec.Rules {
+		for j, match := range rule.Matches {
+			ruleIndex, matchIndex := strconv.Itoa(i), strconv.Itoa(j)
+
+			// Duplicates are reported once, however often they are repeated.
+			headers := make(map[string]int)
+			for _, header := range match.Headers {
+				name := strings.ToLower(string(header.Name))
+				if headers[name] == 1 {
+					result = append(result, NewMessage(messageDuplicateHeaderMatch, "rule", ruleIndex, "match", matchIndex, "header", string(header.Name)))
+				}
+				headers[name]++
+			}
+
+			queryParams := make(map[gatewayv1.HTTPHeaderName]int)
+			for _, queryParam := range match.QueryParams {
+				if queryParams[queryParam.Name] == 1 {
+					result = append(result, NewMessage(messageDuplicateQueryParamMatch, "rule", ruleIndex, "match", matchIndex, "queryParam", string(queryParam.Name)))
+				}
+				queryParams[queryParam.Name]++
+			}
+		}
+	}
+
+	for _, regexMatch := range resourcediscovery.HTTPRouteRegexMatches(httpRoute) {
+		_, err := regexp.Compile(regexMatch.Regex)
+		if err == nil {
+			continue
+		}
+		ruleIndex, matchIndex := strconv.Itoa(regexMatch.Rule), strconv.Itoa(regexMatch.Match)
+		switch {
+		case regexMatch.Header != "":
+			result = append(result, NewMessage(messageInvalidHeaderRegex, "rule", ruleIndex, "match", matchIndex, "regex", regexMatch.Regex, "header", string(regexMatch.Header), "error", err.Error()))
+		case regexMatch.QueryParam != "":
+			result = append(result, NewMessage(messageInvalidQueryParamRegex, "rule", ruleIndex, "match", matchIndex, "regex", regexMatch.Regex, "queryParam", string(regexMatch.QueryParam), "error", err.Error()))
+		default:
+			result = append(result, NewMessage(messageInvalidPathRegex, "rule", ruleIndex, "match", matchIndex, "regex", regexMatch.Regex, "error", err.Error()))
+		}
+	}
+	return result
+}
+
+func analyzeHTTPRouteInvalidMirrors(model *Model, resource common.ObjRef, _ Options) []Message {
+	dryRun := model.dryRunFor(resource)
+	if dryRun == nil {
+		return nil
+	}
+	indexes := maps.Keys(dryRun.HTTPRouteNode.MirrorSamplingErrors)
+	sort.Slice(indexes, func(i, j int) bool {
+		if indexes[i].Rule != indexes[j].Rule {
+			return indexes[i].Rule < indexes[j].Rule
+		}
+		return indexes[i].Filter < indexes[j].Filter
+	})
+	var result []Message
+	for _, index := range indexes {
+		err := dryRun.HTTPRouteNode.MirrorSamplingErrors[index]
+		result = append(result, NewMessage(CheckHTTPRouteInvalidMirror, "rule", strconv.Itoa(index.Rule), "filter", strconv.Itoa(index.Filter), "error", err.Error()))
+	}
+	return result
+}
+
+func analyzeConflictingManagers(model *Model, resource common.ObjRef, _ Options) []Message {
+	if resource.Kind == "Gateway" {
+		gatewayNode := model.gatewayNodeFor(resource)
+		if gatewayNode == nil {
+			return nil
+		}
+		return conflictingManagers(gatewayNode.Gateway, "spec.listeners")
+	}
+	dryRun := model.dryRunFor(resource)
+	if dryRun == nil {
+		return nil
+	}
+	return conflictingManagers(dryRun.HTTPRouteNode.HTTPRoute, "spec.rules")
+}
+
+// conflictingManagers reports the leaf fields within the field of the spec of
+// the object which are owned by more than one field manager, like when they
+// were applied by a user and later adopted and rewritten by a controller.
+// Managers owning different fields within the field, like different listeners
+// of a Gateway, do not conflict.
+func conflictingManagers(obj client.Object, field string) []Message {
+	managersByPath := make(map[string][]string)
+	for _, entry := range obj.GetManagedFields() {
+		paths, err := common.ManagedFieldPaths(entry)
+		if err != nil {
+			return []Message{NewMessage(messageInvalidManagedFields, "error", err.Error())}
+		}
+		for _, path := range paths {
+			if path != field && !strings.HasPrefix(path, field+".") && !strings.HasPrefix(path, field+"[") {
+				continue
+			}
+			if !slices.Contains(managersByPath[path], entry.Manager) {
+				managersByPath[path] = append(managersByPath[path], entry.Manager)
+			}
+		}
+	}
+
+	paths := maps.Keys(managersByPath)
+	sort.Strings(paths)
+	var result []Message
+	for _, path := range paths {
+		managers := managersByPath[path]
+		if len(managers) < 2 {
+			continue
+		}
+		sort.Strings(managers)
+		result = append(result, NewMessage(CheckConflictingManagers,
+			"field", path, "count", strconv.Itoa(len(managers)), "managers", strings.Join(managers, ", ")))
+	}
+	return result
+}
+
+func analyzeIngressHostnameCollisions(model *Model, resource common.ObjRef, _ Options) []Message {
+	var result []Message
+	for _, collision := range model.IngressHostnameCollisions {
+		if ingressRef(collision.Ingress) != resource {
+			continue
+		}
+		result = append(result, NewMessage(CheckIngressHostnameCollision,
+			"host", collision.Host, "hostname", collision.Hostname, "httpRoute", collision.HTTPRoute.String(), "listener", collision.Listener.String()))
+	}
+	return result
+}
+
+func analyzeInvalidHostnames(model *Model, resource common.ObjRef, _ Options) []Message {
+	var result []Message
+	if dryRun := model.dryRunFor(resource); dryRun != nil {
+		for i, hostname := range dryRun.HTTPRouteNode.HTTPRoute.Spec.Hostnames {
+			if err := resourcediscovery.ValidateHostname(string(hostname)); err != nil {
+				result = append(result, NewMessage(CheckInvalidHostname,
+					"field", fmt.Sprintf("spec.hostnames[%d]", i), "hostname", string(hostname), "reason", err.Error()))
+			}
+		}
+	} else if gatewayNode := model.gatewayNodeFor(resource); gatewayNode != nil {
+		for i, listener := range gatewayNode.Gateway.Spec.Listeners {
+			if listener.Hostname == nil {
+				continue
+			}
+			if err := resourcediscovery.ValidateHostname(string(*listener.Hostname)); err != nil {
+				result = append(result, NewMessage(CheckInvalidHostname,
+					"field", fmt.Sprintf("spec.listeners[%d].hostname", i), "hostname", string(*listener.Hostname), "reason", err.Error()))
+			}
+		}
+	}
+	return result
+}
+
+// analyzeHTTPRouteHostnamesNoMatch reports the valid hostnames of the HTTPRoute
+// which no listener of the cluster matches. Invalid hostnames are reported by
+// the invalid-hostname check instead.
+func analyzeHTTPRouteHostnamesNoMatch(model *Model, resource common.ObjRef, _ Options) []Message {
+	dryRun := model.dryRunFor(resource)
+	if dryRun == nil || model.ListenerHostnames == nil {
+		return nil
+	}
+	var result []Message
+	for i, hostname := range dryRun.HTTPRouteNode.HTTPRoute.Spec.Hostnames {
+		if resourcediscovery.ValidateHostname(string(hostname)) != nil {
+			continue
+		}
+		matched := false
+		for _, listenerHostname := range model.ListenerHostnames {
+			if relations.HostnamesMatch(listenerHostname, string(hostname)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			result = append(result, NewMessage(CheckHTTPRouteHostnameNoMatch,
+				"field", fmt.Sprintf("spec.hostnames[%d]", i), "hostname", string(hostname)))
+		}
+	}
+	return result
+}
+
+// analyzeCertificateExpiry reports the certificates of the listeners of the
+// Gateway which expire within the threshold of the options. Certificates which
+// could not be read are not reported, since their expiry is unknown.
+func analyzeCertificateExpiry(model *Model, resource common.ObjRef, opts Options) []Message {
+	now := opts.Clock.Now()
+	var result []Message
+	for _, certificate := range model.Certificates {
+		if certificate.Gateway != resource || certificate.NotAfter == nil {
+			continue
+		}
+		notAfter := certificate.NotAfter.Time
+		secret := fmt.Sprintf("%v/%v", certificate.Secret.Namespace, certificate.Secret.Name)
+		switch {
+		case !notAfter.After(now):
+			result = append(result, NewMessage(messageCertificateExpired, "secret", secret, "listener", certificate.Listener, "notAfter", notAfter.UTC().Format(time.RFC3339)))
+		case notAfter.Before(now.Add(opts.CertificateExpiryThreshold)):
+			daysLeft := int(notAfter.Sub(now).Hours() / 24)
+			result = append(result, NewMessage(messageCertificateExpiring, "secret", secret, "listener", certificate.Listener, "notAfter", notAfter.UTC().Format(time.RFC3339), "daysLeft", strconv.Itoa(daysLeft)))
+		}
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+var (
+	// The exec credential plugin only reports failures as strings, so they are
+	// matched by their message.
+	execPluginNotFoundRegexp = regexp.MustCompile(`exec: executable (\S+) not found`)
+	execPluginFailedRegexp   = regexp.MustCompile(`exec: executable (\S+) failed with exit code (\d+)`)
+)
+
+// unauthorizedMessage is the message of the error returned by the API server
+// when it rejects the credentials of a request.
+const unauthorizedMessage = "the server has asked for the client to provide credentials"
+
+// ExplainAuthError returns an error with an actionable message if err was
+// caused by a failure to authenticate with the API server. Other errors are
+// returned unchanged.
+func ExplainAuthError(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	if m := execPluginNotFoundRegexp.FindStringSubmatch(msg); m != nil {
+		return fmt.Errorf("exec plugin %v not found in PATH; install it or fix the exec command of the current user in the kubeconfig", m[1])
+	}
+	if m := execPluginFailedRegexp.FindStringSubmatch(msg); m != nil {
+		return fmt.Errorf("exec plugin %v failed with exit code %v; run it directly to see why it could not provide credentials", m[1], m[2])
+	}
+	if apierrors.IsUnauthorized(err) || strings.Contains(msg, unauthorizedMessage) {
+		return fmt.Errorf("the API server rejected the credentials of the current context; they may have expired, so try logging in again: %v", err)
+	}
+	return err
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RouteCapacityAnnotation is the annotation of a Gateway or GatewayClass which
+// records the maximum number of routes an implementation supports attaching to
+// a Gateway, for implementations which limit them.
+const RouteCapacityAnnotation = "gwctl.gateway.networking.k8s.io/route-capacity"
+
+// RouteCapacity is the maximum number of routes which can attach to a Gateway.
+type RouteCapacity struct {
+	MaxRoutes int
+	// Source is the object whose annotation the capacity was read from, like
+	// "GatewayClass foo-gatewayclass".
+	Source string
+}
+
+// FindRouteCapacity returns the capacity annotated on the Gateway, or else on
+// its GatewayClass, which may be nil. The result is nil if neither is
+// annotated. An error is returned if the annotation is not a positive integer.
+func FindRouteCapacity(gateway *gatewayv1.Gateway, gatewayClass *gatewayv1.GatewayClass) (*RouteCapacity, error) {
+	type source struct {
+		name        string
+		annotations map[string]string
+	}
+	sources := []source{{name: fmt.Sprintf("Gateway %v", client.ObjectKeyFromObject(gateway)), annotations: gateway.GetAnnotations()}}
+	if gatewayClass != nil {
+		sources = append(sources, source{name: fmt.Sprintf("GatewayClass %v", gatewayClass.GetName()), annotations: gatewayClass.GetAnnotations()})
+	}
+	for _, source := range sources {
+		value, ok := source.annotations[RouteCapacityAnnotation]
+		if !ok {
+			continue
+		}
+		maxRoutes, err := strconv.Atoi(value)
+		if err != nil || maxRoutes <= 0 {
+			return nil, fmt.Errorf("annotation %v of %v has value %q, which is not a positive integer", RouteCapacityAnnotation, source.name, value)
+		}
+		return &RouteCapacity{MaxRoutes: maxRoutes, Source: source.name}, nil
+	}
+	return nil, nil
+}
+
+// Utilization returns the percentage of the capacity used by the attached
+// routes, which exceeds 100 when more routes are attached than supported.
+func (c RouteCapacity) Utilization(attachedRoutes int) int {
+	return attachedRoutes * 100 / c.MaxRoutes
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestFindRouteCapacity(t *testing.T) {
+	annotated := func(value string) map[string]string {
+		if value == "" {
+			return nil
+		}
+		return map[string]string{RouteCapacityAnnotation: value}
+	}
+	testcases := []struct {
+		name            string
+		gatewayCapacity string
+		classCapacity   string
+		noGatewayClass  bool
+		want            *RouteCapacity
+		wantErr         string
+	}{
+		{
+			name: "not annotated",
+		},
+		{
+			name:            "annotated Gateway takes precedence",
+			gatewayCapacity: "10",
+			classCapacity:   "50",
+			want:            &RouteCapacity{MaxRoutes: 10, Source: "Gateway default/foo-gateway"},
+		},
+		{
+			name:          "annotated GatewayClass",
+			classCapacity: "50",
+			want:          &RouteCapacity{MaxRoutes: 50, Source: "GatewayClass foo-gatewayclass"},
+		},
+		{
+			name:           "missing GatewayClass",
+			noGatewayClass: true,
+		},
+		{
+			name:          "invalid capacity",
+			classCapacity: "0",
+			wantErr:       `annotation gwctl.gateway.networking.k8s.io/route-capacity of GatewayClass foo-gatewayclass has value "0", which is not a positive integer`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "foo-gateway", Namespace: "default", Annotations: annotated(tc.gatewayCapacity)}}
+			gatewayClass := &gatewayv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "foo-gatewayclass", Annotations: annotated(tc.classCapacity)}}
+			if tc.noGatewayClass {
+				gatewayClass = nil
+			}
+			got, err := FindRouteCapacity(gateway, gatewayClass)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("FindRouteCapacity() returned error %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindRouteCapacity() returned an unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FindRouteCapacity() returned unexpected capacity (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestRouteCapacity_Utilization(t *testing.T) {
+	capacity := RouteCapacity{MaxRoutes: 8}
+	for attachedRoutes, want := range map[int]int{0: 0, 2: 25, 8: 100, 12: 150} {
+		if got := capacity.Utilization(attachedRoutes); got != want {
+			t.Errorf("Utilization(%d) = %d, want %d", attachedRoutes, got, want)
+		}
+	}
+}
@@ -18,10 +18,15 @@ package common
 
 import (
 	"fmt"
+	"runtime/debug"
+	"slices"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
@@ -29,6 +34,9 @@ import (
 	fakedynamicclient "k8s.io/client-go/dynamic/fake"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/metadata"
+	fakemetadataclient "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -43,14 +51,66 @@ type K8sClients struct {
 	Client          client.Client
 	DC              dynamic.Interface
 	DiscoveryClient discovery.DiscoveryInterface
+	// MetadataClient lists resources as PartialObjectMetadata, which is much
+	// cheaper than fetching the full objects when only their metadata is needed.
+	MetadataClient metadata.Interface
 }
 
-func NewK8sClients(kubeconfig string) (*K8sClients, error) {
-	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+// Version is the version of gwctl. It can be set at build time with
+// -ldflags "-X sigs.k8s.io/gateway-api/gwctl/pkg/common.Version=v1.2.3", and
+// otherwise is the version of the gwctl module recorded in the binary, like
+// for go install, or "devel" if none was recorded.
+var Version = ""
+
+// UserAgent is sent with all requests of gwctl, so that they can be told apart
+// from those of kubectl in the audit logs of the API server.
+var UserAgent = "gwctl/" + gwctlVersion()
+
+func gwctlVersion() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "devel"
+}
+
+// NewRESTConfig returns the rest.Config for the context of the kubeconfig, or
+// for its current context if context is empty. If kubeconfig is empty, the
+// standard loading rules are used: the files listed in the KUBECONFIG
+// environment variable are merged, falling back to $HOME/.kube/config if it is
+// not set. Requests are made as the user and groups of impersonate, if its
+// UserName is set.
+func NewRESTConfig(kubeconfig, context string, impersonate rest.ImpersonationConfig) (*rest.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: context}
+	if impersonate.UserName != "" {
+		overrides.AuthInfo.Impersonate = impersonate.UserName
+		overrides.AuthInfo.ImpersonateGroups = impersonate.Groups
+	}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig.UserAgent = UserAgent
+	return restConfig, nil
+}
+
+// NewK8sClients returns clients for the cluster of the context of the
+// kubeconfig, or of its current context if context is empty.
+func NewK8sClients(kubeconfig, context string, impersonate rest.ImpersonationConfig) (*K8sClients, error) {
+	restConfig, err := NewRESTConfig(kubeconfig, context, impersonate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get restConfig from BuildConfigFromFlags: %v", err)
+		return nil, fmt.Errorf("failed to load kubeconfig: %v", err)
 	}
+	return newK8sClientsForConfig(restConfig)
+}
 
+// newK8sClientsForConfig returns clients for the API server of restConfig.
+func newK8sClientsForConfig(restConfig *rest.Config) (*K8sClients, error) {
 	client, err := client.New(restConfig, client.Options{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize Kubernetes client: %v", err)
@@ -68,31 +128,73 @@ func NewK8sClients(kubeconfig string) (*K8sClients, error) {
 		return nil, err
 	}
 
-	dc := dynamic.NewForConfigOrDie(restConfig)
+	dc, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize dynamic client: %v", err)
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize discovery client: %v", err)
+	}
+	metadataClient, err := metadata.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize metadata client: %v", err)
+	}
 
 	return &K8sClients{
 		Client:          client,
 		DC:              dc,
-		DiscoveryClient: discovery.NewDiscoveryClientForConfigOrDie(restConfig),
+		DiscoveryClient: discoveryClient,
+		MetadataClient:  metadataClient,
 	}, nil
 }
 
 func MustClientsForTest(t *testing.T, initRuntimeObjects ...runtime.Object) *K8sClients {
+	k8sClients, err := NewK8sClientsForObjects(initRuntimeObjects...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k8sClients
+}
+
+// NewK8sClientsForObjects returns fake clients which serve the objects from
+// memory, for tests. Unstructured objects of kinds which the clients know are converted to their
+// typed form, so that they are served by the typed Client as well. The
+// resources of kinds defined by CustomResourceDefinitions among the objects
+// are served under the plural name declared by the CRD.
+func NewK8sClientsForObjects(initRuntimeObjects ...runtime.Object) (*K8sClients, error) {
 	scheme := scheme.Scheme
 	if err := gatewayv1alpha3.Install(scheme); err != nil {
-		t.Fatal(err)
+		return nil, err
 	}
 	if err := gatewayv1alpha2.Install(scheme); err != nil {
-		t.Fatal(err)
+		return nil, err
 	}
 	if err := gatewayv1beta1.Install(scheme); err != nil {
-		t.Fatal(err)
+		return nil, err
 	}
 	if err := gatewayv1.Install(scheme); err != nil {
-		t.Fatal(err)
+		return nil, err
 	}
 	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
-		t.Fatal(err)
+		return nil, err
+	}
+
+	initRuntimeObjects = slices.Clone(initRuntimeObjects)
+	for i, obj := range initRuntimeObjects {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok || !scheme.Recognizes(u.GroupVersionKind()) {
+			continue
+		}
+		typed, err := scheme.New(u.GroupVersionKind())
+		if err != nil {
+			return nil, err
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), typed); err != nil {
+			return nil, fmt.Errorf("failed to convert %v %v/%v: %v", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+		}
+		typed.GetObjectKind().SetGroupVersionKind(u.GroupVersionKind())
+		initRuntimeObjects[i] = typed
 	}
 
 	// These extractorFuncs are used to properly mock the kubernetes client
@@ -140,6 +242,9 @@ func MustClientsForTest(t *testing.T, initRuntimeObjects ...runtime.Object) *K8s
 	gvrToListKind := map[schema.GroupVersionResource]string{
 		gatewayv1GVR: "GatewayList",
 	}
+	// The plural names of the kinds defined by CRDs, which may not be guessed
+	// correctly either.
+	crdResources := make(map[schema.GroupKind]string)
 	for _, obj := range initRuntimeObjects {
 		if crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition); ok {
 			gvr := schema.GroupVersionResource{
@@ -148,24 +253,63 @@ func MustClientsForTest(t *testing.T, initRuntimeObjects ...runtime.Object) *K8s
 				Resource: crd.Spec.Names.Plural, // CRD Kinds directly map to the Resource.
 			}
 			gvrToListKind[gvr] = crd.Spec.Names.Kind + "List"
+			crdResources[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = crd.Spec.Names.Plural
+		}
+	}
+	// gvrFor returns the GVR under which the object is served, if it cannot be
+	// guessed from its kind.
+	gvrFor := func(obj runtime.Object, gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool) {
+		if _, ok := obj.(*gatewayv1.Gateway); ok {
+			return gatewayv1GVR, true
+		}
+		if resource, ok := crdResources[gvk.GroupKind()]; ok {
+			return gvk.GroupVersion().WithResource(resource), true
 		}
+		return schema.GroupVersionResource{}, false
 	}
+
 	fakeDC := fakedynamicclient.NewSimpleDynamicClientWithCustomListKinds(scheme, gvrToListKind)
 	for _, obj := range initRuntimeObjects {
 		var err error
-		if gateway, ok := obj.(*gatewayv1.Gateway); ok {
-			// Register Gateway with correct GVR. This needs to be done explicitly for
-			// Gateway since the automatically guess GVR is incorrect.
+		if gvr, ok := gvrFor(obj, obj.GetObjectKind().GroupVersionKind()); ok {
+			// Register the object with the correct GVR. This needs to be done
+			// explicitly for Gateway since the automatically guessed GVR is
+			// incorrect.
 			//
 			// Automatic guessing of GVR uses `meta.UnsafeGuessKindToResource()` which
 			// pluralizes "gateway" to "gatewaies" (since the singular ends in a 'y')
-			err = fakeDC.Tracker().Create(gatewayv1GVR, gateway, gateway.Namespace)
+			var accessor metav1.Object
+			accessor, err = meta.Accessor(obj)
+			if err == nil {
+				err = fakeDC.Tracker().Create(gvr, obj, accessor.GetNamespace())
+			}
 		} else {
-			// Register non-Gateway resources automatically without GVR.
+			// Register other resources automatically without GVR.
 			err = fakeDC.Tracker().Add(obj)
 		}
 		if err != nil {
-			t.Fatalf("Failed to add object to fake DynamicClient: %v", err)
+			return nil, fmt.Errorf("failed to add object to fake DynamicClient: %v", err)
+		}
+	}
+
+	// The fake MetadataClient only serves PartialObjectMetadata, so the metadata
+	// of each object is registered separately. As with the DynamicClient, the GVR
+	// of Gateways must be provided explicitly.
+	metadataScheme := fakemetadataclient.NewTestScheme()
+	metav1.AddMetaToScheme(metadataScheme)
+	fakeMetadataClient := fakemetadataclient.NewSimpleMetadataClient(metadataScheme)
+	for _, obj := range initRuntimeObjects {
+		partialObjectMetadata, err := partialObjectMetadataFor(scheme, obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert object to PartialObjectMetadata: %v", err)
+		}
+		if gvr, ok := gvrFor(obj, partialObjectMetadata.GroupVersionKind()); ok {
+			err = fakeMetadataClient.Tracker().Create(gvr, partialObjectMetadata, partialObjectMetadata.Namespace)
+		} else {
+			err = fakeMetadataClient.Tracker().Add(partialObjectMetadata)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to add object to fake MetadataClient: %v", err)
 		}
 	}
 
@@ -173,7 +317,26 @@ func MustClientsForTest(t *testing.T, initRuntimeObjects ...runtime.Object) *K8s
 		Client:          fakeClient,
 		DC:              fakeDC,
 		DiscoveryClient: fakeDiscoveryClient,
+		MetadataClient:  fakeMetadataClient,
+	}, nil
+}
+
+func partialObjectMetadataFor(scheme *runtime.Scheme, obj runtime.Object) (*metav1.PartialObjectMetadata, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() {
+		gvks, _, err := scheme.ObjectKinds(obj)
+		if err != nil {
+			return nil, err
+		}
+		gvk = gvks[0]
 	}
+	partialObjectMetadata := meta.AsPartialObjectMetadata(accessor)
+	partialObjectMetadata.SetGroupVersionKind(gvk)
+	return partialObjectMetadata, nil
 }
 
 func PtrTo[T any](a T) *T {
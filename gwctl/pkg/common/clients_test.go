@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+)
+
+// The current context of the first kubeconfig is only defined in the second
+// kubeconfig, which authenticates using an exec plugin.
+const (
+	kubeconfigWithCurrentContext = `
+apiVersion: v1
+kind: Config
+current-context: second
+clusters:
+- name: first
+  cluster:
+    server: https://first.example.com
+contexts:
+- name: first
+  context:
+    cluster: first
+    user: first
+users:
+- name: first
+  user:
+    token: first-token
+`
+	kubeconfigWithExecPlugin = `
+apiVersion: v1
+kind: Config
+current-context: second
+clusters:
+- name: second
+  cluster:
+    server: https://second.example.com
+contexts:
+- name: second
+  context:
+    cluster: second
+    user: second
+users:
+- name: second
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: gke-gcloud-auth-plugin
+      interactiveMode: IfAvailable
+`
+)
+
+func writeKubeconfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	return path
+}
+
+func TestNewRESTConfig_MultipleKubeconfigPaths(t *testing.T) {
+	first := writeKubeconfig(t, "first", kubeconfigWithCurrentContext)
+	second := writeKubeconfig(t, "second", kubeconfigWithExecPlugin)
+	t.Setenv("KUBECONFIG", strings.Join([]string{first, second}, string(os.PathListSeparator)))
+
+	restConfig, err := NewRESTConfig("", "", rest.ImpersonationConfig{})
+	if err != nil {
+		t.Fatalf("NewRESTConfig() failed: %v", err)
+	}
+	if got, want := restConfig.Host, "https://second.example.com"; got != want {
+		t.Errorf("NewRESTConfig() returned Host=%q; want %q", got, want)
+	}
+	if restConfig.ExecProvider == nil || restConfig.ExecProvider.Command != "gke-gcloud-auth-plugin" {
+		t.Errorf("NewRESTConfig() returned ExecProvider=%+v; want exec plugin gke-gcloud-auth-plugin", restConfig.ExecProvider)
+	}
+}
+
+func TestNewRESTConfig_ExplicitPath(t *testing.T) {
+	first := writeKubeconfig(t, "first", kubeconfigWithCurrentContext)
+	second := writeKubeconfig(t, "second", kubeconfigWithExecPlugin)
+	t.Setenv("KUBECONFIG", first)
+
+	// The explicit path takes precedence over the KUBECONFIG environment
+	// variable.
+	restConfig, err := NewRESTConfig(second, "", rest.ImpersonationConfig{})
+	if err != nil {
+		t.Fatalf("NewRESTConfig() failed: %v", err)
+	}
+	if got, want := restConfig.Host, "https://second.example.com"; got != want {
+		t.Errorf("NewRESTConfig() returned Host=%q; want %q", got, want)
+	}
+}
+
+func TestNewRESTConfig_Context(t *testing.T) {
+	first := writeKubeconfig(t, "first", kubeconfigWithCurrentContext)
+	second := writeKubeconfig(t, "second", kubeconfigWithExecPlugin)
+	t.Setenv("KUBECONFIG", strings.Join([]string{first, second}, string(os.PathListSeparator)))
+
+	// The context takes precedence over the current context.
+	restConfig, err := NewRESTConfig("", "first", rest.ImpersonationConfig{})
+	if err != nil {
+		t.Fatalf("NewRESTConfig() failed: %v", err)
+	}
+	if got, want := restConfig.Host, "https://first.example.com"; got != want {
+		t.Errorf("NewRESTConfig() returned Host=%q; want %q", got, want)
+	}
+
+	if _, err := NewRESTConfig("", "missing", rest.ImpersonationConfig{}); err == nil {
+		t.Errorf("NewRESTConfig() did not return an error for a context which does not exist")
+	}
+}
+
+func TestNewRESTConfig_ImpersonationAndUserAgent(t *testing.T) {
+	path := writeKubeconfig(t, "config", kubeconfigWithExecPlugin)
+
+	restConfig, err := NewRESTConfig(path, "", rest.ImpersonationConfig{UserName: "jane", Groups: []string{"sre"}})
+	if err != nil {
+		t.Fatalf("NewRESTConfig() failed: %v", err)
+	}
+	if diff := cmp.Diff(rest.ImpersonationConfig{UserName: "jane", Groups: []string{"sre"}}, restConfig.Impersonate, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("NewRESTConfig() returned unexpected Impersonate (-want +got):\n%v", diff)
+	}
+	if got, want := restConfig.UserAgent, "gwctl/devel"; got != want {
+		t.Errorf("NewRESTConfig() returned UserAgent=%q; want %q", got, want)
+	}
+}
+
+func TestGwctlVersion(t *testing.T) {
+	// Test binaries are not built from a module version.
+	if got, want := gwctlVersion(), "devel"; got != want {
+		t.Errorf("gwctlVersion() = %q; want %q", got, want)
+	}
+
+	defer func(version string) { Version = version }(Version)
+	Version = "v1.2.3"
+	if got, want := gwctlVersion(), "v1.2.3"; got != want {
+		t.Errorf("gwctlVersion() = %q with Version set; want %q", got, want)
+	}
+}
+
+func TestExplainAuthError(t *testing.T) {
+	testcases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "exec plugin not found",
+			err:  errors.New(`failed to list CRDs: Get "https://second.example.com/apis": getting credentials: exec: executable gke-gcloud-auth-plugin not found`),
+			want: "exec plugin gke-gcloud-auth-plugin not found in PATH; install it or fix the exec command of the current user in the kubeconfig",
+		},
+		{
+			name: "exec plugin failed",
+			err:  errors.New(`getting credentials: exec: executable aws failed with exit code 255`),
+			want: "exec plugin aws failed with exit code 255; run it directly to see why it could not provide credentials",
+		},
+		{
+			name: "unauthorized",
+			err:  apierrors.NewUnauthorized("Unauthorized"),
+			want: "the API server rejected the credentials of the current context; they may have expired, so try logging in again: Unauthorized",
+		},
+		{
+			name: "unrelated error",
+			err:  errors.New("connection refused"),
+			want: "connection refused",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ExplainAuthError(tc.err).Error(); got != tc.want {
+				t.Errorf("ExplainAuthError() = %q; want %q", got, tc.want)
+			}
+		})
+	}
+}
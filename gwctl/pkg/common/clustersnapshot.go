@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ClusterSnapshotFormatVersion is the version of the format in which
+// ClusterSnapshots are written. It must be incremented whenever the format
+// changes in a way which older versions of gwctl cannot read.
+const ClusterSnapshotFormatVersion = 1
+
+// ClusterSnapshot is a copy of the resources of a cluster which gwctl reads,
+// saved so that commands can later be run against it instead of against the
+// cluster.
+type ClusterSnapshot struct {
+	// CreatedAt is when the snapshot was taken.
+	CreatedAt metav1.Time
+	// Objects are the resources of the cluster, including the CRDs which define
+	// the kinds of the other resources.
+	Objects []unstructured.Unstructured
+}
+
+// clusterSnapshotFile is the serialized form of a ClusterSnapshot, as gzipped
+// JSON.
+type clusterSnapshotFile struct {
+	FormatVersion int                         `json:"formatVersion"`
+	CreatedAt     metav1.Time                 `json:"createdAt"`
+	Objects       []unstructured.Unstructured `json:"objects"`
+}
+
+// WriteClusterSnapshot writes the snapshot to w in the current format.
+func WriteClusterSnapshot(w io.Writer, snapshot *ClusterSnapshot) error {
+	gzipWriter := gzip.NewWriter(w)
+	file := clusterSnapshotFile{
+		FormatVersion: ClusterSnapshotFormatVersion,
+		CreatedAt:     snapshot.CreatedAt,
+		Objects:       snapshot.Objects,
+	}
+	if err := json.NewEncoder(gzipWriter).Encode(&file); err != nil {
+		gzipWriter.Close()
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// ReadClusterSnapshot reads a snapshot written by WriteClusterSnapshot. It
+// returns an error if the snapshot was written in a format which this version
+// of gwctl does not support.
+func ReadClusterSnapshot(r io.Reader) (*ClusterSnapshot, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a gwctl snapshot: %v", err)
+	}
+	defer gzipReader.Close()
+
+	file := clusterSnapshotFile{}
+	if err := json.NewDecoder(gzipReader).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %v", err)
+	}
+	if file.FormatVersion != ClusterSnapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d; this version of gwctl only supports version %d", file.FormatVersion, ClusterSnapshotFormatVersion)
+	}
+	return &ClusterSnapshot{CreatedAt: file.CreatedAt, Objects: file.Objects}, nil
+}
+
+// ReadClusterSnapshotFile reads the snapshot from the file.
+func ReadClusterSnapshotFile(path string) (*ClusterSnapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	snapshot, err := ReadClusterSnapshot(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %v: %v", path, err)
+	}
+	return snapshot, nil
+}
+
+// K8sClients returns clients which serve the resources of the snapshot.
+func (s *ClusterSnapshot) K8sClients() (*K8sClients, error) {
+	return NewOfflineK8sClients(s.Objects)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// standardConditionTypes are the condition types defined by Gateway API which
+// are shared by its resources. Implementations may add their own condition
+// types alongside these, which are considered implementation-specific.
+var standardConditionTypes = map[string]bool{
+	"Accepted":     true,
+	"Programmed":   true,
+	"ResolvedRefs": true,
+	"Conflicted":   true,
+}
+
+// IsStandardConditionType returns true if the condition type is one of the
+// standard Gateway API condition types.
+func IsStandardConditionType(conditionType string) bool {
+	return standardConditionTypes[conditionType]
+}
+
+// GroupConditions splits the conditions into the standard and the
+// implementation-specific ones, preserving their order within each group.
+func GroupConditions(conditions []metav1.Condition) (standard, implementationSpecific []metav1.Condition) {
+	for _, condition := range conditions {
+		if IsStandardConditionType(condition.Type) {
+			standard = append(standard, condition)
+		} else {
+			implementationSpecific = append(implementationSpecific, condition)
+		}
+	}
+	return standard, implementationSpecific
+}
+
+// FindStandardCondition returns the first condition of the standard condition
+// type, or nil if there is no such condition. Implementation-specific
+// conditions are never returned, even if their type matches.
+func FindStandardCondition(conditions []metav1.Condition, conditionType string) *metav1.Condition {
+	if !IsStandardConditionType(conditionType) {
+		return nil
+	}
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// recentlyDegradedWindow is how recently a condition must have transitioned to
+// False to be reported as recently degraded.
+const recentlyDegradedWindow = 10 * time.Minute
+
+// FindRecentlyDegradedConditions returns a message for each condition which
+// transitioned to False within the recentlyDegradedWindow. prefix identifies
+// the owner of the conditions within the messages, e.g. "Listener http".
+func FindRecentlyDegradedConditions(c clock.PassiveClock, prefix string, conditions []metav1.Condition) []string {
+	var result []string
+	for _, condition := range conditions {
+		if condition.Status != metav1.ConditionFalse || condition.LastTransitionTime.IsZero() {
+			continue
+		}
+		if c.Since(condition.LastTransitionTime.Time) > recentlyDegradedWindow {
+			continue
+		}
+		result = append(result, fmt.Sprintf("%v condition %v=False for %v (recently degraded)",
+			prefix, condition.Type, duration.HumanDuration(c.Since(condition.LastTransitionTime.Time))))
+	}
+	return result
+}
+
+// FindRecentlyDegradedGatewayConditions returns a message for each condition of
+// the Gateway or its listeners which recently transitioned to False.
+func FindRecentlyDegradedGatewayConditions(c clock.PassiveClock, gateway *gatewayv1.Gateway) []string {
+	prefix := fmt.Sprintf("Gateway %v", client.ObjectKeyFromObject(gateway))
+	result := FindRecentlyDegradedConditions(c, prefix, gateway.Status.Conditions)
+	for _, listener := range gateway.Status.Listeners {
+		result = append(result, FindRecentlyDegradedConditions(c, fmt.Sprintf("%v listener %v", prefix, listener.Name), listener.Conditions)...)
+	}
+	return result
+}
+
+// UnprogrammedListener is a listener in the spec of a Gateway which is not
+// Programmed according to the status of the Gateway.
+type UnprogrammedListener struct {
+	Name    gatewayv1.SectionName
+	Reason  string
+	Message string
+}
+
+// FindUnprogrammedListeners returns the listeners in the spec of the Gateway
+// which are not Programmed, matching the spec and status listeners by name. A
+// listener without a status, or without a Programmed condition, is reported
+// with the reason "NotReported".
+func FindUnprogrammedListeners(gateway *gatewayv1.Gateway) []UnprogrammedListener {
+	statusByName := make(map[gatewayv1.SectionName]*gatewayv1.ListenerStatus)
+	for i := range gateway.Status.Listeners {
+		statusByName[gateway.Status.Listeners[i].Name] = &gateway.Status.Listeners[i]
+	}
+
+	var result []UnprogrammedListener
+	for _, listener := range gateway.Spec.Listeners {
+		status, ok := statusByName[listener.Name]
+		if !ok {
+			result = append(result, UnprogrammedListener{Name: listener.Name, Reason: "NotReported", Message: "The listener has no status"})
+			continue
+		}
+		programmed := FindStandardCondition(status.Conditions, "Programmed")
+		if programmed == nil {
+			result = append(result, UnprogrammedListener{Name: listener.Name, Reason: "NotReported", Message: "The listener has no Programmed condition"})
+			continue
+		}
+		if programmed.Status != metav1.ConditionTrue {
+			result = append(result, UnprogrammedListener{Name: listener.Name, Reason: programmed.Reason, Message: programmed.Message})
+		}
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestGroupConditions(t *testing.T) {
+	testcases := []struct {
+		name                       string
+		conditions                 []metav1.Condition
+		wantStandard               []metav1.Condition
+		wantImplementationSpecific []metav1.Condition
+	}{
+		{
+			name: "no conditions",
+		},
+		{
+			name: "only standard conditions",
+			conditions: []metav1.Condition{
+				{Type: "Programmed"},
+				{Type: "Accepted"},
+			},
+			wantStandard: []metav1.Condition{
+				{Type: "Programmed"},
+				{Type: "Accepted"},
+			},
+		},
+		{
+			name: "mixed conditions preserve their order within each group",
+			conditions: []metav1.Condition{
+				{Type: "networking.example.com/LoadBalancerReady"},
+				{Type: "Accepted"},
+				{Type: "Ready"},
+				{Type: "ResolvedRefs"},
+				{Type: "Conflicted"},
+				{Type: "networking.example.com/Programmed"},
+				{Type: "Programmed"},
+			},
+			wantStandard: []metav1.Condition{
+				{Type: "Accepted"},
+				{Type: "ResolvedRefs"},
+				{Type: "Conflicted"},
+				{Type: "Programmed"},
+			},
+			wantImplementationSpecific: []metav1.Condition{
+				{Type: "networking.example.com/LoadBalancerReady"},
+				{Type: "Ready"},
+				{Type: "networking.example.com/Programmed"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotStandard, gotImplementationSpecific := GroupConditions(tc.conditions)
+			if diff := cmp.Diff(tc.wantStandard, gotStandard); diff != "" {
+				t.Errorf("Unexpected diff in standard conditions (-want +got)=\n%v", diff)
+			}
+			if diff := cmp.Diff(tc.wantImplementationSpecific, gotImplementationSpecific); diff != "" {
+				t.Errorf("Unexpected diff in implementation-specific conditions (-want +got)=\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestFindStandardCondition(t *testing.T) {
+	conditions := []metav1.Condition{
+		{Type: "networking.example.com/Programmed", Status: metav1.ConditionTrue},
+		{Type: "Programmed", Status: metav1.ConditionFalse},
+		{Type: "Ready", Status: metav1.ConditionTrue},
+	}
+
+	if got := FindStandardCondition(conditions, "Programmed"); got == nil || got.Status != metav1.ConditionFalse {
+		t.Errorf("FindStandardCondition(Programmed) = %v; want the standard Programmed condition", got)
+	}
+	if got := FindStandardCondition(conditions, "Accepted"); got != nil {
+		t.Errorf("FindStandardCondition(Accepted) = %v; want nil", got)
+	}
+	if got := FindStandardCondition(conditions, "Ready"); got != nil {
+		t.Errorf("FindStandardCondition(Ready) = %v; want nil for implementation-specific type", got)
+	}
+}
+
+func TestFindUnprogrammedListeners(t *testing.T) {
+	gateway := &gatewayv1.Gateway{
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http"},
+				{Name: "https"},
+				{Name: "grpc"},
+				{Name: "tcp"},
+			},
+		},
+		Status: gatewayv1.GatewayStatus{
+			Listeners: []gatewayv1.ListenerStatus{
+				{Name: "https", Conditions: []metav1.Condition{
+					{Type: "Programmed", Status: metav1.ConditionFalse, Reason: "Invalid", Message: "certificate not found"},
+				}},
+				{Name: "http", Conditions: []metav1.Condition{
+					{Type: "Programmed", Status: metav1.ConditionTrue, Reason: "Programmed"},
+				}},
+				{Name: "grpc", Conditions: []metav1.Condition{
+					{Type: "Accepted", Status: metav1.ConditionTrue, Reason: "Accepted"},
+				}},
+			},
+		},
+	}
+
+	want := []UnprogrammedListener{
+		{Name: "https", Reason: "Invalid", Message: "certificate not found"},
+		{Name: "grpc", Reason: "NotReported", Message: "The listener has no Programmed condition"},
+		{Name: "tcp", Reason: "NotReported", Message: "The listener has no status"},
+	}
+	got := FindUnprogrammedListeners(gateway)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FindUnprogrammedListeners(...) returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigPathEnv is the environment variable which overrides the path of the
+// configuration file.
+const ConfigPathEnv = "GWCTL_CONFIG"
+
+// Config is the configuration file of gwctl, like:
+//
+//	annotationColumns:
+//	- name: owner
+//	  annotation: example.com/owner
+type Config struct {
+	// AnnotationColumns are additional columns of the tables printed by get,
+	// whose values are those of an annotation of each resource. Kinds
+	// registered to print their own tables do not have them.
+	AnnotationColumns []AnnotationColumn `json:"annotationColumns,omitempty"`
+}
+
+// AnnotationColumn is a named column whose values are those of an annotation.
+type AnnotationColumn struct {
+	Name       string `json:"name"`
+	Annotation string `json:"annotation"`
+}
+
+// ConfigPath returns the path of the configuration file, which is the value of
+// GWCTL_CONFIG if set, or gwctl/config.yaml within the user configuration
+// directory, like ~/.config/gwctl/config.yaml on Linux. It is empty if neither
+// is known.
+func ConfigPath() string {
+	if path := os.Getenv(ConfigPathEnv); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gwctl", "config.yaml")
+}
+
+// ReadConfigFile reads the configuration file at the path. A missing file is
+// an empty configuration, since the configuration file is optional.
+func ReadConfigFile(path string) (*Config, error) {
+	if path == "" {
+		return &Config{}, nil
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(b, config); err != nil {
+		return nil, fmt.Errorf("failed to read configuration file %v: %v", path, err)
+	}
+	for _, column := range config.AnnotationColumns {
+		if column.Name == "" || column.Annotation == "" {
+			return nil, fmt.Errorf("invalid configuration file %v: annotationColumns must have a name and an annotation", path)
+		}
+	}
+	return config, nil
+}
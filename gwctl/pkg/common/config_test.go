@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadConfigFile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		contents *string
+		want     *Config
+		wantErr  bool
+	}{
+		{
+			name: "annotation columns",
+			contents: PtrTo(`
+annotationColumns:
+- name: owner
+  annotation: example.com/owner
+- name: tier
+  annotation: example.com/tier
+`),
+			want: &Config{AnnotationColumns: []AnnotationColumn{
+				{Name: "owner", Annotation: "example.com/owner"},
+				{Name: "tier", Annotation: "example.com/tier"},
+			}},
+		},
+		{
+			name: "missing file is an empty configuration",
+			want: &Config{},
+		},
+		{
+			name: "column without annotation",
+			contents: PtrTo(`
+annotationColumns:
+- name: owner
+`),
+			wantErr: true,
+		},
+		{
+			name:     "unknown field",
+			contents: PtrTo(`labelColumns: [app]`),
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if tc.contents != nil {
+				if err := os.WriteFile(path, []byte(*tc.contents), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := ReadConfigFile(path)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ReadConfigFile(...) returned err=%v; wantErr=%v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ReadConfigFile(...) returned unexpected diff (-want, +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestConfigPath(t *testing.T) {
+	t.Setenv(ConfigPathEnv, "/etc/gwctl.yaml")
+	if got := ConfigPath(); got != "/etc/gwctl.yaml" {
+		t.Errorf("ConfigPath() = %q; want %q", got, "/etc/gwctl.yaml")
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change
+// in a unified diff.
+const diffContextLines = 3
+
+// DiffYAML returns a unified diff between the YAML representations of from
+// and to, or an empty string if they are equal. The order of items within
+// lists is ignored, so lists which only differ in their ordering are
+// considered equal.
+func DiffYAML(fromName string, from any, toName string, to any) (string, error) {
+	fromLines, err := normalizedYAMLLines(from)
+	if err != nil {
+		return "", err
+	}
+	toLines, err := normalizedYAMLLines(to)
+	if err != nil {
+		return "", err
+	}
+	return unifiedDiff(fromName, fromLines, toName, toLines), nil
+}
+
+// normalizedYAMLLines returns the lines of the YAML representation of v, with
+// all lists sorted.
+func normalizedYAMLLines(v any) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	// Round-trip through JSON so that typed values are converted to their
+	// generic representation.
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	sortLists(generic)
+
+	b, err = yaml.Marshal(generic)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimSuffix(string(b), "\n"), "\n"), nil
+}
+
+// sortLists recursively sorts the items of all lists within v by their JSON
+// representation.
+func sortLists(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for _, value := range v {
+			sortLists(value)
+		}
+	case []any:
+		keys := make(map[int]string, len(v))
+		for i, item := range v {
+			sortLists(item)
+			b, _ := json.Marshal(item)
+			keys[i] = string(b)
+		}
+		indices := make([]int, len(v))
+		for i := range indices {
+			indices[i] = i
+		}
+		sort.SliceStable(indices, func(i, j int) bool {
+			return keys[indices[i]] < keys[indices[j]]
+		})
+		sorted := make([]any, len(v))
+		for i, index := range indices {
+			sorted[i] = v[index]
+		}
+		copy(v, sorted)
+	}
+}
+
+type diffOp struct {
+	kind byte // One of ' ', '-' or '+'.
+	line string
+}
+
+// unifiedDiff returns the unified diff between the lines of from and to, or
+// an empty string if they are equal.
+func unifiedDiff(fromName string, from []string, toName string, to []string) string {
+	ops := diffLines(from, to)
+
+	// Find the ranges of ops which make up each hunk, merging hunks whose
+	// context overlaps.
+	type hunkRange struct{ start, end int }
+	var hunks []hunkRange
+	for i, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		start, end := max(i-diffContextLines, 0), min(i+diffContextLines+1, len(ops))
+		if len(hunks) != 0 && start <= hunks[len(hunks)-1].end {
+			hunks[len(hunks)-1].end = end
+			continue
+		}
+		hunks = append(hunks, hunkRange{start, end})
+	}
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %v\n+++ %v\n", fromName, toName)
+	fromLine, toLine, next := 1, 1, 0
+	for _, hunk := range hunks {
+		// Only unchanged lines are skipped between hunks.
+		fromLine, toLine = fromLine+hunk.start-next, toLine+hunk.start-next
+		var fromCount, toCount int
+		var body strings.Builder
+		for _, op := range ops[hunk.start:hunk.end] {
+			if op.kind != '+' {
+				fromCount++
+			}
+			if op.kind != '-' {
+				toCount++
+			}
+			fmt.Fprintf(&body, "%c%v\n", op.kind, op.line)
+		}
+		fmt.Fprintf(&b, "@@ -%v +%v @@\n%v", hunkPosition(fromLine, fromCount), hunkPosition(toLine, toCount), body.String())
+		fromLine, toLine, next = fromLine+fromCount, toLine+toCount, hunk.end
+	}
+	return b.String()
+}
+
+func hunkPosition(line, count int) string {
+	if count == 0 {
+		// An empty range refers to the line before it.
+		return fmt.Sprintf("%v,0", line-1)
+	}
+	return fmt.Sprintf("%v,%v", line, count)
+}
+
+// diffLines returns the edit script transforming from into to, based on their
+// longest common subsequence.
+func diffLines(from, to []string) []diffOp {
+	// lcs[i][j] is the length of the longest common subsequence of from[i:]
+	// and to[j:].
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			ops = append(ops, diffOp{' ', from[i]})
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', from[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		ops = append(ops, diffOp{'-', from[i]})
+	}
+	for ; j < len(to); j++ {
+		ops = append(ops, diffOp{'+', to[j]})
+	}
+	return ops
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffYAML(t *testing.T) {
+	testcases := []struct {
+		name string
+		from any
+		to   any
+		want string
+	}{
+		{
+			name: "equal",
+			from: map[string]any{"a": "b"},
+			to:   map[string]any{"a": "b"},
+			want: "",
+		},
+		{
+			name: "nested lists differ only in ordering",
+			from: map[string]any{
+				"listeners": []any{
+					map[string]any{"name": "http", "hostnames": []any{"a.com", "b.com"}},
+					map[string]any{"name": "https", "port": 443},
+				},
+			},
+			to: map[string]any{
+				"listeners": []any{
+					map[string]any{"port": 443, "name": "https"},
+					map[string]any{"hostnames": []any{"b.com", "a.com"}, "name": "http"},
+				},
+			},
+			want: "",
+		},
+		{
+			name: "changed value",
+			from: map[string]any{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8, "i": 9},
+			to:   map[string]any{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "h": 8, "i": 10},
+			want: `--- from
++++ to
+@@ -6,4 +6,4 @@
+ f: 6
+ g: 7
+ h: 8
+-i: 9
++i: 10
+`,
+		},
+		{
+			name: "added and removed values",
+			from: map[string]any{"a": 1, "c": 3},
+			to:   map[string]any{"b": 2, "c": 3},
+			want: `--- from
++++ to
+@@ -1,2 +1,2 @@
+-a: 1
++b: 2
+ c: 3
+`,
+		},
+		{
+			name: "from is empty",
+			from: nil,
+			to:   map[string]any{"a": 1},
+			want: `--- from
++++ to
+@@ -0,0 +1,1 @@
++a: 1
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DiffYAML("from", tc.from, "to", tc.to)
+			if err != nil {
+				t.Fatalf("DiffYAML() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("DiffYAML() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FieldOwner is a field manager which owns some fields of a resource, as
+// recorded in the managedFields of the resource.
+type FieldOwner struct {
+	Manager     string
+	Operation   metav1.ManagedFieldsOperationType
+	Subresource string
+}
+
+// String returns the manager along with how it wrote the fields, like
+// "kubectl (Apply)" or "example-controller (Update, status)".
+func (o FieldOwner) String() string {
+	how := string(o.Operation)
+	if o.Subresource != "" {
+		how += ", " + o.Subresource
+	}
+	return fmt.Sprintf("%v (%v)", o.Manager, how)
+}
+
+// SpecFieldOwners returns the owners of each top-level field of the spec of a
+// resource with the managedFields, keyed by the path of the field like
+// "spec.hostnames". A field with several owners was either applied by all of
+// them with the same value, or is being rewritten by one of them after the
+// other, which is how controllers fighting over a resource show up. The owners
+// of each field are sorted by manager.
+func SpecFieldOwners(managedFields []metav1.ManagedFieldsEntry) (map[string][]FieldOwner, error) {
+	result := make(map[string][]FieldOwner)
+	for _, entry := range managedFields {
+		if entry.FieldsV1 == nil {
+			continue
+		}
+		fields := map[string]any{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return nil, fmt.Errorf("failed to parse the fields of manager %q: %v", entry.Manager, err)
+		}
+		spec, ok := fields["f:spec"].(map[string]any)
+		if !ok {
+			continue
+		}
+		owner := FieldOwner{Manager: entry.Manager, Operation: entry.Operation, Subresource: entry.Subresource}
+		for key := range spec {
+			// Keys of fields are prefixed with "f:". Other keys, like ".", denote
+			// the spec itself rather than one of its fields.
+			name, ok := strings.CutPrefix(key, "f:")
+			if !ok {
+				continue
+			}
+			path := "spec." + name
+			result[path] = append(result[path], owner)
+		}
+	}
+	for _, owners := range result {
+		sort.SliceStable(owners, func(i, j int) bool {
+			return owners[i].Manager < owners[j].Manager
+		})
+	}
+	return result, nil
+}
+
+// SpecManagers returns the distinct managers which own some field of the spec
+// of a resource with the managedFields, sorted.
+func SpecManagers(managedFields []metav1.ManagedFieldsEntry) ([]string, error) {
+	owners, err := SpecFieldOwners(managedFields)
+	if err != nil {
+		return nil, err
+	}
+	managers := make(map[string]bool)
+	for _, fieldOwners := range owners {
+		for _, owner := range fieldOwners {
+			managers[owner.Manager] = true
+		}
+	}
+	result := make([]string, 0, len(managers))
+	for manager := range managers {
+		result = append(result, manager)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// ManagedFieldPaths returns the readable paths of the fields owned through the
+// managedFields entry, sorted. Items of lists are identified by their keys,
+// like "spec.listeners[name=https].port", items of sets by their value, like
+// "metadata.finalizers[=example.com/cleanup]", and items of atomic lists by
+// their index.
+func ManagedFieldPaths(entry metav1.ManagedFieldsEntry) ([]string, error) {
+	if entry.FieldsV1 == nil {
+		return nil, nil
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse the fields of manager %q: %v", entry.Manager, err)
+	}
+	var result []string
+	collectManagedFieldPaths("", fields, &result)
+	sort.Strings(result)
+	return result, nil
+}
+
+// collectManagedFieldPaths appends the paths of the leaves of the fields below
+// the prefix to result. It returns the number of fields below the prefix, which
+// is zero if the prefix is a leaf itself.
+func collectManagedFieldPaths(prefix string, fields map[string]any, result *[]string) int {
+	count := 0
+	for key, value := range fields {
+		// The "." key denotes the field itself, rather than one of its fields.
+		if key == "." {
+			continue
+		}
+		path := managedFieldPathElement(prefix, key)
+		children, _ := value.(map[string]any)
+		if collectManagedFieldPaths(path, children, result) == 0 {
+			*result = append(*result, path)
+		}
+		count++
+	}
+	return count
+}
+
+// managedFieldPathElement returns the path of the field with the key of the
+// FieldsV1 format below the prefix.
+func managedFieldPathElement(prefix, key string) string {
+	kind, value, ok := strings.Cut(key, ":")
+	if !ok {
+		return joinFieldPath(prefix, key)
+	}
+	switch kind {
+	case "f":
+		return joinFieldPath(prefix, value)
+	case "k":
+		keys := map[string]any{}
+		if err := json.Unmarshal([]byte(value), &keys); err != nil {
+			return prefix + "[" + value + "]"
+		}
+		var pairs []string
+		for k, v := range keys {
+			pairs = append(pairs, fmt.Sprintf("%v=%v", k, v))
+		}
+		sort.Strings(pairs)
+		return prefix + "[" + strings.Join(pairs, ",") + "]"
+	case "v":
+		var v any
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return prefix + "[=" + value + "]"
+		}
+		return fmt.Sprintf("%v[=%v]", prefix, v)
+	case "i":
+		return prefix + "[" + value + "]"
+	}
+	return joinFieldPath(prefix, key)
+}
+
+func joinFieldPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
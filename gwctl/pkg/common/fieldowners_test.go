@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSpecFieldOwners(t *testing.T) {
+	managedFields := []metav1.ManagedFieldsEntry{
+		{
+			Manager:   "kubectl",
+			Operation: metav1.ManagedFieldsOperationApply,
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:app":{}}},"f:spec":{"f:hostnames":{},"f:parentRefs":{},"f:rules":{}}}`)},
+		},
+		{
+			// A controller which rewrites the rules after they are applied.
+			Manager:   "example-controller",
+			Operation: metav1.ManagedFieldsOperationUpdate,
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{".":{},"f:rules":{}}}`)},
+		},
+		{
+			// Fields of subresources other than spec are ignored.
+			Manager:     "example-controller",
+			Operation:   metav1.ManagedFieldsOperationUpdate,
+			Subresource: "status",
+			FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:parents":{}}}`)},
+		},
+	}
+
+	got, err := SpecFieldOwners(managedFields)
+	if err != nil {
+		t.Fatalf("SpecFieldOwners() returned an unexpected error: %v", err)
+	}
+	kubectl := FieldOwner{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}
+	controller := FieldOwner{Manager: "example-controller", Operation: metav1.ManagedFieldsOperationUpdate}
+	want := map[string][]FieldOwner{
+		"spec.hostnames":  {kubectl},
+		"spec.parentRefs": {kubectl},
+		"spec.rules":      {controller, kubectl},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SpecFieldOwners() returned unexpected owners (-want +got):\n%v", diff)
+	}
+
+	if _, err := SpecFieldOwners([]metav1.ManagedFieldsEntry{{Manager: "kubectl", FieldsV1: &metav1.FieldsV1{Raw: []byte(`not json`)}}}); err == nil {
+		t.Errorf("SpecFieldOwners() did not return an error for invalid fields")
+	}
+}
+
+func TestFieldOwnerString(t *testing.T) {
+	testcases := []struct {
+		owner FieldOwner
+		want  string
+	}{
+		{owner: FieldOwner{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}, want: "kubectl (Apply)"},
+		{owner: FieldOwner{Manager: "example-controller", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status"}, want: "example-controller (Update, status)"},
+	}
+	for _, tc := range testcases {
+		if got := tc.owner.String(); got != tc.want {
+			t.Errorf("String() = %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestManagedFieldPaths(t *testing.T) {
+	entry := metav1.ManagedFieldsEntry{
+		Manager:   "kubectl",
+		Operation: metav1.ManagedFieldsOperationApply,
+		FieldsV1: &metav1.FieldsV1{Raw: []byte(`{
+			"f:metadata":{"f:finalizers":{".":{},"v:\"example.com/cleanup\"":{}}},
+			"f:spec":{
+				".":{},
+				"f:gatewayClassName":{},
+				"f:listeners":{".":{},"k:{\"name\":\"https\"}":{".":{},"f:name":{},"f:port":{}}},
+				"f:addresses":{"i:0":{}}
+			}
+		}`)},
+	}
+	got, err := ManagedFieldPaths(entry)
+	if err != nil {
+		t.Fatalf("ManagedFieldPaths() returned an unexpected error: %v", err)
+	}
+	want := []string{
+		"metadata.finalizers[=example.com/cleanup]",
+		"spec.addresses[0]",
+		"spec.gatewayClassName",
+		"spec.listeners[name=https].name",
+		"spec.listeners[name=https].port",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ManagedFieldPaths() returned unexpected paths (-want +got):\n%v", diff)
+	}
+}
+
+func TestSpecManagers(t *testing.T) {
+	managedFields := []metav1.ManagedFieldsEntry{
+		{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:rules":{}}}`)}},
+		{Manager: "example-controller", Operation: metav1.ManagedFieldsOperationUpdate, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:rules":{}}}`)}},
+		{Manager: "example-controller", Operation: metav1.ManagedFieldsOperationApply, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:hostnames":{}}}`)}},
+		// Managers which only own the status are not counted.
+		{Manager: "status-controller", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:status":{}}`)}},
+	}
+	got, err := SpecManagers(managedFields)
+	if err != nil {
+		t.Fatalf("SpecManagers() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"example-controller", "kubectl"}, got); diff != "" {
+		t.Errorf("SpecManagers() returned unexpected managers (-want +got):\n%v", diff)
+	}
+}
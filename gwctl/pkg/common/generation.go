@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IsStale returns true if the status conditions have not observed the given
+// generation of the spec, meaning the controller has not reconciled the latest
+// changes. Conditions which do not record an observedGeneration are ignored,
+// so a resource is only considered stale if at least one condition records an
+// observedGeneration and every such condition is older than the generation.
+func IsStale(generation int64, conditions []metav1.Condition) bool {
+	observed := false
+	for _, condition := range conditions {
+		if condition.ObservedGeneration == 0 {
+			continue
+		}
+		observed = true
+		if condition.ObservedGeneration >= generation {
+			return false
+		}
+	}
+	return observed
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsStale(t *testing.T) {
+	testcases := []struct {
+		name       string
+		generation int64
+		conditions []metav1.Condition
+		want       bool
+	}{
+		{
+			name:       "no conditions",
+			generation: 2,
+			want:       false,
+		},
+		{
+			name:       "conditions missing observedGeneration entirely",
+			generation: 2,
+			conditions: []metav1.Condition{
+				{Type: "Accepted"},
+				{Type: "ResolvedRefs"},
+			},
+			want: false,
+		},
+		{
+			name:       "all conditions observed the latest generation",
+			generation: 2,
+			conditions: []metav1.Condition{
+				{Type: "Accepted", ObservedGeneration: 2},
+				{Type: "ResolvedRefs", ObservedGeneration: 2},
+			},
+			want: false,
+		},
+		{
+			name:       "some condition observed the latest generation",
+			generation: 2,
+			conditions: []metav1.Condition{
+				{Type: "Accepted", ObservedGeneration: 1},
+				{Type: "ResolvedRefs", ObservedGeneration: 2},
+			},
+			want: false,
+		},
+		{
+			name:       "every condition is older than the generation",
+			generation: 3,
+			conditions: []metav1.Condition{
+				{Type: "Accepted", ObservedGeneration: 1},
+				{Type: "ResolvedRefs", ObservedGeneration: 2},
+			},
+			want: true,
+		},
+		{
+			name:       "conditions missing observedGeneration are ignored",
+			generation: 3,
+			conditions: []metav1.Condition{
+				{Type: "Accepted", ObservedGeneration: 2},
+				{Type: "ResolvedRefs"},
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsStale(tc.generation, tc.conditions); got != tc.want {
+				t.Errorf("IsStale(%v, ...) = %v; want %v", tc.generation, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,392 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/rest"
+)
+
+// offlineResource is a resource served by an offlineAPIServer.
+type offlineResource struct {
+	gvk        schema.GroupVersionKind
+	resource   string
+	namespaced bool
+	shortNames []string
+}
+
+// offlineBuiltinResources are the resources of kinds not defined by CRDs which
+// offline clients serve. Secrets are served without objects, so that they are
+// reported as not found.
+var offlineBuiltinResources = []offlineResource{
+	{gvk: corev1.SchemeGroupVersion.WithKind("Namespace"), resource: "namespaces", shortNames: []string{"ns"}},
+	{gvk: corev1.SchemeGroupVersion.WithKind("Service"), resource: "services", namespaced: true, shortNames: []string{"svc"}},
+	{gvk: corev1.SchemeGroupVersion.WithKind("Secret"), resource: "secrets", namespaced: true},
+	{gvk: corev1.SchemeGroupVersion.WithKind("Event"), resource: "events", namespaced: true, shortNames: []string{"ev"}},
+	{gvk: discoveryv1.SchemeGroupVersion.WithKind("EndpointSlice"), resource: "endpointslices", namespaced: true},
+	{gvk: apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"), resource: "customresourcedefinitions", shortNames: []string{"crd", "crds"}},
+}
+
+// NewOfflineK8sClients returns clients which read the objects from memory
+// instead of from a cluster, like when replaying a snapshot of a cluster. The
+// clients are the same as those for a cluster, but their requests are answered
+// by a read-only API server which serves the objects. The resources of kinds
+// defined by CustomResourceDefinitions among the objects are served as declared
+// by the CRD, and the other objects must be of offlineBuiltinResources. Objects
+// are served at every version of their kind without conversion, as the versions
+// of the Gateway API kinds share the same schema.
+func NewOfflineK8sClients(objects []unstructured.Unstructured) (*K8sClients, error) {
+	server, err := newOfflineAPIServer(objects)
+	if err != nil {
+		return nil, err
+	}
+	restConfig := &rest.Config{
+		// The host is never contacted, since all requests go to the server.
+		Host:          "https://offline.gwctl.invalid",
+		ContentConfig: rest.ContentConfig{ContentType: runtime.ContentTypeJSON},
+		UserAgent:     UserAgent,
+		Transport:     server,
+		// Requests are answered from memory, so they are not rate limited.
+		QPS: -1,
+	}
+	return newK8sClientsForConfig(restConfig)
+}
+
+// offlineAPIServer is an http.RoundTripper which answers the get and list
+// requests of Kubernetes clients from objects held in memory, like an API
+// server whose resources cannot be changed.
+type offlineAPIServer struct {
+	// resources are the resources served under each GroupVersion.
+	resources map[schema.GroupVersion][]offlineResource
+	// objects are the objects of each resource, at the version they were
+	// given in.
+	objects map[schema.GroupResource][]unstructured.Unstructured
+}
+
+func newOfflineAPIServer(objects []unstructured.Unstructured) (*offlineAPIServer, error) {
+	s := &offlineAPIServer{
+		resources: make(map[schema.GroupVersion][]offlineResource),
+		objects:   make(map[schema.GroupResource][]unstructured.Unstructured),
+	}
+	for _, resource := range offlineBuiltinResources {
+		s.addResource(resource)
+	}
+	for _, obj := range objects {
+		if obj.GroupVersionKind().GroupKind() != apiextensionsv1.Kind("CustomResourceDefinition") {
+			continue
+		}
+		crd := apiextensionsv1.CustomResourceDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &crd); err != nil {
+			return nil, fmt.Errorf("failed to convert CRD %v: %v", obj.GetName(), err)
+		}
+		for _, crdVersion := range crd.Spec.Versions {
+			if !crdVersion.Served {
+				continue
+			}
+			s.addResource(offlineResource{
+				gvk:        schema.GroupVersionKind{Group: crd.Spec.Group, Version: crdVersion.Name, Kind: crd.Spec.Names.Kind},
+				resource:   crd.Spec.Names.Plural,
+				namespaced: crd.Spec.Scope == apiextensionsv1.NamespaceScoped,
+				shortNames: crd.Spec.Names.ShortNames,
+			})
+		}
+	}
+
+	for _, obj := range objects {
+		resource, ok := s.resourceForGroupKind(obj.GroupVersionKind().GroupKind())
+		if !ok {
+			return nil, fmt.Errorf("no resource is known for %v %v", obj.GetKind(), obj.GetName())
+		}
+		gr := schema.GroupResource{Group: resource.gvk.Group, Resource: resource.resource}
+		s.objects[gr] = append(s.objects[gr], *obj.DeepCopy())
+	}
+	return s, nil
+}
+
+func (s *offlineAPIServer) addResource(resource offlineResource) {
+	gv := resource.gvk.GroupVersion()
+	for _, existing := range s.resources[gv] {
+		if existing.resource == resource.resource {
+			return
+		}
+	}
+	s.resources[gv] = append(s.resources[gv], resource)
+}
+
+func (s *offlineAPIServer) resourceForGroupKind(gk schema.GroupKind) (offlineResource, bool) {
+	for gv, resources := range s.resources {
+		if gv.Group != gk.Group {
+			continue
+		}
+		for _, resource := range resources {
+			if resource.gvk.Kind == gk.Kind {
+				return resource, true
+			}
+		}
+	}
+	return offlineResource{}, false
+}
+
+func (s *offlineAPIServer) resourceFor(gvr schema.GroupVersionResource) (offlineResource, bool) {
+	for _, resource := range s.resources[gvr.GroupVersion()] {
+		if resource.resource == gvr.Resource {
+			return resource, true
+		}
+	}
+	return offlineResource{}, false
+}
+
+func (s *offlineAPIServer) RoundTrip(req *http.Request) (*http.Response, error) {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	var gv schema.GroupVersion
+	switch {
+	case len(segments) == 1 && segments[0] == "api":
+		return s.respond(req, http.StatusOK, &metav1.APIVersions{
+			TypeMeta: metav1.TypeMeta{Kind: "APIVersions"},
+			Versions: []string{corev1.SchemeGroupVersion.Version},
+		})
+	case len(segments) == 1 && segments[0] == "apis":
+		return s.respond(req, http.StatusOK, s.apiGroupList())
+	case len(segments) >= 2 && segments[0] == "api":
+		gv, segments = schema.GroupVersion{Version: segments[1]}, segments[2:]
+	case len(segments) >= 3 && segments[0] == "apis":
+		gv, segments = schema.GroupVersion{Group: segments[1], Version: segments[2]}, segments[3:]
+	default:
+		return s.respondError(req, apierrors.NewNotFound(schema.GroupResource{}, req.URL.Path))
+	}
+	if len(segments) == 0 {
+		return s.respond(req, http.StatusOK, s.apiResourceList(gv))
+	}
+
+	var namespace, name string
+	resourceName := segments[0]
+	switch len(segments) {
+	case 1:
+	case 2:
+		name = segments[1]
+	case 3, 4:
+		if segments[0] != "namespaces" {
+			// Subresources, like status, are not served.
+			return s.respondError(req, apierrors.NewNotFound(gv.WithResource(resourceName).GroupResource(), segments[1]))
+		}
+		namespace, resourceName = segments[1], segments[2]
+		if len(segments) == 4 {
+			name = segments[3]
+		}
+	default:
+		return s.respondError(req, apierrors.NewNotFound(gv.WithResource(resourceName).GroupResource(), req.URL.Path))
+	}
+	gvr := gv.WithResource(resourceName)
+	resource, ok := s.resourceFor(gvr)
+	if !ok {
+		return s.respondError(req, apierrors.NewNotFound(gvr.GroupResource(), name))
+	}
+	if req.Method != http.MethodGet {
+		return s.respondError(req, apierrors.NewMethodNotSupported(gvr.GroupResource(), strings.ToLower(req.Method)))
+	}
+	if name != "" {
+		return s.get(req, resource, namespace, name)
+	}
+	return s.list(req, resource, namespace)
+}
+
+func (s *offlineAPIServer) get(req *http.Request, resource offlineResource, namespace, name string) (*http.Response, error) {
+	gr := schema.GroupResource{Group: resource.gvk.Group, Resource: resource.resource}
+	for _, obj := range s.objects[gr] {
+		if obj.GetNamespace() != namespace || obj.GetName() != name {
+			continue
+		}
+		obj := obj.DeepCopy()
+		obj.SetGroupVersionKind(resource.gvk)
+		if wantsMetadataOnly(req) {
+			partialObjectMetadata, err := toPartialObjectMetadata(obj)
+			if err != nil {
+				return s.respondError(req, apierrors.NewInternalError(err))
+			}
+			return s.respond(req, http.StatusOK, partialObjectMetadata)
+		}
+		return s.respond(req, http.StatusOK, obj)
+	}
+	return s.respondError(req, apierrors.NewNotFound(gr, name))
+}
+
+func (s *offlineAPIServer) list(req *http.Request, resource offlineResource, namespace string) (*http.Response, error) {
+	query := req.URL.Query()
+	labelSelector, err := labels.Parse(query.Get("labelSelector"))
+	if err != nil {
+		return s.respondError(req, apierrors.NewBadRequest(err.Error()))
+	}
+	fieldSelector, err := fields.ParseSelector(query.Get("fieldSelector"))
+	if err != nil {
+		return s.respondError(req, apierrors.NewBadRequest(err.Error()))
+	}
+
+	var items []unstructured.Unstructured
+	for _, obj := range s.objects[schema.GroupResource{Group: resource.gvk.Group, Resource: resource.resource}] {
+		if namespace != "" && obj.GetNamespace() != namespace {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(obj.GetLabels())) || !fieldSelector.Matches(offlineFieldsFor(obj)) {
+			continue
+		}
+		obj := obj.DeepCopy()
+		obj.SetGroupVersionKind(resource.gvk)
+		items = append(items, *obj)
+	}
+
+	if wantsMetadataOnly(req) {
+		list := &metav1.PartialObjectMetadataList{
+			TypeMeta: metav1.TypeMeta{APIVersion: metav1.SchemeGroupVersion.String(), Kind: "PartialObjectMetadataList"},
+		}
+		for i := range items {
+			partialObjectMetadata, err := toPartialObjectMetadata(&items[i])
+			if err != nil {
+				return s.respondError(req, apierrors.NewInternalError(err))
+			}
+			list.Items = append(list.Items, *partialObjectMetadata)
+		}
+		return s.respond(req, http.StatusOK, list)
+	}
+	list := &unstructured.UnstructuredList{Object: map[string]any{}, Items: items}
+	list.SetGroupVersionKind(resource.gvk.GroupVersion().WithKind(resource.gvk.Kind + "List"))
+	return s.respond(req, http.StatusOK, list)
+}
+
+// apiGroupList lists the groups other than the core group, with their
+// versions sorted from the most to the least preferred.
+func (s *offlineAPIServer) apiGroupList() *metav1.APIGroupList {
+	versionsByGroup := make(map[string][]string)
+	for gv := range s.resources {
+		if gv.Group != "" {
+			versionsByGroup[gv.Group] = append(versionsByGroup[gv.Group], gv.Version)
+		}
+	}
+	result := &metav1.APIGroupList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "APIGroupList"}}
+	for group, versions := range versionsByGroup {
+		sort.Slice(versions, func(i, j int) bool {
+			return version.CompareKubeAwareVersionStrings(versions[i], versions[j]) > 0
+		})
+		apiGroup := metav1.APIGroup{Name: group}
+		for _, v := range versions {
+			apiGroup.Versions = append(apiGroup.Versions, metav1.GroupVersionForDiscovery{GroupVersion: group + "/" + v, Version: v})
+		}
+		apiGroup.PreferredVersion = apiGroup.Versions[0]
+		result.Groups = append(result.Groups, apiGroup)
+	}
+	sort.Slice(result.Groups, func(i, j int) bool { return result.Groups[i].Name < result.Groups[j].Name })
+	return result
+}
+
+func (s *offlineAPIServer) apiResourceList(gv schema.GroupVersion) *metav1.APIResourceList {
+	result := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{APIVersion: "v1", Kind: "APIResourceList"},
+		GroupVersion: gv.String(),
+	}
+	for _, resource := range s.resources[gv] {
+		result.APIResources = append(result.APIResources, metav1.APIResource{
+			Name:         resource.resource,
+			SingularName: strings.ToLower(resource.gvk.Kind),
+			Namespaced:   resource.namespaced,
+			Kind:         resource.gvk.Kind,
+			Verbs:        metav1.Verbs{"get", "list"},
+			ShortNames:   resource.shortNames,
+		})
+	}
+	return result
+}
+
+func (s *offlineAPIServer) respond(req *http.Request, code int, obj any) (*http.Response, error) {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode:    code,
+		Status:        fmt.Sprintf("%d %v", code, http.StatusText(code)),
+		Header:        http.Header{"Content-Type": []string{runtime.ContentTypeJSON}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (s *offlineAPIServer) respondError(req *http.Request, err *apierrors.StatusError) (*http.Response, error) {
+	status := err.Status()
+	status.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}
+	return s.respond(req, int(status.Code), &status)
+}
+
+// wantsMetadataOnly returns true if the request only accepts the metadata of the
+// objects, like those of the metadata client.
+func wantsMetadataOnly(req *http.Request) bool {
+	return strings.Contains(req.Header.Get("Accept"), "as=PartialObjectMetadata")
+}
+
+func toPartialObjectMetadata(obj *unstructured.Unstructured) (*metav1.PartialObjectMetadata, error) {
+	result := &metav1.PartialObjectMetadata{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), result); err != nil {
+		return nil, err
+	}
+	result.TypeMeta = metav1.TypeMeta{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind()}
+	return result, nil
+}
+
+// offlineFieldsFor returns the fields of the object which field selectors can
+// refer to: the name and namespace of all objects, and the fields of Events
+// which the API server supports.
+func offlineFieldsFor(obj unstructured.Unstructured) fields.Set {
+	result := fields.Set{
+		"metadata.name":      obj.GetName(),
+		"metadata.namespace": obj.GetNamespace(),
+	}
+	if obj.GroupVersionKind().GroupKind() == corev1.SchemeGroupVersion.WithKind("Event").GroupKind() {
+		for _, path := range [][]string{
+			{"involvedObject", "kind"},
+			{"involvedObject", "namespace"},
+			{"involvedObject", "name"},
+			{"involvedObject", "uid"},
+			{"involvedObject", "apiVersion"},
+			{"involvedObject", "resourceVersion"},
+			{"involvedObject", "fieldPath"},
+			{"reason"},
+			{"reportingComponent"},
+			{"type"},
+		} {
+			value, _, _ := unstructured.NestedString(obj.Object, path...)
+			result[strings.Join(path, ".")] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestNewOfflineK8sClients(t *testing.T) {
+	object := func(content map[string]any) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: content}
+	}
+	objects := []unstructured.Unstructured{
+		object(map[string]any{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata":   map[string]any{"name": "gateways.gateway.networking.k8s.io"},
+			"spec": map[string]any{
+				"group": gatewayv1.GroupName,
+				"scope": "Namespaced",
+				"names": map[string]any{"plural": "gateways", "kind": "Gateway", "shortNames": []any{"gtw"}},
+				"versions": []any{
+					map[string]any{"name": "v1beta1", "served": true, "storage": false},
+					map[string]any{"name": "v1", "served": true, "storage": true},
+				},
+			},
+		}),
+		object(map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]any{"name": "default"},
+		}),
+		object(map[string]any{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "Gateway",
+			"metadata": map[string]any{
+				"name":      "foo-gateway",
+				"namespace": "default",
+				"labels":    map[string]any{"app": "foo"},
+				"managedFields": []any{
+					map[string]any{"manager": "kubectl", "operation": "Apply"},
+				},
+			},
+			"spec": map[string]any{"gatewayClassName": "foo-gatewayclass"},
+		}),
+		object(map[string]any{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "Gateway",
+			"metadata":   map[string]any{"name": "bar-gateway", "namespace": "default"},
+			"spec":       map[string]any{"gatewayClassName": "bar-gatewayclass"},
+		}),
+		object(map[string]any{
+			"apiVersion":     "v1",
+			"kind":           "Event",
+			"metadata":       map[string]any{"name": "foo-gateway-event", "namespace": "default"},
+			"involvedObject": map[string]any{"kind": "Gateway", "name": "foo-gateway", "namespace": "default"},
+			"reason":         "Programmed",
+		}),
+		object(map[string]any{
+			"apiVersion":     "v1",
+			"kind":           "Event",
+			"metadata":       map[string]any{"name": "bar-gateway-event", "namespace": "default"},
+			"involvedObject": map[string]any{"kind": "Gateway", "name": "bar-gateway", "namespace": "default"},
+			"reason":         "Pending",
+		}),
+	}
+	k8sClients, err := NewOfflineK8sClients(objects)
+	if err != nil {
+		t.Fatalf("NewOfflineK8sClients() returned an unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	gateway := &gatewayv1.Gateway{}
+	if err := k8sClients.Client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "foo-gateway"}, gateway); err != nil {
+		t.Fatalf("Get() returned an unexpected error: %v", err)
+	}
+	if got, want := gateway.Spec.GatewayClassName, gatewayv1.ObjectName("foo-gatewayclass"); got != want {
+		t.Errorf("Get() returned a Gateway with gatewayClassName %v, want %v", got, want)
+	}
+	if len(gateway.ManagedFields) != 1 {
+		t.Errorf("Get() returned a Gateway with managedFields %v, want those of the object", gateway.ManagedFields)
+	}
+
+	events := &corev1.EventList{}
+	if err := k8sClients.Client.List(ctx, events, client.InNamespace("default"), client.MatchingFields{"involvedObject.name": "foo-gateway"}); err != nil {
+		t.Fatalf("List() returned an unexpected error: %v", err)
+	}
+	var gotReasons []string
+	for _, event := range events.Items {
+		gotReasons = append(gotReasons, event.Reason)
+	}
+	if diff := cmp.Diff([]string{"Programmed"}, gotReasons); diff != "" {
+		t.Errorf("List() returned unexpected diff in Events (-want +got):\n%v", diff)
+	}
+
+	// Objects are served at every version of their kind.
+	gatewaysGVR := schema.GroupVersionResource{Group: gatewayv1.GroupName, Version: "v1beta1", Resource: "gateways"}
+	gatewayList, err := k8sClients.DC.Resource(gatewaysGVR).Namespace("default").List(ctx, metav1.ListOptions{LabelSelector: "app=foo"})
+	if err != nil {
+		t.Fatalf("List() returned an unexpected error: %v", err)
+	}
+	var gotGateways []string
+	for _, item := range gatewayList.Items {
+		gotGateways = append(gotGateways, item.GetAPIVersion()+" "+item.GetName())
+	}
+	if diff := cmp.Diff([]string{"gateway.networking.k8s.io/v1beta1 foo-gateway"}, gotGateways); diff != "" {
+		t.Errorf("List() returned unexpected diff in Gateways (-want +got):\n%v", diff)
+	}
+
+	metadataList, err := k8sClients.MetadataClient.Resource(gatewaysGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("List() of metadata returned an unexpected error: %v", err)
+	}
+	var gotNames []string
+	for _, item := range metadataList.Items {
+		gotNames = append(gotNames, item.Name)
+	}
+	if diff := cmp.Diff([]string{"foo-gateway", "bar-gateway"}, gotNames); diff != "" {
+		t.Errorf("List() of metadata returned unexpected diff (-want +got):\n%v", diff)
+	}
+
+	resourceList, err := k8sClients.DiscoveryClient.ServerResourcesForGroupVersion(gatewayv1.GroupVersion.String())
+	if err != nil {
+		t.Fatalf("ServerResourcesForGroupVersion() returned an unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]metav1.APIResource{{
+		Name:         "gateways",
+		SingularName: "gateway",
+		Namespaced:   true,
+		Kind:         "Gateway",
+		Verbs:        metav1.Verbs{"get", "list"},
+		ShortNames:   []string{"gtw"},
+	}}, resourceList.APIResources); diff != "" {
+		t.Errorf("ServerResourcesForGroupVersion() returned unexpected diff (-want +got):\n%v", diff)
+	}
+
+	// Secrets are served, but never saved.
+	if err := k8sClients.Client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "foo-secret"}, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Get() of a Secret returned error %v, want a NotFound error", err)
+	}
+	if err := k8sClients.Client.Delete(ctx, gateway); !apierrors.IsMethodNotSupported(err) {
+		t.Errorf("Delete() returned error %v, want a MethodNotSupported error", err)
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+)
+
+// ReadObjectsFromFiles reads the objects within the YAML or JSON files. Files
+// may contain multiple documents, and objects of kind List are expanded into
+// their items. The source of each object is returned as well; the items of a
+// List share the source of the List.
+func ReadObjectsFromFiles(paths []string) ([]unstructured.Unstructured, ObjectSources, error) {
+	var result []unstructured.Unstructured
+	sources := make(ObjectSources)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		objects, objectSources, err := readObjects(path, data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read objects from %v: %v", path, err)
+		}
+		for i, obj := range objects {
+			gvk := obj.GroupVersionKind()
+			sources[ObjRef{Group: gvk.Group, Kind: gvk.Kind, Name: obj.GetName(), Namespace: obj.GetNamespace()}] = objectSources[i]
+		}
+		result = append(result, objects...)
+	}
+	return result, sources, nil
+}
+
+// readObjects reads the objects within the file at path, whose contents are
+// data, and returns them along with their sources.
+func readObjects(path string, data []byte) ([]unstructured.Unstructured, []ObjectSource, error) {
+	var documents []yamlDocument
+	if utilyaml.IsJSONBuffer(data) {
+		// The JSON decoder cannot tell the lines of the documents.
+		documents = []yamlDocument{{data: data}}
+	} else {
+		var err error
+		documents, err = splitYAMLDocuments(data)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var result []unstructured.Unstructured
+	var sources []ObjectSource
+	index := 0
+	for _, document := range documents {
+		decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(document.data), 4096)
+		for {
+			obj := &unstructured.Unstructured{}
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, nil, err
+			}
+			if len(obj.Object) == 0 {
+				continue
+			}
+			source := ObjectSource{File: path, Document: index, Line: document.line}
+			index++
+			if obj.IsList() {
+				list, err := obj.ToList()
+				if err != nil {
+					return nil, nil, err
+				}
+				for _, item := range list.Items {
+					result = append(result, item)
+					sources = append(sources, source)
+				}
+				continue
+			}
+			result = append(result, *obj)
+			sources = append(sources, source)
+		}
+	}
+	return result, sources, nil
+}
+
+// NewOverlayK8sClients returns clients which read the objects from live, except
+// that the objects are overlaid on top: they replace the live objects with the
+// same kind, namespace and name, and are added otherwise. Namespaced objects
+// without a namespace are placed in the default namespace. Only the DC client
+// is overlaid, so resources which are read through the other clients (like
+// Namespaces and Events) always come from live. mapper is used to find the
+// resources of the objects.
+func NewOverlayK8sClients(live *K8sClients, mapper meta.RESTMapper, objects []unstructured.Unstructured) (*K8sClients, error) {
+	overlay := make(map[schema.GroupResource][]unstructured.Unstructured)
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the resource for %v %v: %v", gvk.Kind, obj.GetName(), err)
+		}
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace && obj.GetNamespace() == "" {
+			obj.SetNamespace(metav1.NamespaceDefault)
+		}
+		gr := mapping.Resource.GroupResource()
+		overlay[gr] = append(overlay[gr], obj)
+	}
+
+	result := *live
+	result.DC = &overlayDynamicClient{Interface: live.DC, overlay: overlay}
+	return &result, nil
+}
+
+// overlayDynamicClient is a dynamic.Interface which overlays objects on top of
+// the objects read through Interface.
+type overlayDynamicClient struct {
+	dynamic.Interface
+	overlay map[schema.GroupResource][]unstructured.Unstructured
+}
+
+func (c *overlayDynamicClient) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	live := c.Interface.Resource(resource)
+	objects := c.overlay[resource.GroupResource()]
+	if len(objects) == 0 {
+		return live
+	}
+	return &overlayNamespaceableResource{
+		NamespaceableResourceInterface: live,
+		objects:                        objects,
+	}
+}
+
+type overlayNamespaceableResource struct {
+	dynamic.NamespaceableResourceInterface
+	objects []unstructured.Unstructured
+}
+
+func (r *overlayNamespaceableResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return &overlayResource{
+		ResourceInterface: r.NamespaceableResourceInterface.Namespace(namespace),
+		namespace:         namespace,
+		objects:           r.objects,
+	}
+}
+
+func (r *overlayNamespaceableResource) Get(ctx context.Context, name string, options metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return r.cluster().Get(ctx, name, options, subresources...)
+}
+
+func (r *overlayNamespaceableResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return r.cluster().List(ctx, opts)
+}
+
+// cluster returns the interface for the resource across all namespaces, or for
+// cluster scoped resources.
+func (r *overlayNamespaceableResource) cluster() *overlayResource {
+	return &overlayResource{
+		ResourceInterface: r.NamespaceableResourceInterface,
+		objects:           r.objects,
+	}
+}
+
+type overlayResource struct {
+	dynamic.ResourceInterface
+	// namespace is empty for all namespaces, and for cluster scoped resources.
+	namespace string
+	objects   []unstructured.Unstructured
+}
+
+func (r *overlayResource) Get(ctx context.Context, name string, options metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	if len(subresources) == 0 {
+		for _, obj := range r.objects {
+			if obj.GetNamespace() == r.namespace && obj.GetName() == name {
+				return obj.DeepCopy(), nil
+			}
+		}
+	}
+	return r.ResourceInterface.Get(ctx, name, options, subresources...)
+}
+
+func (r *overlayResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.ResourceInterface.List(ctx, opts)
+	if err != nil {
+		// The resource may not be installed yet, in which case it only consists of
+		// the overlaid objects.
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		result = &unstructured.UnstructuredList{}
+	}
+
+	overlaid := make(map[types.NamespacedName]bool)
+	for _, obj := range r.objects {
+		overlaid[types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}] = true
+	}
+	var items []unstructured.Unstructured
+	for _, item := range result.Items {
+		if !overlaid[types.NamespacedName{Namespace: item.GetNamespace(), Name: item.GetName()}] {
+			items = append(items, item)
+		}
+	}
+	for _, obj := range r.objects {
+		if r.namespace != "" && obj.GetNamespace() != r.namespace {
+			continue
+		}
+		if !selector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		items = append(items, *obj.DeepCopy())
+	}
+	result.Items = items
+	return result, nil
+}
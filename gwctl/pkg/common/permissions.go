@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Permission is a permission which gwctl needs to function, in the form of
+// the RBAC rule which grants it.
+type Permission struct {
+	Group    string
+	Resource string
+	Verbs    []string
+	// Namespaced is true if the resource is namespaced, in which case the
+	// permission can be granted within a single namespace.
+	Namespaced bool
+	// ListsAllNamespaces is true if gwctl lists the namespaced resource across
+	// all namespaces, whichever namespace the command is for, like to find the
+	// routes attached to a Gateway. The list verb must then be granted across
+	// all namespaces.
+	ListsAllNamespaces bool
+	// Feature describes what gwctl needs the permission for.
+	Feature string
+}
+
+// GroupVersionResource returns the GroupVersionResource of the resource at the
+// given version.
+func (p Permission) GroupVersionResource(version string) schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: p.Group, Version: version, Resource: p.Resource}
+}
+
+// The permissions needed by gwctl. The clients of gwctl refer to these when
+// fetching resources, so that RequiredPermissions stays in sync with what is
+// actually fetched.
+var (
+	GatewayClassesPermission            = Permission{Group: gatewayv1.GroupName, Resource: "gatewayclasses", Verbs: []string{"get", "list"}, Feature: "GatewayClasses"}
+	GatewaysPermission                  = Permission{Group: gatewayv1.GroupName, Resource: "gateways", Verbs: []string{"get", "list"}, Namespaced: true, Feature: "Gateways"}
+	HTTPRoutesPermission                = Permission{Group: gatewayv1.GroupName, Resource: "httproutes", Verbs: []string{"get", "list"}, Namespaced: true, ListsAllNamespaces: true, Feature: "HTTPRoutes"}
+	GRPCRoutesPermission                = Permission{Group: gatewayv1.GroupName, Resource: "grpcroutes", Verbs: []string{"list"}, Namespaced: true, ListsAllNamespaces: true, Feature: "routes attached to Gateways"}
+	TCPRoutesPermission                 = Permission{Group: gatewayv1.GroupName, Resource: "tcproutes", Verbs: []string{"list"}, Namespaced: true, ListsAllNamespaces: true, Feature: "routes attached to Gateways"}
+	TLSRoutesPermission                 = Permission{Group: gatewayv1.GroupName, Resource: "tlsroutes", Verbs: []string{"list"}, Namespaced: true, ListsAllNamespaces: true, Feature: "routes attached to Gateways"}
+	UDPRoutesPermission                 = Permission{Group: gatewayv1.GroupName, Resource: "udproutes", Verbs: []string{"list"}, Namespaced: true, ListsAllNamespaces: true, Feature: "routes attached to Gateways"}
+	ReferenceGrantsPermission           = Permission{Group: gatewayv1.GroupName, Resource: "referencegrants", Verbs: []string{"get", "list"}, Namespaced: true, ListsAllNamespaces: true, Feature: "cross namespace references"}
+	BackendTLSPoliciesPermission        = Permission{Group: gatewayv1.GroupName, Resource: "backendtlspolicies", Verbs: []string{"list"}, Namespaced: true, Feature: "TLS configuration of Backends"}
+	BackendLBPoliciesPermission         = Permission{Group: gatewayv1.GroupName, Resource: "backendlbpolicies", Verbs: []string{"list"}, Namespaced: true, Feature: "load balancing configuration of Backends"}
+	ServicesPermission                  = Permission{Group: "", Resource: "services", Verbs: []string{"get", "list"}, Namespaced: true, Feature: "Backends"}
+	EndpointSlicesPermission            = Permission{Group: "discovery.k8s.io", Resource: "endpointslices", Verbs: []string{"list"}, Namespaced: true, Feature: "readiness of Backends"}
+	IngressesPermission                 = Permission{Group: "networking.k8s.io", Resource: "ingresses", Verbs: []string{"list"}, Namespaced: true, ListsAllNamespaces: true, Feature: "hostname collisions with Ingresses"}
+	NamespacesPermission                = Permission{Group: "", Resource: "namespaces", Verbs: []string{"get", "list"}, Feature: "Namespaces"}
+	EventsPermission                    = Permission{Group: "", Resource: "events", Verbs: []string{"list"}, Namespaced: true, ListsAllNamespaces: true, Feature: "events of described resources"}
+	SecretsPermission                   = Permission{Group: "", Resource: "secrets", Verbs: []string{"get"}, Namespaced: true, Feature: "certificates of Gateway listeners"}
+	CustomResourceDefinitionsPermission = Permission{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions", Verbs: []string{"list"}, Feature: "Policy CRDs"}
+)
+
+// RequiredPermissions lists every permission needed by gwctl. Policies are not
+// listed since their resources are only known once the Policy CRDs have been
+// discovered.
+var RequiredPermissions = []Permission{
+	GatewayClassesPermission,
+	GatewaysPermission,
+	HTTPRoutesPermission,
+	GRPCRoutesPermission,
+	TCPRoutesPermission,
+	TLSRoutesPermission,
+	UDPRoutesPermission,
+	ReferenceGrantsPermission,
+	BackendTLSPoliciesPermission,
+	BackendLBPoliciesPermission,
+	ServicesPermission,
+	EndpointSlicesPermission,
+	IngressesPermission,
+	NamespacesPermission,
+	EventsPermission,
+	SecretsPermission,
+	CustomResourceDefinitionsPermission,
+}
+
+// PermissionCheck is the result of checking whether the current user is
+// allowed to use one verb of a Permission.
+type PermissionCheck struct {
+	Permission Permission `json:"-"`
+	Group      string     `json:"group"`
+	Resource   string     `json:"resource"`
+	Verb       string     `json:"verb"`
+	// Namespace is the namespace in which the verb was checked. It is empty for
+	// resources which are not namespaced, and when checking all namespaces.
+	Namespace string `json:"namespace,omitempty"`
+	Allowed   bool   `json:"allowed"`
+	// Reason is the reason given by the authorizer for its decision, if any.
+	Reason string `json:"reason,omitempty"`
+}
+
+// CheckPermissions checks each verb of the RequiredPermissions with a
+// SelfSubjectAccessReview. Namespaced resources are checked within namespace,
+// or across all namespaces if namespace is empty. Resources which gwctl lists
+// across all namespaces always have their list verb checked across all
+// namespaces.
+func CheckPermissions(ctx context.Context, c client.Client, namespace string) ([]PermissionCheck, error) {
+	var result []PermissionCheck
+	for _, permission := range RequiredPermissions {
+		for _, verb := range permission.Verbs {
+			checkNamespace := ""
+			if permission.Namespaced && !(permission.ListsAllNamespaces && verb == "list") {
+				checkNamespace = namespace
+			}
+			review := &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace: checkNamespace,
+						Verb:      verb,
+						Group:     permission.Group,
+						Resource:  permission.Resource,
+					},
+				},
+			}
+			if err := c.Create(ctx, review); err != nil {
+				return nil, fmt.Errorf("failed to check permission to %v %v: %v", verb, permission.Resource, err)
+			}
+			result = append(result, PermissionCheck{
+				Permission: permission,
+				Group:      permission.Group,
+				Resource:   permission.Resource,
+				Verb:       verb,
+				Namespace:  checkNamespace,
+				Allowed:    review.Status.Allowed,
+				Reason:     review.Status.Reason,
+			})
+		}
+	}
+	return result, nil
+}
+
+// MissingPermissionsRBAC returns the RBAC resources which grant the denied
+// permissions of checks. Permissions checked within a namespace are granted by
+// a Role within namespace. All other permissions, including those to list
+// namespaced resources across all namespaces, are granted by a ClusterRole. Either may be omitted if it would not contain any
+// rules.
+func MissingPermissionsRBAC(checks []PermissionCheck, namespace string) []client.Object {
+	var roleRules, clusterRoleRules []rbacv1.PolicyRule
+	// Denied verbs are grouped into a single rule per resource, in the order of
+	// the checks.
+	ruleIndex := make(map[schema.GroupResource]int)
+	for _, check := range checks {
+		if check.Allowed {
+			continue
+		}
+		rules := &clusterRoleRules
+		if check.Namespace != "" {
+			rules = &roleRules
+		}
+		key := schema.GroupResource{Group: check.Group, Resource: check.Resource}
+		if i, ok := ruleIndex[key]; ok {
+			(*rules)[i].Verbs = append((*rules)[i].Verbs, check.Verb)
+			continue
+		}
+		ruleIndex[key] = len(*rules)
+		*rules = append(*rules, rbacv1.PolicyRule{
+			APIGroups: []string{check.Group},
+			Resources: []string{check.Resource},
+			Verbs:     []string{check.Verb},
+		})
+	}
+
+	var result []client.Object
+	if len(clusterRoleRules) != 0 {
+		result = append(result, &rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{Name: "gwctl-reader"},
+			Rules:      clusterRoleRules,
+		})
+	}
+	if len(roleRules) != 0 {
+		result = append(result, &rbacv1.Role{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+			ObjectMeta: metav1.ObjectMeta{Name: "gwctl-reader", Namespace: namespace},
+			Rules:      roleRules,
+		})
+	}
+	return result
+}
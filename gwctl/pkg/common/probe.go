@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// The stages of probing a listener, in the order in which they are attempted.
+const (
+	ProbeStageTCP  = "tcp"
+	ProbeStageTLS  = "tls"
+	ProbeStageHTTP = "http"
+)
+
+// ProbeTarget is an address of a Gateway at which one of its listeners is
+// probed.
+type ProbeTarget struct {
+	Listener string
+	Address  string
+	Port     int32
+	Protocol gatewayv1.ProtocolType
+	// ServerName is the hostname sent as SNI during the TLS handshake and as
+	// the Host of the HTTP request. It is empty if the listener serves no
+	// specific hostname, in which case the address is used as the Host.
+	ServerName string `json:",omitempty"`
+}
+
+// ProbeResult is the result of probing a target.
+type ProbeResult struct {
+	ProbeTarget
+	// Stage is the last stage of the probe which was attempted, which is the
+	// one which failed if Error is set. It is empty if the target could not be
+	// probed at all.
+	Stage string `json:",omitempty"`
+	// Latency is the time from starting to connect until the last stage
+	// completed or failed.
+	Latency metav1.Duration
+	// StatusCode is the status of the response to the HTTP HEAD request, for
+	// HTTP and HTTPS listeners.
+	StatusCode int    `json:",omitempty"`
+	Error      string `json:",omitempty"`
+}
+
+// ProbeReport holds the results of probing the listeners of a Gateway.
+type ProbeReport struct {
+	Gateway ObjRef
+	// Origin names the machine which ran the probes. Probes are never run from
+	// within the cluster, so their results only tell whether the listeners are
+	// reachable from that machine.
+	Origin  string
+	Results []ProbeResult
+}
+
+// Prober probes the data plane of Gateways, by connecting to the addresses in
+// their status from the machine gwctl runs on.
+type Prober struct {
+	// Timeout bounds the time spent probing each target.
+	Timeout time.Duration
+	// Concurrency bounds the number of targets probed at the same time.
+	Concurrency int
+}
+
+// Probe probes each of the targets, and returns their results in the same
+// order. Failing to reach a target is reported in its result.
+//
+// A TCP connection is attempted first. For HTTPS and TLS listeners, a TLS
+// handshake follows, without verifying the certificate of the server since
+// only reachability is probed. For HTTP and HTTPS listeners, an HTTP HEAD
+// request is then sent; redirects are never followed.
+func (p Prober) Probe(ctx context.Context, targets []ProbeTarget) []ProbeResult {
+	concurrency := p.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	results := make([]ProbeResult, len(targets))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, target ProbeTarget) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i] = p.probe(ctx, target)
+		}(i, target)
+	}
+	wg.Wait()
+	return results
+}
+
+// probe probes the target. The result is named so that its latency is set
+// however the probe ends.
+func (p Prober) probe(ctx context.Context, target ProbeTarget) (result ProbeResult) {
+	result = ProbeResult{ProbeTarget: target}
+	if target.Protocol == gatewayv1.UDPProtocolType {
+		result.Error = "listeners of protocol UDP cannot be probed"
+		return result
+	}
+	result.Stage = ProbeStageTCP
+
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+	start := time.Now()
+	defer func() {
+		result.Latency = metav1.Duration{Duration: time.Since(start).Round(time.Millisecond)}
+	}()
+
+	address := net.JoinHostPort(target.Address, strconv.Itoa(int(target.Port)))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+	}
+
+	scheme := "http"
+	if target.Protocol == gatewayv1.HTTPSProtocolType || target.Protocol == gatewayv1.TLSProtocolType {
+		result.Stage = ProbeStageTLS
+		tlsConn := tls.Client(conn, &tls.Config{
+			ServerName: target.ServerName,
+			// Only reachability is probed. Certificates are reported by
+			// 'gwctl get certificates'.
+			InsecureSkipVerify: true, //nolint:gosec
+		})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		conn, scheme = tlsConn, "https"
+	}
+
+	if target.Protocol != gatewayv1.HTTPProtocolType && target.Protocol != gatewayv1.HTTPSProtocolType {
+		return result
+	}
+	result.Stage = ProbeStageHTTP
+	host := target.ServerName
+	if host == "" {
+		host = target.Address
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, fmt.Sprintf("%v://%v/", scheme, address), nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	req.Host = host
+	req.Header.Set("User-Agent", "gwctl-probe")
+	req.Close = true
+	// The request is written to the connection directly rather than through an
+	// http.Client, so that redirects are never followed.
+	if err := req.Write(conn); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp.Body.Close()
+	result.StatusCode = resp.StatusCode
+	return result
+}
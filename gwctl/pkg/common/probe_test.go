@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// targetForServer returns a target for the listener of the server.
+func targetForServer(t *testing.T, addr string, protocol gatewayv1.ProtocolType, serverName string) ProbeTarget {
+	t.Helper()
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("Failed to split address %q: %v", addr, err)
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatalf("Failed to parse port %q: %v", port, err)
+	}
+	return ProbeTarget{Listener: "foo-listener", Address: host, Port: int32(portNumber), Protocol: protocol, ServerName: serverName}
+}
+
+func TestProber_Probe(t *testing.T) {
+	var requests atomic.Int32
+	var serverNames atomic.Value
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.TLS != nil {
+			serverNames.Store(r.TLS.ServerName)
+		}
+		http.Redirect(w, r, "https://example.com/", http.StatusMovedPermanently)
+	})
+	httpServer := httptest.NewServer(handler)
+	defer httpServer.Close()
+	httpsServer := httptest.NewTLSServer(handler)
+	defer httpsServer.Close()
+
+	// A listener which accepts connections but never responds.
+	silentListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer silentListener.Close()
+	go func() {
+		for {
+			conn, err := silentListener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, _ = io.Copy(io.Discard, conn)
+				conn.Close()
+			}()
+		}
+	}()
+
+	// An address at which nothing listens.
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	closedAddr := closedListener.Addr().String()
+	closedListener.Close()
+
+	targets := []ProbeTarget{
+		targetForServer(t, httpServer.Listener.Addr().String(), gatewayv1.HTTPProtocolType, ""),
+		targetForServer(t, httpsServer.Listener.Addr().String(), gatewayv1.HTTPSProtocolType, "www.example.com"),
+		targetForServer(t, silentListener.Addr().String(), gatewayv1.TCPProtocolType, ""),
+		targetForServer(t, silentListener.Addr().String(), gatewayv1.HTTPProtocolType, ""),
+		targetForServer(t, closedAddr, gatewayv1.TCPProtocolType, ""),
+		targetForServer(t, closedAddr, gatewayv1.UDPProtocolType, ""),
+	}
+	prober := Prober{Timeout: 500 * time.Millisecond, Concurrency: 2}
+	results := prober.Probe(context.Background(), targets)
+	if len(results) != len(targets) {
+		t.Fatalf("Probe() returned %d results, want %d", len(results), len(targets))
+	}
+
+	type want struct {
+		stage      string
+		statusCode int
+		failed     bool
+	}
+	wants := []want{
+		{stage: ProbeStageHTTP, statusCode: http.StatusMovedPermanently},
+		{stage: ProbeStageHTTP, statusCode: http.StatusMovedPermanently},
+		{stage: ProbeStageTCP},
+		{stage: ProbeStageHTTP, failed: true},
+		{stage: ProbeStageTCP, failed: true},
+		{failed: true},
+	}
+	for i, result := range results {
+		if result.ProbeTarget != targets[i] {
+			t.Errorf("Result %d is for target %+v, want %+v", i, result.ProbeTarget, targets[i])
+		}
+		got := want{stage: result.Stage, statusCode: result.StatusCode, failed: result.Error != ""}
+		if got != wants[i] {
+			t.Errorf("Result %d is %+v with error %q, want %+v", i, got, result.Error, wants[i])
+		}
+	}
+
+	// Redirects are not followed.
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Servers received %d requests, want 2", got)
+	}
+	if got := serverNames.Load(); got != "www.example.com" {
+		t.Errorf("TLS handshake used server name %q, want %q", got, "www.example.com")
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ProgressStep is the progress of a slow operation, e.g. "listed 3/7 kinds".
+type ProgressStep struct {
+	Action string
+	Done   int
+	Total  int
+	Unit   string
+}
+
+func (s ProgressStep) String() string {
+	return fmt.Sprintf("%v %d/%d %v", s.Action, s.Done, s.Total, s.Unit)
+}
+
+// Progress receives the progress of slow operations, like discovering every
+// resource of a large cluster.
+type Progress interface {
+	// Step reports the latest progress.
+	Step(step ProgressStep)
+	// Done reports that the operation is complete. It must be called before
+	// anything is written to stdout.
+	Done()
+}
+
+// NoProgress discards all progress.
+type NoProgress struct{}
+
+func (NoProgress) Step(ProgressStep) {}
+
+func (NoProgress) Done() {}
+
+// spinnerFrames are drawn in turn, one per step.
+var spinnerFrames = []rune{'|', '/', '-', '\\'}
+
+// terminalProgress draws a spinner followed by the latest step on a single
+// line of a terminal, which is cleared once the operation is done.
+type terminalProgress struct {
+	w io.Writer
+
+	mu     sync.Mutex
+	frames int
+}
+
+// NewTerminalProgress returns Progress which is drawn on w. w should be a
+// terminal other than stdout, usually stderr, so the progress never interleaves
+// with the output.
+func NewTerminalProgress(w io.Writer) Progress {
+	return &terminalProgress{w: w}
+}
+
+func (p *terminalProgress) Step(step ProgressStep) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Return to the start of the line and clear it, before drawing the step.
+	fmt.Fprintf(p.w, "\r\033[K%c %v", spinnerFrames[p.frames%len(spinnerFrames)], step)
+	p.frames++
+}
+
+func (p *terminalProgress) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.frames != 0 {
+		fmt.Fprint(p.w, "\r\033[K")
+		p.frames = 0
+	}
+}
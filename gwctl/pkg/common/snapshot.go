@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/metadata"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewSnapshotK8sClients returns clients which list all resources at exactly
+// resourceVersion, so that resources of different types are read from a
+// consistent snapshot of the cluster instead of being torn by concurrent
+// changes. Listing at an exact resourceVersion fails once the API server has
+// compacted it, which typically happens after a few minutes, so this is only
+// done when asked for.
+//
+// If resourceVersion is empty, later lists are instead made at a
+// resourceVersion not older than the one returned by the first list. This
+// never fails due to compaction, but only guarantees that no list sees the
+// cluster in an older state than the first one. Lists which already specify a
+// resourceVersion, and calls other than lists, are passed through unchanged.
+func NewSnapshotK8sClients(live *K8sClients, resourceVersion string) *K8sClients {
+	snapshot := &resourceVersionSnapshot{resourceVersion: resourceVersion, match: metav1.ResourceVersionMatchNotOlderThan}
+	if resourceVersion != "" {
+		snapshot.match = metav1.ResourceVersionMatchExact
+	}
+	result := *live
+	result.Client = &snapshotClient{Client: live.Client, snapshot: snapshot}
+	result.DC = &snapshotDynamicClient{Interface: live.DC, snapshot: snapshot}
+	result.MetadataClient = &snapshotMetadataClient{Interface: live.MetadataClient, snapshot: snapshot}
+	return &result
+}
+
+// resourceVersionSnapshot is the resourceVersion at which all lists happen.
+type resourceVersionSnapshot struct {
+	mu              sync.Mutex
+	resourceVersion string
+	// match is how the resourceVersion of the lists relates to resourceVersion.
+	match metav1.ResourceVersionMatch
+}
+
+// listOptions returns the options with the resourceVersion of the snapshot,
+// unless they already specify one.
+func (s *resourceVersionSnapshot) listOptions(opts metav1.ListOptions) metav1.ListOptions {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if opts.ResourceVersion == "" && s.resourceVersion != "" {
+		opts.ResourceVersion = s.resourceVersion
+		opts.ResourceVersionMatch = s.match
+	}
+	return opts
+}
+
+// observe records the resourceVersion returned by a list, if the snapshot does
+// not have a resourceVersion yet.
+func (s *resourceVersionSnapshot) observe(resourceVersion string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.resourceVersion == "" && resourceVersion != "" {
+		klog.V(1).InfoS("Listing all resources at least at the resourceVersion of the first list", "resourceVersion", resourceVersion)
+		s.resourceVersion = resourceVersion
+	}
+}
+
+type snapshotClient struct {
+	client.Client
+	snapshot *resourceVersionSnapshot
+}
+
+func (c *snapshotClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	raw := metav1.ListOptions{}
+	if listOpts.Raw != nil {
+		raw = *listOpts.Raw
+	}
+	raw = c.snapshot.listOptions(raw)
+	listOpts.Raw = &raw
+	if err := c.Client.List(ctx, list, listOpts); err != nil {
+		return err
+	}
+	c.snapshot.observe(list.GetResourceVersion())
+	return nil
+}
+
+type snapshotDynamicClient struct {
+	dynamic.Interface
+	snapshot *resourceVersionSnapshot
+}
+
+func (c *snapshotDynamicClient) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &snapshotNamespaceableResource{
+		NamespaceableResourceInterface: c.Interface.Resource(resource),
+		snapshot:                       c.snapshot,
+	}
+}
+
+type snapshotNamespaceableResource struct {
+	dynamic.NamespaceableResourceInterface
+	snapshot *resourceVersionSnapshot
+}
+
+func (r *snapshotNamespaceableResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return &snapshotResource{
+		ResourceInterface: r.NamespaceableResourceInterface.Namespace(namespace),
+		snapshot:          r.snapshot,
+	}
+}
+
+func (r *snapshotNamespaceableResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return (&snapshotResource{ResourceInterface: r.NamespaceableResourceInterface, snapshot: r.snapshot}).List(ctx, opts)
+}
+
+type snapshotResource struct {
+	dynamic.ResourceInterface
+	snapshot *resourceVersionSnapshot
+}
+
+func (r *snapshotResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	result, err := r.ResourceInterface.List(ctx, r.snapshot.listOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	r.snapshot.observe(result.GetResourceVersion())
+	return result, nil
+}
+
+type snapshotMetadataClient struct {
+	metadata.Interface
+	snapshot *resourceVersionSnapshot
+}
+
+func (c *snapshotMetadataClient) Resource(resource schema.GroupVersionResource) metadata.Getter {
+	return &snapshotMetadataGetter{
+		Getter:   c.Interface.Resource(resource),
+		snapshot: c.snapshot,
+	}
+}
+
+type snapshotMetadataGetter struct {
+	metadata.Getter
+	snapshot *resourceVersionSnapshot
+}
+
+func (g *snapshotMetadataGetter) Namespace(namespace string) metadata.ResourceInterface {
+	return &snapshotMetadataResource{
+		ResourceInterface: g.Getter.Namespace(namespace),
+		snapshot:          g.snapshot,
+	}
+}
+
+func (g *snapshotMetadataGetter) List(ctx context.Context, opts metav1.ListOptions) (*metav1.PartialObjectMetadataList, error) {
+	return (&snapshotMetadataResource{ResourceInterface: g.Getter, snapshot: g.snapshot}).List(ctx, opts)
+}
+
+type snapshotMetadataResource struct {
+	metadata.ResourceInterface
+	snapshot *resourceVersionSnapshot
+}
+
+func (r *snapshotMetadataResource) List(ctx context.Context, opts metav1.ListOptions) (*metav1.PartialObjectMetadataList, error) {
+	result, err := r.ResourceInterface.List(ctx, r.snapshot.listOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	r.snapshot.observe(result.GetResourceVersion())
+	return result, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// recordingDynamicClient records the options of each list, and returns lists at
+// resourceVersion.
+type recordingDynamicClient struct {
+	dynamic.Interface
+	dynamic.NamespaceableResourceInterface
+	resourceVersion string
+	lists           []metav1.ListOptions
+}
+
+func (c *recordingDynamicClient) Resource(_ schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return c
+}
+
+func (c *recordingDynamicClient) Namespace(_ string) dynamic.ResourceInterface {
+	return c
+}
+
+func (c *recordingDynamicClient) List(_ context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	c.lists = append(c.lists, opts)
+	result := &unstructured.UnstructuredList{}
+	result.SetResourceVersion(c.resourceVersion)
+	return result, nil
+}
+
+func TestNewSnapshotK8sClients(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}
+	exact := func(resourceVersion string) metav1.ListOptions {
+		return metav1.ListOptions{ResourceVersion: resourceVersion, ResourceVersionMatch: metav1.ResourceVersionMatchExact}
+	}
+	notOlderThan := func(resourceVersion string) metav1.ListOptions {
+		return metav1.ListOptions{ResourceVersion: resourceVersion, ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan}
+	}
+
+	testcases := []struct {
+		name            string
+		resourceVersion string
+		want            []metav1.ListOptions
+	}{
+		{
+			name: "resourceVersion of the first list",
+			want: []metav1.ListOptions{{}, notOlderThan("100"), notOlderThan("100")},
+		},
+		{
+			name:            "given resourceVersion",
+			resourceVersion: "42",
+			want:            []metav1.ListOptions{exact("42"), exact("42"), exact("42")},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			live := &recordingDynamicClient{resourceVersion: "100"}
+			clients := NewSnapshotK8sClients(&K8sClients{DC: live}, tc.resourceVersion)
+
+			ctx := context.Background()
+			for _, namespace := range []string{"", "default"} {
+				if _, err := clients.DC.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{}); err != nil {
+					t.Fatalf("List() returned an unexpected error: %v", err)
+				}
+			}
+			if _, err := clients.DC.Resource(gvr).List(ctx, metav1.ListOptions{}); err != nil {
+				t.Fatalf("List() returned an unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, live.lists); diff != "" {
+				t.Errorf("List() was called with unexpected options (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObjectSource is where an object read from a file came from.
+type ObjectSource struct {
+	File string `json:"file"`
+	// Document is the index of the document within the file, starting from 0.
+	// Empty documents are not counted.
+	Document int `json:"document"`
+	// Line is the line of the file on which the document starts, starting from
+	// 1. It is 0 if it is not known, like for JSON files.
+	Line int `json:"line,omitempty"`
+}
+
+// String returns the source like "manifests/routes/checkout.yaml#2".
+func (s ObjectSource) String() string {
+	return fmt.Sprintf("%v#%d", s.File, s.Document)
+}
+
+// ObjectSources maps the objects read from files to their source.
+type ObjectSources map[ObjRef]ObjectSource
+
+// Lookup returns the source of the object, or nil if it was not read from a
+// file. Objects in the default namespace are also found if their namespace was
+// omitted in the file.
+func (s ObjectSources) Lookup(objRef ObjRef) *ObjectSource {
+	if source, ok := s[objRef]; ok {
+		return &source
+	}
+	if objRef.Namespace == metav1.NamespaceDefault {
+		objRef.Namespace = ""
+		if source, ok := s[objRef]; ok {
+			return &source
+		}
+	}
+	return nil
+}
+
+// yamlDocument is a document of a YAML stream, along with the line on which it
+// starts.
+type yamlDocument struct {
+	data []byte
+	line int
+}
+
+// splitYAMLDocuments splits the YAML stream into its documents, separated by
+// lines starting with "---" like k8s.io/apimachinery/pkg/util/yaml does. The
+// line of each document is its first line which is neither blank nor a
+// comment, and documents without any such line are dropped.
+func splitYAMLDocuments(data []byte) ([]yamlDocument, error) {
+	var result []yamlDocument
+	var current yamlDocument
+	flush := func() {
+		if current.line != 0 {
+			result = append(result, current)
+		}
+		current = yamlDocument{}
+	}
+	for i, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if bytes.HasPrefix(line, []byte("---")) {
+			trimmed := strings.TrimSpace(string(line[len("---"):]))
+			if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+				return nil, fmt.Errorf("invalid YAML document separator on line %d: %v", i+1, trimmed)
+			}
+			flush()
+			continue
+		}
+		if trimmed := strings.TrimSpace(string(line)); current.line == 0 && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			current.line = i + 1
+		}
+		current.data = append(current.data, line...)
+	}
+	flush()
+	return result, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadObjectsFromFiles_Sources(t *testing.T) {
+	dir := t.TempDir()
+	routesPath := filepath.Join(dir, "routes.yaml")
+	routes := `# Routes of the checkout team.
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: cart
+  namespace: checkout
+---
+---
+# The payment route.
+
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: payment
+--- # Services.
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: payment-svc
+`
+	servicesPath := filepath.Join(dir, "services.json")
+	services := `{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "cart-svc", "namespace": "checkout"}}
+{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "legacy-svc", "namespace": "checkout"}}`
+	for path, contents := range map[string]string{routesPath: routes, servicesPath: services} {
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	objects, sources, err := ReadObjectsFromFiles([]string{routesPath, servicesPath})
+	if err != nil {
+		t.Fatalf("ReadObjectsFromFiles() returned an unexpected error: %v", err)
+	}
+	if len(objects) != 5 {
+		t.Errorf("ReadObjectsFromFiles() returned %d objects, want 5", len(objects))
+	}
+	want := ObjectSources{
+		{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Name: "cart", Namespace: "checkout"}: {File: routesPath, Document: 0, Line: 2},
+		{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Name: "payment"}:                     {File: routesPath, Document: 1, Line: 11},
+		{Kind: "Service", Name: "payment-svc"}:                                                       {File: routesPath, Document: 2, Line: 16},
+		{Kind: "Service", Name: "cart-svc", Namespace: "checkout"}:                                   {File: servicesPath, Document: 0},
+		{Kind: "Service", Name: "legacy-svc", Namespace: "checkout"}:                                 {File: servicesPath, Document: 1},
+	}
+	if diff := cmp.Diff(want, sources); diff != "" {
+		t.Errorf("ReadObjectsFromFiles() returned unexpected sources (-want +got):\n%v", diff)
+	}
+
+	// The namespace of objects in the default namespace may be omitted.
+	got := sources.Lookup(ObjRef{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Name: "payment", Namespace: "default"})
+	if got == nil || got.String() != routesPath+"#1" {
+		t.Errorf("Lookup() = %v, want %v#1", got, routesPath)
+	}
+	if got := sources.Lookup(ObjRef{Kind: "Service", Name: "cart-svc", Namespace: "default"}); got != nil {
+		t.Errorf("Lookup() = %v, want nil", got)
+	}
+}
+
+func TestReadObjectsFromFiles_InvalidSeparator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.yaml")
+	if err := os.WriteFile(path, []byte("kind: HTTPRoute\n--- kind: Service\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ReadObjectsFromFiles([]string{path}); err == nil {
+		t.Errorf("ReadObjectsFromFiles() did not return an error for an invalid separator")
+	}
+}
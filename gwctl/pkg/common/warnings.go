@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Warning is a problem found with a resource which does not prevent a command
+// from completing, like a reference to a resource which does not exist.
+type Warning struct {
+	Resource ObjRef
+	Message  string
+}
+
+func (w Warning) String() string {
+	name := w.Resource.Name
+	if w.Resource.Namespace != "" {
+		name = w.Resource.Namespace + "/" + name
+	}
+	return fmt.Sprintf("%v %v: %v", w.Resource.Kind, name, w.Message)
+}
+
+// Warnings collects the warnings found by all the discoveries and analyses of
+// a command, so that they can be reported together once the command completes.
+// A nil *Warnings discards all warnings.
+type Warnings struct {
+	mu       sync.Mutex
+	warnings []Warning
+}
+
+// Add records the warning about the resource, unless the same warning has
+// already been recorded.
+func (w *Warnings) Add(resource ObjRef, message string) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	warning := Warning{Resource: resource, Message: message}
+	if !slices.Contains(w.warnings, warning) {
+		w.warnings = append(w.warnings, warning)
+	}
+}
+
+// List returns the recorded warnings, sorted by resource and message.
+func (w *Warnings) List() []Warning {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	result := slices.Clone(w.warnings)
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Resource.Kind != b.Resource.Kind {
+			return a.Resource.Kind < b.Resource.Kind
+		}
+		if a.Resource.Namespace != b.Resource.Namespace {
+			return a.Resource.Namespace < b.Resource.Namespace
+		}
+		if a.Resource.Name != b.Resource.Name {
+			return a.Resource.Name < b.Resource.Name
+		}
+		return a.Message < b.Message
+	})
+	return result
+}
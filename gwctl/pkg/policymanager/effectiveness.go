@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policymanager
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Effectiveness describes how many of the fields of a policy survive into the
+// effective policies of the resources it affects.
+type Effectiveness string
+
+const (
+	// EffectivenessFull means every field of the policy takes effect on some
+	// resource.
+	EffectivenessFull Effectiveness = "yes"
+	// EffectivenessPartial means only some of the fields of the policy take
+	// effect, the others being shadowed by policies of higher precedence.
+	EffectivenessPartial Effectiveness = "partial"
+	// EffectivenessNone means no field of the policy takes effect on any
+	// resource, either because all of them are shadowed by policies of higher
+	// precedence or because the policy affects no resource at all. Such
+	// policies are candidates for cleanup.
+	EffectivenessNone Effectiveness = "no"
+)
+
+// ComputeEffectiveness returns the Effectiveness of each of the policies, keyed
+// by the Name of the policy, given the effective policies of every resource. A
+// field of a policy survives into an effective policy if the policy was merged
+// into it and the effective policy has the same value for the field. A policy
+// without fields is fully effective if it was merged into some effective
+// policy.
+//
+// The fields of each policy, and those of each effective policy, are only
+// computed once, even though the same effective policy is usually shared by
+// several resources.
+func ComputeEffectiveness(policies []Policy, effectivePolicies []map[PolicyCrdID]Policy) map[string]Effectiveness {
+	fieldsByPolicy := make(map[string]map[string][]byte, len(policies))
+	for _, policy := range policies {
+		fieldsByPolicy[policy.Name()] = encodedFields(policy)
+	}
+
+	// surviving records the surviving fields of each policy which was merged
+	// into some effective policy.
+	surviving := make(map[string]map[string]bool)
+	for _, policiesByKind := range effectivePolicies {
+		for _, effectivePolicy := range policiesByKind {
+			var effectiveFields map[string][]byte
+			for _, contributor := range effectivePolicy.provenanceOrSelf().contributors {
+				fields, ok := fieldsByPolicy[contributor]
+				if !ok {
+					continue
+				}
+				if surviving[contributor] == nil {
+					surviving[contributor] = make(map[string]bool)
+				}
+				if effectiveFields == nil {
+					effectiveFields = encodedFields(effectivePolicy)
+				}
+				for path, value := range fields {
+					if effectiveValue, ok := effectiveFields[path]; ok && bytes.Equal(value, effectiveValue) {
+						surviving[contributor][path] = true
+					}
+				}
+			}
+		}
+	}
+
+	result := make(map[string]Effectiveness, len(policies))
+	for _, policy := range policies {
+		name := policy.Name()
+		survivingFields, merged := surviving[name]
+		switch {
+		case !merged:
+			result[name] = EffectivenessNone
+		case len(survivingFields) == len(fieldsByPolicy[name]):
+			result[name] = EffectivenessFull
+		case len(survivingFields) == 0:
+			result[name] = EffectivenessNone
+		default:
+			result[name] = EffectivenessPartial
+		}
+	}
+	return result
+}
+
+// encodedFields returns the leaf values of the effective spec of the policy,
+// keyed by their paths and encoded as JSON. Encoding allows values to be
+// compared regardless of whether merging turned integers into floats.
+func encodedFields(policy Policy) map[string][]byte {
+	spec, err := policy.EffectiveSpec()
+	if err != nil {
+		return nil
+	}
+	result := make(map[string][]byte)
+	for path, value := range flattenFields(spec, nil) {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		result[path] = encoded
+	}
+	return result
+}
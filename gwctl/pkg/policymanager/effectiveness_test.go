@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policymanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestComputeEffectiveness(t *testing.T) {
+	newPolicy := func(kind, name string, spec map[string]interface{}) Policy {
+		return Policy{
+			u: unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "foo.com/v1",
+					"kind":       kind,
+					"metadata": map[string]interface{}{
+						"name":      name,
+						"namespace": "default",
+					},
+					"spec": spec,
+				},
+			},
+			inherited: true,
+		}
+	}
+
+	policies := []Policy{
+		newPolicy("HealthCheckPolicy", "health-check-a", map[string]interface{}{
+			"default": map[string]interface{}{"interval": int64(5)},
+		}),
+		newPolicy("HealthCheckPolicy", "health-check-b", map[string]interface{}{
+			"default": map[string]interface{}{"interval": int64(10), "timeout": int64(1)},
+		}),
+		newPolicy("RetryPolicy", "retry-x", map[string]interface{}{
+			"default": map[string]interface{}{"attempts": int64(1)},
+		}),
+		newPolicy("RetryPolicy", "retry-y", map[string]interface{}{
+			"default": map[string]interface{}{"attempts": int64(2)},
+		}),
+		newPolicy("TimeoutPolicy", "timeout-orphan", map[string]interface{}{
+			"default": map[string]interface{}{"seconds": int64(30)},
+		}),
+	}
+	// The policies which are not orphaned are attached at the same level, so
+	// that health-check-a and retry-x take precedence.
+	effectivePolicies, err := MergePoliciesOfSimilarKind(policies[:4])
+	if err != nil {
+		t.Fatalf("MergePoliciesOfSimilarKind() failed: %v", err)
+	}
+
+	got := ComputeEffectiveness(policies, []map[PolicyCrdID]Policy{effectivePolicies})
+	want := map[string]Effectiveness{
+		"HealthCheckPolicy.foo.com/default/health-check-a": EffectivenessFull,
+		"HealthCheckPolicy.foo.com/default/health-check-b": EffectivenessPartial,
+		"RetryPolicy.foo.com/default/retry-x":              EffectivenessFull,
+		"RetryPolicy.foo.com/default/retry-y":              EffectivenessNone,
+		"TimeoutPolicy.foo.com/default/timeout-orphan":     EffectivenessNone,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ComputeEffectiveness() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
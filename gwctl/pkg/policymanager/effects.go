@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policymanager
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// FieldChange describes the change which a policy is predicted to make to a
+// single field in the spec of its target.
+type FieldChange struct {
+	// Path is the dot-separated path of the field within the target spec.
+	Path string
+	// From is the current value of the field in the target spec.
+	From any
+	// To is the value which the policy sets for the field.
+	To any
+}
+
+// EffectPredictorFunc predicts the changes that a policy spec would make to the
+// spec of its target. Implementations are specific to a policy kind, since the
+// mapping of policy fields to target fields varies between policies.
+type EffectPredictorFunc func(policySpec, targetSpec map[string]interface{}) []FieldChange
+
+var (
+	effectPredictorsMu sync.RWMutex
+	// effectPredictors maps a policy kind to the predictor for that kind.
+	effectPredictors = make(map[PolicyCrdID]EffectPredictorFunc)
+)
+
+// RegisterEffectPredictor registers the predictor to be used for policies of
+// the given kind. Policies of kinds without a registered predictor use
+// DefaultEffectPredictor.
+func RegisterEffectPredictor(policyCrdID PolicyCrdID, predictor EffectPredictorFunc) {
+	effectPredictorsMu.Lock()
+	defer effectPredictorsMu.Unlock()
+	effectPredictors[policyCrdID] = predictor
+}
+
+// PredictEffect returns the changes that a Direct policy is predicted to make
+// to the spec of its target. Nothing is returned for Inherited policies since
+// their effect depends on the hierarchy and not only on the target.
+func PredictEffect(policy Policy, targetSpec map[string]interface{}) ([]FieldChange, error) {
+	if policy.IsInherited() {
+		return nil, nil
+	}
+	policySpec, err := policy.EffectiveSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	effectPredictorsMu.RLock()
+	predictor, ok := effectPredictors[policy.PolicyCrdID()]
+	effectPredictorsMu.RUnlock()
+	if !ok {
+		predictor = DefaultEffectPredictor
+	}
+
+	return predictor(policySpec, targetSpec), nil
+}
+
+// DefaultEffectPredictor is a best-effort predictor which assumes that a field
+// in the policy spec affects the field with the same path in the target spec.
+// Fields of the policy which have no counterpart in the target are ignored.
+func DefaultEffectPredictor(policySpec, targetSpec map[string]interface{}) []FieldChange {
+	var result []FieldChange
+	for path, value := range flattenFields(policySpec, nil) {
+		current, found, err := unstructured.NestedFieldNoCopy(targetSpec, strings.Split(path, ".")...)
+		if err != nil || !found {
+			continue
+		}
+		if reflect.DeepEqual(current, value) {
+			continue
+		}
+		result = append(result, FieldChange{Path: path, From: current, To: value})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
+
+// flattenFields returns the leaf values of a nested map keyed by their
+// dot-separated paths. Lists are treated as leaf values.
+func flattenFields(fields map[string]interface{}, prefix []string) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range fields {
+		path := append(append([]string{}, prefix...), key)
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) != 0 {
+			for nestedPath, nestedValue := range flattenFields(nested, path) {
+				result[nestedPath] = nestedValue
+			}
+			continue
+		}
+		result[strings.Join(path, ".")] = value
+	}
+	return result
+}
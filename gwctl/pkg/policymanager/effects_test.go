@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policymanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPredictEffect(t *testing.T) {
+	newPolicy := func(kind string, inherited bool) Policy {
+		return Policy{
+			u: unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "foo.com/v1",
+					"kind":       kind,
+					"metadata": map[string]interface{}{
+						"name": "policy-1",
+					},
+					"spec": map[string]interface{}{
+						"targetRef": map[string]interface{}{
+							"kind": "Service",
+							"name": "svc-1",
+						},
+						"sessionAffinity": "ClientIP",
+						"ipFamilyPolicy":  "SingleStack",
+						"healthCheck": map[string]interface{}{
+							"interval": "10s",
+						},
+					},
+				},
+			},
+			inherited: inherited,
+		}
+	}
+	targetSpec := map[string]interface{}{
+		"sessionAffinity": "None",
+		"ipFamilyPolicy":  "SingleStack",
+	}
+
+	RegisterEffectPredictor("TimeoutPolicy.foo.com", func(_, _ map[string]interface{}) []FieldChange {
+		return []FieldChange{{Path: "custom", From: "a", To: "b"}}
+	})
+
+	testcases := []struct {
+		name   string
+		policy Policy
+		want   []FieldChange
+	}{
+		{
+			name:   "default predictor reports only differing fields present in target",
+			policy: newPolicy("HealthCheckPolicy", false),
+			want: []FieldChange{
+				{Path: "sessionAffinity", From: "None", To: "ClientIP"},
+			},
+		},
+		{
+			name:   "registered predictor is used for its policy kind",
+			policy: newPolicy("TimeoutPolicy", false),
+			want: []FieldChange{
+				{Path: "custom", From: "a", To: "b"},
+			},
+		},
+		{
+			name:   "inherited policies have no predicted effect",
+			policy: newPolicy("HealthCheckPolicy", true),
+			want:   nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := PredictEffect(tc.policy, targetSpec)
+			if err != nil {
+				t.Fatalf("PredictEffect() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("PredictEffect() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -35,9 +36,16 @@ import (
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
 )
 
+// PolicyManager maintains a local cache of Policy CRDs and Policy resources.
+//
+// A PolicyManager is safe for concurrent use by multiple goroutines. Policies
+// returned by its methods are copies of the cached policies, so callers may
+// freely modify them without affecting the cache or other callers.
 type PolicyManager struct {
 	dc dynamic.Interface
 
+	// mu guards policyCRDs and policies.
+	mu sync.RWMutex
 	// policyCRDs maps a CRD name to the CRD object.
 	policyCRDs map[PolicyCrdID]PolicyCRD
 	// policies maps a policy name to the policy object.
@@ -58,38 +66,56 @@ func (p *PolicyManager) Init(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	policyCRDs := make(map[PolicyCrdID]PolicyCRD)
 	for _, crd := range allCRDs {
 		policyCRD := PolicyCRD{crd}
 		// Check if the CRD is a Gateway Policy CRD
 		if policyCRD.IsValid() {
-			p.policyCRDs[policyCRD.ID()] = policyCRD
+			policyCRDs[policyCRD.ID()] = policyCRD
 		}
 	}
 
-	allPolicies, err := fetchPolicies(ctx, p.dc, p.policyCRDs)
+	allPolicies, err := fetchPolicies(ctx, p.dc, policyCRDs)
 	if err != nil {
 		return err
 	}
+	policies := make(map[string]Policy)
 	for _, unstrucutredPolicy := range allPolicies {
-		if err := p.AddPolicy(unstrucutredPolicy); err != nil {
+		policy, err := PolicyFromUnstructured(unstrucutredPolicy, policyCRDs)
+		if err != nil {
 			return err
 		}
+		policies[policyKey(unstrucutredPolicy)] = policy
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id, policyCRD := range policyCRDs {
+		p.policyCRDs[id] = policyCRD
+	}
+	for key, policy := range policies {
+		p.policies[key] = policy
+	}
 	return nil
 }
 
 func (p *PolicyManager) PoliciesAttachedTo(objRef common.ObjRef) []Policy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	var result []Policy
 	for _, policy := range p.policies {
 		if policy.IsAttachedTo(objRef) {
-			result = append(result, policy)
+			result = append(result, policy.DeepCopy())
 		}
 	}
 	return result
 }
 
 func (p *PolicyManager) GetCRDs() []PolicyCRD {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	var result []PolicyCRD
 	for _, policyCRD := range p.policyCRDs {
 		result = append(result, policyCRD)
@@ -98,6 +124,9 @@ func (p *PolicyManager) GetCRDs() []PolicyCRD {
 }
 
 func (p *PolicyManager) GetCRD(name string) (PolicyCRD, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	for _, policyCrd := range p.policyCRDs {
 		if name == policyCrd.CRD().Name {
 			return policyCrd, true
@@ -108,30 +137,47 @@ func (p *PolicyManager) GetCRD(name string) (PolicyCRD, bool) {
 }
 
 func (p *PolicyManager) GetPolicies() []Policy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	var result []Policy
 	for _, policy := range p.policies {
-		result = append(result, policy)
+		result = append(result, policy.DeepCopy())
 	}
 	return result
 }
 
 func (p *PolicyManager) GetPolicy(namespacedName string) (Policy, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
 	policy, ok := p.policies[namespacedName]
-	return policy, ok
+	if !ok {
+		return Policy{}, false
+	}
+	return policy.DeepCopy(), true
 }
 
 func (p *PolicyManager) AddPolicy(unstrucutredPolicy unstructured.Unstructured) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	policy, err := PolicyFromUnstructured(unstrucutredPolicy, p.policyCRDs)
 	if err != nil {
 		return err
 	}
-	p.policies[unstrucutredPolicy.GetNamespace()+"/"+unstrucutredPolicy.GetName()] = policy
+	p.policies[policyKey(unstrucutredPolicy)] = policy
 	return nil
 }
 
+// policyKey returns the key of the policy within the cache.
+func policyKey(unstrucutredPolicy unstructured.Unstructured) string {
+	return unstrucutredPolicy.GetNamespace() + "/" + unstrucutredPolicy.GetName()
+}
+
 // fetchCRDs will fetch all CRDs from the API Server
 func fetchCRDs(ctx context.Context, dc dynamic.Interface) ([]apiextensionsv1.CustomResourceDefinition, error) {
-	gvr := schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+	gvr := common.CustomResourceDefinitionsPermission.GroupVersionResource("v1")
 	unstructuredCRDs, err := dc.Resource(gvr).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return []apiextensionsv1.CustomResourceDefinition{}, fmt.Errorf("failed to list CRDs: %v", err)
@@ -223,6 +269,9 @@ type Policy struct {
 	// Indicates whether the policy is supposed to be "inherited" (as opposed to
 	// "direct").
 	inherited bool
+	// provenance records which policies contributed to this policy in case it
+	// is the result of merging multiple policies.
+	provenance provenance
 }
 
 func (p Policy) ClientObject() client.Object { return p.Unstructured() }
@@ -308,13 +357,22 @@ func (p Policy) Unstructured() *unstructured.Unstructured {
 
 func (p Policy) DeepCopy() Policy {
 	clone := Policy{
-		u:         *p.u.DeepCopy(),
-		targetRef: p.targetRef,
-		inherited: p.inherited,
+		u:          *p.u.DeepCopy(),
+		targetRef:  p.targetRef,
+		inherited:  p.inherited,
+		provenance: p.provenance,
 	}
 	return clone
 }
 
+// WithSpec returns a copy of the policy with its spec replaced by the given
+// spec. The spec must only contain JSON compatible values.
+func (p Policy) WithSpec(spec map[string]interface{}) Policy {
+	result := p.DeepCopy()
+	result.u.Object["spec"] = runtime.DeepCopyJSON(spec)
+	return result
+}
+
 func (p Policy) Spec() map[string]interface{} {
 	spec, ok, err := unstructured.NestedFieldCopy(p.u.UnstructuredContent(), "spec")
 	if err != nil || !ok {
@@ -328,6 +386,22 @@ func (p Policy) Spec() map[string]interface{} {
 	return result
 }
 
+// Ancestors returns the status of the policy for each of its ancestors, as
+// reported by the controllers in status.ancestors. Policies without a status,
+// or with a status which does not follow the format of PolicyStatus, have no
+// ancestors.
+func (p Policy) Ancestors() []gatewayv1alpha2.PolicyAncestorStatus {
+	status, ok, err := unstructured.NestedMap(p.u.UnstructuredContent(), "status")
+	if err != nil || !ok {
+		return nil
+	}
+	var policyStatus gatewayv1alpha2.PolicyStatus
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(status, &policyStatus); err != nil {
+		return nil
+	}
+	return policyStatus.Ancestors
+}
+
 func (p Policy) EffectiveSpec() (map[string]interface{}, error) {
 	if !p.IsInherited() {
 		// No merging is required in case of Direct policies.
@@ -64,18 +64,20 @@ func MergePoliciesOfSimilarKind(policies []Policy) (map[PolicyCrdID]Policy, erro
 }
 
 func MergePoliciesOfSameHierarchy(policies1, policies2 map[PolicyCrdID]Policy) (map[PolicyCrdID]Policy, error) {
-	return mergePolicies(policies1, policies2, orderPolicyByPrecedence)
+	return mergePolicies(policies1, policies2, orderPolicyByPrecedence, true)
 }
 
 func MergePoliciesOfDifferentHierarchy(parentPolicies, childPolicies map[PolicyCrdID]Policy) (map[PolicyCrdID]Policy, error) {
-	return mergePolicies(parentPolicies, childPolicies, func(a, b Policy) (Policy, Policy) { return a, b })
+	return mergePolicies(parentPolicies, childPolicies, func(a, b Policy) (Policy, Policy) { return a, b }, false)
 }
 
 // mergePolicies will merge policies which are partitioned by their Kind.
 //
 // precedence function will order two policies such that the second policy
-// returned will have a higher precedence.
-func mergePolicies(policies1, policies2 map[PolicyCrdID]Policy, precedence func(a, b Policy) (Policy, Policy)) (map[PolicyCrdID]Policy, error) {
+// returned will have a higher precedence. sameHierarchy indicates whether the
+// policies are attached at the same level of the hierarchy, which is recorded
+// in the provenance of the merged policies.
+func mergePolicies(policies1, policies2 map[PolicyCrdID]Policy, precedence func(a, b Policy) (Policy, Policy), sameHierarchy bool) (map[PolicyCrdID]Policy, error) {
 	result := make(map[PolicyCrdID]Policy)
 
 	// Copy policies1 into result.
@@ -101,6 +103,7 @@ func mergePolicies(policies1, policies2 map[PolicyCrdID]Policy, precedence func(
 		if err != nil {
 			return nil, err
 		}
+		res.provenance = mergeProvenance(lowerPolicy, higherPolicy, sameHierarchy)
 		result[policyCrdID] = res
 	}
 	return result, nil
@@ -140,6 +140,10 @@ func TestMergePoliciesOfSimilarKind(t *testing.T) {
 				},
 			},
 			inherited: true,
+			provenance: provenance{
+				winners:      []string{"health-check-2", "health-check-1"},
+				contributors: []string{"HealthCheckPolicy.foo.com//health-check-2", "HealthCheckPolicy.foo.com//health-check-1"},
+			},
 		},
 		PolicyCrdID("TimeoutPolicy.bar.com"): {
 			u: unstructured.Unstructured{
@@ -159,6 +163,10 @@ func TestMergePoliciesOfSimilarKind(t *testing.T) {
 					},
 				},
 			},
+			provenance: provenance{
+				winners:      []string{"timeout-policy-2", "timeout-policy-1"},
+				contributors: []string{"TimeoutPolicy.bar.com//timeout-policy-2", "TimeoutPolicy.bar.com//timeout-policy-1"},
+			},
 		},
 	}
 
@@ -167,7 +175,7 @@ func TestMergePoliciesOfSimilarKind(t *testing.T) {
 		t.Fatalf("MergePoliciesOfSimilarKind returned err=%v; want no error", err)
 	}
 	cmpopts := cmp.Exporter(func(t reflect.Type) bool {
-		return t == reflect.TypeOf(Policy{})
+		return t == reflect.TypeOf(Policy{}) || t == reflect.TypeOf(provenance{})
 	})
 	if diff := cmp.Diff(want, got, cmpopts); diff != "" {
 		t.Errorf("MergePoliciesOfSimilarKind returned unexpected diff (-want, +got):\n%v", diff)
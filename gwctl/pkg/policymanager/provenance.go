@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policymanager
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// PolicyState describes how the policies of a single kind ended up affecting
+// a resource after merging.
+type PolicyState string
+
+const (
+	// PolicyStateApplied means a single policy of the kind takes effect.
+	PolicyStateApplied PolicyState = "applied"
+	// PolicyStateOverridden means the overrides of a policy attached higher up
+	// in the hierarchy take precedence over the policies attached below it.
+	PolicyStateOverridden PolicyState = "overridden"
+	// PolicyStateConflicted means multiple policies of the kind were attached
+	// at the same level of the hierarchy and had to be resolved by precedence.
+	PolicyStateConflicted PolicyState = "conflicted"
+)
+
+// provenance records which policies contributed to a merged policy.
+type provenance struct {
+	// winners are the policies at the most specific level of the hierarchy,
+	// ordered by increasing precedence. More than one winner means they
+	// conflicted.
+	winners []string
+	// overriddenBy is the policy whose overrides take precedence, if any.
+	overriddenBy string
+	// contributors are the Names of all the policies which were merged, at any
+	// level of the hierarchy.
+	contributors []string
+}
+
+// PolicySummary is a one-line summary of the effect of the policies of a
+// single kind on a resource.
+type PolicySummary struct {
+	Kind  PolicyCrdID `json:"kind"`
+	State PolicyState `json:"state"`
+	// Policies lists the relevant policies, ordered by increasing precedence.
+	// For an overridden state, this is the overriding policy.
+	Policies []string `json:"policies"`
+}
+
+func (s PolicySummary) String() string {
+	switch s.State {
+	case PolicyStateOverridden:
+		return fmt.Sprintf("%v: overridden by %v", s.Kind, strings.Join(s.Policies, ", "))
+	case PolicyStateConflicted:
+		winner := s.Policies[len(s.Policies)-1]
+		losers := s.Policies[:len(s.Policies)-1]
+		return fmt.Sprintf("%v: conflicted (%v takes precedence over %v)", s.Kind, winner, strings.Join(losers, ", "))
+	default:
+		return fmt.Sprintf("%v: applied (via %v)", s.Kind, strings.Join(s.Policies, ", "))
+	}
+}
+
+// Summary returns the PolicySummary of the policy based on the provenance
+// recorded while merging. Policies which were never merged are summarized as
+// applied.
+func (p Policy) Summary() PolicySummary {
+	prov := p.provenanceOrSelf()
+	switch {
+	case prov.overriddenBy != "":
+		return PolicySummary{Kind: p.PolicyCrdID(), State: PolicyStateOverridden, Policies: []string{prov.overriddenBy}}
+	case len(prov.winners) > 1:
+		return PolicySummary{Kind: p.PolicyCrdID(), State: PolicyStateConflicted, Policies: prov.winners}
+	default:
+		return PolicySummary{Kind: p.PolicyCrdID(), State: PolicyStateApplied, Policies: prov.winners}
+	}
+}
+
+// SummarizePolicies returns the PolicySummary of each policy, sorted by the
+// policy kind.
+func SummarizePolicies(policies map[PolicyCrdID]Policy) []PolicySummary {
+	result := make([]PolicySummary, 0, len(policies))
+	for _, policy := range policies {
+		result = append(result, policy.Summary())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Kind < result[j].Kind
+	})
+	return result
+}
+
+// provenanceOrSelf returns the recorded provenance, or a provenance consisting
+// solely of the policy itself if it was never merged.
+func (p Policy) provenanceOrSelf() provenance {
+	if len(p.provenance.winners) == 0 {
+		return provenance{winners: []string{p.namespacedName()}, contributors: []string{p.Name()}}
+	}
+	return p.provenance
+}
+
+func (p Policy) namespacedName() string {
+	if p.u.GetNamespace() == "" {
+		return p.u.GetName()
+	}
+	return fmt.Sprintf("%v/%v", p.u.GetNamespace(), p.u.GetName())
+}
+
+// mergeProvenance returns the provenance of the result of merging the parent
+// and child policies. If sameHierarchy is true, the child is the policy with
+// higher precedence attached at the same level as the parent.
+func mergeProvenance(parent, child Policy, sameHierarchy bool) provenance {
+	parentProv, childProv := parent.provenanceOrSelf(), child.provenanceOrSelf()
+
+	contributors := mergeContributors(parentProv.contributors, childProv.contributors)
+
+	if sameHierarchy {
+		result := provenance{overriddenBy: childProv.overriddenBy, contributors: contributors}
+		if result.overriddenBy == "" {
+			result.overriddenBy = parentProv.overriddenBy
+		}
+		result.winners = append(append([]string{}, parentProv.winners...), childProv.winners...)
+		return result
+	}
+
+	result := provenance{
+		winners:      childProv.winners,
+		overriddenBy: parentProv.overriddenBy,
+		contributors: contributors,
+	}
+	if result.overriddenBy == "" && parent.IsInherited() {
+		if _, ok := parent.Spec()["override"]; ok {
+			result.overriddenBy = parentProv.winners[len(parentProv.winners)-1]
+		}
+	}
+	return result
+}
+
+// mergeContributors returns the contributors of both policies, without
+// duplicates. The same policy can be merged more than once, like when a
+// Backend inherits it through several routes.
+func mergeContributors(parent, child []string) []string {
+	result := append([]string{}, parent...)
+	for _, contributor := range child {
+		if !slices.Contains(result, contributor) {
+			result = append(result, contributor)
+		}
+	}
+	return result
+}
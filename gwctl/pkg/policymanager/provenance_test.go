@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policymanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSummarizePolicies(t *testing.T) {
+	newPolicy := func(kind, name string, spec map[string]interface{}) Policy {
+		return Policy{
+			u: unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "foo.com/v1",
+					"kind":       kind,
+					"metadata": map[string]interface{}{
+						"name":      name,
+						"namespace": "default",
+					},
+					"spec": spec,
+				},
+			},
+			inherited: true,
+		}
+	}
+	policyMap := func(policies ...Policy) map[PolicyCrdID]Policy {
+		result := make(map[PolicyCrdID]Policy)
+		for _, policy := range policies {
+			result[policy.PolicyCrdID()] = policy
+		}
+		return result
+	}
+
+	parentPolicies := policyMap(
+		newPolicy("TimeoutPolicy", "timeout-gateway", map[string]interface{}{
+			"default": map[string]interface{}{"seconds": int64(10)},
+		}),
+		newPolicy("RetryPolicy", "retry-gateway", map[string]interface{}{
+			"override": map[string]interface{}{"attempts": int64(3)},
+		}),
+	)
+	childPolicies := policyMap(
+		newPolicy("TimeoutPolicy", "timeout-httproute", map[string]interface{}{
+			"default": map[string]interface{}{"seconds": int64(20)},
+		}),
+		newPolicy("RetryPolicy", "retry-httproute", map[string]interface{}{
+			"default": map[string]interface{}{"attempts": int64(5)},
+		}),
+	)
+	conflictingPolicies, err := MergePoliciesOfSimilarKind([]Policy{
+		newPolicy("HealthCheckPolicy", "health-check-a", map[string]interface{}{}),
+		newPolicy("HealthCheckPolicy", "health-check-b", map[string]interface{}{}),
+	})
+	if err != nil {
+		t.Fatalf("MergePoliciesOfSimilarKind() failed: %v", err)
+	}
+
+	merged, err := MergePoliciesOfDifferentHierarchy(parentPolicies, childPolicies)
+	if err != nil {
+		t.Fatalf("MergePoliciesOfDifferentHierarchy() failed: %v", err)
+	}
+	merged, err = MergePoliciesOfDifferentHierarchy(conflictingPolicies, merged)
+	if err != nil {
+		t.Fatalf("MergePoliciesOfDifferentHierarchy() failed: %v", err)
+	}
+
+	got := SummarizePolicies(merged)
+	want := []PolicySummary{
+		{Kind: "HealthCheckPolicy.foo.com", State: PolicyStateConflicted, Policies: []string{"default/health-check-b", "default/health-check-a"}},
+		{Kind: "RetryPolicy.foo.com", State: PolicyStateOverridden, Policies: []string{"default/retry-gateway"}},
+		{Kind: "TimeoutPolicy.foo.com", State: PolicyStateApplied, Policies: []string{"default/timeout-httproute"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("SummarizePolicies() returned unexpected diff (-want +got):\n%v", diff)
+	}
+
+	var gotLines []string
+	for _, summary := range got {
+		gotLines = append(gotLines, summary.String())
+	}
+	wantLines := []string{
+		"HealthCheckPolicy.foo.com: conflicted (default/health-check-a takes precedence over default/health-check-b)",
+		"RetryPolicy.foo.com: overridden by default/retry-gateway",
+		"TimeoutPolicy.foo.com: applied (via default/timeout-httproute)",
+	}
+	if diff := cmp.Diff(wantLines, gotLines); diff != "" {
+		t.Errorf("PolicySummary.String() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+)
+
+// AddressLookupPrinter prints the data path served through an address of the
+// Gateways: their listeners, and the routes and hostnames served through each
+// listener.
+type AddressLookupPrinter struct {
+	io.Writer
+}
+
+// addressLookupListener is a listener in the describe view of a lookup.
+type addressLookupListener struct {
+	Name     string               `json:"name"`
+	Port     int32                `json:"port"`
+	Protocol string               `json:"protocol"`
+	Hostname string               `json:"hostname"`
+	Routes   []addressLookupRoute `json:"routes"`
+}
+
+// addressLookupRoute is a route served through a listener in the describe view
+// of a lookup.
+type addressLookupRoute struct {
+	HTTPRoute string   `json:"httpRoute"`
+	Hostnames []string `json:"hostnames"`
+}
+
+// Print prints each Gateway in the describe view, followed by the tree of its
+// listeners and the routes served through them.
+func (ap *AddressLookupPrinter) Print(lookups []resourcediscovery.AddressLookup) {
+	for i, lookup := range lookups {
+		gateway := lookup.GatewayNode.Gateway
+		if i > 0 {
+			writeDescribeSeparator(ap, "Gateway", gateway)
+		}
+
+		var addresses []string
+		for _, address := range gateway.Status.Addresses {
+			if address.Type != nil {
+				addresses = append(addresses, fmt.Sprintf("%v (%v)", address.Value, *address.Type))
+			} else {
+				addresses = append(addresses, address.Value)
+			}
+		}
+		listeners := []addressLookupListener{}
+		for _, listenerRoutes := range lookup.Listeners {
+			listener := listenerRoutes.Listener
+			view := addressLookupListener{
+				Name:     string(listener.Name),
+				Port:     int32(listener.Port),
+				Protocol: string(listener.Protocol),
+				Hostname: "*",
+				Routes:   []addressLookupRoute{},
+			}
+			if listener.Hostname != nil {
+				view.Hostname = string(*listener.Hostname)
+			}
+			for _, route := range listenerRoutes.Routes {
+				view.Routes = append(view.Routes, addressLookupRoute{
+					HTTPRoute: fmt.Sprintf("%v/%v", route.HTTPRoute.Namespace, route.HTTPRoute.Name),
+					Hostnames: route.Hostnames,
+				})
+			}
+			listeners = append(listeners, view)
+		}
+
+		Describe(ap, []*DescriberKV{
+			{Key: "Gateway", Value: client.ObjectKeyFromObject(gateway).String()},
+			{Key: "GatewayClass", Value: relations.FindGatewayClassNameForGateway(*gateway)},
+			{Key: "Addresses", Value: addresses},
+			{Key: "Listeners", Value: listeners},
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+)
+
+func TestAddressLookupPrinter_Print(t *testing.T) {
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-gateway", Namespace: "default"},
+		Spec:       gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+		Status: gatewayv1.GatewayStatus{
+			Addresses: []gatewayv1.GatewayStatusAddress{{Type: common.PtrTo(gatewayv1.IPAddressType), Value: "203.0.113.7"}},
+		},
+	}
+	lookups := []resourcediscovery.AddressLookup{{
+		GatewayNode: resourcediscovery.NewGatewayNode(gateway),
+		Listeners: []resourcediscovery.ListenerRoutes{
+			{
+				Listener: gatewayv1.Listener{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: common.PtrTo(gatewayv1.Hostname("*.example.com"))},
+				Routes: []resourcediscovery.ServedRoute{{
+					HTTPRoute: common.ObjRef{Kind: "HTTPRoute", Namespace: "default", Name: "api-httproute"},
+					Hostnames: []string{"api.example.com"},
+				}},
+			},
+			{
+				Listener: gatewayv1.Listener{Name: "tcp", Port: 9000, Protocol: gatewayv1.TCPProtocolType},
+			},
+		},
+	}}
+
+	out := &bytes.Buffer{}
+	ap := &AddressLookupPrinter{Writer: out}
+	ap.Print(lookups)
+
+	got := out.String()
+	want := `
+Gateway: default/foo-gateway
+GatewayClass: foo-gatewayclass
+Addresses:
+- 203.0.113.7 (IPAddress)
+Listeners:
+- hostname: '*.example.com'
+  name: https
+  port: 443
+  protocol: HTTPS
+  routes:
+  - hostnames:
+    - api.example.com
+    httpRoute: default/api-httproute
+- hostname: '*'
+  name: tcp
+  port: 9000
+  protocol: TCP
+  routes: []
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Print returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
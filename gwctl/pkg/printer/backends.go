@@ -20,24 +20,37 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"golang.org/x/exp/maps"
-	"k8s.io/apimachinery/pkg/util/duration"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
 )
 
 type BackendsPrinter struct {
 	io.Writer
-	Clock        clock.Clock
-	EventFetcher eventFetcher
+	Clock               clock.Clock
+	EventFetcher        eventFetcher
+	EventLimit          int
+	MaxListItems        int
+	LabelColumns        []string
+	AnnotationColumns   []common.AnnotationColumn
+	Highlighter         *Highlighter
+	EffectivePolicyKind string
+	ShowDrift           bool
+	ShowFieldOwners     bool
 }
 
 func (bp *BackendsPrinter) GetPrintableNodes(resourceModel *resourcediscovery.ResourceModel) []NodeResource {
-	return NodeResources(maps.Values(resourceModel.GatewayClasses))
+	return NodeResources(maps.Values(resourceModel.Backends))
 }
 
 func (bp *BackendsPrinter) PrintTable(resourceModel *resourcediscovery.ResourceModel, wide bool) {
@@ -49,7 +62,7 @@ func (bp *BackendsPrinter) PrintTable(resourceModel *resourcediscovery.ResourceM
 	}
 
 	table := &Table{
-		ColumnNames:  columnNames,
+		ColumnNames:  append(append(columnNames, labelColumnNames(bp.LabelColumns)...), annotationColumnNames(bp.AnnotationColumns)...),
 		UseSeparator: false,
 	}
 
@@ -59,14 +72,13 @@ func (bp *BackendsPrinter) PrintTable(resourceModel *resourcediscovery.ResourceM
 	for _, backendNode := range sortedBackends {
 		backend := backendNode.Backend
 
-		httpRouteNodes := maps.Values(backendNode.HTTPRoutes)
-		sortedHTTPRouteNodes := SortByString(httpRouteNodes)
-		totalRoutes := len(sortedHTTPRouteNodes)
+		sortedRouteNodes := sortRouteNodes(backendNode.Routes)
+		totalRoutes := len(sortedRouteNodes)
 
-		namespace := backend.GetNamespace()
+		namespace := formatNamespace(backend.GetNamespace(), backendNode.Namespace)
 		name := backend.GetName()
-		backendType := backend.GetKind()
-		age := duration.HumanDuration(bp.Clock.Since(backend.GetCreationTimestamp().Time))
+		backendType := formatBackendType(backendNode)
+		age := formatAge(bp.Clock, backend)
 
 		row := []string{
 			namespace,
@@ -80,9 +92,9 @@ func (bp *BackendsPrinter) PrintTable(resourceModel *resourcediscovery.ResourceM
 				referredByRoutes = "None"
 			} else {
 				var routes []string
-				for i, httpRouteNode := range sortedHTTPRouteNodes {
+				for i, routeNode := range sortedRouteNodes {
 					if i < 2 {
-						namespacedName := client.ObjectKeyFromObject(httpRouteNode.HTTPRoute).String()
+						namespacedName := client.ObjectKeyFromObject(routeNode.ClientObject()).String()
 						routes = append(routes, namespacedName)
 					} else {
 						break
@@ -96,7 +108,10 @@ func (bp *BackendsPrinter) PrintTable(resourceModel *resourcediscovery.ResourceM
 			policiesCount := fmt.Sprintf("%d", len(backendNode.Policies))
 			row = append(row, referredByRoutes, policiesCount)
 		}
+		row = append(row, labelColumnValues(backend, bp.LabelColumns)...)
+		row = append(row, annotationColumnValues(backend, bp.AnnotationColumns)...)
 		table.Rows = append(table.Rows, row)
+		bp.Highlighter.highlightLastRow(table, bp.Clock, backend)
 	}
 
 	table.Write(bp, 0)
@@ -106,6 +121,9 @@ func (bp *BackendsPrinter) PrintDescribeView(resourceModel *resourcediscovery.Re
 	index := 0
 	for _, backendNode := range resourceModel.Backends {
 		index++
+		if index > 1 {
+			writeDescribeSeparator(bp, backendNode.Backend.GetKind(), backendNode.Backend)
+		}
 
 		backend := backendNode.Backend.DeepCopy()
 		backend.SetLabels(nil)
@@ -113,10 +131,32 @@ func (bp *BackendsPrinter) PrintDescribeView(resourceModel *resourcediscovery.Re
 
 		pairs := []*DescriberKV{
 			{Key: "Name", Value: backendNode.Backend.GetName()},
-			{Key: "Namespace", Value: backendNode.Backend.GetNamespace()},
+			{Key: "Namespace", Value: formatNamespace(backendNode.Backend.GetNamespace(), backendNode.Namespace)},
 			{Key: "Labels", Value: backendNode.Backend.GetLabels()},
 			{Key: "Annotations", Value: backendNode.Backend.GetAnnotations()},
-			{Key: "Backend", Value: backend},
+		}
+		if serviceType := backendNode.ServiceType(); serviceType != "" {
+			pairs = append(pairs, &DescriberKV{Key: "Type", Value: formatBackendType(backendNode)})
+			if serviceType == corev1.ServiceTypeExternalName {
+				externalName, _, _ := unstructured.NestedString(backendNode.Backend.Object, "spec", "externalName")
+				pairs = append(pairs, &DescriberKV{Key: "ExternalName", Value: externalName})
+			} else {
+				if clusterIP, _, _ := unstructured.NestedString(backendNode.Backend.Object, "spec", "clusterIP"); clusterIP != "" {
+					pairs = append(pairs, &DescriberKV{Key: "ClusterIP", Value: clusterIP})
+				}
+			}
+		}
+		pairs = append(pairs, &DescriberKV{Key: "Backend", Value: backend})
+		if bp.ShowDrift {
+			pairs = append(pairs, &DescriberKV{Key: "Drift", Value: specDrift(backendNode.Backend)})
+		}
+		if bp.ShowFieldOwners {
+			pairs = append(pairs, &DescriberKV{Key: "FieldOwners", Value: specFieldOwners(backendNode.Backend)})
+		}
+
+		// Endpoints
+		if endpoints := convertBackendEndpointsToTable(backendNode); endpoints != nil {
+			pairs = append(pairs, &DescriberKV{Key: "Endpoints", Value: endpoints})
 		}
 
 		// ReferencedByRoutes
@@ -124,12 +164,20 @@ func (bp *BackendsPrinter) PrintDescribeView(resourceModel *resourcediscovery.Re
 			ColumnNames:  []string{"Kind", "Name"},
 			UseSeparator: true,
 		}
-		for _, httpRouteNode := range backendNode.HTTPRoutes {
-			row := []string{
-				httpRouteNode.HTTPRoute.Kind, // Kind
-				fmt.Sprintf("%v/%v", httpRouteNode.HTTPRoute.Namespace, httpRouteNode.HTTPRoute.Name), // Name
+		for _, routeNode := range sortRouteNodes(backendNode.Routes) {
+			// A route referencing the Backend both in its rules and in a
+			// RequestMirror filter is listed once for each role.
+			for _, role := range backendNode.RolesForRoute(routeNode) {
+				name := client.ObjectKeyFromObject(routeNode.ClientObject()).String()
+				if role == relations.BackendRefRoleMirror {
+					name += " (mirror)"
+				}
+				row := []string{
+					routeNode.RouteID().Kind, // Kind
+					name,                     // Name
+				}
+				routes.Rows = append(routes.Rows, row)
 			}
-			routes.Rows = append(routes.Rows, row)
 		}
 		pairs = append(pairs, &DescriberKV{Key: "ReferencedByRoutes", Value: routes})
 
@@ -137,9 +185,20 @@ func (bp *BackendsPrinter) PrintDescribeView(resourceModel *resourcediscovery.Re
 		policyRefs := resourcediscovery.ConvertPoliciesMapToPolicyRefs(backendNode.Policies)
 		pairs = append(pairs, &DescriberKV{Key: "DirectlyAttachedPolicies", Value: convertPolicyRefsToTable(policyRefs)})
 
-		// EffectivePolicies
-		if len(backendNode.EffectivePolicies) != 0 {
-			pairs = append(pairs, &DescriberKV{Key: "EffectivePolicies", Value: backendNode.EffectivePolicies})
+		// PolicySummary and EffectivePolicies
+		if effectivePolicies := filterPoliciesByGatewayByKind(backendNode.EffectivePolicies, bp.EffectivePolicyKind); len(effectivePolicies) != 0 {
+			pairs = append(pairs, &DescriberKV{Key: "PolicySummary", Value: convertPoliciesByGatewayToPolicySummary(effectivePolicies)})
+			pairs = append(pairs, &DescriberKV{Key: "EffectivePolicies", Value: effectivePolicies})
+		}
+
+		// BackendTLS
+		if len(backendNode.BackendTLSPolicies) != 0 {
+			pairs = append(pairs, &DescriberKV{Key: "BackendTLS", Value: convertBackendTLSPoliciesToTable(backendNode)})
+		}
+
+		// LoadBalancing
+		if len(backendNode.BackendLBPolicies) != 0 {
+			pairs = append(pairs, &DescriberKV{Key: "LoadBalancing", Value: convertBackendLBPoliciesToTable(backendNode)})
 		}
 
 		// ReferenceGrants
@@ -158,12 +217,191 @@ func (bp *BackendsPrinter) PrintDescribeView(resourceModel *resourcediscovery.Re
 
 		// Events
 		eventList := bp.EventFetcher.FetchEventsFor(context.Background(), backendNode.Backend)
-		pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(eventList.Items, bp.Clock)})
+		pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(mostRecentEvents(eventList.Items, bp.EventLimit), bp.Clock)})
+
+		Describe(bp, truncateTables(pairs, bp.MaxListItems))
+	}
+}
 
-		Describe(bp, pairs)
+// formatBackendType returns the type of the Service, marking headless Services,
+// or the kind of the Backend if it is not a Service.
+func formatBackendType(backendNode *resourcediscovery.BackendNode) string {
+	serviceType := backendNode.ServiceType()
+	switch {
+	case serviceType == "":
+		return backendNode.Backend.GetKind()
+	case backendNode.IsHeadlessService():
+		return fmt.Sprintf("%v (headless)", serviceType)
+	default:
+		return string(serviceType)
+	}
+}
+
+// convertBackendEndpointsToTable returns a table with the number of ready and
+// not ready endpoints serving each port of the Service, as found in its
+// EndpointSlices. It returns nil if the Backend is not a Service with ports
+// and endpoints, like an ExternalName Service.
+func convertBackendEndpointsToTable(backendNode *resourcediscovery.BackendNode) *Table {
+	if backendNode.ServiceType() == corev1.ServiceTypeExternalName {
+		return nil
+	}
+	service := backendNode.Resolve(nil).Service
+	if service == nil || len(service.Spec.Ports) == 0 {
+		return nil
+	}
+
+	table := &Table{
+		ColumnNames:  []string{"Port", "Name", "Ready", "NotReady"},
+		UseSeparator: true,
+	}
+	for _, servicePort := range service.Spec.Ports {
+		protocol := servicePort.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		resolution := backendNode.Resolve(common.PtrTo(gatewayv1.PortNumber(servicePort.Port)))
+		row := []string{
+			fmt.Sprintf("%v/%v", servicePort.Port, protocol), // Port
+			servicePort.Name, // Name
+			fmt.Sprintf("%v", resolution.ReadyEndpoints),    // Ready
+			fmt.Sprintf("%v", resolution.NotReadyEndpoints), // NotReady
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// convertBackendTLSPoliciesToTable returns a table with the TLS configuration
+// of the BackendTLSPolicies targeting the Backend. The sections are the ports
+// of the Backend selected by the targetRefs, or None if the policy applies to
+// the whole Backend.
+func convertBackendTLSPoliciesToTable(backendNode *resourcediscovery.BackendNode) *Table {
+	table := &Table{
+		ColumnNames:  []string{"Policy", "Sections", "Hostname (SNI)", "CACertificateRefs", "WellKnownCACertificates"},
+		UseSeparator: true,
+	}
+	backendRef := common.ObjRef{
+		Group:     backendNode.Backend.GroupVersionKind().Group,
+		Kind:      backendNode.Backend.GroupVersionKind().Kind,
+		Namespace: backendNode.Backend.GetNamespace(),
+		Name:      backendNode.Backend.GetName(),
+	}
+	for _, backendTLSPolicy := range backendNode.BackendTLSPolicies {
+		var sections []string
+		for _, targetRef := range relations.BackendTLSPolicyTargets(backendTLSPolicy, backendRef) {
+			if targetRef.SectionName != nil {
+				sections = append(sections, string(*targetRef.SectionName))
+			}
+		}
+		var caCertificateRefs []string
+		for _, ref := range backendTLSPolicy.Spec.Validation.CACertificateRefs {
+			caCertificateRefs = append(caCertificateRefs, fmt.Sprintf("%v/%v", ref.Kind, ref.Name))
+		}
+		wellKnownCACertificates := "None"
+		if backendTLSPolicy.Spec.Validation.WellKnownCACertificates != nil {
+			wellKnownCACertificates = string(*backendTLSPolicy.Spec.Validation.WellKnownCACertificates)
+		}
+		row := []string{
+			backendTLSPolicy.GetName(),
+			joinOrNone(sections),
+			string(backendTLSPolicy.Spec.Validation.Hostname),
+			joinOrNone(caCertificateRefs),
+			wellKnownCACertificates,
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
 
-		if index+1 <= len(resourceModel.Backends) {
-			fmt.Fprintf(bp, "\n\n")
+// convertBackendLBPoliciesToTable returns a table with the session persistence
+// configured by the BackendLBPolicies targeting the Backend.
+func convertBackendLBPoliciesToTable(backendNode *resourcediscovery.BackendNode) *Table {
+	table := &Table{
+		ColumnNames:  []string{"Policy", "SessionPersistence", "SessionName", "IdleTimeout", "AbsoluteTimeout", "CookieLifetime"},
+		UseSeparator: true,
+	}
+	for _, backendLBPolicy := range backendNode.BackendLBPolicies {
+		row := []string{backendLBPolicy.GetName(), "None", "None", "None", "None", "None"}
+		if sp := backendLBPolicy.Spec.SessionPersistence; sp != nil {
+			row = []string{
+				backendLBPolicy.GetName(),          // Policy
+				string(sessionPersistenceType(sp)), // SessionPersistence
+				valueOrNone(sp.SessionName),        // SessionName
+				valueOrNone(sp.IdleTimeout),        // IdleTimeout
+				valueOrNone(sp.AbsoluteTimeout),    // AbsoluteTimeout
+				cookieLifetimeOrNone(sp),           // CookieLifetime
+			}
 		}
+		table.Rows = append(table.Rows, row)
 	}
+	return table
+}
+
+// sessionPersistenceType returns the type of the session persistence,
+// defaulting to Cookie.
+func sessionPersistenceType(sp *gatewayv1.SessionPersistence) gatewayv1.SessionPersistenceType {
+	if sp.Type == nil {
+		return gatewayv1.CookieBasedSessionPersistence
+	}
+	return *sp.Type
+}
+
+// cookieLifetimeOrNone returns the lifetime type of the session persistence
+// cookie, or None if it is not set.
+func cookieLifetimeOrNone(sp *gatewayv1.SessionPersistence) string {
+	if sp.CookieConfig == nil || sp.CookieConfig.LifetimeType == nil {
+		return "None"
+	}
+	return string(*sp.CookieConfig.LifetimeType)
+}
+
+// formatSessionPersistence returns the type of the session persistence
+// followed by the options which are set, e.g. "Cookie idleTimeout=30m".
+func formatSessionPersistence(sp *gatewayv1.SessionPersistence) string {
+	parts := []string{string(sessionPersistenceType(sp))}
+	if sp.SessionName != nil {
+		parts = append(parts, "sessionName="+*sp.SessionName)
+	}
+	if sp.IdleTimeout != nil {
+		parts = append(parts, "idleTimeout="+string(*sp.IdleTimeout))
+	}
+	if sp.AbsoluteTimeout != nil {
+		parts = append(parts, "absoluteTimeout="+string(*sp.AbsoluteTimeout))
+	}
+	if lifetime := cookieLifetimeOrNone(sp); lifetime != "None" {
+		parts = append(parts, "cookieLifetime="+lifetime)
+	}
+	return strings.Join(parts, " ")
+}
+
+// valueOrNone returns the value pointed to, or None if it is nil.
+func valueOrNone[T ~string](value *T) string {
+	if value == nil {
+		return "None"
+	}
+	return string(*value)
+}
+
+// joinOrNone joins the values with commas, or returns None if there are no
+// values.
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "None"
+	}
+	return strings.Join(values, ", ")
+}
+
+// sortRouteNodes returns the routes sorted by their namespaced name, and then
+// by their kind.
+func sortRouteNodes[K comparable](routes map[K]resourcediscovery.RouteNode) []resourcediscovery.RouteNode {
+	result := maps.Values(routes)
+	sort.Slice(result, func(i, j int) bool {
+		a := client.ObjectKeyFromObject(result[i].ClientObject()).String()
+		b := client.ObjectKeyFromObject(result[j].ClientObject()).String()
+		if a != b {
+			return a < b
+		}
+		return result[i].RouteID().Kind < result[j].RouteID().Kind
+	})
+	return result
 }
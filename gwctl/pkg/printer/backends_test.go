@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -32,6 +33,7 @@ import (
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
@@ -112,6 +114,10 @@ func TestBackendsPrinter_Print(t *testing.T) {
 					Time: fakeClock.Now().Add(-72 * time.Hour),
 				},
 			},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeClusterIP,
+				ClusterIP: "10.96.0.10",
+			},
 		},
 		&corev1.Service{
 			TypeMeta: metav1.TypeMeta{
@@ -125,12 +131,34 @@ func TestBackendsPrinter_Print(t *testing.T) {
 					Time: fakeClock.Now().Add(-48 * time.Hour),
 				},
 			},
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeClusterIP,
+				ClusterIP: corev1.ClusterIPNone,
+			},
+		},
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-svc-3",
+				Namespace: "ns1",
+				CreationTimestamp: metav1.Time{
+					Time: fakeClock.Now().Add(-24 * time.Hour),
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Type:         corev1.ServiceTypeExternalName,
+				ExternalName: "foo.example.com",
+			},
 		},
 		httpRoute("ns1", "foo-httproute-1", "foo-svc-1", "foo-gateway-1"),
 		httpRoute("ns1", "foo-httproute-2", "foo-svc-2", "foo-gateway-1"),
 		httpRoute("ns1", "foo-httproute-3", "foo-svc-2", "foo-gateway-1"),
 		httpRoute("ns1", "foo-httproute-4", "foo-svc-2", "foo-gateway-1"),
 		httpRoute("ns1", "foo-httproute-5", "foo-svc-2", "foo-gateway-1"),
+		httpRoute("ns1", "foo-httproute-6", "foo-svc-3", "foo-gateway-1"),
 	}
 
 	backendPolicies := []runtime.Object{
@@ -200,9 +228,10 @@ func TestBackendsPrinter_Print(t *testing.T) {
 
 	got := buff.String()
 	want := `
-NAMESPACE  NAME       TYPE     AGE
-ns1        foo-svc-1  Service  3d
-ns1        foo-svc-2  Service  2d
+NAMESPACE  NAME       TYPE                  AGE
+ns1        foo-svc-1  ClusterIP             3d
+ns1        foo-svc-2  ClusterIP (headless)  2d
+ns1        foo-svc-3  ExternalName          24h
 `
 	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
@@ -217,11 +246,648 @@ ns1        foo-svc-2  Service  2d
 
 	got2 := buff.String()
 	want2 := `
-NAMESPACE  NAME       TYPE     AGE  REFERRED BY ROUTES                                 POLICIES
-ns1        foo-svc-1  Service  3d   ns1/foo-httproute-1                                1
-ns1        foo-svc-2  Service  2d   ns1/foo-httproute-2, ns1/foo-httproute-3 + 2 more  0
+NAMESPACE  NAME       TYPE                  AGE  REFERRED BY ROUTES                                 POLICIES
+ns1        foo-svc-1  ClusterIP             3d   ns1/foo-httproute-1                                1
+ns1        foo-svc-2  ClusterIP (headless)  2d   ns1/foo-httproute-2, ns1/foo-httproute-3 + 2 more  0
+ns1        foo-svc-3  ExternalName          24h  ns1/foo-httproute-6                                0
 `
 	if diff := cmp.Diff(common.YamlString(want2), common.YamlString(got2), common.YamlStringTransformer); diff != "" {
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got2, want2, diff)
 	}
 }
+
+func TestBackendsPrinter_PrintDescribeView_BackendTLS(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout-svc",
+				Namespace: "default",
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "https", Port: 443}, {Name: "grpc", Port: 8443}},
+			},
+		},
+		&gatewayv1alpha3.BackendTLSPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout-tls",
+				Namespace: "default",
+			},
+			Spec: gatewayv1alpha3.BackendTLSPolicySpec{
+				TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+					{LocalPolicyTargetReference: gatewayv1alpha2.LocalPolicyTargetReference{Kind: "Service", Name: "checkout-svc"}, SectionName: common.PtrTo(gatewayv1.SectionName("https"))},
+					{LocalPolicyTargetReference: gatewayv1alpha2.LocalPolicyTargetReference{Kind: "Service", Name: "checkout-svc"}, SectionName: common.PtrTo(gatewayv1.SectionName("grpc"))},
+				},
+				Validation: gatewayv1alpha3.BackendTLSPolicyValidation{
+					CACertificateRefs: []gatewayv1.LocalObjectReference{{Kind: "ConfigMap", Name: "checkout-ca"}},
+					Hostname:          "checkout.example.com",
+				},
+			},
+		},
+		&gatewayv1alpha3.BackendTLSPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "system-ca-tls",
+				Namespace: "default",
+			},
+			Spec: gatewayv1alpha3.BackendTLSPolicySpec{
+				TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+					{LocalPolicyTargetReference: gatewayv1alpha2.LocalPolicyTargetReference{Kind: "Service", Name: "checkout-svc"}},
+				},
+				Validation: gatewayv1alpha3.BackendTLSPolicyValidation{
+					WellKnownCACertificates: common.PtrTo(gatewayv1alpha3.WellKnownCACertificatesSystem),
+					Hostname:                "checkout.internal",
+				},
+			},
+		},
+		&gatewayv1alpha3.BackendTLSPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-tls",
+				Namespace: "default",
+			},
+			Spec: gatewayv1alpha3.BackendTLSPolicySpec{
+				TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{
+					{LocalPolicyTargetReference: gatewayv1alpha2.LocalPolicyTargetReference{Kind: "Service", Name: "other-svc"}},
+				},
+				Validation: gatewayv1alpha3.BackendTLSPolicyValidation{
+					WellKnownCACertificates: common.PtrTo(gatewayv1alpha3.WellKnownCACertificatesSystem),
+					Hostname:                "other.internal",
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForBackend(resourcediscovery.Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	buff := &bytes.Buffer{}
+	bp := &BackendsPrinter{
+		Writer:       buff,
+		Clock:        fakeClock,
+		EventFetcher: discoverer,
+	}
+	bp.PrintDescribeView(resourceModel)
+
+	got := buff.String()
+	want := `
+Name: checkout-svc
+Namespace: default
+Labels: null
+Annotations: null
+Type: ClusterIP
+Backend:
+  apiVersion: v1
+  kind: Service
+  metadata:
+    creationTimestamp: null
+    name: checkout-svc
+    namespace: default
+    resourceVersion: "999"
+  spec:
+    ports:
+    - name: https
+      port: 443
+      targetPort: 0
+    - name: grpc
+      port: 8443
+      targetPort: 0
+  status:
+    loadBalancer: {}
+Endpoints:
+  Port      Name   Ready  NotReady
+  ----      ----   -----  --------
+  443/TCP   https  0      0
+  8443/TCP  grpc   0      0
+ReferencedByRoutes: <none>
+DirectlyAttachedPolicies: <none>
+BackendTLS:
+  Policy         Sections     Hostname (SNI)        CACertificateRefs      WellKnownCACertificates
+  ------         --------     --------------        -----------------      -----------------------
+  checkout-tls   https, grpc  checkout.example.com  ConfigMap/checkout-ca  None
+  system-ca-tls  None         checkout.internal     None                   System
+Events: <none>
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+func TestBackendsPrinter_PrintDescribeView_LoadBalancing(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cart-svc",
+				Namespace: "default",
+			},
+		},
+		&gatewayv1alpha2.BackendLBPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cart-sticky",
+				Namespace: "default",
+			},
+			Spec: gatewayv1alpha2.BackendLBPolicySpec{
+				TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReference{{Kind: "Service", Name: "cart-svc"}},
+				SessionPersistence: &gatewayv1.SessionPersistence{
+					SessionName:     common.PtrTo("cart-session"),
+					IdleTimeout:     common.PtrTo(gatewayv1.Duration("30m")),
+					AbsoluteTimeout: common.PtrTo(gatewayv1.Duration("24h")),
+					CookieConfig: &gatewayv1.CookieConfig{
+						LifetimeType: common.PtrTo(gatewayv1.PermanentCookieLifetimeType),
+					},
+				},
+			},
+		},
+		&gatewayv1alpha2.BackendLBPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cart-empty",
+				Namespace: "default",
+			},
+			Spec: gatewayv1alpha2.BackendLBPolicySpec{
+				TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReference{{Kind: "Service", Name: "cart-svc"}},
+			},
+		},
+		&gatewayv1alpha2.BackendLBPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-sticky",
+				Namespace: "default",
+			},
+			Spec: gatewayv1alpha2.BackendLBPolicySpec{
+				TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReference{{Kind: "Service", Name: "other-svc"}},
+				SessionPersistence: &gatewayv1.SessionPersistence{
+					Type: common.PtrTo(gatewayv1.HeaderBasedSessionPersistence),
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForBackend(resourcediscovery.Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	buff := &bytes.Buffer{}
+	bp := &BackendsPrinter{
+		Writer:       buff,
+		Clock:        fakeClock,
+		EventFetcher: discoverer,
+	}
+	bp.PrintDescribeView(resourceModel)
+
+	got := buff.String()
+	want := `
+Name: cart-svc
+Namespace: default
+Labels: null
+Annotations: null
+Type: ClusterIP
+Backend:
+  apiVersion: v1
+  kind: Service
+  metadata:
+    creationTimestamp: null
+    name: cart-svc
+    namespace: default
+    resourceVersion: "999"
+  spec: {}
+  status:
+    loadBalancer: {}
+ReferencedByRoutes: <none>
+DirectlyAttachedPolicies: <none>
+LoadBalancing:
+  Policy       SessionPersistence  SessionName   IdleTimeout  AbsoluteTimeout  CookieLifetime
+  ------       ------------------  -----------   -----------  ---------------  --------------
+  cart-empty   None                None          None         None             None
+  cart-sticky  Cookie              cart-session  30m          24h              Permanent
+Events: <none>
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+func TestBackendsPrinter_PrintDescribeView_MultipleRouteKinds(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	backendRef := gatewayv1.BackendObjectReference{
+		Group: common.PtrTo(gatewayv1.Group("")),
+		Kind:  common.PtrTo(gatewayv1.Kind("Service")),
+		Name:  "checkout-svc",
+	}
+	objects := []runtime.Object{
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout-svc",
+				Namespace: "default",
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "HTTPRoute",
+				APIVersion: gatewayv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout-http",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{
+					{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef}}}},
+				},
+			},
+		},
+		&gatewayv1.GRPCRoute{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "GRPCRoute",
+				APIVersion: gatewayv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout-grpc",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GRPCRouteSpec{
+				Rules: []gatewayv1.GRPCRouteRule{
+					{BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef}}}},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForBackend(resourcediscovery.Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	buff := &bytes.Buffer{}
+	bp := &BackendsPrinter{
+		Writer:       buff,
+		Clock:        fakeClock,
+		EventFetcher: discoverer,
+	}
+	bp.PrintDescribeView(resourceModel)
+
+	got := buff.String()
+	want := `
+Name: checkout-svc
+Namespace: default
+Labels: null
+Annotations: null
+Type: ClusterIP
+Backend:
+  apiVersion: v1
+  kind: Service
+  metadata:
+    creationTimestamp: null
+    name: checkout-svc
+    namespace: default
+    resourceVersion: "999"
+  spec: {}
+  status:
+    loadBalancer: {}
+ReferencedByRoutes:
+  Kind       Name
+  ----       ----
+  GRPCRoute  default/checkout-grpc
+  HTTPRoute  default/checkout-http
+DirectlyAttachedPolicies: <none>
+Events: <none>
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+func TestBackendsPrinter_PrintDescribeView_MirrorRoutes(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	backendRef := gatewayv1.BackendObjectReference{
+		Group: common.PtrTo(gatewayv1.Group("")),
+		Kind:  common.PtrTo(gatewayv1.Kind("Service")),
+		Name:  "checkout-svc",
+	}
+	mirrorFilter := gatewayv1.HTTPRouteFilter{
+		Type:          gatewayv1.HTTPRouteFilterRequestMirror,
+		RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{BackendRef: backendRef},
+	}
+	objects := []runtime.Object{
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout-svc",
+				Namespace: "default",
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "HTTPRoute",
+				APIVersion: gatewayv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout-both",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{
+					{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef}}}},
+					{Filters: []gatewayv1.HTTPRouteFilter{mirrorFilter}},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "HTTPRoute",
+				APIVersion: gatewayv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout-shadow",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{
+					{Filters: []gatewayv1.HTTPRouteFilter{mirrorFilter}},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForBackend(resourcediscovery.Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	buff := &bytes.Buffer{}
+	bp := &BackendsPrinter{
+		Writer:       buff,
+		Clock:        fakeClock,
+		EventFetcher: discoverer,
+	}
+	bp.PrintDescribeView(resourceModel)
+
+	got := buff.String()
+	want := `
+Name: checkout-svc
+Namespace: default
+Labels: null
+Annotations: null
+Type: ClusterIP
+Backend:
+  apiVersion: v1
+  kind: Service
+  metadata:
+    creationTimestamp: null
+    name: checkout-svc
+    namespace: default
+    resourceVersion: "999"
+  spec: {}
+  status:
+    loadBalancer: {}
+ReferencedByRoutes:
+  Kind       Name
+  ----       ----
+  HTTPRoute  default/checkout-both
+  HTTPRoute  default/checkout-both (mirror)
+  HTTPRoute  default/checkout-shadow (mirror)
+DirectlyAttachedPolicies: <none>
+Events: <none>
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+func TestBackendsPrinter_PrintDescribeView_ExternalName(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy-svc",
+				Namespace: "default",
+			},
+			Spec: corev1.ServiceSpec{
+				Type:         corev1.ServiceTypeExternalName,
+				ExternalName: "legacy.example.com",
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "HTTPRoute",
+				APIVersion: gatewayv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "legacy-route",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{
+					{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+						Group: common.PtrTo(gatewayv1.Group("")),
+						Kind:  common.PtrTo(gatewayv1.Kind("Service")),
+						Name:  "legacy-svc",
+					}}}}},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForBackend(resourcediscovery.Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	buff := &bytes.Buffer{}
+	bp := &BackendsPrinter{
+		Writer:       buff,
+		Clock:        fakeClock,
+		EventFetcher: discoverer,
+	}
+	bp.PrintDescribeView(resourceModel)
+
+	got := buff.String()
+	want := `
+Name: legacy-svc
+Namespace: default
+Labels: null
+Annotations: null
+Type: ExternalName
+ExternalName: legacy.example.com
+Backend:
+  apiVersion: v1
+  kind: Service
+  metadata:
+    creationTimestamp: null
+    name: legacy-svc
+    namespace: default
+    resourceVersion: "999"
+  spec:
+    externalName: legacy.example.com
+    type: ExternalName
+  status:
+    loadBalancer: {}
+ReferencedByRoutes:
+  Kind       Name
+  ----       ----
+  HTTPRoute  default/legacy-route
+DirectlyAttachedPolicies: <none>
+Analysis:
+- HTTPRoute "default/legacy-route" references Service "default/legacy-svc" of type
+  ExternalName, which is not supported by most implementations
+Events: <none>
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+func TestBackendsPrinter_PrintDescribeView_Endpoints(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	endpoint := func(ready bool) discoveryv1.Endpoint {
+		return discoveryv1.Endpoint{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(ready)}}
+	}
+	objects := []runtime.Object{
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-svc",
+				Namespace: "default",
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeClusterIP,
+				Ports: []corev1.ServicePort{
+					{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP},
+					{Name: "metrics", Port: 9090, Protocol: corev1.ProtocolTCP},
+				},
+			},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-svc-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "foo-svc"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Ports:       []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To(int32(8080))}},
+			Endpoints:   []discoveryv1.Endpoint{endpoint(true), endpoint(true), endpoint(false)},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-svc-fghij",
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "foo-svc"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Ports:       []discoveryv1.EndpointPort{{Name: ptr.To("metrics"), Port: ptr.To(int32(9090))}},
+			Endpoints:   []discoveryv1.Endpoint{endpoint(false)},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForBackend(resourcediscovery.Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	buff := &bytes.Buffer{}
+	bp := &BackendsPrinter{
+		Writer:       buff,
+		Clock:        fakeClock,
+		EventFetcher: discoverer,
+	}
+	bp.PrintDescribeView(resourceModel)
+
+	got := buff.String()
+	want := `
+Name: foo-svc
+Namespace: default
+Labels: null
+Annotations: null
+Type: ClusterIP
+Backend:
+  apiVersion: v1
+  kind: Service
+  metadata:
+    creationTimestamp: null
+    name: foo-svc
+    namespace: default
+    resourceVersion: "999"
+  spec:
+    ports:
+    - name: http
+      port: 80
+      protocol: TCP
+      targetPort: 0
+    - name: metrics
+      port: 9090
+      protocol: TCP
+      targetPort: 0
+    type: ClusterIP
+  status:
+    loadBalancer: {}
+Endpoints:
+  Port      Name     Ready  NotReady
+  ----      ----     -----  --------
+  80/TCP    http     2      1
+  9090/TCP  metrics  0      1
+ReferencedByRoutes: <none>
+DirectlyAttachedPolicies: <none>
+Events: <none>
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
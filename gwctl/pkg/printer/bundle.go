@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// WriteBundle writes the bundle as a gzipped tarball with the following layout:
+//
+//	crds/<name>.yaml
+//	resources/<kind>.<group>/[<namespace>/]<name>.yaml
+//	events/<kind>.<group>/[<namespace>/]<name>.yaml
+//
+// The tarball is reproducible: the files are written in a fixed order, with
+// fixed modification times, and without managedFields.
+func WriteBundle(w io.Writer, bundle *resourcediscovery.Bundle) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for i := range bundle.CRDs {
+		crd := &bundle.CRDs[i]
+		if err := writeBundleFile(tarWriter, path.Join("crds", crd.GetName()+".yaml"), crd); err != nil {
+			return err
+		}
+	}
+	for i := range bundle.Objects {
+		object := &bundle.Objects[i].Object
+		if err := writeBundleFile(tarWriter, path.Join("resources", bundleObjectPath(object)), object); err != nil {
+			return err
+		}
+	}
+	for _, bundleObject := range bundle.Objects {
+		if len(bundleObject.Events) == 0 {
+			continue
+		}
+		events := make([]corev1.Event, len(bundleObject.Events))
+		copy(events, bundleObject.Events)
+		sort.SliceStable(events, func(i, j int) bool {
+			if !events[i].LastTimestamp.Equal(&events[j].LastTimestamp) {
+				return events[i].LastTimestamp.Before(&events[j].LastTimestamp)
+			}
+			return events[i].Name < events[j].Name
+		})
+		var objs []client.Object
+		for i := range events {
+			events[i].APIVersion, events[i].Kind = "v1", "Event"
+			objs = append(objs, &events[i])
+		}
+		list, err := renderPrintableObject(objs)
+		if err != nil {
+			return err
+		}
+		if err := writeBundleFile(tarWriter, path.Join("events", bundleObjectPath(&bundleObject.Object)), list); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// bundleObjectPath returns the path of the file for the object, relative to the
+// directory of resources or events.
+func bundleObjectPath(object client.Object) string {
+	gk := object.GetObjectKind().GroupVersionKind().GroupKind()
+	return path.Join(gk.String(), object.GetNamespace(), object.GetName()+".yaml")
+}
+
+func writeBundleFile(tarWriter *tar.Writer, name string, obj interface{}) error {
+	if clientObject, ok := obj.(client.Object); ok {
+		unstructuredObj, err := toPrintableUnstructured(clientObject)
+		if err != nil {
+			return err
+		}
+		obj = unstructuredObj
+	}
+	content, err := utils.MarshalWithFormat(obj, utils.OutputFormatYAML)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %v: %v", name, err)
+	}
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(content)),
+		ModTime: time.Unix(0, 0),
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = tarWriter.Write(content)
+	return err
+}
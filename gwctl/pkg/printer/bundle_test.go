@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+)
+
+func TestWriteBundle(t *testing.T) {
+	bundle := &resourcediscovery.Bundle{
+		CRDs: []unstructured.Unstructured{{
+			Object: map[string]interface{}{
+				"apiVersion": "apiextensions.k8s.io/v1",
+				"kind":       "CustomResourceDefinition",
+				"metadata": map[string]interface{}{
+					"name": "gatewayclasses.gateway.networking.k8s.io",
+				},
+			},
+		}},
+		Objects: []resourcediscovery.BundleObject{
+			{
+				Object: unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"apiVersion": "gateway.networking.k8s.io/v1",
+						"kind":       "GatewayClass",
+						"metadata": map[string]interface{}{
+							"name": "foo-gatewayclass",
+						},
+					},
+				},
+			},
+			{
+				Object: unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"apiVersion": "gateway.networking.k8s.io/v1",
+						"kind":       "Gateway",
+						"metadata": map[string]interface{}{
+							"name":          "foo-gateway",
+							"namespace":     "default",
+							"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl"}},
+						},
+						"status": map[string]interface{}{
+							"addresses": []interface{}{map[string]interface{}{"value": "10.0.0.1"}},
+						},
+					},
+				},
+				Events: []corev1.Event{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo-gateway-event",
+						Namespace: "default",
+					},
+					Reason: "Programmed",
+				}},
+			},
+		},
+	}
+
+	buff := &bytes.Buffer{}
+	if err := WriteBundle(buff, bundle); err != nil {
+		t.Fatalf("WriteBundle() returned an unexpected error: %v", err)
+	}
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(buff.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	files := make(map[string]string)
+	var names []string
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, header.Name)
+		files[header.Name] = string(content)
+	}
+
+	wantNames := []string{
+		"crds/gatewayclasses.gateway.networking.k8s.io.yaml",
+		"resources/GatewayClass.gateway.networking.k8s.io/foo-gatewayclass.yaml",
+		"resources/Gateway.gateway.networking.k8s.io/default/foo-gateway.yaml",
+		"events/Gateway.gateway.networking.k8s.io/default/foo-gateway.yaml",
+	}
+	if diff := cmp.Diff(wantNames, names); diff != "" {
+		t.Errorf("Unexpected diff in files (-want +got)=\n%v", diff)
+	}
+
+	wantGateway := `
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: foo-gateway
+  namespace: default
+status:
+  addresses:
+  - value: 10.0.0.1
+`
+	got := files["resources/Gateway.gateway.networking.k8s.io/default/foo-gateway.yaml"]
+	if diff := cmp.Diff(common.YamlString(wantGateway), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff in Gateway\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, wantGateway, diff)
+	}
+
+	wantEvents := `
+apiVersion: v1
+items:
+- apiVersion: v1
+  eventTime: null
+  firstTimestamp: null
+  involvedObject: {}
+  kind: Event
+  lastTimestamp: null
+  metadata:
+    creationTimestamp: null
+    name: foo-gateway-event
+    namespace: default
+  reason: Programmed
+  reportingComponent: ""
+  reportingInstance: ""
+  source: {}
+kind: List
+`
+	got = files["events/Gateway.gateway.networking.k8s.io/default/foo-gateway.yaml"]
+	if diff := cmp.Diff(common.YamlString(wantEvents), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff in events\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, wantEvents, diff)
+	}
+
+	// The same bundle always results in the same tarball.
+	again := &bytes.Buffer{}
+	if err := WriteBundle(again, bundle); err != nil {
+		t.Fatalf("WriteBundle() returned an unexpected error: %v", err)
+	}
+	if !bytes.Equal(buff.Bytes(), again.Bytes()) {
+		t.Errorf("WriteBundle() is not reproducible")
+	}
+}
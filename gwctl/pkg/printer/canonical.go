@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// serverPopulatedMetadataFields are the fields of the metadata which are set
+// by the API server rather than by the author of the resource.
+var serverPopulatedMetadataFields = []string{
+	"creationTimestamp",
+	"deletionGracePeriodSeconds",
+	"deletionTimestamp",
+	"generation",
+	"managedFields",
+	"resourceVersion",
+	"selfLink",
+	"uid",
+}
+
+// canonicalDefault is a field which the API server defaults to Value. The path
+// is relative to the resource, and "[]" stands for every item of a list.
+type canonicalDefault struct {
+	Path  []string
+	Value any
+}
+
+// canonicalDefaults are the fields defaulted by the Gateway API CRDs, by kind.
+// They are ordered so that the fields within a defaulted object are removed
+// before the object itself.
+var canonicalDefaults = map[string][]canonicalDefault{
+	"Gateway": {
+		{Path: []string{"spec", "listeners", "[]", "allowedRoutes", "namespaces", "from"}, Value: string(gatewayv1.NamespacesFromSame)},
+		{Path: []string{"spec", "listeners", "[]", "tls", "mode"}, Value: string(gatewayv1.TLSModeTerminate)},
+		{Path: []string{"spec", "listeners", "[]", "tls", "certificateRefs", "[]", "group"}, Value: ""},
+		{Path: []string{"spec", "listeners", "[]", "tls", "certificateRefs", "[]", "kind"}, Value: "Secret"},
+	},
+	"HTTPRoute": {
+		{Path: []string{"spec", "parentRefs", "[]", "group"}, Value: gatewayv1.GroupName},
+		{Path: []string{"spec", "parentRefs", "[]", "kind"}, Value: "Gateway"},
+		{Path: []string{"spec", "rules", "[]", "matches"}, Value: []any{map[string]any{"path": map[string]any{"type": string(gatewayv1.PathMatchPathPrefix), "value": "/"}}}},
+		{Path: []string{"spec", "rules", "[]", "matches", "[]", "path", "type"}, Value: string(gatewayv1.PathMatchPathPrefix)},
+		{Path: []string{"spec", "rules", "[]", "matches", "[]", "headers", "[]", "type"}, Value: string(gatewayv1.HeaderMatchExact)},
+		{Path: []string{"spec", "rules", "[]", "matches", "[]", "queryParams", "[]", "type"}, Value: string(gatewayv1.QueryParamMatchExact)},
+		{Path: []string{"spec", "rules", "[]", "backendRefs", "[]", "group"}, Value: ""},
+		{Path: []string{"spec", "rules", "[]", "backendRefs", "[]", "kind"}, Value: "Service"},
+		{Path: []string{"spec", "rules", "[]", "backendRefs", "[]", "weight"}, Value: int64(1)},
+	},
+}
+
+// PrintCanonical prints the resources like Print does for the JSON and YAML
+// formats, but in a canonical form which only changes when the intended
+// configuration of the resources changes, so the output can be kept in git and
+// diffed. The resources are sorted by their namespace and name, and the keys of
+// all maps are sorted. The fields populated by the API server, the status and
+// the last-applied configuration are removed, as are the fields set to the
+// default of the Gateway API CRDs, so resources read from the cluster print the
+// same as their manifests which omit them.
+func PrintCanonical(p Printer, resourceModel *resourcediscovery.ResourceModel, format utils.OutputFormat) {
+	if format != utils.OutputFormatJSON && format != utils.OutputFormatYAML {
+		fmt.Fprintf(os.Stderr, "Unrecognized output format for canonical output: %s\n", format)
+		os.Exit(1)
+	}
+	nodes := SortByString(p.GetPrintableNodes(resourceModel))
+	printablePayload, err := renderPrintableObject(ClientObjects(nodes))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to form the printable payload %v\n", err)
+		os.Exit(1)
+	}
+	for _, item := range printablePayload.Items {
+		canonicalize(item.Object)
+	}
+	// Maps are marshaled with their keys sorted.
+	output, err := utils.MarshalWithFormat(printablePayload, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprint(p, string(output))
+}
+
+// canonicalize removes the fields of the unstructured resource which are not
+// part of its intended configuration.
+func canonicalize(obj map[string]any) {
+	for _, field := range serverPopulatedMetadataFields {
+		unstructured.RemoveNestedField(obj, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj, "metadata", "annotations", lastAppliedConfigAnnotation)
+	if annotations, ok, _ := unstructured.NestedMap(obj, "metadata", "annotations"); ok && len(annotations) == 0 {
+		unstructured.RemoveNestedField(obj, "metadata", "annotations")
+	}
+	delete(obj, "status")
+
+	kind, _ := obj["kind"].(string)
+	for _, d := range canonicalDefaults[kind] {
+		removeDefault(obj, d.Path, d.Value)
+	}
+}
+
+// removeDefault removes the field at the path within v if it is set to the
+// default value. Objects left empty by the removal are removed as well. It
+// returns whether v itself is left empty.
+func removeDefault(v any, path []string, value any) bool {
+	if len(path) == 0 {
+		return false
+	}
+	if path[0] == "[]" {
+		items, _ := v.([]any)
+		for _, item := range items {
+			removeDefault(item, path[1:], value)
+		}
+		return false
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return false
+	}
+	field, ok := m[path[0]]
+	if !ok {
+		return false
+	}
+	if len(path) == 1 {
+		if !reflect.DeepEqual(field, value) {
+			return false
+		}
+		delete(m, path[0])
+		return len(m) == 0
+	}
+	if removeDefault(field, path[1:], value) {
+		delete(m, path[0])
+		return len(m) == 0
+	}
+	return false
+}
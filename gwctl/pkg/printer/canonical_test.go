@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testingclock "k8s.io/utils/clock/testing"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestPrintCanonical(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		&gatewayv1.HTTPRoute{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: gatewayv1.GroupVersion.String(),
+				Kind:       "HTTPRoute",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "checkout",
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(fakeClock.Now()),
+				Generation:        3,
+				UID:               "0b5f1a4e",
+				ResourceVersion:   "999",
+				Annotations: map[string]string{
+					lastAppliedConfigAnnotation: `{"spec":{}}`,
+				},
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}},
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{
+						Group: common.PtrTo(gatewayv1.Group(gatewayv1.GroupName)),
+						Kind:  common.PtrTo(gatewayv1.Kind("Gateway")),
+						Name:  "edge-gw",
+					}},
+				},
+				Rules: []gatewayv1.HTTPRouteRule{
+					{
+						Matches: []gatewayv1.HTTPRouteMatch{{
+							Path: &gatewayv1.HTTPPathMatch{Type: common.PtrTo(gatewayv1.PathMatchPathPrefix), Value: common.PtrTo("/")},
+						}},
+						BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Group: common.PtrTo(gatewayv1.Group("")),
+								Kind:  common.PtrTo(gatewayv1.Kind("Service")),
+								Name:  "checkout-svc",
+								Port:  common.PtrTo(gatewayv1.PortNumber(8080)),
+							},
+							Weight: common.PtrTo(int32(1)),
+						}}},
+					},
+					{
+						Matches: []gatewayv1.HTTPRouteMatch{{
+							Path: &gatewayv1.HTTPPathMatch{Type: common.PtrTo(gatewayv1.PathMatchExact), Value: common.PtrTo("/cart")},
+							Headers: []gatewayv1.HTTPHeaderMatch{{
+								Type:  common.PtrTo(gatewayv1.HeaderMatchExact),
+								Name:  "version",
+								Value: "v2",
+							}},
+						}},
+						BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Name: "cart-svc",
+								Port: common.PtrTo(gatewayv1.PortNumber(8080)),
+							},
+							Weight: common.PtrTo(int32(90)),
+						}}},
+					},
+				},
+			},
+			Status: gatewayv1.HTTPRouteStatus{
+				RouteStatus: gatewayv1.RouteStatus{
+					Parents: []gatewayv1.RouteParentStatus{{
+						ParentRef:      gatewayv1.ParentReference{Name: "edge-gw"},
+						ControllerName: "example.net/gateway-controller",
+					}},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to discover resources: %v", err)
+	}
+
+	buff := &bytes.Buffer{}
+	hp := &HTTPRoutesPrinter{Writer: buff, Clock: fakeClock}
+	PrintCanonical(hp, resourceModel, utils.OutputFormatYAML)
+
+	got := common.YamlString(buff.String())
+	want := common.YamlString(`
+apiVersion: v1
+items:
+- apiVersion: gateway.networking.k8s.io/v1
+  kind: HTTPRoute
+  metadata:
+    name: checkout
+    namespace: default
+  spec:
+    parentRefs:
+    - name: edge-gw
+    rules:
+    - backendRefs:
+      - name: checkout-svc
+        port: 8080
+    - backendRefs:
+      - name: cart-svc
+        port: 8080
+        weight: 90
+      matches:
+      - headers:
+        - name: version
+          value: v2
+        path:
+          type: Exact
+          value: /cart
+kind: List
+`)
+	if diff := cmp.Diff(want, got, common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
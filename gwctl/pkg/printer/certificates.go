@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+type CertificatesPrinter struct {
+	io.Writer
+	Clock clock.Clock
+	// AnnotationColumns are additional columns of the table, holding the
+	// values of annotations of the Gateway of each certificate.
+	AnnotationColumns []common.AnnotationColumn
+}
+
+// Print prints the certificates, which were found for the Gateways of the
+// resourceModel.
+func (cp *CertificatesPrinter) Print(resourceModel *resourcediscovery.ResourceModel, certificates []resourcediscovery.CertificateView, format utils.OutputFormat) {
+	switch format {
+	case utils.OutputFormatJSON, utils.OutputFormatYAML:
+		output, err := utils.MarshalWithFormat(certificates, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(cp, string(output))
+	case utils.OutputFormatTable, utils.OutputFormatWide:
+		cp.printTable(resourceModel, certificates)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output format '%s' found\n", format)
+		os.Exit(1)
+	}
+}
+
+// printTable prints the certificates as a table. An ERROR column is added if
+// any of the certificates could not be read, before the annotation columns.
+func (cp *CertificatesPrinter) printTable(resourceModel *resourcediscovery.ResourceModel, certificates []resourcediscovery.CertificateView) {
+	columnNames := []string{"GATEWAY", "LISTENER", "SECRET", "NOT AFTER", "DAYS LEFT", "ISSUER"}
+	var hasErrors bool
+	for _, certificate := range certificates {
+		hasErrors = hasErrors || certificate.Error != ""
+	}
+	if hasErrors {
+		columnNames = append(columnNames, "ERROR")
+	}
+	columnNames = append(columnNames, annotationColumnNames(cp.AnnotationColumns)...)
+	table := &Table{
+		ColumnNames:  columnNames,
+		UseSeparator: false,
+	}
+
+	for _, certificate := range certificates {
+		notAfter, daysLeft, issuer := "Unknown", "Unknown", "Unknown"
+		if certificate.NotAfter != nil {
+			notAfter = certificate.NotAfter.UTC().Format(time.RFC3339)
+			daysLeft = fmt.Sprintf("%d", int(certificate.NotAfter.Sub(cp.Clock.Now()).Hours()/24))
+			issuer = certificate.Issuer
+		}
+		row := []string{
+			fmt.Sprintf("%v/%v", certificate.Gateway.Namespace, certificate.Gateway.Name),
+			certificate.Listener,
+			fmt.Sprintf("%v/%v", certificate.Secret.Namespace, certificate.Secret.Name),
+			notAfter,
+			daysLeft,
+			issuer,
+		}
+		if hasErrors {
+			errorOutput := "None"
+			if certificate.Error != "" {
+				errorOutput = certificate.Error
+			}
+			row = append(row, errorOutput)
+		}
+		row = append(row, gatewayAnnotationColumnValues(resourceModel, certificate.Gateway, cp.AnnotationColumns)...)
+		table.Rows = append(table.Rows, row)
+	}
+	table.Write(cp, 0)
+}
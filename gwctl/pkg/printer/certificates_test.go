@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testingclock "k8s.io/utils/clock/testing"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func certificatePEMForTest(t *testing.T, issuer string, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: issuer},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCertificatesPrinter_Print(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	tlsListener := func(name string, secretNames ...string) gatewayv1.Listener {
+		listener := gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(name),
+			Port:     443,
+			Protocol: gatewayv1.HTTPSProtocolType,
+			TLS:      &gatewayv1.GatewayTLSConfig{},
+		}
+		for _, secretName := range secretNames {
+			listener.TLS.CertificateRefs = append(listener.TLS.CertificateRefs, gatewayv1.SecretObjectReference{Name: gatewayv1.ObjectName(secretName)})
+		}
+		return listener
+	}
+
+	objects := []runtime.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-gateway",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+					tlsListener("https", "expiring-cert", "valid-cert"),
+					tlsListener("https-2", "opaque-secret", "garbage-cert", "missing-secret"),
+				},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "expiring-cert", Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: certificatePEMForTest(t, "Expiring CA", fakeClock.Now().Add(10*24*time.Hour))},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "valid-cert", Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: certificatePEMForTest(t, "Valid CA", fakeClock.Now().Add(90*24*time.Hour))},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "opaque-secret", Namespace: "default"},
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "garbage-cert", Namespace: "default"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("garbage")},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+	certificates := discoverer.CertificatesForGateways(resourceModel)
+
+	buff := &bytes.Buffer{}
+	cp := &CertificatesPrinter{Writer: buff, Clock: fakeClock}
+	cp.Print(resourceModel, certificates, utils.OutputFormatTable)
+
+	got := buff.String()
+	want := `
+GATEWAY              LISTENER  SECRET                  NOT AFTER             DAYS LEFT  ISSUER          ERROR
+default/foo-gateway  https     default/expiring-cert   2024-06-11T00:00:00Z  10         CN=Expiring CA  None
+default/foo-gateway  https     default/valid-cert      2024-08-30T00:00:00Z  90         CN=Valid CA     None
+default/foo-gateway  https-2   default/opaque-secret   Unknown               Unknown    Unknown         no tls.crt in Secret
+default/foo-gateway  https-2   default/garbage-cert    Unknown               Unknown    Unknown         no PEM encoded certificate found in tls.crt
+default/foo-gateway  https-2   default/missing-secret  Unknown               Unknown    Unknown         failed to get Secret: secrets "missing-secret" not found
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+
+	// Only certificates expiring within 30 days, and those which could not be
+	// read, are kept.
+	buff.Reset()
+	expiring := resourcediscovery.FilterCertificatesExpiringBefore(certificates, fakeClock.Now().Add(30*24*time.Hour))
+	cp.Print(resourceModel, expiring, utils.OutputFormatTable)
+
+	got = buff.String()
+	want = `
+GATEWAY              LISTENER  SECRET                  NOT AFTER             DAYS LEFT  ISSUER          ERROR
+default/foo-gateway  https     default/expiring-cert   2024-06-11T00:00:00Z  10         CN=Expiring CA  None
+default/foo-gateway  https-2   default/opaque-secret   Unknown               Unknown    Unknown         no tls.crt in Secret
+default/foo-gateway  https-2   default/garbage-cert    Unknown               Unknown    Unknown         no PEM encoded certificate found in tls.crt
+default/foo-gateway  https-2   default/missing-secret  Unknown               Unknown    Unknown         failed to get Secret: secrets "missing-secret" not found
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
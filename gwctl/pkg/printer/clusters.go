@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// ClusterAnnotation annotates the resources printed by PrintClusters in the
+// JSON and YAML formats with the cluster they were discovered in.
+const ClusterAnnotation = "gwctl.gateway.networking.k8s.io/cluster"
+
+// ClusterResourceModel is the model of the resources discovered in one cluster
+// of a fleet.
+type ClusterResourceModel struct {
+	// Cluster names the cluster, like the context of the kubeconfig through
+	// which it was discovered.
+	Cluster       string
+	ResourceModel *resourcediscovery.ResourceModel
+}
+
+// TablePrinter is a Printer which can return the table it prints, so that the
+// tables of several clusters can be merged.
+type TablePrinter interface {
+	Printer
+	Table(resourceModel *resourcediscovery.ResourceModel, wide bool) *Table
+}
+
+// PrintClusters prints the resources of several clusters as a single list, in
+// the order of the clusters. Tables get a leading CLUSTER column, and in the
+// JSON and YAML formats, each resource is annotated with its cluster under
+// ClusterAnnotation.
+func PrintClusters(p TablePrinter, clusterModels []ClusterResourceModel, format utils.OutputFormat) {
+	switch format {
+	case utils.OutputFormatTable, utils.OutputFormatWide:
+		mergeClusterTables(p, clusterModels, format == utils.OutputFormatWide).Write(p, 0)
+	case utils.OutputFormatJSON, utils.OutputFormatYAML:
+		printablePayload, err := renderPrintableObject(nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to form the printable payload %v\n", err)
+			os.Exit(1)
+		}
+		for _, clusterModel := range clusterModels {
+			nodes := SortByString(p.GetPrintableNodes(clusterModel.ResourceModel))
+			clusterPayload, err := renderPrintableObject(ClientObjects(nodes))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to form the printable payload %v\n", err)
+				os.Exit(1)
+			}
+			for _, item := range clusterPayload.Items {
+				setClusterAnnotation(item, clusterModel.Cluster)
+				printablePayload.Items = append(printablePayload.Items, item)
+			}
+		}
+		output, err := utils.MarshalWithFormat(printablePayload, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(p, string(output))
+	default:
+		fmt.Fprintf(os.Stderr, "Unrecognized output format for several clusters: %s\n", format)
+		os.Exit(1)
+	}
+}
+
+// mergeClusterTables returns the rows of the tables of all clusters, each
+// prefixed with the cluster, in a single table.
+func mergeClusterTables(p TablePrinter, clusterModels []ClusterResourceModel, wide bool) *Table {
+	result := &Table{}
+	for _, clusterModel := range clusterModels {
+		table := p.Table(clusterModel.ResourceModel, wide)
+		if result.ColumnNames == nil {
+			result.ColumnNames = append([]string{"CLUSTER"}, table.ColumnNames...)
+			result.UseSeparator = table.UseSeparator
+		}
+		if table.Highlighted != nil && result.Highlighted == nil {
+			result.Highlighted = make(map[int]bool)
+			result.HighlightStyle = table.HighlightStyle
+		}
+		for i, row := range table.Rows {
+			if table.Highlighted[i] {
+				result.Highlighted[len(result.Rows)] = true
+			}
+			result.Rows = append(result.Rows, append([]string{clusterModel.Cluster}, row...))
+		}
+	}
+	return result
+}
+
+// setClusterAnnotation annotates the printable object with the cluster.
+func setClusterAnnotation(obj unstructured.Unstructured, cluster string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ClusterAnnotation] = cluster
+	obj.SetAnnotations(annotations)
+}
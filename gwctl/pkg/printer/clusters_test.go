@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testingclock "k8s.io/utils/clock/testing"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestPrintClusters(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	gateway := func(name, address string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: gatewayv1.GroupVersion.String(),
+				Kind:       "Gateway",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(fakeClock.Now().Add(-24 * time.Hour)),
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners:        []gatewayv1.Listener{{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80}},
+			},
+			Status: gatewayv1.GatewayStatus{
+				Addresses: []gatewayv1.GatewayStatusAddress{{Value: address}},
+			},
+		}
+	}
+	// Both clusters have a Gateway with the same name, which must not be
+	// merged into one.
+	clusters := map[string][]runtime.Object{
+		"us-east": {gateway("edge-gw", "10.0.0.1"), gateway("internal-gw", "10.0.0.2")},
+		"eu-west": {gateway("edge-gw", "10.1.0.1")},
+	}
+	var clusterModels []ClusterResourceModel
+	for _, cluster := range []string{"us-east", "eu-west"} {
+		k8sClients := common.MustClientsForTest(t, append(clusters[cluster], &gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-gatewayclass"},
+		})...)
+		discoverer := resourcediscovery.Discoverer{
+			K8sClients:    k8sClients,
+			PolicyManager: utils.MustPolicyManagerForTest(t, k8sClients),
+		}
+		resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{})
+		if err != nil {
+			t.Fatalf("Failed to discover resources: %v", err)
+		}
+		clusterModels = append(clusterModels, ClusterResourceModel{Cluster: cluster, ResourceModel: resourceModel})
+	}
+
+	buff := &bytes.Buffer{}
+	gp := &GatewaysPrinter{Writer: buff, Clock: fakeClock}
+	PrintClusters(gp, clusterModels, utils.OutputFormatTable)
+
+	got := buff.String()
+	want := `
+CLUSTER  NAMESPACE  NAME         CLASS             ADDRESSES  PORTS  PROGRAMMED  AGE
+us-east  default    edge-gw      foo-gatewayclass  10.0.0.1   80     Unknown     24h
+us-east  default    internal-gw  foo-gatewayclass  10.0.0.2   80     Unknown     24h
+eu-west  default    edge-gw      foo-gatewayclass  10.1.0.1   80     Unknown     24h
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+
+	buff.Reset()
+	PrintClusters(gp, clusterModels, utils.OutputFormatJSON)
+	list := struct {
+		Items []struct {
+			Metadata metav1.ObjectMeta `json:"metadata"`
+		} `json:"items"`
+	}{}
+	if err := json.Unmarshal(buff.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to unmarshal the output: %v", err)
+	}
+	var gotClusters []string
+	for _, item := range list.Items {
+		gotClusters = append(gotClusters, item.Metadata.Annotations[ClusterAnnotation]+"/"+item.Metadata.Name)
+	}
+	wantClusters := []string{"us-east/edge-gw", "us-east/internal-gw", "eu-west/edge-gw"}
+	if diff := cmp.Diff(wantClusters, gotClusters); diff != "" {
+		t.Errorf("PrintClusters() annotated unexpected clusters (-want +got):\n%v", diff)
+	}
+}
@@ -17,21 +17,30 @@ limitations under the License.
 package printer
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
 )
 
 // DescriberKV stores key-value pairs that are used with Describing a resource.
@@ -77,11 +86,22 @@ type Table struct {
 	// UseSeparator indicates whether the header row and data rows will be
 	// separated through a separator.
 	UseSeparator bool
+	// Truncated is the number of rows which were omitted from the table, which
+	// is noted after the rows.
+	Truncated int
+	// Highlighted are the indices of the rows which are highlighted in the
+	// HighlightStyle.
+	Highlighted    map[int]bool
+	HighlightStyle HighlightStyle
 }
 
 // Write will write a formatted table to the writer. indent controls the
 // number of spaces at the beginning of each row.
 func (t *Table) Write(w io.Writer, indent int) {
+	if t.Highlighted != nil {
+		t.writeHighlighted(w, indent)
+		return
+	}
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 
 	// Print column names.
@@ -118,6 +138,78 @@ func (t *Table) Write(w io.Writer, indent int) {
 		}
 	}
 	tw.Flush()
+
+	if t.Truncated > 0 {
+		writeTruncated(w, indent, t.Truncated)
+	}
+}
+
+// writeTruncated writes the note that count items were omitted from the list
+// or table printed before it.
+func writeTruncated(w io.Writer, indent, count int) {
+	fmt.Fprintf(w, "%s(… and %d more, use --max-list-items=0 to show all)\n", strings.Repeat(" ", indent), count)
+}
+
+// sortRows sorts the rows of the table by their columns from left to right.
+func sortRows(table *Table) {
+	sort.Slice(table.Rows, func(i, j int) bool {
+		return slices.Compare(table.Rows[i], table.Rows[j]) < 0
+	})
+}
+
+// truncateTables keeps at most maxItems rows of each Table within the pairs,
+// so that describing resources with many related resources remains readable.
+// Rows are kept in their order, so tables must already be sorted. Nothing is
+// truncated if maxItems is 0.
+func truncateTables(pairs []*DescriberKV, maxItems int) []*DescriberKV {
+	if maxItems <= 0 {
+		return pairs
+	}
+	for _, pair := range pairs {
+		table, ok := pair.Value.(*Table)
+		if !ok || len(table.Rows) <= maxItems {
+			continue
+		}
+		table.Truncated += len(table.Rows) - maxItems
+		table.Rows = table.Rows[:maxItems]
+	}
+	return pairs
+}
+
+// truncateList keeps at most maxItems of the items, like truncateTables does
+// for the rows of tables, and returns the number of items which were omitted.
+func truncateList[T any](items []T, maxItems int) ([]T, int) {
+	if maxItems <= 0 || len(items) <= maxItems {
+		return items, 0
+	}
+	return items[:maxItems], len(items) - maxItems
+}
+
+// writeHighlighted writes the table like Write, highlighting the rows which
+// are Highlighted. Rows are highlighted after they have been aligned, so that
+// the escape sequences of colors do not count towards the widths of columns.
+func (t *Table) writeHighlighted(w io.Writer, indent int) {
+	highlighted := t.Highlighted
+	t.Highlighted = nil
+	defer func() { t.Highlighted = highlighted }()
+
+	var buff bytes.Buffer
+	t.Write(&buff, indent)
+
+	headerLines := 0
+	if len(t.ColumnNames) > 0 {
+		headerLines++
+	}
+	if t.UseSeparator {
+		headerLines++
+	}
+	lines := strings.SplitAfter(buff.String(), "\n")
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		fmt.Fprint(w, t.HighlightStyle.apply(line, highlighted[i-headerLines]))
+	}
 }
 
 // indentRow will add 'indent' spaces to the beginning of the row.
@@ -131,6 +223,21 @@ func (t *Table) indentRow(row []string, indent int) []string {
 	return newRow
 }
 
+// mostRecentEvents returns at most limit events, sorted by their
+// lastTimestamp with the most recent first. All events are returned if limit
+// is 0.
+func mostRecentEvents(events []corev1.Event, limit int) []corev1.Event {
+	result := make([]corev1.Event, len(events))
+	copy(result, events)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[j].LastTimestamp.Before(&result[i].LastTimestamp)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
 func convertEventsSliceToTable(events []corev1.Event, clock clock.Clock) *Table {
 	table := &Table{
 		ColumnNames:  []string{"Type", "Reason", "Age", "From", "Message"},
@@ -170,9 +277,127 @@ func convertPolicyRefsToTable(policyRefs []common.ObjRef) *Table {
 		}
 		table.Rows = append(table.Rows, row)
 	}
+	sortRows(table)
 	return table
 }
 
+// formatStale returns the value of the STALE column.
+func formatStale(stale bool) string {
+	if stale {
+		return "True"
+	}
+	return "False"
+}
+
+// labelColumnNames returns the column names used for the values of the given
+// label keys. Similar to kubectl, the column name is the upper-cased last
+// segment of the label key.
+func labelColumnNames(labelColumns []string) []string {
+	var result []string
+	for _, key := range labelColumns {
+		result = append(result, strings.ToUpper(key[strings.LastIndex(key, "/")+1:]))
+	}
+	return result
+}
+
+// labelColumnValues returns the values of the given label keys for the object.
+// Labels which are not present are rendered as empty values.
+func labelColumnValues(obj metav1.Object, labelColumns []string) []string {
+	var result []string
+	for _, key := range labelColumns {
+		result = append(result, obj.GetLabels()[key])
+	}
+	return result
+}
+
+// annotationColumnNames returns the column names used for the annotation
+// columns, which are their upper-cased names.
+func annotationColumnNames(annotationColumns []common.AnnotationColumn) []string {
+	var result []string
+	for _, column := range annotationColumns {
+		result = append(result, strings.ToUpper(column.Name))
+	}
+	return result
+}
+
+// annotationColumnValues returns the values of the annotation columns for the
+// object. Annotations which are not present are rendered as empty values.
+func annotationColumnValues(obj metav1.Object, annotationColumns []common.AnnotationColumn) []string {
+	var result []string
+	for _, column := range annotationColumns {
+		result = append(result, obj.GetAnnotations()[column.Annotation])
+	}
+	return result
+}
+
+// gatewayAnnotationColumnValues returns the values of the annotation columns
+// for the Gateway of the resourceModel, which are empty if the Gateway is not
+// part of it.
+func gatewayAnnotationColumnValues(resourceModel *resourcediscovery.ResourceModel, gatewayRef common.ObjRef, annotationColumns []common.AnnotationColumn) []string {
+	gatewayNode, ok := resourceModel.Gateways[resourcediscovery.GatewayID(gatewayRef.Namespace, gatewayRef.Name)]
+	if !ok {
+		return make([]string, len(annotationColumns))
+	}
+	return annotationColumnValues(gatewayNode.Gateway, annotationColumns)
+}
+
+// filterPoliciesByKind returns the policies of the given kind. The kind can
+// either be the Kind of the policy (e.g. "HealthCheckPolicy") or its
+// PolicyCrdID (e.g. "HealthCheckPolicy.foo.com"), and is matched
+// case-insensitively. All policies are returned if kind is empty.
+func filterPoliciesByKind(policies map[policymanager.PolicyCrdID]policymanager.Policy, kind string) map[policymanager.PolicyCrdID]policymanager.Policy {
+	if kind == "" {
+		return policies
+	}
+	result := make(map[policymanager.PolicyCrdID]policymanager.Policy)
+	for policyCrdID, policy := range policies {
+		if strings.EqualFold(string(policyCrdID), kind) || strings.EqualFold(policy.Unstructured().GetKind(), kind) {
+			result[policyCrdID] = policy
+		}
+	}
+	return result
+}
+
+// filterPoliciesByGatewayByKind is similar to filterPoliciesByKind but for
+// effective policies which are partitioned by Gateway. Gateways left without
+// any policies are dropped.
+func filterPoliciesByGatewayByKind[K comparable](policiesByGateway map[K]map[policymanager.PolicyCrdID]policymanager.Policy, kind string) map[K]map[policymanager.PolicyCrdID]policymanager.Policy {
+	if kind == "" {
+		return policiesByGateway
+	}
+	result := make(map[K]map[policymanager.PolicyCrdID]policymanager.Policy)
+	for gatewayID, policies := range policiesByGateway {
+		if filtered := filterPoliciesByKind(policies, kind); len(filtered) != 0 {
+			result[gatewayID] = filtered
+		}
+	}
+	return result
+}
+
+// convertPoliciesToPolicySummary returns a one-line summary for each kind of
+// the effective policies, describing whether it was applied, overridden or
+// conflicted.
+func convertPoliciesToPolicySummary(policies map[policymanager.PolicyCrdID]policymanager.Policy) []string {
+	var result []string
+	for _, summary := range policymanager.SummarizePolicies(policies) {
+		result = append(result, summary.String())
+	}
+	return result
+}
+
+// convertPoliciesByGatewayToPolicySummary is similar to
+// convertPoliciesToPolicySummary but for effective policies which are
+// partitioned by Gateway.
+func convertPoliciesByGatewayToPolicySummary[K comparable](policiesByGateway map[K]map[policymanager.PolicyCrdID]policymanager.Policy) map[K][]string {
+	result := make(map[K][]string)
+	for gatewayID, policies := range policiesByGateway {
+		if len(policies) != 0 {
+			result[gatewayID] = convertPoliciesToPolicySummary(policies)
+		}
+	}
+	return result
+}
+
 func convertErrorsToString(errors []error) []string {
 	var result []string
 	for _, err := range errors {
@@ -181,6 +406,237 @@ func convertErrorsToString(errors []error) []string {
 	return result
 }
 
+// DescribeSeparator starts the line printed between the describe views of
+// multiple resources. It is followed by the kind and name of the next
+// resource, so the output can be split with tools like grep or csplit.
+const DescribeSeparator = "------"
+
+func writeDescribeSeparator(w io.Writer, kind string, obj client.Object) {
+	name := obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = fmt.Sprintf("%v/%v", obj.GetNamespace(), name)
+	}
+	fmt.Fprintf(w, "\n%v %v %v\n", DescribeSeparator, kind, name)
+}
+
+// lastAppliedConfigAnnotation is the annotation in which `kubectl apply`
+// records the configuration it last applied.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// statusWithGroupedConditions returns the status with every list of conditions
+// within it, including those nested within listeners or parents, grouped into
+// the standard and the implementation-specific conditions. Each condition is
+// annotated with its age, the time since its lastTransitionTime.
+func statusWithGroupedConditions(status any, c clock.PassiveClock) any {
+	b, err := json.Marshal(status)
+	if err != nil {
+		return status
+	}
+	var generic any
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return status
+	}
+	groupConditionsWithin(generic, c)
+	return generic
+}
+
+func groupConditionsWithin(v any, c clock.PassiveClock) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if conditions, ok := value.([]any); ok && key == "conditions" {
+				v[key] = groupConditions(conditions, c)
+				continue
+			}
+			groupConditionsWithin(value, c)
+		}
+	case []any:
+		for _, item := range v {
+			groupConditionsWithin(item, c)
+		}
+	}
+}
+
+// groupConditions groups the generic representation of conditions, preserving
+// their order within each group.
+func groupConditions(conditions []any, c clock.PassiveClock) map[string]any {
+	var standard, implementationSpecific []any
+	for _, condition := range conditions {
+		conditionMap, _ := condition.(map[string]any)
+		if conditionMap != nil {
+			var lastTransitionTime metav1.Time
+			if s, ok := conditionMap["lastTransitionTime"].(string); ok {
+				if t, err := time.Parse(time.RFC3339, s); err == nil {
+					lastTransitionTime = metav1.NewTime(t)
+				}
+			}
+			conditionMap["age"] = formatConditionAge(c, lastTransitionTime)
+		}
+		conditionType, _ := conditionMap["type"].(string)
+		if common.IsStandardConditionType(conditionType) {
+			standard = append(standard, condition)
+		} else {
+			implementationSpecific = append(implementationSpecific, condition)
+		}
+	}
+	result := make(map[string]any)
+	if len(standard) != 0 {
+		result["standard"] = standard
+	}
+	if len(implementationSpecific) != 0 {
+		result["implementationSpecific"] = implementationSpecific
+	}
+	return result
+}
+
+// formatConditionAge returns the time since the lastTransitionTime of a
+// condition, or "<unknown>" if the condition has no lastTransitionTime.
+func formatConditionAge(c clock.PassiveClock, lastTransitionTime metav1.Time) string {
+	if lastTransitionTime.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(c.Since(lastTransitionTime.Time))
+}
+
+// specDrift returns a unified diff from the spec within the last-applied
+// configuration of the object to its live spec. Fields which are absent from
+// the last-applied configuration are ignored, since they are usually defaulted
+// by the API server.
+func specDrift(obj client.Object) string {
+	lastApplied, ok := obj.GetAnnotations()[lastAppliedConfigAnnotation]
+	if !ok {
+		return "<no last-applied annotation>"
+	}
+	var lastAppliedObj, liveObj map[string]any
+	if err := json.Unmarshal([]byte(lastApplied), &lastAppliedObj); err != nil {
+		return fmt.Sprintf("<invalid last-applied annotation: %v>", err)
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Sprintf("<failed to marshal the resource: %v>", err)
+	}
+	if err := json.Unmarshal(b, &liveObj); err != nil {
+		return fmt.Sprintf("<failed to unmarshal the resource: %v>", err)
+	}
+
+	liveSpec := pruneToLastApplied(liveObj["spec"], lastAppliedObj["spec"])
+	diff, err := common.DiffYAML("last-applied", lastAppliedObj["spec"], "live", liveSpec)
+	if err != nil {
+		return fmt.Sprintf("<failed to diff the spec: %v>", err)
+	}
+	if diff == "" {
+		return "<no drift>"
+	}
+	return diff
+}
+
+// specFieldOwners returns the field managers owning each top-level field of the
+// spec of the object, from its managedFields.
+func specFieldOwners(obj client.Object) any {
+	if len(obj.GetManagedFields()) == 0 {
+		return "<no managedFields>"
+	}
+	owners, err := common.SpecFieldOwners(obj.GetManagedFields())
+	if err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	result := make(map[string][]string)
+	for path, fieldOwners := range owners {
+		for _, owner := range fieldOwners {
+			result[path] = append(result[path], owner.String())
+		}
+	}
+	return result
+}
+
+// maxFieldManagerPaths is the maximum number of fields listed for each manager
+// in the FieldManagers section of the describe view.
+const maxFieldManagerPaths = 10
+
+// fieldManagers returns the paths of the fields owned by each field manager of
+// the object, keyed by the manager and how it wrote them. The paths of each
+// manager are capped at maxFieldManagerPaths. The result is nil if the object
+// has no managers owning any fields.
+func fieldManagers(obj client.Object) map[string][]string {
+	var result map[string][]string
+	for _, entry := range obj.GetManagedFields() {
+		owner := common.FieldOwner{Manager: entry.Manager, Operation: entry.Operation, Subresource: entry.Subresource}.String()
+		paths, err := common.ManagedFieldPaths(entry)
+		if err != nil {
+			paths = []string{fmt.Sprintf("<%v>", err)}
+		}
+		if len(paths) == 0 {
+			continue
+		}
+		if result == nil {
+			result = make(map[string][]string)
+		}
+		if len(paths) > maxFieldManagerPaths {
+			paths = append(paths[:maxFieldManagerPaths], fmt.Sprintf("... and %d more", len(paths)-maxFieldManagerPaths))
+		}
+		result[owner] = append(result[owner], paths...)
+	}
+	return result
+}
+
+// formatSpecManagers returns the number of distinct managers which own fields
+// of the spec of the object.
+func formatSpecManagers(obj client.Object) string {
+	managers, err := common.SpecManagers(obj.GetManagedFields())
+	if err != nil {
+		return "Unknown"
+	}
+	return fmt.Sprintf("%d", len(managers))
+}
+
+// pruneToLastApplied removes the fields of live which are absent from
+// lastApplied. Items of lists are matched with the item of lastApplied they
+// are equal to after pruning, falling back to the item at the same index.
+func pruneToLastApplied(live, lastApplied any) any {
+	switch lastApplied := lastApplied.(type) {
+	case map[string]any:
+		liveMap, ok := live.(map[string]any)
+		if !ok {
+			return live
+		}
+		result := make(map[string]any)
+		for key, value := range liveMap {
+			if lastAppliedValue, ok := lastApplied[key]; ok {
+				result[key] = pruneToLastApplied(value, lastAppliedValue)
+			}
+		}
+		return result
+	case []any:
+		liveList, ok := live.([]any)
+		if !ok {
+			return live
+		}
+		used := make([]bool, len(lastApplied))
+		result := make([]any, len(liveList))
+		for i, item := range liveList {
+			match := -1
+			for j, lastAppliedItem := range lastApplied {
+				if !used[j] && reflect.DeepEqual(pruneToLastApplied(item, lastAppliedItem), lastAppliedItem) {
+					match = j
+					break
+				}
+			}
+			if match == -1 && i < len(lastApplied) && !used[i] {
+				match = i
+			}
+			if match == -1 {
+				result[i] = item
+				continue
+			}
+			used[match] = true
+			result[i] = pruneToLastApplied(item, lastApplied[match])
+		}
+		return result
+	default:
+		return live
+	}
+}
+
 type NodeResource interface {
 	ClientObject() client.Object
 }
@@ -202,6 +658,17 @@ func SortByString[K NodeResource](items []K) []K {
 	return items
 }
 
+// SortByPolicyCount sorts the items in descending order of the number of
+// policies attached to them. Items with the same number of policies are sorted
+// by their namespace and name.
+func SortByPolicyCount[K NodeResource](items []K, policyCount func(K) int) []K {
+	SortByString(items)
+	sort.SliceStable(items, func(i, j int) bool {
+		return policyCount(items[i]) > policyCount(items[j])
+	})
+	return items
+}
+
 func NodeResources[K NodeResource](items []K) []NodeResource {
 	output := make([]NodeResource, len(items))
 	for i, item := range items {
@@ -210,6 +677,30 @@ func NodeResources[K NodeResource](items []K) []NodeResource {
 	return output
 }
 
+// formatAge returns the human readable age of the object. Objects which are
+// being deleted are marked as DELETING.
+func formatAge(c clock.PassiveClock, obj metav1.Object) string {
+	age := duration.HumanDuration(c.Since(obj.GetCreationTimestamp().Time))
+	if obj.GetDeletionTimestamp() != nil {
+		return age + " (DELETING)"
+	}
+	return age
+}
+
+// formatNamespace returns the name of the namespace, marking namespaces which
+// are terminating. namespaceNode may be nil if the namespace was not
+// discovered.
+func formatNamespace(name string, namespaceNode *resourcediscovery.NamespaceNode) string {
+	if namespaceNode != nil && namespaceNode.IsTerminating() {
+		return name + " (terminating)"
+	}
+	return name
+}
+
 type eventFetcher interface {
 	FetchEventsFor(context.Context, client.Object) *corev1.EventList
 }
+
+type policyTargetFetcher interface {
+	FetchPolicyTarget(context.Context, policymanager.Policy) (*unstructured.Unstructured, error)
+}
@@ -18,10 +18,18 @@ package printer
 
 import (
 	"bytes"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
 )
 
@@ -83,6 +91,43 @@ Key4-table:
 	}
 }
 
+func TestMostRecentEvents(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	event := func(name string, age time.Duration) corev1.Event {
+		return corev1.Event{
+			ObjectMeta:    metav1.ObjectMeta{Name: name},
+			LastTimestamp: metav1.NewTime(now.Add(-age)),
+		}
+	}
+	events := []corev1.Event{
+		event("oldest", 3*time.Hour),
+		event("newest", time.Minute),
+		event("older", 2*time.Hour),
+		event("newer", time.Hour),
+	}
+
+	testcases := []struct {
+		limit int
+		want  []string
+	}{
+		{limit: 0, want: []string{"newest", "newer", "older", "oldest"}},
+		{limit: 2, want: []string{"newest", "newer"}},
+		{limit: 10, want: []string{"newest", "newer", "older", "oldest"}},
+	}
+	for _, tc := range testcases {
+		var got []string
+		for _, event := range mostRecentEvents(events, tc.limit) {
+			got = append(got, event.Name)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("mostRecentEvents(limit=%d) returned unexpected diff (-want +got):\n%v", tc.limit, diff)
+		}
+	}
+	if events[0].Name != "oldest" {
+		t.Errorf("mostRecentEvents() modified the events")
+	}
+}
+
 func TestTable_writeTable(t *testing.T) {
 	testcases := []struct {
 		name   string
@@ -156,3 +201,277 @@ TCPRoute   ns2/my-tcproute
 		})
 	}
 }
+
+// policyCountNode is a NodeResource with a fixed number of policies.
+type policyCountNode struct {
+	obj      client.Object
+	policies int
+}
+
+func (n policyCountNode) ClientObject() client.Object { return n.obj }
+
+func TestSortByPolicyCount(t *testing.T) {
+	newNode := func(namespace, name string, policies int) policyCountNode {
+		return policyCountNode{
+			obj:      &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}},
+			policies: policies,
+		}
+	}
+	nodes := []policyCountNode{
+		newNode("ns2", "d", 0),
+		newNode("default", "c", 1),
+		newNode("default", "a", 1),
+		newNode("default", "b", 3),
+	}
+
+	got := SortByPolicyCount(nodes, func(node policyCountNode) int { return node.policies })
+
+	var gotKeys []string
+	for _, node := range got {
+		gotKeys = append(gotKeys, client.ObjectKeyFromObject(node.ClientObject()).String())
+	}
+	want := []string{"default/b", "default/a", "default/c", "ns2/d"}
+	if diff := cmp.Diff(want, gotKeys); diff != "" {
+		t.Errorf("SortByPolicyCount() returned unexpected order (-want +got):\n%v", diff)
+	}
+}
+
+func TestSpecDrift(t *testing.T) {
+	// The listeners of the live spec are reordered and have defaulted fields
+	// which are absent from the last-applied configuration.
+	newGateway := func(lastApplied string, httpsPort gatewayv1.PortNumber) *gatewayv1.Gateway {
+		fromSame := gatewayv1.NamespacesFromSame
+		gateway := &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-gateway",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{
+						Name:          "https",
+						Port:          httpsPort,
+						Protocol:      gatewayv1.HTTPSProtocolType,
+						AllowedRoutes: &gatewayv1.AllowedRoutes{Namespaces: &gatewayv1.RouteNamespaces{From: &fromSame}},
+					},
+					{
+						Name:          "http",
+						Port:          80,
+						Protocol:      gatewayv1.HTTPProtocolType,
+						AllowedRoutes: &gatewayv1.AllowedRoutes{Namespaces: &gatewayv1.RouteNamespaces{From: &fromSame}},
+					},
+				},
+			},
+		}
+		if lastApplied != "" {
+			gateway.Annotations = map[string]string{lastAppliedConfigAnnotation: lastApplied}
+		}
+		return gateway
+	}
+	lastApplied := `{"apiVersion":"gateway.networking.k8s.io/v1","kind":"Gateway","metadata":{"name":"foo-gateway","namespace":"default"},"spec":{"gatewayClassName":"foo-gatewayclass","listeners":[{"name":"http","port":80,"protocol":"HTTP"},{"name":"https","port":443,"protocol":"HTTPS"}]}}`
+
+	testcases := []struct {
+		name    string
+		gateway *gatewayv1.Gateway
+		want    string
+	}{
+		{
+			name:    "no last-applied annotation",
+			gateway: newGateway("", 443),
+			want:    "<no last-applied annotation>",
+		},
+		{
+			name:    "no drift",
+			gateway: newGateway(lastApplied, 443),
+			want:    "<no drift>",
+		},
+		{
+			name:    "hot-patched port",
+			gateway: newGateway(lastApplied, 8443),
+			want: `--- last-applied
++++ live
+@@ -4,5 +4,5 @@
+   port: 80
+   protocol: HTTP
+ - name: https
+-  port: 443
++  port: 8443
+   protocol: HTTPS
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := specDrift(tc.gateway)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("specDrift() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestSpecFieldOwners(t *testing.T) {
+	httpRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-httproute",
+			Namespace: "default",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:   "kubectl",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:hostnames":{},"f:rules":{}}}`)},
+				},
+				{
+					Manager:   "example-controller",
+					Operation: metav1.ManagedFieldsOperationUpdate,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:rules":{}}}`)},
+				},
+			},
+		},
+	}
+	want := map[string][]string{
+		"spec.hostnames": {"kubectl (Apply)"},
+		"spec.rules":     {"example-controller (Update)", "kubectl (Apply)"},
+	}
+	if diff := cmp.Diff(any(want), specFieldOwners(httpRoute)); diff != "" {
+		t.Errorf("specFieldOwners() returned unexpected owners (-want +got):\n%v", diff)
+	}
+
+	httpRoute.ManagedFields = nil
+	if diff := cmp.Diff(any("<no managedFields>"), specFieldOwners(httpRoute)); diff != "" {
+		t.Errorf("specFieldOwners() returned unexpected owners (-want +got):\n%v", diff)
+	}
+}
+
+func TestFieldManagers(t *testing.T) {
+	var listeners []string
+	for i := 0; i < 12; i++ {
+		listeners = append(listeners, fmt.Sprintf(`"k:{\"name\":\"listener-%02d\"}":{"f:port":{}}`, i))
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-gateway",
+			Namespace: "default",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:   "kubectl",
+					Operation: metav1.ManagedFieldsOperationApply,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:gatewayClassName":{}}}`)},
+				},
+				{
+					Manager:   "example-controller",
+					Operation: metav1.ManagedFieldsOperationUpdate,
+					FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:listeners":{` + strings.Join(listeners, ",") + `}}}`)},
+				},
+			},
+		},
+	}
+	want := map[string][]string{
+		"kubectl (Apply)": {"spec.gatewayClassName"},
+		"example-controller (Update)": {
+			"spec.listeners[name=listener-00].port",
+			"spec.listeners[name=listener-01].port",
+			"spec.listeners[name=listener-02].port",
+			"spec.listeners[name=listener-03].port",
+			"spec.listeners[name=listener-04].port",
+			"spec.listeners[name=listener-05].port",
+			"spec.listeners[name=listener-06].port",
+			"spec.listeners[name=listener-07].port",
+			"spec.listeners[name=listener-08].port",
+			"spec.listeners[name=listener-09].port",
+			"... and 2 more",
+		},
+	}
+	if diff := cmp.Diff(want, fieldManagers(gateway)); diff != "" {
+		t.Errorf("fieldManagers() returned unexpected managers (-want +got):\n%v", diff)
+	}
+	if got := formatSpecManagers(gateway); got != "2" {
+		t.Errorf("formatSpecManagers() = %q, want %q", got, "2")
+	}
+
+	gateway.ManagedFields = nil
+	if got := fieldManagers(gateway); got != nil {
+		t.Errorf("fieldManagers() = %v, want nil for an object without managedFields", got)
+	}
+}
+
+func TestStatusWithGroupedConditions(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	status := &gatewayv1.GatewayStatus{
+		Conditions: []metav1.Condition{
+			{Type: "networking.example.com/LoadBalancerReady", Status: metav1.ConditionFalse, Reason: "Pending"},
+			{Type: "Accepted", Status: metav1.ConditionTrue, Reason: "Accepted"},
+			{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"},
+			{Type: "Programmed", Status: metav1.ConditionFalse, Reason: "Pending", LastTransitionTime: metav1.NewTime(fakeClock.Now().Add(-3 * time.Minute))},
+		},
+		Listeners: []gatewayv1.ListenerStatus{
+			{
+				Name:           "http",
+				AttachedRoutes: 1,
+				SupportedKinds: []gatewayv1.RouteGroupKind{},
+				Conditions: []metav1.Condition{
+					{Type: "ResolvedRefs", Status: metav1.ConditionTrue, Reason: "ResolvedRefs"},
+					{Type: "Conflicted", Status: metav1.ConditionFalse, Reason: "NoConflicts"},
+				},
+			},
+		},
+	}
+
+	buff := &bytes.Buffer{}
+	Describe(buff, []*DescriberKV{{Key: "Status", Value: statusWithGroupedConditions(status, fakeClock)}})
+
+	got := buff.String()
+	want := `
+Status:
+  conditions:
+    implementationSpecific:
+    - age: <unknown>
+      lastTransitionTime: null
+      message: ""
+      reason: Pending
+      status: "False"
+      type: networking.example.com/LoadBalancerReady
+    - age: <unknown>
+      lastTransitionTime: null
+      message: ""
+      reason: Ready
+      status: "True"
+      type: Ready
+    standard:
+    - age: <unknown>
+      lastTransitionTime: null
+      message: ""
+      reason: Accepted
+      status: "True"
+      type: Accepted
+    - age: 3m
+      lastTransitionTime: "2024-01-01T11:57:00Z"
+      message: ""
+      reason: Pending
+      status: "False"
+      type: Programmed
+  listeners:
+  - attachedRoutes: 1
+    conditions:
+      standard:
+      - age: <unknown>
+        lastTransitionTime: null
+        message: ""
+        reason: ResolvedRefs
+        status: "True"
+        type: ResolvedRefs
+      - age: <unknown>
+        lastTransitionTime: null
+        message: ""
+        reason: NoConflicts
+        status: "False"
+        type: Conflicted
+    name: http
+    supportedKinds: []
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/analysis"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// DryRunPrinter prints how HTTPRoutes would behave once applied to the
+// cluster.
+type DryRunPrinter struct {
+	io.Writer
+	// Sources are the sources of the resources which were read from files.
+	// Resources with a source are printed with it.
+	Sources common.ObjectSources
+}
+
+// Print prints how each HTTPRoute would behave, followed by the findings of
+// the analysis for the HTTPRoute. The findings for other resources, like the
+// Gateways serving the same hostnames, are printed after the HTTPRoutes.
+func (dp *DryRunPrinter) Print(dryRuns []*resourcediscovery.HTTPRouteDryRun, findings []analysis.Finding) {
+	printed := make(map[common.ObjRef]bool)
+	for i, dryRun := range dryRuns {
+		httpRouteNode := dryRun.HTTPRouteNode
+		if i > 0 {
+			writeDescribeSeparator(dp, "HTTPRoute", httpRouteNode.HTTPRoute)
+		}
+
+		resource := common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Name: httpRouteNode.HTTPRoute.GetName(), Namespace: httpRouteNode.HTTPRoute.GetNamespace()}
+		pairs := []*DescriberKV{
+			{Key: "HTTPRoute", Value: client.ObjectKeyFromObject(httpRouteNode.HTTPRoute).String()},
+		}
+		if source := dp.Sources.Lookup(resource); source != nil {
+			pairs = append(pairs, &DescriberKV{Key: "Source", Value: source.String()})
+		}
+		pairs = append(pairs, []*DescriberKV{
+			{Key: "Attachments", Value: convertListenerAttachmentsToTable(dryRun.Attachments)},
+			{Key: "Overlaps", Value: convertRouteOverlapsToTable(dryRun.Overlaps)},
+		}...)
+		if len(httpRouteNode.EffectivePolicies) != 0 {
+			pairs = append(pairs, &DescriberKV{Key: "PolicySummary", Value: convertPoliciesByGatewayToPolicySummary(httpRouteNode.EffectivePolicies)})
+			pairs = append(pairs, &DescriberKV{Key: "EffectivePolicies", Value: httpRouteNode.EffectivePolicies})
+		}
+
+		printed[resource] = true
+		if messages := findingMessagesFor(findings, resource); len(messages) != 0 {
+			pairs = append(pairs, &DescriberKV{Key: "Analysis", Value: messages})
+		}
+		Describe(dp, pairs)
+	}
+
+	for _, finding := range findings {
+		resource := finding.Resource
+		if printed[resource] {
+			continue
+		}
+		printed[resource] = true
+		fmt.Fprintf(dp, "\n%v %v %v/%v\n", DescribeSeparator, resource.Kind, resource.Namespace, resource.Name)
+		pairs := []*DescriberKV{
+			{Key: resource.Kind, Value: fmt.Sprintf("%v/%v", resource.Namespace, resource.Name)},
+		}
+		if finding.Source != nil {
+			pairs = append(pairs, &DescriberKV{Key: "Source", Value: finding.Source.String()})
+		}
+		pairs = append(pairs, &DescriberKV{Key: "Analysis", Value: findingMessagesFor(findings, resource)})
+		Describe(dp, pairs)
+	}
+}
+
+// PrintFindings prints the findings in a machine readable format.
+func (dp *DryRunPrinter) PrintFindings(findings []analysis.Finding, format utils.OutputFormat) {
+	output, err := utils.MarshalWithFormat(findings, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to marshal the findings %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprint(dp, string(output))
+}
+
+// PrintFindingIDs prints the ID of the check and the resource of each finding,
+// one finding per line, like "duplicate-hostname Gateway team-a/gateway-a".
+func (dp *DryRunPrinter) PrintFindingIDs(findings []analysis.Finding) {
+	for _, finding := range findings {
+		resource := finding.Resource
+		name := resource.Name
+		if resource.Namespace != "" {
+			name = resource.Namespace + "/" + name
+		}
+		fmt.Fprintf(dp, "%v %v %v\n", finding.CheckID, resource.Kind, name)
+	}
+}
+
+// findingMessagesFor returns the messages of the findings for the resource.
+func findingMessagesFor(findings []analysis.Finding, resource common.ObjRef) []string {
+	var result []string
+	for _, finding := range findings {
+		if finding.Resource == resource {
+			result = append(result, finding.Message)
+		}
+	}
+	return result
+}
+
+func convertListenerAttachmentsToTable(attachments []resourcediscovery.ListenerAttachment) *Table {
+	table := &Table{
+		ColumnNames:  []string{"Gateway", "Listener", "Attached", "Hostnames", "Reason"},
+		UseSeparator: true,
+	}
+	for _, attachment := range attachments {
+		listener := "None"
+		if attachment.Listener != "" {
+			listener = string(attachment.Listener)
+		}
+		attached := "Yes"
+		reason := "None"
+		if attachment.Reason != "" {
+			attached = "No"
+			reason = attachment.Reason
+		}
+		row := []string{
+			attachment.Gateway.String(),      // Gateway
+			listener,                         // Listener
+			attached,                         // Attached
+			joinOrNone(attachment.Hostnames), // Hostnames
+			reason,                           // Reason
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+func convertRouteOverlapsToTable(overlaps []resourcediscovery.RouteOverlap) *Table {
+	table := &Table{
+		ColumnNames:  []string{"HTTPRoute", "Gateway", "Listener", "Hostname", "Match"},
+		UseSeparator: true,
+	}
+	for _, overlap := range overlaps {
+		row := []string{
+			overlap.HTTPRoute.String(),          // HTTPRoute
+			overlap.Gateway.String(),            // Gateway
+			string(overlap.Listener),            // Listener
+			overlap.Hostname,                    // Hostname
+			formatHTTPRouteMatch(overlap.Match), // Match
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// formatHTTPRouteMatch formats the path and method of the match, followed by
+// the number of header and query parameter matches if there are any.
+func formatHTTPRouteMatch(match gatewayv1.HTTPRouteMatch) string {
+	var parts []string
+	if match.Path != nil && match.Path.Type != nil && match.Path.Value != nil {
+		parts = append(parts, fmt.Sprintf("%v %v", *match.Path.Type, *match.Path.Value))
+	}
+	if match.Method != nil {
+		parts = append(parts, string(*match.Method))
+	}
+	if n := len(match.Headers) + len(match.QueryParams); n != 0 {
+		parts = append(parts, fmt.Sprintf("+%d header/query matches", n))
+	}
+	return strings.Join(parts, " ")
+}
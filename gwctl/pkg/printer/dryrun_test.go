@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/analysis"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+)
+
+func TestDryRunPrinter_Print(t *testing.T) {
+	httpRouteNode := resourcediscovery.NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "new-route",
+			Namespace: "default",
+		},
+	})
+	httpRouteNode.Errors = append(httpRouteNode.Errors, resourcediscovery.ReferenceToNonExistentResourceError{ReferenceFromTo: resourcediscovery.ReferenceFromTo{
+		ReferringObject: common.ObjRef{Kind: "HTTPRoute", Name: "new-route", Namespace: "default"},
+		ReferredObject:  common.ObjRef{Kind: "Service", Name: "missing-svc", Namespace: "default"},
+	}})
+	fakeClock := testingclock.NewFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	edgeGateway := types.NamespacedName{Namespace: "infra", Name: "edge-gw"}
+	// The Gateway degraded recently, while its listener degraded long ago.
+	gatewayNode := resourcediscovery.NewGatewayNode(&gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      edgeGateway.Name,
+			Namespace: edgeGateway.Namespace,
+		},
+		Status: gatewayv1.GatewayStatus{
+			Conditions: []metav1.Condition{
+				{Type: "Accepted", Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(fakeClock.Now().Add(-1 * time.Minute))},
+				{Type: "Programmed", Status: metav1.ConditionFalse, LastTransitionTime: metav1.NewTime(fakeClock.Now().Add(-3 * time.Minute))},
+			},
+			Listeners: []gatewayv1.ListenerStatus{{
+				Name: "https",
+				Conditions: []metav1.Condition{
+					{Type: "ResolvedRefs", Status: metav1.ConditionFalse, LastTransitionTime: metav1.NewTime(fakeClock.Now().Add(-2 * time.Hour))},
+				},
+			}},
+		},
+	})
+	httpRouteNode.Gateways[gatewayNode.ID()] = gatewayNode
+	dryRuns := []*resourcediscovery.HTTPRouteDryRun{{
+		HTTPRouteNode: httpRouteNode,
+		Attachments: []resourcediscovery.ListenerAttachment{
+			{Gateway: edgeGateway, Listener: "http", Hostnames: []string{"shop.example.com"}},
+			{Gateway: types.NamespacedName{Namespace: "default", Name: "missing-gw"}, Reason: "Gateway does not exist"},
+		},
+		Overlaps: []resourcediscovery.RouteOverlap{{
+			HTTPRoute: types.NamespacedName{Namespace: "default", Name: "existing-route"},
+			Gateway:   edgeGateway,
+			Listener:  "http",
+			Hostname:  "shop.example.com",
+			Match: gatewayv1.HTTPRouteMatch{
+				Path:   &gatewayv1.HTTPPathMatch{Type: common.PtrTo(gatewayv1.PathMatchPathPrefix), Value: common.PtrTo("/checkout")},
+				Method: common.PtrTo(gatewayv1.HTTPMethodPost),
+			},
+		}},
+	}}
+
+	listenerA := resourcediscovery.GatewayListener{Gateway: types.NamespacedName{Namespace: "team-a", Name: "gateway-a"}, Listener: "https"}
+	listenerB := resourcediscovery.GatewayListener{Gateway: types.NamespacedName{Namespace: "team-b", Name: "gateway-b"}, Listener: "https"}
+	model := &analysis.Model{
+		DryRuns: dryRuns,
+		ServedHostnames: resourcediscovery.ServedHostnames{
+			{Hostname: "api.example.com", Port: 443}: {listenerA, listenerB},
+		},
+	}
+	model.Sources = common.ObjectSources{
+		{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Name: "new-route"}:                    {File: "manifests/routes/checkout.yaml", Document: 2, Line: 14},
+		{Group: gatewayv1.GroupName, Kind: "Gateway", Name: "gateway-b", Namespace: "team-b"}: {File: "manifests/gateways.yaml", Document: 0, Line: 1},
+	}
+	findings := analysis.Run(model, analysis.Options{Clock: fakeClock})
+
+	buff := &bytes.Buffer{}
+	dp := &DryRunPrinter{Writer: buff, Sources: model.Sources}
+	dp.Print(dryRuns, findings)
+
+	got := buff.String()
+	want := `
+HTTPRoute: default/new-route
+Source: manifests/routes/checkout.yaml#2
+Attachments:
+  Gateway             Listener  Attached  Hostnames         Reason
+  -------             --------  --------  ---------         ------
+  infra/edge-gw       http      Yes       shop.example.com  None
+  default/missing-gw  None      No        None              Gateway does not exist
+Overlaps:
+  HTTPRoute               Gateway        Listener  Hostname          Match
+  ---------               -------        --------  --------          -----
+  default/existing-route  infra/edge-gw  http      shop.example.com  PathPrefix /checkout POST
+Analysis:
+- Gateway infra/edge-gw condition Programmed=False for 3m (recently degraded)
+- HTTPRoute "default/new-route" references a non-existent Service "default/missing-svc"
+- 'HTTPRoute does not attach to Gateway default/missing-gw: Gateway does not exist'
+- HTTPRoute default/existing-route defines the same match for hostname shop.example.com
+  on listener http of Gateway infra/edge-gw; only the oldest HTTPRoute receives the
+  requests
+
+------ Gateway team-a/gateway-a
+Gateway: team-a/gateway-a
+Analysis:
+- hostname api.example.com is also served on port 443 by team-b/gateway-b/https
+
+------ Gateway team-b/gateway-b
+Gateway: team-b/gateway-b
+Source: manifests/gateways.yaml#0
+Analysis:
+- hostname api.example.com is also served on port 443 by team-a/gateway-a/https
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+
+	buff.Reset()
+	dp.PrintFindingIDs(findings)
+	got = buff.String()
+	want = `gateway-recently-degraded HTTPRoute default/new-route
+httproute-error HTTPRoute default/new-route
+httproute-not-attached HTTPRoute default/new-route
+httproute-overlap HTTPRoute default/new-route
+duplicate-hostname Gateway team-a/gateway-a
+duplicate-hostname Gateway team-b/gateway-b
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PrintFindingIDs() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
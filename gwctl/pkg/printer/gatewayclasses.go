@@ -20,20 +20,32 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 
 	"golang.org/x/exp/maps"
-	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/clock"
 
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
 )
 
 var _ Printer = (*GatewayClassesPrinter)(nil)
 
 type GatewayClassesPrinter struct {
 	io.Writer
-	Clock        clock.Clock
-	EventFetcher eventFetcher
+	Clock             clock.Clock
+	EventFetcher      eventFetcher
+	EventLimit        int
+	MaxListItems      int
+	LabelColumns      []string
+	AnnotationColumns []common.AnnotationColumn
+	Highlighter       *Highlighter
+	ShowDrift         bool
+	ShowFieldOwners   bool
 }
 
 func (gcp *GatewayClassesPrinter) GetPrintableNodes(resourceModel *resourcediscovery.ResourceModel) []NodeResource {
@@ -43,12 +55,12 @@ func (gcp *GatewayClassesPrinter) GetPrintableNodes(resourceModel *resourcedisco
 func (gcp *GatewayClassesPrinter) PrintTable(resourceModel *resourcediscovery.ResourceModel, wide bool) {
 	var columnNames []string
 	if wide {
-		columnNames = []string{"NAME", "CONTROLLER", "ACCEPTED", "AGE", "GATEWAYS"}
+		columnNames = []string{"NAME", "CONTROLLER", "ACCEPTED", "AGE", "GATEWAYS", "STALE"}
 	} else {
 		columnNames = []string{"NAME", "CONTROLLER", "ACCEPTED", "AGE"}
 	}
 	table := &Table{
-		ColumnNames:  columnNames,
+		ColumnNames:  append(append(columnNames, labelColumnNames(gcp.LabelColumns)...), annotationColumnNames(gcp.AnnotationColumns)...),
 		UseSeparator: false,
 	}
 
@@ -56,13 +68,11 @@ func (gcp *GatewayClassesPrinter) PrintTable(resourceModel *resourcediscovery.Re
 
 	for _, gatewayClassNode := range SortByString(gatewayClassNodes) {
 		accepted := "Unknown"
-		for _, condition := range gatewayClassNode.GatewayClass.Status.Conditions {
-			if condition.Type == "Accepted" {
-				accepted = string(condition.Status)
-			}
+		if condition := common.FindStandardCondition(gatewayClassNode.GatewayClass.Status.Conditions, "Accepted"); condition != nil {
+			accepted = string(condition.Status)
 		}
 
-		age := duration.HumanDuration(gcp.Clock.Since(gatewayClassNode.GatewayClass.GetCreationTimestamp().Time))
+		age := formatAge(gcp.Clock, gatewayClassNode.GatewayClass)
 
 		row := []string{
 			gatewayClassNode.GatewayClass.GetName(),
@@ -72,18 +82,55 @@ func (gcp *GatewayClassesPrinter) PrintTable(resourceModel *resourcediscovery.Re
 		}
 		if wide {
 			gatewayCount := fmt.Sprintf("%d", len(gatewayClassNode.Gateways))
-			row = append(row, gatewayCount)
+			stale := formatStale(resourcediscovery.IsGatewayClassStale(gatewayClassNode.GatewayClass))
+			row = append(row, gatewayCount, stale)
 		}
+		row = append(row, labelColumnValues(gatewayClassNode.GatewayClass, gcp.LabelColumns)...)
+		row = append(row, annotationColumnValues(gatewayClassNode.GatewayClass, gcp.AnnotationColumns)...)
 		table.Rows = append(table.Rows, row)
+		gcp.Highlighter.highlightLastRow(table, gcp.Clock, gatewayClassNode.GatewayClass)
 	}
 
 	table.Write(gcp, 0)
 }
 
+// PrintWithUsage prints the GatewayClasses as a List in JSON or YAML, like
+// Print, along with a "usage" field of the List which counts the resources
+// using each GatewayClass. The usage is kept outside of the items, so that the
+// items remain exactly as fetched from the cluster and can be re-applied.
+func (gcp *GatewayClassesPrinter) PrintWithUsage(resourceModel *resourcediscovery.ResourceModel, format utils.OutputFormat) {
+	gatewayClassNodes := SortByString(maps.Values(resourceModel.GatewayClasses))
+	printablePayload, err := renderPrintableObject(ClientObjects(gatewayClassNodes))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to form the printable payload %v\n", err)
+		os.Exit(1)
+	}
+	var usage []interface{}
+	for _, gatewayClassNode := range gatewayClassNodes {
+		gatewayClassUsage, err := runtime.DefaultUnstructuredConverter.ToUnstructured(common.PtrTo(resourcediscovery.UsageForGatewayClass(gatewayClassNode)))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to form the printable payload %v\n", err)
+			os.Exit(1)
+		}
+		usage = append(usage, gatewayClassUsage)
+	}
+	printablePayload.Object["usage"] = usage
+
+	output, err := utils.MarshalWithFormat(printablePayload, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprint(gcp, string(output))
+}
+
 func (gcp *GatewayClassesPrinter) PrintDescribeView(resourceModel *resourcediscovery.ResourceModel) {
 	index := 0
 	for _, gatewayClassNode := range resourceModel.GatewayClasses {
 		index++
+		if index > 1 {
+			writeDescribeSeparator(gcp, "GatewayClass", gatewayClassNode.GatewayClass)
+		}
 
 		metadata := gatewayClassNode.GatewayClass.ObjectMeta.DeepCopy()
 		metadata.Labels = nil
@@ -100,21 +147,50 @@ func (gcp *GatewayClassesPrinter) PrintDescribeView(resourceModel *resourcedisco
 			{Key: "Kind", Value: gatewayClassNode.GatewayClass.Kind},
 			{Key: "Metadata", Value: metadata},
 			{Key: "Spec", Value: &gatewayClassNode.GatewayClass.Spec},
-			{Key: "Status", Value: &gatewayClassNode.GatewayClass.Status},
+			{Key: "Status", Value: statusWithGroupedConditions(&gatewayClassNode.GatewayClass.Status, gcp.Clock)},
+		}
+		if gcp.ShowDrift {
+			pairs = append(pairs, &DescriberKV{Key: "Drift", Value: specDrift(gatewayClassNode.GatewayClass)})
+		}
+		if gcp.ShowFieldOwners {
+			pairs = append(pairs, &DescriberKV{Key: "FieldOwners", Value: specFieldOwners(gatewayClassNode.GatewayClass)})
 		}
 
+		// AffectedHTTPRoutes
+		pairs = append(pairs, &DescriberKV{Key: "AffectedHTTPRoutes", Value: affectedHTTPRoutesToTable(resourceModel, gatewayClassNode)})
+
 		// DirectlyAttachedPolicies
 		policyRefs := resourcediscovery.ConvertPoliciesMapToPolicyRefs(gatewayClassNode.Policies)
 		pairs = append(pairs, &DescriberKV{Key: "DirectlyAttachedPolicies", Value: convertPolicyRefsToTable(policyRefs)})
 
 		// Events
 		eventList := gcp.EventFetcher.FetchEventsFor(context.Background(), gatewayClassNode.GatewayClass)
-		pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(eventList.Items, gcp.Clock)})
+		pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(mostRecentEvents(eventList.Items, gcp.EventLimit), gcp.Clock)})
 
-		Describe(gcp, pairs)
+		Describe(gcp, truncateTables(pairs, gcp.MaxListItems))
+	}
+}
 
-		if index+1 <= len(resourceModel.GatewayClasses) {
-			fmt.Fprintf(gcp, "\n\n")
-		}
+// affectedHTTPRoutesToTable lists the HTTPRoutes attached to the Gateways of
+// the GatewayClass, which would be affected by changes to the GatewayClass,
+// like to its controllerName or parametersRef. The HTTPRoutes are only known
+// if the resourceModel was discovered with Discoverer.DiscoverUsage.
+func affectedHTTPRoutesToTable(resourceModel *resourcediscovery.ResourceModel, gatewayClassNode *resourcediscovery.GatewayClassNode) *Table {
+	var gateways []gatewayv1.Gateway
+	for _, gatewayNode := range gatewayClassNode.Gateways {
+		gateways = append(gateways, *gatewayNode.Gateway)
+	}
+	var httpRoutes []gatewayv1.HTTPRoute
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		httpRoutes = append(httpRoutes, *httpRouteNode.HTTPRoute)
+	}
+
+	table := &Table{
+		ColumnNames:  []string{"Namespace", "Name"},
+		UseSeparator: true,
+	}
+	for _, httpRoute := range relations.FindRoutesAffectedByGatewayClass(gatewayClassNode.GatewayClass.GetName(), gateways, httpRoutes) {
+		table.Rows = append(table.Rows, []string{httpRoute.Namespace, httpRoute.Name})
 	}
+	return table
 }
@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/yaml"
 
 	apisv1beta1 "sigs.k8s.io/gateway-api/apis/applyconfiguration/apis/v1beta1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -169,10 +170,10 @@ foo-com-internal-gateway-class  foo.com/internal-gateway-class  Unknown   24m
 
 	got2 := buff.String()
 	want2 := `
-NAME                            CONTROLLER                      ACCEPTED  AGE   GATEWAYS
-bar-com-internal-gateway-class  bar.baz/internal-gateway-class  True      365d  1
-foo-com-external-gateway-class  foo.com/external-gateway-class  False     100d  0
-foo-com-internal-gateway-class  foo.com/internal-gateway-class  Unknown   24m   0
+NAME                            CONTROLLER                      ACCEPTED  AGE   GATEWAYS  STALE
+bar-com-internal-gateway-class  bar.baz/internal-gateway-class  True      365d  1         False
+foo-com-external-gateway-class  foo.com/external-gateway-class  False     100d  0         False
+foo-com-internal-gateway-class  foo.com/internal-gateway-class  Unknown   24m   0         False
 `
 	if diff := cmp.Diff(common.YamlString(want2), common.YamlString(got2), common.YamlStringTransformer); diff != "" {
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got2, want2, diff)
@@ -183,9 +184,10 @@ func TestGatewayClassesPrinter_PrintDescribeView(t *testing.T) {
 	fakeClock := testingclock.NewFakeClock(time.Now())
 
 	testcases := []struct {
-		name    string
-		objects []runtime.Object
-		want    string
+		name          string
+		objects       []runtime.Object
+		discoverUsage bool
+		want          string
 	}{
 		{
 			name: "GatewayClass with description and policy",
@@ -267,6 +269,7 @@ Spec:
   controllerName: example.net/gateway-controller
   description: random
 Status: {}
+AffectedHTTPRoutes: <none>
 DirectlyAttachedPolicies:
   Type                       Name
   ----                       ----
@@ -309,6 +312,86 @@ Metadata:
 Spec:
   controllerName: example.net/gateway-controller
 Status: {}
+AffectedHTTPRoutes: <none>
+DirectlyAttachedPolicies: <none>
+Events: <none>
+`,
+		},
+		{
+			name: "GatewayClass with affected HTTPRoutes",
+			objects: []runtime.Object{
+				&gatewayv1.GatewayClass{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: gatewayv1.GroupVersion.String(),
+						Kind:       "GatewayClass",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo-gatewayclass",
+					},
+					Spec: gatewayv1.GatewayClassSpec{
+						ControllerName: "example.net/gateway-controller",
+					},
+				},
+				common.NamespaceForTest("default"),
+				common.NamespaceForTest("other"),
+				&gatewayv1.Gateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo-gateway",
+						Namespace: "default",
+					},
+					Spec: gatewayv1.GatewaySpec{
+						GatewayClassName: "foo-gatewayclass",
+					},
+				},
+				&gatewayv1.Gateway{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "bar-gateway",
+						Namespace: "default",
+					},
+					Spec: gatewayv1.GatewaySpec{
+						GatewayClassName: "bar-gatewayclass",
+					},
+				},
+				&gatewayv1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo-httproute",
+						Namespace: "other",
+					},
+					Spec: gatewayv1.HTTPRouteSpec{
+						CommonRouteSpec: gatewayv1.CommonRouteSpec{
+							ParentRefs: []gatewayv1.ParentReference{{Name: "foo-gateway", Namespace: common.PtrTo(gatewayv1.Namespace("default"))}},
+						},
+					},
+				},
+				&gatewayv1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "bar-httproute",
+						Namespace: "default",
+					},
+					Spec: gatewayv1.HTTPRouteSpec{
+						CommonRouteSpec: gatewayv1.CommonRouteSpec{
+							ParentRefs: []gatewayv1.ParentReference{{Name: "bar-gateway"}},
+						},
+					},
+				},
+			},
+			discoverUsage: true,
+			want: `
+Name: foo-gatewayclass
+Labels: null
+Annotations: null
+APIVersion: gateway.networking.k8s.io/v1
+Kind: GatewayClass
+Metadata:
+  creationTimestamp: null
+  resourceVersion: "999"
+Spec:
+  controllerName: example.net/gateway-controller
+Status: {}
+AffectedHTTPRoutes:
+  Namespace  Name
+  ---------  ----
+  other      foo-httproute
 DirectlyAttachedPolicies: <none>
 Events: <none>
 `,
@@ -324,6 +407,7 @@ Events: <none>
 			discoverer := resourcediscovery.Discoverer{
 				K8sClients:    k8sClients,
 				PolicyManager: policyManager,
+				DiscoverUsage: tc.discoverUsage,
 			}
 			resourceModel, err := discoverer.DiscoverResourcesForGatewayClass(resourcediscovery.Filter{})
 			if err != nil {
@@ -472,3 +556,120 @@ kind: List`, creationTime.Format(time.RFC3339)))
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", gotYaml, wantYaml, diff)
 	}
 }
+
+func TestGatewayClassesPrinter_PrintWithUsage(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gateway-1",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+					{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443},
+				},
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gateway-2",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "httproute-1",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "gateway-1"}, {Name: "gateway-2"}},
+				},
+				Rules: []gatewayv1.HTTPRouteRule{{
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Kind: common.PtrTo(gatewayv1.Kind("Service")), Name: "svc-1"}}},
+						{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Kind: common.PtrTo(gatewayv1.Kind("Service")), Name: "svc-2"}}},
+					},
+				}},
+			},
+		},
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "svc-1",
+				Namespace: "default",
+			},
+		},
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "svc-2",
+				Namespace: "default",
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+		DiscoverUsage: true,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGatewayClass(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	buff := &bytes.Buffer{}
+	gcp := &GatewayClassesPrinter{
+		Writer: buff,
+		Clock:  fakeClock,
+	}
+	gcp.PrintWithUsage(resourceModel, utils.OutputFormatYAML)
+
+	got := &unstructured.UnstructuredList{}
+	if err := yaml.Unmarshal(buff.Bytes(), &got.Object); err != nil {
+		t.Fatalf("Failed to unmarshal the output: %v", err)
+	}
+	items, _, _ := unstructured.NestedSlice(got.Object, "items")
+	if len(items) != 1 {
+		t.Errorf("Got %v items, want 1", len(items))
+	}
+	if _, ok, _ := unstructured.NestedFieldNoCopy(items[0].(map[string]interface{}), "usage"); ok {
+		t.Errorf("The usage was added to the GatewayClass, want it only within the List")
+	}
+	gotUsage, _, _ := unstructured.NestedSlice(got.Object, "usage")
+	wantUsage := []interface{}{
+		map[string]interface{}{
+			"name":            "foo-gatewayclass",
+			"gateways":        float64(2),
+			"listeners":       float64(3),
+			"attachedRoutes":  float64(1),
+			"backendServices": float64(2),
+		},
+	}
+	if diff := cmp.Diff(wantUsage, gotUsage); diff != "" {
+		t.Errorf("Unexpected usage (-want +got):\n%v", diff)
+	}
+}
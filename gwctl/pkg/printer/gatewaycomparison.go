@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+type GatewayComparisonPrinter struct {
+	io.Writer
+}
+
+func (gcp *GatewayComparisonPrinter) Print(comparison resourcediscovery.GatewayComparison, format utils.OutputFormat) {
+	switch format {
+	case utils.OutputFormatJSON, utils.OutputFormatYAML:
+		output, err := utils.MarshalWithFormat(comparison, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(gcp, string(output))
+	case utils.OutputFormatTable, utils.OutputFormatWide:
+		gcp.printComparisonView(comparison)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output format '%s' found\n", format)
+		os.Exit(1)
+	}
+}
+
+func (gcp *GatewayComparisonPrinter) printComparisonView(comparison resourcediscovery.GatewayComparison) {
+	left := fmt.Sprintf("%v/%v", comparison.Left.Namespace, comparison.Left.Name)
+	right := fmt.Sprintf("%v/%v", comparison.Right.Namespace, comparison.Right.Name)
+
+	pairs := []*DescriberKV{
+		{Key: "Left", Value: left},
+		{Key: "Right", Value: right},
+		{Key: "Listeners", Value: convertSetComparisonToTable(comparison.Listeners, left, right)},
+		{Key: "AttachedRoutes", Value: convertSetComparisonToTable(comparison.AttachedRoutes, left, right)},
+		{Key: "AddressTypes", Value: convertSetComparisonToTable(comparison.AddressTypes, left, right)},
+		{Key: "DirectlyAttachedPolicies", Value: convertSetComparisonToTable(comparison.DirectlyAttachedPolicies, left, right)},
+		{Key: "InheritedPolicies", Value: convertSetComparisonToTable(comparison.InheritedPolicies, left, right)},
+		{Key: "Differences", Value: comparison.Differences},
+	}
+	Describe(gcp, pairs)
+}
+
+// convertSetComparisonToTable renders a SetComparison with one row per item,
+// listing items which differ before the items which are common to both.
+func convertSetComparisonToTable(s resourcediscovery.SetComparison, left, right string) *Table {
+	table := &Table{
+		ColumnNames:  []string{"Item", left, right},
+		UseSeparator: true,
+	}
+	for _, item := range s.OnlyInLeft {
+		table.Rows = append(table.Rows, []string{item, "yes", "no"})
+	}
+	for _, item := range s.OnlyInRight {
+		table.Rows = append(table.Rows, []string{item, "no", "yes"})
+	}
+	for _, item := range s.Both {
+		table.Rows = append(table.Rows, []string{item, "yes", "yes"})
+	}
+	return table
+}
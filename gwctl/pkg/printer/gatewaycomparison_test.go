@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestGatewayComparisonPrinter_Print(t *testing.T) {
+	comparison := resourcediscovery.GatewayComparison{
+		Left:  common.ObjRef{Kind: "Gateway", Namespace: "default", Name: "old"},
+		Right: common.ObjRef{Kind: "Gateway", Namespace: "ns2", Name: "new"},
+		Listeners: resourcediscovery.SetComparison{
+			OnlyInLeft:  []string{"443/HTTPS/foo.com"},
+			OnlyInRight: []string{"8080/HTTP"},
+			Both:        []string{"80/HTTP"},
+		},
+		AttachedRoutes: resourcediscovery.SetComparison{
+			Both: []string{"HTTPRoute default/shared-route"},
+		},
+		Differences: 2,
+	}
+
+	buff := &bytes.Buffer{}
+	gcp := &GatewayComparisonPrinter{Writer: buff}
+	gcp.Print(comparison, utils.OutputFormatTable)
+
+	got := buff.String()
+	want := `
+Left: default/old
+Right: ns2/new
+Listeners:
+  Item               default/old  ns2/new
+  ----               -----------  -------
+  443/HTTPS/foo.com  yes          no
+  8080/HTTP          no           yes
+  80/HTTP            yes          yes
+AttachedRoutes:
+  Item                            default/old  ns2/new
+  ----                            -----------  -------
+  HTTPRoute default/shared-route  yes          yes
+AddressTypes: <none>
+DirectlyAttachedPolicies: <none>
+InheritedPolicies: <none>
+Differences: 2
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
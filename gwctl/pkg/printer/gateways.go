@@ -20,13 +20,19 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"golang.org/x/exp/maps"
-	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
 )
 
 var _ Printer = (*GatewaysPrinter)(nil)
@@ -35,6 +41,42 @@ type GatewaysPrinter struct {
 	io.Writer
 	Clock        clock.Clock
 	EventFetcher eventFetcher
+	// EventLimit is the maximum number of the most recent events printed for
+	// each resource. All events are printed if it is 0.
+	EventLimit int
+	// MaxListItems is the maximum number of rows printed for each repeating
+	// section of the describe view, like the attached routes. All rows are
+	// printed if it is 0.
+	MaxListItems int
+	// SortBy is the key used to sort rows when printing a table.
+	SortBy utils.SortKey
+	// LabelColumns are the keys of labels whose values are printed as
+	// additional columns of the table.
+	LabelColumns []string
+	// AnnotationColumns are additional columns of the table, following the
+	// label columns, whose values are those of annotations.
+	AnnotationColumns []common.AnnotationColumn
+	// Highlighter highlights the rows of the table for resources which match
+	// its predicates, if it is set.
+	Highlighter *Highlighter
+	// EffectivePolicyKind limits the effective policies in the describe view
+	// to a single policy kind. All kinds are shown if this is empty.
+	EffectivePolicyKind string
+	// ShowDrift adds a diff of the spec from the last-applied configuration
+	// to the describe view.
+	ShowDrift bool
+	// ShowFieldOwners adds the field managers owning each top-level field of
+	// the spec to the describe view.
+	ShowFieldOwners bool
+	// ShowPrecedence adds the matches of the attached HTTPRoutes, in the order
+	// of precedence in which requests are matched, to the describe view.
+	ShowPrecedence bool
+	// GroupByClass prints a separate table for the Gateways of each
+	// GatewayClass, headed by the class and its controllerName.
+	GroupByClass bool
+	// EffectiveSubtree adds the effective policies of each route attached to
+	// the Gateway, and of each Backend of those routes, to the describe view.
+	EffectiveSubtree bool
 }
 
 func (gp *GatewaysPrinter) GetPrintableNodes(resourceModel *resourcediscovery.ResourceModel) []NodeResource {
@@ -42,20 +84,70 @@ func (gp *GatewaysPrinter) GetPrintableNodes(resourceModel *resourcediscovery.Re
 }
 
 func (gp *GatewaysPrinter) PrintTable(resourceModel *resourcediscovery.ResourceModel, wide bool) {
+	if !gp.GroupByClass {
+		gp.Table(resourceModel, wide).Write(gp, 0)
+		return
+	}
+	gatewayNodes := gp.sortedGatewayNodes(resourceModel)
+
+	// Sections are sorted by the name of the GatewayClass, while the Gateways
+	// within each section retain their order.
+	gatewayNodesByClass := make(map[string][]*resourcediscovery.GatewayNode)
+	controllerNameByClass := make(map[string]string)
+	for _, gatewayNode := range gatewayNodes {
+		className := relations.FindGatewayClassNameForGateway(*gatewayNode.Gateway)
+		gatewayNodesByClass[className] = append(gatewayNodesByClass[className], gatewayNode)
+		if gatewayNode.GatewayClass != nil {
+			controllerNameByClass[className] = string(gatewayNode.GatewayClass.GatewayClass.Spec.ControllerName)
+		}
+	}
+	classNames := maps.Keys(gatewayNodesByClass)
+	sort.Strings(classNames)
+	for i, className := range classNames {
+		if i > 0 {
+			fmt.Fprintln(gp)
+		}
+		controllerName, ok := controllerNameByClass[className]
+		if !ok {
+			controllerName = "Unknown"
+		}
+		fmt.Fprintf(gp, "GatewayClass: %v (controller: %v)\n", className, controllerName)
+		gp.gatewaysToTable(gatewayNodesByClass[className], wide).Write(gp, 0)
+	}
+}
+
+// Table returns the table printed by PrintTable, without grouping the Gateways
+// by their GatewayClass.
+func (gp *GatewaysPrinter) Table(resourceModel *resourcediscovery.ResourceModel, wide bool) *Table {
+	return gp.gatewaysToTable(gp.sortedGatewayNodes(resourceModel), wide)
+}
+
+// sortedGatewayNodes returns the Gateways of the model in the order of SortBy.
+func (gp *GatewaysPrinter) sortedGatewayNodes(resourceModel *resourcediscovery.ResourceModel) []*resourcediscovery.GatewayNode {
+	gatewayNodes := maps.Values(resourceModel.Gateways)
+	if gp.SortBy == utils.SortKeyPolicies {
+		SortByPolicyCount(gatewayNodes, func(gatewayNode *resourcediscovery.GatewayNode) int { return len(gatewayNode.Policies) })
+	} else {
+		SortByString(gatewayNodes)
+	}
+	return gatewayNodes
+}
+
+// gatewaysToTable returns a table with a row for each of the gatewayNodes, in
+// the same order.
+func (gp *GatewaysPrinter) gatewaysToTable(gatewayNodes []*resourcediscovery.GatewayNode, wide bool) *Table {
 	var columnNames []string
 	if wide {
-		columnNames = []string{"NAMESPACE", "NAME", "CLASS", "ADDRESSES", "PORTS", "PROGRAMMED", "AGE", "POLICIES", "HTTPROUTES"}
+		columnNames = []string{"NAMESPACE", "NAME", "CLASS", "ADDRESSES", "PORTS", "PROGRAMMED", "AGE", "POLICIES", "HTTPROUTES", "STALE", "MANAGERS", "ATTACHED"}
 	} else {
 		columnNames = []string{"NAMESPACE", "NAME", "CLASS", "ADDRESSES", "PORTS", "PROGRAMMED", "AGE"}
 	}
 	table := &Table{
-		ColumnNames:  columnNames,
+		ColumnNames:  append(append(columnNames, labelColumnNames(gp.LabelColumns)...), annotationColumnNames(gp.AnnotationColumns)...),
 		UseSeparator: false,
 	}
 
-	gatewayNodes := maps.Values(resourceModel.Gateways)
-
-	for _, gatewayNode := range SortByString(gatewayNodes) {
+	for _, gatewayNode := range gatewayNodes {
 		var addresses []string
 		for _, address := range gatewayNode.Gateway.Status.Addresses {
 			addresses = append(addresses, address.Value)
@@ -72,17 +164,14 @@ func (gp *GatewaysPrinter) PrintTable(resourceModel *resourcediscovery.ResourceM
 		portsOutput := strings.Join(ports, ",")
 
 		programmedStatus := "Unknown"
-		for _, condition := range gatewayNode.Gateway.Status.Conditions {
-			if condition.Type == "Programmed" {
-				programmedStatus = string(condition.Status)
-				break
-			}
+		if condition := common.FindStandardCondition(gatewayNode.Gateway.Status.Conditions, "Programmed"); condition != nil {
+			programmedStatus = string(condition.Status)
 		}
 
-		age := duration.HumanDuration(gp.Clock.Since(gatewayNode.Gateway.GetCreationTimestamp().Time))
+		age := formatAge(gp.Clock, gatewayNode.Gateway)
 
 		row := []string{
-			gatewayNode.Gateway.GetNamespace(),
+			formatNamespace(gatewayNode.Gateway.GetNamespace(), gatewayNode.Namespace),
 			gatewayNode.Gateway.GetName(),
 			string(gatewayNode.Gateway.Spec.GatewayClassName),
 			addressesOutput,
@@ -93,18 +182,25 @@ func (gp *GatewaysPrinter) PrintTable(resourceModel *resourcediscovery.ResourceM
 		if wide {
 			policiesCount := fmt.Sprintf("%d", len(gatewayNode.Policies))
 			httpRoutesCount := fmt.Sprintf("%d", len(gatewayNode.HTTPRoutes))
-			row = append(row, policiesCount, httpRoutesCount)
+			stale := formatStale(resourcediscovery.IsGatewayStale(gatewayNode.Gateway))
+			row = append(row, policiesCount, httpRoutesCount, stale, formatSpecManagers(gatewayNode.Gateway), formatAttachedRoutes(gatewayNode))
 		}
+		row = append(row, labelColumnValues(gatewayNode.Gateway, gp.LabelColumns)...)
+		row = append(row, annotationColumnValues(gatewayNode.Gateway, gp.AnnotationColumns)...)
 		table.Rows = append(table.Rows, row)
+		gp.Highlighter.highlightLastRow(table, gp.Clock, gatewayNode.Gateway)
 	}
 
-	table.Write(gp, 0)
+	return table
 }
 
 func (gp *GatewaysPrinter) PrintDescribeView(resourceModel *resourcediscovery.ResourceModel) {
 	index := 0
 	for _, gatewayNode := range resourceModel.Gateways {
 		index++
+		if index > 1 {
+			writeDescribeSeparator(gp, "Gateway", gatewayNode.Gateway)
+		}
 
 		metadata := gatewayNode.Gateway.ObjectMeta.DeepCopy()
 		metadata.Labels = nil
@@ -115,14 +211,35 @@ func (gp *GatewaysPrinter) PrintDescribeView(resourceModel *resourcediscovery.Re
 
 		pairs := []*DescriberKV{
 			{Key: "Name", Value: gatewayNode.Gateway.GetName()},
-			{Key: "Namespace", Value: gatewayNode.Gateway.GetNamespace()},
+			{Key: "Namespace", Value: formatNamespace(gatewayNode.Gateway.GetNamespace(), gatewayNode.Namespace)},
 			{Key: "Labels", Value: gatewayNode.Gateway.Labels},
 			{Key: "Annotations", Value: gatewayNode.Gateway.Annotations},
 			{Key: "APIVersion", Value: gatewayNode.Gateway.APIVersion},
 			{Key: "Kind", Value: gatewayNode.Gateway.Kind},
 			{Key: "Metadata", Value: metadata},
 			{Key: "Spec", Value: &gatewayNode.Gateway.Spec},
-			{Key: "Status", Value: &gatewayNode.Gateway.Status},
+			{Key: "Status", Value: statusWithGroupedConditions(&gatewayNode.Gateway.Status, gp.Clock)},
+		}
+		if gp.ShowDrift {
+			pairs = append(pairs, &DescriberKV{Key: "Drift", Value: specDrift(gatewayNode.Gateway)})
+		}
+		if gp.ShowFieldOwners {
+			pairs = append(pairs, &DescriberKV{Key: "FieldOwners", Value: specFieldOwners(gatewayNode.Gateway)})
+		}
+		if managers := fieldManagers(gatewayNode.Gateway); len(managers) != 0 {
+			pairs = append(pairs, &DescriberKV{Key: "FieldManagers", Value: managers})
+		}
+
+		// UnprogrammedListeners
+		if unprogrammedListeners := common.FindUnprogrammedListeners(gatewayNode.Gateway); len(unprogrammedListeners) != 0 {
+			table := &Table{
+				ColumnNames:  []string{"Name", "Reason", "Message"},
+				UseSeparator: true,
+			}
+			for _, listener := range unprogrammedListeners {
+				table.Rows = append(table.Rows, []string{string(listener.Name), listener.Reason, listener.Message})
+			}
+			pairs = append(pairs, &DescriberKV{Key: "UnprogrammedListeners", Value: table})
 		}
 
 		// AttachedRoutes
@@ -137,30 +254,171 @@ func (gp *GatewaysPrinter) PrintDescribeView(resourceModel *resourcediscovery.Re
 			}
 			attachedRoutes.Rows = append(attachedRoutes.Rows, row)
 		}
+		sortRows(attachedRoutes)
 		pairs = append(pairs, &DescriberKV{Key: "AttachedRoutes", Value: attachedRoutes})
 
+		// Capacity
+		capacity, capacityErr := gatewayCapacityFor(gatewayNode)
+		pairs = append(pairs, &DescriberKV{Key: "Capacity", Value: capacity})
+
+		// RoutePrecedence
+		if gp.ShowPrecedence {
+			pairs = append(pairs, &DescriberKV{Key: "RoutePrecedence", Value: convertRouteMatchPrecedenceToTable(resourcediscovery.RouteMatchPrecedenceForGateway(gatewayNode))})
+		}
+
 		// DirectlyAttachedPolicies
 		policyRefs := resourcediscovery.ConvertPoliciesMapToPolicyRefs(gatewayNode.Policies)
 		pairs = append(pairs, &DescriberKV{Key: "DirectlyAttachedPolicies", Value: convertPolicyRefsToTable(policyRefs)})
 
-		// EffectivePolicies
-		if len(gatewayNode.EffectivePolicies) != 0 {
-			pairs = append(pairs, &DescriberKV{Key: "EffectivePolicies", Value: gatewayNode.EffectivePolicies})
+		// PolicySummary and EffectivePolicies
+		if effectivePolicies := filterPoliciesByKind(gatewayNode.EffectivePolicies, gp.EffectivePolicyKind); len(effectivePolicies) != 0 {
+			pairs = append(pairs, &DescriberKV{Key: "PolicySummary", Value: convertPoliciesToPolicySummary(effectivePolicies)})
+			pairs = append(pairs, &DescriberKV{Key: "EffectivePolicies", Value: effectivePolicies})
+		}
+
+		// EffectiveSubtree
+		if gp.EffectiveSubtree {
+			pairs = append(pairs, &DescriberKV{Key: "EffectiveSubtree", Value: effectiveSubtreeForGateway(resourceModel, gatewayNode, gp.EffectivePolicyKind)})
 		}
 
 		// Analysis
-		if len(gatewayNode.Errors) != 0 {
-			pairs = append(pairs, &DescriberKV{Key: "Analysis", Value: convertErrorsToString(gatewayNode.Errors)})
+		analysis := convertErrorsToString(gatewayNode.Errors)
+		analysis = append(analysis, common.FindRecentlyDegradedGatewayConditions(gp.Clock, gatewayNode.Gateway)...)
+		if capacityErr != nil {
+			analysis = append(analysis, capacityErr.Error())
+		}
+		if len(analysis) != 0 {
+			pairs = append(pairs, &DescriberKV{Key: "Analysis", Value: analysis})
 		}
 
 		// Events
 		eventList := gp.EventFetcher.FetchEventsFor(context.Background(), gatewayNode.Gateway)
-		pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(eventList.Items, gp.Clock)})
+		pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(mostRecentEvents(eventList.Items, gp.EventLimit), gp.Clock)})
 
-		Describe(gp, pairs)
+		Describe(gp, truncateTables(pairs, gp.MaxListItems))
+	}
+}
 
-		if index+1 <= len(resourceModel.Gateways) {
-			fmt.Fprintf(gp, "\n\n")
+// convertRouteMatchPrecedenceToTable returns a table of the matches in their
+// order of precedence, numbered from 1 for each hostname.
+func convertRouteMatchPrecedenceToTable(precedence []resourcediscovery.RouteMatchPrecedence) *Table {
+	table := &Table{
+		ColumnNames:  []string{"Listener", "Hostname", "Order", "HTTPRoute", "Match", "Conditions"},
+		UseSeparator: true,
+	}
+	order := 0
+	for i, entry := range precedence {
+		order++
+		if i > 0 && (precedence[i-1].Listener != entry.Listener || precedence[i-1].Hostname != entry.Hostname) {
+			order = 1
 		}
+		row := []string{
+			string(entry.Listener),   // Listener
+			entry.Hostname,           // Hostname
+			fmt.Sprintf("%d", order), // Order
+			entry.HTTPRoute.String(), // HTTPRoute
+			fmt.Sprintf("rules[%d].matches[%d]", entry.Rule, entry.Match), // Match
+			formatHTTPRouteMatch(entry.RouteMatch),                        // Conditions
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// effectivePoliciesNode is a resource beneath a Gateway, along with the
+// policies which are effective for it through the Gateway.
+type effectivePoliciesNode struct {
+	Resource          string
+	PolicySummary     []string                                           `json:",omitempty"`
+	EffectivePolicies map[policymanager.PolicyCrdID]policymanager.Policy `json:",omitempty"`
+	Backends          []*effectivePoliciesNode                           `json:",omitempty"`
+}
+
+// effectiveSubtreeForGateway returns the routes of every kind attached to the
+// Gateway, each with the Backends it references, along with the effective
+// policies of each of them through the Gateway. This shows how the inheritance
+// of policies resolves at every resource beneath the Gateway. The effective
+// policies are limited to a single kind if policyKind is not empty.
+func effectiveSubtreeForGateway(resourceModel *resourcediscovery.ResourceModel, gatewayNode *resourcediscovery.GatewayNode, policyKind string) []*effectivePoliciesNode {
+	gatewayID := gatewayNode.ID()
+	routeNodes := make(map[any]resourcediscovery.RouteNode)
+	for _, httpRouteNode := range gatewayNode.HTTPRoutes {
+		routeNodes[httpRouteNode.RouteID()] = httpRouteNode
+	}
+	for _, otherRouteNode := range resourceModel.OtherRoutes {
+		if _, ok := otherRouteNode.Gateways[gatewayID]; ok {
+			routeNodes[otherRouteNode.RouteID()] = otherRouteNode
+		}
+	}
+
+	var result []*effectivePoliciesNode
+	for _, routeNode := range sortRouteNodes(routeNodes) {
+		node := newEffectivePoliciesNode(routeNode.RouteID().Kind, routeNode.ClientObject(), routeNode.EffectivePoliciesByGateway()[gatewayID], policyKind)
+
+		var backendNodes []*resourcediscovery.BackendNode
+		switch routeNode := routeNode.(type) {
+		case *resourcediscovery.HTTPRouteNode:
+			backendNodes = maps.Values(routeNode.Backends)
+		case *resourcediscovery.OtherRouteNode:
+			backendNodes = maps.Values(routeNode.Backends)
+		}
+		for _, backendNode := range SortByString(backendNodes) {
+			node.Backends = append(node.Backends, newEffectivePoliciesNode(backendNode.Backend.GetKind(), backendNode.Backend, backendNode.EffectivePolicies[gatewayID], policyKind))
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+func newEffectivePoliciesNode(kind string, object client.Object, effectivePolicies map[policymanager.PolicyCrdID]policymanager.Policy, policyKind string) *effectivePoliciesNode {
+	effectivePolicies = filterPoliciesByKind(effectivePolicies, policyKind)
+	return &effectivePoliciesNode{
+		Resource:          fmt.Sprintf("%v %v", kind, client.ObjectKeyFromObject(object)),
+		PolicySummary:     convertPoliciesToPolicySummary(effectivePolicies),
+		EffectivePolicies: effectivePolicies,
+	}
+}
+
+// gatewayCapacity describes how many routes are attached to a Gateway, and
+// how much of its capacity they use if the Gateway or its GatewayClass is
+// annotated with the maximum number of routes.
+type gatewayCapacity struct {
+	AttachedRoutes int
+	MaxRoutes      int    `json:",omitempty"`
+	Utilization    string `json:",omitempty"`
+	Source         string `json:",omitempty"`
+}
+
+// gatewayCapacityFor returns the capacity of the Gateway, counting the routes
+// of all kinds attached to it. The error reports an invalid capacity
+// annotation, in which case only the attached routes are returned.
+func gatewayCapacityFor(gatewayNode *resourcediscovery.GatewayNode) (*gatewayCapacity, error) {
+	result := &gatewayCapacity{AttachedRoutes: len(gatewayNode.HTTPRoutes)}
+	for _, count := range gatewayNode.AttachedRoutesByKind {
+		result.AttachedRoutes += count
+	}
+
+	var gatewayClass *gatewayv1.GatewayClass
+	if gatewayNode.GatewayClass != nil {
+		gatewayClass = gatewayNode.GatewayClass.GatewayClass
+	}
+	capacity, err := common.FindRouteCapacity(gatewayNode.Gateway, gatewayClass)
+	if err != nil || capacity == nil {
+		return result, err
+	}
+	result.MaxRoutes = capacity.MaxRoutes
+	result.Utilization = fmt.Sprintf("%d%%", capacity.Utilization(result.AttachedRoutes))
+	result.Source = capacity.Source
+	return result, nil
+}
+
+// formatAttachedRoutes returns the number of routes of each kind attached to
+// the Gateway, e.g. "http:3 grpc:1 tcp:0 tls:0 udp:0".
+func formatAttachedRoutes(gatewayNode *resourcediscovery.GatewayNode) string {
+	counts := []string{fmt.Sprintf("http:%d", len(gatewayNode.HTTPRoutes))}
+	for _, kind := range []string{"GRPCRoute", "TCPRoute", "TLSRoute", "UDPRoute"} {
+		prefix := strings.ToLower(strings.TrimSuffix(kind, "Route"))
+		counts = append(counts, fmt.Sprintf("%v:%d", prefix, gatewayNode.AttachedRoutesByKind[kind]))
 	}
+	return strings.Join(counts, " ")
 }
@@ -19,6 +19,7 @@ package printer
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	testingclock "k8s.io/utils/clock/testing"
 
 	apisv1beta1 "sigs.k8s.io/gateway-api/apis/applyconfiguration/apis/v1beta1"
@@ -193,6 +195,33 @@ func TestGatewaysPrinter_PrintTable(t *testing.T) {
 				},
 			},
 		},
+		&gatewayv1.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-grpcroute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GRPCRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					// Attached to two listeners of the same Gateway, which only counts
+					// once.
+					ParentRefs: []gatewayv1.ParentReference{
+						{Name: "abc-gateway-12345", SectionName: common.PtrTo(gatewayv1.SectionName("http"))},
+						{Name: "abc-gateway-12345", SectionName: common.PtrTo(gatewayv1.SectionName("https"))},
+					},
+				},
+			},
+		},
+		&gatewayv1alpha2.TCPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-tcproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1alpha2.TCPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "demo-gateway-2"}},
+				},
+			},
+		},
 
 		&apiextensionsv1.CustomResourceDefinition{
 			ObjectMeta: metav1.ObjectMeta{
@@ -301,16 +330,362 @@ default    random-gateway     regional-internal-class  10.11.12.13
 
 	got2 := buff.String()
 	want2 := `
-NAMESPACE  NAME               CLASS                    ADDRESSES                   PORTS     PROGRAMMED  AGE  POLICIES  HTTPROUTES
-default    abc-gateway-12345  internal-class           192.168.100.5               443,8080  False       20d  0         1
-default    demo-gateway-2     external-class           10.0.0.1,10.0.0.2 + 1 more  80        True        5d   0         0
-default    random-gateway     regional-internal-class  10.11.12.13                 8443      Unknown     3s   1         0
+NAMESPACE  NAME               CLASS                    ADDRESSES                   PORTS     PROGRAMMED  AGE  POLICIES  HTTPROUTES  STALE  MANAGERS  ATTACHED
+default    abc-gateway-12345  internal-class           192.168.100.5               443,8080  False       20d  0         1           False  0         http:1 grpc:1 tcp:0 tls:0 udp:0
+default    demo-gateway-2     external-class           10.0.0.1,10.0.0.2 + 1 more  80        True        5d   0         0           False  0         http:0 grpc:0 tcp:1 tls:0 udp:0
+default    random-gateway     regional-internal-class  10.11.12.13                 8443      Unknown     3s   1         0           False  0         http:0 grpc:0 tcp:0 tls:0 udp:0
 `
 	if diff := cmp.Diff(common.YamlString(want2), common.YamlString(got2), common.YamlStringTransformer); diff != "" {
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got2, want2, diff)
 	}
 }
 
+func TestGatewaysPrinter_PrintTable_LabelColumns(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "internal-class",
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "example.net/gateway-controller",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gateway-1",
+				Namespace: "default",
+				Labels: map[string]string{
+					"app":                     "store",
+					"example.com/environment": "prod",
+				},
+				CreationTimestamp: metav1.Time{
+					Time: fakeClock.Now().Add(-2 * 24 * time.Hour),
+				},
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "internal-class",
+				Listeners: []gatewayv1.Listener{
+					{
+						Name:     gatewayv1.SectionName("http-80"),
+						Protocol: gatewayv1.HTTPProtocolType,
+						Port:     gatewayv1.PortNumber(80),
+					},
+				},
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gateway-2",
+				Namespace: "default",
+				Labels: map[string]string{
+					"example.com/environment": "dev",
+				},
+				CreationTimestamp: metav1.Time{
+					Time: fakeClock.Now().Add(-2 * 24 * time.Hour),
+				},
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "internal-class",
+				Listeners: []gatewayv1.Listener{
+					{
+						Name:     gatewayv1.SectionName("http-80"),
+						Protocol: gatewayv1.HTTPProtocolType,
+						Port:     gatewayv1.PortNumber(80),
+					},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	buff := &bytes.Buffer{}
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	gp := &GatewaysPrinter{
+		Writer:       buff,
+		Clock:        fakeClock,
+		LabelColumns: []string{"app", "example.com/environment"},
+	}
+	gp.PrintTable(resourceModel, false)
+
+	got := buff.String()
+	want := `
+NAMESPACE  NAME       CLASS           ADDRESSES  PORTS  PROGRAMMED  AGE  APP    ENVIRONMENT
+default    gateway-1  internal-class             80     Unknown     2d   store  prod
+default    gateway-2  internal-class             80     Unknown     2d          dev
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+func TestGatewaysPrinter_PrintTable_AnnotationColumns(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	gateway := func(name string, labels, annotations map[string]string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            labels,
+				Annotations:       annotations,
+				CreationTimestamp: metav1.Time{Time: fakeClock.Now().Add(-2 * 24 * time.Hour)},
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "internal-class",
+				Listeners: []gatewayv1.Listener{
+					{
+						Name:     gatewayv1.SectionName("http-80"),
+						Protocol: gatewayv1.HTTPProtocolType,
+						Port:     gatewayv1.PortNumber(80),
+					},
+				},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "internal-class",
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "example.net/gateway-controller",
+			},
+		},
+		gateway("gateway-1", map[string]string{"app": "store"}, map[string]string{"example.com/owner": "team-a", "example.com/tier": "gold"}),
+		gateway("gateway-2", nil, map[string]string{"example.com/tier": "silver"}),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	buff := &bytes.Buffer{}
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	gp := &GatewaysPrinter{
+		Writer:       buff,
+		Clock:        fakeClock,
+		LabelColumns: []string{"app"},
+		AnnotationColumns: []common.AnnotationColumn{
+			{Name: "owner", Annotation: "example.com/owner"},
+			{Name: "tier", Annotation: "example.com/tier"},
+		},
+	}
+	gp.PrintTable(resourceModel, false)
+
+	got := buff.String()
+	want := `
+NAMESPACE  NAME       CLASS           ADDRESSES  PORTS  PROGRAMMED  AGE  APP    OWNER   TIER
+default    gateway-1  internal-class             80     Unknown     2d   store  team-a  gold
+default    gateway-2  internal-class             80     Unknown     2d                  silver
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+func TestGatewaysPrinter_PrintTable_Highlight(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	gateway := func(name string, age time.Duration, programmed metav1.ConditionStatus) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(fakeClock.Now().Add(-age)),
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "internal-class",
+				Listeners: []gatewayv1.Listener{
+					{
+						Name:     gatewayv1.SectionName("http-80"),
+						Protocol: gatewayv1.HTTPProtocolType,
+						Port:     gatewayv1.PortNumber(80),
+					},
+				},
+			},
+			Status: gatewayv1.GatewayStatus{
+				Conditions: []metav1.Condition{{Type: "Programmed", Status: programmed}},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "internal-class",
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "example.net/gateway-controller",
+			},
+		},
+		gateway("gateway-1", 2*24*time.Hour, metav1.ConditionTrue),
+		gateway("gateway-2", 2*24*time.Hour, metav1.ConditionFalse),
+		gateway("gateway-3", 5*time.Minute, metav1.ConditionTrue),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	var predicates []*resourcediscovery.ObjectPredicate
+	for _, expression := range []string{"status=NotProgrammed", "age<10m"} {
+		predicate, err := resourcediscovery.ParseObjectPredicate(expression)
+		if err != nil {
+			t.Fatalf("ParseObjectPredicate(%q) returned err=%v", expression, err)
+		}
+		predicates = append(predicates, predicate)
+	}
+
+	testcases := []struct {
+		name  string
+		style HighlightStyle
+		want  string
+	}{
+		{
+			name:  "marker",
+			style: HighlightMarker,
+			want: `
+  NAMESPACE  NAME       CLASS           ADDRESSES  PORTS  PROGRAMMED  AGE
+  default    gateway-1  internal-class             80     True        2d
+* default    gateway-2  internal-class             80     False       2d
+* default    gateway-3  internal-class             80     True        5m
+`,
+		},
+		{
+			name:  "color",
+			style: HighlightColor,
+			want: `
+NAMESPACE  NAME       CLASS           ADDRESSES  PORTS  PROGRAMMED  AGE
+default    gateway-1  internal-class             80     True        2d
+\x1b[1;33mdefault    gateway-2  internal-class             80     False       2d\x1b[0m
+\x1b[1;33mdefault    gateway-3  internal-class             80     True        5m\x1b[0m
+`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			gp := &GatewaysPrinter{
+				Writer:      buff,
+				Clock:       fakeClock,
+				Highlighter: &Highlighter{Predicates: predicates, Style: tc.style},
+			}
+			gp.PrintTable(resourceModel, false)
+
+			got := buff.String()
+			want := strings.ReplaceAll(tc.want, `\x1b`, "\x1b")
+			if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+				t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+			}
+		})
+	}
+}
+
+func TestGatewaysPrinter_PrintTable_GroupByClass(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	gateway := func(name, className string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				CreationTimestamp: metav1.Time{
+					Time: fakeClock.Now().Add(-2 * 24 * time.Hour),
+				},
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: gatewayv1.ObjectName(className),
+				Listeners: []gatewayv1.Listener{
+					{
+						Name:     gatewayv1.SectionName("http-80"),
+						Protocol: gatewayv1.HTTPProtocolType,
+						Port:     gatewayv1.PortNumber(80),
+					},
+				},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "internal-class",
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "example.net/internal-controller",
+			},
+		},
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "external-class",
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "example.net/external-controller",
+			},
+		},
+		gateway("internal-gateway-2", "internal-class"),
+		gateway("internal-gateway-1", "internal-class"),
+		gateway("external-gateway", "external-class"),
+		gateway("orphan-gateway", "missing-class"),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	buff := &bytes.Buffer{}
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	gp := &GatewaysPrinter{
+		Writer:       buff,
+		Clock:        fakeClock,
+		GroupByClass: true,
+	}
+	gp.PrintTable(resourceModel, false)
+
+	got := buff.String()
+	want := `
+GatewayClass: external-class (controller: example.net/external-controller)
+NAMESPACE  NAME              CLASS           ADDRESSES  PORTS  PROGRAMMED  AGE
+default    external-gateway  external-class             80     Unknown     2d
+
+GatewayClass: internal-class (controller: example.net/internal-controller)
+NAMESPACE  NAME                CLASS           ADDRESSES  PORTS  PROGRAMMED  AGE
+default    internal-gateway-1  internal-class             80     Unknown     2d
+default    internal-gateway-2  internal-class             80     Unknown     2d
+
+GatewayClass: missing-class (controller: Unknown)
+NAMESPACE  NAME            CLASS          ADDRESSES  PORTS  PROGRAMMED  AGE
+default    orphan-gateway  missing-class             80     Unknown     2d
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
 func TestGatewaysPrinter_PrintDescribeView(t *testing.T) {
 	fakeClock := testingclock.NewFakeClock(time.Now())
 	objects := []runtime.Object{
@@ -333,13 +708,289 @@ func TestGatewaysPrinter_PrintDescribeView(t *testing.T) {
 				GatewayClassName: "foo-gatewayclass",
 			},
 		},
-
-		&gatewayv1.HTTPRoute{
+
+		&gatewayv1.HTTPRoute{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "HTTPRoute",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-httproute",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{
+						Kind:  common.PtrTo(gatewayv1.Kind("Gateway")),
+						Group: common.PtrTo(gatewayv1.Group("gateway.networking.k8s.io")),
+						Name:  "foo-gateway",
+					}},
+				},
+			},
+		},
+
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "healthcheckpolicies.foo.com",
+				Labels: map[string]string{
+					gatewayv1alpha2.PolicyLabelKey: "inherited",
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.ClusterScoped,
+				Group:    "foo.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "healthcheckpolicies",
+					Kind:   "HealthCheckPolicy",
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "foo.com/v1",
+				"kind":       "HealthCheckPolicy",
+				"metadata": map[string]interface{}{
+					"name": "health-check-gatewayclass",
+				},
+				"spec": map[string]interface{}{
+					"override": map[string]interface{}{
+						"key1": "value-parent-1",
+						"key3": "value-parent-3",
+						"key5": "value-parent-5",
+					},
+					"default": map[string]interface{}{
+						"key2": "value-parent-2",
+						"key4": "value-parent-4",
+					},
+					"targetRef": map[string]interface{}{
+						"group": "gateway.networking.k8s.io",
+						"kind":  "GatewayClass",
+						"name":  "foo-gatewayclass",
+					},
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "foo.com/v1",
+				"kind":       "HealthCheckPolicy",
+				"metadata": map[string]interface{}{
+					"name": "health-check-gateway",
+				},
+				"spec": map[string]interface{}{
+					"override": map[string]interface{}{
+						"key1": "value-child-1",
+					},
+					"default": map[string]interface{}{
+						"key2": "value-child-2",
+						"key5": "value-child-5",
+					},
+					"targetRef": map[string]interface{}{
+						"group":     "gateway.networking.k8s.io",
+						"kind":      "Gateway",
+						"name":      "foo-gateway",
+						"namespace": "default",
+					},
+				},
+			},
+		},
+
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "timeoutpolicies.bar.com",
+				Labels: map[string]string{
+					gatewayv1alpha2.PolicyLabelKey: "direct",
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.ClusterScoped,
+				Group:    "bar.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "timeoutpolicies",
+					Kind:   "TimeoutPolicy",
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "bar.com/v1",
+				"kind":       "TimeoutPolicy",
+				"metadata": map[string]interface{}{
+					"name": "timeout-policy-namespace",
+				},
+				"spec": map[string]interface{}{
+					"condition": "path=/abc",
+					"seconds":   int64(30),
+					"targetRef": map[string]interface{}{
+						"kind": "Namespace",
+						"name": "default",
+					},
+				},
+			},
+		},
+
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "event-1",
+			},
+			Type:   corev1.EventTypeNormal,
+			Reason: "SYNC",
+			Source: corev1.EventSource{
+				Component: "my-gateway-controller",
+			},
+			InvolvedObject: corev1.ObjectReference{
+				Kind: "Gateway",
+				Name: "foo-gateway",
+				UID:  "00000000-0000-0000-0000-000000000001",
+			},
+			Message: "some random message",
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	buff := &bytes.Buffer{}
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	gp := &GatewaysPrinter{
+		Writer:       buff,
+		Clock:        fakeClock,
+		EventFetcher: discoverer,
+	}
+	gp.PrintDescribeView(resourceModel)
+
+	got := buff.String()
+	want := `
+Name: foo-gateway
+Namespace: ""
+Labels: null
+Annotations: null
+APIVersion: ""
+Kind: ""
+Metadata:
+  creationTimestamp: null
+  resourceVersion: "999"
+  uid: 00000000-0000-0000-0000-000000000001
+Spec:
+  gatewayClassName: foo-gatewayclass
+  listeners: null
+Status: {}
+AttachedRoutes:
+  Kind       Name
+  ----       ----
+  HTTPRoute  /foo-httproute
+Capacity:
+  AttachedRoutes: 1
+DirectlyAttachedPolicies:
+  Type                       Name
+  ----                       ----
+  HealthCheckPolicy.foo.com  health-check-gateway
+PolicySummary:
+- 'HealthCheckPolicy.foo.com: overridden by health-check-gatewayclass'
+- 'TimeoutPolicy.bar.com: applied (via timeout-policy-namespace)'
+EffectivePolicies:
+  HealthCheckPolicy.foo.com:
+    key1: value-parent-1
+    key2: value-child-2
+    key3: value-parent-3
+    key4: value-parent-4
+    key5: value-parent-5
+  TimeoutPolicy.bar.com:
+    condition: path=/abc
+    seconds: 30
+Events:
+  Type    Reason  Age      From                   Message
+  ----    ------  ---      ----                   -------
+  Normal  SYNC    Unknown  my-gateway-controller  some random message
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+
+	// Limit the effective policies to a single kind.
+	buff.Reset()
+	gp.EffectivePolicyKind = "timeoutpolicy"
+	gp.PrintDescribeView(resourceModel)
+
+	got = buff.String()
+	want = `
+Name: foo-gateway
+Namespace: ""
+Labels: null
+Annotations: null
+APIVersion: ""
+Kind: ""
+Metadata:
+  creationTimestamp: null
+  resourceVersion: "999"
+  uid: 00000000-0000-0000-0000-000000000001
+Spec:
+  gatewayClassName: foo-gatewayclass
+  listeners: null
+Status: {}
+AttachedRoutes:
+  Kind       Name
+  ----       ----
+  HTTPRoute  /foo-httproute
+Capacity:
+  AttachedRoutes: 1
+DirectlyAttachedPolicies:
+  Type                       Name
+  ----                       ----
+  HealthCheckPolicy.foo.com  health-check-gateway
+PolicySummary:
+- 'TimeoutPolicy.bar.com: applied (via timeout-policy-namespace)'
+EffectivePolicies:
+  TimeoutPolicy.bar.com:
+    condition: path=/abc
+    seconds: 30
+Events:
+  Type    Reason  Age      From                   Message
+  ----    ------  ---      ----                   -------
+  Normal  SYNC    Unknown  my-gateway-controller  some random message
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+// TestGatewaysPrinter_PrintDescribeView_MaxListItems tests that the repeating
+// sections of the describe view are truncated after sorting.
+func TestGatewaysPrinter_PrintDescribeView_MaxListItems(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "example.net/gateway-controller",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gateway",
+				UID:  "00000000-0000-0000-0000-000000000001",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+			},
+		},
+	}
+	for i := 5; i >= 1; i-- {
+		objects = append(objects, &gatewayv1.HTTPRoute{
 			TypeMeta: metav1.TypeMeta{
 				Kind: "HTTPRoute",
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "foo-httproute",
+				Name: fmt.Sprintf("httproute-%d", i),
 			},
 			Spec: gatewayv1.HTTPRouteSpec{
 				CommonRouteSpec: gatewayv1.CommonRouteSpec{
@@ -350,23 +1001,127 @@ func TestGatewaysPrinter_PrintDescribeView(t *testing.T) {
 					}},
 				},
 			},
+		})
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	testcases := []struct {
+		name         string
+		maxListItems int
+		want         string
+	}{
+		{
+			name:         "truncated",
+			maxListItems: 2,
+			want: `
+Name: foo-gateway
+Namespace: ""
+Labels: null
+Annotations: null
+APIVersion: ""
+Kind: ""
+Metadata:
+  creationTimestamp: null
+  resourceVersion: "999"
+  uid: 00000000-0000-0000-0000-000000000001
+Spec:
+  gatewayClassName: foo-gatewayclass
+  listeners: null
+Status: {}
+AttachedRoutes:
+  Kind       Name
+  ----       ----
+  HTTPRoute  /httproute-1
+  HTTPRoute  /httproute-2
+  (… and 3 more, use --max-list-items=0 to show all)
+Capacity:
+  AttachedRoutes: 5
+DirectlyAttachedPolicies: <none>
+Events: <none>
+`,
+		},
+		{
+			name:         "untruncated",
+			maxListItems: 0,
+			want: `
+Name: foo-gateway
+Namespace: ""
+Labels: null
+Annotations: null
+APIVersion: ""
+Kind: ""
+Metadata:
+  creationTimestamp: null
+  resourceVersion: "999"
+  uid: 00000000-0000-0000-0000-000000000001
+Spec:
+  gatewayClassName: foo-gatewayclass
+  listeners: null
+Status: {}
+AttachedRoutes:
+  Kind       Name
+  ----       ----
+  HTTPRoute  /httproute-1
+  HTTPRoute  /httproute-2
+  HTTPRoute  /httproute-3
+  HTTPRoute  /httproute-4
+  HTTPRoute  /httproute-5
+Capacity:
+  AttachedRoutes: 5
+DirectlyAttachedPolicies: <none>
+Events: <none>
+`,
 		},
+	}
 
-		&apiextensionsv1.CustomResourceDefinition{
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			gp := &GatewaysPrinter{
+				Writer:       buff,
+				Clock:        fakeClock,
+				EventFetcher: discoverer,
+				MaxListItems: tc.maxListItems,
+			}
+			gp.PrintDescribeView(resourceModel)
+
+			got := buff.String()
+			if diff := cmp.Diff(common.YamlString(tc.want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+				t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, tc.want, diff)
+			}
+		})
+	}
+}
+
+// TestGatewaysPrinter_PrintJsonYaml tests the -o json/yaml output of the `get` subcommand
+func TestEffectiveSubtreeForGateway(t *testing.T) {
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&gatewayv1.GatewayClass{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "healthcheckpolicies.foo.com",
-				Labels: map[string]string{
-					gatewayv1alpha2.PolicyLabelKey: "inherited",
-				},
+				Name: "foo-gatewayclass",
 			},
-			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
-				Scope:    apiextensionsv1.ClusterScoped,
-				Group:    "foo.com",
-				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
-				Names: apiextensionsv1.CustomResourceDefinitionNames{
-					Plural: "healthcheckpolicies",
-					Kind:   "HealthCheckPolicy",
-				},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "example.net/gateway-controller",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-gateway",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
 			},
 		},
 		&unstructured.Unstructured{
@@ -374,107 +1129,92 @@ func TestGatewaysPrinter_PrintDescribeView(t *testing.T) {
 				"apiVersion": "foo.com/v1",
 				"kind":       "HealthCheckPolicy",
 				"metadata": map[string]interface{}{
-					"name": "health-check-gatewayclass",
+					"name": "health-check-gateway",
 				},
 				"spec": map[string]interface{}{
-					"override": map[string]interface{}{
-						"key1": "value-parent-1",
-						"key3": "value-parent-3",
-						"key5": "value-parent-5",
-					},
 					"default": map[string]interface{}{
-						"key2": "value-parent-2",
-						"key4": "value-parent-4",
+						"key1": "value-gateway-1",
 					},
 					"targetRef": map[string]interface{}{
-						"group": "gateway.networking.k8s.io",
-						"kind":  "GatewayClass",
-						"name":  "foo-gatewayclass",
+						"group":     "gateway.networking.k8s.io",
+						"kind":      "Gateway",
+						"name":      "foo-gateway",
+						"namespace": "default",
 					},
 				},
 			},
 		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "foo-gateway"}},
+				},
+				Rules: []gatewayv1.HTTPRouteRule{{
+					BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{
+							Kind: common.PtrTo(gatewayv1.Kind("Service")),
+							Name: "foo-svc",
+						},
+					}}},
+				}},
+			},
+		},
 		&unstructured.Unstructured{
 			Object: map[string]interface{}{
 				"apiVersion": "foo.com/v1",
 				"kind":       "HealthCheckPolicy",
 				"metadata": map[string]interface{}{
-					"name": "health-check-gateway",
+					"name": "health-check-httproute",
 				},
 				"spec": map[string]interface{}{
-					"override": map[string]interface{}{
-						"key1": "value-child-1",
-					},
 					"default": map[string]interface{}{
-						"key2": "value-child-2",
-						"key5": "value-child-5",
+						"key1": "value-httproute-1",
+						"key2": "value-httproute-2",
 					},
 					"targetRef": map[string]interface{}{
 						"group":     "gateway.networking.k8s.io",
-						"kind":      "Gateway",
-						"name":      "foo-gateway",
+						"kind":      "HTTPRoute",
+						"name":      "foo-httproute",
 						"namespace": "default",
 					},
 				},
 			},
 		},
-
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-svc",
+				Namespace: "default",
+			},
+		},
 		&apiextensionsv1.CustomResourceDefinition{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: "timeoutpolicies.bar.com",
+				Name: "healthcheckpolicies.foo.com",
 				Labels: map[string]string{
-					gatewayv1alpha2.PolicyLabelKey: "direct",
+					gatewayv1alpha2.PolicyLabelKey: "inherited",
 				},
 			},
 			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
 				Scope:    apiextensionsv1.ClusterScoped,
-				Group:    "bar.com",
+				Group:    "foo.com",
 				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
 				Names: apiextensionsv1.CustomResourceDefinitionNames{
-					Plural: "timeoutpolicies",
-					Kind:   "TimeoutPolicy",
-				},
-			},
-		},
-		&unstructured.Unstructured{
-			Object: map[string]interface{}{
-				"apiVersion": "bar.com/v1",
-				"kind":       "TimeoutPolicy",
-				"metadata": map[string]interface{}{
-					"name": "timeout-policy-namespace",
-				},
-				"spec": map[string]interface{}{
-					"condition": "path=/abc",
-					"seconds":   int64(30),
-					"targetRef": map[string]interface{}{
-						"kind": "Namespace",
-						"name": "default",
-					},
+					Plural: "healthcheckpolicies",
+					Kind:   "HealthCheckPolicy",
 				},
 			},
 		},
-
-		&corev1.Event{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: "event-1",
-			},
-			Type:   corev1.EventTypeNormal,
-			Reason: "SYNC",
-			Source: corev1.EventSource{
-				Component: "my-gateway-controller",
-			},
-			InvolvedObject: corev1.ObjectReference{
-				Kind: "Gateway",
-				Name: "foo-gateway",
-				UID:  "00000000-0000-0000-0000-000000000001",
-			},
-			Message: "some random message",
-		},
 	}
 
 	k8sClients := common.MustClientsForTest(t, objects...)
 	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
-	buff := &bytes.Buffer{}
 	discoverer := resourcediscovery.Discoverer{
 		K8sClients:    k8sClients,
 		PolicyManager: policyManager,
@@ -483,59 +1223,65 @@ func TestGatewaysPrinter_PrintDescribeView(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to construct resourceModel: %v", err)
 	}
+	gatewayNode := resourceModel.Gateways[resourcediscovery.GatewayID("default", "foo-gateway")]
 
-	gp := &GatewaysPrinter{
-		Writer:       buff,
-		Clock:        fakeClock,
-		EventFetcher: discoverer,
+	buff := &bytes.Buffer{}
+	Describe(buff, []*DescriberKV{{Key: "EffectiveSubtree", Value: effectiveSubtreeForGateway(resourceModel, gatewayNode, "")}})
+
+	got := buff.String()
+	want := `
+EffectiveSubtree:
+- Backends:
+  - EffectivePolicies:
+      HealthCheckPolicy.foo.com:
+        key1: value-httproute-1
+        key2: value-httproute-2
+    PolicySummary:
+    - 'HealthCheckPolicy.foo.com: applied (via health-check-httproute)'
+    Resource: Service default/foo-svc
+  EffectivePolicies:
+    HealthCheckPolicy.foo.com:
+      key1: value-httproute-1
+      key2: value-httproute-2
+  PolicySummary:
+  - 'HealthCheckPolicy.foo.com: applied (via health-check-httproute)'
+  Resource: HTTPRoute default/foo-httproute
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
 	}
-	gp.PrintDescribeView(resourceModel)
+}
+
+func TestConvertRouteMatchPrecedenceToTable(t *testing.T) {
+	match := func(pathType gatewayv1.PathMatchType, value string) gatewayv1.HTTPRouteMatch {
+		return gatewayv1.HTTPRouteMatch{Path: &gatewayv1.HTTPPathMatch{Type: common.PtrTo(pathType), Value: common.PtrTo(value)}}
+	}
+	withMethod := match(gatewayv1.PathMatchPathPrefix, "/api")
+	withMethod.Method = common.PtrTo(gatewayv1.HTTPMethodGet)
+	precedence := []resourcediscovery.RouteMatchPrecedence{
+		{Listener: "http", Hostname: "foo.example.com", HTTPRoute: types.NamespacedName{Namespace: "default", Name: "bar-httproute"}, Rule: 0, Match: 1, RouteMatch: match(gatewayv1.PathMatchExact, "/")},
+		{Listener: "http", Hostname: "foo.example.com", HTTPRoute: types.NamespacedName{Namespace: "default", Name: "bar-httproute"}, Rule: 0, Match: 0, RouteMatch: withMethod},
+		{Listener: "http", Hostname: "*.example.com", HTTPRoute: types.NamespacedName{Namespace: "default", Name: "foo-httproute"}, Rule: 1, Match: 0, RouteMatch: match(gatewayv1.PathMatchPathPrefix, "/")},
+		{Listener: "https", Hostname: "foo.example.com", HTTPRoute: types.NamespacedName{Namespace: "default", Name: "bar-httproute"}, Rule: 0, Match: 1, RouteMatch: match(gatewayv1.PathMatchExact, "/")},
+	}
+
+	buff := &bytes.Buffer{}
+	convertRouteMatchPrecedenceToTable(precedence).Write(buff, 0)
 
 	got := buff.String()
 	want := `
-Name: foo-gateway
-Namespace: ""
-Labels: null
-Annotations: null
-APIVersion: ""
-Kind: ""
-Metadata:
-  creationTimestamp: null
-  resourceVersion: "999"
-  uid: 00000000-0000-0000-0000-000000000001
-Spec:
-  gatewayClassName: foo-gatewayclass
-  listeners: null
-Status: {}
-AttachedRoutes:
-  Kind       Name
-  ----       ----
-  HTTPRoute  /foo-httproute
-DirectlyAttachedPolicies:
-  Type                       Name
-  ----                       ----
-  HealthCheckPolicy.foo.com  health-check-gateway
-EffectivePolicies:
-  HealthCheckPolicy.foo.com:
-    key1: value-parent-1
-    key2: value-child-2
-    key3: value-parent-3
-    key4: value-parent-4
-    key5: value-parent-5
-  TimeoutPolicy.bar.com:
-    condition: path=/abc
-    seconds: 30
-Events:
-  Type    Reason  Age      From                   Message
-  ----    ------  ---      ----                   -------
-  Normal  SYNC    Unknown  my-gateway-controller  some random message
+Listener  Hostname         Order  HTTPRoute              Match                Conditions
+--------  --------         -----  ---------              -----                ----------
+http      foo.example.com  1      default/bar-httproute  rules[0].matches[1]  Exact /
+http      foo.example.com  2      default/bar-httproute  rules[0].matches[0]  PathPrefix /api GET
+http      *.example.com    1      default/foo-httproute  rules[1].matches[0]  PathPrefix /
+https     foo.example.com  1      default/bar-httproute  rules[0].matches[1]  Exact /
 `
 	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
 	}
 }
 
-// TestGatewaysPrinter_PrintJsonYaml tests the -o json/yaml output of the `get` subcommand
 func TestGatewaysPrinter_PrintJsonYaml(t *testing.T) {
 	fakeClock := testingclock.NewFakeClock(time.Now())
 	creationTime := fakeClock.Now().Add(-5 * 24 * time.Hour).UTC() // UTC being necessary for consistently handling the time while marshaling/unmarshaling its JSON
@@ -811,3 +1557,51 @@ kind: List`, creationTime.Format(time.RFC3339)))
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
 	}
 }
+
+func TestGatewaysPrinter_PrintName(t *testing.T) {
+	gateway := func(namespace, name string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "gatewayclass-1",
+			},
+		}
+	}
+	objects := []runtime.Object{
+		gateway("default", "gateway-2"),
+		gateway("default", "gateway-1"),
+		gateway("ns1", "gateway-3"),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	buff := &bytes.Buffer{}
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+		MetadataOnly:  true,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", resourceModel)
+	}
+
+	gp := &GatewaysPrinter{
+		Writer: buff,
+		Clock:  testingclock.NewFakeClock(time.Now()),
+	}
+	Print(gp, resourceModel, utils.OutputFormatName)
+
+	got := buff.String()
+	want := `
+gateway.gateway.networking.k8s.io/gateway-1
+gateway.gateway.networking.k8s.io/gateway-2
+gateway.gateway.networking.k8s.io/gateway-3
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// printHelmValues prints the resources as the values of a Helm chart which
+// templates them. The values group the resources by kind, under the plural
+// name of the kind in lowerCamelCase (like httpRoutes), and key them by name:
+//
+//	gateways:
+//	  my-gateway:
+//	    namespace: default
+//	    spec:
+//	      ...
+//
+// Each resource has its namespace, labels, annotations and spec. Its status
+// and the metadata set by the API server are left out, since a chart cannot
+// set them. Resources of the same kind and name in different namespaces are
+// keyed by NAMESPACE/NAME instead.
+func printHelmValues(w io.Writer, objs []client.Object) {
+	values, err := renderHelmValues(objs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to form the printable payload %v\n", err)
+		os.Exit(1)
+	}
+	output, err := utils.MarshalWithFormat(values, utils.OutputFormatYAML)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprint(w, string(output))
+}
+
+func renderHelmValues(objs []client.Object) (map[string]any, error) {
+	type entry struct {
+		obj    client.Object
+		values map[string]any
+	}
+	entriesByKind := map[string][]entry{}
+	for _, obj := range objs {
+		unstructuredObj, err := toPrintableUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		objValues := map[string]any{}
+		if obj.GetNamespace() != "" {
+			objValues["namespace"] = obj.GetNamespace()
+		}
+		if labels := obj.GetLabels(); len(labels) != 0 {
+			objValues["labels"] = labels
+		}
+		annotations := map[string]string{}
+		for k, v := range obj.GetAnnotations() {
+			if k != lastAppliedConfigAnnotation {
+				annotations[k] = v
+			}
+		}
+		if len(annotations) != 0 {
+			objValues["annotations"] = annotations
+		}
+		if spec, ok := unstructuredObj["spec"]; ok {
+			objValues["spec"] = spec
+		}
+		kindKey := helmValuesKindKey(obj)
+		entriesByKind[kindKey] = append(entriesByKind[kindKey], entry{obj: obj, values: objValues})
+	}
+
+	result := map[string]any{}
+	for kindKey, entries := range entriesByKind {
+		namespacesByName := map[string]map[string]bool{}
+		for _, e := range entries {
+			if namespacesByName[e.obj.GetName()] == nil {
+				namespacesByName[e.obj.GetName()] = map[string]bool{}
+			}
+			namespacesByName[e.obj.GetName()][e.obj.GetNamespace()] = true
+		}
+		kindValues := map[string]any{}
+		for _, e := range entries {
+			key := e.obj.GetName()
+			if len(namespacesByName[key]) > 1 {
+				key = e.obj.GetNamespace() + "/" + key
+			}
+			kindValues[key] = e.values
+		}
+		result[kindKey] = kindValues
+	}
+	return result, nil
+}
+
+// helmValuesKindKey returns the plural name of the kind of the resource in
+// lowerCamelCase, like httpRoutes for HTTPRoutes.
+func helmValuesKindKey(obj client.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		// Objects fetched through the typed client do not have their TypeMeta set.
+		if gvks, _, err := scheme.Scheme.ObjectKinds(obj); err == nil {
+			kind = gvks[0].Kind
+		}
+	}
+
+	// Lowercase the leading acronym or word, like HTTP in HTTPRoute or Gateway
+	// in GatewayClass.
+	i := 0
+	for i < len(kind) && isUpper(kind[i]) {
+		i++
+	}
+	if i > 1 && i < len(kind) {
+		i-- // The last uppercase letter starts the next word.
+	}
+	if i == 0 {
+		i = 1
+	}
+	key := strings.ToLower(kind[:min(i, len(kind))]) + kind[min(i, len(kind)):]
+
+	switch {
+	case strings.HasSuffix(key, "s"):
+		return key + "es"
+	case len(key) > 1 && key[len(key)-1] == 'y' && !strings.ContainsRune("aeiou", rune(key[len(key)-2])):
+		return strings.TrimSuffix(key, "y") + "ies"
+	}
+	return key + "s"
+}
+
+func isUpper(c byte) bool {
+	return 'A' <= c && c <= 'Z'
+}
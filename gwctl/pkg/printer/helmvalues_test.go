@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestHTTPRoutesPrinter_PrintHelmValues(t *testing.T) {
+	httpRoute := func(namespace, name string, hostname gatewayv1.Hostname) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: gatewayv1.GroupVersion.String(),
+				Kind:       "HTTPRoute",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{"app": "store"},
+				Annotations: map[string]string{
+					"example.com/owner":         "team-a",
+					lastAppliedConfigAnnotation: `{"kind":"HTTPRoute"}`,
+				},
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Hostnames: []gatewayv1.Hostname{hostname},
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "gateway-1"}},
+				},
+			},
+			Status: gatewayv1.HTTPRouteStatus{
+				RouteStatus: gatewayv1.RouteStatus{
+					Parents: []gatewayv1.RouteParentStatus{{ControllerName: "example.net/gateway-controller"}},
+				},
+			},
+		}
+	}
+
+	objects := []runtime.Object{
+		httpRoute("default", "store", "store.example.com"),
+		httpRoute("default", "checkout", "checkout.example.com"),
+		httpRoute("staging", "checkout", "checkout.staging.example.com"),
+	}
+	k8sClients := common.MustClientsForTest(t, objects...)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: utils.MustPolicyManagerForTest(t, k8sClients),
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to discover resources: %v", err)
+	}
+
+	hp := &HTTPRoutesPrinter{
+		Writer: &bytes.Buffer{},
+		Clock:  testingclock.NewFakeClock(time.Now()),
+	}
+	Print(hp, resourceModel, utils.OutputFormatHelmValues)
+
+	got := common.YamlString(hp.Writer.(*bytes.Buffer).String())
+	want := common.YamlString(`
+httpRoutes:
+  default/checkout:
+    annotations:
+      example.com/owner: team-a
+    labels:
+      app: store
+    namespace: default
+    spec:
+      hostnames:
+      - checkout.example.com
+      parentRefs:
+      - name: gateway-1
+  staging/checkout:
+    annotations:
+      example.com/owner: team-a
+    labels:
+      app: store
+    namespace: staging
+    spec:
+      hostnames:
+      - checkout.staging.example.com
+      parentRefs:
+      - name: gateway-1
+  store:
+    annotations:
+      example.com/owner: team-a
+    labels:
+      app: store
+    namespace: default
+    spec:
+      hostnames:
+      - store.example.com
+      parentRefs:
+      - name: gateway-1
+`)
+	if diff := cmp.Diff(want, got, common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+func TestHelmValuesKindKey(t *testing.T) {
+	testcases := []struct {
+		obj  client.Object
+		want string
+	}{
+		{obj: &gatewayv1.Gateway{}, want: "gateways"},
+		{obj: &gatewayv1.GatewayClass{}, want: "gatewayClasses"},
+		{obj: &gatewayv1.HTTPRoute{}, want: "httpRoutes"},
+		{obj: &gatewayv1.GRPCRoute{}, want: "grpcRoutes"},
+		{obj: &gatewayv1beta1.ReferenceGrant{}, want: "referenceGrants"},
+	}
+	for _, tc := range testcases {
+		if got := helmValuesKindKey(tc.obj); got != tc.want {
+			t.Errorf("helmValuesKindKey(%T) = %q, want %q", tc.obj, got, tc.want)
+		}
+	}
+}
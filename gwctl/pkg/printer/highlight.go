@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"strings"
+
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+)
+
+// HighlightStyle is how highlighted rows of tables stand out.
+type HighlightStyle int
+
+const (
+	// HighlightColor prints highlighted rows in bold and color, for terminals.
+	HighlightColor HighlightStyle = iota
+	// HighlightMarker prefixes highlighted rows with "*", and other rows with a
+	// space to keep them aligned, for output without colors.
+	HighlightMarker
+)
+
+const (
+	highlightColorStart = "\x1b[1;33m"
+	highlightColorEnd   = "\x1b[0m"
+)
+
+// apply returns the line of a table, which ends with a newline, in the style.
+func (s HighlightStyle) apply(line string, highlighted bool) string {
+	switch s {
+	case HighlightMarker:
+		if highlighted {
+			return "* " + line
+		}
+		return "  " + line
+	default:
+		if highlighted {
+			return highlightColorStart + strings.TrimSuffix(line, "\n") + highlightColorEnd + "\n"
+		}
+		return line
+	}
+}
+
+// Highlighter highlights the rows of tables for resources which match any of
+// its predicates.
+type Highlighter struct {
+	Predicates []*resourcediscovery.ObjectPredicate
+	Style      HighlightStyle
+}
+
+// highlightLastRow highlights the last row of the table if the resource of the
+// row matches any predicate of the highlighter. A nil highlighter highlights
+// nothing and leaves the table unchanged.
+func (h *Highlighter) highlightLastRow(table *Table, c clock.PassiveClock, obj client.Object) {
+	if h == nil || len(table.Rows) == 0 {
+		return
+	}
+	if table.Highlighted == nil {
+		table.Highlighted = make(map[int]bool)
+		table.HighlightStyle = h.Style
+	}
+	for _, predicate := range h.Predicates {
+		if predicate.Matches(c, obj) {
+			table.Highlighted[len(table.Rows)-1] = true
+			return
+		}
+	}
+}
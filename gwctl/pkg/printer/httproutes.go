@@ -20,23 +20,41 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 
 	"golang.org/x/exp/maps"
-	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
 )
 
 var _ Printer = (*HTTPRoutesPrinter)(nil)
 
 type HTTPRoutesPrinter struct {
 	io.Writer
-	Clock clock.Clock
+	Clock               clock.Clock
+	SortBy              utils.SortKey
+	LabelColumns        []string
+	AnnotationColumns   []common.AnnotationColumn
+	Highlighter         *Highlighter
+	EffectivePolicyKind string
+	ShowDrift           bool
+	ShowFieldOwners     bool
+	// SortHostnames sorts the hostnames alphabetically instead of showing them
+	// in the order of the spec.
+	SortHostnames bool
+	// MaxListItems is the maximum number of items printed for each repeating
+	// section of the describe view, like the rules and the directly attached
+	// policies. All items are printed if it is 0.
+	MaxListItems int
 }
 
 func (hp *HTTPRoutesPrinter) GetPrintableNodes(resourceModel *resourcediscovery.ResourceModel) []NodeResource {
@@ -44,39 +62,44 @@ func (hp *HTTPRoutesPrinter) GetPrintableNodes(resourceModel *resourcediscovery.
 }
 
 func (hp *HTTPRoutesPrinter) PrintTable(resourceModel *resourcediscovery.ResourceModel, wide bool) {
+	hp.Table(resourceModel, wide).Write(hp, 0)
+}
+
+// Table returns the table printed by PrintTable.
+func (hp *HTTPRoutesPrinter) Table(resourceModel *resourcediscovery.ResourceModel, wide bool) *Table {
 	var columnNames []string
 	if wide {
-		columnNames = []string{"NAMESPACE", "NAME", "HOSTNAMES", "PARENT REFS", "AGE", "POLICIES"}
+		columnNames = []string{"NAMESPACE", "NAME", "HOSTNAMES", "PARENT REFS", "AGE", "POLICIES", "BACKENDS", "CONTROLLER", "STALE", "MANAGERS"}
 	} else {
 		columnNames = []string{"NAMESPACE", "NAME", "HOSTNAMES", "PARENT REFS", "AGE"}
 	}
 
 	table := &Table{
-		ColumnNames:  columnNames,
+		ColumnNames:  append(append(columnNames, labelColumnNames(hp.LabelColumns)...), annotationColumnNames(hp.AnnotationColumns)...),
 		UseSeparator: false,
 	}
 	httpRouteNodes := maps.Values(resourceModel.HTTPRoutes)
+	if hp.SortBy == utils.SortKeyPolicies {
+		SortByPolicyCount(httpRouteNodes, func(httpRouteNode *resourcediscovery.HTTPRouteNode) int { return len(httpRouteNode.Policies) })
+	} else {
+		SortByString(httpRouteNodes)
+	}
 
-	for _, httpRouteNode := range SortByString(httpRouteNodes) {
-		var hostNames []string
-		for _, hostName := range httpRouteNode.HTTPRoute.Spec.Hostnames {
-			hostNames = append(hostNames, string(hostName))
-		}
-		hostNamesOutput := "None"
-		if hostNamesCount := len(hostNames); hostNamesCount > 0 {
-			if hostNamesCount > 2 {
-				hostNamesOutput = fmt.Sprintf("%v + %v more", strings.Join(hostNames[:2], ","), hostNamesCount-2)
-			} else {
-				hostNamesOutput = strings.Join(hostNames, ",")
-			}
-		}
+	var httpRoutes []gatewayv1.HTTPRoute
+	for _, httpRouteNode := range httpRouteNodes {
+		httpRoutes = append(httpRoutes, *httpRouteNode.HTTPRoute)
+	}
+	fanout := relations.ComputeRouteFanout(httpRoutes)
+
+	for _, httpRouteNode := range httpRouteNodes {
+		hostNamesOutput := formatHostnames(httpRouteNode, hp.SortHostnames)
 
 		parentRefsCount := fmt.Sprintf("%d", len(httpRouteNode.HTTPRoute.Spec.ParentRefs))
 
-		age := duration.HumanDuration(hp.Clock.Since(httpRouteNode.HTTPRoute.GetCreationTimestamp().Time))
+		age := formatAge(hp.Clock, httpRouteNode.HTTPRoute)
 
 		row := []string{
-			httpRouteNode.HTTPRoute.GetNamespace(),
+			formatNamespace(httpRouteNode.HTTPRoute.GetNamespace(), httpRouteNode.Namespace),
 			httpRouteNode.HTTPRoute.GetName(),
 			hostNamesOutput,
 			parentRefsCount,
@@ -84,45 +107,146 @@ func (hp *HTTPRoutesPrinter) PrintTable(resourceModel *resourcediscovery.Resourc
 		}
 		if wide {
 			policiesCount := fmt.Sprintf("%d", len(httpRouteNode.Policies))
-			row = append(row, policiesCount)
+			stale := formatStale(resourcediscovery.IsHTTPRouteStale(httpRouteNode.HTTPRoute))
+			backendsCount := fmt.Sprintf("%d", fanout[client.ObjectKeyFromObject(httpRouteNode.HTTPRoute)])
+			controllers := "None"
+			if controllerNames := controllerNamesForHTTPRoute(httpRouteNode); len(controllerNames) != 0 {
+				controllers = strings.Join(controllerNames, ",")
+			}
+			row = append(row, policiesCount, backendsCount, controllers, stale, formatSpecManagers(httpRouteNode.HTTPRoute))
 		}
+		row = append(row, labelColumnValues(httpRouteNode.HTTPRoute, hp.LabelColumns)...)
+		row = append(row, annotationColumnValues(httpRouteNode.HTTPRoute, hp.AnnotationColumns)...)
 		table.Rows = append(table.Rows, row)
+		hp.Highlighter.highlightLastRow(table, hp.Clock, httpRouteNode.HTTPRoute)
 	}
-	table.Write(hp, 0)
+	return table
+}
+
+// controllerNamesForHTTPRoute returns the distinct controllerNames of the
+// GatewayClasses of the Gateways which the HTTPRoute is attached to, sorted.
+func controllerNamesForHTTPRoute(httpRouteNode *resourcediscovery.HTTPRouteNode) []string {
+	controllerNames := make(map[string]bool)
+	for _, gatewayNode := range httpRouteNode.Gateways {
+		if gatewayNode.GatewayClass == nil {
+			continue
+		}
+		controllerNames[string(gatewayNode.GatewayClass.GatewayClass.Spec.ControllerName)] = true
+	}
+	result := maps.Keys(controllerNames)
+	sort.Strings(result)
+	return result
 }
 
 type httpRouteDescribeView struct {
+	Summary                  string                      `json:",omitempty"`
 	Name                     string                      `json:",omitempty"`
 	Namespace                string                      `json:",omitempty"`
-	Hostnames                []gatewayv1.Hostname        `json:",omitempty"`
+	Hostnames                []string                    `json:",omitempty"`
 	ParentRefs               []gatewayv1.ParentReference `json:",omitempty"`
+	ListenerHostnames        map[string][]string         `json:",omitempty"`
+	ListenerTLS              map[string][]listenerTLS    `json:",omitempty"`
+	Rules                    []httpRouteRuleSummary      `json:",omitempty"`
+	Drift                    string                      `json:",omitempty"`
+	FieldOwners              any                         `json:",omitempty"`
+	FieldManagers            map[string][]string         `json:",omitempty"`
 	DirectlyAttachedPolicies []common.ObjRef             `json:",omitempty"`
+	PolicySummary            any                         `json:",omitempty"`
 	EffectivePolicies        any                         `json:",omitempty"`
+	Analysis                 []string                    `json:",omitempty"`
+
+	// truncated is the number of items omitted from the list of the view
+	// because of MaxListItems.
+	truncated int
+}
+
+// listenerTLS describes how a listener which an HTTPRoute attaches to
+// terminates TLS.
+type listenerTLS struct {
+	Listener        gatewayv1.SectionName
+	Mode            gatewayv1.TLSModeType
+	CertificateRefs []string `json:",omitempty"`
+}
+
+// httpRouteRuleSummary summarizes how traffic matching a rule is split between
+// backends and which fraction of it is mirrored. SessionPersistence is noted
+// when it is set on the rule, since it takes precedence over the
+// BackendLBPolicies of the backends.
+type httpRouteRuleSummary struct {
+	Backends           []string `json:",omitempty"`
+	Mirrors            []string `json:",omitempty"`
+	SessionPersistence string   `json:",omitempty"`
 }
 
 func (hp *HTTPRoutesPrinter) PrintDescribeView(resourceModel *resourcediscovery.ResourceModel) {
 	index := 0
 	for _, httpRouteNode := range resourceModel.HTTPRoutes {
 		index++
+		if index > 1 {
+			writeDescribeSeparator(hp, "HTTPRoute", httpRouteNode.HTTPRoute)
+		}
 
 		views := []httpRouteDescribeView{
+			{
+				Summary: resourcediscovery.SummarizeAttachments(httpRouteNode).String(),
+			},
 			{
 				Name:      httpRouteNode.HTTPRoute.GetName(),
-				Namespace: httpRouteNode.HTTPRoute.GetNamespace(),
+				Namespace: formatNamespace(httpRouteNode.HTTPRoute.GetNamespace(), httpRouteNode.Namespace),
 			},
 			{
-				Hostnames:  httpRouteNode.HTTPRoute.Spec.Hostnames,
-				ParentRefs: httpRouteNode.HTTPRoute.Spec.ParentRefs,
+				Hostnames:         effectiveHostnamesToString(httpRouteNode, hp.SortHostnames),
+				ParentRefs:        httpRouteNode.HTTPRoute.Spec.ParentRefs,
+				ListenerHostnames: listenerHostnamesByGateway(httpRouteNode),
+				ListenerTLS:       listenerTLSByGateway(httpRouteNode),
 			},
 		}
+		if rules := summarizeHTTPRouteRules(httpRouteNode); len(rules) != 0 {
+			rules, truncated := truncateList(rules, hp.MaxListItems)
+			views = append(views, httpRouteDescribeView{
+				Rules:     rules,
+				truncated: truncated,
+			})
+		}
+		if hp.ShowDrift {
+			views = append(views, httpRouteDescribeView{
+				Drift: specDrift(httpRouteNode.HTTPRoute),
+			})
+		}
+		if hp.ShowFieldOwners {
+			views = append(views, httpRouteDescribeView{
+				FieldOwners: specFieldOwners(httpRouteNode.HTTPRoute),
+			})
+		}
+		if managers := fieldManagers(httpRouteNode.HTTPRoute); len(managers) != 0 {
+			views = append(views, httpRouteDescribeView{
+				FieldManagers: managers,
+			})
+		}
 		if policyRefs := resourcediscovery.ConvertPoliciesMapToPolicyRefs(httpRouteNode.Policies); len(policyRefs) != 0 {
+			// The policies are sorted, so that the same of them are kept when
+			// the list is truncated.
+			sort.Slice(policyRefs, func(i, j int) bool {
+				a, b := policyRefs[i], policyRefs[j]
+				return fmt.Sprintf("%v/%v/%v/%v", a.Group, a.Kind, a.Namespace, a.Name) < fmt.Sprintf("%v/%v/%v/%v", b.Group, b.Kind, b.Namespace, b.Name)
+			})
+			policyRefs, truncated := truncateList(policyRefs, hp.MaxListItems)
 			views = append(views, httpRouteDescribeView{
 				DirectlyAttachedPolicies: policyRefs,
+				truncated:                truncated,
 			})
 		}
-		if len(httpRouteNode.EffectivePolicies) != 0 {
+		if effectivePolicies := filterPoliciesByGatewayByKind(httpRouteNode.EffectivePolicies, hp.EffectivePolicyKind); len(effectivePolicies) != 0 {
+			views = append(views, httpRouteDescribeView{
+				PolicySummary: convertPoliciesByGatewayToPolicySummary(effectivePolicies),
+			})
 			views = append(views, httpRouteDescribeView{
-				EffectivePolicies: httpRouteNode.EffectivePolicies,
+				EffectivePolicies: effectivePolicies,
+			})
+		}
+		if len(httpRouteNode.Errors) != 0 {
+			views = append(views, httpRouteDescribeView{
+				Analysis: convertErrorsToString(httpRouteNode.Errors),
 			})
 		}
 
@@ -133,10 +257,246 @@ func (hp *HTTPRoutesPrinter) PrintDescribeView(resourceModel *resourcediscovery.
 				os.Exit(1)
 			}
 			fmt.Fprint(hp, string(b))
+			if view.truncated > 0 {
+				writeTruncated(hp, 0, view.truncated)
+			}
 		}
+	}
+}
+
+// formatHostnames returns the hostnames of the HTTPRoute for the table, with the
+// invalid ones marked, and at most the first two of them followed by the
+// number of the others. The hostnames are sorted before they are truncated if
+// sortHostnames is true.
+func formatHostnames(httpRouteNode *resourcediscovery.HTTPRouteNode, sortHostnames bool) string {
+	invalidHostNames := invalidHostnamesForHTTPRoute(httpRouteNode)
+	var hostNames []string
+	for _, hostName := range httpRouteNode.HTTPRoute.Spec.Hostnames {
+		if invalidHostNames[string(hostName)] {
+			hostNames = append(hostNames, fmt.Sprintf("%v (invalid)", hostName))
+			continue
+		}
+		hostNames = append(hostNames, string(hostName))
+	}
+	if sortHostnames {
+		sort.Strings(hostNames)
+	}
+
+	switch hostNamesCount := len(hostNames); {
+	case hostNamesCount == 0:
+		return "None"
+	case hostNamesCount > 2:
+		return fmt.Sprintf("%v + %v more", strings.Join(hostNames[:2], ","), hostNamesCount-2)
+	default:
+		return strings.Join(hostNames, ",")
+	}
+}
+
+// effectiveHostnamesToString returns the hostnames of the HTTPRoute, annotated
+// with whether any listener which accepted the HTTPRoute serves them. The
+// hostnames are sorted if sortHostnames is true.
+func effectiveHostnamesToString(httpRouteNode *resourcediscovery.HTTPRouteNode, sortHostnames bool) []string {
+	effectiveHostnames := resourcediscovery.EffectiveHostnames(httpRouteNode)
+	if sortHostnames {
+		sort.SliceStable(effectiveHostnames, func(i, j int) bool {
+			return effectiveHostnames[i].Hostname < effectiveHostnames[j].Hostname
+		})
+	}
+	var result []string
+	for _, effectiveHostname := range effectiveHostnames {
+		result = append(result, effectiveHostname.String())
+	}
+	return result
+}
 
-		if index+1 <= len(resourceModel.HTTPRoutes) {
-			fmt.Fprintf(hp, "\n\n")
+// listenerHostnamesByGateway returns, for each parent Gateway, the listeners
+// which the HTTPRoute attaches to along with the hostnames it serves through
+// each of them, which are the intersection of the hostnames of the HTTPRoute
+// and the hostname of the listener.
+func listenerHostnamesByGateway(httpRouteNode *resourcediscovery.HTTPRouteNode) map[string][]string {
+	result := make(map[string][]string)
+	for _, attachment := range httpRouteNode.Attachments {
+		if attachment.Reason != "" {
+			continue
 		}
+		gateway := attachment.Gateway.String()
+		result[gateway] = append(result[gateway], fmt.Sprintf("%v: %v", attachment.Listener, strings.Join(attachment.Hostnames, ", ")))
+	}
+	return result
+}
+
+// listenerTLSByGateway returns, for each parent Gateway, the TLS configuration
+// of the listeners which the HTTPRoute attaches to. Listeners without a tls
+// block are left out.
+func listenerTLSByGateway(httpRouteNode *resourcediscovery.HTTPRouteNode) map[string][]listenerTLS {
+	result := make(map[string][]listenerTLS)
+	for _, attachment := range httpRouteNode.Attachments {
+		if attachment.Reason != "" {
+			continue
+		}
+		listener, ok := resourcediscovery.AttachedListener(httpRouteNode, attachment)
+		if !ok || listener.TLS == nil {
+			continue
+		}
+		tls := listenerTLS{Listener: listener.Name, Mode: gatewayv1.TLSModeTerminate}
+		if listener.TLS.Mode != nil {
+			tls.Mode = *listener.TLS.Mode
+		}
+		for _, certificateRef := range listener.TLS.CertificateRefs {
+			tls.CertificateRefs = append(tls.CertificateRefs, formatCertificateRef(certificateRef, attachment.Gateway.Namespace))
+		}
+		gateway := attachment.Gateway.String()
+		result[gateway] = append(result[gateway], tls)
+	}
+	return result
+}
+
+// formatCertificateRef returns the kind, namespace and name of the certificate
+// referenced by a listener of a Gateway in the namespace, like
+// "Secret default/foo-cert".
+func formatCertificateRef(ref gatewayv1.SecretObjectReference, namespace string) string {
+	kind := "Secret"
+	if ref.Kind != nil {
+		kind = string(*ref.Kind)
+	}
+	if ref.Group != nil && *ref.Group != "" {
+		kind += "." + string(*ref.Group)
+	}
+	if ref.Namespace != nil {
+		namespace = string(*ref.Namespace)
+	}
+	return fmt.Sprintf("%v %v/%v", kind, namespace, ref.Name)
+}
+
+// invalidHostnamesForHTTPRoute returns the hostnames of the HTTPRoute which
+// were reported as invalid during discovery.
+func invalidHostnamesForHTTPRoute(httpRouteNode *resourcediscovery.HTTPRouteNode) map[string]bool {
+	result := make(map[string]bool)
+	for _, err := range httpRouteNode.Errors {
+		if invalidHostnameErr, ok := err.(resourcediscovery.InvalidHostnameError); ok {
+			result[invalidHostnameErr.Hostname] = true
+		}
+	}
+	return result
+}
+
+// summarizeHTTPRouteRules returns a summary for each rule of the HTTPRoute
+// which forwards or mirrors traffic. Backends are shown with the percentage of
+// traffic they receive based on their weights, and mirrors with the percentage
+// of traffic which is sampled.
+func summarizeHTTPRouteRules(httpRouteNode *resourcediscovery.HTTPRouteNode) []httpRouteRuleSummary {
+	httpRoute := httpRouteNode.HTTPRoute
+	var result []httpRouteRuleSummary
+	for i, rule := range httpRoute.Spec.Rules {
+		summary := httpRouteRuleSummary{}
+
+		var totalWeight int32
+		for _, backendRef := range rule.BackendRefs {
+			totalWeight += backendWeight(backendRef.BackendRef)
+		}
+		for _, backendRef := range rule.BackendRefs {
+			weight := backendWeight(backendRef.BackendRef)
+			var percent float64
+			if totalWeight != 0 {
+				percent = float64(weight) * 100 / float64(totalWeight)
+			}
+			summary.Backends = append(summary.Backends, fmt.Sprintf("%v weight=%d (%v%%)",
+				backendObjectReferenceToString(backendRef.BackendObjectReference, httpRoute.GetNamespace()),
+				weight, strconv.FormatFloat(percent, 'f', -1, 64)))
+		}
+
+		for j, filter := range rule.Filters {
+			if filter.Type != gatewayv1.HTTPRouteFilterRequestMirror || filter.RequestMirror == nil {
+				continue
+			}
+			sampling, ok := httpRouteNode.MirrorSamplings[resourcediscovery.MirrorFilterIndex{Rule: i, Filter: j}]
+			samplingOutput := "invalid"
+			if ok {
+				samplingOutput = sampling.String()
+			}
+			summary.Mirrors = append(summary.Mirrors, fmt.Sprintf("%v (%v)",
+				backendObjectReferenceToString(filter.RequestMirror.BackendRef, httpRoute.GetNamespace()),
+				samplingOutput))
+		}
+
+		if rule.SessionPersistence != nil {
+			summary.SessionPersistence = fmt.Sprintf("%v (set on the rule, overrides BackendLBPolicies)", formatSessionPersistence(rule.SessionPersistence))
+		}
+
+		if len(summary.Backends) != 0 || len(summary.Mirrors) != 0 || summary.SessionPersistence != "" {
+			result = append(result, summary)
+		}
+	}
+	return result
+}
+
+// backendWeight returns the weight of the backendRef, defaulting to 1.
+func backendWeight(backendRef gatewayv1.BackendRef) int32 {
+	if backendRef.Weight == nil {
+		return 1
+	}
+	return *backendRef.Weight
+}
+
+// backendObjectReferenceToString returns a human readable reference of the
+// form "Kind namespace/name[:port]".
+func backendObjectReferenceToString(ref gatewayv1.BackendObjectReference, defaultNamespace string) string {
+	kind := "Service"
+	if ref.Kind != nil {
+		kind = string(*ref.Kind)
+	}
+	namespace := defaultNamespace
+	if ref.Namespace != nil {
+		namespace = string(*ref.Namespace)
+	}
+	result := fmt.Sprintf("%v %v/%v", kind, namespace, ref.Name)
+	if ref.Port != nil {
+		result = fmt.Sprintf("%v:%d", result, *ref.Port)
+	}
+	return result
+}
+
+// PrintConditions prints a table with one row for every status condition of
+// every parent of the HTTPRoutes, to spot failing HTTPRoutes at a glance.
+func (hp *HTTPRoutesPrinter) PrintConditions(resourceModel *resourcediscovery.ResourceModel) {
+	table := &Table{
+		ColumnNames:  []string{"ROUTE", "PARENT", "TYPE", "STATUS", "REASON", "AGE"},
+		UseSeparator: false,
+	}
+	httpRouteNodes := SortByString(maps.Values(resourceModel.HTTPRoutes))
+	for _, httpRouteNode := range httpRouteNodes {
+		httpRoute := httpRouteNode.HTTPRoute
+		for _, parent := range httpRoute.Status.Parents {
+			for _, condition := range parent.Conditions {
+				row := []string{
+					client.ObjectKeyFromObject(httpRoute).String(),
+					formatParentRef(httpRoute.GetNamespace(), parent.ParentRef),
+					condition.Type,
+					string(condition.Status),
+					condition.Reason,
+					formatConditionAge(hp.Clock, condition.LastTransitionTime),
+				}
+				table.Rows = append(table.Rows, row)
+			}
+		}
+	}
+	table.Write(hp, 0)
+}
+
+// formatParentRef formats a parentRef of a route in the namespace as
+// kind/namespace/name, in the same format as the --parent flag, followed by
+// the sectionName if there is one.
+func formatParentRef(namespace string, parentRef gatewayv1.ParentReference) string {
+	kind := "Gateway"
+	if parentRef.Kind != nil {
+		kind = string(*parentRef.Kind)
+	}
+	if parentRef.Namespace != nil {
+		namespace = string(*parentRef.Namespace)
+	}
+	result := fmt.Sprintf("%v/%v/%v", strings.ToLower(kind), namespace, parentRef.Name)
+	if parentRef.SectionName != nil {
+		result += ":" + string(*parentRef.SectionName)
 	}
+	return result
 }
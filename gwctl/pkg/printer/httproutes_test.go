@@ -19,6 +19,7 @@ package printer
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -56,7 +57,7 @@ func TestHTTPRoutesPrinter_PrintTable(t *testing.T) {
 				Name: "demo-gatewayclass-2",
 			},
 			Spec: gatewayv1.GatewayClassSpec{
-				ControllerName: "example.net/gateway-controller",
+				ControllerName: "example.net/other-controller",
 				Description:    common.PtrTo("random"),
 			},
 		},
@@ -100,7 +101,7 @@ func TestHTTPRoutesPrinter_PrintTable(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: gatewayv1.GatewaySpec{
-				GatewayClassName: "demo-gatewayclass-1",
+				GatewayClassName: "demo-gatewayclass-2",
 			},
 		},
 		&gatewayv1.Gateway{
@@ -132,6 +133,20 @@ func TestHTTPRoutesPrinter_PrintTable(t *testing.T) {
 						},
 					},
 				},
+				// The route splits traffic between two distinct backends.
+				Rules: []gatewayv1.HTTPRouteRule{
+					{
+						BackendRefs: []gatewayv1.HTTPBackendRef{
+							{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "foo-svc-1"}}},
+							{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "foo-svc-2"}}},
+						},
+					},
+					{
+						BackendRefs: []gatewayv1.HTTPBackendRef{
+							{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "foo-svc-1"}}},
+						},
+					},
+				},
 			},
 		},
 		&gatewayv1.HTTPRoute{
@@ -280,17 +295,167 @@ ns2        bax-httproute-18777  None                               1
 
 	got2 := buff.String()
 	want2 := `
-NAMESPACE  NAME                 HOSTNAMES                          PARENT REFS  AGE  POLICIES
-default    foo-httproute-1      example.com,example2.com + 1 more  1            24h  1
-default    qmn-httproute-100    example.com                        2            11h  0
-ns1        bar-route-21         foo.com,bar.com + 5 more           1            9h   0
-ns2        bax-httproute-18777  None                               1            5m   0
+NAMESPACE  NAME                 HOSTNAMES                          PARENT REFS  AGE  POLICIES  BACKENDS  CONTROLLER                                                   STALE  MANAGERS
+default    foo-httproute-1      example.com,example2.com + 1 more  1            24h  1         2         example.net/other-controller                                 False  0
+default    qmn-httproute-100    example.com                        2            11h  0         0         example.net/gateway-controller,example.net/other-controller  False  0
+ns1        bar-route-21         foo.com,bar.com + 5 more           1            9h   0         0         example.net/other-controller                                 False  0
+ns2        bax-httproute-18777  None                               1            5m   0         0         example.net/other-controller                                 False  0
 `
 	if diff := cmp.Diff(common.YamlString(want2), common.YamlString(got2), common.YamlStringTransformer); diff != "" {
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got2, want2, diff)
 	}
 }
 
+func TestHTTPRoutesPrinter_PrintTable_TerminatingNamespaceAndDeletingRoute(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	deletionTimestamp := &metav1.Time{Time: fakeClock.Now().Add(-1 * time.Minute)}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "ns-old",
+				DeletionTimestamp: deletionTimestamp,
+				Finalizers:        []string{"kubernetes"},
+			},
+			Status: corev1.NamespaceStatus{
+				Phase: corev1.NamespaceTerminating,
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "active-route",
+				Namespace:         "default",
+				CreationTimestamp: metav1.Time{Time: fakeClock.Now().Add(-2 * time.Hour)},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "deleting-route",
+				Namespace:         "default",
+				CreationTimestamp: metav1.Time{Time: fakeClock.Now().Add(-2 * time.Hour)},
+				DeletionTimestamp: deletionTimestamp,
+				Finalizers:        []string{"example.net/finalizer"},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "old-route",
+				Namespace:         "ns-old",
+				CreationTimestamp: metav1.Time{Time: fakeClock.Now().Add(-3 * time.Hour)},
+				DeletionTimestamp: deletionTimestamp,
+				Finalizers:        []string{"example.net/finalizer"},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	buff := &bytes.Buffer{}
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	hp := &HTTPRoutesPrinter{
+		Writer: buff,
+		Clock:  fakeClock,
+	}
+	hp.PrintTable(resourceModel, false)
+
+	got := buff.String()
+	want := `
+NAMESPACE             NAME            HOSTNAMES  PARENT REFS  AGE
+default               active-route    None       0            120m
+default               deleting-route  None       0            120m (DELETING)
+ns-old (terminating)  old-route       None       0            3h (DELETING)
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+func TestHTTPRoutesPrinter_PrintTable_SortHostnames(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "route-1",
+				Namespace:         "default",
+				CreationTimestamp: metav1.Time{Time: fakeClock.Now().Add(-1 * time.Hour)},
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Hostnames: []gatewayv1.Hostname{"foo.example.com", "bar.example.com", "baz.example.com"},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "route-2",
+				Namespace:         "default",
+				CreationTimestamp: metav1.Time{Time: fakeClock.Now().Add(-1 * time.Hour)},
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Hostnames: []gatewayv1.Hostname{"baz.example.com", "foo.example.com", "bar.example.com"},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	testcases := []struct {
+		name          string
+		sortHostnames bool
+		want          string
+	}{
+		{
+			name: "spec order",
+			want: `
+NAMESPACE  NAME     HOSTNAMES                                 PARENT REFS  AGE
+default    route-1  foo.example.com,bar.example.com + 1 more  0            60m
+default    route-2  baz.example.com,foo.example.com + 1 more  0            60m
+`,
+		},
+		{
+			name:          "sorted",
+			sortHostnames: true,
+			want: `
+NAMESPACE  NAME     HOSTNAMES                                 PARENT REFS  AGE
+default    route-1  bar.example.com,baz.example.com + 1 more  0            60m
+default    route-2  bar.example.com,baz.example.com + 1 more  0            60m
+`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			hp := &HTTPRoutesPrinter{
+				Writer:        buff,
+				Clock:         fakeClock,
+				SortHostnames: tc.sortHostnames,
+			}
+			hp.PrintTable(resourceModel, false)
+
+			got := buff.String()
+			if diff := cmp.Diff(common.YamlString(tc.want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+				t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, tc.want, diff)
+			}
+		})
+	}
+}
+
 func TestHTTPRoutesPrinter_PrintDescribeView(t *testing.T) {
 	fakeClock := testingclock.NewFakeClock(time.Now())
 	objects := []runtime.Object{
@@ -470,6 +635,7 @@ func TestHTTPRoutesPrinter_PrintDescribeView(t *testing.T) {
 
 	got := buff.String()
 	want := `
+Summary: Attached to 1 gateway (1 pending), serving 0 hostnames, routing to 0 backends
 Name: foo-httproute
 ParentRefs:
 - group: gateway.networking.k8s.io
@@ -479,6 +645,10 @@ DirectlyAttachedPolicies:
 - Group: bar.com
   Kind: TimeoutPolicy
   Name: timeout-policy-httproute
+PolicySummary:
+  default/foo-gateway:
+  - 'HealthCheckPolicy.foo.com: overridden by health-check-gatewayclass'
+  - 'TimeoutPolicy.bar.com: applied (via timeout-policy-httproute)'
 EffectivePolicies:
   default/foo-gateway:
     HealthCheckPolicy.foo.com:
@@ -496,6 +666,474 @@ EffectivePolicies:
 	}
 }
 
+func TestHTTPRoutesPrinter_PrintDescribeView_Rules(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	mirrorFilter := func(name string) gatewayv1.HTTPRouteFilter {
+		return gatewayv1.HTTPRouteFilter{
+			Type: gatewayv1.HTTPRouteFilterRequestMirror,
+			RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+				BackendRef: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(name)},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Hostnames: []gatewayv1.Hostname{"example.com"},
+				Rules: []gatewayv1.HTTPRouteRule{
+					{
+						BackendRefs: []gatewayv1.HTTPBackendRef{
+							{BackendRef: gatewayv1.BackendRef{
+								BackendObjectReference: gatewayv1.BackendObjectReference{Name: "svc-a", Port: common.PtrTo(gatewayv1.PortNumber(80))},
+								Weight:                 common.PtrTo(int32(3)),
+							}},
+							{BackendRef: gatewayv1.BackendRef{
+								BackendObjectReference: gatewayv1.BackendObjectReference{Name: "svc-b", Port: common.PtrTo(gatewayv1.PortNumber(80))},
+							}},
+						},
+						Filters: []gatewayv1.HTTPRouteFilter{mirrorFilter("mirror-percent"), mirrorFilter("mirror-fraction")},
+					},
+					{
+						BackendRefs: []gatewayv1.HTTPBackendRef{
+							{BackendRef: gatewayv1.BackendRef{
+								BackendObjectReference: gatewayv1.BackendObjectReference{
+									Name:      "svc-c",
+									Namespace: common.PtrTo(gatewayv1.Namespace("bar")),
+									Port:      common.PtrTo(gatewayv1.PortNumber(8080)),
+								},
+							}},
+						},
+						Filters: []gatewayv1.HTTPRouteFilter{mirrorFilter("mirror-all"), mirrorFilter("mirror-invalid")},
+					},
+				},
+			},
+		},
+	}
+	for _, name := range []string{"default/svc-a", "default/svc-b", "bar/svc-c", "default/mirror-percent", "default/mirror-fraction", "default/mirror-all", "default/mirror-invalid"} {
+		namespace, name, _ := strings.Cut(name, "/")
+		objects = append(objects, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		})
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	buff := &bytes.Buffer{}
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(resourcediscovery.Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	// The sampling fields are not part of the HTTPRoute API version used in
+	// tests, so set the parsed samplings directly.
+	httpRouteNode := resourceModel.HTTPRoutes[resourcediscovery.HTTPRouteID("default", "foo-httproute")]
+	httpRouteNode.MirrorSamplings[resourcediscovery.MirrorFilterIndex{Rule: 0, Filter: 0}] = resourcediscovery.MirrorSampling{Numerator: 20, Denominator: 100}
+	httpRouteNode.MirrorSamplings[resourcediscovery.MirrorFilterIndex{Rule: 0, Filter: 1}] = resourcediscovery.MirrorSampling{Numerator: 1, Denominator: 8}
+	delete(httpRouteNode.MirrorSamplings, resourcediscovery.MirrorFilterIndex{Rule: 1, Filter: 1})
+	httpRouteNode.MirrorSamplingErrors[resourcediscovery.MirrorFilterIndex{Rule: 1, Filter: 1}] = fmt.Errorf("percent 120 must be between 0 and 100")
+
+	hp := &HTTPRoutesPrinter{
+		Writer: buff,
+		Clock:  fakeClock,
+	}
+	hp.PrintDescribeView(resourceModel)
+
+	got := buff.String()
+	want := `
+Summary: Attached to 0 gateways, serving 0 hostnames, routing to 3 backends
+Name: foo-httproute
+Namespace: default
+Hostnames:
+- 'example.com (not served: no accepting listener)'
+Rules:
+- Backends:
+  - Service default/svc-a:80 weight=3 (75%)
+  - Service default/svc-b:80 weight=1 (25%)
+  Mirrors:
+  - Service default/mirror-percent (20%)
+  - Service default/mirror-fraction (12.5%)
+- Backends:
+  - Service bar/svc-c:8080 weight=1 (100%)
+  Mirrors:
+  - Service default/mirror-all (100%)
+  - Service default/mirror-invalid (invalid)
+Analysis:
+- HTTPRoute "default/foo-httproute" is not permitted to reference  "bar/svc-c"
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+// TestHTTPRoutesPrinter_PrintDescribeView_MaxListItems tests that the rules
+// and the directly attached policies of the describe view are truncated, the
+// policies after sorting.
+func TestHTTPRoutesPrinter_PrintDescribeView_MaxListItems(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	httpRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-httproute",
+			Namespace: "default",
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"example.com"},
+		},
+	}
+	objects := []runtime.Object{
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "timeoutpolicies.bar.com",
+				Labels: map[string]string{
+					gatewayv1alpha2.PolicyLabelKey: "direct",
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    "bar.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "timeoutpolicies",
+					Kind:   "TimeoutPolicy",
+				},
+			},
+		},
+		httpRoute,
+	}
+	for i := 3; i >= 1; i-- {
+		name := fmt.Sprintf("svc-%d", i)
+		httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, gatewayv1.HTTPRouteRule{
+			BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(name), Port: common.PtrTo(gatewayv1.PortNumber(80))},
+			}}},
+		})
+		objects = append(objects,
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			},
+			&unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "bar.com/v1",
+					"kind":       "TimeoutPolicy",
+					"metadata": map[string]interface{}{
+						"name":      fmt.Sprintf("timeout-policy-%d", i),
+						"namespace": "default",
+					},
+					"spec": map[string]interface{}{
+						"targetRef": map[string]interface{}{
+							"group": "gateway.networking.k8s.io",
+							"kind":  "HTTPRoute",
+							"name":  "foo-httproute",
+						},
+					},
+				},
+			},
+		)
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(resourcediscovery.Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	testcases := []struct {
+		name         string
+		maxListItems int
+		want         string
+	}{
+		{
+			name:         "truncated",
+			maxListItems: 2,
+			want: `
+Summary: Attached to 0 gateways, serving 0 hostnames, routing to 3 backends
+Name: foo-httproute
+Namespace: default
+Hostnames:
+- 'example.com (not served: no accepting listener)'
+Rules:
+- Backends:
+  - Service default/svc-3:80 weight=1 (100%)
+- Backends:
+  - Service default/svc-2:80 weight=1 (100%)
+(… and 1 more, use --max-list-items=0 to show all)
+DirectlyAttachedPolicies:
+- Group: bar.com
+  Kind: TimeoutPolicy
+  Name: timeout-policy-1
+  Namespace: default
+- Group: bar.com
+  Kind: TimeoutPolicy
+  Name: timeout-policy-2
+  Namespace: default
+(… and 1 more, use --max-list-items=0 to show all)
+`,
+		},
+		{
+			name:         "untruncated",
+			maxListItems: 0,
+			want: `
+Summary: Attached to 0 gateways, serving 0 hostnames, routing to 3 backends
+Name: foo-httproute
+Namespace: default
+Hostnames:
+- 'example.com (not served: no accepting listener)'
+Rules:
+- Backends:
+  - Service default/svc-3:80 weight=1 (100%)
+- Backends:
+  - Service default/svc-2:80 weight=1 (100%)
+- Backends:
+  - Service default/svc-1:80 weight=1 (100%)
+DirectlyAttachedPolicies:
+- Group: bar.com
+  Kind: TimeoutPolicy
+  Name: timeout-policy-1
+  Namespace: default
+- Group: bar.com
+  Kind: TimeoutPolicy
+  Name: timeout-policy-2
+  Namespace: default
+- Group: bar.com
+  Kind: TimeoutPolicy
+  Name: timeout-policy-3
+  Namespace: default
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			hp := &HTTPRoutesPrinter{
+				Writer:       buff,
+				Clock:        fakeClock,
+				MaxListItems: tc.maxListItems,
+			}
+			hp.PrintDescribeView(resourceModel)
+
+			got := buff.String()
+			if diff := cmp.Diff(common.YamlString(tc.want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+				t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, tc.want, diff)
+			}
+		})
+	}
+}
+
+func TestHTTPRoutesPrinter_PrintDescribeView_EffectiveHostnames(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	accepted := func(gateway string) gatewayv1.RouteParentStatus {
+		return gatewayv1.RouteParentStatus{
+			ParentRef: gatewayv1.ParentReference{Name: gatewayv1.ObjectName(gateway)},
+			Conditions: []metav1.Condition{
+				{Type: string(gatewayv1.RouteConditionAccepted), Status: metav1.ConditionTrue},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-gateway",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				Listeners: []gatewayv1.Listener{
+					{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443, Hostname: common.PtrTo(gatewayv1.Hostname("*.example.com"))},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "reconciled-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "foo-gateway"}},
+				},
+				Hostnames: []gatewayv1.Hostname{"api.example.com", "example.com"},
+			},
+			Status: gatewayv1.HTTPRouteStatus{
+				RouteStatus: gatewayv1.RouteStatus{
+					Parents: []gatewayv1.RouteParentStatus{accepted("foo-gateway")},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "unreconciled-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "foo-gateway"}},
+				},
+				Hostnames: []gatewayv1.Hostname{"api.example.com", "example.com"},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	buff := &bytes.Buffer{}
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(resourcediscovery.Filter{Namespace: "default", Name: "reconciled-httproute"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+	hp := &HTTPRoutesPrinter{
+		Writer: buff,
+		Clock:  fakeClock,
+	}
+	hp.PrintDescribeView(resourceModel)
+
+	got := buff.String()
+	want := `
+Summary: Attached to 1 gateway (1 accepted), serving 1 hostname, routing to 0 backends
+Name: reconciled-httproute
+Namespace: default
+Hostnames:
+- api.example.com
+- 'example.com (not served: no accepting listener)'
+ListenerHostnames:
+  default/foo-gateway:
+  - 'https: api.example.com'
+ParentRefs:
+- name: foo-gateway
+PolicySummary: {}
+EffectivePolicies:
+  default/foo-gateway: {}
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+
+	buff.Reset()
+	resourceModel, err = discoverer.DiscoverResourcesForHTTPRoute(resourcediscovery.Filter{Namespace: "default", Name: "unreconciled-httproute"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+	hp.PrintDescribeView(resourceModel)
+
+	got = buff.String()
+	want = `
+Summary: Attached to 1 gateway (1 pending), serving 1 hostname, routing to 0 backends
+Name: unreconciled-httproute
+Namespace: default
+Hostnames:
+- 'api.example.com (unknown: not reconciled by the Gateway controller yet)'
+- 'example.com (unknown: not reconciled by the Gateway controller yet)'
+ListenerHostnames:
+  default/foo-gateway:
+  - 'https: api.example.com'
+ParentRefs:
+- name: foo-gateway
+PolicySummary: {}
+EffectivePolicies:
+  default/foo-gateway: {}
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+func TestHTTPRoutesPrinter_PrintDescribeView_ListenerTLS(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-gateway",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				Listeners: []gatewayv1.Listener{
+					{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+					{
+						Name:     "https",
+						Protocol: gatewayv1.HTTPSProtocolType,
+						Port:     443,
+						TLS: &gatewayv1.GatewayTLSConfig{
+							CertificateRefs: []gatewayv1.SecretObjectReference{
+								{Name: "foo-cert"},
+								{Name: "shared-cert", Namespace: common.PtrTo(gatewayv1.Namespace("certs"))},
+							},
+						},
+					},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "foo-gateway"}},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	buff := &bytes.Buffer{}
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(resourcediscovery.Filter{Namespace: "default", Name: "foo-httproute"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+	hp := &HTTPRoutesPrinter{
+		Writer: buff,
+		Clock:  fakeClock,
+	}
+	hp.PrintDescribeView(resourceModel)
+
+	got := buff.String()
+	want := `
+Summary: Attached to 1 gateway (1 pending), serving 1 hostname, routing to 0 backends
+Name: foo-httproute
+Namespace: default
+ListenerHostnames:
+  default/foo-gateway:
+  - 'http: *'
+  - 'https: *'
+ListenerTLS:
+  default/foo-gateway:
+  - CertificateRefs:
+    - Secret default/foo-cert
+    - Secret certs/shared-cert
+    Listener: https
+    Mode: Terminate
+ParentRefs:
+- name: foo-gateway
+PolicySummary: {}
+EffectivePolicies:
+  default/foo-gateway: {}
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
 // TestHTTPRoutesPrinter_PrintJsonYaml tests the correctness of JSON/YAML output associated with -o json/yaml of `get` subcommand
 func TestHTTPRoutesPrinter_PrintJsonYaml(t *testing.T) {
 	fakeClock := testingclock.NewFakeClock(time.Now())
@@ -642,3 +1280,84 @@ kind: List`, creationTime.Format(time.RFC3339)))
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", gotYaml, wantYaml, diff)
 	}
 }
+
+func TestHTTPRoutesPrinter_PrintConditions(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	edgeGatewayRef := gatewayv1.ParentReference{
+		Name:        "edge-gw",
+		Namespace:   common.PtrTo(gatewayv1.Namespace("infra")),
+		SectionName: common.PtrTo(gatewayv1.SectionName("https")),
+	}
+	objects := []runtime.Object{
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{edgeGatewayRef, {Name: "internal-gw"}},
+				},
+			},
+			Status: gatewayv1.HTTPRouteStatus{
+				RouteStatus: gatewayv1.RouteStatus{
+					Parents: []gatewayv1.RouteParentStatus{
+						{
+							ParentRef: edgeGatewayRef,
+							Conditions: []metav1.Condition{
+								{Type: "Accepted", Status: metav1.ConditionTrue, Reason: "Accepted", LastTransitionTime: metav1.Time{Time: fakeClock.Now().Add(-3 * time.Hour)}},
+								{Type: "ResolvedRefs", Status: metav1.ConditionFalse, Reason: "BackendNotFound", LastTransitionTime: metav1.Time{Time: fakeClock.Now().Add(-5 * time.Minute)}},
+							},
+						},
+						{
+							ParentRef: gatewayv1.ParentReference{Name: "internal-gw"},
+							Conditions: []metav1.Condition{
+								{Type: "Accepted", Status: metav1.ConditionFalse, Reason: "NotAllowedByListeners"},
+							},
+						},
+					},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bar-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "internal-gw"}},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(resourcediscovery.Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	buff := &bytes.Buffer{}
+	hp := &HTTPRoutesPrinter{
+		Writer: buff,
+		Clock:  fakeClock,
+	}
+	hp.PrintConditions(resourceModel)
+
+	got := buff.String()
+	want := `
+ROUTE                  PARENT                       TYPE          STATUS  REASON                 AGE
+default/foo-httproute  gateway/infra/edge-gw:https  Accepted      True    Accepted               3h
+default/foo-httproute  gateway/infra/edge-gw:https  ResolvedRefs  False   BackendNotFound        5m
+default/foo-httproute  gateway/default/internal-gw  Accepted      False   NotAllowedByListeners  <unknown>
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
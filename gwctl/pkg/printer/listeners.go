@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+type ListenersPrinter struct {
+	io.Writer
+	// AnnotationColumns are additional columns of the table, holding the
+	// values of annotations of the Gateway of each listener.
+	AnnotationColumns []common.AnnotationColumn
+}
+
+func (lp *ListenersPrinter) Print(resourceModel *resourcediscovery.ResourceModel, format utils.OutputFormat) {
+	listeners := resourcediscovery.ListenersForGateways(resourceModel)
+
+	switch format {
+	case utils.OutputFormatJSON, utils.OutputFormatYAML:
+		output, err := utils.MarshalWithFormat(listeners, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(lp, string(output))
+	case utils.OutputFormatTable, utils.OutputFormatWide:
+		lp.printTable(resourceModel, listeners)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output format '%s' found\n", format)
+		os.Exit(1)
+	}
+}
+
+func (lp *ListenersPrinter) printTable(resourceModel *resourcediscovery.ResourceModel, listeners []resourcediscovery.ListenerView) {
+	columnNames := []string{"GATEWAY", "LISTENER NAME", "PORT", "PROTOCOL", "HOSTNAME", "ATTACHED ROUTES", "PROGRAMMED"}
+	table := &Table{
+		ColumnNames:  append(columnNames, annotationColumnNames(lp.AnnotationColumns)...),
+		UseSeparator: false,
+	}
+	for _, listener := range listeners {
+		hostname := listener.Hostname
+		if hostname == "" {
+			hostname = "*"
+		}
+		row := []string{
+			fmt.Sprintf("%v/%v", listener.Gateway.Namespace, listener.Gateway.Name),
+			listener.Name,
+			fmt.Sprintf("%d", listener.Port),
+			listener.Protocol,
+			hostname,
+			fmt.Sprintf("%d", listener.AttachedRoutes),
+			listener.Programmed,
+		}
+		row = append(row, gatewayAnnotationColumnValues(resourceModel, listener.Gateway, lp.AnnotationColumns)...)
+		table.Rows = append(table.Rows, row)
+	}
+	table.Write(lp, 0)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestListenersPrinter_Print(t *testing.T) {
+	objects := []runtime.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-gateway",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+					{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: common.PtrTo(gatewayv1.Hostname("foo.com"))},
+				},
+			},
+			Status: gatewayv1.GatewayStatus{
+				Listeners: []gatewayv1.ListenerStatus{
+					{
+						Name:           "https",
+						AttachedRoutes: 2,
+						Conditions: []metav1.Condition{
+							{Type: "Accepted", Status: metav1.ConditionTrue},
+							{Type: "Programmed", Status: metav1.ConditionFalse},
+						},
+					},
+					{
+						Name:           "http",
+						AttachedRoutes: 1,
+						Conditions: []metav1.Condition{
+							{Type: "Programmed", Status: metav1.ConditionTrue},
+						},
+					},
+				},
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bar-gateway",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{Name: "tcp", Port: 9000, Protocol: gatewayv1.TCPProtocolType},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	buff := &bytes.Buffer{}
+	lp := &ListenersPrinter{Writer: buff}
+	lp.Print(resourceModel, utils.OutputFormatTable)
+
+	got := buff.String()
+	want := `
+GATEWAY              LISTENER NAME  PORT  PROTOCOL  HOSTNAME  ATTACHED ROUTES  PROGRAMMED
+default/bar-gateway  tcp            9000  TCP       *         0                Unknown
+default/foo-gateway  http           80    HTTP      *         1                True
+default/foo-gateway  https          443   HTTPS     foo.com   2                False
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
@@ -22,9 +22,9 @@ import (
 	"io"
 
 	"golang.org/x/exp/maps"
-	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/utils/clock"
 
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
 )
 
@@ -32,8 +32,13 @@ var _ Printer = (*NamespacesPrinter)(nil)
 
 type NamespacesPrinter struct {
 	io.Writer
-	Clock        clock.Clock
-	EventFetcher eventFetcher
+	Clock             clock.Clock
+	EventFetcher      eventFetcher
+	EventLimit        int
+	MaxListItems      int
+	LabelColumns      []string
+	AnnotationColumns []common.AnnotationColumn
+	Highlighter       *Highlighter
 }
 
 func (nsp *NamespacesPrinter) GetPrintableNodes(resourceModel *resourcediscovery.ResourceModel) []NodeResource {
@@ -49,15 +54,15 @@ func (nsp *NamespacesPrinter) PrintTable(resourceModel *resourcediscovery.Resour
 	}
 
 	table := &Table{
-		ColumnNames:  columnNames,
+		ColumnNames:  append(append(columnNames, labelColumnNames(nsp.LabelColumns)...), annotationColumnNames(nsp.AnnotationColumns)...),
 		UseSeparator: false,
 	}
 
 	namespaceNodes := maps.Values(resourceModel.Namespaces)
 	for _, namespaceNode := range SortByString(namespaceNodes) {
-		age := duration.HumanDuration(nsp.Clock.Since(namespaceNode.Namespace.CreationTimestamp.Time))
+		age := formatAge(nsp.Clock, namespaceNode.Namespace)
 		row := []string{
-			namespaceNode.Namespace.Name,
+			formatNamespace(namespaceNode.Namespace.Name, namespaceNode),
 			string(namespaceNode.Namespace.Status.Phase),
 			age,
 		}
@@ -65,7 +70,10 @@ func (nsp *NamespacesPrinter) PrintTable(resourceModel *resourcediscovery.Resour
 			policiesCount := fmt.Sprintf("%d", len(namespaceNode.Policies))
 			row = append(row, policiesCount)
 		}
+		row = append(row, labelColumnValues(namespaceNode.Namespace, nsp.LabelColumns)...)
+		row = append(row, annotationColumnValues(namespaceNode.Namespace, nsp.AnnotationColumns)...)
 		table.Rows = append(table.Rows, row)
+		nsp.Highlighter.highlightLastRow(table, nsp.Clock, namespaceNode.Namespace)
 	}
 
 	table.Write(nsp, 0)
@@ -76,6 +84,9 @@ func (nsp *NamespacesPrinter) PrintDescribeView(resourceModel *resourcediscovery
 	index := 0
 	for _, namespaceNode := range SortByString(namespaceNodes) {
 		index++
+		if index > 1 {
+			writeDescribeSeparator(nsp, "Namespace", namespaceNode.Namespace)
+		}
 
 		metadata := namespaceNode.Namespace.ObjectMeta.DeepCopy()
 		metadata.Labels = nil
@@ -85,7 +96,7 @@ func (nsp *NamespacesPrinter) PrintDescribeView(resourceModel *resourcediscovery
 		metadata.ManagedFields = nil
 
 		pairs := []*DescriberKV{
-			{Key: "Name", Value: namespaceNode.Namespace.GetName()},
+			{Key: "Name", Value: formatNamespace(namespaceNode.Namespace.GetName(), namespaceNode)},
 			{Key: "Labels", Value: namespaceNode.Namespace.Labels},
 			{Key: "Annotations", Value: namespaceNode.Namespace.Annotations},
 			{Key: "Status", Value: &namespaceNode.Namespace.Status},
@@ -97,12 +108,8 @@ func (nsp *NamespacesPrinter) PrintDescribeView(resourceModel *resourcediscovery
 
 		// Events
 		eventList := nsp.EventFetcher.FetchEventsFor(context.Background(), namespaceNode.Namespace)
-		pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(eventList.Items, nsp.Clock)})
-
-		Describe(nsp, pairs)
+		pairs = append(pairs, &DescriberKV{Key: "Events", Value: convertEventsSliceToTable(mostRecentEvents(eventList.Items, nsp.EventLimit), nsp.Clock)})
 
-		if index+1 <= len(resourceModel.Namespaces) {
-			fmt.Fprintf(nsp, "\n\n")
-		}
+		Describe(nsp, truncateTables(pairs, nsp.MaxListItems))
 	}
 }
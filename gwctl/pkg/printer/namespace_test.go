@@ -130,10 +130,10 @@ func TestNamespacePrinter_PrintTable(t *testing.T) {
 
 	got := buff.String()
 	want := `
-NAME         STATUS       AGE
-default      Active       46d
-kube-system  Active       46d
-ns1          Terminating  10m
+NAME               STATUS       AGE
+default            Active       46d
+kube-system        Active       46d
+ns1 (terminating)  Terminating  10m
 `
 	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
@@ -148,10 +148,10 @@ ns1          Terminating  10m
 
 	got2 := buff.String()
 	want2 := `
-NAME         STATUS       AGE  POLICIES
-default      Active       46d  0
-kube-system  Active       46d  0
-ns1          Terminating  10m  1
+NAME               STATUS       AGE  POLICIES
+default            Active       46d  0
+kube-system        Active       46d  0
+ns1 (terminating)  Terminating  10m  1
 `
 	if diff := cmp.Diff(common.YamlString(want2), common.YamlString(got2), common.YamlStringTransformer); diff != "" {
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got2, want2, diff)
@@ -303,7 +303,7 @@ DirectlyAttachedPolicies:
   HealthCheckPolicy.foo.com  health-check-gatewayclass
 Events: <none>
 
-
+------ Namespace production
 Name: production
 Labels:
   type: production-namespace
@@ -428,3 +428,62 @@ kind: List
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", gotYaml, wantYaml, diff)
 	}
 }
+
+// TestNamespacesPrinter_PrintDescribe_Yaml tests that -o yaml of the
+// `describe` subcommand prints one YAML document per resource.
+func TestNamespacesPrinter_PrintDescribe_Yaml(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	creationTime := fakeClock.Now().Add(-46 * 24 * time.Hour).UTC()
+
+	objects := []runtime.Object{
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "namespace-1",
+				CreationTimestamp: metav1.Time{Time: creationTime},
+			},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "namespace-2",
+				CreationTimestamp: metav1.Time{Time: creationTime},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForNamespace(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", resourceModel)
+	}
+
+	buff := &bytes.Buffer{}
+	nsp := &NamespacesPrinter{
+		Writer: buff,
+		Clock:  fakeClock,
+	}
+	PrintDescribe(nsp, resourceModel, utils.OutputFormatYAML)
+
+	got := buff.String()
+	want := fmt.Sprintf(`metadata:
+  creationTimestamp: "%[1]s"
+  name: namespace-1
+  resourceVersion: "999"
+spec: {}
+status: {}
+---
+metadata:
+  creationTimestamp: "%[1]s"
+  name: namespace-2
+  resourceVersion: "999"
+spec: {}
+status: {}
+`, creationTime.Format(time.RFC3339))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
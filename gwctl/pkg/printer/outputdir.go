@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// DescribeToDir writes the describe view of each resource of the kind to its
+// own file in dir, named <namespace>_<name>.txt, or <name>.txt for
+// cluster-scoped resources, with the extension .yaml for the YAML format.
+// Existing files are replaced atomically.
+//
+// newPrinter returns the printer writing to a single file. The resources are
+// described by up to concurrency printers at the same time, which fetch the
+// events of their resource when it is described. DescribeToDir returns the
+// number of files written, and an error for each resource which could not be
+// written.
+func DescribeToDir(newPrinter func(io.Writer) DescribePrinter, kind string, resourceModel *resourcediscovery.ResourceModel, format utils.OutputFormat, dir string, concurrency int) (int, []error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, []error{err}
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	objects := ClientObjects(SortByString(newPrinter(io.Discard).GetPrintableNodes(resourceModel)))
+	errs := make([]error, len(objects))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, object := range objects {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, object client.Object) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			var buf bytes.Buffer
+			PrintDescribe(newPrinter(&buf), resourceModel.Single(kind, object), format)
+			path := filepath.Join(dir, describeFileName(object, format))
+			if err := writeFileAtomically(path, buf.Bytes()); err != nil {
+				errs[i] = fmt.Errorf("failed to write %v %v: %w", kind, client.ObjectKeyFromObject(object), err)
+			}
+		}(i, object)
+	}
+	wg.Wait()
+
+	var result []error
+	for _, err := range errs {
+		if err != nil {
+			result = append(result, err)
+		}
+	}
+	return len(objects) - len(result), result
+}
+
+// describeFileName returns the name of the file to which DescribeToDir writes
+// the describe view of the object.
+func describeFileName(object client.Object, format utils.OutputFormat) string {
+	name := object.GetName()
+	if object.GetNamespace() != "" {
+		name = object.GetNamespace() + "_" + name
+	}
+	if format == utils.OutputFormatYAML {
+		return name + ".yaml"
+	}
+	return name + ".txt"
+}
+
+// writeFileAtomically writes the data to a temporary file in the directory of
+// path, which is then renamed to path, so that readers never see a partially
+// written file.
+func writeFileAtomically(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Chmod(0o644); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
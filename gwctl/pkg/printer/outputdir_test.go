@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testingclock "k8s.io/utils/clock/testing"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestDescribeToDir(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "example.net/gateway-controller",
+			},
+		},
+		common.NamespaceForTest("default"),
+		common.NamespaceForTest("other"),
+	}
+	for _, key := range []struct{ namespace, name string }{{"default", "foo-gateway"}, {"default", "bar-gateway"}, {"other", "foo-gateway"}} {
+		objects = append(objects, &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.name,
+				Namespace: key.namespace,
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+			},
+		})
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(resourcediscovery.Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+	newPrinter := func(w io.Writer) DescribePrinter {
+		return &GatewaysPrinter{Writer: w, Clock: fakeClock, EventFetcher: discoverer}
+	}
+
+	testcases := []struct {
+		name      string
+		format    utils.OutputFormat
+		wantFiles []string
+	}{
+		{
+			name:      "table",
+			format:    utils.OutputFormatTable,
+			wantFiles: []string{"default_bar-gateway.txt", "default_foo-gateway.txt", "other_foo-gateway.txt"},
+		},
+		{
+			name:      "yaml",
+			format:    utils.OutputFormatYAML,
+			wantFiles: []string{"default_bar-gateway.yaml", "default_foo-gateway.yaml", "other_foo-gateway.yaml"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "archive")
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			// An existing file is replaced.
+			if err := os.WriteFile(filepath.Join(dir, tc.wantFiles[0]), []byte("stale"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			written, errs := DescribeToDir(newPrinter, "Gateway", resourceModel, tc.format, dir, 2)
+			if written != len(tc.wantFiles) || len(errs) != 0 {
+				t.Fatalf("DescribeToDir() = %v, %v; want %v, no errors", written, errs, len(tc.wantFiles))
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotFiles []string
+			for _, entry := range entries {
+				gotFiles = append(gotFiles, entry.Name())
+			}
+			if diff := cmp.Diff(tc.wantFiles, gotFiles); diff != "" {
+				t.Fatalf("Unexpected files (-want +got):\n%v", diff)
+			}
+
+			for _, file := range tc.wantFiles {
+				data, err := os.ReadFile(filepath.Join(dir, file))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := string(data)
+				namespace, name, _ := strings.Cut(strings.TrimSuffix(strings.TrimSuffix(file, ".txt"), ".yaml"), "_")
+				if !strings.Contains(got, "name: "+name) && !strings.Contains(got, "Name: "+name) {
+					t.Errorf("%v does not describe %v/%v:\n%v", file, namespace, name, got)
+				}
+				if !strings.Contains(got, namespace) {
+					t.Errorf("%v does not describe %v/%v:\n%v", file, namespace, name, got)
+				}
+				if strings.Contains(got, DescribeSeparator) || strings.Contains(got, YAMLDocumentSeparator) {
+					t.Errorf("%v describes more than one resource:\n%v", file, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// PermissionsPrinter prints the results of checking the permissions needed by
+// gwctl.
+type PermissionsPrinter struct {
+	io.Writer
+}
+
+// Print prints the checks, followed by the RBAC resources granting the denied
+// permissions for the table format. namespace is the namespace in which
+// namespaced resources were checked, or empty for all namespaces.
+func (pp *PermissionsPrinter) Print(checks []common.PermissionCheck, namespace string, format utils.OutputFormat) {
+	switch format {
+	case utils.OutputFormatJSON, utils.OutputFormatYAML:
+		output, err := utils.MarshalWithFormat(checks, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(pp, string(output))
+	case utils.OutputFormatTable, utils.OutputFormatWide:
+		pp.printTable(checks)
+		if missing := common.MissingPermissionsRBAC(checks, namespace); len(missing) != 0 {
+			fmt.Fprintf(pp, "\nThe denied permissions can be granted with:\n\n")
+			printYAMLDocuments(pp, missing)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output format '%s' found\n", format)
+		os.Exit(1)
+	}
+}
+
+func (pp *PermissionsPrinter) printTable(checks []common.PermissionCheck) {
+	table := &Table{
+		ColumnNames:  []string{"RESOURCE", "VERB", "NAMESPACE", "ALLOWED", "NEEDED FOR"},
+		UseSeparator: false,
+	}
+	for _, check := range checks {
+		resource := check.Resource
+		if check.Group != "" {
+			resource = fmt.Sprintf("%v.%v", check.Resource, check.Group)
+		}
+		namespace := check.Namespace
+		if namespace == "" {
+			namespace = "*"
+		}
+		allowed := "yes"
+		if !check.Allowed {
+			allowed = "no"
+		}
+		row := []string{
+			resource,
+			check.Verb,
+			namespace,
+			allowed,
+			check.Permission.Feature,
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	table.Write(pp, 0)
+}
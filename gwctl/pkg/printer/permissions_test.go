@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestPermissionsPrinter_Print(t *testing.T) {
+	// The user may read everything except Secrets, Events and the
+	// experimental route kinds.
+	denied := map[string]bool{
+		"secrets":   true,
+		"events":    true,
+		"tcproutes": true,
+		"tlsroutes": true,
+		"udproutes": true,
+	}
+	fakeClient := fakeclient.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			review := obj.(*authorizationv1.SelfSubjectAccessReview)
+			review.Status.Allowed = !denied[review.Spec.ResourceAttributes.Resource]
+			return nil
+		},
+	}).Build()
+
+	checks, err := common.CheckPermissions(context.Background(), fakeClient, "team-a")
+	if err != nil {
+		t.Fatalf("Failed to check permissions: %v", err)
+	}
+
+	buff := &bytes.Buffer{}
+	pp := &PermissionsPrinter{Writer: buff}
+	pp.Print(checks, "team-a", utils.OutputFormatTable)
+
+	got := buff.String()
+	want := `
+RESOURCE                                        VERB  NAMESPACE  ALLOWED  NEEDED FOR
+gatewayclasses.gateway.networking.k8s.io        get   *          yes      GatewayClasses
+gatewayclasses.gateway.networking.k8s.io        list  *          yes      GatewayClasses
+gateways.gateway.networking.k8s.io              get   team-a     yes      Gateways
+gateways.gateway.networking.k8s.io              list  team-a     yes      Gateways
+httproutes.gateway.networking.k8s.io            get   team-a     yes      HTTPRoutes
+httproutes.gateway.networking.k8s.io            list  *          yes      HTTPRoutes
+grpcroutes.gateway.networking.k8s.io            list  *          yes      routes attached to Gateways
+tcproutes.gateway.networking.k8s.io             list  *          no       routes attached to Gateways
+tlsroutes.gateway.networking.k8s.io             list  *          no       routes attached to Gateways
+udproutes.gateway.networking.k8s.io             list  *          no       routes attached to Gateways
+referencegrants.gateway.networking.k8s.io       get   team-a     yes      cross namespace references
+referencegrants.gateway.networking.k8s.io       list  *          yes      cross namespace references
+backendtlspolicies.gateway.networking.k8s.io    list  team-a     yes      TLS configuration of Backends
+backendlbpolicies.gateway.networking.k8s.io     list  team-a     yes      load balancing configuration of Backends
+services                                        get   team-a     yes      Backends
+services                                        list  team-a     yes      Backends
+endpointslices.discovery.k8s.io                 list  team-a     yes      readiness of Backends
+ingresses.networking.k8s.io                     list  *          yes      hostname collisions with Ingresses
+namespaces                                      get   *          yes      Namespaces
+namespaces                                      list  *          yes      Namespaces
+events                                          list  *          no       events of described resources
+secrets                                         get   team-a     no       certificates of Gateway listeners
+customresourcedefinitions.apiextensions.k8s.io  list  *          yes      Policy CRDs
+
+The denied permissions can be granted with:
+
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  creationTimestamp: null
+  name: gwctl-reader
+rules:
+- apiGroups:
+  - gateway.networking.k8s.io
+  resources:
+  - tcproutes
+  verbs:
+  - list
+- apiGroups:
+  - gateway.networking.k8s.io
+  resources:
+  - tlsroutes
+  verbs:
+  - list
+- apiGroups:
+  - gateway.networking.k8s.io
+  resources:
+  - udproutes
+  verbs:
+  - list
+- apiGroups:
+  - ""
+  resources:
+  - events
+  verbs:
+  - list
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  creationTimestamp: null
+  name: gwctl-reader
+  namespace: team-a
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - secrets
+  verbs:
+  - get
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
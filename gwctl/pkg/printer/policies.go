@@ -17,25 +17,51 @@ limitations under the License.
 package printer
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"sort"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
 )
 
 type PoliciesPrinter struct {
 	io.Writer
 	Clock clock.Clock
+	// TargetFetcher is used to resolve the targets of policies, to show whether
+	// they exist and to predict which fields of the target a Direct policy
+	// would change. Neither is shown if this is nil.
+	TargetFetcher policyTargetFetcher
+	// Effectiveness is the Effectiveness of each policy keyed by its Name,
+	// which is shown as an additional column of the wide table if it is set.
+	Effectiveness map[string]policymanager.Effectiveness
+	// MaxListItems is the maximum number of rows printed for each repeating
+	// section of the describe view, like the ancestors. All rows are printed
+	// if it is 0.
+	MaxListItems int
+	// PolicyCRDs are the CRDs of the policies, whose labels classify the
+	// policies as inherited or direct.
+	PolicyCRDs []policymanager.PolicyCRD
+	// AnnotationColumns are additional columns of the tables of policies and
+	// policy CRDs, holding the values of annotations of each resource.
+	AnnotationColumns []common.AnnotationColumn
 }
 
 func (pp *PoliciesPrinter) printClientObjects(objects []client.Object, format utils.OutputFormat) {
@@ -52,21 +78,27 @@ func (pp *PoliciesPrinter) printClientObjects(objects []client.Object, format ut
 	fmt.Fprint(pp, string(output))
 }
 
-func (pp *PoliciesPrinter) printPoliciesTable(sortedPoliciesList []policymanager.Policy) {
+func (pp *PoliciesPrinter) printPoliciesTable(sortedPoliciesList []policymanager.Policy, wide bool) {
+	columnNames := []string{"NAME", "KIND", "TARGET NAME", "TARGET KIND", "POLICY TYPE", "AGE"}
+	if pp.TargetFetcher != nil {
+		columnNames = append(columnNames, "TARGET AGE")
+	}
+	showEffectiveness := wide && pp.Effectiveness != nil
+	if showEffectiveness {
+		columnNames = append(columnNames, "EFFECTIVE")
+	}
+	columnNames = append(columnNames, annotationColumnNames(pp.AnnotationColumns)...)
 	table := &Table{
-		ColumnNames:  []string{"NAME", "KIND", "TARGET NAME", "TARGET KIND", "POLICY TYPE", "AGE"},
+		ColumnNames:  columnNames,
 		UseSeparator: false,
 	}
 
 	for _, policy := range sortedPoliciesList {
-		policyType := "Direct"
-		if policy.IsInherited() {
-			policyType = "Inherited"
-		}
+		policyType := pp.policyType(policy)
 
 		kind := fmt.Sprintf("%v.%v", policy.Unstructured().GroupVersionKind().Kind, policy.Unstructured().GroupVersionKind().Group)
 
-		age := duration.HumanDuration(pp.Clock.Since(policy.Unstructured().GetCreationTimestamp().Time))
+		age := formatAge(pp.Clock, policy.Unstructured())
 
 		row := []string{
 			policy.Unstructured().GetName(),
@@ -76,11 +108,32 @@ func (pp *PoliciesPrinter) printPoliciesTable(sortedPoliciesList []policymanager
 			policyType,
 			age,
 		}
+		if pp.TargetFetcher != nil {
+			_, targetStatus := pp.resolveTarget(policy)
+			row = append(row, targetStatus)
+		}
+		if showEffectiveness {
+			effectiveness, ok := pp.Effectiveness[policy.Name()]
+			if !ok {
+				effectiveness = "Unknown"
+			}
+			row = append(row, string(effectiveness))
+		}
+		row = append(row, annotationColumnValues(policy.Unstructured(), pp.AnnotationColumns)...)
 		table.Rows = append(table.Rows, row)
 	}
 	table.Write(pp, 0)
 }
 
+// policyType returns Inherited or Direct for the policy, as classified by the
+// labels of the PolicyCRDs. Policies of CRDs labeled as neither are Direct.
+func (pp *PoliciesPrinter) policyType(policy policymanager.Policy) string {
+	if len(relations.FindInheritedPolicies([]policymanager.Policy{policy}, pp.PolicyCRDs)) != 0 {
+		return "Inherited"
+	}
+	return "Direct"
+}
+
 func (pp *PoliciesPrinter) PrintPolicies(policies []policymanager.Policy, format utils.OutputFormat) {
 	sortedPolicies := SortByString(policies)
 	clientObjects := ClientObjects(sortedPolicies)
@@ -89,7 +142,13 @@ func (pp *PoliciesPrinter) PrintPolicies(policies []policymanager.Policy, format
 	case utils.OutputFormatJSON, utils.OutputFormatYAML:
 		pp.printClientObjects(clientObjects, format)
 	case utils.OutputFormatTable, utils.OutputFormatWide:
-		pp.printPoliciesTable(sortedPolicies)
+		pp.printPoliciesTable(sortedPolicies, format == utils.OutputFormatWide)
+	case utils.OutputFormatName:
+		for _, obj := range clientObjects {
+			fmt.Fprintln(pp, resourceName(obj))
+		}
+	case utils.OutputFormatHelmValues:
+		printHelmValues(pp, clientObjects)
 	default:
 		fmt.Fprintf(os.Stderr, "unknown output format '%s' found\n", format)
 		os.Exit(1)
@@ -98,7 +157,7 @@ func (pp *PoliciesPrinter) PrintPolicies(policies []policymanager.Policy, format
 
 func (pp *PoliciesPrinter) printCRDsTable(sortedPolicyCRDsList []policymanager.PolicyCRD) {
 	table := &Table{
-		ColumnNames:  []string{"NAME", "POLICY TYPE", "SCOPE", "AGE"},
+		ColumnNames:  append([]string{"NAME", "POLICY TYPE", "SCOPE", "AGE"}, annotationColumnNames(pp.AnnotationColumns)...),
 		UseSeparator: false,
 	}
 
@@ -116,6 +175,7 @@ func (pp *PoliciesPrinter) printCRDsTable(sortedPolicyCRDsList []policymanager.P
 			string(policyCRD.CRD().Spec.Scope),
 			age,
 		}
+		row = append(row, annotationColumnValues(policyCRD.CRD(), pp.AnnotationColumns)...)
 		table.Rows = append(table.Rows, row)
 	}
 
@@ -137,6 +197,28 @@ func (pp *PoliciesPrinter) PrintCRDs(policyCRDs []policymanager.PolicyCRD, forma
 	}
 }
 
+// PrintPoliciesDescribe prints the describe view of the policies for the
+// table format. For the YAML format, it prints one YAML document per policy
+// with the same fields as the describe view, including those computed by gwctl
+// like the resolved target, unlike `get -o yaml` which prints the policy
+// objects as they are.
+func (pp *PoliciesPrinter) PrintPoliciesDescribe(policies []policymanager.Policy, format utils.OutputFormat) {
+	switch format {
+	case utils.OutputFormatTable:
+		pp.PrintPoliciesDescribeView(policies)
+	case utils.OutputFormatYAML:
+		for i, policy := range SortByString(policies) {
+			if i > 0 {
+				fmt.Fprintln(pp, YAMLDocumentSeparator)
+			}
+			pp.writeYAML(pp.describePolicy(policy))
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output format '%s' found\n", format)
+		os.Exit(1)
+	}
+}
+
 type policyDescribeView struct {
 	Name      string                 `json:",omitempty"`
 	Namespace string                 `json:",omitempty"`
@@ -144,42 +226,207 @@ type policyDescribeView struct {
 	Kind      string                 `json:",omitempty"`
 	Inherited string                 `json:",omitempty"`
 	Spec      map[string]interface{} `json:",omitempty"`
+	// Target is the resolved target of the policy along with its age, or a
+	// "(missing)" marker if it does not exist.
+	Target string `json:",omitempty"`
+	// PredictedChanges lists the fields of the target which the policy is
+	// predicted to change, in the format "path: current -> new".
+	PredictedChanges []string `json:",omitempty"`
+	// Ancestors lists whether each ancestor of the policy accepted it, as
+	// reported in its status.
+	Ancestors []policyAncestor `json:",omitempty"`
+}
+
+// policyAncestor is the acceptance of a policy by one of its ancestors.
+type policyAncestor struct {
+	Ancestor string
+	Accepted metav1.ConditionStatus
+	Reason   string `json:",omitempty"`
+}
+
+// describePolicy returns the describe view of the policy with every field set.
+func (pp *PoliciesPrinter) describePolicy(policy policymanager.Policy) policyDescribeView {
+	view := policyDescribeView{
+		Name:      policy.Unstructured().GetName(),
+		Namespace: policy.Unstructured().GetNamespace(),
+		Group:     policy.Unstructured().GroupVersionKind().Group,
+		Kind:      policy.Unstructured().GroupVersionKind().Kind,
+		Inherited: fmt.Sprintf("%v", pp.policyType(policy) == "Inherited"),
+		Spec:      policy.Spec(),
+	}
+	if pp.TargetFetcher != nil {
+		target, targetStatus := pp.resolveTarget(policy)
+		if target != nil {
+			targetStatus = fmt.Sprintf("(age %v)", targetStatus)
+		}
+		view.Target = fmt.Sprintf("%v %v %v", policy.TargetRef().Kind, policyTargetName(policy, target), targetStatus)
+		view.PredictedChanges = pp.predictedChanges(policy, target)
+	}
+	view.Ancestors = policyAncestors(policy)
+	return view
+}
+
+// policyAncestors returns the acceptance of the policy by each ancestor in its
+// status. Ancestors which have not reported an Accepted condition are
+// reported with the status Unknown.
+func policyAncestors(policy policymanager.Policy) []policyAncestor {
+	var result []policyAncestor
+	for _, ancestorStatus := range policy.Ancestors() {
+		ancestor := policyAncestor{
+			Ancestor: formatPolicyAncestorRef(ancestorStatus.AncestorRef, policy.Unstructured().GetNamespace()),
+			Accepted: metav1.ConditionUnknown,
+		}
+		if condition := meta.FindStatusCondition(ancestorStatus.Conditions, string(gatewayv1alpha2.PolicyConditionAccepted)); condition != nil {
+			ancestor.Accepted = condition.Status
+			ancestor.Reason = condition.Reason
+		}
+		result = append(result, ancestor)
+	}
+	return result
+}
+
+// formatPolicyAncestorRef returns the kind and namespaced name of the ancestor,
+// along with its section, if any. The ancestor defaults to a Gateway in the
+// namespace of the policy, like a parentRef.
+func formatPolicyAncestorRef(ref gatewayv1.ParentReference, policyNamespace string) string {
+	kind := "Gateway"
+	if ref.Kind != nil {
+		kind = string(*ref.Kind)
+	}
+	name := string(ref.Name)
+	switch {
+	case ref.Namespace != nil && *ref.Namespace != "":
+		name = fmt.Sprintf("%v/%v", *ref.Namespace, name)
+	case kind != "GatewayClass" && kind != "Namespace" && policyNamespace != "":
+		name = fmt.Sprintf("%v/%v", policyNamespace, name)
+	}
+	if ref.SectionName != nil {
+		name = fmt.Sprintf("%v (%v)", name, *ref.SectionName)
+	}
+	return fmt.Sprintf("%v %v", kind, name)
+}
+
+// policyAncestorsToTable returns the table of the acceptance of the policy by
+// each of its ancestors.
+func policyAncestorsToTable(ancestors []policyAncestor) *Table {
+	table := &Table{
+		ColumnNames:  []string{"Ancestor", "Accepted", "Reason"},
+		UseSeparator: true,
+	}
+	for _, ancestor := range ancestors {
+		reason := ancestor.Reason
+		if reason == "" {
+			reason = "<none>"
+		}
+		table.Rows = append(table.Rows, []string{ancestor.Ancestor, string(ancestor.Accepted), reason})
+	}
+	return table
 }
 
 func (pp *PoliciesPrinter) PrintPoliciesDescribeView(policies []policymanager.Policy) {
 	for i, policy := range SortByString(policies) {
+		if i > 0 {
+			writeDescribeSeparator(pp, policy.Unstructured().GetKind(), policy.Unstructured())
+		}
+
+		// The fields are printed in separate views to retain their order.
+		view := pp.describePolicy(policy)
 		views := []policyDescribeView{
-			{
-				Name:      policy.Unstructured().GetName(),
-				Namespace: policy.Unstructured().GetNamespace(),
-			},
-			{
-				Group: policy.Unstructured().GroupVersionKind().Group,
-				Kind:  policy.Unstructured().GroupVersionKind().Kind,
-			},
-			{
-				Inherited: fmt.Sprintf("%v", policy.IsInherited()),
-			},
-			{
-				Spec: policy.Spec(),
-			},
+			{Name: view.Name, Namespace: view.Namespace},
+			{Group: view.Group, Kind: view.Kind},
+			{Inherited: view.Inherited},
+			{Spec: view.Spec},
+		}
+		if view.Target != "" {
+			views = append(views, policyDescribeView{Target: view.Target})
+		}
+		if len(view.PredictedChanges) != 0 {
+			views = append(views, policyDescribeView{PredictedChanges: view.PredictedChanges})
 		}
 
 		for _, view := range views {
-			b, err := yaml.Marshal(view)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "failed to marshal to yaml: %v\n", err)
-				os.Exit(1)
-			}
-			fmt.Fprint(pp, string(b))
+			pp.writeYAML(view)
 		}
-
-		if i+1 != len(policies) {
-			fmt.Fprintf(pp, "\n\n")
+		if len(view.Ancestors) != 0 {
+			Describe(pp, truncateTables([]*DescriberKV{{Key: "Ancestors", Value: policyAncestorsToTable(view.Ancestors)}}, pp.MaxListItems))
 		}
 	}
 }
 
+func (pp *PoliciesPrinter) writeYAML(view policyDescribeView) {
+	b, err := yaml.Marshal(view)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to marshal to yaml: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprint(pp, string(b))
+}
+
+// resolveTarget fetches the target of the policy. It returns the target, if it
+// exists, along with its age, or a marker describing why it could not be
+// resolved.
+func (pp *PoliciesPrinter) resolveTarget(policy policymanager.Policy) (*unstructured.Unstructured, string) {
+	target, err := pp.TargetFetcher.FetchPolicyTarget(context.Background(), policy)
+	if apierrors.IsNotFound(err) {
+		return nil, "(missing)"
+	}
+	if err != nil {
+		klog.V(3).ErrorS(err, "Failed to fetch target of policy", "policy", policy.Unstructured().GetName())
+		return nil, "(unknown)"
+	}
+	return target, formatAge(pp.Clock, target)
+}
+
+// policyTargetName returns the namespaced name of the target of the policy.
+// The resolved target, if any, is used to omit the namespace of cluster-scoped
+// targets.
+func policyTargetName(policy policymanager.Policy, target *unstructured.Unstructured) string {
+	if target != nil {
+		return client.ObjectKeyFromObject(target).String()
+	}
+	if policy.TargetRef().Namespace == "" {
+		return policy.TargetRef().Name
+	}
+	return fmt.Sprintf("%v/%v", policy.TargetRef().Namespace, policy.TargetRef().Name)
+}
+
+// predictedChanges returns the human readable list of fields that the policy
+// would change on its target. Failures to predict the changes are logged and
+// result in no changes being reported.
+func (pp *PoliciesPrinter) predictedChanges(policy policymanager.Policy, target *unstructured.Unstructured) []string {
+	if target == nil || len(relations.FindDirectPolicies([]policymanager.Policy{policy}, pp.PolicyCRDs)) == 0 {
+		return nil
+	}
+
+	targetSpec, _, _ := unstructured.NestedMap(target.Object, "spec")
+
+	changes, err := policymanager.PredictEffect(policy, targetSpec)
+	if err != nil {
+		klog.V(3).ErrorS(err, "Failed to predict effect of policy", "policy", policy.Unstructured().GetName())
+		return nil
+	}
+
+	var result []string
+	for _, change := range changes {
+		result = append(result, fmt.Sprintf("%v: %v -> %v", change.Path, change.From, change.To))
+	}
+	return result
+}
+
+// PrintPolicyCRDsDescribe prints the describe view of the policy CRDs for the
+// table format, or one YAML document per CRD for the YAML format.
+func (pp *PoliciesPrinter) PrintPolicyCRDsDescribe(policyCrds []policymanager.PolicyCRD, format utils.OutputFormat) {
+	switch format {
+	case utils.OutputFormatTable:
+		pp.PrintPolicyCRDsDescribeView(policyCrds)
+	case utils.OutputFormatYAML:
+		printYAMLDocuments(pp, ClientObjects(SortByString(policyCrds)))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output format '%s' found\n", format)
+		os.Exit(1)
+	}
+}
+
 type policyCrdDescribeView struct {
 	Name        string                                          `json:",omitempty"`
 	Namespace   string                                          `json:",omitempty"`
@@ -201,6 +448,9 @@ func (pp *PoliciesPrinter) PrintPolicyCRDsDescribeView(policyCrds []policymanage
 
 	for i, policyCrd := range policyCrds {
 		crd := policyCrd.CRD()
+		if i > 0 {
+			writeDescribeSeparator(pp, "CustomResourceDefinition", crd)
+		}
 
 		metadata := crd.ObjectMeta.DeepCopy()
 		metadata.Labels = nil
@@ -240,9 +490,5 @@ func (pp *PoliciesPrinter) PrintPolicyCRDsDescribeView(policyCrds []policymanage
 			}
 			fmt.Fprint(pp, string(b))
 		}
-
-		if i+1 != len(policyCrds) {
-			fmt.Fprintf(pp, "\n\n")
-		}
 	}
 }
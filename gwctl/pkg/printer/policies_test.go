@@ -30,8 +30,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	testingclock "k8s.io/utils/clock/testing"
 
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
 )
 
@@ -167,8 +170,9 @@ func TestPoliciesPrinter_Print_And_PrintDescribeView(t *testing.T) {
 	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
 
 	pp := &PoliciesPrinter{
-		Writer: &bytes.Buffer{},
-		Clock:  fakeClock,
+		Writer:     &bytes.Buffer{},
+		Clock:      fakeClock,
+		PolicyCRDs: policyManager.GetCRDs(),
 	}
 
 	pp.PrintPolicies(policyManager.GetPolicies(), utils.OutputFormatTable)
@@ -184,6 +188,27 @@ timeout-policy-namespace   TimeoutPolicy.bar.com      default           Namespac
 		t.Errorf("Print: Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
 	}
 
+	pp.Writer = &bytes.Buffer{}
+	pp.Effectiveness = map[string]policymanager.Effectiveness{
+		"HealthCheckPolicy.foo.com//health-check-gateway":      policymanager.EffectivenessPartial,
+		"HealthCheckPolicy.foo.com//health-check-gatewayclass": policymanager.EffectivenessFull,
+		"TimeoutPolicy.bar.com//timeout-policy-httproute":      policymanager.EffectivenessFull,
+		"TimeoutPolicy.bar.com//timeout-policy-namespace":      policymanager.EffectivenessNone,
+	}
+	pp.PrintPolicies(policyManager.GetPolicies(), utils.OutputFormatWide)
+	got = pp.Writer.(*bytes.Buffer).String()
+	want = `
+NAME                       KIND                       TARGET NAME       TARGET KIND   POLICY TYPE  AGE  EFFECTIVE
+health-check-gateway       HealthCheckPolicy.foo.com  foo-gateway       Gateway       Inherited    20d  partial
+health-check-gatewayclass  HealthCheckPolicy.foo.com  foo-gatewayclass  GatewayClass  Inherited    6d   yes
+timeout-policy-httproute   TimeoutPolicy.bar.com      foo-httproute     HTTPRoute     Direct       13m  yes
+timeout-policy-namespace   TimeoutPolicy.bar.com      default           Namespace     Direct       5m   no
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Print wide: Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+	pp.Effectiveness = nil
+
 	pp.Writer = &bytes.Buffer{}
 	pp.PrintPoliciesDescribeView(policyManager.GetPolicies())
 	got = pp.Writer.(*bytes.Buffer).String()
@@ -204,7 +229,7 @@ Spec:
     name: foo-gateway
     namespace: default
 
-
+------ HealthCheckPolicy health-check-gatewayclass
 Name: health-check-gatewayclass
 Group: foo.com
 Kind: HealthCheckPolicy
@@ -222,7 +247,7 @@ Spec:
     kind: GatewayClass
     name: foo-gatewayclass
 
-
+------ TimeoutPolicy timeout-policy-httproute
 Name: timeout-policy-httproute
 Group: bar.com
 Kind: TimeoutPolicy
@@ -235,7 +260,7 @@ Spec:
     kind: HTTPRoute
     name: foo-httproute
 
-
+------ TimeoutPolicy timeout-policy-namespace
 Name: timeout-policy-namespace
 Group: bar.com
 Kind: TimeoutPolicy
@@ -252,6 +277,260 @@ Spec:
 	}
 }
 
+func TestPoliciesPrinter_ResolvedTargets(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "foo-gateway",
+				Namespace:         "default",
+				CreationTimestamp: metav1.Time{Time: fakeClock.Now().Add(-3 * 24 * time.Hour)},
+			},
+			Spec: gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+		},
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "timeoutpolicies.bar.com",
+				Labels: map[string]string{
+					gatewayv1alpha2.PolicyLabelKey: "inherited",
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    "bar.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "timeoutpolicies",
+					Kind:   "TimeoutPolicy",
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "bar.com/v1",
+				"kind":       "TimeoutPolicy",
+				"metadata": map[string]interface{}{
+					"name":              "timeout-policy-gateway",
+					"namespace":         "default",
+					"creationTimestamp": fakeClock.Now().Add(-5 * time.Minute).Format(time.RFC3339),
+				},
+				"spec": map[string]interface{}{
+					"targetRef": map[string]interface{}{
+						"group": "gateway.networking.k8s.io",
+						"kind":  "Gateway",
+						"name":  "foo-gateway",
+					},
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "bar.com/v1",
+				"kind":       "TimeoutPolicy",
+				"metadata": map[string]interface{}{
+					"name":              "timeout-policy-httproute",
+					"namespace":         "default",
+					"creationTimestamp": fakeClock.Now().Add(-5 * time.Minute).Format(time.RFC3339),
+				},
+				"spec": map[string]interface{}{
+					"targetRef": map[string]interface{}{
+						"group": "gateway.networking.k8s.io",
+						"kind":  "HTTPRoute",
+						"name":  "missing-httproute",
+					},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+
+	pp := &PoliciesPrinter{
+		Writer:        &bytes.Buffer{},
+		Clock:         fakeClock,
+		PolicyCRDs:    policyManager.GetCRDs(),
+		TargetFetcher: discoverer,
+	}
+
+	pp.PrintPolicies(policyManager.GetPolicies(), utils.OutputFormatTable)
+	got := pp.Writer.(*bytes.Buffer).String()
+	want := `
+NAME                      KIND                   TARGET NAME        TARGET KIND  POLICY TYPE  AGE  TARGET AGE
+timeout-policy-gateway    TimeoutPolicy.bar.com  foo-gateway        Gateway      Inherited    5m   3d
+timeout-policy-httproute  TimeoutPolicy.bar.com  missing-httproute  HTTPRoute    Inherited    5m   (missing)
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Print: Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+
+	pp.Writer = &bytes.Buffer{}
+	pp.PrintPoliciesDescribeView(policyManager.GetPolicies())
+	got = pp.Writer.(*bytes.Buffer).String()
+	want = `
+Name: timeout-policy-gateway
+Namespace: default
+Group: bar.com
+Kind: TimeoutPolicy
+Inherited: "true"
+Spec:
+  targetRef:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: foo-gateway
+Target: Gateway default/foo-gateway (age 3d)
+
+------ TimeoutPolicy default/timeout-policy-httproute
+Name: timeout-policy-httproute
+Namespace: default
+Group: bar.com
+Kind: TimeoutPolicy
+Inherited: "true"
+Spec:
+  targetRef:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: missing-httproute
+Target: HTTPRoute default/missing-httproute (missing)
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("PrintDescribeView: Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
+func TestPoliciesPrinter_PrintDescribeView_Ancestors(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	condition := func(status, reason string) map[string]interface{} {
+		return map[string]interface{}{
+			"type":               "Accepted",
+			"status":             status,
+			"reason":             reason,
+			"lastTransitionTime": fakeClock.Now().Format(time.RFC3339),
+		}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "timeoutpolicies.bar.com",
+				Labels: map[string]string{
+					gatewayv1alpha2.PolicyLabelKey: "direct",
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    "bar.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "timeoutpolicies",
+					Kind:   "TimeoutPolicy",
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "bar.com/v1",
+				"kind":       "TimeoutPolicy",
+				"metadata": map[string]interface{}{
+					"name":      "timeout-policy",
+					"namespace": "default",
+				},
+				"spec": map[string]interface{}{
+					"targetRef": map[string]interface{}{
+						"group": "",
+						"kind":  "Service",
+						"name":  "foo-svc",
+					},
+				},
+				"status": map[string]interface{}{
+					"ancestors": []interface{}{
+						map[string]interface{}{
+							"ancestorRef":    map[string]interface{}{"name": "foo-gateway"},
+							"controllerName": "example.net/gateway-controller",
+							"conditions":     []interface{}{condition("True", "Accepted")},
+						},
+						map[string]interface{}{
+							"ancestorRef":    map[string]interface{}{"name": "bar-gateway", "namespace": "infra", "sectionName": "https"},
+							"controllerName": "example.net/gateway-controller",
+							"conditions":     []interface{}{condition("False", "Conflicted")},
+						},
+						map[string]interface{}{
+							"ancestorRef":    map[string]interface{}{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "name": "foo-httproute"},
+							"controllerName": "example.net/gateway-controller",
+							"conditions":     []interface{}{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+
+	header := `
+Name: timeout-policy
+Namespace: default
+Group: bar.com
+Kind: TimeoutPolicy
+Inherited: "false"
+Spec:
+  targetRef:
+    group: ""
+    kind: Service
+    name: foo-svc
+`
+	testcases := []struct {
+		name         string
+		maxListItems int
+		want         string
+	}{
+		{
+			name:         "untruncated",
+			maxListItems: 0,
+			want: header + `Ancestors:
+  Ancestor                           Accepted  Reason
+  --------                           --------  ------
+  Gateway default/foo-gateway        True      Accepted
+  Gateway infra/bar-gateway (https)  False     Conflicted
+  HTTPRoute default/foo-httproute    Unknown   <none>
+`,
+		},
+		{
+			name:         "truncated",
+			maxListItems: 2,
+			want: header + `Ancestors:
+  Ancestor                           Accepted  Reason
+  --------                           --------  ------
+  Gateway default/foo-gateway        True      Accepted
+  Gateway infra/bar-gateway (https)  False     Conflicted
+  (… and 1 more, use --max-list-items=0 to show all)
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			pp := &PoliciesPrinter{
+				Writer:       &bytes.Buffer{},
+				Clock:        fakeClock,
+				PolicyCRDs:   policyManager.GetCRDs(),
+				MaxListItems: tc.maxListItems,
+			}
+			pp.PrintPoliciesDescribeView(policyManager.GetPolicies())
+			got := pp.Writer.(*bytes.Buffer).String()
+			if diff := cmp.Diff(common.YamlString(tc.want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+				t.Errorf("PrintDescribeView: Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, tc.want, diff)
+			}
+		})
+	}
+}
+
 func TestPoliciesPrinter_PrintCRDs(t *testing.T) {
 	fakeClock := testingclock.NewFakeClock(time.Now())
 	objects := []runtime.Object{
@@ -261,6 +540,9 @@ func TestPoliciesPrinter_PrintCRDs(t *testing.T) {
 				Labels: map[string]string{
 					gatewayv1alpha2.PolicyLabelKey: "inherited",
 				},
+				Annotations: map[string]string{
+					"example.com/owner": "team-a",
+				},
 				CreationTimestamp: metav1.Time{
 					Time: fakeClock.Now().Add(-24 * 24 * time.Hour),
 				},
@@ -280,7 +562,11 @@ func TestPoliciesPrinter_PrintCRDs(t *testing.T) {
 				"apiVersion": "foo.com/v1",
 				"kind":       "HealthCheckPolicy",
 				"metadata": map[string]interface{}{
-					"name": "health-check-gateway",
+					"name":              "health-check-gateway",
+					"creationTimestamp": fakeClock.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+					"annotations": map[string]interface{}{
+						"example.com/owner": "team-a",
+					},
 				},
 				"spec": map[string]interface{}{
 					"override": map[string]interface{}{
@@ -306,6 +592,9 @@ func TestPoliciesPrinter_PrintCRDs(t *testing.T) {
 				Labels: map[string]string{
 					gatewayv1alpha2.PolicyLabelKey: "direct",
 				},
+				Annotations: map[string]string{
+					"example.com/owner": "team-b",
+				},
 				CreationTimestamp: metav1.Time{
 					Time: fakeClock.Now().Add(-5 * time.Minute),
 				},
@@ -325,7 +614,11 @@ func TestPoliciesPrinter_PrintCRDs(t *testing.T) {
 				"apiVersion": "bar.com/v1",
 				"kind":       "TimeoutPolicy",
 				"metadata": map[string]interface{}{
-					"name": "timeout-policy-namespace",
+					"name":              "timeout-policy-namespace",
+					"creationTimestamp": fakeClock.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+					"annotations": map[string]interface{}{
+						"example.com/owner": "team-b",
+					},
 				},
 				"spec": map[string]interface{}{
 					"condition": "path=/abc",
@@ -353,6 +646,30 @@ func TestPoliciesPrinter_PrintCRDs(t *testing.T) {
 NAME                         POLICY TYPE  SCOPE       AGE
 healthcheckpolicies.foo.com  Inherited    Cluster     24d
 timeoutpolicies.bar.com      Direct       Namespaced  5m
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+
+	// The annotation columns follow the columns of both the policy CRDs and the
+	// policies.
+	pp = &PoliciesPrinter{
+		Writer:            &bytes.Buffer{},
+		Clock:             fakeClock,
+		PolicyCRDs:        policyManager.GetCRDs(),
+		AnnotationColumns: []common.AnnotationColumn{{Name: "owner", Annotation: "example.com/owner"}},
+	}
+	pp.PrintCRDs(policyManager.GetCRDs(), utils.OutputFormatTable)
+	pp.PrintPolicies(policyManager.GetPolicies(), utils.OutputFormatTable)
+
+	got = pp.Writer.(*bytes.Buffer).String()
+	want = `
+NAME                         POLICY TYPE  SCOPE       AGE  OWNER
+healthcheckpolicies.foo.com  Inherited    Cluster     24d  team-a
+timeoutpolicies.bar.com      Direct       Namespaced  5m   team-b
+NAME                      KIND                       TARGET NAME  TARGET KIND  POLICY TYPE  AGE  OWNER
+health-check-gateway      HealthCheckPolicy.foo.com  foo-gateway  Gateway      Inherited    60m  team-a
+timeout-policy-namespace  TimeoutPolicy.bar.com      default      Namespace    Direct       60m  team-b
 `
 	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
 		t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
@@ -612,6 +929,183 @@ kind: List`, creationTime1.Format(time.RFC3339), creationTime2.Format(time.RFC33
 	}
 }
 
+func TestPoliciesPrinter_PrintPolicies_Yaml(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	objects := []runtime.Object{
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "healthcheckpolicies.foo.com",
+				Labels: map[string]string{
+					gatewayv1alpha2.PolicyLabelKey: "inherited",
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    "foo.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "healthcheckpolicies",
+					Kind:   "HealthCheckPolicy",
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "foo.com/v1",
+				"kind":       "HealthCheckPolicy",
+				"metadata": map[string]interface{}{
+					"name":      "health-check-gateway",
+					"namespace": "default",
+					"managedFields": []interface{}{
+						map[string]interface{}{"manager": "kubectl", "operation": "Apply"},
+					},
+				},
+				"spec": map[string]interface{}{
+					"override": map[string]interface{}{
+						"key1": "value-child-1",
+					},
+					"default": map[string]interface{}{
+						"key2": "value-child-2",
+					},
+					"targetRef": map[string]interface{}{
+						"group": "gateway.networking.k8s.io",
+						"kind":  "Gateway",
+						"name":  "foo-gateway",
+					},
+				},
+			},
+		},
+
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "timeoutpolicies.bar.com",
+				Labels: map[string]string{
+					gatewayv1alpha2.PolicyLabelKey: "direct",
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    "bar.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "timeoutpolicies",
+					Kind:   "TimeoutPolicy",
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "bar.com/v1",
+				"kind":       "TimeoutPolicy",
+				"metadata": map[string]interface{}{
+					"name":      "timeout-policy-httproute",
+					"namespace": "default",
+				},
+				"spec": map[string]interface{}{
+					"seconds": int64(60),
+					"targetRef": map[string]interface{}{
+						"group": "gateway.networking.k8s.io",
+						"kind":  "HTTPRoute",
+						"name":  "foo-httproute",
+					},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	pp := &PoliciesPrinter{
+		Writer:     &bytes.Buffer{},
+		Clock:      fakeClock,
+		PolicyCRDs: policyManager.GetCRDs(),
+	}
+
+	// The policies are printed as they were fetched, without the managedFields,
+	// rather than with their effective spec.
+	pp.PrintPolicies(policyManager.GetPolicies(), utils.OutputFormatYAML)
+	got := common.YamlString(pp.Writer.(*bytes.Buffer).String())
+	want := common.YamlString(`
+apiVersion: v1
+items:
+- apiVersion: foo.com/v1
+  kind: HealthCheckPolicy
+  metadata:
+    name: health-check-gateway
+    namespace: default
+    resourceVersion: "999"
+  spec:
+    default:
+      key2: value-child-2
+    override:
+      key1: value-child-1
+    targetRef:
+      group: gateway.networking.k8s.io
+      kind: Gateway
+      name: foo-gateway
+- apiVersion: bar.com/v1
+  kind: TimeoutPolicy
+  metadata:
+    name: timeout-policy-httproute
+    namespace: default
+    resourceVersion: "999"
+  spec:
+    seconds: 60
+    targetRef:
+      group: gateway.networking.k8s.io
+      kind: HTTPRoute
+      name: foo-httproute
+kind: List
+`)
+	if diff := cmp.Diff(want, got, common.YamlStringTransformer); diff != "" {
+		t.Errorf("PrintPolicies(yaml): Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+
+	pp.Writer = &bytes.Buffer{}
+	pp.PrintPolicies(policyManager.GetPolicies(), utils.OutputFormatName)
+	gotNames := pp.Writer.(*bytes.Buffer).String()
+	wantNames := "healthcheckpolicy.foo.com/health-check-gateway\ntimeoutpolicy.bar.com/timeout-policy-httproute\n"
+	if diff := cmp.Diff(wantNames, gotNames); diff != "" {
+		t.Errorf("PrintPolicies(name): Unexpected diff (-want +got)=\n%v", diff)
+	}
+
+	// The structured describe includes the fields computed by gwctl.
+	pp.Writer = &bytes.Buffer{}
+	pp.PrintPoliciesDescribe(policyManager.GetPolicies(), utils.OutputFormatYAML)
+	got = common.YamlString(pp.Writer.(*bytes.Buffer).String())
+	want = common.YamlString(`
+Group: foo.com
+Inherited: "true"
+Kind: HealthCheckPolicy
+Name: health-check-gateway
+Namespace: default
+Spec:
+  default:
+    key2: value-child-2
+  override:
+    key1: value-child-1
+  targetRef:
+    group: gateway.networking.k8s.io
+    kind: Gateway
+    name: foo-gateway
+---
+Group: bar.com
+Inherited: "false"
+Kind: TimeoutPolicy
+Name: timeout-policy-httproute
+Namespace: default
+Spec:
+  seconds: 60
+  targetRef:
+    group: gateway.networking.k8s.io
+    kind: HTTPRoute
+    name: foo-httproute
+`)
+	if diff := cmp.Diff(want, got, common.YamlStringTransformer); diff != "" {
+		t.Errorf("PrintPoliciesDescribe(yaml): Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, want, diff)
+	}
+}
+
 func TestPolicyCrd_PrintDescribeView(t *testing.T) {
 	objects := []runtime.Object{
 		&apiextensionsv1.CustomResourceDefinition{
@@ -736,7 +1230,7 @@ Status:
   conditions: null
   storedVersions: null
 
-
+------ CustomResourceDefinition timeoutpolicies.bar.com
 Name: timeoutpolicies.bar.com
 APIVersion: apiextensions.k8s.io/v1
 Kind: CustomResourceDefinition
@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
@@ -56,13 +58,85 @@ func Print(p Printer, resourceModel *resourcediscovery.ResourceModel, format uti
 			os.Exit(1)
 		}
 		fmt.Fprint(p, string(output))
+	case utils.OutputFormatName:
+		nodes := SortByString(p.GetPrintableNodes(resourceModel))
+		for _, obj := range ClientObjects(nodes) {
+			fmt.Fprintln(p, resourceName(obj))
+		}
+	case utils.OutputFormatHelmValues:
+		nodes := SortByString(p.GetPrintableNodes(resourceModel))
+		printHelmValues(p, ClientObjects(nodes))
 	default:
 		fmt.Fprintf(os.Stderr, "Unrecognized output format: %s\n", format)
 		os.Exit(1)
 	}
 }
 
-func renderPrintableObject(objs []client.Object) (runtime.Object, error) {
+// resourceName returns the name of the object in the form TYPE[.GROUP]/NAME,
+// matching the output of `kubectl get -o name`.
+func resourceName(obj client.Object) string {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() {
+		// Objects fetched through the typed client do not have their TypeMeta set.
+		if gvks, _, err := scheme.Scheme.ObjectKinds(obj); err == nil {
+			gvk = gvks[0]
+		}
+	}
+	resourceType := strings.ToLower(gvk.Kind)
+	if gvk.Group != "" {
+		resourceType += "." + gvk.Group
+	}
+	return resourceType + "/" + obj.GetName()
+}
+
+// YAMLDocumentSeparator separates the YAML documents of multiple resources.
+const YAMLDocumentSeparator = "---"
+
+// DescribePrinter is a Printer which can also print the describe view of
+// resources.
+type DescribePrinter interface {
+	Printer
+	PrintDescribeView(resourceModel *resourcediscovery.ResourceModel)
+}
+
+// PrintDescribe prints the describe view of the resources for the table
+// format, or one YAML document per resource for the YAML format.
+func PrintDescribe(p DescribePrinter, resourceModel *resourcediscovery.ResourceModel, format utils.OutputFormat) {
+	switch format {
+	case utils.OutputFormatTable:
+		p.PrintDescribeView(resourceModel)
+	case utils.OutputFormatYAML:
+		nodes := SortByString(p.GetPrintableNodes(resourceModel))
+		printYAMLDocuments(p, ClientObjects(nodes))
+	default:
+		fmt.Fprintf(os.Stderr, "Unrecognized output format for describe: %s\n", format)
+		os.Exit(1)
+	}
+}
+
+// printYAMLDocuments prints each object as a separate YAML document. The
+// documents are separated by YAMLDocumentSeparator, without a trailing
+// separator after the last document.
+func printYAMLDocuments(w io.Writer, objs []client.Object) {
+	for i, obj := range objs {
+		unstructuredObj, err := toPrintableUnstructured(obj)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to form the printable payload %v\n", err)
+			os.Exit(1)
+		}
+		output, err := utils.MarshalWithFormat(unstructuredObj, utils.OutputFormatYAML)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+			os.Exit(1)
+		}
+		if i > 0 {
+			fmt.Fprintln(w, YAMLDocumentSeparator)
+		}
+		fmt.Fprint(w, string(output))
+	}
+}
+
+func renderPrintableObject(objs []client.Object) (*unstructured.UnstructuredList, error) {
 	list := &unstructured.UnstructuredList{
 		Object: map[string]interface{}{
 			"kind":       "List",
@@ -70,7 +144,7 @@ func renderPrintableObject(objs []client.Object) (runtime.Object, error) {
 		},
 	}
 	for _, obj := range objs {
-		unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		unstructuredObj, err := toPrintableUnstructured(obj)
 		if err != nil {
 			return list, err
 		}
@@ -78,3 +152,21 @@ func renderPrintableObject(objs []client.Object) (runtime.Object, error) {
 	}
 	return list, nil
 }
+
+// toPrintableUnstructured converts the object to its unstructured content as
+// fetched from the cluster, without the managedFields which are rarely useful
+// and are also omitted by kubectl by default. The object itself is not
+// modified.
+func toPrintableUnstructured(obj client.Object) (map[string]interface{}, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		// ToUnstructured returns the content of unstructured objects without
+		// copying it.
+		obj = u.DeepCopy()
+	}
+	unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	unstructured.RemoveNestedField(unstructuredObj, "metadata", "managedFields")
+	return unstructuredObj, nil
+}
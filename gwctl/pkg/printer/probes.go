@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// ProbesPrinter prints the results of probing the listeners of a Gateway.
+type ProbesPrinter struct {
+	io.Writer
+}
+
+// Print prints the results of the probes. For the table format, the results
+// are preceded by a note on the machine which ran the probes.
+func (pp *ProbesPrinter) Print(report common.ProbeReport, format utils.OutputFormat) {
+	switch format {
+	case utils.OutputFormatJSON, utils.OutputFormatYAML:
+		output, err := utils.MarshalWithFormat(report, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(pp, string(output))
+	case utils.OutputFormatTable, utils.OutputFormatWide:
+		fmt.Fprintf(pp, "Probed Gateway %v/%v from %v, not from within the cluster.\n\n", report.Gateway.Namespace, report.Gateway.Name, report.Origin)
+		if len(report.Results) == 0 {
+			fmt.Fprintf(pp, "No listeners were probed since the Gateway has no addresses in its status.\n")
+			return
+		}
+		pp.printTable(report.Results)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output format '%s' found\n", format)
+		os.Exit(1)
+	}
+}
+
+func (pp *ProbesPrinter) printTable(results []common.ProbeResult) {
+	table := &Table{
+		ColumnNames:  []string{"LISTENER", "ADDRESS", "PROTOCOL", "SERVER NAME", "RESULT", "LATENCY"},
+		UseSeparator: false,
+	}
+	for _, result := range results {
+		serverName := result.ServerName
+		if serverName == "" {
+			serverName = "None"
+		}
+		row := []string{
+			result.Listener,
+			fmt.Sprintf("%v:%d", result.Address, result.Port),
+			string(result.Protocol),
+			serverName,
+			FormatProbeResult(result),
+			result.Latency.Duration.String(),
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	table.Write(pp, 0)
+}
+
+// FormatProbeResult summarizes the result of a probe, like "HTTP 301" or
+// "tcp failed: connection refused".
+func FormatProbeResult(result common.ProbeResult) string {
+	switch {
+	case result.Error != "" && result.Stage == "":
+		return result.Error
+	case result.Error != "":
+		return fmt.Sprintf("%v failed: %v", result.Stage, result.Error)
+	case result.StatusCode != 0:
+		return fmt.Sprintf("HTTP %d", result.StatusCode)
+	default:
+		return fmt.Sprintf("%v ok", result.Stage)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestProbesPrinter_Print(t *testing.T) {
+	report := common.ProbeReport{
+		Gateway: common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "default", Name: "edge"},
+		Origin:  "laptop",
+		Results: []common.ProbeResult{
+			{
+				ProbeTarget: common.ProbeTarget{Listener: "http", Address: "192.0.2.1", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+				Stage:       common.ProbeStageHTTP,
+				Latency:     metav1.Duration{Duration: 12 * time.Millisecond},
+				StatusCode:  301,
+			},
+			{
+				ProbeTarget: common.ProbeTarget{Listener: "https", Address: "192.0.2.1", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, ServerName: "www.example.com"},
+				Stage:       common.ProbeStageTLS,
+				Latency:     metav1.Duration{Duration: 5 * time.Second},
+				Error:       "i/o timeout",
+			},
+			{
+				ProbeTarget: common.ProbeTarget{Listener: "tcp", Address: "192.0.2.1", Port: 5432, Protocol: gatewayv1.TCPProtocolType},
+				Stage:       common.ProbeStageTCP,
+				Latency:     metav1.Duration{Duration: 3 * time.Millisecond},
+			},
+			{
+				ProbeTarget: common.ProbeTarget{Listener: "dns", Address: "192.0.2.1", Port: 53, Protocol: gatewayv1.UDPProtocolType},
+				Error:       "listeners of protocol UDP cannot be probed",
+			},
+		},
+	}
+
+	buff := &bytes.Buffer{}
+	pp := &ProbesPrinter{Writer: buff}
+	pp.Print(report, utils.OutputFormatTable)
+
+	got := buff.String()
+	want := `
+Probed Gateway default/edge from laptop, not from within the cluster.
+
+LISTENER  ADDRESS         PROTOCOL  SERVER NAME      RESULT                                      LATENCY
+http      192.0.2.1:80    HTTP      None             HTTP 301                                    12ms
+https     192.0.2.1:443   HTTPS     www.example.com  tls failed: i/o timeout                     5s
+tcp       192.0.2.1:5432  TCP       None             tcp ok                                      3ms
+dns       192.0.2.1:53    UDP       None             listeners of protocol UDP cannot be probed  0s
+`
+	if diff := cmp.Diff(common.YamlString(want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+		t.Errorf("Print() returned unexpected output (-want +got):\n%v", diff)
+	}
+}
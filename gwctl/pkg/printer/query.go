@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/query"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+type QueryPrinter struct {
+	io.Writer
+}
+
+// QueryResultRef is the JSON form of a result of a query without a
+// projection.
+type QueryResultRef struct {
+	Kind      string `json:"kind"`
+	Group     string `json:"group,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// Print prints the results of a query. The table format prints one line per
+// result: its kind and namespaced name, only its namespaced name with the name
+// projection, or the resource as a single line of JSON with the json
+// projection. The json format prints all the results as a JSON array.
+func (qp *QueryPrinter) Print(results []query.Result, projection query.Projection, format utils.OutputFormat) {
+	switch format {
+	case utils.OutputFormatTable:
+		for _, result := range results {
+			switch projection {
+			case query.ProjectionName:
+				fmt.Fprintln(qp, queryResultName(result))
+			case query.ProjectionJSON:
+				b, err := json.Marshal(queryResultObject(result))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Fprintln(qp, string(b))
+			default:
+				fmt.Fprintf(qp, "%v %v\n", result.Ref.Kind, queryResultName(result))
+			}
+		}
+	case utils.OutputFormatJSON:
+		payload := make([]any, 0, len(results))
+		for _, result := range results {
+			switch projection {
+			case query.ProjectionName:
+				payload = append(payload, queryResultName(result))
+			case query.ProjectionJSON:
+				payload = append(payload, queryResultObject(result))
+			default:
+				payload = append(payload, QueryResultRef{Kind: result.Ref.Kind, Group: result.Ref.Group, Namespace: result.Ref.Namespace, Name: result.Ref.Name})
+			}
+		}
+		output, err := utils.MarshalWithFormat(payload, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(qp, string(output))
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output format '%s' found\n", format)
+		os.Exit(1)
+	}
+}
+
+// queryResultName returns the namespaced name of the result, or only its name
+// if it is cluster-scoped.
+func queryResultName(result query.Result) string {
+	if result.Ref.Namespace == "" {
+		return result.Ref.Name
+	}
+	return result.Ref.Namespace + "/" + result.Ref.Name
+}
+
+// queryResultObject returns the printable content of the resource of the
+// result, with its apiVersion and kind even if it was fetched through the typed
+// client.
+func queryResultObject(result query.Result) map[string]interface{} {
+	object, err := toPrintableUnstructured(result.Object)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to form the printable payload %v\n", err)
+		os.Exit(1)
+	}
+	if object["kind"] == nil {
+		if gvks, _, err := scheme.Scheme.ObjectKinds(result.Object); err == nil {
+			object["apiVersion"], object["kind"] = gvks[0].GroupVersion().String(), gvks[0].Kind
+		}
+	}
+	return object
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/query"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestQueryPrinter_Print(t *testing.T) {
+	// Creating the clients registers the Gateway API types with the scheme, from
+	// which the kinds of typed objects are printed.
+	common.MustClientsForTest(t)
+
+	results := []query.Result{
+		{
+			Ref: common.ObjRef{Group: gatewayv1.GroupName, Kind: "GatewayClass", Name: "foo-gatewayclass"},
+			Object: &gatewayv1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo-gatewayclass"},
+				Spec:       gatewayv1.GatewayClassSpec{ControllerName: "example.net/gateway-controller"},
+			},
+		},
+		{
+			Ref: common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "default", Name: "foo-gateway"},
+			Object: &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo-gateway", Namespace: "default"},
+				Spec:       gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name       string
+		projection query.Projection
+		format     utils.OutputFormat
+		want       string
+	}{
+		{
+			name:   "no projection",
+			format: utils.OutputFormatTable,
+			want: `GatewayClass foo-gatewayclass
+Gateway default/foo-gateway
+`,
+		},
+		{
+			name:       "name projection",
+			projection: query.ProjectionName,
+			format:     utils.OutputFormatTable,
+			want: `foo-gatewayclass
+default/foo-gateway
+`,
+		},
+		{
+			name:       "json projection",
+			projection: query.ProjectionJSON,
+			format:     utils.OutputFormatTable,
+			want: `{"apiVersion":"gateway.networking.k8s.io/v1","kind":"GatewayClass","metadata":{"creationTimestamp":null,"name":"foo-gatewayclass"},"spec":{"controllerName":"example.net/gateway-controller"},"status":{}}
+{"apiVersion":"gateway.networking.k8s.io/v1","kind":"Gateway","metadata":{"creationTimestamp":null,"name":"foo-gateway","namespace":"default"},"spec":{"gatewayClassName":"foo-gatewayclass","listeners":null},"status":{}}
+`,
+		},
+		{
+			name:   "no projection with json output",
+			format: utils.OutputFormatJSON,
+			want: `[
+  {
+    "kind": "GatewayClass",
+    "group": "gateway.networking.k8s.io",
+    "name": "foo-gatewayclass"
+  },
+  {
+    "kind": "Gateway",
+    "group": "gateway.networking.k8s.io",
+    "namespace": "default",
+    "name": "foo-gateway"
+  }
+]`,
+		},
+		{
+			name:       "name projection with json output",
+			projection: query.ProjectionName,
+			format:     utils.OutputFormatJSON,
+			want: `[
+  "foo-gatewayclass",
+  "default/foo-gateway"
+]`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			qp := &QueryPrinter{Writer: buff}
+			qp.Print(results, tc.projection, tc.format)
+
+			got := buff.String()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, tc.want, diff)
+			}
+		})
+	}
+}
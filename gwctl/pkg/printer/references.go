@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// ReferencesPrinter prints the Gateway API resources which reference some
+// resource, like a Service or a Secret.
+type ReferencesPrinter struct {
+	io.Writer
+}
+
+// ReferencesView is the YAML form of the references to a resource.
+type ReferencesView struct {
+	Target          common.ObjRef                              `json:"target"`
+	ReferencedBy    []resourcediscovery.ReferenceView          `json:"referencedBy"`
+	ReferenceGrants []resourcediscovery.ReferenceGrantCoverage `json:"referenceGrants,omitempty"`
+}
+
+func (rp *ReferencesPrinter) PrintDescribe(target common.ObjRef, references []resourcediscovery.ReferenceView, referenceGrants []resourcediscovery.ReferenceGrantCoverage, format utils.OutputFormat) {
+	switch format {
+	case utils.OutputFormatTable:
+		rp.PrintDescribeView(target, references, referenceGrants)
+	case utils.OutputFormatYAML:
+		view := ReferencesView{Target: target, ReferencedBy: references, ReferenceGrants: referenceGrants}
+		output, err := utils.MarshalWithFormat(view, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(rp, string(output))
+	default:
+		fmt.Fprintf(os.Stderr, "Unrecognized output format for describe: %s\n", format)
+		os.Exit(1)
+	}
+}
+
+// PrintDescribeView prints the references to the target, followed by the
+// ReferenceGrants which expose the target, if any, along with how many
+// references and distinct resources each of them authorizes across its
+// namespace.
+func (rp *ReferencesPrinter) PrintDescribeView(target common.ObjRef, references []resourcediscovery.ReferenceView, referenceGrants []resourcediscovery.ReferenceGrantCoverage) {
+	pairs := []*DescriberKV{
+		{Key: "Name", Value: target.Name},
+		{Key: "Namespace", Value: target.Namespace},
+		{Key: "Kind", Value: target.Kind},
+	}
+
+	// References from HTTPRoutes are within a rule, and references from Gateways
+	// are within a listener.
+	sectionColumn := "Rule"
+	if target.Kind == "Secret" {
+		sectionColumn = "Listener"
+	}
+	table := &Table{
+		ColumnNames:  []string{"Kind", "Name", sectionColumn, "Permitted", "ReferenceGrant"},
+		UseSeparator: true,
+	}
+	for _, reference := range references {
+		section := reference.Listener
+		if reference.Rule != nil {
+			section = fmt.Sprintf("%d", *reference.Rule)
+		}
+		permitted := "True"
+		if !reference.Permitted {
+			permitted = "False"
+		}
+		referenceGrant := "None"
+		if reference.ReferenceGrant != nil {
+			referenceGrant = fmt.Sprintf("%v/%v", reference.ReferenceGrant.Namespace, reference.ReferenceGrant.Name)
+		}
+		row := []string{
+			reference.From.Kind,
+			fmt.Sprintf("%v/%v", reference.From.Namespace, reference.From.Name),
+			section,
+			permitted,
+			referenceGrant,
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	pairs = append(pairs, &DescriberKV{Key: "ReferencedBy", Value: table})
+
+	if len(referenceGrants) != 0 {
+		referenceGrantsTable := &Table{
+			ColumnNames:  []string{"Name", "References", "Targets", "Wildcard"},
+			UseSeparator: true,
+		}
+		for _, coverage := range referenceGrants {
+			wildcard := "False"
+			if coverage.Wildcard {
+				wildcard = "True"
+			}
+			referenceGrantsTable.Rows = append(referenceGrantsTable.Rows, []string{
+				fmt.Sprintf("%v/%v", coverage.ReferenceGrant.Namespace, coverage.ReferenceGrant.Name),
+				fmt.Sprintf("%d", len(coverage.References)),
+				fmt.Sprintf("%d", len(coverage.Targets())),
+				wildcard,
+			})
+		}
+		pairs = append(pairs, &DescriberKV{Key: "ReferenceGrants", Value: referenceGrantsTable})
+	}
+
+	Describe(rp, pairs)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestReferencesPrinter_PrintDescribeView(t *testing.T) {
+	httpRoute := func(namespace, name string, rules ...gatewayv1.HTTPRouteRule) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: rules,
+			},
+		}
+	}
+	backendRule := func(namespace, name string) gatewayv1.HTTPRouteRule {
+		backendRef := gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(name)}}}
+		if namespace != "" {
+			backendRef.Namespace = common.PtrTo(gatewayv1.Namespace(namespace))
+		}
+		return gatewayv1.HTTPRouteRule{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef}}
+	}
+	gateway := func(namespace, name string, secretNamespace *gatewayv1.Namespace) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{
+						Name:     "https",
+						Port:     443,
+						Protocol: gatewayv1.HTTPSProtocolType,
+						TLS: &gatewayv1.GatewayTLSConfig{
+							CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "checkout-cert", Namespace: secretNamespace}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		common.NamespaceForTest("ns2"),
+		common.NamespaceForTest("ns3"),
+		httpRoute("default", "route-a", backendRule("", "other-svc"), backendRule("", "checkout-svc")),
+		httpRoute("ns2", "route-b", backendRule("default", "checkout-svc")),
+		httpRoute("ns3", "route-c", backendRule("default", "checkout-svc")),
+		httpRoute("ns3", "route-d", backendRule("", "checkout-svc")),
+		gateway("default", "gateway-a", nil),
+		gateway("ns3", "gateway-b", common.PtrTo(gatewayv1.Namespace("default"))),
+		&gatewayv1beta1.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "allow-ns2",
+				Namespace: "default",
+			},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "ns2"}},
+				To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: "Service"}},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverAllResources(resourcediscovery.Filter{Labels: labels.Everything()})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	testcases := []struct {
+		name   string
+		target common.ObjRef
+		want   string
+	}{
+		{
+			name:   "service",
+			target: common.ObjRef{Kind: "Service", Namespace: "default", Name: "checkout-svc"},
+			want: `
+Name: checkout-svc
+Namespace: default
+Kind: Service
+ReferencedBy:
+  Kind       Name             Rule  Permitted  ReferenceGrant
+  ----       ----             ----  ---------  --------------
+  HTTPRoute  default/route-a  1     True       None
+  HTTPRoute  ns2/route-b      0     True       default/allow-ns2
+  HTTPRoute  ns3/route-c      0     False      None
+ReferenceGrants:
+  Name               References  Targets  Wildcard
+  ----               ----------  -------  --------
+  default/allow-ns2  1           1        True
+`,
+		},
+		{
+			name:   "secret",
+			target: common.ObjRef{Kind: "Secret", Namespace: "default", Name: "checkout-cert"},
+			want: `
+Name: checkout-cert
+Namespace: default
+Kind: Secret
+ReferencedBy:
+  Kind     Name               Listener  Permitted  ReferenceGrant
+  ----     ----               --------  ---------  --------------
+  Gateway  default/gateway-a  https     True       None
+  Gateway  ns3/gateway-b      https     False      None
+`,
+		},
+		{
+			name:   "unreferenced",
+			target: common.ObjRef{Kind: "Service", Namespace: "default", Name: "unknown-svc"},
+			want: `
+Name: unknown-svc
+Namespace: default
+Kind: Service
+ReferencedBy: <none>
+ReferenceGrants:
+  Name               References  Targets  Wildcard
+  ----               ----------  -------  --------
+  default/allow-ns2  1           1        True
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			references, err := discoverer.ReferencesTo(resourceModel, tc.target)
+			if err != nil {
+				t.Fatalf("Failed to find references: %v", err)
+			}
+			referenceGrants, err := discoverer.ReferenceGrantCoverageFor(resourceModel, tc.target)
+			if err != nil {
+				t.Fatalf("Failed to find ReferenceGrants: %v", err)
+			}
+
+			buff := &bytes.Buffer{}
+			rp := &ReferencesPrinter{Writer: buff}
+			rp.PrintDescribeView(tc.target, references, referenceGrants)
+
+			got := buff.String()
+			if diff := cmp.Diff(common.YamlString(tc.want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+				t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, tc.want, diff)
+			}
+		})
+	}
+}
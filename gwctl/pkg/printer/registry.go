@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// Kind is a kind of resources which gwctl knows, like HTTPRoutes. The get and
+// describe commands have a subcommand for each registered Kind which can be
+// printed.
+//
+// The kinds built into gwctl set NewCommand, since their subcommands discover
+// and print the resources along with the resources related to them, with flags
+// of their own. Other kinds, like the CRDs of a vendor, set Discover and
+// PrintTable, and optionally PrintDescribe, to get subcommands which print the
+// resources on their own.
+type Kind struct {
+	// GroupKind of the resources. It is empty for kinds which are not
+	// Kubernetes resources, like listeners.
+	GroupKind schema.GroupKind
+	// Name is the plural name of the resources, which names the subcommands for
+	// the resources.
+	Name string
+	// Aliases are the other names by which users can refer to the resources,
+	// like their singular name and short names.
+	Aliases []string
+
+	// Discover returns the resources matching the filter. Only the Namespace,
+	// Name and Labels of the filter are set.
+	Discover func(ctx context.Context, k8sClients *common.K8sClients, filter resourcediscovery.Filter) ([]client.Object, error)
+	// PrintTable prints the resources as a table, with additional columns if
+	// wide is true. The resources are sorted by namespace and name.
+	PrintTable func(w io.Writer, c clock.PassiveClock, objs []client.Object, wide bool)
+	// PrintDescribe prints the describe view of the resources. The resources
+	// cannot be described if it is not set.
+	PrintDescribe func(w io.Writer, c clock.PassiveClock, objs []client.Object)
+
+	// NewCommand returns the subcommand for the kind of the command named
+	// commandName, like "get", or nil if the command does not support the kind.
+	// It takes precedence over Discover, PrintTable and PrintDescribe.
+	NewCommand func(f utils.Factory, out io.Writer, commandName string) *cobra.Command
+}
+
+// Printable returns true if the resources of the kind can be printed through
+// the Kind itself, as opposed to by the commands built into gwctl.
+func (k Kind) Printable() bool {
+	return k.Discover != nil && k.PrintTable != nil
+}
+
+var (
+	kindsMu sync.RWMutex
+	kinds   []Kind
+)
+
+// RegisterKind registers the kind. It panics if the name or an alias of the
+// kind is already registered, ignoring case, or if the kind sets only some of
+// Discover and PrintTable.
+func RegisterKind(kind Kind) {
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+	if (kind.Discover == nil) != (kind.PrintTable == nil) {
+		panic(fmt.Sprintf("kind %q must set both or neither of Discover and PrintTable", kind.Name))
+	}
+	for _, name := range append([]string{kind.Name}, kind.Aliases...) {
+		if _, ok := lookupKindLocked(name); ok {
+			panic(fmt.Sprintf("kind %q is already registered", name))
+		}
+	}
+	kinds = append(kinds, kind)
+}
+
+// UnregisterKind removes the kind with the name, if any, like one which was
+// registered for a test.
+func UnregisterKind(name string) {
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+	kinds = slices.DeleteFunc(kinds, func(kind Kind) bool { return kind.Name == name })
+}
+
+// Kinds returns the registered kinds, in the order in which they were
+// registered.
+func Kinds() []Kind {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+	return slices.Clone(kinds)
+}
+
+// LookupKind returns the kind with the name or alias, ignoring case.
+func LookupKind(name string) (Kind, bool) {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+	return lookupKindLocked(name)
+}
+
+func lookupKindLocked(name string) (Kind, bool) {
+	for _, kind := range kinds {
+		if strings.EqualFold(kind.Name, name) || slices.ContainsFunc(kind.Aliases, func(alias string) bool { return strings.EqualFold(alias, name) }) {
+			return kind, true
+		}
+	}
+	return Kind{}, false
+}
+
+// LookupGroupKind returns the kind of the resources with the GroupKind.
+func LookupGroupKind(groupKind schema.GroupKind) (Kind, bool) {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+	for _, kind := range kinds {
+		if !kind.GroupKind.Empty() && kind.GroupKind == groupKind {
+			return kind, true
+		}
+	}
+	return Kind{}, false
+}
+
+// PrintKind prints the resources of a Printable kind in the format.
+func PrintKind(w io.Writer, c clock.PassiveClock, kind Kind, objs []client.Object, format utils.OutputFormat) {
+	objs = sortObjects(objs)
+	switch format {
+	case utils.OutputFormatTable:
+		kind.PrintTable(w, c, objs, false)
+	case utils.OutputFormatWide:
+		kind.PrintTable(w, c, objs, true)
+	case utils.OutputFormatJSON, utils.OutputFormatYAML:
+		printablePayload, err := renderPrintableObject(objs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to form the printable payload %v\n", err)
+			os.Exit(1)
+		}
+		output, err := utils.MarshalWithFormat(printablePayload, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(w, string(output))
+	case utils.OutputFormatName:
+		for _, obj := range objs {
+			fmt.Fprintln(w, resourceName(obj))
+		}
+	case utils.OutputFormatHelmValues:
+		printHelmValues(w, objs)
+	default:
+		fmt.Fprintf(os.Stderr, "Unrecognized output format: %s\n", format)
+		os.Exit(1)
+	}
+}
+
+// PrintKindDescribe prints the describe view of the resources of a Printable
+// kind for the table format, or one YAML document per resource for the YAML
+// format.
+func PrintKindDescribe(w io.Writer, c clock.PassiveClock, kind Kind, objs []client.Object, format utils.OutputFormat) {
+	objs = sortObjects(objs)
+	switch format {
+	case utils.OutputFormatTable:
+		kind.PrintDescribe(w, c, objs)
+	case utils.OutputFormatYAML:
+		printYAMLDocuments(w, objs)
+	default:
+		fmt.Fprintf(os.Stderr, "Unrecognized output format for describe: %s\n", format)
+		os.Exit(1)
+	}
+}
+
+func sortObjects(objs []client.Object) []client.Object {
+	objs = slices.Clone(objs)
+	sort.Slice(objs, func(i, j int) bool {
+		return client.ObjectKeyFromObject(objs[i]).String() < client.ObjectKeyFromObject(objs[j]).String()
+	})
+	return objs
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+type SummaryPrinter struct {
+	io.Writer
+}
+
+// Print prints the summaries. groupByName is used as the heading of the column
+// containing the groups in the table output.
+func (sp *SummaryPrinter) Print(summaries []resourcediscovery.ResourceSummary, groupByName string, format utils.OutputFormat) {
+	switch format {
+	case utils.OutputFormatJSON, utils.OutputFormatYAML:
+		output, err := utils.MarshalWithFormat(summaries, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal the object %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprint(sp, string(output))
+	case utils.OutputFormatTable, utils.OutputFormatWide:
+		sp.printTable(summaries, groupByName)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown output format '%s' found\n", format)
+		os.Exit(1)
+	}
+}
+
+func (sp *SummaryPrinter) printTable(summaries []resourcediscovery.ResourceSummary, groupByName string) {
+	table := &Table{
+		ColumnNames:  []string{strings.ToUpper(groupByName), "GATEWAYS", "HTTPROUTES", "BACKENDS", "POLICIES", "MAX ROUTE FANOUT"},
+		UseSeparator: false,
+	}
+	for _, summary := range summaries {
+		row := []string{
+			summary.Group,
+			fmt.Sprintf("%d", summary.Gateways),
+			fmt.Sprintf("%d", summary.HTTPRoutes),
+			fmt.Sprintf("%d", summary.Backends),
+			fmt.Sprintf("%d", summary.Policies),
+			fmt.Sprintf("%d", summary.MaxRouteFanout),
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	table.Write(sp, 0)
+}
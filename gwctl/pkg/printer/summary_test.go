@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestSummaryPrinter_Print(t *testing.T) {
+	summaries := []resourcediscovery.ResourceSummary{
+		{Group: "(none)", Gateways: 1, Policies: 2},
+		{Group: "payments", Gateways: 1, HTTPRoutes: 3, Backends: 2, MaxRouteFanout: 2},
+	}
+
+	testcases := []struct {
+		name   string
+		format utils.OutputFormat
+		want   string
+	}{
+		{
+			name:   "table",
+			format: utils.OutputFormatTable,
+			want: `
+TEAM      GATEWAYS  HTTPROUTES  BACKENDS  POLICIES  MAX ROUTE FANOUT
+(none)    1         0           0         2         0
+payments  1         3           2         0         2
+`,
+		},
+		{
+			name:   "json",
+			format: utils.OutputFormatJSON,
+			want: `
+[
+  {
+    "group": "(none)",
+    "gateways": 1,
+    "httpRoutes": 0,
+    "backends": 0,
+    "policies": 2,
+    "maxRouteFanout": 0
+  },
+  {
+    "group": "payments",
+    "gateways": 1,
+    "httpRoutes": 3,
+    "backends": 2,
+    "policies": 0,
+    "maxRouteFanout": 2
+  }
+]`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			sp := &SummaryPrinter{Writer: buff}
+			sp.Print(summaries, "team", tc.format)
+
+			got := buff.String()
+			if diff := cmp.Diff(common.YamlString(tc.want), common.YamlString(got), common.YamlStringTransformer); diff != "" {
+				t.Errorf("Unexpected diff\ngot=\n%v\nwant=\n%v\ndiff (-want +got)=\n%v", got, tc.want, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenString
+	tokenPipe
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenEqual
+	tokenNotEqual
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type token struct {
+	typ tokenType
+	// text is the text of the token, unquoted for strings.
+	text string
+	// pos is the byte offset of the token in the query.
+	pos int
+}
+
+func (t token) String() string {
+	switch t.typ {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// lex splits the query into tokens. Identifiers are made of letters, digits
+// and the characters "_", "-", "." and "/", so that field paths like
+// spec.gatewayClassName and values like example.net/gateway-controller need no
+// quotes.
+func lex(query string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(query); {
+		c := query[pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+		case c == '|' && strings.HasPrefix(query[pos:], "||"):
+			tokens = append(tokens, token{typ: tokenOr, text: "||", pos: pos})
+			pos += 2
+		case c == '|':
+			tokens = append(tokens, token{typ: tokenPipe, text: "|", pos: pos})
+			pos++
+		case c == '&' && strings.HasPrefix(query[pos:], "&&"):
+			tokens = append(tokens, token{typ: tokenAnd, text: "&&", pos: pos})
+			pos += 2
+		case c == '=' && strings.HasPrefix(query[pos:], "=="):
+			tokens = append(tokens, token{typ: tokenEqual, text: "==", pos: pos})
+			pos += 2
+		case c == '!' && strings.HasPrefix(query[pos:], "!="):
+			tokens = append(tokens, token{typ: tokenNotEqual, text: "!=", pos: pos})
+			pos += 2
+		case c == '!':
+			tokens = append(tokens, token{typ: tokenNot, text: "!", pos: pos})
+			pos++
+		case c == '(':
+			tokens = append(tokens, token{typ: tokenLParen, text: "(", pos: pos})
+			pos++
+		case c == ')':
+			tokens = append(tokens, token{typ: tokenRParen, text: ")", pos: pos})
+			pos++
+		case c == '[':
+			tokens = append(tokens, token{typ: tokenLBracket, text: "[", pos: pos})
+			pos++
+		case c == ']':
+			tokens = append(tokens, token{typ: tokenRBracket, text: "]", pos: pos})
+			pos++
+		case c == '"' || c == '\'':
+			end := pos + 1
+			for end < len(query) && query[end] != c {
+				if query[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(query) {
+				return nil, fmt.Errorf("unterminated string at position %d", pos)
+			}
+			text := query[pos+1 : end]
+			if c == '"' {
+				unquoted, err := strconv.Unquote(query[pos : end+1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid string at position %d: %v", pos, err)
+				}
+				text = unquoted
+			}
+			tokens = append(tokens, token{typ: tokenString, text: text, pos: pos})
+			pos = end + 1
+		case isIdentChar(c):
+			end := pos
+			for end < len(query) && isIdentChar(query[end]) {
+				end++
+			}
+			tokens = append(tokens, token{typ: tokenIdent, text: query[pos:end], pos: pos})
+			pos = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, pos)
+		}
+	}
+	return append(tokens, token{typ: tokenEOF, pos: len(query)}), nil
+}
+
+func isIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.' || c == '/'
+}
+
+// parser is a recursive descent parser of queries:
+//
+//	query      = start { "|" stage }
+//	stage      = traversal | "where" "(" expression ")" | projection
+//	expression = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" expression ")" | comparison
+//	comparison = field ( "==" | "!=" ) value
+//	field      = ident [ "[" string "]" ]
+//	value      = ident | string
+//
+// A projection must be the last stage.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(typ tokenType, what string) (token, error) {
+	t := p.next()
+	if t.typ != typ {
+		return t, fmt.Errorf("expected %v but found %v at position %d", what, t, t.pos)
+	}
+	return t, nil
+}
+
+func (p *parser) parseQuery() (*Query, error) {
+	t, err := p.expect(tokenIdent, "kind of resources")
+	if err != nil {
+		return nil, err
+	}
+	start, ok := startKinds[strings.ToLower(t.text)]
+	if !ok {
+		return nil, fmt.Errorf("unknown kind of resources %q at position %d; must be one of [%v]", t.text, t.pos, strings.Join(startKindNames, ", "))
+	}
+	q := &Query{start: start}
+
+	for p.peek().typ == tokenPipe {
+		p.next()
+		if q.projection != ProjectionNone {
+			return nil, fmt.Errorf("projection %q must be the last stage", q.projection)
+		}
+		t, err := p.expect(tokenIdent, "stage")
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case t.text == "where":
+			if _, err := p.expect(tokenLParen, `"("`); err != nil {
+				return nil, err
+			}
+			expr, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(tokenRParen, `")"`); err != nil {
+				return nil, err
+			}
+			q.stages = append(q.stages, whereStage{expr: expr})
+		case traversals[t.text] != nil:
+			q.stages = append(q.stages, traversalStage{name: t.text})
+		case Projection(t.text) == ProjectionName || Projection(t.text) == ProjectionJSON:
+			q.projection = Projection(t.text)
+		default:
+			return nil, fmt.Errorf("unknown stage %q at position %d; must be where(...), a traversal in [%v] or a projection in [name, json]", t.text, t.pos, strings.Join(traversalNames, ", "))
+		}
+	}
+	if t := p.peek(); t.typ != tokenEOF {
+		return nil, fmt.Errorf(`expected "|" but found %v at position %d`, t, t.pos)
+	}
+	return q, nil
+}
+
+func (p *parser) parseExpression() (expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().typ == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpression{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().typ == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpression{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (expression, error) {
+	switch p.peek().typ {
+	case tokenNot:
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpression{expr: expr}, nil
+	case tokenLParen:
+		p.next()
+		expr, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *parser) parseComparison() (expression, error) {
+	t, err := p.expect(tokenIdent, "field")
+	if err != nil {
+		return nil, err
+	}
+	path := strings.Split(t.text, ".")
+	for _, segment := range path {
+		if segment == "" {
+			return nil, fmt.Errorf("invalid field %q at position %d", t.text, t.pos)
+		}
+	}
+	if p.peek().typ == tokenLBracket {
+		p.next()
+		key, err := p.expect(tokenString, "quoted key")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRBracket, `"]"`); err != nil {
+			return nil, err
+		}
+		path = append(path, key.text)
+	}
+
+	op := p.next()
+	if op.typ != tokenEqual && op.typ != tokenNotEqual {
+		return nil, fmt.Errorf(`expected "==" or "!=" but found %v at position %d`, op, op.pos)
+	}
+	value := p.next()
+	if value.typ != tokenIdent && value.typ != tokenString {
+		return nil, fmt.Errorf("expected value but found %v at position %d", value, value.pos)
+	}
+	return comparison{path: path, value: value.text, negate: op.typ == tokenNotEqual}, nil
+}
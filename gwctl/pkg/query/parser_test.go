@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package query
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLex(t *testing.T) {
+	tokens, err := lex(`gateways|where(labels["app.kubernetes.io/name"]!='foo' || !(name==bar-1 && kind=="Gate\"way"))`)
+	if err != nil {
+		t.Fatalf("lex() failed: %v", err)
+	}
+	var got []string
+	for _, token := range tokens {
+		got = append(got, token.String())
+	}
+	want := []string{
+		`"gateways"`, `"|"`, `"where"`, `"("`, `"labels"`, `"["`, `"app.kubernetes.io/name"`, `"]"`, `"!="`, `"foo"`,
+		`"||"`, `"!"`, `"("`, `"name"`, `"=="`, `"bar-1"`, `"&&"`, `"kind"`, `"=="`, `"Gate\"way"`, `")"`, `")"`,
+		"end of query",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("lex() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
+
+func TestParse(t *testing.T) {
+	testcases := []struct {
+		query          string
+		wantStages     int
+		wantProjection Projection
+		wantErr        string
+	}{
+		{
+			query: "gateways",
+		},
+		{
+			query:          "Gateways | attachedRoutes | backends | where(kind==\"Service\") | name",
+			wantStages:     3,
+			wantProjection: ProjectionName,
+		},
+		{
+			query:          "policies | parents | json",
+			wantStages:     1,
+			wantProjection: ProjectionJSON,
+		},
+		{
+			query:      `httproutes | where((namespace==default || namespace=="prod") && !labels["team"]==blue)`,
+			wantStages: 1,
+		},
+		{
+			query:   "",
+			wantErr: "expected kind of resources but found end of query at position 0",
+		},
+		{
+			query:   "services",
+			wantErr: `unknown kind of resources "services" at position 0; must be one of [backends, gatewayclasses, gateways, httproutes, namespaces, policies, routes]`,
+		},
+		{
+			query:   "gateways | children",
+			wantErr: `unknown stage "children" at position 11; must be where(...), a traversal in [attachedRoutes, backends, parents, policies] or a projection in [name, json]`,
+		},
+		{
+			query:   "gateways | name | attachedRoutes",
+			wantErr: `projection "name" must be the last stage`,
+		},
+		{
+			query:   "gateways attachedRoutes",
+			wantErr: `expected "|" but found "attachedRoutes" at position 9`,
+		},
+		{
+			query:   "gateways | where(name)",
+			wantErr: `expected "==" or "!=" but found ")" at position 21`,
+		},
+		{
+			query:   "gateways | where(name==)",
+			wantErr: `expected value but found ")" at position 23`,
+		},
+		{
+			query:   "gateways | where(name==foo",
+			wantErr: `expected ")" but found end of query at position 26`,
+		},
+		{
+			query:   "gateways | where(labels[team]==blue)",
+			wantErr: `expected quoted key but found "team" at position 24`,
+		},
+		{
+			query:   "gateways | where(spec..name==foo)",
+			wantErr: `invalid field "spec..name" at position 17`,
+		},
+		{
+			query:   `gateways | where(name=="foo)`,
+			wantErr: "unterminated string at position 23",
+		},
+		{
+			query:   "gateways | where(name=foo)",
+			wantErr: `unexpected character '=' at position 21`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := Parse(tc.query)
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Fatalf("Parse() returned error %q, want %q", gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(q.stages) != tc.wantStages || q.Projection() != tc.wantProjection {
+				t.Errorf("Parse() returned %v stages and projection %q, want %v stages and projection %q", len(q.stages), q.Projection(), tc.wantStages, tc.wantProjection)
+			}
+		})
+	}
+}
@@ -0,0 +1,383 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package query implements a small pipeline language to traverse the resource
+// graph, like:
+//
+//	gateways | attachedRoutes | backends | where(kind=="Service") | name
+//
+// A query starts with the set of all resources of a kind, which each stage
+// then transforms: traversals replace each resource by the resources connected
+// to it, where() keeps the resources matching an expression, and a final
+// projection selects how the resources are printed.
+package query
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+)
+
+// Projection selects how the results of a query are printed.
+type Projection string
+
+const (
+	// ProjectionNone prints the kind and the namespaced name of each result.
+	ProjectionNone Projection = ""
+	// ProjectionName prints the namespaced name of each result.
+	ProjectionName Projection = "name"
+	// ProjectionJSON prints each result as a JSON object.
+	ProjectionJSON Projection = "json"
+)
+
+// Query is a parsed query.
+type Query struct {
+	start      startKind
+	stages     []stage
+	projection Projection
+}
+
+// Projection returns the projection of the query, which is the last stage.
+func (q *Query) Projection() Projection {
+	return q.projection
+}
+
+// Parse parses the query. Errors report the position of the invalid part of
+// the query.
+func Parse(query string) (*Query, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	return p.parseQuery()
+}
+
+// Result is a resource selected by a query.
+type Result struct {
+	// Ref identifies the resource.
+	Ref common.ObjRef
+	// Object is the resource itself.
+	Object client.Object
+
+	// node is the node of the resource in the resource model, through which it
+	// is traversed.
+	node any
+}
+
+// Run runs the query against the resource model. The results are unique, and
+// sorted by kind, namespace and name within the results of each resource they
+// were traversed from.
+func (q *Query) Run(resourceModel *resourcediscovery.ResourceModel) []Result {
+	results := q.start(resourceModel)
+	sortResults(results)
+	for _, stage := range q.stages {
+		results = stage.apply(resourceModel, results)
+	}
+	return results
+}
+
+type stage interface {
+	apply(resourceModel *resourcediscovery.ResourceModel, results []Result) []Result
+}
+
+// startKind returns every resource of a kind.
+type startKind func(resourceModel *resourcediscovery.ResourceModel) []Result
+
+var startKinds = map[string]startKind{
+	"gatewayclasses": func(rm *resourcediscovery.ResourceModel) []Result { return mapResults(rm.GatewayClasses) },
+	"gateways":       func(rm *resourcediscovery.ResourceModel) []Result { return mapResults(rm.Gateways) },
+	"httproutes":     func(rm *resourcediscovery.ResourceModel) []Result { return mapResults(rm.HTTPRoutes) },
+	"routes": func(rm *resourcediscovery.ResourceModel) []Result {
+		return append(mapResults(rm.HTTPRoutes), mapResults(rm.OtherRoutes)...)
+	},
+	"backends":   func(rm *resourcediscovery.ResourceModel) []Result { return mapResults(rm.Backends) },
+	"namespaces": func(rm *resourcediscovery.ResourceModel) []Result { return mapResults(rm.Namespaces) },
+	"policies":   func(rm *resourcediscovery.ResourceModel) []Result { return mapResults(rm.Policies) },
+}
+
+var startKindNames = sortedKeys(startKinds)
+
+// traversal returns the resources connected to the resource of the node.
+type traversal func(resourceModel *resourcediscovery.ResourceModel, node any) []Result
+
+var traversals = map[string]traversal{
+	// attachedRoutes returns the routes attached to a Gateway.
+	"attachedRoutes": func(rm *resourcediscovery.ResourceModel, node any) []Result {
+		gatewayNode, ok := node.(*resourcediscovery.GatewayNode)
+		if !ok {
+			return nil
+		}
+		results := mapResults(gatewayNode.HTTPRoutes)
+		for _, otherRouteNode := range rm.OtherRoutes {
+			for _, parent := range otherRouteNode.Gateways {
+				if parent == gatewayNode {
+					results = append(results, newResult(otherRouteNode))
+				}
+			}
+		}
+		return results
+	},
+	// parents returns the Gateways of a route, the GatewayClass of a Gateway,
+	// the routes referencing a Backend and the target of a policy.
+	"parents": func(_ *resourcediscovery.ResourceModel, node any) []Result {
+		switch node := node.(type) {
+		case *resourcediscovery.GatewayNode:
+			if node.GatewayClass != nil {
+				return []Result{newResult(node.GatewayClass)}
+			}
+		case *resourcediscovery.HTTPRouteNode:
+			return mapResults(node.Gateways)
+		case *resourcediscovery.OtherRouteNode:
+			return mapResults(node.Gateways)
+		case *resourcediscovery.BackendNode:
+			var results []Result
+			for _, routeNode := range node.Routes {
+				results = append(results, newResult(routeNode))
+			}
+			return results
+		case *resourcediscovery.PolicyNode:
+			return policyTarget(node)
+		}
+		return nil
+	},
+	// backends returns the Backends referenced by a route.
+	"backends": func(_ *resourcediscovery.ResourceModel, node any) []Result {
+		switch node := node.(type) {
+		case *resourcediscovery.HTTPRouteNode:
+			return mapResults(node.Backends)
+		case *resourcediscovery.OtherRouteNode:
+			return mapResults(node.Backends)
+		}
+		return nil
+	},
+	// policies returns the policies directly attached to a resource.
+	"policies": func(_ *resourcediscovery.ResourceModel, node any) []Result {
+		switch node := node.(type) {
+		case *resourcediscovery.GatewayClassNode:
+			return mapResults(node.Policies)
+		case *resourcediscovery.GatewayNode:
+			return mapResults(node.Policies)
+		case *resourcediscovery.HTTPRouteNode:
+			return mapResults(node.Policies)
+		case *resourcediscovery.OtherRouteNode:
+			return mapResults(node.Policies)
+		case *resourcediscovery.BackendNode:
+			return mapResults(node.Policies)
+		case *resourcediscovery.NamespaceNode:
+			return mapResults(node.Policies)
+		}
+		return nil
+	},
+}
+
+var traversalNames = sortedKeys(traversals)
+
+// policyTarget returns the resource which the policy is directly attached to,
+// if it is in the resource model.
+func policyTarget(policyNode *resourcediscovery.PolicyNode) []Result {
+	switch {
+	case policyNode.GatewayClass != nil:
+		return []Result{newResult(policyNode.GatewayClass)}
+	case policyNode.Gateway != nil:
+		return []Result{newResult(policyNode.Gateway)}
+	case policyNode.HTTPRoute != nil:
+		return []Result{newResult(policyNode.HTTPRoute)}
+	case policyNode.OtherRoute != nil:
+		return []Result{newResult(policyNode.OtherRoute)}
+	case policyNode.Backend != nil:
+		return []Result{newResult(policyNode.Backend)}
+	case policyNode.Namespace != nil:
+		return []Result{newResult(policyNode.Namespace)}
+	}
+	return nil
+}
+
+// traversalStage replaces each result by the resources connected to it. The
+// connected resources of each result are sorted, and resources reached from
+// several results are only kept the first time.
+type traversalStage struct {
+	name string
+}
+
+func (s traversalStage) apply(resourceModel *resourcediscovery.ResourceModel, results []Result) []Result {
+	var next []Result
+	seen := make(map[common.ObjRef]bool)
+	for _, result := range results {
+		connected := traversals[s.name](resourceModel, result.node)
+		sortResults(connected)
+		for _, c := range connected {
+			if !seen[c.Ref] {
+				seen[c.Ref] = true
+				next = append(next, c)
+			}
+		}
+	}
+	return next
+}
+
+// whereStage keeps the results matching the expression.
+type whereStage struct {
+	expr expression
+}
+
+func (s whereStage) apply(_ *resourcediscovery.ResourceModel, results []Result) []Result {
+	var next []Result
+	for _, result := range results {
+		if s.expr.matches(newFields(result)) {
+			next = append(next, result)
+		}
+	}
+	return next
+}
+
+type expression interface {
+	matches(f *fields) bool
+}
+
+type andExpression struct{ left, right expression }
+
+func (e andExpression) matches(f *fields) bool { return e.left.matches(f) && e.right.matches(f) }
+
+type orExpression struct{ left, right expression }
+
+func (e orExpression) matches(f *fields) bool { return e.left.matches(f) || e.right.matches(f) }
+
+type notExpression struct{ expr expression }
+
+func (e notExpression) matches(f *fields) bool { return !e.expr.matches(f) }
+
+// comparison compares a field with a value. Fields which do not exist compare
+// equal to the empty string.
+type comparison struct {
+	path   []string
+	value  string
+	negate bool
+}
+
+func (c comparison) matches(f *fields) bool {
+	return (f.get(c.path) == c.value) != c.negate
+}
+
+// fields resolves the fields of a result. kind, group, namespace and name are
+// those of the resource, labels and annotations are shorthands for those of
+// its metadata, and any other path is looked up in the resource.
+type fields struct {
+	result  Result
+	content map[string]interface{}
+}
+
+func newFields(result Result) *fields {
+	return &fields{result: result}
+}
+
+func (f *fields) get(path []string) string {
+	if len(path) == 1 {
+		switch path[0] {
+		case "kind":
+			return f.result.Ref.Kind
+		case "group":
+			return f.result.Ref.Group
+		case "namespace":
+			return f.result.Ref.Namespace
+		case "name":
+			return f.result.Ref.Name
+		}
+	}
+	if path[0] == "labels" || path[0] == "annotations" {
+		path = append([]string{"metadata"}, path...)
+	}
+
+	if f.content == nil {
+		f.content = unstructuredContent(f.result.Object)
+	}
+	value, ok, err := unstructured.NestedFieldNoCopy(f.content, path...)
+	if err != nil || !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// unstructuredContent returns the content of the object as a map, or an empty
+// map if it cannot be converted.
+func unstructuredContent(object client.Object) map[string]interface{} {
+	if u, ok := object.(*unstructured.Unstructured); ok {
+		return u.Object
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	return content
+}
+
+// newResult returns the result for a node of the resource model.
+func newResult(node interface{ ClientObject() client.Object }) Result {
+	object := node.ClientObject()
+	ref := common.ObjRef{Namespace: object.GetNamespace(), Name: object.GetName()}
+	switch node.(type) {
+	case *resourcediscovery.GatewayClassNode:
+		ref.Group, ref.Kind = gatewayv1.GroupName, "GatewayClass"
+	case *resourcediscovery.GatewayNode:
+		ref.Group, ref.Kind = gatewayv1.GroupName, "Gateway"
+	case *resourcediscovery.HTTPRouteNode:
+		ref.Group, ref.Kind = gatewayv1.GroupName, "HTTPRoute"
+	case *resourcediscovery.NamespaceNode:
+		ref.Kind = "Namespace"
+	default:
+		gvk := object.GetObjectKind().GroupVersionKind()
+		ref.Group, ref.Kind = gvk.Group, gvk.Kind
+	}
+	return Result{Ref: ref, Object: object, node: node}
+}
+
+// mapResults returns the results for the nodes of a map of the resource model.
+func mapResults[K comparable, N interface{ ClientObject() client.Object }](nodes map[K]N) []Result {
+	results := make([]Result, 0, len(nodes))
+	for _, node := range nodes {
+		results = append(results, newResult(node))
+	}
+	return results
+}
+
+func sortResults(results []Result) {
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i].Ref, results[j].Ref
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package query
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestRun(t *testing.T) {
+	gateway := func(namespace, name string, labels map[string]string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+		}
+	}
+	httpRoute := func(namespace, name, gatewayNamespace, gatewayName string, backends ...string) *gatewayv1.HTTPRoute {
+		route := &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{
+						Name:      gatewayv1.ObjectName(gatewayName),
+						Namespace: common.PtrTo(gatewayv1.Namespace(gatewayNamespace)),
+					}},
+				},
+			},
+		}
+		rule := gatewayv1.HTTPRouteRule{}
+		for _, backend := range backends {
+			rule.BackendRefs = append(rule.BackendRefs, gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+				Kind: common.PtrTo(gatewayv1.Kind("Service")),
+				Name: gatewayv1.ObjectName(backend),
+			}}})
+		}
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{rule}
+		return route
+	}
+	service := func(namespace, name string) *corev1.Service {
+		return &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		common.NamespaceForTest("prod"),
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-gatewayclass"},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: "example.net/gateway-controller"},
+		},
+		gateway("default", "foo-gateway", nil),
+		gateway("prod", "bar-gateway", map[string]string{"app.kubernetes.io/name": "bar", "team": "blue"}),
+		httpRoute("default", "route-1", "default", "foo-gateway", "svc-b", "svc-a"),
+		httpRoute("default", "route-2", "default", "foo-gateway", "svc-a"),
+		httpRoute("prod", "route-3", "prod", "bar-gateway", "svc-c"),
+		service("default", "svc-a"),
+		service("default", "svc-b"),
+		service("prod", "svc-c"),
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "timeoutpolicies.bar.com",
+				Labels: map[string]string{gatewayv1alpha2.PolicyLabelKey: "direct"},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    "bar.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "timeoutpolicies",
+					Kind:   "TimeoutPolicy",
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "bar.com/v1",
+				"kind":       "TimeoutPolicy",
+				"metadata": map[string]interface{}{
+					"name":      "timeout-policy",
+					"namespace": "prod",
+				},
+				"spec": map[string]interface{}{
+					"targetRef": map[string]interface{}{
+						"group": "gateway.networking.k8s.io",
+						"kind":  "Gateway",
+						"name":  "bar-gateway",
+					},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := resourcediscovery.Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverAllResources(resourcediscovery.Filter{Labels: labels.Everything()})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	testcases := []struct {
+		query string
+		want  []string
+	}{
+		{
+			query: "gateways",
+			want:  []string{"Gateway default/foo-gateway", "Gateway prod/bar-gateway"},
+		},
+		{
+			query: "gateways | attachedRoutes",
+			want:  []string{"HTTPRoute default/route-1", "HTTPRoute default/route-2", "HTTPRoute prod/route-3"},
+		},
+		{
+			// Backends referenced by several routes are only listed once.
+			query: `gateways | attachedRoutes | backends | where(kind=="Service")`,
+			want:  []string{"Service default/svc-a", "Service default/svc-b", "Service prod/svc-c"},
+		},
+		{
+			query: "backends | where(name==svc-a) | parents",
+			want:  []string{"HTTPRoute default/route-1", "HTTPRoute default/route-2"},
+		},
+		{
+			query: "httproutes | where(namespace==default) | parents | parents",
+			want:  []string{"GatewayClass /foo-gatewayclass"},
+		},
+		{
+			query: `gateways | where(labels["app.kubernetes.io/name"]==bar)`,
+			want:  []string{"Gateway prod/bar-gateway"},
+		},
+		{
+			query: "gateways | where(labels.team!=blue)",
+			want:  []string{"Gateway default/foo-gateway"},
+		},
+		{
+			query: "gateways | where(!(namespace==prod || name==foo-gateway))",
+		},
+		{
+			query: "gateways | where(namespace==prod && spec.gatewayClassName==foo-gatewayclass)",
+			want:  []string{"Gateway prod/bar-gateway"},
+		},
+		{
+			query: "gateways | policies",
+			want:  []string{"TimeoutPolicy prod/timeout-policy"},
+		},
+		{
+			query: "policies | parents | attachedRoutes | backends",
+			want:  []string{"Service prod/svc-c"},
+		},
+		{
+			query: "namespaces",
+			want:  []string{"Namespace /default", "Namespace /prod"},
+		},
+		{
+			// Traversals which do not apply to a kind of resources return nothing.
+			query: "backends | attachedRoutes",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := Parse(tc.query)
+			if err != nil {
+				t.Fatalf("Parse() failed: %v", err)
+			}
+			var got []string
+			for _, result := range q.Run(resourceModel) {
+				got = append(got, result.Ref.Kind+" "+result.Ref.Namespace+"/"+result.Ref.Name)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Run() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
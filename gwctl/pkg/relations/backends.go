@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package relations
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+// BackendResolution describes how a backendRef resolves all the way down to
+// the endpoints which serve its traffic.
+type BackendResolution struct {
+	// Backend is the Backend referenced by the backendRef.
+	Backend common.ObjRef
+	// Service is the Service referenced by the backendRef. It is nil if the
+	// Backend is not a Service, or if the Service does not exist.
+	Service *corev1.Service
+	// Port is the port referenced by the backendRef, if any.
+	Port *gatewayv1.PortNumber
+	// ServicePort is the port of the Service matching Port. It is nil if Port is
+	// not specified or if the Service has no such port.
+	ServicePort *corev1.ServicePort
+	// ReadyEndpoints and NotReadyEndpoints count the endpoints of the
+	// EndpointSlices of the Service which serve ServicePort, or any port of the
+	// Service if Port is not specified.
+	ReadyEndpoints    int
+	NotReadyEndpoints int
+}
+
+// PortExists returns true if the backendRef does not specify a port, or if the
+// Service has the port specified by the backendRef.
+func (r BackendResolution) PortExists() bool {
+	return r.Port == nil || r.ServicePort != nil
+}
+
+// ResolveBackend resolves the Backend ref through the Service and its
+// EndpointSlices. port is the port specified by the backendRef, if any. service
+// is nil if the Service does not exist, and endpointSlices may contain
+// EndpointSlices of other Services, which are ignored.
+func ResolveBackend(ref common.ObjRef, port *gatewayv1.PortNumber, service *corev1.Service, endpointSlices []discoveryv1.EndpointSlice) BackendResolution {
+	result := BackendResolution{Backend: ref, Port: port}
+	if ref.Group != "" || ref.Kind != "Service" || service == nil {
+		return result
+	}
+	result.Service = service
+
+	if port != nil {
+		for i, servicePort := range service.Spec.Ports {
+			if servicePort.Port == int32(*port) {
+				result.ServicePort = &service.Spec.Ports[i]
+				break
+			}
+		}
+		if result.ServicePort == nil {
+			return result
+		}
+	}
+
+	for _, endpointSlice := range endpointSlices {
+		if endpointSlice.GetNamespace() != service.GetNamespace() || endpointSlice.GetLabels()[discoveryv1.LabelServiceName] != service.GetName() {
+			continue
+		}
+		if result.ServicePort != nil && !endpointSliceServesPort(endpointSlice, *result.ServicePort) {
+			continue
+		}
+		for _, endpoint := range endpointSlice.Endpoints {
+			// A nil ready condition must be interpreted as ready.
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				result.ReadyEndpoints++
+			} else {
+				result.NotReadyEndpoints++
+			}
+		}
+	}
+	return result
+}
+
+// endpointSliceServesPort returns true if the EndpointSlice has the port of the
+// Service. The ports of an EndpointSlice are named after the ports of the
+// Service.
+func endpointSliceServesPort(endpointSlice discoveryv1.EndpointSlice, servicePort corev1.ServicePort) bool {
+	for _, port := range endpointSlice.Ports {
+		if port.Name != nil && *port.Name == servicePort.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// BackendPortIssue describes a backendRef of a route which references a port
+// that the Service it references does not have.
+type BackendPortIssue struct {
+	// Backend is the Service referenced by the backendRef.
+	Backend common.ObjRef
+	// Port is the port referenced by the backendRef.
+	Port gatewayv1.PortNumber
+	// ServicePorts are the ports which the Service has.
+	ServicePorts []int32
+}
+
+// FindInvalidBackendPorts returns the backendRefs of the HTTPRoute, including
+// those of its RequestMirror filters, which reference a port that the Service
+// they reference does not have. Each Service and port is reported once.
+// backendRefs which reference Services missing from services, Services of type
+// ExternalName, Services without any port or Backends of other kinds are not
+// checked.
+func FindInvalidBackendPorts(route gatewayv1.HTTPRoute, services []corev1.Service) []BackendPortIssue {
+	var backendRefs []gatewayv1.BackendObjectReference
+	for _, rule := range route.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			backendRefs = append(backendRefs, backendRef.BackendObjectReference)
+		}
+		for _, filter := range rule.Filters {
+			if filter.Type == gatewayv1.HTTPRouteFilterRequestMirror && filter.RequestMirror != nil {
+				backendRefs = append(backendRefs, filter.RequestMirror.BackendRef)
+			}
+		}
+	}
+
+	var result []BackendPortIssue
+	type backendPort struct {
+		backend common.ObjRef
+		port    gatewayv1.PortNumber
+	}
+	seen := make(map[backendPort]bool)
+	for _, backendRef := range backendRefs {
+		if backendRef.Port == nil {
+			continue
+		}
+		refs := FindBackendRefsForRoute(route.GetNamespace(), []gatewayv1.BackendObjectReference{backendRef})
+		ref := refs[0]
+		if ref.Group != "" || (ref.Kind != "" && ref.Kind != "Service") {
+			continue
+		}
+		ref.Kind = "Service"
+		service := findService(services, ref.Namespace, ref.Name)
+		if service == nil || service.Spec.Type == corev1.ServiceTypeExternalName || len(service.Spec.Ports) == 0 {
+			continue
+		}
+		if resolution := ResolveBackend(ref, backendRef.Port, service, nil); resolution.PortExists() {
+			continue
+		}
+		key := backendPort{backend: ref, port: *backendRef.Port}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		issue := BackendPortIssue{Backend: ref, Port: *backendRef.Port}
+		for _, servicePort := range service.Spec.Ports {
+			issue.ServicePorts = append(issue.ServicePorts, servicePort.Port)
+		}
+		result = append(result, issue)
+	}
+	return result
+}
+
+// findService returns the Service with the namespace and name, or nil if there
+// is none.
+func findService(services []corev1.Service, namespace, name string) *corev1.Service {
+	for i := range services {
+		if services[i].GetNamespace() == namespace && services[i].GetName() == name {
+			return &services[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package relations
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Conflict describes a hostname which is served on the same address and port
+// by routes attached to different Gateways.
+type Conflict struct {
+	Address  string
+	Port     int32
+	Hostname string
+	// Gateways are the Gateways which serve the hostname on the address and port.
+	Gateways []types.NamespacedName
+	// Routes are the HTTPRoutes which serve the hostname through these Gateways.
+	Routes []types.NamespacedName
+}
+
+// DetectCrossGatewayHostnameConflicts returns the hostnames which are served by
+// routes attached to more than one Gateway bound to the same address and port.
+// Requests for such hostnames may be ambiguous at the infrastructure layer.
+// Gateways without any addresses in their status are ignored since the address
+// they are bound to is not known.
+func DetectCrossGatewayHostnameConflicts(gateways []gatewayv1.Gateway, routes []gatewayv1.HTTPRoute) []Conflict {
+	type conflictKey struct {
+		address  string
+		port     int32
+		hostname string
+	}
+	gatewaysByKey := make(map[conflictKey]map[types.NamespacedName]bool)
+	routesByKey := make(map[conflictKey]map[types.NamespacedName]bool)
+
+	gatewaysByName := make(map[types.NamespacedName]gatewayv1.Gateway)
+	for _, gateway := range gateways {
+		namespace := gateway.GetNamespace()
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		gatewaysByName[types.NamespacedName{Namespace: namespace, Name: gateway.GetName()}] = gateway
+	}
+
+	for _, route := range routes {
+		routeName := types.NamespacedName{Namespace: route.GetNamespace(), Name: route.GetName()}
+		for _, parentRef := range route.Spec.ParentRefs {
+			gatewayName := gatewayRefForParentRef(route.GetNamespace(), parentRef)
+			gateway, ok := gatewaysByName[gatewayName]
+			if !ok {
+				continue
+			}
+
+			for _, listener := range gateway.Spec.Listeners {
+				if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+					continue
+				}
+				if parentRef.Port != nil && *parentRef.Port != listener.Port {
+					continue
+				}
+				for _, hostname := range IntersectHostnames(listener.Hostname, route.Spec.Hostnames) {
+					for _, address := range gateway.Status.Addresses {
+						key := conflictKey{address: address.Value, port: int32(listener.Port), hostname: hostname}
+						if gatewaysByKey[key] == nil {
+							gatewaysByKey[key] = make(map[types.NamespacedName]bool)
+							routesByKey[key] = make(map[types.NamespacedName]bool)
+						}
+						gatewaysByKey[key][gatewayName] = true
+						routesByKey[key][routeName] = true
+					}
+				}
+			}
+		}
+	}
+
+	var result []Conflict
+	for key, gatewaySet := range gatewaysByKey {
+		if len(gatewaySet) < 2 {
+			continue
+		}
+		result = append(result, Conflict{
+			Address:  key.address,
+			Port:     key.port,
+			Hostname: key.hostname,
+			Gateways: sortedNamespacedNames(gatewaySet),
+			Routes:   sortedNamespacedNames(routesByKey[key]),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Address != b.Address {
+			return a.Address < b.Address
+		}
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		return a.Hostname < b.Hostname
+	})
+	return result
+}
+
+func sortedNamespacedNames(set map[types.NamespacedName]bool) []types.NamespacedName {
+	result := make([]types.NamespacedName, 0, len(set))
+	for name := range set {
+		result = append(result, name)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package relations
+
+import (
+	"sort"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GraphEdge is a reference from one resource to another, like from an
+// HTTPRoute to its parent Gateway.
+type GraphEdge struct {
+	From common.ObjRef `json:"from"`
+	To   common.ObjRef `json:"to"`
+	// Role is the role of a reference from a route to a Backend, which tells
+	// apart the Backends receiving the traffic from those only receiving a
+	// mirrored copy of it. It is empty for the other references.
+	Role BackendRefRole `json:"role,omitempty"`
+}
+
+// ResourceGraph is the graph of references between resources. Resources which
+// are referenced but do not exist, like a missing Backend, are part of the
+// graph too.
+type ResourceGraph struct {
+	Edges []GraphEdge `json:"edges"`
+}
+
+// BuildResourceGraph returns the graph with an edge from each HTTPRoute to its
+// parents and Backends, from each Gateway to its GatewayClass, and from each
+// Policy to its target. An HTTPRoute referencing a Backend both in its rules and
+// in a RequestMirror filter has an edge to the Backend for each role.
+func BuildResourceGraph(gateways []gatewayv1.Gateway, routes []gatewayv1.HTTPRoute, policies []policymanager.Policy) *ResourceGraph {
+	graph := &ResourceGraph{}
+	for _, gateway := range gateways {
+		graph.Edges = append(graph.Edges, GraphEdge{
+			From: common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: gateway.GetNamespace(), Name: gateway.GetName()},
+			To:   common.ObjRef{Group: gatewayv1.GroupName, Kind: "GatewayClass", Name: FindGatewayClassNameForGateway(gateway)},
+		})
+	}
+	for _, route := range routes {
+		routeRef := common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: route.GetNamespace(), Name: route.GetName()}
+		for _, parentRef := range route.Spec.ParentRefs {
+			graph.Edges = append(graph.Edges, GraphEdge{From: routeRef, To: parentObjRef(route.GetNamespace(), parentRef)})
+		}
+		for _, roleBackendRef := range FindRoleBackendRefsForHTTPRoute(route) {
+			backendRef := roleBackendRef.Backend
+			// backendRefs without a kind refer to Services.
+			if backendRef.Kind == "" {
+				backendRef.Kind = "Service"
+			}
+			graph.Edges = append(graph.Edges, GraphEdge{From: routeRef, To: backendRef, Role: roleBackendRef.Role})
+		}
+	}
+	for _, policy := range policies {
+		policyRef := common.ObjRef{
+			Group:     policy.Unstructured().GroupVersionKind().Group,
+			Kind:      policy.Unstructured().GetKind(),
+			Namespace: policy.Unstructured().GetNamespace(),
+			Name:      policy.Unstructured().GetName(),
+		}
+		graph.Edges = append(graph.Edges, GraphEdge{From: policyRef, To: policy.TargetRef()})
+	}
+	return graph
+}
+
+// parentObjRef returns the parent referenced by the parentRef of a route
+// within the namespace.
+func parentObjRef(namespace string, parentRef gatewayv1.ParentReference) common.ObjRef {
+	ref := common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: namespace, Name: string(parentRef.Name)}
+	if ref.Namespace == "" {
+		ref.Namespace = metav1.NamespaceDefault
+	}
+	if parentRef.Group != nil {
+		ref.Group = string(*parentRef.Group)
+	}
+	if parentRef.Kind != nil {
+		ref.Kind = string(*parentRef.Kind)
+	}
+	if parentRef.Namespace != nil {
+		ref.Namespace = string(*parentRef.Namespace)
+	}
+	return ref
+}
+
+// FindPath returns the shortest path of references connecting the resources,
+// starting with from and ending with to, like an HTTPRoute, its Gateway and
+// the GatewayClass of the Gateway. References are followed in both directions,
+// so a Backend is connected to the Gateways of the HTTPRoutes referencing it.
+// Among paths of the same length, the one through the resources which sort
+// first is returned. The result is nil if the resources are not connected.
+func FindPath(from, to common.ObjRef, graph *ResourceGraph) []common.ObjRef {
+	neighbors := make(map[common.ObjRef][]common.ObjRef)
+	for _, edge := range graph.Edges {
+		neighbors[edge.From] = append(neighbors[edge.From], edge.To)
+		neighbors[edge.To] = append(neighbors[edge.To], edge.From)
+	}
+	if _, ok := neighbors[from]; !ok {
+		return nil
+	}
+	for _, refs := range neighbors {
+		sort.Slice(refs, func(i, j int) bool { return objRefLess(refs[i], refs[j]) })
+	}
+
+	// Breadth-first search, remembering through which resource each resource
+	// was first reached.
+	previous := map[common.ObjRef]common.ObjRef{from: from}
+	queue := []common.ObjRef{from}
+	for len(queue) != 0 && !contains(previous, to) {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range neighbors[current] {
+			if contains(previous, next) {
+				continue
+			}
+			previous[next] = current
+			queue = append(queue, next)
+		}
+	}
+	if !contains(previous, to) {
+		return nil
+	}
+
+	path := []common.ObjRef{to}
+	for current := to; current != from; {
+		current = previous[current]
+		path = append(path, current)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+func contains(m map[common.ObjRef]common.ObjRef, ref common.ObjRef) bool {
+	_, ok := m[ref]
+	return ok
+}
+
+func objRefLess(a, b common.ObjRef) bool {
+	if a.Group != b.Group {
+		return a.Group < b.Group
+	}
+	if a.Kind != b.Kind {
+		return a.Kind < b.Kind
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package relations
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestFindPath(t *testing.T) {
+	gateway := func(namespace, name, className string) gatewayv1.Gateway {
+		return gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: gatewayv1.ObjectName(className)},
+		}
+	}
+	route := func(name string, parents []string, backends []string) gatewayv1.HTTPRoute {
+		route := gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+		for _, parent := range parents {
+			route.Spec.ParentRefs = append(route.Spec.ParentRefs, gatewayv1.ParentReference{Name: gatewayv1.ObjectName(parent)})
+		}
+		rule := gatewayv1.HTTPRouteRule{}
+		for _, backend := range backends {
+			rule.BackendRefs = append(rule.BackendRefs, gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(backend)},
+			}})
+		}
+		route.Spec.Rules = []gatewayv1.HTTPRouteRule{rule}
+		return route
+	}
+	graph := BuildResourceGraph(
+		[]gatewayv1.Gateway{
+			gateway("default", "foo-gateway", "foo-gatewayclass"),
+			gateway("default", "bar-gateway", "bar-gatewayclass"),
+			gateway("default", "baz-gateway", "baz-gatewayclass"),
+		},
+		[]gatewayv1.HTTPRoute{
+			route("foo-httproute", []string{"foo-gateway"}, []string{"foo-svc"}),
+			route("bar-httproute", []string{"bar-gateway"}, []string{"foo-svc", "bar-svc"}),
+		},
+		nil,
+	)
+
+	gatewayClassRef := func(name string) common.ObjRef {
+		return common.ObjRef{Group: gatewayv1.GroupName, Kind: "GatewayClass", Name: name}
+	}
+	gatewayRef := func(name string) common.ObjRef {
+		return common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "default", Name: name}
+	}
+	routeRef := func(name string) common.ObjRef {
+		return common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "default", Name: name}
+	}
+	serviceRef := func(name string) common.ObjRef {
+		return common.ObjRef{Kind: "Service", Namespace: "default", Name: name}
+	}
+
+	testcases := []struct {
+		name string
+		from common.ObjRef
+		to   common.ObjRef
+		want []common.ObjRef
+	}{
+		{
+			name: "route to its gatewayclass",
+			from: routeRef("foo-httproute"),
+			to:   gatewayClassRef("foo-gatewayclass"),
+			want: []common.ObjRef{routeRef("foo-httproute"), gatewayRef("foo-gateway"), gatewayClassRef("foo-gatewayclass")},
+		},
+		{
+			name: "gatewayclass to a backend of its routes",
+			from: gatewayClassRef("foo-gatewayclass"),
+			to:   serviceRef("foo-svc"),
+			want: []common.ObjRef{gatewayClassRef("foo-gatewayclass"), gatewayRef("foo-gateway"), routeRef("foo-httproute"), serviceRef("foo-svc")},
+		},
+		{
+			name: "routes connected through a shared backend",
+			from: routeRef("foo-httproute"),
+			to:   serviceRef("bar-svc"),
+			want: []common.ObjRef{routeRef("foo-httproute"), serviceRef("foo-svc"), routeRef("bar-httproute"), serviceRef("bar-svc")},
+		},
+		{
+			name: "same resource",
+			from: routeRef("foo-httproute"),
+			to:   routeRef("foo-httproute"),
+			want: []common.ObjRef{routeRef("foo-httproute")},
+		},
+		{
+			name: "unrelated resources",
+			from: routeRef("foo-httproute"),
+			to:   gatewayRef("baz-gateway"),
+			want: nil,
+		},
+		{
+			name: "resource which is not part of the graph",
+			from: routeRef("does-not-exist"),
+			to:   gatewayRef("foo-gateway"),
+			want: nil,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FindPath(tc.from, tc.to, graph)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FindPath() returned unexpected path (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestBuildResourceGraph_MirrorEdges(t *testing.T) {
+	backendRef := gatewayv1.BackendObjectReference{Name: "foo-svc"}
+	route := gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-httproute"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{{
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef}}},
+				Filters: []gatewayv1.HTTPRouteFilter{{
+					Type:          gatewayv1.HTTPRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{BackendRef: backendRef},
+				}},
+			}},
+		},
+	}
+
+	routeRef := common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "default", Name: "foo-httproute"}
+	serviceRef := common.ObjRef{Kind: "Service", Namespace: "default", Name: "foo-svc"}
+	want := []GraphEdge{
+		{From: routeRef, To: serviceRef, Role: BackendRefRolePrimary},
+		{From: routeRef, To: serviceRef, Role: BackendRefRoleMirror},
+	}
+	got := BuildResourceGraph(nil, []gatewayv1.HTTPRoute{route}, nil).Edges
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("BuildResourceGraph() returned unexpected edges (-want +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package relations
+
+import (
+	"strings"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// WildcardHostnameMatches returns true if the wildcard hostname, like
+// *.example.com, matches the hostname. Following the Gateway API, the wildcard
+// label matches one or more labels, so *.example.com matches api.example.com
+// and foo.api.example.com, but not example.com. The hostname may itself be a
+// more specific wildcard hostname, like *.api.example.com.
+func WildcardHostnameMatches(wildcard, hostname string) bool {
+	suffix, ok := strings.CutPrefix(wildcard, "*")
+	return ok && strings.HasSuffix(hostname, suffix) && len(hostname) > len(suffix)
+}
+
+// HostnamesMatch returns true if the hostname of a listener and a hostname of
+// a route match, which is the case when some request hostname is matched by
+// both. Either may be a wildcard hostname, and a listener without a hostname
+// matches any hostname.
+func HostnamesMatch(listenerHostname, routeHostname string) bool {
+	return listenerHostname == "" ||
+		listenerHostname == routeHostname ||
+		WildcardHostnameMatches(listenerHostname, routeHostname) ||
+		WildcardHostnameMatches(routeHostname, listenerHostname)
+}
+
+// IntersectHostnames returns the hostnames which are matched by both the
+// hostname of a listener and some hostname of a route. Each such hostname is
+// the more specific of the two, so a listener for *.example.com serves the
+// route hostname api.example.com, and a listener for api.example.com serves
+// the route hostname *.example.com as api.example.com. A route without
+// hostnames serves the hostname of the listener, or all hostnames ("*") if the
+// listener has none.
+func IntersectHostnames(listenerHostname *gatewayv1.Hostname, routeHostnames []gatewayv1.Hostname) []string {
+	listener := ""
+	if listenerHostname != nil {
+		listener = string(*listenerHostname)
+	}
+	if len(routeHostnames) == 0 {
+		if listener == "" {
+			return []string{"*"}
+		}
+		return []string{listener}
+	}
+
+	var result []string
+	for _, routeHostname := range routeHostnames {
+		route := string(routeHostname)
+		switch {
+		case listener == "" || listener == route || WildcardHostnameMatches(listener, route):
+			result = append(result, route)
+		case WildcardHostnameMatches(route, listener):
+			result = append(result, listener)
+		}
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package relations
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestHostnamesMatch(t *testing.T) {
+	testcases := []struct {
+		listenerHostname string
+		routeHostname    string
+		want             bool
+	}{
+		{listenerHostname: "", routeHostname: "api.example.com", want: true},
+		{listenerHostname: "api.example.com", routeHostname: "api.example.com", want: true},
+		{listenerHostname: "api.example.com", routeHostname: "web.example.com", want: false},
+		{listenerHostname: "*.example.com", routeHostname: "api.example.com", want: true},
+		{listenerHostname: "*.example.com", routeHostname: "foo.api.example.com", want: true},
+		{listenerHostname: "*.example.com", routeHostname: "example.com", want: false},
+		{listenerHostname: "*.example.com", routeHostname: "api.example.net", want: false},
+		{listenerHostname: "api.example.com", routeHostname: "*.example.com", want: true},
+		{listenerHostname: "*.example.com", routeHostname: "*.api.example.com", want: true},
+		{listenerHostname: "*.api.example.com", routeHostname: "*.example.com", want: true},
+		{listenerHostname: "*.example.com", routeHostname: "*.example.net", want: false},
+	}
+
+	for _, tc := range testcases {
+		got := HostnamesMatch(tc.listenerHostname, tc.routeHostname)
+		if got != tc.want {
+			t.Errorf("HostnamesMatch(%q, %q) = %v; want %v", tc.listenerHostname, tc.routeHostname, got, tc.want)
+		}
+	}
+}
+
+func TestIntersectHostnames(t *testing.T) {
+	testcases := []struct {
+		name             string
+		listenerHostname *gatewayv1.Hostname
+		routeHostnames   []gatewayv1.Hostname
+		want             []string
+	}{
+		{
+			name: "neither has hostnames",
+			want: []string{"*"},
+		},
+		{
+			name:             "route without hostnames serves the listener hostname",
+			listenerHostname: common.PtrTo(gatewayv1.Hostname("*.example.com")),
+			want:             []string{"*.example.com"},
+		},
+		{
+			name:             "wildcard listener serves specific route hostnames",
+			listenerHostname: common.PtrTo(gatewayv1.Hostname("*.example.com")),
+			routeHostnames:   []gatewayv1.Hostname{"api.example.com", "example.com", "api.example.net"},
+			want:             []string{"api.example.com"},
+		},
+		{
+			name:             "wildcard route hostname serves the specific listener hostname",
+			listenerHostname: common.PtrTo(gatewayv1.Hostname("api.example.com")),
+			routeHostnames:   []gatewayv1.Hostname{"*.example.com", "web.example.com"},
+			want:             []string{"api.example.com"},
+		},
+		{
+			name:           "listener without hostname serves every route hostname",
+			routeHostnames: []gatewayv1.Hostname{"*.example.com", "api.example.net"},
+			want:           []string{"*.example.com", "api.example.net"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := IntersectHostnames(tc.listenerHostname, tc.routeHostnames)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IntersectHostnames(...) returned unexpected diff (-want, +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package relations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+)
+
+// contributorMarkerPrefix prefixes the markers which replace the values of
+// policies, so the policy which contributed a value can be identified after
+// merging.
+const contributorMarkerPrefix = "\x00contributor:"
+
+// PrecedenceRule is the rule of policy inheritance which decides the policy
+// whose value takes effect for a key.
+type PrecedenceRule string
+
+const (
+	// PrecedenceRuleOnlyPolicy applies when a single policy sets the key.
+	PrecedenceRuleOnlyPolicy PrecedenceRule = "only policy setting the key"
+	// PrecedenceRuleAncestorOverride applies when policies at different levels
+	// override the key: the override of the ancestor wins.
+	PrecedenceRuleAncestorOverride PrecedenceRule = "ancestor override wins over descendant override"
+	// PrecedenceRuleDescendantDefault applies when policies at different levels
+	// default the key: the default of the descendant wins.
+	PrecedenceRuleDescendantDefault PrecedenceRule = "descendant default wins over ancestor default"
+	// PrecedenceRuleOverrideOverDefault applies when an override competes only
+	// with defaults.
+	PrecedenceRuleOverrideOverDefault PrecedenceRule = "override wins over default"
+	// PrecedenceRuleSameLevel applies when the policies competing for the key
+	// are attached at the same level, which are ordered by their creation time
+	// and name.
+	PrecedenceRuleSameLevel PrecedenceRule = "conflict resolution between policies at the same level"
+)
+
+// PolicyPrecedence describes which policy sets a key of the effective spec.
+type PolicyPrecedence struct {
+	// Key is a dot separated path within the effective spec.
+	Key string
+	// Winner is the policy whose value takes effect.
+	Winner common.ObjRef
+	// Rule is the rule which made the Winner win over the Losers.
+	Rule PrecedenceRule
+	// Losers are the other policies setting the key.
+	Losers []common.ObjRef
+}
+
+// policySetting is a key set by some policy within the hierarchy.
+type policySetting struct {
+	ref      common.ObjRef
+	level    int
+	override bool
+}
+
+// ComputePolicyPrecedence returns, for every key set in the effective spec of
+// the policies of the given kind, the policy whose value takes effect and the
+// rule which decided it. The hierarchy lists the policies attached at each
+// level of the inheritance chain of the target, from the top (e.g. the
+// GatewayClass) down to the target itself. Keys are the dot separated paths of
+// the values within the effective spec, sorted alphabetically; objects whose
+// fields may be set by different policies are not keys themselves.
+//
+// The policies are merged exactly as when calculating effective policies,
+// except that every value is replaced by a marker of the policy it came from.
+func ComputePolicyPrecedence(policyKind policymanager.PolicyCrdID, hierarchy [][]policymanager.Policy) ([]PolicyPrecedence, error) {
+	var contributors []policySetting
+	var policies []policymanager.Policy
+	var merged map[policymanager.PolicyCrdID]policymanager.Policy
+	for i, level := range hierarchy {
+		var markedPolicies []policymanager.Policy
+		for _, policy := range level {
+			if policy.PolicyCrdID() != policyKind {
+				continue
+			}
+			marker := fmt.Sprintf("%v%d", contributorMarkerPrefix, len(contributors))
+			contributors = append(contributors, policySetting{ref: policymanager.ToPolicyRefs([]policymanager.Policy{policy})[0], level: i})
+			policies = append(policies, policy)
+			markedPolicies = append(markedPolicies, policy.WithSpec(markValues(policy.Spec(), marker)))
+		}
+
+		levelPolicies, err := policymanager.MergePoliciesOfSimilarKind(markedPolicies)
+		if err != nil {
+			return nil, err
+		}
+		merged, err = policymanager.MergePoliciesOfDifferentHierarchy(merged, levelPolicies)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	policy, ok := merged[policyKind]
+	if !ok {
+		return nil, nil
+	}
+	effectiveSpec, err := policy.EffectiveSpec()
+	if err != nil {
+		return nil, err
+	}
+	markers := make(map[string]string)
+	collectMarkers(effectiveSpec, "", markers)
+
+	var result []PolicyPrecedence
+	for _, key := range sortedKeys(markers) {
+		var index int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(markers[key], contributorMarkerPrefix), "%d", &index); err != nil {
+			return nil, err
+		}
+		var winner policySetting
+		var losers []policySetting
+		for i, contributor := range contributors {
+			override, ok := setsKey(policies[i], key)
+			if !ok {
+				continue
+			}
+			contributor.override = override
+			if i == index {
+				winner = contributor
+			} else {
+				losers = append(losers, contributor)
+			}
+		}
+		precedence := PolicyPrecedence{Key: key, Winner: winner.ref, Rule: precedenceRule(winner, losers)}
+		for _, loser := range losers {
+			precedence.Losers = append(precedence.Losers, loser.ref)
+		}
+		result = append(result, precedence)
+	}
+	return result, nil
+}
+
+// precedenceRule returns the rule which made the winner win over the losers.
+// Overrides competing across levels are reported first, since they are the
+// most surprising to users of the descendants.
+func precedenceRule(winner policySetting, losers []policySetting) PrecedenceRule {
+	if len(losers) == 0 {
+		return PrecedenceRuleOnlyPolicy
+	}
+	var sameLevel, otherLevelOverride, otherLevelDefault bool
+	for _, loser := range losers {
+		switch {
+		case loser.level == winner.level && loser.override == winner.override:
+			sameLevel = true
+		case loser.override && loser.level != winner.level:
+			otherLevelOverride = true
+		case !loser.override && loser.level != winner.level:
+			otherLevelDefault = true
+		}
+	}
+	switch {
+	case winner.override && otherLevelOverride:
+		return PrecedenceRuleAncestorOverride
+	case sameLevel:
+		return PrecedenceRuleSameLevel
+	case !winner.override && otherLevelDefault:
+		return PrecedenceRuleDescendantDefault
+	default:
+		return PrecedenceRuleOverrideOverDefault
+	}
+}
+
+// setsKey returns whether the policy sets the key, and whether it does so
+// through its override. The whole spec of direct policies acts as a default.
+func setsKey(policy policymanager.Policy, key string) (override bool, ok bool) {
+	spec := policy.Spec()
+	if !policy.IsInherited() {
+		return false, lookupKey(spec, key) != nil
+	}
+	if overrideSpec, isMap := spec["override"].(map[string]interface{}); isMap && lookupKey(overrideSpec, key) != nil {
+		return true, true
+	}
+	if defaultSpec, isMap := spec["default"].(map[string]interface{}); isMap && lookupKey(defaultSpec, key) != nil {
+		return false, true
+	}
+	return false, false
+}
+
+// FindContributingPolicyForKey returns the policy of the given kind whose
+// value for the key takes effect on the target of the hierarchy, as computed
+// by ComputePolicyPrecedence. The key is a dot separated path within the
+// effective spec, like "protocol.name". Nil is returned if no policy sets the
+// key, or if the key refers to an object whose fields may be set by different
+// policies.
+func FindContributingPolicyForKey(policyKind policymanager.PolicyCrdID, key string, hierarchy [][]policymanager.Policy) (*common.ObjRef, error) {
+	precedences, err := ComputePolicyPrecedence(policyKind, hierarchy)
+	if err != nil {
+		return nil, err
+	}
+	for _, precedence := range precedences {
+		if precedence.Key == key {
+			return &precedence.Winner, nil
+		}
+	}
+	return nil, nil
+}
+
+// collectMarkers records the marker of every value within the spec by the dot
+// separated path of the value.
+func collectMarkers(spec map[string]interface{}, prefix string, markers map[string]string) {
+	for field, value := range spec {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			collectMarkers(value, prefix+field+".", markers)
+		case string:
+			if strings.HasPrefix(value, contributorMarkerPrefix) {
+				markers[prefix+field] = value
+			}
+		}
+	}
+}
+
+// lookupKey returns the value at the dot separated path within the spec, or nil
+// if it is not set.
+func lookupKey(spec map[string]interface{}, key string) interface{} {
+	var current interface{} = spec
+	for _, field := range strings.Split(key, ".") {
+		fields, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = fields[field]
+	}
+	return current
+}
+
+func sortedKeys(m map[string]string) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// markValues returns a copy of the spec with every value, other than objects
+// whose fields are merged individually, replaced by the marker. Lists are
+// replaced as a whole when merging, so they are replaced by a single marker.
+func markValues(spec map[string]interface{}, marker string) map[string]interface{} {
+	result := make(map[string]interface{}, len(spec))
+	for field, value := range spec {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			result[field] = markValues(value, marker)
+		case nil:
+			// Null values remove fields when merging, rather than setting them.
+			result[field] = nil
+		default:
+			result[field] = marker
+		}
+	}
+	return result
+}
+
+// FindInheritedPolicies returns the policies whose CRD is labeled as an
+// inherited policy with the gateway.networking.k8s.io/policy-attachment label,
+// sorted by kind, namespace and name. Policies whose CRD is not among the crds
+// are omitted.
+func FindInheritedPolicies(policies []policymanager.Policy, crds []policymanager.PolicyCRD) []common.ObjRef {
+	return findPoliciesWithCRD(policies, crds, policymanager.PolicyCRD.IsInherited)
+}
+
+// FindDirectPolicies returns the policies whose CRD is labeled as a direct
+// policy with the gateway.networking.k8s.io/policy-attachment label, sorted by
+// kind, namespace and name. Policies whose CRD is not among the crds are
+// omitted.
+func FindDirectPolicies(policies []policymanager.Policy, crds []policymanager.PolicyCRD) []common.ObjRef {
+	return findPoliciesWithCRD(policies, crds, policymanager.PolicyCRD.IsDirect)
+}
+
+// findPoliciesWithCRD returns references to the policies whose CRD satisfies
+// the predicate.
+func findPoliciesWithCRD(policies []policymanager.Policy, crds []policymanager.PolicyCRD, predicate func(policymanager.PolicyCRD) bool) []common.ObjRef {
+	matchingCRDs := make(map[policymanager.PolicyCrdID]bool)
+	for _, crd := range crds {
+		if predicate(crd) {
+			matchingCRDs[crd.ID()] = true
+		}
+	}
+
+	var result []common.ObjRef
+	for _, policy := range policies {
+		if !matchingCRDs[policy.PolicyCrdID()] {
+			continue
+		}
+		gvk := policy.Unstructured().GroupVersionKind()
+		result = append(result, common.ObjRef{
+			Group:     gvk.Group,
+			Kind:      gvk.Kind,
+			Name:      policy.Unstructured().GetName(),
+			Namespace: policy.Unstructured().GetNamespace(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return result
+}
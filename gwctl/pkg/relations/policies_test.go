@@ -0,0 +1,358 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package relations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestFindContributingPolicyForKey(t *testing.T) {
+	healthCheckPolicy := func(name string, targetKind, targetName string, spec map[string]interface{}) *unstructured.Unstructured {
+		spec["targetRef"] = map[string]interface{}{
+			"group": "gateway.networking.k8s.io",
+			"kind":  targetKind,
+			"name":  targetName,
+		}
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "foo.com/v1",
+				"kind":       "HealthCheckPolicy",
+				"metadata": map[string]interface{}{
+					"name":      name,
+					"namespace": "default",
+				},
+				"spec": spec,
+			},
+		}
+	}
+	objects := []runtime.Object{
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "healthcheckpolicies.foo.com",
+				Labels: map[string]string{
+					gatewayv1alpha2.PolicyLabelKey: "inherited",
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    "foo.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "healthcheckpolicies",
+					Kind:   "HealthCheckPolicy",
+				},
+			},
+		},
+		healthCheckPolicy("gateway-policy", "Gateway", "foo-gateway", map[string]interface{}{
+			"override": map[string]interface{}{
+				"timeout": "10s",
+			},
+			"default": map[string]interface{}{
+				"retries":  int64(3),
+				"interval": "5s",
+				"path":     "/healthz",
+			},
+		}),
+		healthCheckPolicy("httproute-policy", "HTTPRoute", "foo-httproute", map[string]interface{}{
+			"default": map[string]interface{}{
+				"timeout":  "1s",
+				"retries":  int64(5),
+				"path":     nil,
+				"protocol": map[string]interface{}{"name": "HTTP"},
+			},
+		}),
+	}
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	hierarchy := [][]policymanager.Policy{
+		policyManager.PoliciesAttachedTo(common.ObjRef{Group: "gateway.networking.k8s.io", Kind: "Gateway", Name: "foo-gateway", Namespace: "default"}),
+		policyManager.PoliciesAttachedTo(common.ObjRef{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Name: "foo-httproute", Namespace: "default"}),
+	}
+	gatewayPolicy := &common.ObjRef{Group: "foo.com", Kind: "HealthCheckPolicy", Name: "gateway-policy", Namespace: "default"}
+	httpRoutePolicy := &common.ObjRef{Group: "foo.com", Kind: "HealthCheckPolicy", Name: "httproute-policy", Namespace: "default"}
+
+	testcases := []struct {
+		name string
+		key  string
+		want *common.ObjRef
+	}{
+		{
+			name: "override of parent takes precedence over default of child",
+			key:  "timeout",
+			want: gatewayPolicy,
+		},
+		{
+			name: "default of child takes precedence over default of parent",
+			key:  "retries",
+			want: httpRoutePolicy,
+		},
+		{
+			name: "default of parent is used if child does not set it",
+			key:  "interval",
+			want: gatewayPolicy,
+		},
+		{
+			name: "nested key",
+			key:  "protocol.name",
+			want: httpRoutePolicy,
+		},
+		{
+			name: "key removed by child",
+			key:  "path",
+			want: nil,
+		},
+		{
+			name: "key which is not set",
+			key:  "unknown",
+			want: nil,
+		},
+		{
+			name: "key of an object",
+			key:  "protocol",
+			want: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FindContributingPolicyForKey("HealthCheckPolicy.foo.com", tc.key, hierarchy)
+			if err != nil {
+				t.Fatalf("FindContributingPolicyForKey(%q) returned err=%v; want no error", tc.key, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FindContributingPolicyForKey(%q) returned unexpected diff (-want +got):\n%v", tc.key, diff)
+			}
+		})
+	}
+}
+
+func TestComputePolicyPrecedence(t *testing.T) {
+	timeoutPolicy := func(name, targetKind, targetName string, creationTimestamp metav1.Time, spec map[string]interface{}) *unstructured.Unstructured {
+		spec["targetRef"] = map[string]interface{}{
+			"group": "gateway.networking.k8s.io",
+			"kind":  targetKind,
+			"name":  targetName,
+		}
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "bar.com/v1",
+				"kind":       "TimeoutPolicy",
+				"metadata": map[string]interface{}{
+					"name":              name,
+					"namespace":         "default",
+					"creationTimestamp": creationTimestamp.UTC().Format(time.RFC3339),
+				},
+				"spec": spec,
+			},
+		}
+	}
+	older := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	objects := []runtime.Object{
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "timeoutpolicies.bar.com",
+				Labels: map[string]string{
+					gatewayv1alpha2.PolicyLabelKey: "inherited",
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    "bar.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "timeoutpolicies",
+					Kind:   "TimeoutPolicy",
+				},
+			},
+		},
+		timeoutPolicy("gatewayclass-policy", "GatewayClass", "foo-gatewayclass", older, map[string]interface{}{
+			"override": map[string]interface{}{
+				"connect": "1s",
+			},
+			"default": map[string]interface{}{
+				"idle": "60s",
+			},
+		}),
+		timeoutPolicy("gateway-policy", "Gateway", "foo-gateway", older, map[string]interface{}{
+			"override": map[string]interface{}{
+				"connect": "2s",
+				"request": "30s",
+			},
+			"default": map[string]interface{}{
+				"idle":     "120s",
+				"response": "10s",
+			},
+		}),
+		timeoutPolicy("httproute-policy-old", "HTTPRoute", "foo-httproute", older, map[string]interface{}{
+			"override": map[string]interface{}{
+				"request": "5s",
+			},
+			"default": map[string]interface{}{
+				"response": "3s",
+				"backend":  "4s",
+			},
+		}),
+		timeoutPolicy("httproute-policy-new", "HTTPRoute", "foo-httproute", newer, map[string]interface{}{
+			"default": map[string]interface{}{
+				"backend": "8s",
+				"stream":  "0s",
+			},
+		}),
+	}
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	hierarchy := [][]policymanager.Policy{
+		policyManager.PoliciesAttachedTo(common.ObjRef{Group: "gateway.networking.k8s.io", Kind: "GatewayClass", Name: "foo-gatewayclass"}),
+		policyManager.PoliciesAttachedTo(common.ObjRef{Group: "gateway.networking.k8s.io", Kind: "Gateway", Name: "foo-gateway", Namespace: "default"}),
+		policyManager.PoliciesAttachedTo(common.ObjRef{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Name: "foo-httproute", Namespace: "default"}),
+	}
+	policyRef := func(name string) common.ObjRef {
+		return common.ObjRef{Group: "bar.com", Kind: "TimeoutPolicy", Name: name, Namespace: "default"}
+	}
+
+	got, err := ComputePolicyPrecedence("TimeoutPolicy.bar.com", hierarchy)
+	if err != nil {
+		t.Fatalf("ComputePolicyPrecedence() returned err=%v; want no error", err)
+	}
+	want := []PolicyPrecedence{
+		{
+			Key:    "backend",
+			Winner: policyRef("httproute-policy-old"),
+			Rule:   PrecedenceRuleSameLevel,
+			Losers: []common.ObjRef{policyRef("httproute-policy-new")},
+		},
+		{
+			// The override of the GatewayClass wins over the override of the
+			// Gateway, although the Gateway is closer to the HTTPRoute.
+			Key:    "connect",
+			Winner: policyRef("gatewayclass-policy"),
+			Rule:   PrecedenceRuleAncestorOverride,
+			Losers: []common.ObjRef{policyRef("gateway-policy")},
+		},
+		{
+			Key:    "idle",
+			Winner: policyRef("gateway-policy"),
+			Rule:   PrecedenceRuleDescendantDefault,
+			Losers: []common.ObjRef{policyRef("gatewayclass-policy")},
+		},
+		{
+			Key:    "request",
+			Winner: policyRef("gateway-policy"),
+			Rule:   PrecedenceRuleAncestorOverride,
+			Losers: []common.ObjRef{policyRef("httproute-policy-old")},
+		},
+		{
+			Key:    "response",
+			Winner: policyRef("httproute-policy-old"),
+			Rule:   PrecedenceRuleDescendantDefault,
+			Losers: []common.ObjRef{policyRef("gateway-policy")},
+		},
+		{
+			Key:    "stream",
+			Winner: policyRef("httproute-policy-new"),
+			Rule:   PrecedenceRuleOnlyPolicy,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ComputePolicyPrecedence() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
+
+func TestFindInheritedAndDirectPolicies(t *testing.T) {
+	policyCRD := func(plural, kind, group, policyType string) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: plural + "." + group,
+				Labels: map[string]string{
+					gatewayv1alpha2.PolicyLabelKey: policyType,
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    group,
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: plural,
+					Kind:   kind,
+				},
+			},
+		}
+	}
+	policy := func(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": apiVersion,
+				"kind":       kind,
+				"metadata": map[string]interface{}{
+					"name":      name,
+					"namespace": namespace,
+				},
+				"spec": map[string]interface{}{
+					"targetRef": map[string]interface{}{
+						"group": "gateway.networking.k8s.io",
+						"kind":  "Gateway",
+						"name":  "foo-gateway",
+					},
+				},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		policyCRD("healthcheckpolicies", "HealthCheckPolicy", "foo.com", "inherited"),
+		policyCRD("timeoutpolicies", "TimeoutPolicy", "bar.com", "direct"),
+		policy("foo.com/v1", "HealthCheckPolicy", "ns2", "health-check-1"),
+		policy("foo.com/v1", "HealthCheckPolicy", "ns1", "health-check-2"),
+		policy("bar.com/v1", "TimeoutPolicy", "ns1", "timeout-1"),
+	}
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	policies := policyManager.GetPolicies()
+	crds := policyManager.GetCRDs()
+
+	wantInherited := []common.ObjRef{
+		{Group: "foo.com", Kind: "HealthCheckPolicy", Namespace: "ns1", Name: "health-check-2"},
+		{Group: "foo.com", Kind: "HealthCheckPolicy", Namespace: "ns2", Name: "health-check-1"},
+	}
+	if diff := cmp.Diff(wantInherited, FindInheritedPolicies(policies, crds)); diff != "" {
+		t.Errorf("FindInheritedPolicies() returned unexpected diff (-want +got):\n%v", diff)
+	}
+
+	wantDirect := []common.ObjRef{
+		{Group: "bar.com", Kind: "TimeoutPolicy", Namespace: "ns1", Name: "timeout-1"},
+	}
+	if diff := cmp.Diff(wantDirect, FindDirectPolicies(policies, crds)); diff != "" {
+		t.Errorf("FindDirectPolicies() returned unexpected diff (-want +got):\n%v", diff)
+	}
+
+	// Policies whose CRD is unknown are not classified.
+	if got := FindDirectPolicies(policies, nil); got != nil {
+		t.Errorf("FindDirectPolicies() with no CRDs = %v; want nil", got)
+	}
+}
@@ -19,7 +19,11 @@ limitations under the License.
 package relations
 
 import (
+	"sort"
+
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
 
@@ -30,22 +34,66 @@ import (
 // FindGatewayRefsForHTTPRoute returns Gateways which the HTTPRoute is attached
 // to.
 func FindGatewayRefsForHTTPRoute(httpRoute gatewayv1.HTTPRoute) []types.NamespacedName {
+	return FindGatewayRefsForRoute(httpRoute.GetNamespace(), httpRoute.Spec.ParentRefs)
+}
+
+// FindGatewayRefsForRoute returns Gateways which a route of any kind is
+// attached to, given the namespace and the parentRefs of the route.
+func FindGatewayRefsForRoute(namespace string, parentRefs []gatewayv1.ParentReference) []types.NamespacedName {
 	result := []types.NamespacedName{}
-	for _, gatewayRef := range httpRoute.Spec.ParentRefs {
-		namespace := httpRoute.GetNamespace()
-		if namespace == "" {
-			namespace = metav1.NamespaceDefault
+	for _, gatewayRef := range parentRefs {
+		result = append(result, gatewayRefForParentRef(namespace, gatewayRef))
+	}
+	return result
+}
+
+// FindDuplicateParentRefs returns the parents which the HTTPRoute lists more
+// than once in its parentRefs, after defaulting their namespace to that of the
+// HTTPRoute. ParentRefs which select different sections or ports of the same
+// parent are not duplicates. The result is sorted and contains no duplicates.
+func FindDuplicateParentRefs(route gatewayv1.HTTPRoute) []types.NamespacedName {
+	type parentRefKey struct {
+		parent      common.ObjRef
+		sectionName string
+		port        int32
+	}
+	seen := make(map[parentRefKey]bool)
+	duplicates := make(map[types.NamespacedName]bool)
+	for _, parentRef := range route.Spec.ParentRefs {
+		key := parentRefKey{parent: parentObjRef(route.GetNamespace(), parentRef)}
+		if parentRef.SectionName != nil {
+			key.sectionName = string(*parentRef.SectionName)
+		}
+		if parentRef.Port != nil {
+			key.port = int32(*parentRef.Port)
 		}
-		if gatewayRef.Namespace != nil {
-			namespace = string(*gatewayRef.Namespace)
+		if seen[key] {
+			duplicates[types.NamespacedName{Namespace: key.parent.Namespace, Name: key.parent.Name}] = true
 		}
+		seen[key] = true
+	}
+	return sortedNamespacedNames(duplicates)
+}
 
-		result = append(result, types.NamespacedName{
-			Namespace: namespace,
-			Name:      string(gatewayRef.Name),
-		})
+// gatewayRefForParentRef returns the Gateway referenced by the parentRef of a
+// route within the namespace.
+func gatewayRefForParentRef(namespace string, gatewayRef gatewayv1.ParentReference) types.NamespacedName {
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
 	}
-	return result
+	if gatewayRef.Namespace != nil {
+		namespace = string(*gatewayRef.Namespace)
+	}
+	return types.NamespacedName{
+		Namespace: namespace,
+		Name:      string(gatewayRef.Name),
+	}
+}
+
+// ParentRefMatches returns true if the parentRef of a route within the
+// namespace refers to the parent.
+func ParentRefMatches(namespace string, parentRef gatewayv1.ParentReference, parent common.ObjRef) bool {
+	return parentObjRef(namespace, parentRef) == parent
 }
 
 // FindGatewayClassNameForGateway returns GatewayClass for the Gateway.
@@ -72,6 +120,64 @@ func FindBackendRefsForHTTPRoute(httpRoute gatewayv1.HTTPRoute) []common.ObjRef
 		}
 	}
 
+	return FindBackendRefsForRoute(httpRoute.GetNamespace(), backendRefs)
+}
+
+// BackendRefRole is the role in which a route references a Backend.
+type BackendRefRole string
+
+const (
+	// BackendRefRolePrimary is the role of the backendRefs of the rules of a
+	// route, between which the traffic is split.
+	BackendRefRolePrimary BackendRefRole = "primary"
+	// BackendRefRoleMirror is the role of the backendRefs of RequestMirror
+	// filters, which receive a fire-and-forget copy of the traffic.
+	BackendRefRoleMirror BackendRefRole = "mirror"
+)
+
+// RoleBackendRef is a Backend referenced by a route in some role.
+type RoleBackendRef struct {
+	Backend common.ObjRef  `json:"backend"`
+	Role    BackendRefRole `json:"role"`
+}
+
+// FindRoleBackendRefsForHTTPRoute returns the Backends which the HTTPRoute
+// references, along with the role of each reference. A Backend which the
+// HTTPRoute references both in its rules and in a RequestMirror filter is
+// returned once for each role. The result is sorted, with the primary role
+// first for each Backend, and contains no duplicates.
+func FindRoleBackendRefsForHTTPRoute(httpRoute gatewayv1.HTTPRoute) []RoleBackendRef {
+	var primaryRefs, mirrorRefs []gatewayv1.BackendObjectReference
+	for _, rule := range httpRoute.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			primaryRefs = append(primaryRefs, backendRef.BackendObjectReference)
+		}
+		for _, filter := range rule.Filters {
+			if filter.Type == gatewayv1.HTTPRouteFilterRequestMirror && filter.RequestMirror != nil {
+				mirrorRefs = append(mirrorRefs, filter.RequestMirror.BackendRef)
+			}
+		}
+	}
+
+	var result []RoleBackendRef
+	for _, backendRef := range FindBackendRefsForRoute(httpRoute.GetNamespace(), primaryRefs) {
+		result = append(result, RoleBackendRef{Backend: backendRef, Role: BackendRefRolePrimary})
+	}
+	for _, backendRef := range FindBackendRefsForRoute(httpRoute.GetNamespace(), mirrorRefs) {
+		result = append(result, RoleBackendRef{Backend: backendRef, Role: BackendRefRoleMirror})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Backend != result[j].Backend {
+			return objRefLess(result[i].Backend, result[j].Backend)
+		}
+		return result[i].Role == BackendRefRolePrimary && result[j].Role != BackendRefRolePrimary
+	})
+	return result
+}
+
+// FindBackendRefsForRoute returns the unique Backends referenced by a route of
+// any kind, given the namespace and the backendRefs of the route.
+func FindBackendRefsForRoute(namespace string, backendRefs []gatewayv1.BackendObjectReference) []common.ObjRef {
 	// Convert each BackendRef to ObjRef. ObjRef does not use pointers and thus is
 	// easily comparable.
 	resultSet := make(map[common.ObjRef]bool)
@@ -80,7 +186,7 @@ func FindBackendRefsForHTTPRoute(httpRoute gatewayv1.HTTPRoute) []common.ObjRef
 			Name: string(backendRef.Name),
 			// Assume namespace is unspecified in the backendRef and check later to
 			// override the default value.
-			Namespace: httpRoute.GetNamespace(),
+			Namespace: namespace,
 		}
 		if backendRef.Group != nil {
 			objRef.Group = string(*backendRef.Group)
@@ -102,6 +208,57 @@ func FindBackendRefsForHTTPRoute(httpRoute gatewayv1.HTTPRoute) []common.ObjRef
 	return result
 }
 
+// ComputeRouteFanout returns the number of distinct Backends which each
+// HTTPRoute references, keyed by the namespaced name of the HTTPRoute. Routes
+// with a high fan-out are worth reviewing for the correctness of their traffic
+// splitting.
+func ComputeRouteFanout(routes []gatewayv1.HTTPRoute) map[types.NamespacedName]int {
+	result := make(map[types.NamespacedName]int, len(routes))
+	for _, route := range routes {
+		result[types.NamespacedName{Namespace: route.GetNamespace(), Name: route.GetName()}] = len(FindBackendRefsForHTTPRoute(route))
+	}
+	return result
+}
+
+// RouteServesAnyHostname returns true if the route serves requests for any
+// hostname through the listener, which is the case when neither the route nor
+// the listener specify hostnames and the listener uses the HTTP or HTTPS
+// protocol. Listeners of other protocols do not match on hostnames, or, for
+// TLS, require routes with hostnames of their own kind.
+func RouteServesAnyHostname(route gatewayv1.HTTPRoute, listener gatewayv1.Listener) bool {
+	if len(route.Spec.Hostnames) != 0 {
+		return false
+	}
+	if listener.Hostname != nil && *listener.Hostname != "" {
+		return false
+	}
+	return listener.Protocol == gatewayv1.HTTPProtocolType || listener.Protocol == gatewayv1.HTTPSProtocolType
+}
+
+// FindRoutesAffectedByGatewayClass returns the HTTPRoutes which are attached
+// to some Gateway of the GatewayClass, and would hence be affected by changes
+// to the GatewayClass, like its controllerName or parametersRef. The result is
+// sorted and contains no duplicates.
+func FindRoutesAffectedByGatewayClass(className string, gateways []gatewayv1.Gateway, routes []gatewayv1.HTTPRoute) []types.NamespacedName {
+	classGateways := make(map[types.NamespacedName]bool)
+	for _, gateway := range gateways {
+		if FindGatewayClassNameForGateway(gateway) == className {
+			classGateways[types.NamespacedName{Namespace: gateway.GetNamespace(), Name: gateway.GetName()}] = true
+		}
+	}
+
+	affectedRoutes := make(map[types.NamespacedName]bool)
+	for _, route := range routes {
+		for _, gatewayRef := range FindGatewayRefsForHTTPRoute(route) {
+			if classGateways[gatewayRef] {
+				affectedRoutes[types.NamespacedName{Namespace: route.GetNamespace(), Name: route.GetName()}] = true
+				break
+			}
+		}
+	}
+	return sortedNamespacedNames(affectedRoutes)
+}
+
 // ReferenceGrantExposes returns true if the provided reference grant "exposes"
 // the given resource. "Exposes" means that the resource is part of the "To"
 // fields within the ReferenceGrant.
@@ -123,6 +280,36 @@ func ReferenceGrantExposes(referenceGrant gatewayv1beta1.ReferenceGrant, resourc
 	return false
 }
 
+// BackendTLSPolicyTargets returns the targetRefs of the BackendTLSPolicy which
+// refer to the given backend. The targetRefs may differ in their sectionName,
+// which selects a port of the backend.
+func BackendTLSPolicyTargets(backendTLSPolicy gatewayv1alpha3.BackendTLSPolicy, backend common.ObjRef) []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName {
+	if backendTLSPolicy.GetNamespace() != backend.Namespace {
+		return nil
+	}
+	var result []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName
+	for _, targetRef := range backendTLSPolicy.Spec.TargetRefs {
+		if string(targetRef.Group) == backend.Group && string(targetRef.Kind) == backend.Kind && string(targetRef.Name) == backend.Name {
+			result = append(result, targetRef)
+		}
+	}
+	return result
+}
+
+// BackendLBPolicyTargetsBackend returns true if some targetRef of the
+// BackendLBPolicy refers to the given backend.
+func BackendLBPolicyTargetsBackend(backendLBPolicy gatewayv1alpha2.BackendLBPolicy, backend common.ObjRef) bool {
+	if backendLBPolicy.GetNamespace() != backend.Namespace {
+		return false
+	}
+	for _, targetRef := range backendLBPolicy.Spec.TargetRefs {
+		if string(targetRef.Group) == backend.Group && string(targetRef.Kind) == backend.Kind && string(targetRef.Name) == backend.Name {
+			return true
+		}
+	}
+	return false
+}
+
 // ReferenceGrantAccepts returns true if the provided reference grant "accepts"
 // references from the given resource. "Accepts" means that the resource is part
 // of the "From" fields within the ReferenceGrant.
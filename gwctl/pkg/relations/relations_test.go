@@ -0,0 +1,307 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package relations
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestFindDuplicateParentRefs(t *testing.T) {
+	testcases := []struct {
+		name       string
+		namespace  string
+		parentRefs []gatewayv1.ParentReference
+		want       []types.NamespacedName
+	}{
+		{
+			name:      "distinct parents",
+			namespace: "default",
+			parentRefs: []gatewayv1.ParentReference{
+				{Name: "foo-gateway"},
+				{Name: "bar-gateway"},
+				{Name: "foo-gateway", Namespace: common.PtrTo(gatewayv1.Namespace("other"))},
+			},
+			want: []types.NamespacedName{},
+		},
+		{
+			name:      "duplicate after namespace defaulting",
+			namespace: "default",
+			parentRefs: []gatewayv1.ParentReference{
+				{Name: "foo-gateway"},
+				{Name: "bar-gateway"},
+				{Name: "foo-gateway", Namespace: common.PtrTo(gatewayv1.Namespace("default"))},
+				{Name: "foo-gateway"},
+			},
+			want: []types.NamespacedName{{Namespace: "default", Name: "foo-gateway"}},
+		},
+		{
+			name: "route without namespace",
+			parentRefs: []gatewayv1.ParentReference{
+				{Name: "foo-gateway"},
+				{Name: "foo-gateway", Namespace: common.PtrTo(gatewayv1.Namespace("default"))},
+			},
+			want: []types.NamespacedName{{Namespace: "default", Name: "foo-gateway"}},
+		},
+		{
+			name:      "different sections and ports",
+			namespace: "default",
+			parentRefs: []gatewayv1.ParentReference{
+				{Name: "foo-gateway", SectionName: common.PtrTo(gatewayv1.SectionName("http"))},
+				{Name: "foo-gateway", SectionName: common.PtrTo(gatewayv1.SectionName("https"))},
+				{Name: "foo-gateway", Port: common.PtrTo(gatewayv1.PortNumber(80))},
+				{Name: "foo-gateway"},
+			},
+			want: []types.NamespacedName{},
+		},
+		{
+			name:      "same name but different kind",
+			namespace: "default",
+			parentRefs: []gatewayv1.ParentReference{
+				{Name: "foo"},
+				{Name: "foo", Group: common.PtrTo(gatewayv1.Group("")), Kind: common.PtrTo(gatewayv1.Kind("Service"))},
+			},
+			want: []types.NamespacedName{},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			route := gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tc.namespace, Name: "foo-httproute"},
+				Spec:       gatewayv1.HTTPRouteSpec{CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: tc.parentRefs}},
+			}
+			got := FindDuplicateParentRefs(route)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FindDuplicateParentRefs() returned unexpected parents (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestFindInvalidBackendPorts(t *testing.T) {
+	service := func(name string, serviceType corev1.ServiceType, ports ...int32) corev1.Service {
+		service := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: serviceType},
+		}
+		for _, port := range ports {
+			service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{Port: port})
+		}
+		return service
+	}
+	services := []corev1.Service{
+		service("foo-svc", corev1.ServiceTypeClusterIP, 80, 443),
+		service("external-svc", corev1.ServiceTypeExternalName),
+	}
+	backendRef := func(name string, port *gatewayv1.PortNumber) gatewayv1.BackendObjectReference {
+		return gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(name), Port: port}
+	}
+
+	testcases := []struct {
+		name        string
+		backendRefs []gatewayv1.BackendObjectReference
+		mirror      *gatewayv1.BackendObjectReference
+		want        []BackendPortIssue
+	}{
+		{
+			name:        "port of service",
+			backendRefs: []gatewayv1.BackendObjectReference{backendRef("foo-svc", common.PtrTo(gatewayv1.PortNumber(443)))},
+		},
+		{
+			name: "port missing from service is reported once",
+			backendRefs: []gatewayv1.BackendObjectReference{
+				backendRef("foo-svc", common.PtrTo(gatewayv1.PortNumber(8080))),
+				backendRef("foo-svc", common.PtrTo(gatewayv1.PortNumber(8080))),
+			},
+			want: []BackendPortIssue{
+				{Backend: common.ObjRef{Kind: "Service", Namespace: "default", Name: "foo-svc"}, Port: 8080, ServicePorts: []int32{80, 443}},
+			},
+		},
+		{
+			name:   "port of mirror missing from service",
+			mirror: common.PtrTo(backendRef("foo-svc", common.PtrTo(gatewayv1.PortNumber(9090)))),
+			want: []BackendPortIssue{
+				{Backend: common.ObjRef{Kind: "Service", Namespace: "default", Name: "foo-svc"}, Port: 9090, ServicePorts: []int32{80, 443}},
+			},
+		},
+		{
+			name: "unchecked backendRefs",
+			backendRefs: []gatewayv1.BackendObjectReference{
+				backendRef("foo-svc", nil),
+				backendRef("missing-svc", common.PtrTo(gatewayv1.PortNumber(8080))),
+				backendRef("external-svc", common.PtrTo(gatewayv1.PortNumber(8080))),
+				{Group: common.PtrTo(gatewayv1.Group("example.net")), Kind: common.PtrTo(gatewayv1.Kind("Bucket")), Name: "foo-svc", Port: common.PtrTo(gatewayv1.PortNumber(8080))},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := gatewayv1.HTTPRouteRule{}
+			for _, ref := range tc.backendRefs {
+				rule.BackendRefs = append(rule.BackendRefs, gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{BackendObjectReference: ref}})
+			}
+			if tc.mirror != nil {
+				rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{
+					Type:          gatewayv1.HTTPRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{BackendRef: *tc.mirror},
+				})
+			}
+			route := gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+				Spec:       gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{rule}},
+			}
+
+			got := FindInvalidBackendPorts(route, services)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FindInvalidBackendPorts() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestFindRoleBackendRefsForHTTPRoute(t *testing.T) {
+	backendRef := func(name string) gatewayv1.BackendObjectReference {
+		return gatewayv1.BackendObjectReference{Kind: common.PtrTo(gatewayv1.Kind("Service")), Name: gatewayv1.ObjectName(name)}
+	}
+	mirror := func(name string) gatewayv1.HTTPRouteFilter {
+		return gatewayv1.HTTPRouteFilter{
+			Type:          gatewayv1.HTTPRouteFilterRequestMirror,
+			RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{BackendRef: backendRef(name)},
+		}
+	}
+	route := gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-httproute"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef("foo-svc")}},
+						{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef("bar-svc")}},
+					},
+					Filters: []gatewayv1.HTTPRouteFilter{mirror("foo-svc"), mirror("shadow-svc")},
+				},
+				{
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef("foo-svc")}},
+					},
+					Filters: []gatewayv1.HTTPRouteFilter{mirror("shadow-svc")},
+				},
+			},
+		},
+	}
+
+	serviceRef := func(name string) common.ObjRef {
+		return common.ObjRef{Kind: "Service", Namespace: "default", Name: name}
+	}
+	want := []RoleBackendRef{
+		{Backend: serviceRef("bar-svc"), Role: BackendRefRolePrimary},
+		{Backend: serviceRef("foo-svc"), Role: BackendRefRolePrimary},
+		{Backend: serviceRef("foo-svc"), Role: BackendRefRoleMirror},
+		{Backend: serviceRef("shadow-svc"), Role: BackendRefRoleMirror},
+	}
+	got := FindRoleBackendRefsForHTTPRoute(route)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FindRoleBackendRefsForHTTPRoute() returned unexpected result (-want +got):\n%v", diff)
+	}
+}
+
+func TestFindRoutesAffectedByGatewayClass(t *testing.T) {
+	gateways := []gatewayv1.Gateway{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-gateway"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "infra", Name: "foo-gateway"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bar-gateway"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "bar-gatewayclass"},
+		},
+	}
+	httpRoute := func(namespace, name string, parentRefs ...gatewayv1.ParentReference) gatewayv1.HTTPRoute {
+		return gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       gatewayv1.HTTPRouteSpec{CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs}},
+		}
+	}
+
+	testcases := []struct {
+		name      string
+		className string
+		routes    []gatewayv1.HTTPRoute
+		want      []types.NamespacedName
+	}{
+		{
+			name:      "routes attached to Gateways of the class",
+			className: "foo-gatewayclass",
+			routes: []gatewayv1.HTTPRoute{
+				httpRoute("default", "route-b", gatewayv1.ParentReference{Name: "foo-gateway"}),
+				httpRoute("other", "route-a", gatewayv1.ParentReference{Name: "foo-gateway", Namespace: common.PtrTo(gatewayv1.Namespace("infra"))}),
+				httpRoute("default", "route-c", gatewayv1.ParentReference{Name: "bar-gateway"}),
+			},
+			want: []types.NamespacedName{
+				{Namespace: "default", Name: "route-b"},
+				{Namespace: "other", Name: "route-a"},
+			},
+		},
+		{
+			name:      "route attached to several Gateways of the class is returned once",
+			className: "foo-gatewayclass",
+			routes: []gatewayv1.HTTPRoute{
+				httpRoute("default", "route-a",
+					gatewayv1.ParentReference{Name: "foo-gateway"},
+					gatewayv1.ParentReference{Name: "foo-gateway", Namespace: common.PtrTo(gatewayv1.Namespace("infra"))},
+				),
+			},
+			want: []types.NamespacedName{{Namespace: "default", Name: "route-a"}},
+		},
+		{
+			name:      "route in another namespace without an explicit parent namespace",
+			className: "foo-gatewayclass",
+			routes: []gatewayv1.HTTPRoute{
+				httpRoute("other", "route-a", gatewayv1.ParentReference{Name: "foo-gateway"}),
+			},
+			want: []types.NamespacedName{},
+		},
+		{
+			name:      "class without Gateways",
+			className: "unused-gatewayclass",
+			routes: []gatewayv1.HTTPRoute{
+				httpRoute("default", "route-a", gatewayv1.ParentReference{Name: "foo-gateway"}),
+			},
+			want: []types.NamespacedName{},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FindRoutesAffectedByGatewayClass(tc.className, gateways, tc.routes)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FindRoutesAffectedByGatewayClass() returned unexpected routes (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
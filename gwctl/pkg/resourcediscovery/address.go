@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"net/netip"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+// GatewayHasAddress returns true if the status of the Gateway lists the
+// address. Addresses of type Hostname are compared case-insensitively, IP
+// addresses are compared as IPs, so that different notations of the same IPv6
+// address match, and addresses of other types must match exactly.
+func GatewayHasAddress(gateway *gatewayv1.Gateway, address string) bool {
+	for _, statusAddress := range gateway.Status.Addresses {
+		if addressMatches(statusAddress, address) {
+			return true
+		}
+	}
+	return false
+}
+
+func addressMatches(statusAddress gatewayv1.GatewayStatusAddress, address string) bool {
+	addressType := gatewayv1.IPAddressType
+	if statusAddress.Type != nil {
+		addressType = *statusAddress.Type
+	}
+	switch addressType {
+	case gatewayv1.HostnameAddressType:
+		return strings.EqualFold(statusAddress.Value, address)
+	case gatewayv1.IPAddressType:
+		ip, err := netip.ParseAddr(statusAddress.Value)
+		if err != nil {
+			return statusAddress.Value == address
+		}
+		other, err := netip.ParseAddr(address)
+		return err == nil && ip == other
+	default:
+		return statusAddress.Value == address
+	}
+}
+
+// RestrictToGatewayAddress removes the Gateways from the resourceModel whose
+// status does not list the address.
+func (rm *ResourceModel) RestrictToGatewayAddress(address string) {
+	for gatewayID, gatewayNode := range rm.Gateways {
+		if !GatewayHasAddress(gatewayNode.Gateway, address) {
+			delete(rm.Gateways, gatewayID)
+		}
+	}
+}
+
+// AddressLookup is the data path served through an address of a Gateway: the
+// listeners of the Gateway, and the HTTPRoutes served through each of them.
+type AddressLookup struct {
+	GatewayNode *GatewayNode
+	Listeners   []ListenerRoutes
+}
+
+// ListenerRoutes is a listener of a Gateway along with the HTTPRoutes which
+// attach to it.
+type ListenerRoutes struct {
+	Listener gatewayv1.Listener
+	Routes   []ServedRoute
+}
+
+// ServedRoute is an HTTPRoute attached to a listener, along with the hostnames
+// which it serves through the listener.
+type ServedRoute struct {
+	HTTPRoute common.ObjRef
+	Hostnames []string
+}
+
+// LookupAddress returns the data path of each Gateway in the resourceModel
+// whose status lists the address, sorted by namespace and name. An HTTPRoute is
+// served through a listener if its parentRefs select the listener, the
+// listener allows it, and their hostnames intersect, as evaluated from the
+// spec of the resources. HTTPRoutes matching any hostname are served with the
+// hostname "*".
+func LookupAddress(resourceModel *ResourceModel, address string) []AddressLookup {
+	gateways := make(map[types.NamespacedName]*gatewayv1.Gateway)
+	for _, gatewayNode := range resourceModel.Gateways {
+		gateways[client.ObjectKeyFromObject(gatewayNode.Gateway)] = gatewayNode.Gateway
+	}
+	namespaceLabels := make(map[string]map[string]string)
+	for _, namespaceNode := range resourceModel.Namespaces {
+		if namespaceNode.Namespace != nil {
+			namespaceLabels[namespaceNode.Namespace.GetName()] = namespaceNode.Namespace.GetLabels()
+		}
+	}
+
+	var result []AddressLookup
+	for _, gatewayNode := range sortedGatewayNodes(resourceModel) {
+		if !GatewayHasAddress(gatewayNode.Gateway, address) {
+			continue
+		}
+		gatewayName := client.ObjectKeyFromObject(gatewayNode.Gateway)
+		routesByListener := make(map[gatewayv1.SectionName][]ServedRoute)
+		for _, httpRouteNode := range gatewayNode.HTTPRoutes {
+			httpRoute := httpRouteNode.HTTPRoute
+			for _, attachment := range listenerAttachmentsForHTTPRoute(*httpRoute, gateways, namespaceLabels) {
+				if attachment.Gateway != gatewayName || attachment.Reason != "" {
+					continue
+				}
+				routes := routesByListener[attachment.Listener]
+				ref := common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: httpRoute.GetNamespace(), Name: httpRoute.GetName()}
+				// A route may select the same listener through several parentRefs.
+				if len(routes) != 0 && routes[len(routes)-1].HTTPRoute == ref {
+					continue
+				}
+				routesByListener[attachment.Listener] = append(routes, ServedRoute{HTTPRoute: ref, Hostnames: attachment.Hostnames})
+			}
+		}
+
+		lookup := AddressLookup{GatewayNode: gatewayNode}
+		for _, listener := range gatewayNode.Gateway.Spec.Listeners {
+			routes := routesByListener[listener.Name]
+			sort.Slice(routes, func(i, j int) bool {
+				a, b := routes[i].HTTPRoute, routes[j].HTTPRoute
+				if a.Namespace != b.Namespace {
+					return a.Namespace < b.Namespace
+				}
+				return a.Name < b.Name
+			})
+			lookup.Listeners = append(lookup.Listeners, ListenerRoutes{Listener: listener, Routes: routes})
+		}
+		result = append(result, lookup)
+	}
+	return result
+}
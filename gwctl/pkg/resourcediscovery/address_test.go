@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestGatewayHasAddress(t *testing.T) {
+	testcases := []struct {
+		name          string
+		statusAddress gatewayv1.GatewayStatusAddress
+		address       string
+		want          bool
+	}{
+		{
+			name:          "same IP",
+			statusAddress: gatewayv1.GatewayStatusAddress{Type: common.PtrTo(gatewayv1.IPAddressType), Value: "203.0.113.7"},
+			address:       "203.0.113.7",
+			want:          true,
+		},
+		{
+			name:          "different IP",
+			statusAddress: gatewayv1.GatewayStatusAddress{Type: common.PtrTo(gatewayv1.IPAddressType), Value: "203.0.113.7"},
+			address:       "203.0.113.70",
+			want:          false,
+		},
+		{
+			name:          "IP without type",
+			statusAddress: gatewayv1.GatewayStatusAddress{Value: "203.0.113.7"},
+			address:       "203.0.113.7",
+			want:          true,
+		},
+		{
+			name:          "different notations of the same IPv6 address",
+			statusAddress: gatewayv1.GatewayStatusAddress{Type: common.PtrTo(gatewayv1.IPAddressType), Value: "2001:db8::1"},
+			address:       "2001:DB8:0::1",
+			want:          true,
+		},
+		{
+			name:          "hostname in a different case",
+			statusAddress: gatewayv1.GatewayStatusAddress{Type: common.PtrTo(gatewayv1.HostnameAddressType), Value: "lb-123.elb.example.com"},
+			address:       "LB-123.elb.example.com",
+			want:          true,
+		},
+		{
+			name:          "different hostname",
+			statusAddress: gatewayv1.GatewayStatusAddress{Type: common.PtrTo(gatewayv1.HostnameAddressType), Value: "lb-123.elb.example.com"},
+			address:       "lb-456.elb.example.com",
+			want:          false,
+		},
+		{
+			name:          "implementation-specific type is matched exactly",
+			statusAddress: gatewayv1.GatewayStatusAddress{Type: common.PtrTo(gatewayv1.AddressType("example.net/named-address")), Value: "Foo"},
+			address:       "foo",
+			want:          false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &gatewayv1.Gateway{
+				Status: gatewayv1.GatewayStatus{Addresses: []gatewayv1.GatewayStatusAddress{tc.statusAddress}},
+			}
+			if got := GatewayHasAddress(gateway, tc.address); got != tc.want {
+				t.Errorf("GatewayHasAddress(%q) = %v, want %v", tc.address, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLookupAddress(t *testing.T) {
+	gateway := func(name, address string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: common.PtrTo(gatewayv1.Hostname("*.example.com"))},
+					{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+				},
+			},
+			Status: gatewayv1.GatewayStatus{
+				Addresses: []gatewayv1.GatewayStatusAddress{{Type: common.PtrTo(gatewayv1.IPAddressType), Value: address}},
+			},
+		}
+	}
+	httpRoute := func(name string, sectionName *gatewayv1.SectionName, hostnames ...gatewayv1.Hostname) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "foo-gateway", SectionName: sectionName}}},
+				Hostnames:       hostnames,
+			},
+		}
+	}
+
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-gatewayclass"},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: "example.net/gateway-controller"},
+		},
+		gateway("foo-gateway", "203.0.113.7"),
+		gateway("bar-gateway", "203.0.113.8"),
+		httpRoute("api-httproute", nil, "api.example.com"),
+		httpRoute("web-httproute", common.PtrTo(gatewayv1.SectionName("http")), "www.example.net"),
+		httpRoute("other-httproute", common.PtrTo(gatewayv1.SectionName("https")), "www.example.net"),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(Filter{Labels: labels.Everything()})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	type route struct {
+		HTTPRoute string
+		Hostnames []string
+	}
+	type listener struct {
+		Name   string
+		Routes []route
+	}
+	type lookup struct {
+		Gateway   string
+		Listeners []listener
+	}
+	var got []lookup
+	for _, l := range LookupAddress(resourceModel, "203.0.113.7") {
+		gotLookup := lookup{Gateway: l.GatewayNode.Gateway.GetName()}
+		for _, listenerRoutes := range l.Listeners {
+			gotListener := listener{Name: string(listenerRoutes.Listener.Name)}
+			for _, servedRoute := range listenerRoutes.Routes {
+				gotListener.Routes = append(gotListener.Routes, route{HTTPRoute: servedRoute.HTTPRoute.Name, Hostnames: servedRoute.Hostnames})
+			}
+			gotLookup.Listeners = append(gotLookup.Listeners, gotListener)
+		}
+		got = append(got, gotLookup)
+	}
+
+	want := []lookup{{
+		Gateway: "foo-gateway",
+		Listeners: []listener{
+			{Name: "https", Routes: []route{{HTTPRoute: "api-httproute", Hostnames: []string{"api.example.com"}}}},
+			{Name: "http", Routes: []route{
+				{HTTPRoute: "api-httproute", Hostnames: []string{"api.example.com"}},
+				{HTTPRoute: "web-httproute", Hostnames: []string{"www.example.net"}},
+			}},
+		},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LookupAddress() returned unexpected diff (-want +got):\n%v", diff)
+	}
+
+	resourceModel.RestrictToGatewayAddress("203.0.113.8")
+	var gateways []string
+	for _, gatewayNode := range resourceModel.Gateways {
+		gateways = append(gateways, gatewayNode.Gateway.GetName())
+	}
+	if diff := cmp.Diff([]string{"bar-gateway"}, gateways); diff != "" {
+		t.Errorf("RestrictToGatewayAddress() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
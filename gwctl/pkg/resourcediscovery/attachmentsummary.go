@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+)
+
+// AttachmentSummary aggregates the status and relations of an HTTPRoute into
+// counts which summarize where it is attached and where it routes to.
+type AttachmentSummary struct {
+	// Gateways is the number of distinct Gateways referenced by the parentRefs
+	// of the HTTPRoute, of which Accepted reported accepting it, Rejected
+	// reported not accepting it, and the others have not reported any status.
+	Gateways int
+	Accepted int
+	Rejected int
+	// Hostnames is the number of distinct hostnames served through the
+	// listeners which the HTTPRoute attaches to, leaving out the Gateways which
+	// rejected it. An HTTPRoute without hostnames serves the hostname of the
+	// listener, or "*" for listeners matching any hostname. It is only known if
+	// HostnamesKnown is true, since it depends on the Attachments of the node.
+	Hostnames      int
+	HostnamesKnown bool
+	// Backends is the number of distinct Backends between which the rules of
+	// the HTTPRoute split traffic, leaving out those it only mirrors traffic to.
+	Backends int
+}
+
+// SummarizeAttachments returns the AttachmentSummary of the HTTPRoute, built
+// from its status, its spec and the Attachments of the node.
+func SummarizeAttachments(httpRouteNode *HTTPRouteNode) AttachmentSummary {
+	httpRoute := httpRouteNode.HTTPRoute
+	var result AttachmentSummary
+
+	rejected := make(map[types.NamespacedName]bool)
+	seen := make(map[types.NamespacedName]bool)
+	for _, parentRef := range httpRoute.Spec.ParentRefs {
+		if parentRef.Kind != nil && *parentRef.Kind != "Gateway" {
+			continue
+		}
+		namespace := httpRoute.GetNamespace()
+		if parentRef.Namespace != nil {
+			namespace = string(*parentRef.Namespace)
+		}
+		gateway := types.NamespacedName{Namespace: namespace, Name: string(parentRef.Name)}
+		if seen[gateway] {
+			continue
+		}
+		seen[gateway] = true
+		result.Gateways++
+		switch accepted, reported := gatewayAcceptedHTTPRoute(httpRoute, gateway); {
+		case accepted:
+			result.Accepted++
+		case reported:
+			result.Rejected++
+			rejected[gateway] = true
+		}
+	}
+
+	result.HostnamesKnown = !httpRouteNode.AttachmentsUnknown
+	hostnames := make(map[string]bool)
+	for _, attachment := range httpRouteNode.Attachments {
+		if attachment.Reason != "" || rejected[attachment.Gateway] {
+			continue
+		}
+		for _, hostname := range attachment.Hostnames {
+			hostnames[hostname] = true
+		}
+	}
+	result.Hostnames = len(hostnames)
+
+	var backendRefs []gatewayv1.BackendObjectReference
+	for _, rule := range httpRoute.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			backendRefs = append(backendRefs, backendRef.BackendObjectReference)
+		}
+	}
+	result.Backends = len(relations.FindBackendRefsForRoute(httpRoute.GetNamespace(), backendRefs))
+	return result
+}
+
+// String returns the summary as a sentence, like "Attached to 2 gateways (1
+// accepted, 1 rejected), serving 3 hostnames, routing to 2 backends".
+func (s AttachmentSummary) String() string {
+	attached := fmt.Sprintf("Attached to %v", pluralize(s.Gateways, "gateway"))
+	var statuses []string
+	if s.Accepted != 0 {
+		statuses = append(statuses, fmt.Sprintf("%d accepted", s.Accepted))
+	}
+	if s.Rejected != 0 {
+		statuses = append(statuses, fmt.Sprintf("%d rejected", s.Rejected))
+	}
+	if pending := s.Gateways - s.Accepted - s.Rejected; pending != 0 {
+		statuses = append(statuses, fmt.Sprintf("%d pending", pending))
+	}
+	if len(statuses) != 0 {
+		attached += fmt.Sprintf(" (%v)", strings.Join(statuses, ", "))
+	}
+	hostnames := "Unknown hostnames"
+	if s.HostnamesKnown {
+		hostnames = pluralize(s.Hostnames, "hostname")
+	}
+	return fmt.Sprintf("%v, serving %v, routing to %v", attached, hostnames, pluralize(s.Backends, "backend"))
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %v", noun)
+	}
+	return fmt.Sprintf("%d %vs", count, noun)
+}
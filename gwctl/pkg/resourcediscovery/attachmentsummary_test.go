@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestSummarizeAttachments(t *testing.T) {
+	gatewayA := types.NamespacedName{Namespace: "default", Name: "gateway-a"}
+	gatewayB := types.NamespacedName{Namespace: "default", Name: "gateway-b"}
+	parentStatus := func(gateway string, accepted metav1.ConditionStatus) gatewayv1.RouteParentStatus {
+		return gatewayv1.RouteParentStatus{
+			ParentRef:  gatewayv1.ParentReference{Name: gatewayv1.ObjectName(gateway)},
+			Conditions: []metav1.Condition{{Type: string(gatewayv1.RouteConditionAccepted), Status: accepted}},
+		}
+	}
+	backendRef := func(name string) gatewayv1.BackendObjectReference {
+		return gatewayv1.BackendObjectReference{Kind: common.PtrTo(gatewayv1.Kind("Service")), Name: gatewayv1.ObjectName(name)}
+	}
+
+	testcases := []struct {
+		name        string
+		parentRefs  []gatewayv1.ParentReference
+		rules       []gatewayv1.HTTPRouteRule
+		attachments []ListenerAttachment
+		// attachmentsUnknown is true if the attachments could not be evaluated.
+		attachmentsUnknown bool
+		parents            []gatewayv1.RouteParentStatus
+		want               string
+	}{
+		{
+			name: "accepted and rejected by different Gateways",
+			parentRefs: []gatewayv1.ParentReference{
+				{Name: "gateway-a", SectionName: common.PtrTo(gatewayv1.SectionName("https"))},
+				{Name: "gateway-a", SectionName: common.PtrTo(gatewayv1.SectionName("http"))},
+				{Name: "gateway-b"},
+			},
+			rules: []gatewayv1.HTTPRouteRule{
+				{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef("foo-svc")}}}},
+				{
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef("foo-svc")}},
+						{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef("bar-svc")}},
+					},
+					Filters: []gatewayv1.HTTPRouteFilter{{
+						Type:          gatewayv1.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{BackendRef: backendRef("mirror-svc")},
+					}},
+				},
+			},
+			attachments: []ListenerAttachment{
+				{Gateway: gatewayA, Listener: "https", Hostnames: []string{"api.example.com", "www.example.com"}},
+				{Gateway: gatewayA, Listener: "http", Hostnames: []string{"api.example.com", "www.example.com", "example.com"}},
+				{Gateway: gatewayB, Listener: "http", Hostnames: []string{"other.example.com"}},
+			},
+			parents: []gatewayv1.RouteParentStatus{
+				parentStatus("gateway-a", metav1.ConditionTrue),
+				parentStatus("gateway-b", metav1.ConditionFalse),
+			},
+			want: "Attached to 2 gateways (1 accepted, 1 rejected), serving 3 hostnames, routing to 2 backends",
+		},
+		{
+			name:        "not reconciled yet",
+			parentRefs:  []gatewayv1.ParentReference{{Name: "gateway-a"}},
+			rules:       []gatewayv1.HTTPRouteRule{{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef("foo-svc")}}}}},
+			attachments: []ListenerAttachment{{Gateway: gatewayA, Listener: "http", Hostnames: []string{"*"}}},
+			want:        "Attached to 1 gateway (1 pending), serving 1 hostname, routing to 1 backend",
+		},
+		{
+			name: "no parent Gateway",
+			want: "Attached to 0 gateways, serving 0 hostnames, routing to 0 backends",
+		},
+		{
+			name:               "attachments could not be evaluated",
+			parentRefs:         []gatewayv1.ParentReference{{Name: "gateway-a"}},
+			rules:              []gatewayv1.HTTPRouteRule{{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef("foo-svc")}}}}},
+			attachmentsUnknown: true,
+			parents:            []gatewayv1.RouteParentStatus{parentStatus("gateway-a", metav1.ConditionTrue)},
+			want:               "Attached to 1 gateway (1 accepted), serving Unknown hostnames, routing to 1 backend",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpRouteNode := NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: tc.parentRefs},
+					Rules:           tc.rules,
+				},
+				Status: gatewayv1.HTTPRouteStatus{RouteStatus: gatewayv1.RouteStatus{Parents: tc.parents}},
+			})
+			httpRouteNode.Attachments = tc.attachments
+			httpRouteNode.AttachmentsUnknown = tc.attachmentsUnknown
+
+			if got := SummarizeAttachments(httpRouteNode).String(); got != tc.want {
+				t.Errorf("SummarizeAttachments() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+// Bundle is a snapshot of all Gateway API resources of the cluster, meant to be
+// shared for troubleshooting.
+type Bundle struct {
+	// CRDs are the CRDs of the Gateway API kinds and of the Policy kinds.
+	CRDs []unstructured.Unstructured
+	// Objects are the resources of all kinds defined by the CRDs, including
+	// their status. They are sorted by kind, namespace and name.
+	Objects []BundleObject
+}
+
+// BundleObject is a resource within a Bundle, along with its events.
+type BundleObject struct {
+	Object unstructured.Unstructured
+	Events []corev1.Event
+}
+
+// DiscoverBundle collects the resources of all Gateway API and Policy kinds,
+// along with their CRDs and events. Kinds whose resources cannot be listed are
+// skipped, so that a partial bundle can still be shared.
+func (d Discoverer) DiscoverBundle() (*Bundle, error) {
+	ctx := context.Background()
+	unstructuredCRDs, err := d.K8sClients.DC.Resource(common.CustomResourceDefinitionsPermission.GroupVersionResource("v1")).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CRDs: %v", err)
+	}
+	policyCRDs := make(map[string]bool)
+	for _, policyCRD := range d.PolicyManager.GetCRDs() {
+		policyCRDs[policyCRD.CRD().Name] = true
+	}
+
+	sort.Slice(unstructuredCRDs.Items, func(i, j int) bool {
+		return unstructuredCRDs.Items[i].GetName() < unstructuredCRDs.Items[j].GetName()
+	})
+	result := &Bundle{}
+	var gvrs []schema.GroupVersionResource
+	var kinds []string
+	for _, u := range unstructuredCRDs.Items {
+		crd := apiextensionsv1.CustomResourceDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), &crd); err != nil {
+			return nil, fmt.Errorf("failed to convert unstructured CRD to structured: %v", err)
+		}
+		if crd.Spec.Group == gatewayv1.GroupName || policyCRDs[crd.Name] {
+			result.CRDs = append(result.CRDs, u)
+			gvrs = append(gvrs, schema.GroupVersionResource{Group: crd.Spec.Group, Version: storageVersion(crd), Resource: crd.Spec.Names.Plural})
+			kinds = append(kinds, crd.Spec.Names.Kind)
+		}
+	}
+
+	for i, gvr := range gvrs {
+		d.step(common.ProgressStep{Action: "listed", Done: i, Total: len(gvrs), Unit: "kinds"})
+		objects, err := d.K8sClients.DC.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			klog.V(1).ErrorS(err, "Failed to list resources for the bundle", "resource", gvr)
+			continue
+		}
+		for _, object := range objects.Items {
+			// The items of lists do not always carry their kind.
+			object.SetGroupVersionKind(gvr.GroupVersion().WithKind(kinds[i]))
+			result.Objects = append(result.Objects, BundleObject{Object: object})
+		}
+	}
+	d.step(common.ProgressStep{Action: "listed", Done: len(gvrs), Total: len(gvrs), Unit: "kinds"})
+	sort.SliceStable(result.Objects, func(i, j int) bool {
+		a, b := result.Objects[i].Object, result.Objects[j].Object
+		if a.GroupVersionKind().GroupKind() != b.GroupVersionKind().GroupKind() {
+			return a.GroupVersionKind().GroupKind().String() < b.GroupVersionKind().GroupKind().String()
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+
+	// Events are listed once and matched locally to their objects, rather than
+	// listed once per object.
+	events, err := d.listEventIndex(ctx)
+	if err != nil {
+		klog.V(1).ErrorS(err, "Failed to list events for the bundle")
+		return result, nil
+	}
+	for i := range result.Objects {
+		result.Objects[i].Events = events.eventsFor(&result.Objects[i].Object)
+	}
+	return result, nil
+}
+
+// eventObjectKey identifies the object involved in an event.
+type eventObjectKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// eventIndex holds the events of the cluster by the object involved in them.
+type eventIndex map[eventObjectKey][]corev1.Event
+
+// listEventIndex lists all events of the cluster at once, and indexes them by
+// the object involved in them.
+func (d Discoverer) listEventIndex(ctx context.Context) (eventIndex, error) {
+	eventList := &corev1.EventList{}
+	if err := d.K8sClients.Client.List(ctx, eventList); err != nil {
+		return nil, err
+	}
+	result := make(eventIndex)
+	for _, event := range eventList.Items {
+		key := eventObjectKey{
+			kind:      event.InvolvedObject.Kind,
+			namespace: event.InvolvedObject.Namespace,
+			name:      event.InvolvedObject.Name,
+		}
+		result[key] = append(result[key], event)
+	}
+	return result, nil
+}
+
+// eventsFor returns the events involving the object. Events of an earlier
+// object with the same name are left out.
+func (index eventIndex) eventsFor(object *unstructured.Unstructured) []corev1.Event {
+	var result []corev1.Event
+	key := eventObjectKey{kind: object.GetKind(), namespace: object.GetNamespace(), name: object.GetName()}
+	for _, event := range index[key] {
+		if object.GetUID() != "" && event.InvolvedObject.UID != "" && event.InvolvedObject.UID != object.GetUID() {
+			continue
+		}
+		result = append(result, event)
+	}
+	return result
+}
+
+// storageVersion returns the version in which the resources of the CRD are
+// stored, falling back to the first served version.
+func storageVersion(crd apiextensionsv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+	for _, version := range crd.Spec.Versions {
+		if version.Served {
+			return version.Name
+		}
+	}
+	return ""
+}
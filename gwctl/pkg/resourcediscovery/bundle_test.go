@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestDiscoverBundle(t *testing.T) {
+	crd := func(group, plural, kind string, labels map[string]string) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   plural + "." + group,
+				Labels: labels,
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    group,
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: plural,
+					Kind:   kind,
+				},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		crd(gatewayv1.GroupName, "gateways", "Gateway", nil),
+		crd(gatewayv1.GroupName, "httproutes", "HTTPRoute", nil),
+		crd("foo.com", "healthcheckpolicies", "HealthCheckPolicy", map[string]string{gatewayv1alpha2.PolicyLabelKey: "inherited"}),
+		// CRDs of other groups are not part of the bundle.
+		crd("bar.com", "widgets", "Widget", nil),
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-gateway",
+				Namespace: "default",
+				UID:       "foo-gateway-uid",
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-httproute",
+				Namespace: "default",
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "foo.com/v1",
+				"kind":       "HealthCheckPolicy",
+				"metadata": map[string]interface{}{
+					"name":      "health-check",
+					"namespace": "default",
+				},
+				"spec": map[string]interface{}{
+					"targetRef": map[string]interface{}{
+						"group": "gateway.networking.k8s.io",
+						"kind":  "Gateway",
+						"name":  "foo-gateway",
+					},
+				},
+			},
+		},
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-gateway-event",
+				Namespace: "default",
+			},
+			InvolvedObject: corev1.ObjectReference{Kind: "Gateway", Name: "foo-gateway", Namespace: "default", UID: "foo-gateway-uid"},
+			Reason:         "Programmed",
+		},
+		// Events of other kinds, or of an earlier object with the same name, are
+		// not attached to the Gateway.
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-gateway-other-kind-event",
+				Namespace: "default",
+			},
+			InvolvedObject: corev1.ObjectReference{Kind: "Service", Name: "foo-gateway", Namespace: "default"},
+			Reason:         "Synced",
+		},
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-gateway-old-event",
+				Namespace: "default",
+			},
+			InvolvedObject: corev1.ObjectReference{Kind: "Gateway", Name: "foo-gateway", Namespace: "default", UID: "old-foo-gateway-uid"},
+			Reason:         "Deleted",
+		},
+		&corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "health-check-event",
+				Namespace: "default",
+			},
+			InvolvedObject: corev1.ObjectReference{Kind: "HealthCheckPolicy", Name: "health-check", Namespace: "default"},
+			Reason:         "Accepted",
+		},
+	}
+	k8sClients := common.MustClientsForTest(t, objects...)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: utils.MustPolicyManagerForTest(t, k8sClients),
+	}
+
+	bundle, err := discoverer.DiscoverBundle()
+	if err != nil {
+		t.Fatalf("DiscoverBundle() returned an unexpected error: %v", err)
+	}
+
+	var gotCRDs []string
+	for _, crd := range bundle.CRDs {
+		gotCRDs = append(gotCRDs, crd.GetName())
+	}
+	wantCRDs := []string{"gateways.gateway.networking.k8s.io", "healthcheckpolicies.foo.com", "httproutes.gateway.networking.k8s.io"}
+	if diff := cmp.Diff(wantCRDs, gotCRDs); diff != "" {
+		t.Errorf("Unexpected diff in CRDs (-want +got)=\n%v", diff)
+	}
+
+	var gotObjects, gotEvents []string
+	for _, bundleObject := range bundle.Objects {
+		name := bundleObject.Object.GroupVersionKind().Kind + "/" + bundleObject.Object.GetName()
+		gotObjects = append(gotObjects, name)
+		for _, event := range bundleObject.Events {
+			gotEvents = append(gotEvents, name+": "+event.Reason)
+		}
+	}
+	wantObjects := []string{"Gateway/foo-gateway", "HTTPRoute/foo-httproute", "HealthCheckPolicy/health-check"}
+	if diff := cmp.Diff(wantObjects, gotObjects); diff != "" {
+		t.Errorf("Unexpected diff in Objects (-want +got)=\n%v", diff)
+	}
+	wantEvents := []string{"Gateway/foo-gateway: Programmed", "HealthCheckPolicy/health-check: Accepted"}
+	if diff := cmp.Diff(wantEvents, gotEvents); diff != "" {
+		t.Errorf("Unexpected diff in Events (-want +got)=\n%v", diff)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+// CertificateView describes the TLS certificate stored in a Secret which is
+// referenced by the certificateRefs of a Gateway listener.
+type CertificateView struct {
+	Gateway  common.ObjRef `json:"gateway"`
+	Listener string        `json:"listener"`
+	Secret   common.ObjRef `json:"secret"`
+	// NotAfter and Issuer are only set if the certificate could be parsed.
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	Issuer   string       `json:"issuer,omitempty"`
+	// Error describes why the certificate could not be read, if it could not.
+	Error string `json:"error,omitempty"`
+}
+
+// CertificatesForGateways returns the certificates referenced by the listeners
+// of all Gateways in the resourceModel, sorted by Gateway and then by the
+// order in which they appear within the Gateway. Failures to read a single
+// certificate are recorded in the Error of its CertificateView.
+func (d Discoverer) CertificatesForGateways(resourceModel *ResourceModel) []CertificateView {
+	ctx := context.Background()
+
+	var result []CertificateView
+	for _, gatewayNode := range sortedGatewayNodes(resourceModel) {
+		gatewayRef := gatewayRef(gatewayNode)
+		for _, listener := range gatewayNode.Gateway.Spec.Listeners {
+			if listener.TLS == nil {
+				continue
+			}
+			for _, certificateRef := range listener.TLS.CertificateRefs {
+				result = append(result, d.certificateForRef(ctx, gatewayRef, string(listener.Name), certificateRef))
+			}
+		}
+	}
+	return result
+}
+
+// DiscoverCertificates returns the certificates referenced by the listeners of
+// all Gateways of the cluster, like CertificatesForGateways.
+func (d Discoverer) DiscoverCertificates() ([]CertificateView, error) {
+	gateways, err := d.fetchGateways(context.Background(), Filter{ /* all Gateways */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	resourceModel := &ResourceModel{}
+	resourceModel.addGateways(gateways...)
+	return d.CertificatesForGateways(resourceModel), nil
+}
+
+func (d Discoverer) certificateForRef(ctx context.Context, gatewayRef common.ObjRef, listenerName string, certificateRef gatewayv1.SecretObjectReference) CertificateView {
+	view := CertificateView{
+		Gateway:  gatewayRef,
+		Listener: listenerName,
+		Secret: common.ObjRef{
+			Kind:      "Secret",
+			Name:      string(certificateRef.Name),
+			Namespace: gatewayRef.Namespace,
+		},
+	}
+	if certificateRef.Group != nil {
+		view.Secret.Group = string(*certificateRef.Group)
+	}
+	if certificateRef.Kind != nil {
+		view.Secret.Kind = string(*certificateRef.Kind)
+	}
+	if certificateRef.Namespace != nil {
+		view.Secret.Namespace = string(*certificateRef.Namespace)
+	}
+	if view.Secret.Group != "" || view.Secret.Kind != "Secret" {
+		view.Error = fmt.Sprintf("unsupported certificateRef kind %v", view.Secret.Kind)
+		return view
+	}
+
+	secret := &corev1.Secret{}
+	if err := d.K8sClients.Client.Get(ctx, client.ObjectKey{Namespace: view.Secret.Namespace, Name: view.Secret.Name}, secret); err != nil {
+		view.Error = fmt.Sprintf("failed to get Secret: %v", err)
+		return view
+	}
+	certificate, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		view.Error = err.Error()
+		return view
+	}
+	view.NotAfter = &metav1.Time{Time: certificate.NotAfter}
+	view.Issuer = certificate.Issuer.String()
+	return view
+}
+
+// parseCertificate parses the first certificate within the PEM encoded data.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no %v in Secret", corev1.TLSCertKey)
+	}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM encoded certificate found in %v", corev1.TLSCertKey)
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %v", err)
+		}
+		return certificate, nil
+	}
+}
+
+// FilterCertificatesExpiringBefore returns the certificates which expire
+// before the deadline. Certificates which could not be read are kept, since
+// their expiry is unknown.
+func FilterCertificatesExpiringBefore(certificates []CertificateView, deadline time.Time) []CertificateView {
+	var result []CertificateView
+	for _, certificate := range certificates {
+		if certificate.NotAfter == nil || certificate.NotAfter.Time.Before(deadline) {
+			result = append(result, certificate)
+		}
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+// clusterSnapshotCoreKinds are the kinds outside of Gateway API which the
+// commands read, and which are therefore part of a ClusterSnapshot. Secrets are
+// deliberately left out so that snapshots can be shared, which means the
+// certificates of listeners cannot be inspected from a snapshot.
+var clusterSnapshotCoreKinds = map[string]schema.GroupVersionResource{
+	"Namespace":     common.NamespacesPermission.GroupVersionResource(corev1.SchemeGroupVersion.Version),
+	"Service":       serviceGVR,
+	"EndpointSlice": common.EndpointSlicesPermission.GroupVersionResource(discoveryv1.SchemeGroupVersion.Version),
+}
+
+// DiscoverClusterSnapshot collects everything which the read-only commands
+// read from the cluster: the resources of all Gateway API and Policy kinds
+// along with their CRDs, the Namespaces, Services and EndpointSlices, and the
+// events of all of them. The managedFields of the resources are kept, so that
+// the field owners of resources can be inspected from the snapshot.
+func (d Discoverer) DiscoverClusterSnapshot(c clock.PassiveClock) (*common.ClusterSnapshot, error) {
+	ctx := context.Background()
+	bundle, err := d.DiscoverBundle()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &common.ClusterSnapshot{CreatedAt: metav1.NewTime(c.Now())}
+	var events []corev1.Event
+	for _, crd := range bundle.CRDs {
+		crd.SetGroupVersionKind(common.CustomResourceDefinitionsPermission.GroupVersionResource("v1").GroupVersion().WithKind("CustomResourceDefinition"))
+		result.Objects = append(result.Objects, crd)
+	}
+	for _, bundleObject := range bundle.Objects {
+		result.Objects = append(result.Objects, bundleObject.Object)
+		events = append(events, bundleObject.Events...)
+	}
+
+	// The events of the core objects are listed once and matched locally, like
+	// those of the bundle.
+	eventsByObject, err := d.listEventIndex(ctx)
+	if err != nil {
+		klog.V(1).ErrorS(err, "Failed to list events for the snapshot")
+	}
+	for kind, gvr := range clusterSnapshotCoreKinds {
+		objects, err := d.K8sClients.DC.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %v: %v", gvr.Resource, err)
+		}
+		for _, object := range objects.Items {
+			// The items of lists do not always carry their kind.
+			object.SetGroupVersionKind(gvr.GroupVersion().WithKind(kind))
+			result.Objects = append(result.Objects, object)
+			events = append(events, eventsByObject.eventsFor(&object)...)
+		}
+	}
+
+	seenEvents := make(map[string]bool)
+	for _, event := range events {
+		key := event.Namespace + "/" + event.Name
+		if seenEvents[key] {
+			continue
+		}
+		seenEvents[key] = true
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert Event to unstructured: %v", err)
+		}
+		object := unstructured.Unstructured{Object: content}
+		object.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Event"))
+		result.Objects = append(result.Objects, object)
+	}
+
+	sort.SliceStable(result.Objects, func(i, j int) bool {
+		a, b := result.Objects[i], result.Objects[j]
+		if a.GroupVersionKind().GroupKind() != b.GroupVersionKind().GroupKind() {
+			return a.GroupVersionKind().GroupKind().String() < b.GroupVersionKind().GroupKind().String()
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+	return result, nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"sort"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+// GatewayComparison captures the differences between two Gateways. The
+// "Left" and "Right" naming is used throughout to refer to the first and
+// second Gateway respectively.
+type GatewayComparison struct {
+	Left  common.ObjRef
+	Right common.ObjRef
+
+	// Listeners compares the (port, protocol, hostname) tuples of the
+	// listeners.
+	Listeners SetComparison
+	// AttachedRoutes compares the routes attached to each Gateway.
+	AttachedRoutes SetComparison
+	// AddressTypes compares the types of addresses reported in the status.
+	AddressTypes SetComparison
+	// DirectlyAttachedPolicies compares the policies which directly target
+	// each Gateway.
+	DirectlyAttachedPolicies SetComparison
+	// InheritedPolicies compares the policies which are inherited from the
+	// GatewayClass and the Namespace of each Gateway.
+	InheritedPolicies SetComparison
+
+	// Differences is the total number of items which are present in only one
+	// of the Gateways, across all sections.
+	Differences int
+}
+
+// SetComparison partitions the items of two sets into those present only in
+// the left set, only in the right set, or in both. Each partition is sorted.
+type SetComparison struct {
+	OnlyInLeft  []string
+	OnlyInRight []string
+	Both        []string
+}
+
+// Differences returns the number of items present in only one of the sets.
+func (s SetComparison) Differences() int {
+	return len(s.OnlyInLeft) + len(s.OnlyInRight)
+}
+
+// CompareGateways computes the differences between two GatewayNodes.
+func CompareGateways(left, right *GatewayNode) GatewayComparison {
+	result := GatewayComparison{
+		Left:                     gatewayRef(left),
+		Right:                    gatewayRef(right),
+		Listeners:                compareSets(listenerKeys(left), listenerKeys(right)),
+		AttachedRoutes:           compareSets(attachedRouteKeys(left), attachedRouteKeys(right)),
+		AddressTypes:             compareSets(addressTypeKeys(left), addressTypeKeys(right)),
+		DirectlyAttachedPolicies: compareSets(policyKeys(left.Policies), policyKeys(right.Policies)),
+		InheritedPolicies:        compareSets(inheritedPolicyKeys(left), inheritedPolicyKeys(right)),
+	}
+	for _, s := range []SetComparison{
+		result.Listeners,
+		result.AttachedRoutes,
+		result.AddressTypes,
+		result.DirectlyAttachedPolicies,
+		result.InheritedPolicies,
+	} {
+		result.Differences += s.Differences()
+	}
+	return result
+}
+
+func gatewayRef(gatewayNode *GatewayNode) common.ObjRef {
+	return common.ObjRef{
+		Group:     gatewayv1.GroupName,
+		Kind:      "Gateway",
+		Name:      gatewayNode.Gateway.GetName(),
+		Namespace: gatewayNode.Gateway.GetNamespace(),
+	}
+}
+
+// listenerKeys returns a "port/protocol[/hostname]" key for each listener.
+func listenerKeys(gatewayNode *GatewayNode) map[string]bool {
+	result := make(map[string]bool)
+	for _, listener := range gatewayNode.Gateway.Spec.Listeners {
+		key := fmt.Sprintf("%d/%v", listener.Port, listener.Protocol)
+		if listener.Hostname != nil && *listener.Hostname != "" {
+			key = fmt.Sprintf("%v/%v", key, *listener.Hostname)
+		}
+		result[key] = true
+	}
+	return result
+}
+
+func attachedRouteKeys(gatewayNode *GatewayNode) map[string]bool {
+	result := make(map[string]bool)
+	for _, httpRouteNode := range gatewayNode.HTTPRoutes {
+		key := fmt.Sprintf("HTTPRoute %v/%v", httpRouteNode.HTTPRoute.GetNamespace(), httpRouteNode.HTTPRoute.GetName())
+		result[key] = true
+	}
+	return result
+}
+
+func addressTypeKeys(gatewayNode *GatewayNode) map[string]bool {
+	result := make(map[string]bool)
+	for _, address := range gatewayNode.Gateway.Status.Addresses {
+		addressType := gatewayv1.IPAddressType
+		if address.Type != nil {
+			addressType = *address.Type
+		}
+		result[string(addressType)] = true
+	}
+	return result
+}
+
+func policyKeys(policies map[policyID]*PolicyNode) map[string]bool {
+	result := make(map[string]bool)
+	for _, policyRef := range ConvertPoliciesMapToPolicyRefs(policies) {
+		name := policyRef.Name
+		if policyRef.Namespace != "" {
+			name = fmt.Sprintf("%v/%v", policyRef.Namespace, name)
+		}
+		result[fmt.Sprintf("%v.%v %v", policyRef.Kind, policyRef.Group, name)] = true
+	}
+	return result
+}
+
+// inheritedPolicyKeys returns keys for the policies attached to the
+// GatewayClass and the Namespace of the Gateway.
+func inheritedPolicyKeys(gatewayNode *GatewayNode) map[string]bool {
+	result := make(map[string]bool)
+	if gatewayNode.GatewayClass != nil {
+		for key := range policyKeys(gatewayNode.GatewayClass.Policies) {
+			result[key] = true
+		}
+	}
+	if gatewayNode.Namespace != nil {
+		for key := range policyKeys(gatewayNode.Namespace.Policies) {
+			result[key] = true
+		}
+	}
+	return result
+}
+
+func compareSets(left, right map[string]bool) SetComparison {
+	result := SetComparison{
+		OnlyInLeft:  []string{},
+		OnlyInRight: []string{},
+		Both:        []string{},
+	}
+	for key := range left {
+		if right[key] {
+			result.Both = append(result.Both, key)
+		} else {
+			result.OnlyInLeft = append(result.OnlyInLeft, key)
+		}
+	}
+	for key := range right {
+		if !left[key] {
+			result.OnlyInRight = append(result.OnlyInRight, key)
+		}
+	}
+	sort.Strings(result.OnlyInLeft)
+	sort.Strings(result.OnlyInRight)
+	sort.Strings(result.Both)
+	return result
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestCompareGateways(t *testing.T) {
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		common.NamespaceForTest("ns2"),
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "old",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+					{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: common.PtrTo(gatewayv1.Hostname("foo.com"))},
+				},
+			},
+			Status: gatewayv1.GatewayStatus{
+				Addresses: []gatewayv1.GatewayStatusAddress{{Value: "10.0.0.1"}},
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "new",
+				Namespace: "ns2",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+					{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: common.PtrTo(gatewayv1.Hostname("bar.com"))},
+					{Name: "http-8080", Port: 8080, Protocol: gatewayv1.HTTPProtocolType},
+				},
+			},
+			Status: gatewayv1.GatewayStatus{
+				Addresses: []gatewayv1.GatewayStatusAddress{{Type: common.PtrTo(gatewayv1.HostnameAddressType), Value: "lb.example.com"}},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shared-route",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{
+						{Name: "old"},
+						{Name: "new", Namespace: common.PtrTo(gatewayv1.Namespace("ns2"))},
+					},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "old-route",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "old"}},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(Filter{})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	got := CompareGateways(
+		resourceModel.Gateways[GatewayID("default", "old")],
+		resourceModel.Gateways[GatewayID("ns2", "new")],
+	)
+	want := GatewayComparison{
+		Left:  common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "default", Name: "old"},
+		Right: common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "ns2", Name: "new"},
+		Listeners: SetComparison{
+			OnlyInLeft:  []string{"443/HTTPS/foo.com"},
+			OnlyInRight: []string{"443/HTTPS/bar.com", "8080/HTTP"},
+			Both:        []string{"80/HTTP"},
+		},
+		AttachedRoutes: SetComparison{
+			OnlyInLeft:  []string{"HTTPRoute default/old-route"},
+			OnlyInRight: []string{},
+			Both:        []string{"HTTPRoute default/shared-route"},
+		},
+		AddressTypes: SetComparison{
+			OnlyInLeft:  []string{"IPAddress"},
+			OnlyInRight: []string{"Hostname"},
+			Both:        []string{},
+		},
+		DirectlyAttachedPolicies: SetComparison{OnlyInLeft: []string{}, OnlyInRight: []string{}, Both: []string{}},
+		InheritedPolicies:        SetComparison{OnlyInLeft: []string{}, OnlyInRight: []string{}, Both: []string{}},
+		Differences:              6,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected diff in GatewayComparison (-want +got)=\n%v", diff)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+// restrictToController removes the resources from the resourceModel which are
+// not managed by the controller. These are the GatewayClasses with a different
+// controllerName, the Gateways whose GatewayClass is not managed by the
+// controller (or does not exist), and the routes and Backends which are not
+// reachable from the remaining Gateways.
+func (rm *ResourceModel) restrictToController(controllerName string) {
+	for gatewayClassID, gatewayClassNode := range rm.GatewayClasses {
+		if string(gatewayClassNode.GatewayClass.Spec.ControllerName) != controllerName {
+			delete(rm.GatewayClasses, gatewayClassID)
+		}
+	}
+
+	for gatewayID, gatewayNode := range rm.Gateways {
+		if gatewayNode.GatewayClass == nil || string(gatewayNode.GatewayClass.GatewayClass.Spec.ControllerName) != controllerName {
+			delete(rm.Gateways, gatewayID)
+		}
+	}
+
+	for httpRouteID, httpRouteNode := range rm.HTTPRoutes {
+		for gatewayID, gatewayNode := range httpRouteNode.Gateways {
+			if gatewayNode.GatewayClass == nil || string(gatewayNode.GatewayClass.GatewayClass.Spec.ControllerName) != controllerName {
+				delete(httpRouteNode.Gateways, gatewayID)
+			}
+		}
+		if len(httpRouteNode.Gateways) == 0 {
+			delete(rm.HTTPRoutes, httpRouteID)
+		}
+	}
+
+	for routeID, otherRouteNode := range rm.OtherRoutes {
+		for gatewayID, gatewayNode := range otherRouteNode.Gateways {
+			if gatewayNode.GatewayClass == nil || string(gatewayNode.GatewayClass.GatewayClass.Spec.ControllerName) != controllerName {
+				delete(otherRouteNode.Gateways, gatewayID)
+			}
+		}
+		if len(otherRouteNode.Gateways) == 0 {
+			delete(rm.OtherRoutes, routeID)
+		}
+	}
+
+	for backendID, backendNode := range rm.Backends {
+		for routeID, routeNode := range backendNode.Routes {
+			switch routeNode := routeNode.(type) {
+			case *HTTPRouteNode:
+				if _, ok := rm.HTTPRoutes[routeNode.ID()]; !ok {
+					delete(backendNode.Routes, routeID)
+				}
+			case *OtherRouteNode:
+				if _, ok := rm.OtherRoutes[routeID]; !ok {
+					delete(backendNode.Routes, routeID)
+				}
+			}
+		}
+		if len(backendNode.Routes) == 0 {
+			delete(rm.Backends, backendID)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestDiscoverAllResources_Controller(t *testing.T) {
+	gatewayClass := func(name, controllerName string) *gatewayv1.GatewayClass {
+		return &gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: gatewayv1.GatewayController(controllerName)},
+		}
+	}
+	gateway := func(name, gatewayClassName string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: gatewayv1.ObjectName(gatewayClassName)},
+		}
+	}
+	httpRoute := func(name, backendName string, gatewayNames ...string) *gatewayv1.HTTPRoute {
+		httpRoute := &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{{
+					BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+						Group: common.PtrTo(gatewayv1.Group("")),
+						Kind:  common.PtrTo(gatewayv1.Kind("Service")),
+						Name:  gatewayv1.ObjectName(backendName),
+					}}}},
+				}},
+			},
+		}
+		for _, gatewayName := range gatewayNames {
+			httpRoute.Spec.ParentRefs = append(httpRoute.Spec.ParentRefs, gatewayv1.ParentReference{Name: gatewayv1.ObjectName(gatewayName)})
+		}
+		return httpRoute
+	}
+	service := func(name string) *corev1.Service {
+		return &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		}
+	}
+
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		gatewayClass("foo-gatewayclass", "example.net/foo-controller"),
+		gatewayClass("bar-gatewayclass", "example.net/bar-controller"),
+		gateway("foo-gateway", "foo-gatewayclass"),
+		gateway("bar-gateway", "bar-gatewayclass"),
+		gateway("orphan-gateway", "missing-gatewayclass"),
+		httpRoute("foo-httproute", "foo-svc", "foo-gateway"),
+		httpRoute("bar-httproute", "bar-svc", "bar-gateway"),
+		httpRoute("shared-httproute", "shared-svc", "foo-gateway", "bar-gateway"),
+		httpRoute("orphan-httproute", "orphan-svc", "orphan-gateway"),
+		service("foo-svc"),
+		service("bar-svc"),
+		service("shared-svc"),
+		service("orphan-svc"),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverAllResources(Filter{Labels: labels.Everything(), Controller: "example.net/foo-controller"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	names := func(objects ...metav1.Object) []string {
+		var result []string
+		for _, object := range objects {
+			result = append(result, object.GetName())
+		}
+		sort.Strings(result)
+		return result
+	}
+	var gatewayClasses, gateways, httpRoutes, backends []metav1.Object
+	for _, gatewayClassNode := range resourceModel.GatewayClasses {
+		gatewayClasses = append(gatewayClasses, gatewayClassNode.GatewayClass)
+	}
+	for _, gatewayNode := range resourceModel.Gateways {
+		gateways = append(gateways, gatewayNode.Gateway)
+	}
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		httpRoutes = append(httpRoutes, httpRouteNode.HTTPRoute)
+	}
+	for _, backendNode := range resourceModel.Backends {
+		backends = append(backends, backendNode.Backend)
+	}
+
+	if diff := cmp.Diff([]string{"foo-gatewayclass"}, names(gatewayClasses...)); diff != "" {
+		t.Errorf("Unexpected diff in GatewayClasses (-want +got)=\n%v", diff)
+	}
+	if diff := cmp.Diff([]string{"foo-gateway"}, names(gateways...)); diff != "" {
+		t.Errorf("Unexpected diff in Gateways (-want +got)=\n%v", diff)
+	}
+	if diff := cmp.Diff([]string{"foo-httproute", "shared-httproute"}, names(httpRoutes...)); diff != "" {
+		t.Errorf("Unexpected diff in HTTPRoutes (-want +got)=\n%v", diff)
+	}
+	if diff := cmp.Diff([]string{"foo-svc", "shared-svc"}, names(backends...)); diff != "" {
+		t.Errorf("Unexpected diff in Backends (-want +got)=\n%v", diff)
+	}
+
+	// The Gateway of the other controller is no longer reachable from the
+	// shared HTTPRoute.
+	sharedHTTPRouteNode := resourceModel.HTTPRoutes[HTTPRouteID("default", "shared-httproute")]
+	if _, ok := sharedHTTPRouteNode.Gateways[GatewayID("default", "bar-gateway")]; ok {
+		t.Errorf("HTTPRoute default/shared-httproute is still attached to Gateway default/bar-gateway")
+	}
+}
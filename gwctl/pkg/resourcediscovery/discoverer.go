@@ -20,16 +20,25 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 
+	"golang.org/x/exp/maps"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
@@ -40,28 +49,65 @@ import (
 	"k8s.io/klog/v2"
 )
 
-const (
-	// Maximum number of events to be fetched for each resource when constructing
-	// the resourceModel.
-	maxEventsPerResource = 10
-)
-
 var (
 	defaultGatewayClassGroupVersion   = gatewayv1.GroupVersion
 	defaultGatewayGroupVersion        = gatewayv1.GroupVersion
 	defaultHTTPRouteGroupVersion      = gatewayv1.GroupVersion
 	defaultReferenceGrantGroupVersion = gatewayv1beta1.GroupVersion
+
+	serviceGVR = common.ServicesPermission.GroupVersionResource("v1")
 )
 
+// otherRouteKinds are the route kinds, other than HTTPRoute, which are counted
+// for the Gateways they are attached to.
+var otherRouteKinds = []struct {
+	kind string
+	gvr  schema.GroupVersionResource
+}{
+	{"GRPCRoute", common.GRPCRoutesPermission.GroupVersionResource("v1")},
+	{"TCPRoute", common.TCPRoutesPermission.GroupVersionResource("v1alpha2")},
+	{"TLSRoute", common.TLSRoutesPermission.GroupVersionResource("v1alpha2")},
+	{"UDPRoute", common.UDPRoutesPermission.GroupVersionResource("v1alpha2")},
+}
+
 // Filter struct defines parameters for filtering resources
 type Filter struct {
 	Namespace string
 	Name      string
 	Labels    labels.Selector
+	// Stale limits the results to resources whose status has not observed the
+	// latest generation of their spec.
+	Stale bool
+	// Controller limits the results to resources managed by the controller with
+	// this controllerName, as resolved through the GatewayClasses of Gateways.
+	// HTTPRoutes and Backends are kept if they are attached to some Gateway of
+	// the controller.
+	Controller string
+	// NameRegex limits the listed resources to those whose name matches the
+	// regular expression. It is ignored when Name is set.
+	NameRegex *regexp.Regexp
+	// Namespaces limits the listed resources to those within any of the
+	// namespaces, which are listed one namespace at a time. It takes precedence
+	// over Namespace, and is ignored when Name is set.
+	Namespaces []string
+}
+
+// listNamespaces returns the namespaces in which the resources matching the
+// filter are listed.
+func (f Filter) listNamespaces() []string {
+	if len(f.Namespaces) != 0 {
+		return f.Namespaces
+	}
+	return []string{f.Namespace}
 }
 
 // Discoverer orchestrates the discovery of resources and their associated
 // policies, building a model of interconnected resources.
+//
+// A Discoverer is safe for concurrent use by multiple goroutines as long as its
+// fields are not modified after construction. Every discovery builds a new
+// ResourceModel which is owned by the caller and is not shared with other
+// discoveries.
 type Discoverer struct {
 	K8sClients    *common.K8sClients
 	PolicyManager *policymanager.PolicyManager
@@ -74,6 +120,35 @@ type Discoverer struct {
 	PreferredGatewayGroupVersion        metav1.GroupVersion
 	PreferredHTTPRouteGroupVersion      metav1.GroupVersion
 	PreferredReferenceGrantGroupVersion metav1.GroupVersion
+
+	// ValidateHostnames enables reporting hostnames of HTTPRoutes which are not
+	// valid RFC 1123 DNS names.
+	ValidateHostnames bool
+
+	// ValidateRegexes enables reporting regular expressions used by matches of
+	// HTTPRoutes which do not compile.
+	ValidateRegexes bool
+
+	// MetadataOnly limits the discovery of GatewayClasses, Gateways, HTTPRoutes
+	// and Backends to the metadata of the resources matching the filter. They are
+	// listed as PartialObjectMetadata, which greatly reduces the size of the
+	// responses on large clusters. Related resources and policies are not
+	// discovered. It is ignored when filtering stale resources or by controller,
+	// since those require the status of the resources and their related
+	// resources respectively.
+	MetadataOnly bool
+
+	// DiscoverUsage extends the discovery of GatewayClasses to the routes
+	// attached to their Gateways and the Backends of those HTTPRoutes, which are
+	// needed to compute the usage of the GatewayClasses.
+	DiscoverUsage bool
+
+	// Progress receives the progress of discovering all resources, if set.
+	Progress common.Progress
+
+	// Warnings receives the errors found with the discovered resources, like
+	// references to resources which do not exist, if set.
+	Warnings *common.Warnings
 }
 
 func NewDiscoverer(k8sClients *common.K8sClients, policyManager *policymanager.PolicyManager) Discoverer {
@@ -129,15 +204,39 @@ func (d Discoverer) DiscoverResourcesForGatewayClass(filter Filter) (*ResourceMo
 	ctx := context.Background()
 	resourceModel := &ResourceModel{}
 
+	if d.MetadataOnly && !filter.Stale && filter.Controller == "" {
+		metadataList, err := d.fetchMetadata(ctx, d.gatewayClassGVR(), "GatewayClass", filter)
+		if err != nil {
+			return resourceModel, err
+		}
+		for _, m := range metadataList {
+			resourceModel.addGatewayClasses(gatewayv1.GatewayClass{TypeMeta: m.TypeMeta, ObjectMeta: m.ObjectMeta})
+		}
+		return resourceModel, nil
+	}
+
 	gatewayClasses, err := d.fetchGatewayClasses(ctx, filter)
 	if err != nil {
 		return resourceModel, err
 	}
+	if filter.Stale {
+		gatewayClasses = filterStale(gatewayClasses, IsGatewayClassStale)
+	}
 	resourceModel.addGatewayClasses(gatewayClasses...)
 
 	d.discoverGatewaysForGatewayClasses(ctx, resourceModel)
+	if d.DiscoverUsage {
+		d.discoverHTTPRoutesForGateways(ctx, resourceModel)
+		d.discoverOtherRoutesForGateways(ctx, resourceModel)
+		d.discoverBackendsForHTTPRoutes(ctx, resourceModel)
+	}
 	d.discoverPolicies(resourceModel)
 
+	if filter.Controller != "" {
+		resourceModel.restrictToController(filter.Controller)
+	}
+
+	d.reportWarnings(resourceModel)
 	return resourceModel, nil
 }
 
@@ -146,14 +245,39 @@ func (d Discoverer) DiscoverResourcesForGateway(filter Filter) (*ResourceModel,
 	ctx := context.Background()
 	resourceModel := &ResourceModel{}
 
+	if d.MetadataOnly && !filter.Stale && filter.Controller == "" {
+		metadataList, err := d.fetchMetadata(ctx, d.gatewayGVR(), "Gateway", filter)
+		if err := d.tolerateTerminatingNamespace(ctx, filter.Namespace, err); err != nil {
+			return resourceModel, err
+		}
+		for _, m := range metadataList {
+			resourceModel.addGateways(gatewayv1.Gateway{TypeMeta: m.TypeMeta, ObjectMeta: m.ObjectMeta})
+		}
+		return resourceModel, nil
+	}
+
 	gateways, err := d.fetchGateways(ctx, filter)
-	if err != nil {
+	if err := d.tolerateTerminatingNamespace(ctx, filter.Namespace, err); err != nil {
 		return resourceModel, err
 	}
+	if filter.Stale {
+		gateways = filterStale(gateways, IsGatewayStale)
+	}
 	resourceModel.addGateways(gateways...)
 
 	d.discoverHTTPRoutesForGateways(ctx, resourceModel)
+	d.discoverListenerAttachmentsForHTTPRoutes(ctx, resourceModel, gateways)
+	d.discoverOtherRoutesForGateways(ctx, resourceModel)
 	d.discoverBackendsForHTTPRoutes(ctx, resourceModel)
+	d.discoverMirrorSamplingsForHTTPRoutes(ctx, resourceModel)
+	findDuplicateParentRefsForHTTPRoutes(resourceModel)
+	if d.ValidateHostnames {
+		validateHostnamesForHTTPRoutes(resourceModel)
+	}
+	if d.ValidateRegexes {
+		validateRegexesForHTTPRoutes(resourceModel)
+	}
+	d.discoverCrossGatewayHostnameConflicts(ctx, resourceModel)
 	d.discoverGatewayClassesForGateways(ctx, resourceModel)
 	d.discoverNamespaces(ctx, resourceModel)
 	d.discoverPolicies(resourceModel)
@@ -162,6 +286,11 @@ func (d Discoverer) DiscoverResourcesForGateway(filter Filter) (*ResourceModel,
 		return resourceModel, err
 	}
 
+	if filter.Controller != "" {
+		resourceModel.restrictToController(filter.Controller)
+	}
+
+	d.reportWarnings(resourceModel)
 	return resourceModel, nil
 }
 
@@ -170,14 +299,37 @@ func (d Discoverer) DiscoverResourcesForHTTPRoute(filter Filter) (*ResourceModel
 	ctx := context.Background()
 	resourceModel := &ResourceModel{}
 
+	if d.MetadataOnly && !filter.Stale && filter.Controller == "" {
+		metadataList, err := d.fetchMetadata(ctx, d.httpRouteGVR(), "HTTPRoute", filter)
+		if err := d.tolerateTerminatingNamespace(ctx, filter.Namespace, err); err != nil {
+			return resourceModel, err
+		}
+		for _, m := range metadataList {
+			resourceModel.addHTTPRoutes(gatewayv1.HTTPRoute{TypeMeta: m.TypeMeta, ObjectMeta: m.ObjectMeta})
+		}
+		return resourceModel, nil
+	}
+
 	httpRoutes, err := d.fetchHTTPRoutes(ctx, filter)
-	if err != nil {
+	if err := d.tolerateTerminatingNamespace(ctx, filter.Namespace, err); err != nil {
 		return resourceModel, err
 	}
+	if filter.Stale {
+		httpRoutes = filterStale(httpRoutes, IsHTTPRouteStale)
+	}
 	resourceModel.addHTTPRoutes(httpRoutes...)
 
 	d.discoverBackendsForHTTPRoutes(ctx, resourceModel)
-	d.discoverGatewaysForHTTPRoutes(ctx, resourceModel)
+	d.discoverMirrorSamplingsForHTTPRoutes(ctx, resourceModel)
+	findDuplicateParentRefsForHTTPRoutes(resourceModel)
+	d.discoverSessionPersistenceConflicts(ctx, resourceModel)
+	if d.ValidateHostnames {
+		validateHostnamesForHTTPRoutes(resourceModel)
+	}
+	if d.ValidateRegexes {
+		validateRegexesForHTTPRoutes(resourceModel)
+	}
+	d.discoverGatewaysForRoutes(ctx, resourceModel)
 	d.discoverGatewayClassesForGateways(ctx, resourceModel)
 	d.discoverNamespaces(ctx, resourceModel)
 	d.discoverPolicies(resourceModel)
@@ -186,6 +338,11 @@ func (d Discoverer) DiscoverResourcesForHTTPRoute(filter Filter) (*ResourceModel
 		return resourceModel, err
 	}
 
+	if filter.Controller != "" {
+		resourceModel.restrictToController(filter.Controller)
+	}
+
+	d.reportWarnings(resourceModel)
 	return resourceModel, nil
 }
 
@@ -194,23 +351,124 @@ func (d Discoverer) DiscoverResourcesForBackend(filter Filter) (*ResourceModel,
 	ctx := context.Background()
 	resourceModel := &ResourceModel{}
 
+	if d.MetadataOnly && !filter.Stale && filter.Controller == "" {
+		metadataList, err := d.fetchMetadata(ctx, serviceGVR, "Service", filter)
+		if err := d.tolerateTerminatingNamespace(ctx, filter.Namespace, err); err != nil {
+			return resourceModel, err
+		}
+		for _, m := range metadataList {
+			backend, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&m)
+			if err != nil {
+				return resourceModel, fmt.Errorf("failed to convert PartialObjectMetadata to unstructured: %v", err)
+			}
+			resourceModel.addBackends(unstructured.Unstructured{Object: backend})
+		}
+		return resourceModel, nil
+	}
+
 	backends, err := d.fetchBackends(ctx, filter)
-	if err != nil {
+	if err := d.tolerateTerminatingNamespace(ctx, filter.Namespace, err); err != nil {
 		return resourceModel, err
 	}
 	resourceModel.addBackends(backends...)
 
 	d.discoverReferenceGrantsForBackends(ctx, resourceModel)
+	d.discoverBackendTLSPoliciesForBackends(ctx, resourceModel)
+	d.discoverBackendLBPoliciesForBackends(ctx, maps.Values(resourceModel.Backends))
+	d.discoverEndpointSlicesForBackends(ctx, resourceModel)
 	d.discoverHTTPRoutesForBackends(ctx, resourceModel)
-	d.discoverGatewaysForHTTPRoutes(ctx, resourceModel)
+	d.discoverOtherRoutesForBackends(ctx, resourceModel)
+	d.discoverMirrorSamplingsForHTTPRoutes(ctx, resourceModel)
+	findDuplicateParentRefsForHTTPRoutes(resourceModel)
+	if d.ValidateHostnames {
+		validateHostnamesForHTTPRoutes(resourceModel)
+	}
+	if d.ValidateRegexes {
+		validateRegexesForHTTPRoutes(resourceModel)
+	}
+	d.discoverGatewaysForRoutes(ctx, resourceModel)
+	d.discoverGatewayClassesForGateways(ctx, resourceModel)
+	d.discoverNamespaces(ctx, resourceModel)
+	d.discoverPolicies(resourceModel)
+
+	if err := resourceModel.calculateEffectivePolicies(); err != nil {
+		return resourceModel, err
+	}
+
+	if filter.Controller != "" {
+		resourceModel.restrictToController(filter.Controller)
+	}
+
+	d.reportWarnings(resourceModel)
+	return resourceModel, nil
+}
+
+// DiscoverAllResources discovers all Gateways, HTTPRoutes and Backends matching
+// the filter, along with their related resources. Unlike the other discovery
+// methods, HTTPRoutes are included even if they are not attached to any
+// Gateway. Backends are only included if they are referenced by some HTTPRoute.
+func (d Discoverer) DiscoverAllResources(filter Filter) (*ResourceModel, error) {
+	ctx := context.Background()
+	resourceModel := &ResourceModel{}
+	// The kinds are Gateways, HTTPRoutes, routes of other kinds, Backends,
+	// GatewayClasses, Namespaces and Policies.
+	const totalKinds = 7
+	var listedKinds int
+	kindListed := func() {
+		listedKinds++
+		d.step(common.ProgressStep{Action: "listed", Done: listedKinds, Total: totalKinds, Unit: "kinds"})
+	}
+
+	gateways, err := d.fetchGateways(ctx, filter)
+	if err := d.tolerateTerminatingNamespace(ctx, filter.Namespace, err); err != nil {
+		return resourceModel, err
+	}
+	if filter.Stale {
+		gateways = filterStale(gateways, IsGatewayStale)
+	}
+	resourceModel.addGateways(gateways...)
+	kindListed()
+
+	httpRoutes, err := d.fetchHTTPRoutes(ctx, filter)
+	if err := d.tolerateTerminatingNamespace(ctx, filter.Namespace, err); err != nil {
+		return resourceModel, err
+	}
+	if filter.Stale {
+		httpRoutes = filterStale(httpRoutes, IsHTTPRouteStale)
+	}
+	resourceModel.addHTTPRoutes(httpRoutes...)
+
+	d.discoverHTTPRoutesForGateways(ctx, resourceModel)
+	kindListed()
+	d.discoverOtherRoutesForGateways(ctx, resourceModel)
+	kindListed()
+	d.discoverBackendsForHTTPRoutes(ctx, resourceModel)
+	d.discoverMirrorSamplingsForHTTPRoutes(ctx, resourceModel)
+	findDuplicateParentRefsForHTTPRoutes(resourceModel)
+	if d.ValidateHostnames {
+		validateHostnamesForHTTPRoutes(resourceModel)
+	}
+	if d.ValidateRegexes {
+		validateRegexesForHTTPRoutes(resourceModel)
+	}
+	d.discoverCrossGatewayHostnameConflicts(ctx, resourceModel)
+	kindListed()
 	d.discoverGatewayClassesForGateways(ctx, resourceModel)
+	kindListed()
 	d.discoverNamespaces(ctx, resourceModel)
+	kindListed()
 	d.discoverPolicies(resourceModel)
+	kindListed()
 
 	if err := resourceModel.calculateEffectivePolicies(); err != nil {
 		return resourceModel, err
 	}
 
+	if filter.Controller != "" {
+		resourceModel.restrictToController(filter.Controller)
+	}
+
+	d.reportWarnings(resourceModel)
 	return resourceModel, nil
 }
 
@@ -227,6 +485,7 @@ func (d Discoverer) DiscoverResourcesForNamespace(filter Filter) (*ResourceModel
 
 	d.discoverPolicies(resourceModel)
 
+	d.reportWarnings(resourceModel)
 	return resourceModel, nil
 }
 
@@ -288,15 +547,16 @@ func (d Discoverer) discoverGatewayClassesForGateways(ctx context.Context, resou
 	}
 }
 
-// discoverGatewaysForHTTPRoutes will add Gateways associated with HTTPRoutes
-// in the resourceModel.
-func (d Discoverer) discoverGatewaysForHTTPRoutes(ctx context.Context, resourceModel *ResourceModel) {
+// discoverGatewaysForRoutes will add Gateways associated with HTTPRoutes and
+// routes of other kinds in the resourceModel.
+func (d Discoverer) discoverGatewaysForRoutes(ctx context.Context, resourceModel *ResourceModel) {
 	gateways, err := d.fetchGateways(ctx, Filter{ /* every gateway */ })
 	if err != nil {
 		klog.V(1).ErrorS(err, "Failed to fetch Gateways")
 		return
 	}
 	resourceModel.addGateways(gateways...)
+	d.discoverListenerAttachmentsForHTTPRoutes(ctx, resourceModel, gateways)
 
 	// Visit all gateways corresponding to the httpRoutes
 	for httpRouteID, httpRouteNode := range resourceModel.HTTPRoutes {
@@ -316,14 +576,66 @@ func (d Discoverer) discoverGatewaysForHTTPRoutes(ctx context.Context, resourceM
 		}
 	}
 
-	// Remove Gateways which are not connected to any HTTPRoutes.
+	// Visit all gateways corresponding to the routes of other kinds.
+	for routeID, otherRouteNode := range resourceModel.OtherRoutes {
+		for _, gatewayRef := range relations.FindGatewayRefsForRoute(otherRouteNode.Route.GetNamespace(), otherRouteNode.ParentRefs()) {
+			gatewayID := GatewayID(gatewayRef.Namespace, gatewayRef.Name)
+			_, ok := resourceModel.Gateways[gatewayID]
+			if !ok {
+				err := ReferenceToNonExistentResourceError{ReferenceFromTo: ReferenceFromTo{
+					ReferringObject: common.ObjRef{Kind: routeID.Kind, Name: otherRouteNode.Route.GetName(), Namespace: otherRouteNode.Route.GetNamespace()},
+					ReferredObject:  common.ObjRef{Kind: "Gateway", Name: gatewayRef.Name, Namespace: gatewayRef.Namespace},
+				}}
+				otherRouteNode.Errors = append(otherRouteNode.Errors, err)
+				klog.V(1).Info(err)
+				continue
+			}
+			resourceModel.connectOtherRouteWithGateway(routeID, gatewayID)
+		}
+	}
+
+	// Remove Gateways which are not connected to any routes.
+	connectedGateways := make(map[gatewayID]bool)
+	for _, otherRouteNode := range resourceModel.OtherRoutes {
+		for gatewayID := range otherRouteNode.Gateways {
+			connectedGateways[gatewayID] = true
+		}
+	}
 	for gatewayID, gatewayNode := range resourceModel.Gateways {
-		if len(gatewayNode.HTTPRoutes) == 0 {
+		if len(gatewayNode.HTTPRoutes) == 0 && !connectedGateways[gatewayID] {
 			delete(resourceModel.Gateways, gatewayID)
 		}
 	}
 }
 
+// discoverListenerAttachmentsForHTTPRoutes evaluates whether the HTTPRoutes in
+// the resourceModel attach to the listeners of the Gateways selected by their
+// parentRefs. The Namespaces are only listed if some listener selects the
+// namespaces of the routes it allows by their labels. If they cannot be listed,
+// the attachments of the HTTPRoutes are unknown.
+func (d Discoverer) discoverListenerAttachmentsForHTTPRoutes(ctx context.Context, resourceModel *ResourceModel, gateways []gatewayv1.Gateway) {
+	var namespaceLabels map[string]map[string]string
+	if gatewaysSelectNamespaces(gateways) {
+		var err error
+		namespaceLabels, err = d.fetchNamespaceLabels(ctx)
+		if err != nil {
+			klog.V(1).ErrorS(err, "Failed to fetch Namespaces")
+			for _, httpRouteNode := range resourceModel.HTTPRoutes {
+				httpRouteNode.AttachmentsUnknown = true
+				httpRouteNode.Errors = append(httpRouteNode.Errors, fmt.Errorf("unable to evaluate the listeners which the HTTPRoute attaches to: %v", err))
+			}
+			return
+		}
+	}
+	gatewaysByName := make(map[apimachinerytypes.NamespacedName]*gatewayv1.Gateway)
+	for i := range gateways {
+		gatewaysByName[client.ObjectKeyFromObject(&gateways[i])] = &gateways[i]
+	}
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		httpRouteNode.Attachments = listenerAttachmentsForHTTPRoute(*httpRouteNode.HTTPRoute, gatewaysByName, namespaceLabels)
+	}
+}
+
 // discoverHTTPRoutesForGateways will add HTTPRoutes that are attached to any
 // Gateway in the resourceModel.
 func (d Discoverer) discoverHTTPRoutesForGateways(ctx context.Context, resourceModel *ResourceModel) {
@@ -368,6 +680,172 @@ func (d Discoverer) discoverHTTPRoutesForGateways(ctx context.Context, resourceM
 	}
 }
 
+// discoverOtherRoutesForGateways counts the routes of otherRouteKinds which
+// are attached to each Gateway in the resourceModel. Route kinds which are not
+// installed in the cluster are skipped. If the routes of a kind cannot be
+// listed in all namespaces, they are only counted in the namespaces of the
+// Gateways.
+func (d Discoverer) discoverOtherRoutesForGateways(ctx context.Context, resourceModel *ResourceModel) {
+	if len(resourceModel.Gateways) == 0 {
+		return
+	}
+	for _, routeKind := range otherRouteKinds {
+		routes, err := d.K8sClients.DC.Resource(routeKind.gvr).List(ctx, metav1.ListOptions{})
+		var routeItems []unstructured.Unstructured
+		switch {
+		case apierrors.IsForbidden(err):
+			klog.V(1).ErrorS(err, "Failed to list routes in all namespaces, listing them in the namespaces of the Gateways", "kind", routeKind.kind)
+			routeItems = d.fetchOtherRoutesInGatewayNamespaces(ctx, resourceModel, routeKind.kind, routeKind.gvr, err)
+		case err != nil:
+			klog.V(1).ErrorS(err, "Failed to list routes", "kind", routeKind.kind)
+			continue
+		default:
+			routeItems = routes.Items
+		}
+		for _, route := range routeItems {
+			spec, _, _ := unstructured.NestedMap(route.Object, "spec")
+			commonRouteSpec := gatewayv1.CommonRouteSpec{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(spec, &commonRouteSpec); err != nil {
+				klog.V(1).ErrorS(err, "Failed to convert route spec", "kind", routeKind.kind, "route", route.GetNamespace()+"/"+route.GetName())
+				continue
+			}
+
+			// A route attached to multiple listeners of the same Gateway is only
+			// counted once.
+			gatewayIDs := make(map[gatewayID]bool)
+			for _, gatewayRef := range relations.FindGatewayRefsForRoute(route.GetNamespace(), commonRouteSpec.ParentRefs) {
+				gatewayIDs[GatewayID(gatewayRef.Namespace, gatewayRef.Name)] = true
+			}
+			for id := range gatewayIDs {
+				gatewayNode, ok := resourceModel.Gateways[id]
+				if !ok {
+					continue
+				}
+				if gatewayNode.AttachedRoutesByKind == nil {
+					gatewayNode.AttachedRoutesByKind = make(map[string]int)
+				}
+				gatewayNode.AttachedRoutesByKind[routeKind.kind]++
+			}
+		}
+	}
+}
+
+// fetchOtherRoutesInGatewayNamespaces lists the routes of the resource gvr in
+// the namespaces of the Gateways in the resourceModel, after listing them in all
+// namespaces failed with listErr. Gateways which may have routes attached from
+// other namespaces, or whose namespace cannot be listed either, are reported as
+// missing some of the routes.
+func (d Discoverer) fetchOtherRoutesInGatewayNamespaces(ctx context.Context, resourceModel *ResourceModel, kind string, gvr schema.GroupVersionResource, listErr error) []unstructured.Unstructured {
+	gatewaysByNamespace := make(map[string][]*GatewayNode)
+	for _, gatewayNode := range resourceModel.Gateways {
+		namespace := gatewayNode.Gateway.GetNamespace()
+		gatewaysByNamespace[namespace] = append(gatewaysByNamespace[namespace], gatewayNode)
+	}
+	namespaces := maps.Keys(gatewaysByNamespace)
+	sort.Strings(namespaces)
+
+	var result []unstructured.Unstructured
+	for _, namespace := range namespaces {
+		routes, err := d.K8sClients.DC.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			klog.V(1).ErrorS(err, "Failed to list routes", "kind", kind, "namespace", namespace)
+			for _, gatewayNode := range gatewaysByNamespace[namespace] {
+				gatewayNode.Errors = append(gatewayNode.Errors, fmt.Errorf("unable to count the attached %vs: %v", kind, err))
+			}
+			continue
+		}
+		result = append(result, routes.Items...)
+		for _, gatewayNode := range gatewaysByNamespace[namespace] {
+			if gatewayAllowsRoutesFromOtherNamespaces(*gatewayNode.Gateway) {
+				gatewayNode.Errors = append(gatewayNode.Errors, fmt.Errorf("unable to count the %vs attached from other namespaces: %v", kind, listErr))
+			}
+		}
+	}
+	return result
+}
+
+// gatewayAllowsRoutesFromOtherNamespaces returns true if some listener of the
+// gateway allows routes from namespaces other than its own.
+func gatewayAllowsRoutesFromOtherNamespaces(gateway gatewayv1.Gateway) bool {
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil &&
+			listener.AllowedRoutes.Namespaces.From != nil && *listener.AllowedRoutes.Namespaces.From != gatewayv1.NamespacesFromSame {
+			return true
+		}
+	}
+	return false
+}
+
+// discoverMirrorSamplingsForHTTPRoutes will populate the sampling of the
+// RequestMirror filters of HTTPRoutes in the resourceModel. The sampling fields
+// may not be known to the Gateway API version used by gwctl, so they are read
+// from the unstructured HTTPRoutes, which are only listed in the namespaces of
+// the HTTPRoutes in the resourceModel. Invalid samplings are left to the
+// analysis to report.
+func (d Discoverer) discoverMirrorSamplingsForHTTPRoutes(ctx context.Context, resourceModel *ResourceModel) {
+	if len(resourceModel.HTTPRoutes) == 0 {
+		return
+	}
+	gvr, _, err := d.resourceForGroupKind(schema.GroupKind{Group: gatewayv1.GroupName, Kind: "HTTPRoute"})
+	if err != nil {
+		klog.V(1).ErrorS(err, "Failed to find resource for HTTPRoutes")
+		return
+	}
+
+	namespaces := make(map[string]bool)
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		namespaces[httpRouteNode.HTTPRoute.GetNamespace()] = true
+	}
+	sortedNamespaces := maps.Keys(namespaces)
+	sort.Strings(sortedNamespaces)
+	for _, namespace := range sortedNamespaces {
+		httpRouteListUnstructured, err := d.K8sClients.DC.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			klog.V(1).ErrorS(err, "Failed to list HTTPRoutes", "namespace", namespace)
+			continue
+		}
+		for _, httpRoute := range httpRouteListUnstructured.Items {
+			httpRouteNode, ok := resourceModel.HTTPRoutes[HTTPRouteID(httpRoute.GetNamespace(), httpRoute.GetName())]
+			if !ok {
+				continue
+			}
+			httpRouteNode.MirrorSamplings, httpRouteNode.MirrorSamplingErrors = mirrorSamplingsForHTTPRoute(httpRoute)
+		}
+	}
+}
+
+// discoverCrossGatewayHostnameConflicts reports hostnames which Gateways in the
+// resourceModel serve on the same address and port as some other Gateway. All
+// Gateways and HTTPRoutes are considered, since the conflicting Gateway may not
+// be part of the resourceModel.
+func (d Discoverer) discoverCrossGatewayHostnameConflicts(ctx context.Context, resourceModel *ResourceModel) {
+	if len(resourceModel.Gateways) == 0 {
+		return
+	}
+	gateways, err := d.fetchGateways(ctx, Filter{ /* all Gateways */ Labels: labels.Everything()})
+	if err != nil {
+		klog.V(1).ErrorS(err, "Failed to list all Gateways")
+		return
+	}
+	httpRoutes, err := d.fetchHTTPRoutes(ctx, Filter{ /* all HTTPRoutes */ Labels: labels.Everything()})
+	if err != nil {
+		klog.V(1).ErrorS(err, "Failed to list all HTTPRoutes")
+		return
+	}
+
+	for _, conflict := range relations.DetectCrossGatewayHostnameConflicts(gateways, httpRoutes) {
+		for _, gatewayRef := range conflict.Gateways {
+			gatewayNode, ok := resourceModel.Gateways[GatewayID(gatewayRef.Namespace, gatewayRef.Name)]
+			if !ok {
+				continue
+			}
+			err := CrossGatewayHostnameConflictError{Conflict: conflict}
+			gatewayNode.Errors = append(gatewayNode.Errors, err)
+			klog.V(1).Info(err)
+		}
+	}
+}
+
 // discoverHTTPRoutesForBackends will add HTTPRoutes that reference any Backend
 // present in resourceModel.
 func (d Discoverer) discoverHTTPRoutesForBackends(ctx context.Context, resourceModel *ResourceModel) {
@@ -422,6 +900,15 @@ func (d Discoverer) discoverHTTPRoutesForBackends(ctx context.Context, resourceM
 			//    HTTPRoute, or is exposed through a ReferenceGrant.
 			includeRouteInResourceModel = true
 
+			if backendNode.ServiceType() == corev1.ServiceTypeExternalName {
+				err := ReferenceToExternalNameServiceError{ReferenceFromTo: ReferenceFromTo{
+					ReferringObject: common.ObjRef{Kind: "HTTPRoute", Name: httpRoute.GetName(), Namespace: httpRoute.GetNamespace()},
+					ReferredObject:  backendRef,
+				}}
+				backendNode.Errors = append(backendNode.Errors, err)
+				klog.V(1).Info(err)
+			}
+
 			resourceModel.addHTTPRoutes(httpRoute)
 			resourceModel.connectHTTPRouteWithBackend(HTTPRouteID(httpRoute.GetNamespace(), httpRoute.GetName()), backendID)
 		}
@@ -434,6 +921,66 @@ func (d Discoverer) discoverHTTPRoutesForBackends(ctx context.Context, resourceM
 	}
 }
 
+// discoverOtherRoutesForBackends will add routes of otherRouteKinds that
+// reference any Backend present in resourceModel. Route kinds which are not
+// installed in the cluster are skipped.
+func (d Discoverer) discoverOtherRoutesForBackends(ctx context.Context, resourceModel *ResourceModel) {
+	if len(resourceModel.Backends) == 0 {
+		return
+	}
+	for _, routeKind := range otherRouteKinds {
+		routeList, err := d.K8sClients.DC.Resource(routeKind.gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			klog.V(1).ErrorS(err, "Failed to list routes", "kind", routeKind.kind)
+			continue
+		}
+		for _, route := range routeList.Items {
+			route.SetKind(routeKind.kind)
+			otherRouteNode := NewOtherRouteNode(&route)
+			routeID := otherRouteNode.RouteID()
+
+			for _, backendRef := range otherRouteNode.BackendRefs() {
+				// Check if the referenced backend exists in the resourceModel.
+				backendID := BackendID(backendRef.Group, backendRef.Kind, backendRef.Namespace, backendRef.Name)
+				backendNode, ok := resourceModel.Backends[backendID]
+				if !ok {
+					continue
+				}
+
+				// Ensure that if this is a cross namespace reference, then it is
+				// accepted through some ReferenceGrant.
+				if route.GetNamespace() != backendRef.Namespace {
+					routeRef := common.ObjRef{
+						Group:     gatewayv1.GroupName,
+						Kind:      routeKind.kind,
+						Name:      route.GetName(),
+						Namespace: route.GetNamespace(),
+					}
+					var referenceAccepted bool
+					for _, referenceGrantNode := range backendNode.ReferenceGrants {
+						if relations.ReferenceGrantAccepts(*referenceGrantNode.ReferenceGrant, routeRef) {
+							referenceAccepted = true
+							break
+						}
+					}
+					if !referenceAccepted {
+						err := ReferenceNotPermittedError{ReferenceFromTo: ReferenceFromTo{
+							ReferringObject: common.ObjRef{Kind: routeKind.kind, Name: route.GetName(), Namespace: route.GetNamespace()},
+							ReferredObject:  backendRef,
+						}}
+						backendNode.Errors = append(backendNode.Errors, err)
+						klog.V(1).Info(err)
+						continue
+					}
+				}
+
+				resourceModel.addOtherRoutes(route)
+				resourceModel.connectOtherRouteWithBackend(routeID, backendID)
+			}
+		}
+	}
+}
+
 // discoverBackendsForHTTPRoutes will add Backends that are referenced by any
 // HTTPRoute in the resourceModel.
 func (d Discoverer) discoverBackendsForHTTPRoutes(ctx context.Context, resourceModel *ResourceModel) {
@@ -498,12 +1045,35 @@ func (d Discoverer) discoverBackendsForHTTPRoutes(ctx context.Context, resourceM
 
 			// At this point, we know that either Backend is in the same namespace as
 			// the HTTPRoute, or is exposed through a ReferenceGrant.
+			if backendNode.ServiceType() == corev1.ServiceTypeExternalName {
+				err := ReferenceToExternalNameServiceError{ReferenceFromTo: ReferenceFromTo{
+					ReferringObject: common.ObjRef{Kind: "HTTPRoute", Name: httpRouteNode.HTTPRoute.GetName(), Namespace: httpRouteNode.HTTPRoute.GetNamespace()},
+					ReferredObject:  backendRef,
+				}}
+				httpRouteNode.Errors = append(httpRouteNode.Errors, err)
+				klog.V(1).Info(err)
+			}
 			resourceModel.connectHTTPRouteWithBackend(HTTPRouteID(httpRouteNode.HTTPRoute.GetNamespace(), httpRouteNode.HTTPRoute.GetName()), backendID)
 		}
+
+		var services []corev1.Service
+		for _, backendNode := range httpRouteNode.Backends {
+			if backendNode.ServiceType() == "" {
+				continue
+			}
+			if service := backendNode.service(); service != nil {
+				services = append(services, *service)
+			}
+		}
+		for _, issue := range relations.FindInvalidBackendPorts(*httpRouteNode.HTTPRoute, services) {
+			err := BackendPortNotFoundError{BackendPortIssue: issue}
+			httpRouteNode.Errors = append(httpRouteNode.Errors, err)
+			klog.V(1).Info(err)
+		}
 	}
 	// Remove Backends which are not connected to any HTTPRoute
 	for backendID, backendNode := range resourceModel.Backends {
-		if len(backendNode.HTTPRoutes) == 0 {
+		if len(backendNode.Routes) == 0 {
 			delete(resourceModel.Backends, backendID)
 		}
 	}
@@ -531,6 +1101,10 @@ func (d Discoverer) discoverNamespaces(ctx context.Context, resourceModel *Resou
 		resourceModel.addNamespace(namespaceMap[httpRouteNode.HTTPRoute.GetNamespace()])
 		resourceModel.connectHTTPRouteWithNamespace(httpRouteID, NamespaceID(httpRouteNode.HTTPRoute.GetNamespace()))
 	}
+	for routeID, otherRouteNode := range resourceModel.OtherRoutes {
+		resourceModel.addNamespace(namespaceMap[otherRouteNode.Route.GetNamespace()])
+		resourceModel.connectOtherRouteWithNamespace(routeID, NamespaceID(otherRouteNode.Route.GetNamespace()))
+	}
 	for backendID, backendNode := range resourceModel.Backends {
 		resourceModel.addNamespace(namespaceMap[backendNode.Backend.GetNamespace()])
 		resourceModel.connectBackendWithNamespace(backendID, NamespaceID(backendNode.Backend.GetNamespace()))
@@ -546,7 +1120,7 @@ func (d Discoverer) discoverReferenceGrantsForBackends(ctx context.Context, reso
 		if !ok {
 			var err error
 			referenceGrants, err = d.fetchReferenceGrants(ctx, Filter{Namespace: backendNS, Labels: labels.Everything()})
-			if err != nil {
+			if err := d.tolerateTerminatingNamespace(ctx, backendNS, err); err != nil {
 				fmt.Fprintf(os.Stderr, "failed to fetch list of ReferenceGrants: %v\n", err)
 				os.Exit(1)
 			}
@@ -572,21 +1146,185 @@ func (d Discoverer) discoverReferenceGrantsForBackends(ctx context.Context, reso
 	}
 }
 
+// discoverBackendTLSPoliciesForBackends adds the BackendTLSPolicies which
+// target each Backend in the resourceModel. Nothing is added if
+// BackendTLSPolicies are not installed in the cluster.
+func (d Discoverer) discoverBackendTLSPoliciesForBackends(ctx context.Context, resourceModel *ResourceModel) {
+	backendTLSPoliciesByNamespace := make(map[string][]gatewayv1alpha3.BackendTLSPolicy)
+	for _, backendNode := range resourceModel.Backends {
+		backendNS := backendNode.Backend.GetNamespace()
+
+		backendTLSPolicies, ok := backendTLSPoliciesByNamespace[backendNS]
+		if !ok {
+			var err error
+			backendTLSPolicies, err = d.fetchBackendTLSPolicies(ctx, backendNS)
+			if err != nil {
+				klog.V(1).ErrorS(err, "Failed to list BackendTLSPolicies", "namespace", backendNS)
+			}
+			backendTLSPoliciesByNamespace[backendNS] = backendTLSPolicies
+		}
+
+		backendRef := common.ObjRef{
+			Group:     backendNode.Backend.GroupVersionKind().Group,
+			Kind:      backendNode.Backend.GroupVersionKind().Kind,
+			Name:      backendNode.Backend.GetName(),
+			Namespace: backendNS,
+		}
+		for _, backendTLSPolicy := range backendTLSPolicies {
+			if len(relations.BackendTLSPolicyTargets(backendTLSPolicy, backendRef)) != 0 {
+				backendNode.BackendTLSPolicies = append(backendNode.BackendTLSPolicies, backendTLSPolicy)
+			}
+		}
+	}
+}
+
+// discoverBackendLBPoliciesForBackends adds the BackendLBPolicies which target
+// each of the backendNodes. Nothing is added if BackendLBPolicies are not
+// installed in the cluster.
+func (d Discoverer) discoverBackendLBPoliciesForBackends(ctx context.Context, backendNodes []*BackendNode) {
+	backendLBPoliciesByNamespace := make(map[string][]gatewayv1alpha2.BackendLBPolicy)
+	for _, backendNode := range backendNodes {
+		backendNS := backendNode.Backend.GetNamespace()
+
+		backendLBPolicies, ok := backendLBPoliciesByNamespace[backendNS]
+		if !ok {
+			var err error
+			backendLBPolicies, err = d.fetchBackendLBPolicies(ctx, backendNS)
+			if err != nil {
+				klog.V(1).ErrorS(err, "Failed to list BackendLBPolicies", "namespace", backendNS)
+			}
+			backendLBPoliciesByNamespace[backendNS] = backendLBPolicies
+		}
+
+		backendRef := common.ObjRef{
+			Group:     backendNode.Backend.GroupVersionKind().Group,
+			Kind:      backendNode.Backend.GroupVersionKind().Kind,
+			Name:      backendNode.Backend.GetName(),
+			Namespace: backendNS,
+		}
+		for _, backendLBPolicy := range backendLBPolicies {
+			if relations.BackendLBPolicyTargetsBackend(backendLBPolicy, backendRef) {
+				backendNode.BackendLBPolicies = append(backendNode.BackendLBPolicies, backendLBPolicy)
+			}
+		}
+	}
+}
+
+// discoverEndpointSlicesForBackends adds the EndpointSlices of each Backend in
+// the resourceModel which is a Service.
+func (d Discoverer) discoverEndpointSlicesForBackends(ctx context.Context, resourceModel *ResourceModel) {
+	for _, backendNode := range resourceModel.Backends {
+		gvk := backendNode.Backend.GroupVersionKind()
+		if gvk.Group != "" || gvk.Kind != "Service" {
+			continue
+		}
+		endpointSlices, err := d.fetchEndpointSlices(ctx, backendNode.Backend.GetNamespace(), backendNode.Backend.GetName())
+		if err != nil {
+			klog.V(1).ErrorS(err, "Failed to list EndpointSlices", "service", client.ObjectKeyFromObject(backendNode.Backend))
+		}
+		backendNode.EndpointSlices = endpointSlices
+	}
+}
+
+// step reports the progress of a slow operation, if the Discoverer reports
+// progress.
+func (d Discoverer) step(step common.ProgressStep) {
+	if d.Progress != nil {
+		d.Progress.Step(step)
+	}
+}
+
+// reportWarnings reports the errors found with the resources of the model, if
+// the Discoverer collects warnings.
+func (d Discoverer) reportWarnings(resourceModel *ResourceModel) {
+	if d.Warnings == nil {
+		return
+	}
+	for _, gatewayNode := range resourceModel.Gateways {
+		for _, err := range gatewayNode.Errors {
+			d.Warnings.Add(gatewayRef(gatewayNode), err.Error())
+		}
+	}
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		httpRoute := httpRouteNode.HTTPRoute
+		for _, err := range httpRouteNode.Errors {
+			d.Warnings.Add(common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: httpRoute.GetNamespace(), Name: httpRoute.GetName()}, err.Error())
+		}
+	}
+	for routeID, otherRouteNode := range resourceModel.OtherRoutes {
+		route := otherRouteNode.Route
+		for _, err := range otherRouteNode.Errors {
+			d.Warnings.Add(common.ObjRef{Group: route.GroupVersionKind().Group, Kind: routeID.Kind, Namespace: route.GetNamespace(), Name: route.GetName()}, err.Error())
+		}
+	}
+	for _, backendNode := range resourceModel.Backends {
+		backend := backendNode.Backend
+		for _, err := range backendNode.Errors {
+			d.Warnings.Add(common.ObjRef{Group: backend.GroupVersionKind().Group, Kind: backend.GroupVersionKind().Kind, Namespace: backend.GetNamespace(), Name: backend.GetName()}, err.Error())
+		}
+	}
+}
+
 // discoverPolicies adds Policies for resources that exist in the resourceModel.
 func (d Discoverer) discoverPolicies(resourceModel *ResourceModel) {
 	resourceModel.addPolicyIfTargetExists(d.PolicyManager.GetPolicies()...)
 }
 
-// fetchGatewayClasses fetches GatewayClasses based on a filter.
-func (d Discoverer) fetchGatewayClasses(ctx context.Context, filter Filter) ([]gatewayv1.GatewayClass, error) {
+// gatewayClassGVR returns the GroupVersionResource used to fetch GatewayClasses.
+func (d Discoverer) gatewayClassGVR() schema.GroupVersionResource {
 	gvr := schema.GroupVersionResource{
 		Group:    defaultGatewayClassGroupVersion.Group,
 		Version:  defaultGatewayClassGroupVersion.Version,
-		Resource: "gatewayclasses",
+		Resource: common.GatewayClassesPermission.Resource,
 	}
 	if d.PreferredGatewayClassGroupVersion != (metav1.GroupVersion{}) {
 		gvr.Version = d.PreferredGatewayClassGroupVersion.Version
 	}
+	return gvr
+}
+
+// gatewayGVR returns the GroupVersionResource used to fetch Gateways.
+func (d Discoverer) gatewayGVR() schema.GroupVersionResource {
+	gvr := schema.GroupVersionResource{
+		Group:    defaultGatewayGroupVersion.Group,
+		Version:  defaultGatewayGroupVersion.Version,
+		Resource: common.GatewaysPermission.Resource,
+	}
+	if d.PreferredGatewayGroupVersion != (metav1.GroupVersion{}) {
+		gvr.Version = d.PreferredGatewayGroupVersion.Version
+	}
+	return gvr
+}
+
+// httpRouteGVR returns the GroupVersionResource used to fetch HTTPRoutes.
+func (d Discoverer) httpRouteGVR() schema.GroupVersionResource {
+	gvr := schema.GroupVersionResource{
+		Group:    defaultHTTPRouteGroupVersion.Group,
+		Version:  defaultHTTPRouteGroupVersion.Version,
+		Resource: common.HTTPRoutesPermission.Resource,
+	}
+	if d.PreferredHTTPRouteGroupVersion != (metav1.GroupVersion{}) {
+		gvr.Version = d.PreferredHTTPRouteGroupVersion.Version
+	}
+	return gvr
+}
+
+// referenceGrantGVR returns the GroupVersionResource used to fetch ReferenceGrants.
+func (d Discoverer) referenceGrantGVR() schema.GroupVersionResource {
+	gvr := schema.GroupVersionResource{
+		Group:    defaultReferenceGrantGroupVersion.Group,
+		Version:  defaultReferenceGrantGroupVersion.Version,
+		Resource: common.ReferenceGrantsPermission.Resource,
+	}
+	if d.PreferredReferenceGrantGroupVersion != (metav1.GroupVersion{}) {
+		gvr.Version = d.PreferredReferenceGrantGroupVersion.Version
+	}
+	return gvr
+}
+
+// fetchGatewayClasses fetches GatewayClasses based on a filter.
+func (d Discoverer) fetchGatewayClasses(ctx context.Context, filter Filter) ([]gatewayv1.GatewayClass, error) {
+	gvr := d.gatewayClassGVR()
 
 	if filter.Name != "" {
 		// Use Get call.
@@ -617,19 +1355,12 @@ func (d Discoverer) fetchGatewayClasses(ctx context.Context, filter Filter) ([]g
 	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(gatewayClassListUnstructured.UnstructuredContent(), gatewayClassList); err != nil {
 		return []gatewayv1.GatewayClass{}, fmt.Errorf("failed to convert unstructured GatewayClassList to structured: %v", err)
 	}
-	return gatewayClassList.Items, nil
+	return filterByNameRegex(gatewayClassList.Items, filter.NameRegex), nil
 }
 
 // fetchGateways fetches Gateways based on a filter.
 func (d Discoverer) fetchGateways(ctx context.Context, filter Filter) ([]gatewayv1.Gateway, error) {
-	gvr := schema.GroupVersionResource{
-		Group:    defaultGatewayGroupVersion.Group,
-		Version:  defaultGatewayGroupVersion.Version,
-		Resource: "gateways",
-	}
-	if d.PreferredGatewayGroupVersion != (metav1.GroupVersion{}) {
-		gvr.Version = d.PreferredGatewayGroupVersion.Version
-	}
+	gvr := d.gatewayGVR()
 
 	if filter.Name != "" {
 		// Use Get call.
@@ -652,7 +1383,7 @@ func (d Discoverer) fetchGateways(ctx context.Context, filter Filter) ([]gateway
 	listOptions := metav1.ListOptions{
 		LabelSelector: labelSelector,
 	}
-	gatewayListUnstructured, err := d.K8sClients.DC.Resource(gvr).Namespace(filter.Namespace).List(ctx, listOptions)
+	gatewayListUnstructured, err := d.listUnstructured(ctx, gvr, filter, listOptions)
 	if err != nil {
 		return []gatewayv1.Gateway{}, err
 	}
@@ -660,19 +1391,12 @@ func (d Discoverer) fetchGateways(ctx context.Context, filter Filter) ([]gateway
 	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(gatewayListUnstructured.UnstructuredContent(), gatewayList); err != nil {
 		return []gatewayv1.Gateway{}, fmt.Errorf("failed to convert unstructured GatewayList to structured: %v", err)
 	}
-	return gatewayList.Items, nil
+	return filterByNameRegex(gatewayList.Items, filter.NameRegex), nil
 }
 
 // fetchHTTPRoutes fetches HTTPRoutes based on a filter.
 func (d Discoverer) fetchHTTPRoutes(ctx context.Context, filter Filter) ([]gatewayv1.HTTPRoute, error) {
-	gvr := schema.GroupVersionResource{
-		Group:    defaultHTTPRouteGroupVersion.Group,
-		Version:  defaultHTTPRouteGroupVersion.Version,
-		Resource: "httproutes",
-	}
-	if d.PreferredHTTPRouteGroupVersion != (metav1.GroupVersion{}) {
-		gvr.Version = d.PreferredHTTPRouteGroupVersion.Version
-	}
+	gvr := d.httpRouteGVR()
 
 	if filter.Name != "" {
 		// Use Get call.
@@ -695,7 +1419,7 @@ func (d Discoverer) fetchHTTPRoutes(ctx context.Context, filter Filter) ([]gatew
 	listOptions := metav1.ListOptions{
 		LabelSelector: labelSelector,
 	}
-	httpRouteListUnstructured, err := d.K8sClients.DC.Resource(gvr).Namespace(filter.Namespace).List(ctx, listOptions)
+	httpRouteListUnstructured, err := d.listUnstructured(ctx, gvr, filter, listOptions)
 	if err != nil {
 		return []gatewayv1.HTTPRoute{}, err
 	}
@@ -703,19 +1427,12 @@ func (d Discoverer) fetchHTTPRoutes(ctx context.Context, filter Filter) ([]gatew
 	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(httpRouteListUnstructured.UnstructuredContent(), httpRouteList); err != nil {
 		return []gatewayv1.HTTPRoute{}, fmt.Errorf("failed to convert unstructured HTTPRouteList to structured: %v", err)
 	}
-	return httpRouteList.Items, nil
+	return filterByNameRegex(httpRouteList.Items, filter.NameRegex), nil
 }
 
 // fetchReferenceGrants fetches ReferenceGrants based on a filter.
 func (d Discoverer) fetchReferenceGrants(ctx context.Context, filter Filter) ([]gatewayv1beta1.ReferenceGrant, error) {
-	gvr := schema.GroupVersionResource{
-		Group:    defaultReferenceGrantGroupVersion.Group,
-		Version:  defaultReferenceGrantGroupVersion.Version,
-		Resource: "referencegrants",
-	}
-	if d.PreferredReferenceGrantGroupVersion != (metav1.GroupVersion{}) {
-		gvr.Version = d.PreferredReferenceGrantGroupVersion.Version
-	}
+	gvr := d.referenceGrantGVR()
 
 	if filter.Name != "" {
 		// Use Get call.
@@ -745,16 +1462,70 @@ func (d Discoverer) fetchReferenceGrants(ctx context.Context, filter Filter) ([]
 	return referenceGrantList.Items, nil
 }
 
+// fetchBackendTLSPolicies fetches all BackendTLSPolicies within the namespace,
+// sorted by name.
+func (d Discoverer) fetchBackendTLSPolicies(ctx context.Context, namespace string) ([]gatewayv1alpha3.BackendTLSPolicy, error) {
+	gvr := common.BackendTLSPoliciesPermission.GroupVersionResource(gatewayv1alpha3.GroupVersion.Version)
+
+	backendTLSPolicyListUnstructured, err := d.K8sClients.DC.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return []gatewayv1alpha3.BackendTLSPolicy{}, err
+	}
+	backendTLSPolicyList := &gatewayv1alpha3.BackendTLSPolicyList{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(backendTLSPolicyListUnstructured.UnstructuredContent(), backendTLSPolicyList); err != nil {
+		return []gatewayv1alpha3.BackendTLSPolicy{}, fmt.Errorf("failed to convert unstructured BackendTLSPolicyList to structured: %v", err)
+	}
+	sort.Slice(backendTLSPolicyList.Items, func(i, j int) bool {
+		return backendTLSPolicyList.Items[i].GetName() < backendTLSPolicyList.Items[j].GetName()
+	})
+	return backendTLSPolicyList.Items, nil
+}
+
+// fetchBackendLBPolicies fetches all BackendLBPolicies within the namespace,
+// sorted by name.
+func (d Discoverer) fetchBackendLBPolicies(ctx context.Context, namespace string) ([]gatewayv1alpha2.BackendLBPolicy, error) {
+	gvr := common.BackendLBPoliciesPermission.GroupVersionResource(gatewayv1alpha2.GroupVersion.Version)
+
+	backendLBPolicyListUnstructured, err := d.K8sClients.DC.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return []gatewayv1alpha2.BackendLBPolicy{}, err
+	}
+	backendLBPolicyList := &gatewayv1alpha2.BackendLBPolicyList{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(backendLBPolicyListUnstructured.UnstructuredContent(), backendLBPolicyList); err != nil {
+		return []gatewayv1alpha2.BackendLBPolicy{}, fmt.Errorf("failed to convert unstructured BackendLBPolicyList to structured: %v", err)
+	}
+	sort.Slice(backendLBPolicyList.Items, func(i, j int) bool {
+		return backendLBPolicyList.Items[i].GetName() < backendLBPolicyList.Items[j].GetName()
+	})
+	return backendLBPolicyList.Items, nil
+}
+
+// fetchEndpointSlices fetches the EndpointSlices of the Service, sorted by
+// name.
+func (d Discoverer) fetchEndpointSlices(ctx context.Context, namespace, serviceName string) ([]discoveryv1.EndpointSlice, error) {
+	gvr := common.EndpointSlicesPermission.GroupVersionResource(discoveryv1.SchemeGroupVersion.Version)
+
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: serviceName})
+	endpointSliceListUnstructured, err := d.K8sClients.DC.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return []discoveryv1.EndpointSlice{}, err
+	}
+	endpointSliceList := &discoveryv1.EndpointSliceList{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(endpointSliceListUnstructured.UnstructuredContent(), endpointSliceList); err != nil {
+		return []discoveryv1.EndpointSlice{}, fmt.Errorf("failed to convert unstructured EndpointSliceList to structured: %v", err)
+	}
+	sort.Slice(endpointSliceList.Items, func(i, j int) bool {
+		return endpointSliceList.Items[i].GetName() < endpointSliceList.Items[j].GetName()
+	})
+	return endpointSliceList.Items, nil
+}
+
 // fetchBackends fetches Backends based on a filter.
 //
 // At the moment, this is exclusively used for Backends of type Service, though
 // it still returns a slice of unstructured.Unstructured for future extensions.
 func (d Discoverer) fetchBackends(ctx context.Context, filter Filter) ([]unstructured.Unstructured, error) {
-	gvr := schema.GroupVersionResource{
-		Group:    "",
-		Version:  "v1",
-		Resource: "services",
-	}
+	gvr := serviceGVR
 
 	if filter.Name != "" {
 		// Use Get call.
@@ -774,13 +1545,94 @@ func (d Discoverer) fetchBackends(ctx context.Context, filter Filter) ([]unstruc
 	listOptions := metav1.ListOptions{
 		LabelSelector: labelSelector,
 	}
-	var backendsList *unstructured.UnstructuredList
-	backendsList, err := d.K8sClients.DC.Resource(gvr).Namespace(filter.Namespace).List(ctx, listOptions)
+	backendsList, err := d.listUnstructured(ctx, gvr, filter, listOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	return backendsList.Items, nil
+	return filterByNameRegex(backendsList.Items, filter.NameRegex), nil
+}
+
+// fetchMetadata fetches only the metadata of resources of the given
+// GroupVersionResource based on a filter. The returned PartialObjectMetadata
+// have their TypeMeta set to that of the resource, rather than that of
+// PartialObjectMetadata.
+func (d Discoverer) fetchMetadata(ctx context.Context, gvr schema.GroupVersionResource, kind string, filter Filter) ([]metav1.PartialObjectMetadata, error) {
+	var result []metav1.PartialObjectMetadata
+	if filter.Name != "" {
+		// Use Get call.
+		partialObjectMetadata, err := d.K8sClients.MetadataClient.Resource(gvr).Namespace(filter.Namespace).Get(ctx, filter.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *partialObjectMetadata)
+	} else {
+		// Use List call.
+		labelSelector := ""
+		if filter.Labels != nil {
+			labelSelector = filter.Labels.String()
+		}
+		listOptions := metav1.ListOptions{
+			LabelSelector: labelSelector,
+		}
+		for _, namespace := range filter.listNamespaces() {
+			partialObjectMetadataList, err := d.K8sClients.MetadataClient.Resource(gvr).Namespace(namespace).List(ctx, listOptions)
+			if err := d.tolerateTerminatingNamespace(ctx, namespace, err); err != nil {
+				return nil, err
+			}
+			if partialObjectMetadataList != nil {
+				result = append(result, filterByNameRegex(partialObjectMetadataList.Items, filter.NameRegex)...)
+			}
+		}
+	}
+
+	for i := range result {
+		result[i].TypeMeta = metav1.TypeMeta{
+			APIVersion: gvr.GroupVersion().String(),
+			Kind:       kind,
+		}
+	}
+	return result, nil
+}
+
+// listUnstructured lists the resources of the GroupVersionResource within each
+// of the namespaces of the filter, and merges the results. Errors from listing
+// within a terminating namespace are ignored, like in
+// tolerateTerminatingNamespace.
+func (d Discoverer) listUnstructured(ctx context.Context, gvr schema.GroupVersionResource, filter Filter, listOptions metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	result := &unstructured.UnstructuredList{}
+	for _, namespace := range filter.listNamespaces() {
+		list, err := d.K8sClients.DC.Resource(gvr).Namespace(namespace).List(ctx, listOptions)
+		if err := d.tolerateTerminatingNamespace(ctx, namespace, err); err != nil {
+			return nil, err
+		}
+		if list == nil {
+			continue
+		}
+		if result.Object == nil {
+			result.Object = list.Object
+		}
+		result.Items = append(result.Items, list.Items...)
+	}
+	return result, nil
+}
+
+// filterByNameRegex returns the items whose name matches nameRegex. All items
+// are returned if nameRegex is nil.
+func filterByNameRegex[T any, PT interface {
+	*T
+	GetName() string
+}](items []T, nameRegex *regexp.Regexp) []T {
+	if nameRegex == nil {
+		return items
+	}
+	var result []T
+	for i := range items {
+		if nameRegex.MatchString(PT(&items[i]).GetName()) {
+			result = append(result, items[i])
+		}
+	}
+	return result
 }
 
 // fetchNamespace fetches Namespaces based on a filter.
@@ -809,21 +1661,113 @@ func (d Discoverer) fetchNamespace(ctx context.Context, filter Filter) ([]corev1
 	return namespacesList.Items, nil
 }
 
-// fetchEventsFor fetches events associated with the given object.
+// tolerateTerminatingNamespace returns nil if err was encountered when fetching
+// resources within a terminating namespace, since such requests can fail while
+// the namespace is being deleted. Otherwise, err is returned unchanged.
+func (d Discoverer) tolerateTerminatingNamespace(ctx context.Context, namespace string, err error) error {
+	if err == nil || namespace == "" {
+		return err
+	}
+	ns := &corev1.Namespace{}
+	if getErr := d.K8sClients.Client.Get(ctx, apimachinerytypes.NamespacedName{Name: namespace}, ns); getErr != nil {
+		return err
+	}
+	if !NewNamespaceNode(*ns).IsTerminating() {
+		return err
+	}
+	klog.V(1).ErrorS(err, "Ignoring error from fetching resources in terminating namespace", "namespace", namespace)
+	return nil
+}
+
+// FetchEventsFor fetches all events associated with the given object. Printers
+// limit the number of events they show, and only the most recent events should
+// be shown, which the API server cannot select.
+//
+// Events are matched by the kind, name and namespace of the object they
+// involve, and by its UID when known, so that the events of objects read
+// without their UID are found too. The events of a namespaced object are in
+// its namespace, while those of a cluster-scoped object, like a GatewayClass,
+// are searched across all namespaces, since controllers record them in their
+// own namespace or in the default namespace.
 func (d Discoverer) FetchEventsFor(ctx context.Context, object client.Object) *corev1.EventList {
 	eventList := &corev1.EventList{}
+	gvk := object.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		if typedGVK, err := apiutil.GVKForObject(object, d.K8sClients.Client.Scheme()); err == nil {
+			gvk = typedGVK
+		}
+	}
+
+	selectors := []fields.Selector{fields.OneTermEqualSelector("involvedObject.name", object.GetName())}
+	if gvk.Kind != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("involvedObject.kind", gvk.Kind))
+	}
+	if object.GetNamespace() != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("involvedObject.namespace", object.GetNamespace()))
+	}
+	if object.GetUID() != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("involvedObject.uid", string(object.GetUID())))
+	}
 	options := &client.ListOptions{
-		FieldSelector: fields.AndSelectors(
-			fields.OneTermEqualSelector("involvedObject.uid", string(object.GetUID())),
-		),
-		Limit: maxEventsPerResource,
+		Namespace:     object.GetNamespace(),
+		FieldSelector: fields.AndSelectors(selectors...),
 	}
 	if err := d.K8sClients.Client.List(ctx, eventList, options); err != nil {
 		klog.V(1).ErrorS(err, "Failed to list events associated with resource.",
-			"resourceType", object.GetObjectKind().GroupVersionKind().Kind+"."+object.GetObjectKind().GroupVersionKind().Group,
+			"resourceType", gvk.Kind+"."+gvk.Group,
 			"resourceNamespace", object.GetNamespace(),
 			"resourceName", object.GetName())
 		return eventList
 	}
 	return eventList
 }
+
+// FetchPolicyTarget fetches the object targeted by the given policy.
+func (d Discoverer) FetchPolicyTarget(ctx context.Context, policy policymanager.Policy) (*unstructured.Unstructured, error) {
+	targetRef := policy.TargetRef()
+
+	gvr, namespaced, err := d.resourceForGroupKind(schema.GroupKind{Group: targetRef.Group, Kind: targetRef.Kind})
+	if err != nil {
+		return nil, err
+	}
+	if !namespaced {
+		return d.K8sClients.DC.Resource(gvr).Get(ctx, targetRef.Name, metav1.GetOptions{})
+	}
+	namespace := targetRef.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return d.K8sClients.DC.Resource(gvr).Namespace(namespace).Get(ctx, targetRef.Name, metav1.GetOptions{})
+}
+
+// resourceForGroupKind returns the GroupVersionResource for the given
+// GroupKind, and whether the resource is namespaced. Resources which are
+// natively understood by the Discoverer use their preferred versions; all
+// others are resolved through the RESTMapper.
+func (d Discoverer) resourceForGroupKind(gk schema.GroupKind) (schema.GroupVersionResource, bool, error) {
+	preferredVersion := func(preferred, fallback metav1.GroupVersion) string {
+		if preferred != (metav1.GroupVersion{}) {
+			return preferred.Version
+		}
+		return fallback.Version
+	}
+
+	switch {
+	case gk.Group == gatewayv1.GroupName && gk.Kind == "GatewayClass":
+		return schema.GroupVersionResource{Group: gk.Group, Version: preferredVersion(d.PreferredGatewayClassGroupVersion, defaultGatewayClassGroupVersion), Resource: common.GatewayClassesPermission.Resource}, false, nil
+	case gk.Group == gatewayv1.GroupName && gk.Kind == "Gateway":
+		return schema.GroupVersionResource{Group: gk.Group, Version: preferredVersion(d.PreferredGatewayGroupVersion, defaultGatewayGroupVersion), Resource: common.GatewaysPermission.Resource}, true, nil
+	case gk.Group == gatewayv1.GroupName && gk.Kind == "HTTPRoute":
+		return schema.GroupVersionResource{Group: gk.Group, Version: preferredVersion(d.PreferredHTTPRouteGroupVersion, defaultHTTPRouteGroupVersion), Resource: common.HTTPRoutesPermission.Resource}, true, nil
+	case gk.Group == corev1.GroupName && gk.Kind == "Namespace":
+		return corev1.SchemeGroupVersion.WithResource("namespaces"), false, nil
+	case gk.Group == corev1.GroupName && gk.Kind == "Service":
+		return corev1.SchemeGroupVersion.WithResource("services"), true, nil
+	}
+
+	mapping, err := d.K8sClients.Client.RESTMapper().RESTMapping(gk)
+	if err != nil {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("failed to find resource for %v: %v", gk, err)
+	}
+	return mapping.Resource, mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
@@ -17,19 +17,32 @@ limitations under the License.
 package resourcediscovery
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	fakedynamicclient "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
@@ -209,6 +222,204 @@ func TestDiscoverResourcesForGateway(t *testing.T) {
 	}
 }
 
+func TestDiscoverResourcesForGateway_CrossGatewayHostnameConflicts(t *testing.T) {
+	newGateway := func(name, address string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners:        []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}},
+			},
+			Status: gatewayv1.GatewayStatus{
+				Addresses: []gatewayv1.GatewayStatusAddress{{Value: address}},
+			},
+		}
+	}
+	newHTTPRoute := func(name, gatewayName string, hostnames ...gatewayv1.Hostname) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gatewayName)}},
+				},
+				Hostnames: hostnames,
+			},
+		}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		newGateway("gateway-1", "10.0.0.1"),
+		newGateway("gateway-2", "10.0.0.1"),
+		newGateway("gateway-3", "10.0.0.2"),
+		newHTTPRoute("route-1", "gateway-1", "foo.com", "bar.com"),
+		newHTTPRoute("route-2", "gateway-2", "foo.com"),
+		newHTTPRoute("route-3", "gateway-3", "foo.com", "bar.com"),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(Filter{Namespace: "default", Name: "gateway-1"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	var gotErrors []string
+	for _, err := range resourceModel.Gateways[GatewayID("default", "gateway-1")].Errors {
+		if _, ok := err.(CrossGatewayHostnameConflictError); ok {
+			gotErrors = append(gotErrors, err.Error())
+		}
+	}
+	wantErrors := []string{
+		`Hostname "foo.com" on 10.0.0.1:80 is served by multiple Gateways [default/gateway-1, default/gateway-2] through HTTPRoutes [default/route-1, default/route-2]; requests may be ambiguous`,
+	}
+	if diff := cmp.Diff(wantErrors, gotErrors); diff != "" {
+		t.Errorf("Unexpected diff in Gateway errors (-want +got):\n%v", diff)
+	}
+}
+
+func TestDiscoverResourcesForGateway_OtherRoutesForbidden(t *testing.T) {
+	newGateway := func(name string, from gatewayv1.FromNamespaces) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{{
+					Name:          "http",
+					Port:          80,
+					Protocol:      gatewayv1.HTTPProtocolType,
+					AllowedRoutes: &gatewayv1.AllowedRoutes{Namespaces: &gatewayv1.RouteNamespaces{From: &from}},
+				}},
+			},
+		}
+	}
+	newGRPCRoute := func(namespace, name, gatewayName string) *gatewayv1.GRPCRoute {
+		return &gatewayv1.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: gatewayv1.GRPCRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{
+						Name:      gatewayv1.ObjectName(gatewayName),
+						Namespace: common.PtrTo(gatewayv1.Namespace("default")),
+					}},
+				},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		common.NamespaceForTest("other"),
+		&gatewayv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "foo-gatewayclass"}},
+		newGateway("gateway-same", gatewayv1.NamespacesFromSame),
+		newGateway("gateway-all", gatewayv1.NamespacesFromAll),
+		newGRPCRoute("default", "route-1", "gateway-same"),
+		newGRPCRoute("default", "route-2", "gateway-all"),
+		newGRPCRoute("other", "route-3", "gateway-all"),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	// GRPCRoutes can only be listed within a namespace.
+	k8sClients.DC.(*fakedynamicclient.FakeDynamicClient).PrependReactor("list", "grpcroutes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() != "" {
+			return false, nil, nil
+		}
+		return true, nil, apierrors.NewForbidden(action.GetResource().GroupResource(), "", fmt.Errorf("cluster-wide list denied"))
+	})
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: utils.MustPolicyManagerForTest(t, k8sClients),
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(Filter{Namespace: "default", Labels: labels.Everything()})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	type gatewayResult struct {
+		GRPCRoutes int
+		Errors     []string
+	}
+	got := make(map[string]gatewayResult)
+	for _, gatewayNode := range resourceModel.Gateways {
+		var errors []string
+		for _, err := range gatewayNode.Errors {
+			errors = append(errors, err.Error())
+		}
+		got[gatewayNode.Gateway.GetName()] = gatewayResult{GRPCRoutes: gatewayNode.AttachedRoutesByKind["GRPCRoute"], Errors: errors}
+	}
+	want := map[string]gatewayResult{
+		"gateway-same": {GRPCRoutes: 1},
+		"gateway-all": {
+			GRPCRoutes: 1,
+			Errors:     []string{`unable to count the GRPCRoutes attached from other namespaces: grpcroutes.gateway.networking.k8s.io is forbidden: cluster-wide list denied`},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected diff in Gateways (-want +got):\n%v", diff)
+	}
+}
+
+func TestDiscoverResourcesForHTTPRoute_BackendPortNotFound(t *testing.T) {
+	backendRef := func(name string, port gatewayv1.PortNumber) gatewayv1.HTTPBackendRef {
+		return gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+			Kind: common.PtrTo(gatewayv1.Kind("Service")),
+			Name: gatewayv1.ObjectName(name),
+			Port: common.PtrTo(port),
+		}}}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-svc",
+				Namespace: "default",
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "http", Port: 80}, {Name: "https", Port: 443}},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{
+					{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("foo-svc", 80), backendRef("foo-svc", 8080)}},
+					{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("foo-svc", 8080)}},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(Filter{Namespace: "default", Name: "foo-httproute"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	var gotErrors []string
+	for _, err := range resourceModel.HTTPRoutes[HTTPRouteID("default", "foo-httproute")].Errors {
+		if _, ok := err.(BackendPortNotFoundError); ok {
+			gotErrors = append(gotErrors, err.Error())
+		}
+	}
+	wantErrors := []string{
+		`Port 8080 referenced by a backendRef does not exist on Service "default/foo-svc", which has ports [80, 443]`,
+	}
+	if diff := cmp.Diff(wantErrors, gotErrors); diff != "" {
+		t.Errorf("Unexpected diff in HTTPRoute errors (-want +got):\n%v", diff)
+	}
+}
+
 func TestDiscoverResourcesForHTTPRoute(t *testing.T) {
 	testcases := []struct {
 		name    string
@@ -418,6 +629,348 @@ func TestDiscoverResourcesForHTTPRoute(t *testing.T) {
 	}
 }
 
+func TestDiscoverResourcesForHTTPRoute_ExternalNameService(t *testing.T) {
+	service := func(name string, spec corev1.ServiceSpec) *corev1.Service {
+		return &corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: spec,
+		}
+	}
+	backendRef := func(name string) gatewayv1.HTTPBackendRef {
+		return gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+			Group: common.PtrTo(gatewayv1.Group("")),
+			Kind:  common.PtrTo(gatewayv1.Kind("Service")),
+			Name:  gatewayv1.ObjectName(name),
+		}}}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		service("cluster-ip-svc", corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "10.96.0.10"}),
+		service("headless-svc", corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: corev1.ClusterIPNone}),
+		service("external-svc", corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "foo.example.com"}),
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{
+					{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("cluster-ip-svc"), backendRef("headless-svc"), backendRef("external-svc")}},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	warnings := &common.Warnings{}
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+		Warnings:      warnings,
+	}
+
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(Filter{Labels: labels.Everything()})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", resourceModel)
+	}
+
+	httpRouteNode := resourceModel.HTTPRoutes[HTTPRouteID("default", "foo-httproute")]
+	if httpRouteNode == nil {
+		t.Fatalf("HTTPRoute default/foo-httproute was not discovered")
+	}
+	// All Backends are connected, but only the ExternalName Service is reported.
+	if got := len(httpRouteNode.Backends); got != 3 {
+		t.Errorf("Unexpected number of Backends; got=%v, want=3", got)
+	}
+	wantErrors := []error{
+		ReferenceToExternalNameServiceError{ReferenceFromTo: ReferenceFromTo{
+			ReferringObject: common.ObjRef{Kind: "HTTPRoute", Name: "foo-httproute", Namespace: "default"},
+			ReferredObject:  common.ObjRef{Kind: "Service", Name: "external-svc", Namespace: "default"},
+		}},
+	}
+	if diff := cmp.Diff(wantErrors, httpRouteNode.Errors, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Unexpected diff in Errors; got=%v, want=%v;\ndiff (-want +got)=\n%v", httpRouteNode.Errors, wantErrors, diff)
+	}
+
+	// The error is collected as a warning of the discovery as well.
+	wantWarnings := []common.Warning{
+		{
+			Resource: common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Name: "foo-httproute", Namespace: "default"},
+			Message:  wantErrors[0].Error(),
+		},
+	}
+	if diff := cmp.Diff(wantWarnings, warnings.List()); diff != "" {
+		t.Errorf("Unexpected diff in Warnings (-want +got)=\n%v", diff)
+	}
+}
+
+// TestDiscoverResourcesForHTTPRoute_Concurrent runs multiple discoveries in
+// parallel while policies are being added to the PolicyManager. Run with -race
+// to detect unsynchronized access to shared state.
+func TestDiscoverResourcesForHTTPRoute_Concurrent(t *testing.T) {
+	timeoutPolicy := func(name string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "bar.com/v1",
+				"kind":       "TimeoutPolicy",
+				"metadata": map[string]interface{}{
+					"name":      name,
+					"namespace": "default",
+				},
+				"spec": map[string]interface{}{
+					"seconds": int64(30),
+					"targetRef": map[string]interface{}{
+						"group": "gateway.networking.k8s.io",
+						"kind":  "HTTPRoute",
+						"name":  "foo-httproute",
+					},
+				},
+			},
+		}
+	}
+	policy := timeoutPolicy("timeout-policy")
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-gatewayclass"},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: "example.net/gateway-controller"},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-gateway", Namespace: "default"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "foo-gateway"}},
+				},
+			},
+		},
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "timeoutpolicies.bar.com",
+				Labels: map[string]string{
+					gatewayv1alpha2.PolicyLabelKey: "direct",
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    "bar.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "timeoutpolicies",
+					Kind:   "TimeoutPolicy",
+				},
+			},
+		},
+		&policy,
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+
+	const workers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(Filter{Namespace: "default", Labels: labels.Everything()})
+			if err != nil {
+				errs <- err
+				return
+			}
+			httpRouteNode, ok := resourceModel.HTTPRoutes[HTTPRouteID("default", "foo-httproute")]
+			if !ok {
+				errs <- fmt.Errorf("HTTPRoute default/foo-httproute not found in resourceModel")
+				return
+			}
+			if len(httpRouteNode.Policies) == 0 {
+				errs <- fmt.Errorf("no policies attached to HTTPRoute default/foo-httproute")
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			if err := policyManager.AddPolicy(timeoutPolicy(fmt.Sprintf("timeout-policy-%d", i))); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestDiscoverResourcesForHTTPRoute_Stale(t *testing.T) {
+	httpRoute := func(name string, generation, observedGeneration int64) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Namespace:  "default",
+				Generation: generation,
+			},
+			Status: gatewayv1.HTTPRouteStatus{
+				RouteStatus: gatewayv1.RouteStatus{
+					Parents: []gatewayv1.RouteParentStatus{{
+						ParentRef:      gatewayv1.ParentReference{Name: "foo-gateway"},
+						ControllerName: "example.net/gateway-controller",
+						Conditions: []metav1.Condition{{
+							Type:               "Accepted",
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: observedGeneration,
+						}},
+					}},
+				},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		httpRoute("up-to-date-httproute", 2, 2),
+		httpRoute("stale-httproute", 3, 2),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(Filter{Namespace: "default", Labels: labels.Everything(), Stale: true})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	var got []apimachinerytypes.NamespacedName
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		got = append(got, apimachinerytypes.NamespacedName{Namespace: httpRouteNode.HTTPRoute.GetNamespace(), Name: httpRouteNode.HTTPRoute.GetName()})
+	}
+	want := []apimachinerytypes.NamespacedName{{Namespace: "default", Name: "stale-httproute"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected diff in HTTPRoutes (-want +got):\n%v", diff)
+	}
+}
+
+func TestDiscoverResourcesForHTTPRoute_NameRegex(t *testing.T) {
+	httpRoute := func(name string) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		httpRoute("foo-canary"),
+		httpRoute("foo-canary-old"),
+		httpRoute("bar-canary"),
+		httpRoute("foo"),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	for _, metadataOnly := range []bool{false, true} {
+		discoverer.MetadataOnly = metadataOnly
+		resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(Filter{Namespace: "default", Labels: labels.Everything(), NameRegex: regexp.MustCompile("^(?:.*-canary)$")})
+		if err != nil {
+			t.Fatalf("Failed to construct resourceModel: %v", err)
+		}
+
+		var got []string
+		for _, httpRouteNode := range resourceModel.HTTPRoutes {
+			got = append(got, httpRouteNode.HTTPRoute.GetName())
+		}
+		sort.Strings(got)
+		want := []string{"bar-canary", "foo-canary"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Unexpected diff in HTTPRoutes with MetadataOnly=%v (-want +got):\n%v", metadataOnly, diff)
+		}
+	}
+}
+
+func TestDiscoverResourcesForHTTPRoute_Namespaces(t *testing.T) {
+	httpRoute := func(namespace, name string) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{{
+					BackendRefs: []gatewayv1.HTTPBackendRef{{
+						BackendRef: gatewayv1.BackendRef{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Kind: common.PtrTo(gatewayv1.Kind("Service")),
+								Name: "foo-svc",
+								Port: common.PtrTo(gatewayv1.PortNumber(8080)),
+							},
+						},
+					}},
+				}},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("team-a"),
+		common.NamespaceForTest("team-b"),
+		common.NamespaceForTest("team-c"),
+		httpRoute("team-a", "foo-httproute"),
+		httpRoute("team-b", "bar-httproute"),
+		httpRoute("team-c", "baz-httproute"),
+		&corev1.Service{TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"}, ObjectMeta: metav1.ObjectMeta{Name: "foo-svc", Namespace: "team-a"}},
+		&corev1.Service{TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"}, ObjectMeta: metav1.ObjectMeta{Name: "foo-svc", Namespace: "team-b"}},
+		&corev1.Service{TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"}, ObjectMeta: metav1.ObjectMeta{Name: "foo-svc", Namespace: "team-c"}},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	for _, metadataOnly := range []bool{false, true} {
+		discoverer.MetadataOnly = metadataOnly
+		resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(Filter{Namespaces: []string{"team-a", "team-b"}, Labels: labels.Everything()})
+		if err != nil {
+			t.Fatalf("Failed to construct resourceModel: %v", err)
+		}
+
+		var got []string
+		for _, httpRouteNode := range resourceModel.HTTPRoutes {
+			got = append(got, httpRouteNode.HTTPRoute.GetNamespace()+"/"+httpRouteNode.HTTPRoute.GetName())
+		}
+		sort.Strings(got)
+		want := []string{"team-a/foo-httproute", "team-b/bar-httproute"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Unexpected diff in HTTPRoutes with MetadataOnly=%v (-want +got):\n%v", metadataOnly, diff)
+		}
+
+		// Only the Backends of the HTTPRoutes in the selected namespaces are
+		// discovered.
+		wantBackends := 2
+		if metadataOnly {
+			wantBackends = 0
+		}
+		if got := len(resourceModel.Backends); got != wantBackends {
+			t.Errorf("Discovered %d Backends with MetadataOnly=%v, want %d", got, metadataOnly, wantBackends)
+		}
+	}
+}
+
 func TestDiscoverResourcesForBackend(t *testing.T) {
 	testcases := []struct {
 		name    string
@@ -627,6 +1180,116 @@ func TestDiscoverResourcesForBackend(t *testing.T) {
 	}
 }
 
+func TestDiscoverResourcesForBackend_ResolveBackend(t *testing.T) {
+	endpointSlice := func(name, serviceName, portName string, ready ...bool) *discoveryv1.EndpointSlice {
+		endpointSlice := &discoveryv1.EndpointSlice{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "EndpointSlice",
+				APIVersion: "discovery.k8s.io/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: serviceName},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Ports:       []discoveryv1.EndpointPort{{Name: common.PtrTo(portName)}},
+		}
+		for i, r := range ready {
+			endpointSlice.Endpoints = append(endpointSlice.Endpoints, discoveryv1.Endpoint{
+				Addresses:  []string{fmt.Sprintf("10.0.0.%d", i)},
+				Conditions: discoveryv1.EndpointConditions{Ready: common.PtrTo(r)},
+			})
+		}
+		return endpointSlice
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&corev1.Service{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-svc",
+				Namespace: "default",
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{
+					{Name: "http", Port: 80},
+					{Name: "metrics", Port: 9090},
+				},
+			},
+		},
+		endpointSlice("foo-svc-http", "foo-svc", "http", true, true, false),
+		endpointSlice("foo-svc-metrics", "foo-svc", "metrics", false),
+		endpointSlice("bar-svc-http", "bar-svc", "http", true),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+
+	resourceModel, err := discoverer.DiscoverResourcesForBackend(Filter{Labels: labels.Everything()})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", resourceModel)
+	}
+
+	type resolution struct {
+		ServiceFound      bool
+		PortExists        bool
+		ReadyEndpoints    int
+		NotReadyEndpoints int
+	}
+	testcases := []struct {
+		name string
+		ref  common.ObjRef
+		port *gatewayv1.PortNumber
+		want resolution
+	}{
+		{
+			name: "port of service",
+			ref:  common.ObjRef{Kind: "Service", Namespace: "default", Name: "foo-svc"},
+			port: common.PtrTo(gatewayv1.PortNumber(80)),
+			want: resolution{ServiceFound: true, PortExists: true, ReadyEndpoints: 2, NotReadyEndpoints: 1},
+		},
+		{
+			name: "no port counts endpoints of every port",
+			ref:  common.ObjRef{Kind: "Service", Namespace: "default", Name: "foo-svc"},
+			want: resolution{ServiceFound: true, PortExists: true, ReadyEndpoints: 2, NotReadyEndpoints: 2},
+		},
+		{
+			name: "port missing from service",
+			ref:  common.ObjRef{Kind: "Service", Namespace: "default", Name: "foo-svc"},
+			port: common.PtrTo(gatewayv1.PortNumber(8080)),
+			want: resolution{ServiceFound: true},
+		},
+		{
+			name: "missing service",
+			ref:  common.ObjRef{Kind: "Service", Namespace: "default", Name: "bar-svc"},
+			port: common.PtrTo(gatewayv1.PortNumber(80)),
+			want: resolution{},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			backendResolution := resourceModel.ResolveBackend(tc.ref, tc.port)
+			got := resolution{
+				ServiceFound:      backendResolution.Service != nil,
+				PortExists:        backendResolution.PortExists(),
+				ReadyEndpoints:    backendResolution.ReadyEndpoints,
+				NotReadyEndpoints: backendResolution.NotReadyEndpoints,
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected diff in ResolveBackend(%v, %v); diff (-want +got)=\n%v", tc.ref, tc.port, diff)
+			}
+		})
+	}
+}
+
 // TestDiscoverResourcesForGatewayClass_LabelSelector Tests label selector filtering for GatewayClasses.
 func TestDiscoverResourcesForGatewayClass_LabelSelector(t *testing.T) {
 	fakeClock := testingclock.NewFakeClock(time.Now())
@@ -890,6 +1553,84 @@ func TestDiscoverResourcesForNamespace_LabelSelector(t *testing.T) {
 	}
 }
 
+func TestDiscoverResourcesForGateway_MetadataOnly(t *testing.T) {
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-gateway",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "foo"},
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bar-gateway",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "foo-gateway"}},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+		MetadataOnly:  true,
+	}
+
+	selector, err := labels.Parse("app=foo")
+	if err != nil {
+		t.Fatalf("Failed to parse label selector: %v", err)
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForGateway(Filter{Namespace: "default", Labels: selector})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	wantGateways := []apimachinerytypes.NamespacedName{{Namespace: "default", Name: "foo-gateway"}}
+	gotGateways := namespacedGatewaysFromResourceModel(resourceModel)
+	if diff := cmp.Diff(wantGateways, gotGateways); diff != "" {
+		t.Errorf("Unexpected diff in Gateways; got=%v, want=%v;\ndiff (-want +got)=\n%v", gotGateways, wantGateways, diff)
+	}
+	// Only the metadata of the Gateway is fetched, and related resources are
+	// not discovered.
+	for _, gatewayNode := range resourceModel.Gateways {
+		wantGVK := gatewayv1.SchemeGroupVersion.WithKind("Gateway")
+		if gotGVK := gatewayNode.Gateway.GroupVersionKind(); gotGVK != wantGVK {
+			t.Errorf("Unexpected GroupVersionKind; got=%v, want=%v", gotGVK, wantGVK)
+		}
+		if gatewayNode.Gateway.Spec.GatewayClassName != "" {
+			t.Errorf("Unexpected spec for metadata only Gateway: %v", gatewayNode.Gateway.Spec)
+		}
+	}
+	if len(resourceModel.HTTPRoutes) != 0 || len(resourceModel.GatewayClasses) != 0 {
+		t.Errorf("Unexpected related resources discovered; HTTPRoutes=%v, GatewayClasses=%v", resourceModel.HTTPRoutes, resourceModel.GatewayClasses)
+	}
+}
+
 func gatewayClassNamesFromResourceModel(r *ResourceModel) []string {
 	var gatewayClassNames []string
 	for _, gatewayClassNode := range r.GatewayClasses {
@@ -942,3 +1683,120 @@ func gatewayErrorsFromResourceModel(r *ResourceModel) map[apimachinerytypes.Name
 	}
 	return result
 }
+
+// fakeProgress records the progress which is reported to it.
+type fakeProgress struct {
+	steps []string
+	done  bool
+}
+
+func (p *fakeProgress) Step(step common.ProgressStep) { p.steps = append(p.steps, step.String()) }
+
+func (p *fakeProgress) Done() { p.done = true }
+
+func TestDiscoverAllResources_Progress(t *testing.T) {
+	k8sClients := common.MustClientsForTest(t, common.NamespaceForTest("default"))
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	progress := &fakeProgress{}
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+		Progress:      progress,
+	}
+
+	if _, err := discoverer.DiscoverAllResources(Filter{Labels: labels.Everything()}); err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	wantSteps := []string{
+		"listed 1/7 kinds",
+		"listed 2/7 kinds",
+		"listed 3/7 kinds",
+		"listed 4/7 kinds",
+		"listed 5/7 kinds",
+		"listed 6/7 kinds",
+		"listed 7/7 kinds",
+	}
+	if diff := cmp.Diff(wantSteps, progress.steps); diff != "" {
+		t.Errorf("Unexpected diff in progress steps; got=%v, want=%v;\ndiff (-want +got)=\n%v", progress.steps, wantSteps, diff)
+	}
+	// Completing the progress is left to the caller, which knows when the output
+	// starts.
+	if progress.done {
+		t.Errorf("DiscoverAllResources() completed the progress")
+	}
+}
+
+func TestFetchEventsFor(t *testing.T) {
+	event := func(name, namespace string, involvedObject corev1.ObjectReference) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: namespace},
+			InvolvedObject: involvedObject,
+			Reason:         name,
+		}
+	}
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", UID: "foo-gatewayclass-uid"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "example.net/gateway-controller"},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+		Spec:       gatewayv1.GatewaySpec{GatewayClassName: "foo"},
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		common.NamespaceForTest("gateway-system"),
+		gatewayClass,
+		gateway,
+		// Events of the GatewayClass, recorded in the namespace of the controller
+		// and in the default namespace.
+		event("gatewayclass-controller-namespace", "gateway-system", corev1.ObjectReference{Kind: "GatewayClass", Name: "foo", UID: "foo-gatewayclass-uid"}),
+		event("gatewayclass-default-namespace", "default", corev1.ObjectReference{Kind: "GatewayClass", Name: "foo", UID: "foo-gatewayclass-uid"}),
+		// Event of a previous GatewayClass with the same name.
+		event("gatewayclass-previous", "default", corev1.ObjectReference{Kind: "GatewayClass", Name: "foo", UID: "previous-uid"}),
+		// Events of the Gateway, which has the same name as the GatewayClass.
+		event("gateway", "default", corev1.ObjectReference{Kind: "Gateway", Name: "foo", Namespace: "default"}),
+		event("gateway-other-namespace", "gateway-system", corev1.ObjectReference{Kind: "Gateway", Name: "foo", Namespace: "gateway-system"}),
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+
+	testcases := []struct {
+		name   string
+		object client.Object
+		want   []string
+	}{
+		{
+			name:   "cluster-scoped object",
+			object: gatewayClass,
+			want:   []string{"gatewayclass-controller-namespace", "gatewayclass-default-namespace"},
+		},
+		{
+			name:   "cluster-scoped object without uid",
+			object: &gatewayv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+			want:   []string{"gatewayclass-controller-namespace", "gatewayclass-default-namespace", "gatewayclass-previous"},
+		},
+		{
+			name:   "namespaced object",
+			object: gateway,
+			want:   []string{"gateway"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, event := range discoverer.FetchEventsFor(context.Background(), tc.object).Items {
+				got = append(got, event.Reason)
+			}
+			sort.Strings(got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected diff in events (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+)
+
+// HTTPRouteDryRun describes how an HTTPRoute behaves within the cluster. It is
+// meant for HTTPRoutes which have not been applied yet, in which case the
+// Discoverer reads the cluster through clients overlaid with the HTTPRoute.
+type HTTPRouteDryRun struct {
+	// HTTPRouteNode is the HTTPRoute within the ResourceModel discovered for it.
+	// Its Errors report Backends which do not exist or are not permitted, and
+	// its EffectivePolicies are the policies it would inherit.
+	HTTPRouteNode *HTTPRouteNode
+	// Attachments lists whether the HTTPRoute attaches to each listener
+	// selected by its parentRefs.
+	Attachments []ListenerAttachment
+	// Overlaps lists the matches of the HTTPRoute which other HTTPRoutes also
+	// define for the same listener and hostname.
+	Overlaps []RouteOverlap
+}
+
+// ListenerAttachment describes whether an HTTPRoute attaches to a listener of
+// a Gateway.
+type ListenerAttachment struct {
+	Gateway types.NamespacedName
+	// Listener is empty if the Gateway does not exist.
+	Listener gatewayv1.SectionName
+	// Hostnames are the hostnames which the HTTPRoute serves through the
+	// listener.
+	Hostnames []string
+	// Reason explains why the HTTPRoute does not attach to the listener. It is
+	// empty if the HTTPRoute attaches.
+	Reason string
+}
+
+// AttachedListener returns the listener of the Gateway which the HTTPRoute
+// attaches to through the attachment. It returns false if the Gateway is not
+// among the Gateways of the HTTPRoute, or has no such listener.
+func AttachedListener(httpRouteNode *HTTPRouteNode, attachment ListenerAttachment) (gatewayv1.Listener, bool) {
+	for _, gatewayNode := range httpRouteNode.Gateways {
+		if client.ObjectKeyFromObject(gatewayNode.Gateway) != attachment.Gateway {
+			continue
+		}
+		for _, listener := range gatewayNode.Gateway.Spec.Listeners {
+			if listener.Name == attachment.Listener {
+				return listener, true
+			}
+		}
+	}
+	return gatewayv1.Listener{}, false
+}
+
+// RouteOverlap describes a match which another HTTPRoute defines as well for
+// the same listener and hostname. Only one of the HTTPRoutes receives the
+// matching requests, which is the oldest one.
+type RouteOverlap struct {
+	HTTPRoute types.NamespacedName
+	Gateway   types.NamespacedName
+	Listener  gatewayv1.SectionName
+	Hostname  string
+	Match     gatewayv1.HTTPRouteMatch
+}
+
+// DryRunHTTPRoute discovers how the HTTPRoute attaches to the listeners of its
+// parent Gateways, which other HTTPRoutes it overlaps with, whether its
+// Backends exist and which policies it inherits.
+func (d Discoverer) DryRunHTTPRoute(namespace, name string) (*HTTPRouteDryRun, error) {
+	ctx := context.Background()
+	resourceModel, err := d.DiscoverResourcesForHTTPRoute(Filter{Namespace: namespace, Name: name, Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	httpRouteNode, ok := resourceModel.HTTPRoutes[HTTPRouteID(namespace, name)]
+	if !ok {
+		return nil, fmt.Errorf("HTTPRoute %v/%v not found", namespace, name)
+	}
+
+	namespaceLabels, err := d.fetchNamespaceLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gateways, err := d.fetchGateways(ctx, Filter{ /* all Gateways */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	gatewaysByName := make(map[types.NamespacedName]*gatewayv1.Gateway)
+	for i := range gateways {
+		gatewaysByName[client.ObjectKeyFromObject(&gateways[i])] = &gateways[i]
+	}
+
+	result := &HTTPRouteDryRun{
+		HTTPRouteNode: httpRouteNode,
+		Attachments:   listenerAttachmentsForHTTPRoute(*httpRouteNode.HTTPRoute, gatewaysByName, namespaceLabels),
+	}
+
+	httpRoutes, err := d.fetchHTTPRoutes(ctx, Filter{ /* all HTTPRoutes */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range httpRoutes {
+		if other.GetNamespace() == namespace && other.GetName() == name {
+			continue
+		}
+		otherAttachments := listenerAttachmentsForHTTPRoute(other, gatewaysByName, namespaceLabels)
+		result.Overlaps = append(result.Overlaps, findRouteOverlaps(*httpRouteNode.HTTPRoute, result.Attachments, other, otherAttachments)...)
+	}
+	return result, nil
+}
+
+// gatewaysSelectNamespaces returns true if some listener of the Gateways allows
+// routes from the namespaces selected by a label selector, so that the labels
+// of the Namespaces are needed to evaluate which routes attach to it.
+func gatewaysSelectNamespaces(gateways []gatewayv1.Gateway) bool {
+	for _, gateway := range gateways {
+		for _, listener := range gateway.Spec.Listeners {
+			if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil &&
+				listener.AllowedRoutes.Namespaces.From != nil && *listener.AllowedRoutes.Namespaces.From == gatewayv1.NamespacesFromSelector {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (d Discoverer) fetchNamespaceLabels(ctx context.Context) (map[string]map[string]string, error) {
+	namespacesList := &corev1.NamespaceList{}
+	if err := d.K8sClients.Client.List(ctx, namespacesList, &client.ListOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to fetch list of namespaces: %v", err)
+	}
+	result := make(map[string]map[string]string)
+	for _, namespace := range namespacesList.Items {
+		result[namespace.Name] = namespace.Labels
+	}
+	return result, nil
+}
+
+// listenerAttachmentsForHTTPRoute returns whether the HTTPRoute attaches to
+// each listener selected by its parentRefs which refer to Gateways.
+func listenerAttachmentsForHTTPRoute(httpRoute gatewayv1.HTTPRoute, gateways map[types.NamespacedName]*gatewayv1.Gateway, namespaceLabels map[string]map[string]string) []ListenerAttachment {
+	return listenerAttachmentsForRoute("HTTPRoute", httpRoute.GetNamespace(), httpRoute.Spec.ParentRefs, httpRoute.Spec.Hostnames, gateways, namespaceLabels)
+}
+
+// listenerAttachmentsForRoute returns whether a route of the kind, in the
+// namespace, attaches to each listener selected by its parentRefs which refer
+// to Gateways. Kinds without hostnames, like TCPRoute, pass nil hostnames.
+func listenerAttachmentsForRoute(kind, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, gateways map[types.NamespacedName]*gatewayv1.Gateway, namespaceLabels map[string]map[string]string) []ListenerAttachment {
+	var result []ListenerAttachment
+	for _, parentRef := range parentRefs {
+		if parentRef.Kind != nil && *parentRef.Kind != "Gateway" {
+			continue
+		}
+		gatewayNamespace := namespace
+		if parentRef.Namespace != nil {
+			gatewayNamespace = string(*parentRef.Namespace)
+		}
+		gatewayName := types.NamespacedName{Namespace: gatewayNamespace, Name: string(parentRef.Name)}
+		gateway, ok := gateways[gatewayName]
+		if !ok {
+			result = append(result, ListenerAttachment{Gateway: gatewayName, Reason: "Gateway does not exist"})
+			continue
+		}
+
+		for _, listener := range gateway.Spec.Listeners {
+			if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+				continue
+			}
+			if parentRef.Port != nil && *parentRef.Port != listener.Port {
+				continue
+			}
+			attachment := ListenerAttachment{Gateway: gatewayName, Listener: listener.Name}
+			switch {
+			case !listenerAllowsRouteKind(listener, kind):
+				attachment.Reason = fmt.Sprintf("listener with protocol %v does not allow %vs", listener.Protocol, kind)
+			case !listenerAllowsNamespace(listener, gateway.GetNamespace(), namespace, namespaceLabels[namespace]):
+				attachment.Reason = fmt.Sprintf("listener does not allow routes from namespace %v", namespace)
+			default:
+				attachment.Hostnames = relations.IntersectHostnames(listener.Hostname, hostnames)
+				if len(attachment.Hostnames) == 0 {
+					attachment.Reason = fmt.Sprintf("no hostname of the %v matches the listener hostname %v", kind, *listener.Hostname)
+				}
+			}
+			result = append(result, attachment)
+		}
+	}
+	return result
+}
+
+// defaultRouteKindsByProtocol are the route kinds which listeners of each
+// protocol allow when their allowedRoutes do not list any kinds.
+var defaultRouteKindsByProtocol = map[gatewayv1.ProtocolType][]string{
+	gatewayv1.HTTPProtocolType:  {"HTTPRoute", "GRPCRoute"},
+	gatewayv1.HTTPSProtocolType: {"HTTPRoute", "GRPCRoute"},
+	gatewayv1.TLSProtocolType:   {"TLSRoute"},
+	gatewayv1.TCPProtocolType:   {"TCPRoute"},
+	gatewayv1.UDPProtocolType:   {"UDPRoute"},
+}
+
+func listenerAllowsRouteKind(listener gatewayv1.Listener, kind string) bool {
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		return slices.Contains(defaultRouteKindsByProtocol[listener.Protocol], kind)
+	}
+	for _, routeKind := range listener.AllowedRoutes.Kinds {
+		if string(routeKind.Kind) == kind && (routeKind.Group == nil || *routeKind.Group == gatewayv1.GroupName) {
+			return true
+		}
+	}
+	return false
+}
+
+func listenerAllowsNamespace(listener gatewayv1.Listener, gatewayNamespace, routeNamespace string, routeNamespaceLabels map[string]string) bool {
+	from := gatewayv1.NamespacesFromSame
+	var selector *metav1.LabelSelector
+	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil {
+		if listener.AllowedRoutes.Namespaces.From != nil {
+			from = *listener.AllowedRoutes.Namespaces.From
+		}
+		selector = listener.AllowedRoutes.Namespaces.Selector
+	}
+	switch from {
+	case gatewayv1.NamespacesFromAll:
+		return true
+	case gatewayv1.NamespacesFromSelector:
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return false
+		}
+		return labelSelector.Matches(labels.Set(routeNamespaceLabels))
+	default:
+		return gatewayNamespace == routeNamespace
+	}
+}
+
+// findRouteOverlaps returns the matches of httpRoute which other defines as
+// well, for listeners and hostnames which both HTTPRoutes are attached to.
+func findRouteOverlaps(httpRoute gatewayv1.HTTPRoute, attachments []ListenerAttachment, other gatewayv1.HTTPRoute, otherAttachments []ListenerAttachment) []RouteOverlap {
+	otherMatches := matchesForHTTPRoute(other)
+	var result []RouteOverlap
+	for _, attachment := range attachments {
+		if attachment.Reason != "" {
+			continue
+		}
+		for _, otherAttachment := range otherAttachments {
+			if otherAttachment.Reason != "" || otherAttachment.Gateway != attachment.Gateway || otherAttachment.Listener != attachment.Listener {
+				continue
+			}
+			for _, hostname := range attachment.Hostnames {
+				if !slices.Contains(otherAttachment.Hostnames, hostname) {
+					continue
+				}
+				for _, match := range matchesForHTTPRoute(httpRoute) {
+					for _, otherMatch := range otherMatches {
+						if !reflect.DeepEqual(match, otherMatch) {
+							continue
+						}
+						result = append(result, RouteOverlap{
+							HTTPRoute: client.ObjectKeyFromObject(&other),
+							Gateway:   attachment.Gateway,
+							Listener:  attachment.Listener,
+							Hostname:  hostname,
+							Match:     match,
+						})
+					}
+				}
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].HTTPRoute.String() < result[j].HTTPRoute.String()
+	})
+	return result
+}
+
+// matchesForHTTPRoute returns the matches of all rules of the HTTPRoute, with
+// the defaults of the path match filled in.
+func matchesForHTTPRoute(httpRoute gatewayv1.HTTPRoute) []gatewayv1.HTTPRouteMatch {
+	var result []gatewayv1.HTTPRouteMatch
+	for _, rule := range httpRoute.Spec.Rules {
+		matches := rule.Matches
+		if len(matches) == 0 {
+			matches = []gatewayv1.HTTPRouteMatch{{}}
+		}
+		for _, match := range matches {
+			result = append(result, withDefaultPathMatch(match))
+		}
+	}
+	return result
+}
+
+// withDefaultPathMatch returns the match with the defaults of its path filled
+// in, which is a PathPrefix match on "/".
+func withDefaultPathMatch(match gatewayv1.HTTPRouteMatch) gatewayv1.HTTPRouteMatch {
+	path := gatewayv1.HTTPPathMatch{
+		Type:  common.PtrTo(gatewayv1.PathMatchPathPrefix),
+		Value: common.PtrTo("/"),
+	}
+	if match.Path != nil {
+		if match.Path.Type != nil {
+			path.Type = match.Path.Type
+		}
+		if match.Path.Value != nil {
+			path.Value = match.Path.Value
+		}
+	}
+	match.Path = &path
+	return match
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+)
+
+func TestDryRunHTTPRoute(t *testing.T) {
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		common.NamespaceForTest("infra"),
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "edge-gw",
+				Namespace: "infra",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{
+					{
+						Name:     "http",
+						Protocol: gatewayv1.HTTPProtocolType,
+						Port:     80,
+						Hostname: common.PtrTo(gatewayv1.Hostname("*.example.com")),
+						AllowedRoutes: &gatewayv1.AllowedRoutes{
+							Namespaces: &gatewayv1.RouteNamespaces{From: common.PtrTo(gatewayv1.NamespacesFromAll)},
+						},
+					},
+					{
+						Name:     "tcp",
+						Protocol: gatewayv1.TCPProtocolType,
+						Port:     9000,
+					},
+				},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "checkout-svc",
+				Namespace: "default",
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "existing-route",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{
+						Name:        "edge-gw",
+						Namespace:   common.PtrTo(gatewayv1.Namespace("infra")),
+						SectionName: common.PtrTo(gatewayv1.SectionName("http")),
+					}},
+				},
+				Hostnames: []gatewayv1.Hostname{"shop.example.com"},
+				Rules: []gatewayv1.HTTPRouteRule{{
+					Matches: []gatewayv1.HTTPRouteMatch{{
+						Path: &gatewayv1.HTTPPathMatch{Value: common.PtrTo("/checkout")},
+					}},
+				}},
+			},
+		},
+	}
+	newRoute := `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: new-route
+  namespace: default
+spec:
+  parentRefs:
+  - name: edge-gw
+    namespace: infra
+  - name: missing-gw
+  hostnames:
+  - shop.example.com
+  - shop.example.org
+  rules:
+  - matches:
+    - path:
+        type: PathPrefix
+        value: /checkout
+    backendRefs:
+    - group: ""
+      kind: Service
+      name: missing-svc
+      port: 80
+`
+	path := filepath.Join(t.TempDir(), "new-route.yaml")
+	if err := os.WriteFile(path, []byte(newRoute), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	overlayObjects, _, err := common.ReadObjectsFromFiles([]string{path})
+	if err != nil {
+		t.Fatalf("ReadObjectsFromFiles() returned an unexpected error: %v", err)
+	}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: gatewayv1.GroupName, Version: "v1", Kind: "HTTPRoute"}, meta.RESTScopeNamespace)
+	k8sClients, err := common.NewOverlayK8sClients(common.MustClientsForTest(t, objects...), mapper, overlayObjects)
+	if err != nil {
+		t.Fatalf("NewOverlayK8sClients() returned an unexpected error: %v", err)
+	}
+	policyManager := policymanager.New(k8sClients.DC)
+	if err := policyManager.Init(context.Background()); err != nil {
+		t.Fatalf("failed to initialize PolicyManager: %v", err)
+	}
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+
+	dryRun, err := discoverer.DryRunHTTPRoute("default", "new-route")
+	if err != nil {
+		t.Fatalf("DryRunHTTPRoute() returned an unexpected error: %v", err)
+	}
+
+	edgeGateway := types.NamespacedName{Namespace: "infra", Name: "edge-gw"}
+	wantAttachments := []ListenerAttachment{
+		{Gateway: edgeGateway, Listener: "http", Hostnames: []string{"shop.example.com"}},
+		{Gateway: edgeGateway, Listener: "tcp", Reason: "listener with protocol TCP does not allow HTTPRoutes"},
+		{Gateway: types.NamespacedName{Namespace: "default", Name: "missing-gw"}, Reason: "Gateway does not exist"},
+	}
+	if diff := cmp.Diff(wantAttachments, dryRun.Attachments); diff != "" {
+		t.Errorf("Unexpected diff in Attachments (-want +got)=\n%v", diff)
+	}
+
+	wantOverlaps := []RouteOverlap{{
+		HTTPRoute: types.NamespacedName{Namespace: "default", Name: "existing-route"},
+		Gateway:   edgeGateway,
+		Listener:  "http",
+		Hostname:  "shop.example.com",
+		Match: gatewayv1.HTTPRouteMatch{
+			Path: &gatewayv1.HTTPPathMatch{Type: common.PtrTo(gatewayv1.PathMatchPathPrefix), Value: common.PtrTo("/checkout")},
+		},
+	}}
+	if diff := cmp.Diff(wantOverlaps, dryRun.Overlaps); diff != "" {
+		t.Errorf("Unexpected diff in Overlaps (-want +got)=\n%v", diff)
+	}
+
+	var gotMissingBackend bool
+	for _, err := range dryRun.HTTPRouteNode.Errors {
+		if refErr, ok := err.(ReferenceToNonExistentResourceError); ok && refErr.ReferredObject.Name == "missing-svc" {
+			gotMissingBackend = true
+		}
+	}
+	if !gotMissingBackend {
+		t.Errorf("Errors of the HTTPRoute do not report the missing Backend: %v", dryRun.HTTPRouteNode.Errors)
+	}
+}
+
+func TestAttachedListener(t *testing.T) {
+	gatewayNode := NewGatewayNode(&gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-gateway", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+				{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443},
+			},
+		},
+	})
+	httpRouteNode := NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+	})
+	httpRouteNode.Gateways[gatewayNode.ID()] = gatewayNode
+
+	testcases := []struct {
+		name       string
+		attachment ListenerAttachment
+		want       gatewayv1.SectionName
+		wantOK     bool
+	}{
+		{
+			name:       "attached listener",
+			attachment: ListenerAttachment{Gateway: types.NamespacedName{Namespace: "default", Name: "foo-gateway"}, Listener: "https"},
+			want:       "https",
+			wantOK:     true,
+		},
+		{
+			name:       "unknown listener",
+			attachment: ListenerAttachment{Gateway: types.NamespacedName{Namespace: "default", Name: "foo-gateway"}, Listener: "grpc"},
+		},
+		{
+			name:       "other Gateway",
+			attachment: ListenerAttachment{Gateway: types.NamespacedName{Namespace: "infra", Name: "foo-gateway"}, Listener: "https"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			listener, ok := AttachedListener(httpRouteNode, tc.attachment)
+			if ok != tc.wantOK || listener.Name != tc.want {
+				t.Errorf("AttachedListener() = (%q, %v); want (%q, %v)", listener.Name, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+)
+
+// HostnameServing is whether a hostname of an HTTPRoute is actually served.
+type HostnameServing string
+
+const (
+	// HostnameServed means some listener which accepted the HTTPRoute serves
+	// the hostname.
+	HostnameServed HostnameServing = "served"
+	// HostnameNotServed means the Gateways reported the status of the HTTPRoute,
+	// but no listener which accepted the HTTPRoute serves the hostname.
+	HostnameNotServed HostnameServing = "not served"
+	// HostnameServingUnknown means the Gateways have not reported whether they
+	// accepted the HTTPRoute, typically because the controller has not
+	// reconciled the HTTPRoute yet.
+	HostnameServingUnknown HostnameServing = "unknown"
+	// HostnameAttachmentsUnknown means the listeners which the HTTPRoute
+	// attaches to could not be evaluated, so whether any of them serves the
+	// hostname is unknown.
+	HostnameAttachmentsUnknown HostnameServing = "unknown attachments"
+)
+
+// EffectiveHostname is a hostname from the spec of an HTTPRoute, along with
+// whether it is actually served.
+type EffectiveHostname struct {
+	Hostname string
+	Serving  HostnameServing
+}
+
+// String returns the hostname, annotated with why it may not be served.
+func (e EffectiveHostname) String() string {
+	switch e.Serving {
+	case HostnameNotServed:
+		return fmt.Sprintf("%v (not served: no accepting listener)", e.Hostname)
+	case HostnameServingUnknown:
+		return fmt.Sprintf("%v (unknown: not reconciled by the Gateway controller yet)", e.Hostname)
+	case HostnameAttachmentsUnknown:
+		return fmt.Sprintf("%v (unknown: the attached listeners could not be evaluated)", e.Hostname)
+	default:
+		return e.Hostname
+	}
+}
+
+// EffectiveHostnames returns whether each hostname in the spec of the HTTPRoute
+// is served by some listener which accepted the HTTPRoute. A hostname is served
+// if a listener which the HTTPRoute attaches to, according to the Attachments
+// of the node, belongs to a Gateway which reported the HTTPRoute as Accepted.
+//
+// Hostnames are only reported as not served once the Gateways reported the
+// status of the HTTPRoute, or if the HTTPRoute has no Gateway to attach to;
+// otherwise, whether they are served is unknown. It is unknown as well if the
+// Attachments of the node could not be evaluated.
+func EffectiveHostnames(httpRouteNode *HTTPRouteNode) []EffectiveHostname {
+	httpRoute := httpRouteNode.HTTPRoute
+	var result []EffectiveHostname
+	for _, hostname := range httpRoute.Spec.Hostnames {
+		serving := HostnameAttachmentsUnknown
+		if !httpRouteNode.AttachmentsUnknown {
+			serving = hostnameServing(httpRoute, httpRouteNode.Attachments, string(hostname))
+		}
+		result = append(result, EffectiveHostname{Hostname: string(hostname), Serving: serving})
+	}
+	return result
+}
+
+func hostnameServing(httpRoute *gatewayv1.HTTPRoute, attachments []ListenerAttachment, hostname string) HostnameServing {
+	// Without any Gateway to attach to, no controller ever reports a status.
+	if len(attachments) == 0 {
+		return HostnameNotServed
+	}
+	if len(httpRoute.Status.Parents) == 0 {
+		return HostnameServingUnknown
+	}
+	result := HostnameNotServed
+	for _, attachment := range attachments {
+		if attachment.Reason != "" || !attachmentServesHostname(attachment, hostname) {
+			continue
+		}
+		accepted, reported := gatewayAcceptedHTTPRoute(httpRoute, attachment.Gateway)
+		switch {
+		case accepted:
+			return HostnameServed
+		case !reported:
+			result = HostnameServingUnknown
+		}
+	}
+	return result
+}
+
+// attachmentServesHostname returns true if the listener serves the hostname
+// of the HTTPRoute, or a more specific hostname matched by it.
+func attachmentServesHostname(attachment ListenerAttachment, hostname string) bool {
+	return slices.ContainsFunc(attachment.Hostnames, func(served string) bool {
+		return served == hostname || relations.WildcardHostnameMatches(hostname, served)
+	})
+}
+
+// gatewayAcceptedHTTPRoute returns whether the HTTPRoute reports the Gateway as
+// a parent which accepted it, and whether the Gateway reported any status for
+// the HTTPRoute at all.
+func gatewayAcceptedHTTPRoute(httpRoute *gatewayv1.HTTPRoute, gateway types.NamespacedName) (accepted, reported bool) {
+	for _, parent := range httpRoute.Status.Parents {
+		if parent.ParentRef.Kind != nil && *parent.ParentRef.Kind != "Gateway" {
+			continue
+		}
+		namespace := httpRoute.GetNamespace()
+		if parent.ParentRef.Namespace != nil {
+			namespace = string(*parent.ParentRef.Namespace)
+		}
+		if namespace != gateway.Namespace || string(parent.ParentRef.Name) != gateway.Name {
+			continue
+		}
+		reported = true
+		if meta.IsStatusConditionTrue(parent.Conditions, string(gatewayv1.RouteConditionAccepted)) {
+			accepted = true
+		}
+	}
+	return accepted, reported
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestEffectiveHostnames(t *testing.T) {
+	gatewayA := types.NamespacedName{Namespace: "default", Name: "gateway-a"}
+	gatewayB := types.NamespacedName{Namespace: "default", Name: "gateway-b"}
+	parentStatus := func(gateway string, accepted metav1.ConditionStatus) gatewayv1.RouteParentStatus {
+		return gatewayv1.RouteParentStatus{
+			ParentRef:  gatewayv1.ParentReference{Name: gatewayv1.ObjectName(gateway)},
+			Conditions: []metav1.Condition{{Type: string(gatewayv1.RouteConditionAccepted), Status: accepted}},
+		}
+	}
+
+	testcases := []struct {
+		name        string
+		hostnames   []gatewayv1.Hostname
+		attachments []ListenerAttachment
+		// attachmentsUnknown is true if the attachments could not be evaluated.
+		attachmentsUnknown bool
+		parents            []gatewayv1.RouteParentStatus
+		want               []string
+	}{
+		{
+			name:        "accepted by the Gateway of the serving listener",
+			hostnames:   []gatewayv1.Hostname{"api.example.com", "example.com"},
+			attachments: []ListenerAttachment{{Gateway: gatewayA, Listener: "https", Hostnames: []string{"api.example.com"}}},
+			parents:     []gatewayv1.RouteParentStatus{parentStatus("gateway-a", metav1.ConditionTrue)},
+			want:        []string{"api.example.com", "example.com (not served: no accepting listener)"},
+		},
+		{
+			name:        "wildcard hostname served through a more specific listener hostname",
+			hostnames:   []gatewayv1.Hostname{"*.example.com"},
+			attachments: []ListenerAttachment{{Gateway: gatewayA, Listener: "https", Hostnames: []string{"api.example.com"}}},
+			parents:     []gatewayv1.RouteParentStatus{parentStatus("gateway-a", metav1.ConditionTrue)},
+			want:        []string{"*.example.com"},
+		},
+		{
+			name:        "rejected by the Gateway of the serving listener",
+			hostnames:   []gatewayv1.Hostname{"api.example.com"},
+			attachments: []ListenerAttachment{{Gateway: gatewayA, Listener: "https", Hostnames: []string{"api.example.com"}}},
+			parents:     []gatewayv1.RouteParentStatus{parentStatus("gateway-a", metav1.ConditionFalse)},
+			want:        []string{"api.example.com (not served: no accepting listener)"},
+		},
+		{
+			name:        "no listener attaches",
+			hostnames:   []gatewayv1.Hostname{"api.example.com"},
+			attachments: []ListenerAttachment{{Gateway: gatewayA, Listener: "tcp", Reason: "listener with protocol TCP does not allow HTTPRoutes"}},
+			parents:     []gatewayv1.RouteParentStatus{parentStatus("gateway-a", metav1.ConditionFalse)},
+			want:        []string{"api.example.com (not served: no accepting listener)"},
+		},
+		{
+			name:        "no status reported yet",
+			hostnames:   []gatewayv1.Hostname{"api.example.com", "example.com"},
+			attachments: []ListenerAttachment{{Gateway: gatewayA, Listener: "https", Hostnames: []string{"api.example.com"}}},
+			want: []string{
+				"api.example.com (unknown: not reconciled by the Gateway controller yet)",
+				"example.com (unknown: not reconciled by the Gateway controller yet)",
+			},
+		},
+		{
+			name:      "status not reported by the Gateway of the serving listener",
+			hostnames: []gatewayv1.Hostname{"api.example.com"},
+			attachments: []ListenerAttachment{
+				{Gateway: gatewayA, Listener: "https", Hostnames: []string{"api.example.com"}},
+				{Gateway: gatewayB, Listener: "https", Reason: "listener does not allow routes from namespace default"},
+			},
+			parents: []gatewayv1.RouteParentStatus{parentStatus("gateway-b", metav1.ConditionFalse)},
+			want:    []string{"api.example.com (unknown: not reconciled by the Gateway controller yet)"},
+		},
+		{
+			name:      "no parent Gateway",
+			hostnames: []gatewayv1.Hostname{"api.example.com"},
+			want:      []string{"api.example.com (not served: no accepting listener)"},
+		},
+		{
+			name:               "attachments could not be evaluated",
+			hostnames:          []gatewayv1.Hostname{"api.example.com"},
+			attachmentsUnknown: true,
+			parents:            []gatewayv1.RouteParentStatus{parentStatus("gateway-a", metav1.ConditionTrue)},
+			want:               []string{"api.example.com (unknown: the attached listeners could not be evaluated)"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpRouteNode := NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+				Spec:       gatewayv1.HTTPRouteSpec{Hostnames: tc.hostnames},
+				Status:     gatewayv1.HTTPRouteStatus{RouteStatus: gatewayv1.RouteStatus{Parents: tc.parents}},
+			})
+			httpRouteNode.Attachments = tc.attachments
+			httpRouteNode.AttachmentsUnknown = tc.attachmentsUnknown
+
+			var got []string
+			for _, effectiveHostname := range EffectiveHostnames(httpRouteNode) {
+				got = append(got, effectiveHostname.String())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("EffectiveHostnames() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -18,8 +18,13 @@ package resourcediscovery
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+
+	types "k8s.io/apimachinery/pkg/types"
 
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
 )
 
 type ReferenceToNonExistentResourceError struct {
@@ -42,6 +47,101 @@ func (r ReferenceNotPermittedError) Error() string {
 		r.referredObjectKind(), r.referredObjectName())
 }
 
+// ReferenceToExternalNameServiceError is reported for a route which references
+// a Service of type ExternalName. Implementations are not required to support
+// such Backends, and must then set the ResolvedRefs condition of the route to
+// False.
+type ReferenceToExternalNameServiceError struct {
+	ReferenceFromTo
+}
+
+func (r ReferenceToExternalNameServiceError) Error() string {
+	return fmt.Sprintf("%v %q references %v %q of type ExternalName, which is not supported by most implementations",
+		r.referringObjectKind(), r.referringObjectName(),
+		r.referredObjectKind(), r.referredObjectName())
+}
+
+// BackendPortNotFoundError is reported for a route which references a port of
+// a Service that the Service does not have, so the traffic to the backendRef
+// cannot be delivered.
+type BackendPortNotFoundError struct {
+	relations.BackendPortIssue
+}
+
+func (b BackendPortNotFoundError) Error() string {
+	var ports []string
+	for _, port := range b.ServicePorts {
+		ports = append(ports, strconv.Itoa(int(port)))
+	}
+	return fmt.Sprintf("Port %d referenced by a backendRef does not exist on Service %q, which has ports [%v]",
+		b.Port, b.Backend.Namespace+"/"+b.Backend.Name, strings.Join(ports, ", "))
+}
+
+// CrossGatewayHostnameConflictError is reported for a Gateway which serves a
+// hostname on the same address and port as some other Gateway.
+type CrossGatewayHostnameConflictError struct {
+	relations.Conflict
+}
+
+func (c CrossGatewayHostnameConflictError) Error() string {
+	var gateways, routes []string
+	for _, gateway := range c.Gateways {
+		gateways = append(gateways, gateway.String())
+	}
+	for _, route := range c.Routes {
+		routes = append(routes, route.String())
+	}
+	return fmt.Sprintf("Hostname %q on %v:%d is served by multiple Gateways [%v] through HTTPRoutes [%v]; requests may be ambiguous",
+		c.Hostname, c.Address, c.Port, strings.Join(gateways, ", "), strings.Join(routes, ", "))
+}
+
+// InvalidHostnameError is reported for a hostname which is not a valid RFC 1123
+// DNS name.
+type InvalidHostnameError struct {
+	Hostname string
+	Reason   string
+}
+
+func (i InvalidHostnameError) Error() string {
+	return fmt.Sprintf("Hostname %q is not a valid RFC 1123 DNS name: %v", i.Hostname, i.Reason)
+}
+
+// InvalidRegexError is reported for a regular expression used by a match which
+// does not compile.
+type InvalidRegexError struct {
+	Field  string
+	Regex  string
+	Reason string
+}
+
+func (i InvalidRegexError) Error() string {
+	return fmt.Sprintf("Regular expression %q of %v match does not compile: %v", i.Regex, i.Field, i.Reason)
+}
+
+// DuplicateParentRefError is reported for a parent which an HTTPRoute lists
+// more than once in its parentRefs, which is likely a copy-paste mistake.
+type DuplicateParentRefError struct {
+	Parent types.NamespacedName
+}
+
+func (d DuplicateParentRefError) Error() string {
+	return fmt.Sprintf("Parent %q is listed more than once in parentRefs", d.Parent.String())
+}
+
+// SessionPersistenceConflictError is reported for a rule of an HTTPRoute which
+// configures session persistence differently from a BackendLBPolicy of one of
+// its Backends. The configuration of the rule takes precedence.
+type SessionPersistenceConflictError struct {
+	Rule            int
+	Backend         common.ObjRef
+	BackendLBPolicy string
+}
+
+func (s SessionPersistenceConflictError) Error() string {
+	return fmt.Sprintf("Rule %d sets sessionPersistence, which takes precedence over the different sessionPersistence of BackendLBPolicy %q targeting %v %q",
+		s.Rule, s.BackendLBPolicy, s.Backend.Kind, s.Backend.Namespace+"/"+s.Backend.Name)
+}
+
 type ReferenceFromTo struct {
 	// ReferringObject is the "from" object which is referring "to" some other
 	// object.
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"slices"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/pkg/features"
+)
+
+// FeatureUsage is a feature, as named by GatewayClasses in their
+// supportedFeatures, which a resource uses through a field of its spec.
+type FeatureUsage struct {
+	Feature features.SupportedFeature
+	// Field is the path of the field using the feature, like
+	// spec.rules[].matches[].queryParams.
+	Field string
+}
+
+// httpRouteFeatures maps the fields of HTTPRoutes to the features they use.
+var httpRouteFeatures = []struct {
+	usage FeatureUsage
+	uses  func(httpRoute *gatewayv1.HTTPRoute) bool
+}{
+	{
+		usage: FeatureUsage{features.SupportHTTPRouteParentRefPort, "spec.parentRefs[].port"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return slices.ContainsFunc(httpRoute.Spec.ParentRefs, func(parentRef gatewayv1.ParentReference) bool { return parentRef.Port != nil })
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRouteQueryParamMatching, "spec.rules[].matches[].queryParams"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return anyHTTPRouteMatch(httpRoute, func(match gatewayv1.HTTPRouteMatch) bool { return len(match.QueryParams) != 0 })
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRouteMethodMatching, "spec.rules[].matches[].method"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return anyHTTPRouteMatch(httpRoute, func(match gatewayv1.HTTPRouteMatch) bool { return match.Method != nil })
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRouteResponseHeaderModification, "spec.rules[].filters[].responseHeaderModifier"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return anyHTTPRouteFilter(httpRoute, func(filter gatewayv1.HTTPRouteFilter) bool {
+				return filter.Type == gatewayv1.HTTPRouteFilterResponseHeaderModifier
+			})
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRouteRequestMirror, "spec.rules[].filters[].requestMirror"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return anyHTTPRouteFilter(httpRoute, func(filter gatewayv1.HTTPRouteFilter) bool {
+				return filter.Type == gatewayv1.HTTPRouteFilterRequestMirror
+			})
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRouteRequestMultipleMirrors, "spec.rules[].filters[].requestMirror"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return slices.ContainsFunc(httpRoute.Spec.Rules, func(rule gatewayv1.HTTPRouteRule) bool {
+				var mirrors int
+				for _, filter := range rule.Filters {
+					if filter.Type == gatewayv1.HTTPRouteFilterRequestMirror {
+						mirrors++
+					}
+				}
+				return mirrors > 1
+			})
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRoutePortRedirect, "spec.rules[].filters[].requestRedirect.port"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return anyHTTPRouteFilter(httpRoute, func(filter gatewayv1.HTTPRouteFilter) bool {
+				return filter.RequestRedirect != nil && filter.RequestRedirect.Port != nil
+			})
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRouteSchemeRedirect, "spec.rules[].filters[].requestRedirect.scheme"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return anyHTTPRouteFilter(httpRoute, func(filter gatewayv1.HTTPRouteFilter) bool {
+				return filter.RequestRedirect != nil && filter.RequestRedirect.Scheme != nil
+			})
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRoutePathRedirect, "spec.rules[].filters[].requestRedirect.path"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return anyHTTPRouteFilter(httpRoute, func(filter gatewayv1.HTTPRouteFilter) bool {
+				return filter.RequestRedirect != nil && filter.RequestRedirect.Path != nil
+			})
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRouteHostRewrite, "spec.rules[].filters[].urlRewrite.hostname"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return anyHTTPRouteFilter(httpRoute, func(filter gatewayv1.HTTPRouteFilter) bool {
+				return filter.URLRewrite != nil && filter.URLRewrite.Hostname != nil
+			})
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRoutePathRewrite, "spec.rules[].filters[].urlRewrite.path"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return anyHTTPRouteFilter(httpRoute, func(filter gatewayv1.HTTPRouteFilter) bool {
+				return filter.URLRewrite != nil && filter.URLRewrite.Path != nil
+			})
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRouteRequestTimeout, "spec.rules[].timeouts.request"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return slices.ContainsFunc(httpRoute.Spec.Rules, func(rule gatewayv1.HTTPRouteRule) bool {
+				return rule.Timeouts != nil && rule.Timeouts.Request != nil
+			})
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRouteBackendTimeout, "spec.rules[].timeouts.backendRequest"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return slices.ContainsFunc(httpRoute.Spec.Rules, func(rule gatewayv1.HTTPRouteRule) bool {
+				return rule.Timeouts != nil && rule.Timeouts.BackendRequest != nil
+			})
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportHTTPRouteBackendRequestHeaderModification, "spec.rules[].backendRefs[].filters[].requestHeaderModifier"},
+		uses: func(httpRoute *gatewayv1.HTTPRoute) bool {
+			return slices.ContainsFunc(httpRoute.Spec.Rules, func(rule gatewayv1.HTTPRouteRule) bool {
+				return slices.ContainsFunc(rule.BackendRefs, func(backendRef gatewayv1.HTTPBackendRef) bool {
+					return slices.ContainsFunc(backendRef.Filters, func(filter gatewayv1.HTTPRouteFilter) bool {
+						return filter.Type == gatewayv1.HTTPRouteFilterRequestHeaderModifier
+					})
+				})
+			})
+		},
+	},
+}
+
+// gatewayFeatures maps the fields of Gateways to the features they use.
+var gatewayFeatures = []struct {
+	usage FeatureUsage
+	uses  func(gateway *gatewayv1.Gateway) bool
+}{
+	{
+		usage: FeatureUsage{features.SupportGatewayPort8080, "spec.listeners[].port"},
+		uses: func(gateway *gatewayv1.Gateway) bool {
+			return slices.ContainsFunc(gateway.Spec.Listeners, func(listener gatewayv1.Listener) bool { return listener.Port == 8080 })
+		},
+	},
+	{
+		usage: FeatureUsage{features.SupportGatewayStaticAddresses, "spec.addresses"},
+		uses: func(gateway *gatewayv1.Gateway) bool {
+			return len(gateway.Spec.Addresses) != 0
+		},
+	},
+}
+
+// HTTPRouteFeatureUsages returns the features used by the HTTPRoute, in the
+// order in which the features package defines them.
+func HTTPRouteFeatureUsages(httpRoute *gatewayv1.HTTPRoute) []FeatureUsage {
+	var result []FeatureUsage
+	for _, f := range httpRouteFeatures {
+		if f.uses(httpRoute) {
+			result = append(result, f.usage)
+		}
+	}
+	return result
+}
+
+// GatewayFeatureUsages returns the features used by the Gateway.
+func GatewayFeatureUsages(gateway *gatewayv1.Gateway) []FeatureUsage {
+	var result []FeatureUsage
+	for _, f := range gatewayFeatures {
+		if f.uses(gateway) {
+			result = append(result, f.usage)
+		}
+	}
+	return result
+}
+
+// UnadvertisedFeatureUsages returns the usages of features which the
+// GatewayClass does not list in its supportedFeatures. GatewayClasses which do
+// not list any feature may well support them, so nothing is returned for them.
+func UnadvertisedFeatureUsages(usages []FeatureUsage, gatewayClass *gatewayv1.GatewayClass) []FeatureUsage {
+	supportedFeatures := gatewayClass.Status.SupportedFeatures
+	if len(supportedFeatures) == 0 {
+		return nil
+	}
+	var result []FeatureUsage
+	for _, usage := range usages {
+		if !slices.Contains(supportedFeatures, gatewayv1.SupportedFeature(usage.Feature)) {
+			result = append(result, usage)
+		}
+	}
+	return result
+}
+
+func anyHTTPRouteMatch(httpRoute *gatewayv1.HTTPRoute, predicate func(gatewayv1.HTTPRouteMatch) bool) bool {
+	return slices.ContainsFunc(httpRoute.Spec.Rules, func(rule gatewayv1.HTTPRouteRule) bool {
+		return slices.ContainsFunc(rule.Matches, predicate)
+	})
+}
+
+func anyHTTPRouteFilter(httpRoute *gatewayv1.HTTPRoute, predicate func(gatewayv1.HTTPRouteFilter) bool) bool {
+	return slices.ContainsFunc(httpRoute.Spec.Rules, func(rule gatewayv1.HTTPRouteRule) bool {
+		return slices.ContainsFunc(rule.Filters, predicate)
+	})
+}
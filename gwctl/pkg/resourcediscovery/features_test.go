@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/pkg/features"
+)
+
+func TestHTTPRouteFeatureUsages(t *testing.T) {
+	httpRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1.HTTPRouteMatch{{Method: common.PtrTo(gatewayv1.HTTPMethodPost)}},
+					Filters: []gatewayv1.HTTPRouteFilter{{
+						Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+						URLRewrite: &gatewayv1.HTTPURLRewriteFilter{Hostname: common.PtrTo(gatewayv1.PreciseHostname("internal.example.com"))},
+					}},
+				},
+				{
+					Timeouts: &gatewayv1.HTTPRouteTimeouts{BackendRequest: common.PtrTo(gatewayv1.Duration("5s"))},
+					BackendRefs: []gatewayv1.HTTPBackendRef{{
+						Filters: []gatewayv1.HTTPRouteFilter{{Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier}},
+					}},
+				},
+			},
+		},
+	}
+
+	got := HTTPRouteFeatureUsages(httpRoute)
+	want := []FeatureUsage{
+		{Feature: features.SupportHTTPRouteMethodMatching, Field: "spec.rules[].matches[].method"},
+		{Feature: features.SupportHTTPRouteHostRewrite, Field: "spec.rules[].filters[].urlRewrite.hostname"},
+		{Feature: features.SupportHTTPRouteBackendTimeout, Field: "spec.rules[].timeouts.backendRequest"},
+		{Feature: features.SupportHTTPRouteBackendRequestHeaderModification, Field: "spec.rules[].backendRefs[].filters[].requestHeaderModifier"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("HTTPRouteFeatureUsages() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
+
+func TestUnadvertisedFeatureUsages(t *testing.T) {
+	gateway := &gatewayv1.Gateway{
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{{Name: "http", Port: 8080}},
+			Addresses: []gatewayv1.GatewayAddress{{Value: "10.0.0.1"}},
+		},
+	}
+	usages := GatewayFeatureUsages(gateway)
+
+	testcases := []struct {
+		name              string
+		supportedFeatures []gatewayv1.SupportedFeature
+		want              []FeatureUsage
+	}{
+		{
+			name: "no supported features are reported",
+		},
+		{
+			name:              "some features are not advertised",
+			supportedFeatures: []gatewayv1.SupportedFeature{gatewayv1.SupportedFeature(features.SupportGatewayPort8080)},
+			want:              []FeatureUsage{{Feature: features.SupportGatewayStaticAddresses, Field: "spec.addresses"}},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gatewayClass := &gatewayv1.GatewayClass{Status: gatewayv1.GatewayClassStatus{SupportedFeatures: tc.supportedFeatures}}
+			got := UnadvertisedFeatureUsages(usages, gatewayClass)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("UnadvertisedFeatureUsages() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
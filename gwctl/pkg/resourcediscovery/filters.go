@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"strings"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// HTTPRouteFilterTypes are the types of filters which HTTPRoute rules may use.
+var HTTPRouteFilterTypes = []gatewayv1.HTTPRouteFilterType{
+	gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+	gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+	gatewayv1.HTTPRouteFilterRequestMirror,
+	gatewayv1.HTTPRouteFilterRequestRedirect,
+	gatewayv1.HTTPRouteFilterURLRewrite,
+	gatewayv1.HTTPRouteFilterExtensionRef,
+}
+
+// ParseHTTPRouteFilterType returns the HTTPRoute filter type with the name,
+// ignoring case.
+func ParseHTTPRouteFilterType(name string) (gatewayv1.HTTPRouteFilterType, error) {
+	var names []string
+	for _, filterType := range HTTPRouteFilterTypes {
+		if strings.EqualFold(string(filterType), name) {
+			return filterType, nil
+		}
+		names = append(names, string(filterType))
+	}
+	return "", fmt.Errorf("unknown HTTPRoute filter type %q; must be one of (%v)", name, strings.Join(names, ", "))
+}
+
+// HTTPRouteUsesFilterType returns true if some rule of the HTTPRoute uses a
+// filter of the type.
+func HTTPRouteUsesFilterType(httpRoute *gatewayv1.HTTPRoute, filterType gatewayv1.HTTPRouteFilterType) bool {
+	for _, rule := range httpRoute.Spec.Rules {
+		for _, filter := range rule.Filters {
+			if filter.Type == filterType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RestrictHTTPRoutesToFilterType removes the HTTPRoutes from the resourceModel
+// which use no filter of the type in any of their rules.
+func (rm *ResourceModel) RestrictHTTPRoutesToFilterType(filterType gatewayv1.HTTPRouteFilterType) {
+	for httpRouteID, httpRouteNode := range rm.HTTPRoutes {
+		if !HTTPRouteUsesFilterType(httpRouteNode.HTTPRoute, filterType) {
+			delete(rm.HTTPRoutes, httpRouteID)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestParseHTTPRouteFilterType(t *testing.T) {
+	got, err := ParseHTTPRouteFilterType("requestredirect")
+	if err != nil {
+		t.Fatalf("ParseHTTPRouteFilterType(requestredirect) returned an unexpected error: %v", err)
+	}
+	if got != gatewayv1.HTTPRouteFilterRequestRedirect {
+		t.Errorf("ParseHTTPRouteFilterType(requestredirect) = %v, want %v", got, gatewayv1.HTTPRouteFilterRequestRedirect)
+	}
+
+	if _, err := ParseHTTPRouteFilterType("Redirect"); err == nil {
+		t.Errorf("ParseHTTPRouteFilterType(Redirect) returned no error for an unknown filter type")
+	}
+}
+
+func TestRestrictHTTPRoutesToFilterType(t *testing.T) {
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "redirect-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{
+					{},
+					{
+						Filters: []gatewayv1.HTTPRouteFilter{
+							{Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier},
+							{Type: gatewayv1.HTTPRouteFilterRequestRedirect},
+						},
+					},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "rewrite-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{
+					{
+						Filters: []gatewayv1.HTTPRouteFilter{
+							{Type: gatewayv1.HTTPRouteFilterURLRewrite},
+						},
+					},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "plain-httproute",
+				Namespace: "default",
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+
+	testcases := []struct {
+		filterType gatewayv1.HTTPRouteFilterType
+		want       []string
+	}{
+		{
+			filterType: gatewayv1.HTTPRouteFilterRequestRedirect,
+			want:       []string{"default/redirect-httproute"},
+		},
+		{
+			filterType: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+			want:       []string{"default/redirect-httproute"},
+		},
+		{
+			filterType: gatewayv1.HTTPRouteFilterURLRewrite,
+			want:       []string{"default/rewrite-httproute"},
+		},
+		{
+			filterType: gatewayv1.HTTPRouteFilterRequestMirror,
+			want:       nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(string(tc.filterType), func(t *testing.T) {
+			resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(Filter{Namespace: "default"})
+			if err != nil {
+				t.Fatalf("Failed to construct resourceModel: %v", err)
+			}
+			resourceModel.RestrictHTTPRoutesToFilterType(tc.filterType)
+
+			var got []string
+			for _, httpRouteNode := range resourceModel.HTTPRoutes {
+				got = append(got, httpRouteNode.HTTPRoute.GetNamespace()+"/"+httpRouteNode.HTTPRoute.GetName())
+			}
+			sort.Strings(got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected diff in HTTPRoutes (-want +got)=\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateHostname returns an error if the hostname does not follow the
+// grammar of hostnames of the Gateway API: a lowercase RFC 1123 DNS name,
+// optionally prefixed with a single wildcard label ("*."). Unlike the
+// validation performed by the API server, this also enforces the length of
+// individual labels, and the error tells precisely what is wrong, like an
+// uppercase letter, a trailing dot, a wildcard which is not the leftmost label,
+// or an IP address.
+func ValidateHostname(hostname string) error {
+	switch {
+	case hostname == "":
+		return fmt.Errorf("must not be empty")
+	case net.ParseIP(strings.Trim(hostname, "[]")) != nil:
+		return fmt.Errorf("must not be an IP address")
+	case strings.Contains(hostname, ":"):
+		return fmt.Errorf("must not include a port")
+	case strings.HasSuffix(hostname, "."):
+		return fmt.Errorf("must not end with a dot")
+	case strings.ToLower(hostname) != hostname:
+		return fmt.Errorf("must be lowercase")
+	case len(hostname) > validation.DNS1123SubdomainMaxLength:
+		return fmt.Errorf("must be no more than %d characters", validation.DNS1123SubdomainMaxLength)
+	}
+
+	name := strings.TrimPrefix(hostname, "*.")
+	if strings.Contains(name, "*") {
+		return fmt.Errorf("wildcard must be the whole leftmost label, like *.example.com")
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return fmt.Errorf("must not contain empty labels")
+		}
+		if errs := validation.IsDNS1123Label(label); len(errs) != 0 {
+			return fmt.Errorf("label %q: %v", label, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// validateHostnamesForHTTPRoutes reports the hostnames of HTTPRoutes in the
+// resourceModel which are not valid RFC 1123 DNS names.
+func validateHostnamesForHTTPRoutes(resourceModel *ResourceModel) {
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		for _, hostname := range httpRouteNode.HTTPRoute.Spec.Hostnames {
+			if err := ValidateHostname(string(hostname)); err != nil {
+				httpRouteNode.Errors = append(httpRouteNode.Errors, InvalidHostnameError{
+					Hostname: string(hostname),
+					Reason:   err.Error(),
+				})
+			}
+		}
+	}
+}
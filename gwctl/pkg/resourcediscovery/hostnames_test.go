@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestValidateHostname(t *testing.T) {
+	testcases := []struct {
+		hostname string
+		wantErr  string
+	}{
+		{hostname: "example.com"},
+		{hostname: "localhost"},
+		{hostname: "*.example.com"},
+		{hostname: "*.foo.example.com"},
+		{hostname: "foo-bar.example.com"},
+		{hostname: "0.example.com"},
+		{hostname: "1-2.example.com"},
+		{hostname: strings.Repeat("a", 63) + ".example.com"},
+		{hostname: strings.Repeat("a.", 126) + "a"},
+		{hostname: "", wantErr: "must not be empty"},
+		{hostname: "10.0.0.1", wantErr: "must not be an IP address"},
+		{hostname: "2001:db8::1", wantErr: "must not be an IP address"},
+		{hostname: "[2001:db8::1]", wantErr: "must not be an IP address"},
+		{hostname: "example.com:8080", wantErr: "must not include a port"},
+		{hostname: "example.com.", wantErr: "must not end with a dot"},
+		{hostname: "*.example.com.", wantErr: "must not end with a dot"},
+		{hostname: "Example.com", wantErr: "must be lowercase"},
+		{hostname: "*.EXAMPLE.COM", wantErr: "must be lowercase"},
+		{hostname: strings.Repeat("a.", 127) + "com", wantErr: "must be no more than 253 characters"},
+		{hostname: "*", wantErr: "wildcard must be the whole leftmost label, like *.example.com"},
+		{hostname: "foo.*.example.com", wantErr: "wildcard must be the whole leftmost label, like *.example.com"},
+		{hostname: "*foo.example.com", wantErr: "wildcard must be the whole leftmost label, like *.example.com"},
+		{hostname: "foo*.example.com", wantErr: "wildcard must be the whole leftmost label, like *.example.com"},
+		{hostname: "*.*.example.com", wantErr: "wildcard must be the whole leftmost label, like *.example.com"},
+		{hostname: "example.*", wantErr: "wildcard must be the whole leftmost label, like *.example.com"},
+		{hostname: "foo..example.com", wantErr: "must not contain empty labels"},
+		{hostname: ".example.com", wantErr: "must not contain empty labels"},
+		{hostname: "*.", wantErr: "must not end with a dot"},
+		{hostname: "foo_bar.example.com", wantErr: `label "foo_bar": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`},
+		{hostname: "-foo.example.com", wantErr: `label "-foo": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`},
+		{hostname: "foo-.example.com", wantErr: `label "foo-": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`},
+		{hostname: strings.Repeat("a", 64) + ".example.com", wantErr: `label "` + strings.Repeat("a", 64) + `": must be no more than 63 characters`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.hostname, func(t *testing.T) {
+			var gotErr string
+			if err := ValidateHostname(tc.hostname); err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tc.wantErr {
+				t.Errorf("ValidateHostname(%q) returned err=%q; want err=%q", tc.hostname, gotErr, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateHostnamesForHTTPRoutes(t *testing.T) {
+	resourceModel := &ResourceModel{}
+	resourceModel.addHTTPRoutes(gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-httproute", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"example.com", "foo_bar.example.com"},
+		},
+	})
+
+	validateHostnamesForHTTPRoutes(resourceModel)
+
+	var got []string
+	for _, err := range resourceModel.HTTPRoutes[HTTPRouteID("default", "foo-httproute")].Errors {
+		if invalidHostnameErr, ok := err.(InvalidHostnameError); ok {
+			got = append(got, invalidHostnameErr.Hostname)
+		}
+	}
+	want := []string{"foo_bar.example.com"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected diff in invalid hostnames (-want +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+)
+
+// IngressHostnameCollision is a host of an Ingress which overlaps a hostname
+// served by an HTTPRoute through a listener of a Gateway. Which of them
+// receives the requests for the host depends on the controllers.
+type IngressHostnameCollision struct {
+	Ingress types.NamespacedName
+	Host    string
+	// HTTPRoute serves Hostname through Listener. Either of Host and Hostname
+	// may be a wildcard matching the other.
+	HTTPRoute types.NamespacedName
+	Listener  GatewayListener
+	Hostname  string
+}
+
+// DiscoverIngressHostnameCollisions lists the networking.k8s.io/v1 Ingresses of
+// the cluster, and returns the hosts of their rules which overlap a hostname
+// served by some HTTPRoute, sorted by Ingress and host. Rules without a host
+// are ignored. No collisions are returned if the cluster does not serve
+// Ingresses, but an error is returned if they cannot be listed otherwise, like
+// without permission to; Ingresses are only listed when asked for.
+func (d Discoverer) DiscoverIngressHostnameCollisions() ([]IngressHostnameCollision, error) {
+	ctx := context.Background()
+	ingresses, err := d.fetchIngresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(ingresses) == 0 {
+		return nil, nil
+	}
+
+	namespaceLabels, err := d.fetchNamespaceLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gateways, err := d.fetchGateways(ctx, Filter{ /* all Gateways */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	gatewaysByName := make(map[types.NamespacedName]*gatewayv1.Gateway)
+	for i := range gateways {
+		gatewaysByName[client.ObjectKeyFromObject(&gateways[i])] = &gateways[i]
+	}
+	httpRoutes, err := d.fetchHTTPRoutes(ctx, Filter{ /* all HTTPRoutes */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	return findIngressHostnameCollisions(ingresses, httpRoutes, gatewaysByName, namespaceLabels), nil
+}
+
+func findIngressHostnameCollisions(ingresses []networkingv1.Ingress, httpRoutes []gatewayv1.HTTPRoute, gateways map[types.NamespacedName]*gatewayv1.Gateway, namespaceLabels map[string]map[string]string) []IngressHostnameCollision {
+	type servedHostname struct {
+		httpRoute types.NamespacedName
+		listener  GatewayListener
+		hostname  string
+	}
+	var served []servedHostname
+	for _, httpRoute := range httpRoutes {
+		for _, attachment := range listenerAttachmentsForHTTPRoute(httpRoute, gateways, namespaceLabels) {
+			if attachment.Reason != "" {
+				continue
+			}
+			for _, hostname := range attachment.Hostnames {
+				served = append(served, servedHostname{
+					httpRoute: client.ObjectKeyFromObject(&httpRoute),
+					listener:  GatewayListener{Gateway: attachment.Gateway, Listener: attachment.Listener},
+					hostname:  hostname,
+				})
+			}
+		}
+	}
+
+	var result []IngressHostnameCollision
+	for _, ingress := range ingresses {
+		var hosts []string
+		for _, rule := range ingress.Spec.Rules {
+			if rule.Host != "" && !slices.Contains(hosts, rule.Host) {
+				hosts = append(hosts, rule.Host)
+			}
+		}
+		for _, host := range hosts {
+			for _, s := range served {
+				if !relations.HostnamesMatch(s.hostname, host) {
+					continue
+				}
+				result = append(result, IngressHostnameCollision{
+					Ingress:   client.ObjectKeyFromObject(&ingress),
+					Host:      host,
+					HTTPRoute: s.httpRoute,
+					Listener:  s.listener,
+					Hostname:  s.hostname,
+				})
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Ingress != b.Ingress {
+			return a.Ingress.String() < b.Ingress.String()
+		}
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		if a.HTTPRoute != b.HTTPRoute {
+			return a.HTTPRoute.String() < b.HTTPRoute.String()
+		}
+		return a.Listener.String() < b.Listener.String()
+	})
+	return result
+}
+
+// fetchIngresses lists the Ingresses of all namespaces. No Ingresses are
+// returned if the cluster does not serve them.
+func (d Discoverer) fetchIngresses(ctx context.Context) ([]networkingv1.Ingress, error) {
+	gvr := common.IngressesPermission.GroupVersionResource(networkingv1.SchemeGroupVersion.Version)
+	ingressListUnstructured, err := d.K8sClients.DC.Resource(gvr).List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Ingresses: %v", err)
+	}
+	ingressList := &networkingv1.IngressList{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ingressListUnstructured.UnstructuredContent(), ingressList); err != nil {
+		return nil, fmt.Errorf("failed to convert unstructured IngressList to structured: %v", err)
+	}
+	return ingressList.Items, nil
+}
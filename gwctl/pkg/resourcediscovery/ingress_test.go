@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestDiscoverIngressHostnameCollisions(t *testing.T) {
+	ingress := func(namespace, name string, hosts ...string) *networkingv1.Ingress {
+		ingress := &networkingv1.Ingress{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: networkingv1.SchemeGroupVersion.String(),
+				Kind:       "Ingress",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+		for _, host := range hosts {
+			ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{Host: host})
+		}
+		return ingress
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		common.NamespaceForTest("legacy"),
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "edge-gw",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{{
+					Name:     "https",
+					Protocol: gatewayv1.HTTPSProtocolType,
+					Port:     443,
+					Hostname: common.PtrTo(gatewayv1.Hostname("*.example.com")),
+				}},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "edge-gw"}},
+				},
+				Hostnames: []gatewayv1.Hostname{"shop.example.com"},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "catch-all",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "edge-gw"}},
+				},
+			},
+		},
+		// shop.example.com collides with both HTTPRoutes, while the rule
+		// without a host and the host of another domain do not collide.
+		ingress("legacy", "shop", "shop.example.com", "", "shop.example.org"),
+		// The wildcard host overlaps the hostnames served by both HTTPRoutes.
+		ingress("legacy", "wildcard", "*.example.com"),
+		ingress("legacy", "unrelated", "intranet.example.net"),
+	}
+	discoverer := Discoverer{K8sClients: common.MustClientsForTest(t, objects...)}
+
+	got, err := discoverer.DiscoverIngressHostnameCollisions()
+	if err != nil {
+		t.Fatalf("DiscoverIngressHostnameCollisions() returned an unexpected error: %v", err)
+	}
+
+	listener := GatewayListener{Gateway: types.NamespacedName{Namespace: "default", Name: "edge-gw"}, Listener: "https"}
+	want := []IngressHostnameCollision{
+		{Ingress: types.NamespacedName{Namespace: "legacy", Name: "shop"}, Host: "shop.example.com", HTTPRoute: types.NamespacedName{Namespace: "default", Name: "catch-all"}, Listener: listener, Hostname: "*.example.com"},
+		{Ingress: types.NamespacedName{Namespace: "legacy", Name: "shop"}, Host: "shop.example.com", HTTPRoute: types.NamespacedName{Namespace: "default", Name: "shop"}, Listener: listener, Hostname: "shop.example.com"},
+		{Ingress: types.NamespacedName{Namespace: "legacy", Name: "wildcard"}, Host: "*.example.com", HTTPRoute: types.NamespacedName{Namespace: "default", Name: "catch-all"}, Listener: listener, Hostname: "*.example.com"},
+		{Ingress: types.NamespacedName{Namespace: "legacy", Name: "wildcard"}, Host: "*.example.com", HTTPRoute: types.NamespacedName{Namespace: "default", Name: "shop"}, Listener: listener, Hostname: "shop.example.com"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DiscoverIngressHostnameCollisions() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
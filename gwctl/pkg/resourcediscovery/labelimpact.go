@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// AttachmentChange describes a listener which a route attaches to either before
+// or after a change of labels, but not both.
+type AttachmentChange struct {
+	// RouteKind is the kind of the route, like HTTPRoute or TCPRoute.
+	RouteKind string
+	Route     types.NamespacedName
+	Gateway   types.NamespacedName
+	Listener  gatewayv1.SectionName
+	// Attached is true if the route attaches to the listener only after the
+	// change, and false if it no longer attaches after the change.
+	Attached bool
+}
+
+func (a AttachmentChange) String() string {
+	if a.Attached {
+		return fmt.Sprintf("%v %v would start attaching to listener %v of Gateway %v", a.RouteKind, a.Route, a.Listener, a.Gateway)
+	}
+	return fmt.Sprintf("%v %v would no longer attach to listener %v of Gateway %v", a.RouteKind, a.Route, a.Listener, a.Gateway)
+}
+
+// labelImpactRoute is the part of a route which decides the listeners it
+// attaches to.
+type labelImpactRoute struct {
+	kind       string
+	name       types.NamespacedName
+	parentRefs []gatewayv1.ParentReference
+	hostnames  []gatewayv1.Hostname
+}
+
+// NamespaceLabelChangeImpact returns the listeners which the HTTPRoutes and
+// routes of otherRouteKinds in the Namespace would start or stop attaching to if
+// the labels of the Namespace were replaced with newLabels. Labels of a
+// Namespace are the only labels which Gateway API selects resources by, through
+// the allowedRoutes of listeners; Policies attach to their targets by name, so
+// changing labels does not change which resources they attach to. Route kinds
+// which are not installed in the cluster are skipped.
+func (d Discoverer) NamespaceLabelChangeImpact(namespace string, newLabels map[string]string) ([]AttachmentChange, error) {
+	ctx := context.Background()
+	namespaceLabels, err := d.fetchNamespaceLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gateways, err := d.fetchGateways(ctx, Filter{ /* all Gateways */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	httpRoutes, err := d.fetchHTTPRoutes(ctx, Filter{Namespace: namespace, Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	var routes []labelImpactRoute
+	for _, httpRoute := range httpRoutes {
+		routes = append(routes, labelImpactRoute{
+			kind:       "HTTPRoute",
+			name:       client.ObjectKeyFromObject(&httpRoute),
+			parentRefs: httpRoute.Spec.ParentRefs,
+			hostnames:  httpRoute.Spec.Hostnames,
+		})
+	}
+	for _, routeKind := range otherRouteKinds {
+		routeList, err := d.K8sClients.DC.Resource(routeKind.gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %vs: %v", routeKind.kind, err)
+		}
+		for _, route := range routeList.Items {
+			spec, _, _ := unstructured.NestedMap(route.Object, "spec")
+			routeSpec := struct {
+				gatewayv1.CommonRouteSpec `json:",inline"`
+				Hostnames                 []gatewayv1.Hostname `json:"hostnames,omitempty"`
+			}{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(spec, &routeSpec); err != nil {
+				return nil, fmt.Errorf("failed to convert the spec of %v %v/%v: %v", routeKind.kind, route.GetNamespace(), route.GetName(), err)
+			}
+			routes = append(routes, labelImpactRoute{
+				kind:       routeKind.kind,
+				name:       client.ObjectKeyFromObject(&route),
+				parentRefs: routeSpec.ParentRefs,
+				hostnames:  routeSpec.Hostnames,
+			})
+		}
+	}
+
+	gatewaysByName := make(map[types.NamespacedName]*gatewayv1.Gateway)
+	for i := range gateways {
+		gatewaysByName[client.ObjectKeyFromObject(&gateways[i])] = &gateways[i]
+	}
+	changedNamespaceLabels := maps.Clone(namespaceLabels)
+	changedNamespaceLabels[namespace] = newLabels
+
+	var result []AttachmentChange
+	for _, route := range routes {
+		before := listenerAttachmentsForRoute(route.kind, namespace, route.parentRefs, route.hostnames, gatewaysByName, namespaceLabels)
+		after := listenerAttachmentsForRoute(route.kind, namespace, route.parentRefs, route.hostnames, gatewaysByName, changedNamespaceLabels)
+		// Only the labels differ, so both list the same listeners in the same
+		// order.
+		for i := range before {
+			attachedBefore, attachedAfter := before[i].Reason == "", after[i].Reason == ""
+			if attachedBefore == attachedAfter {
+				continue
+			}
+			result = append(result, AttachmentChange{
+				RouteKind: route.kind,
+				Route:     route.name,
+				Gateway:   before[i].Gateway,
+				Listener:  before[i].Listener,
+				Attached:  attachedAfter,
+			})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+)
+
+func TestNamespaceLabelChangeImpact(t *testing.T) {
+	objects := []runtime.Object{
+		common.NamespaceForTest("infra"),
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{"tier": "internal"},
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "edge-gw",
+				Namespace: "infra",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				Listeners: []gatewayv1.Listener{
+					{
+						Name:     "internal",
+						Protocol: gatewayv1.HTTPProtocolType,
+						Port:     8080,
+						AllowedRoutes: &gatewayv1.AllowedRoutes{
+							Namespaces: &gatewayv1.RouteNamespaces{
+								From:     common.PtrTo(gatewayv1.NamespacesFromSelector),
+								Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "internal"}},
+							},
+						},
+					},
+					{
+						Name:     "external",
+						Protocol: gatewayv1.HTTPProtocolType,
+						Port:     80,
+						AllowedRoutes: &gatewayv1.AllowedRoutes{
+							Namespaces: &gatewayv1.RouteNamespaces{
+								From:     common.PtrTo(gatewayv1.NamespacesFromSelector),
+								Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "external"}},
+							},
+						},
+					},
+					{
+						Name:     "all",
+						Protocol: gatewayv1.HTTPProtocolType,
+						Port:     9000,
+						AllowedRoutes: &gatewayv1.AllowedRoutes{
+							Namespaces: &gatewayv1.RouteNamespaces{From: common.PtrTo(gatewayv1.NamespacesFromAll)},
+						},
+					},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "route-a",
+				Namespace: "team-a",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "edge-gw", Namespace: common.PtrTo(gatewayv1.Namespace("infra"))}},
+				},
+			},
+		},
+		&gatewayv1.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "grpc-route-a",
+				Namespace: "team-a",
+			},
+			Spec: gatewayv1.GRPCRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "edge-gw", Namespace: common.PtrTo(gatewayv1.Namespace("infra")), SectionName: common.PtrTo(gatewayv1.SectionName("internal"))}},
+				},
+			},
+		},
+		// The listeners of edge-gw do not allow TCPRoutes, whatever the labels.
+		&gatewayv1alpha2.TCPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp-route-a",
+				Namespace: "team-a",
+			},
+			Spec: gatewayv1alpha2.TCPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "edge-gw", Namespace: common.PtrTo(gatewayv1.Namespace("infra"))}},
+				},
+			},
+		},
+	}
+	k8sClients := common.MustClientsForTest(t, objects...)
+	discoverer := NewDiscoverer(k8sClients, policymanager.New(k8sClients.DC))
+
+	testcases := []struct {
+		name      string
+		newLabels map[string]string
+		want      []string
+	}{
+		{
+			name:      "unchanged selector matches",
+			newLabels: map[string]string{"tier": "internal", "team": "a"},
+		},
+		{
+			name:      "selector of another listener matches",
+			newLabels: map[string]string{"tier": "external"},
+			want: []string{
+				"HTTPRoute team-a/route-a would no longer attach to listener internal of Gateway infra/edge-gw",
+				"HTTPRoute team-a/route-a would start attaching to listener external of Gateway infra/edge-gw",
+				"GRPCRoute team-a/grpc-route-a would no longer attach to listener internal of Gateway infra/edge-gw",
+			},
+		},
+		{
+			name: "labels removed",
+			want: []string{
+				"HTTPRoute team-a/route-a would no longer attach to listener internal of Gateway infra/edge-gw",
+				"GRPCRoute team-a/grpc-route-a would no longer attach to listener internal of Gateway infra/edge-gw",
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			changes, err := discoverer.NamespaceLabelChangeImpact("team-a", tc.newLabels)
+			if err != nil {
+				t.Fatalf("NamespaceLabelChangeImpact() returned an unexpected error: %v", err)
+			}
+			var got []string
+			for _, change := range changes {
+				got = append(got, change.String())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NamespaceLabelChangeImpact() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"sort"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+// ListenerView is a flattened view of a single listener of a Gateway, combining
+// its spec with its status.
+type ListenerView struct {
+	Gateway        common.ObjRef `json:"gateway"`
+	Name           string        `json:"name"`
+	Port           int32         `json:"port"`
+	Protocol       string        `json:"protocol"`
+	Hostname       string        `json:"hostname,omitempty"`
+	AttachedRoutes int32         `json:"attachedRoutes"`
+	// Programmed is the status of the Programmed condition of the listener, or
+	// "Unknown" if the listener has no such condition.
+	Programmed string `json:"programmed"`
+}
+
+// ListenersForGateways flattens the listeners of all Gateways in the
+// resourceModel. Listeners are sorted by Gateway and then by the order in which
+// they appear within the Gateway.
+func ListenersForGateways(resourceModel *ResourceModel) []ListenerView {
+	var result []ListenerView
+	for _, gatewayNode := range sortedGatewayNodes(resourceModel) {
+		gateway := gatewayNode.Gateway
+		listenerStatuses := make(map[gatewayv1.SectionName]gatewayv1.ListenerStatus)
+		for _, listenerStatus := range gateway.Status.Listeners {
+			listenerStatuses[listenerStatus.Name] = listenerStatus
+		}
+
+		for _, listener := range gateway.Spec.Listeners {
+			view := ListenerView{
+				Gateway:    gatewayRef(gatewayNode),
+				Name:       string(listener.Name),
+				Port:       int32(listener.Port),
+				Protocol:   string(listener.Protocol),
+				Programmed: "Unknown",
+			}
+			if listener.Hostname != nil {
+				view.Hostname = string(*listener.Hostname)
+			}
+			if listenerStatus, ok := listenerStatuses[listener.Name]; ok {
+				view.AttachedRoutes = listenerStatus.AttachedRoutes
+				if condition := common.FindStandardCondition(listenerStatus.Conditions, string(gatewayv1.ListenerConditionProgrammed)); condition != nil {
+					view.Programmed = string(condition.Status)
+				}
+			}
+			result = append(result, view)
+		}
+	}
+	return result
+}
+
+// sortedGatewayNodes returns the Gateways in the resourceModel sorted by
+// namespace and name.
+func sortedGatewayNodes(resourceModel *ResourceModel) []*GatewayNode {
+	gatewayNodes := make([]*GatewayNode, 0, len(resourceModel.Gateways))
+	for _, gatewayNode := range resourceModel.Gateways {
+		gatewayNodes = append(gatewayNodes, gatewayNode)
+	}
+	sort.Slice(gatewayNodes, func(i, j int) bool {
+		a, b := gatewayNodes[i].Gateway, gatewayNodes[j].Gateway
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+	return gatewayNodes
+}
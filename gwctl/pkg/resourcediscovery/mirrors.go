@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// MirrorFilterIndex identifies a RequestMirror filter within an HTTPRoute by
+// the index of the rule and the index of the filter within that rule.
+type MirrorFilterIndex struct {
+	Rule   int
+	Filter int
+}
+
+// MirrorSampling is the fraction of requests which are mirrored by a
+// RequestMirror filter.
+type MirrorSampling struct {
+	Numerator   int64
+	Denominator int64
+}
+
+// fullMirrorSampling is used for RequestMirror filters which do not specify
+// the fraction or percent fields, including those of older API versions which
+// do not support them.
+var fullMirrorSampling = MirrorSampling{Numerator: 100, Denominator: 100}
+
+// Percent returns the percentage of requests which are mirrored.
+func (m MirrorSampling) Percent() float64 {
+	if m.Denominator == 0 {
+		return 0
+	}
+	return float64(m.Numerator) * 100 / float64(m.Denominator)
+}
+
+func (m MirrorSampling) String() string {
+	return strconv.FormatFloat(m.Percent(), 'f', -1, 64) + "%"
+}
+
+// ParseMirrorSampling parses the sampling of a RequestMirror filter from its
+// unstructured representation. Both the integer "percent" field and the
+// "fraction" struct are supported. If neither is present, all requests are
+// mirrored.
+func ParseMirrorSampling(requestMirror map[string]interface{}) (MirrorSampling, error) {
+	if percent, found, err := unstructured.NestedInt64(requestMirror, "percent"); err != nil {
+		return MirrorSampling{}, fmt.Errorf("invalid percent: %v", err)
+	} else if found {
+		if percent < 0 || percent > 100 {
+			return MirrorSampling{}, fmt.Errorf("percent %d must be between 0 and 100", percent)
+		}
+		return MirrorSampling{Numerator: percent, Denominator: 100}, nil
+	}
+
+	fraction, found, err := unstructured.NestedMap(requestMirror, "fraction")
+	if err != nil {
+		return MirrorSampling{}, fmt.Errorf("invalid fraction: %v", err)
+	}
+	if !found {
+		return fullMirrorSampling, nil
+	}
+	numerator, _, err := unstructured.NestedInt64(fraction, "numerator")
+	if err != nil {
+		return MirrorSampling{}, fmt.Errorf("invalid fraction numerator: %v", err)
+	}
+	denominator, found, err := unstructured.NestedInt64(fraction, "denominator")
+	if err != nil {
+		return MirrorSampling{}, fmt.Errorf("invalid fraction denominator: %v", err)
+	}
+	if !found {
+		denominator = 100
+	}
+	if denominator <= 0 {
+		return MirrorSampling{}, fmt.Errorf("fraction denominator %d must be greater than 0", denominator)
+	}
+	if numerator < 0 || numerator > denominator {
+		return MirrorSampling{}, fmt.Errorf("fraction numerator %d must be between 0 and the denominator %d", numerator, denominator)
+	}
+	return MirrorSampling{Numerator: numerator, Denominator: denominator}, nil
+}
+
+// mirrorSamplingsForHTTPRoute returns the sampling of every RequestMirror
+// filter in the unstructured HTTPRoute. Filters with an invalid sampling are
+// omitted and reported through the returned errors instead.
+func mirrorSamplingsForHTTPRoute(httpRoute unstructured.Unstructured) (map[MirrorFilterIndex]MirrorSampling, map[MirrorFilterIndex]error) {
+	result := make(map[MirrorFilterIndex]MirrorSampling)
+	errs := make(map[MirrorFilterIndex]error)
+
+	rules, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+	for i, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		filters, _, _ := unstructured.NestedSlice(ruleMap, "filters")
+		for j, filter := range filters {
+			filterMap, ok := filter.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			requestMirror, found, _ := unstructured.NestedMap(filterMap, "requestMirror")
+			if !found {
+				continue
+			}
+			sampling, err := ParseMirrorSampling(requestMirror)
+			if err != nil {
+				errs[MirrorFilterIndex{Rule: i, Filter: j}] = err
+				continue
+			}
+			result[MirrorFilterIndex{Rule: i, Filter: j}] = sampling
+		}
+	}
+	return result, errs
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseMirrorSampling(t *testing.T) {
+	testcases := []struct {
+		name          string
+		requestMirror map[string]interface{}
+		want          MirrorSampling
+		wantPercent   string
+		wantErr       bool
+	}{
+		{
+			name:          "no sampling fields mirrors all requests",
+			requestMirror: map[string]interface{}{},
+			want:          MirrorSampling{Numerator: 100, Denominator: 100},
+			wantPercent:   "100%",
+		},
+		{
+			name:          "percent",
+			requestMirror: map[string]interface{}{"percent": int64(25)},
+			want:          MirrorSampling{Numerator: 25, Denominator: 100},
+			wantPercent:   "25%",
+		},
+		{
+			name: "fraction",
+			requestMirror: map[string]interface{}{
+				"fraction": map[string]interface{}{"numerator": int64(1), "denominator": int64(8)},
+			},
+			want:        MirrorSampling{Numerator: 1, Denominator: 8},
+			wantPercent: "12.5%",
+		},
+		{
+			name: "fraction with default denominator",
+			requestMirror: map[string]interface{}{
+				"fraction": map[string]interface{}{"numerator": int64(40)},
+			},
+			want:        MirrorSampling{Numerator: 40, Denominator: 100},
+			wantPercent: "40%",
+		},
+		{
+			name:          "percent out of range",
+			requestMirror: map[string]interface{}{"percent": int64(150)},
+			wantErr:       true,
+		},
+		{
+			name: "fraction numerator greater than denominator",
+			requestMirror: map[string]interface{}{
+				"fraction": map[string]interface{}{"numerator": int64(5), "denominator": int64(4)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fraction with zero denominator",
+			requestMirror: map[string]interface{}{
+				"fraction": map[string]interface{}{"numerator": int64(0), "denominator": int64(0)},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseMirrorSampling(tc.requestMirror)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ParseMirrorSampling() = %v; want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMirrorSampling() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseMirrorSampling() returned unexpected diff (-want +got):\n%v", diff)
+			}
+			if got.String() != tc.wantPercent {
+				t.Errorf("MirrorSampling.String() = %q; want %q", got.String(), tc.wantPercent)
+			}
+		})
+	}
+}
+
+func TestMirrorSamplingsForHTTPRoute(t *testing.T) {
+	httpRoute := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{
+						"filters": []interface{}{
+							map[string]interface{}{"type": "RequestHeaderModifier"},
+							map[string]interface{}{
+								"type":          "RequestMirror",
+								"requestMirror": map[string]interface{}{"percent": int64(10)},
+							},
+						},
+					},
+					map[string]interface{}{
+						"filters": []interface{}{
+							map[string]interface{}{
+								"type":          "RequestMirror",
+								"requestMirror": map[string]interface{}{"percent": int64(-1)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got, errs := mirrorSamplingsForHTTPRoute(httpRoute)
+	want := map[MirrorFilterIndex]MirrorSampling{
+		{Rule: 0, Filter: 1}: {Numerator: 10, Denominator: 100},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mirrorSamplingsForHTTPRoute() returned unexpected diff (-want +got):\n%v", diff)
+	}
+	if _, ok := errs[MirrorFilterIndex{Rule: 1, Filter: 0}]; !ok || len(errs) != 1 {
+		t.Errorf("mirrorSamplingsForHTTPRoute() returned errors %v; want a single error for rule 1, filter 0", errs)
+	}
+}
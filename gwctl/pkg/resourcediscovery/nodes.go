@@ -20,13 +20,19 @@ import (
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
@@ -49,6 +55,7 @@ type (
 	namespaceID      resourceID
 	gatewayID        resourceID
 	httpRouteID      resourceID
+	routeID          resourceID
 	backendID        resourceID
 	referenceGrantID resourceID
 	policyID         resourceID
@@ -83,6 +90,14 @@ func HTTPRouteID(namespace, name string) httpRouteID { //nolint:revive
 	return httpRouteID(resourceID{Namespace: namespace, Name: name})
 }
 
+// RouteID returns an ID for a route of any kind.
+func RouteID(kind, namespace, name string) routeID { //nolint:revive
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return routeID(resourceID{Kind: kind, Namespace: namespace, Name: name})
+}
+
 // BackendID returns an ID for a Backend.
 func BackendID(group, kind, namespace, name string) backendID { //nolint:revive
 	return backendID(resourceID{
@@ -164,6 +179,9 @@ type GatewayNode struct {
 	GatewayClass *GatewayClassNode
 	// HTTPRoutes stores HTTPRoutes attached to this Gateway.
 	HTTPRoutes map[httpRouteID]*HTTPRouteNode
+	// AttachedRoutesByKind counts the routes of kinds other than HTTPRoute (like
+	// GRPCRoute or TCPRoute) which are attached to this Gateway, keyed by kind.
+	AttachedRoutesByKind map[string]int
 	// Policies stores Policies directly applied to the Gateway.
 	Policies map[policyID]*PolicyNode
 	// EffectivePolicies reflects the effective policies applicable to this Gateway,
@@ -211,18 +229,32 @@ type HTTPRouteNode struct {
 	// EffectivePolicies reflects the effective policies applicable to this
 	// HTTPRoute, mapped per Gateway for context-specific enforcement.
 	EffectivePolicies map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy
+	// MirrorSamplings stores the sampling of each RequestMirror filter in the
+	// HTTPRoute.
+	MirrorSamplings map[MirrorFilterIndex]MirrorSampling
+	// MirrorSamplingErrors stores why the sampling of a RequestMirror filter is
+	// invalid, for the filters which are missing from MirrorSamplings.
+	MirrorSamplingErrors map[MirrorFilterIndex]error
+	// Attachments lists whether the HTTPRoute attaches to each listener
+	// selected by its parentRefs, as evaluated from the spec of the Gateways.
+	Attachments []ListenerAttachment
+	// AttachmentsUnknown is true if the Attachments could not be evaluated,
+	// like when the Namespaces selected by some listener cannot be listed.
+	AttachmentsUnknown bool
 	// Errors contains any errorrs associated with this resource.
 	Errors []error
 }
 
 func NewHTTPRouteNode(httpRoute *gatewayv1.HTTPRoute) *HTTPRouteNode {
 	return &HTTPRouteNode{
-		HTTPRoute:         httpRoute,
-		Gateways:          make(map[gatewayID]*GatewayNode),
-		Backends:          make(map[backendID]*BackendNode),
-		Policies:          make(map[policyID]*PolicyNode),
-		EffectivePolicies: make(map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy),
-		Errors:            []error{},
+		HTTPRoute:            httpRoute,
+		Gateways:             make(map[gatewayID]*GatewayNode),
+		Backends:             make(map[backendID]*BackendNode),
+		Policies:             make(map[policyID]*PolicyNode),
+		EffectivePolicies:    make(map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy),
+		MirrorSamplings:      make(map[MirrorFilterIndex]MirrorSampling),
+		MirrorSamplingErrors: make(map[MirrorFilterIndex]error),
+		Errors:               []error{},
 	}
 }
 
@@ -236,8 +268,121 @@ func (h *HTTPRouteNode) ID() httpRouteID { //nolint:revive
 	return HTTPRouteID(h.HTTPRoute.GetNamespace(), h.HTTPRoute.GetName())
 }
 
+func (h *HTTPRouteNode) RouteID() routeID { //nolint:revive
+	return RouteID("HTTPRoute", h.HTTPRoute.GetNamespace(), h.HTTPRoute.GetName())
+}
+
+func (h *HTTPRouteNode) BackendRefs() []common.ObjRef {
+	return relations.FindBackendRefsForHTTPRoute(*h.HTTPRoute)
+}
+
+func (h *HTTPRouteNode) EffectivePoliciesByGateway() map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy {
+	return h.EffectivePolicies
+}
+
+// RouteNode is implemented by the nodes of every route kind. It allows the
+// relationships between Backends and routes, and the policies inherited by
+// Backends through routes, to be computed regardless of the kind of route.
+type RouteNode interface {
+	ClientObject() client.Object
+	// RouteID returns the ID of the route, which includes its kind.
+	RouteID() routeID
+	// BackendRefs returns the unique Backends referenced by the route.
+	BackendRefs() []common.ObjRef
+	// EffectivePoliciesByGateway returns the effective policies applicable to
+	// the route, mapped per Gateway.
+	EffectivePoliciesByGateway() map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy
+}
+
+// OtherRouteNode models the relationships and dependencies of a route of a kind
+// other than HTTPRoute, like GRPCRoute or TCPRoute. Such routes are only
+// modelled for the Backends they reference.
+type OtherRouteNode struct {
+	// Route references the actual route resource.
+	Route *unstructured.Unstructured
+
+	// Namespace is the namespace of the route.
+	Namespace *NamespaceNode
+	// Gateways stores Gateways which this route is attached to.
+	Gateways map[gatewayID]*GatewayNode
+	// Backends lists Backends serving as target endpoints for traffic through
+	// this route.
+	Backends map[backendID]*BackendNode
+	// Policies stores Policies directly applied to the route.
+	Policies map[policyID]*PolicyNode
+	// EffectivePolicies reflects the effective policies applicable to this
+	// route, mapped per Gateway for context-specific enforcement.
+	EffectivePolicies map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy
+	// Errors contains any errorrs associated with this resource.
+	Errors []error
+}
+
+func NewOtherRouteNode(route *unstructured.Unstructured) *OtherRouteNode {
+	return &OtherRouteNode{
+		Route:             route,
+		Gateways:          make(map[gatewayID]*GatewayNode),
+		Backends:          make(map[backendID]*BackendNode),
+		Policies:          make(map[policyID]*PolicyNode),
+		EffectivePolicies: make(map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy),
+		Errors:            []error{},
+	}
+}
+
+func (o OtherRouteNode) ClientObject() client.Object { return o.Route }
+
+func (o *OtherRouteNode) RouteID() routeID { //nolint:revive
+	if o.Route == nil {
+		klog.V(0).ErrorS(nil, "returning empty ID since route is nil")
+		return routeID(resourceID{})
+	}
+	return RouteID(o.Route.GetKind(), o.Route.GetNamespace(), o.Route.GetName())
+}
+
+// ParentRefs returns the parentRefs of the route.
+func (o *OtherRouteNode) ParentRefs() []gatewayv1.ParentReference {
+	spec, _, _ := unstructured.NestedMap(o.Route.Object, "spec")
+	commonRouteSpec := gatewayv1.CommonRouteSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(spec, &commonRouteSpec); err != nil {
+		klog.V(1).ErrorS(err, "Failed to convert route spec", "route", o.RouteID())
+		return nil
+	}
+	return commonRouteSpec.ParentRefs
+}
+
+// BackendRefs returns the Backends referenced by the rules of the route. All
+// route kinds share the fields of BackendObjectReference within their
+// backendRefs, so these are read from the unstructured route.
+func (o *OtherRouteNode) BackendRefs() []common.ObjRef {
+	rules, _, _ := unstructured.NestedSlice(o.Route.Object, "spec", "rules")
+	var backendRefs []gatewayv1.BackendObjectReference
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		refs, _, _ := unstructured.NestedSlice(ruleMap, "backendRefs")
+		for _, ref := range refs {
+			refMap, ok := ref.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			backendRef := gatewayv1.BackendObjectReference{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(refMap, &backendRef); err != nil {
+				klog.V(1).ErrorS(err, "Failed to convert backendRef", "route", o.RouteID())
+				continue
+			}
+			backendRefs = append(backendRefs, backendRef)
+		}
+	}
+	return relations.FindBackendRefsForRoute(o.Route.GetNamespace(), backendRefs)
+}
+
+func (o *OtherRouteNode) EffectivePoliciesByGateway() map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy {
+	return o.EffectivePolicies
+}
+
 // BackendNode models the relationships and dependencies of a Backend resource,
-// representing the ultimate destination for traffic directed by HTTPRoutes. It
+// representing the ultimate destination for traffic directed by routes. It
 // serves as a generic abstraction, encompassing various underlying resource
 // types that can act as traffic targets, such as Services, ServiceImports, etc.
 type BackendNode struct {
@@ -246,12 +391,22 @@ type BackendNode struct {
 
 	// Namespace is the namespace of the Backend.
 	Namespace *NamespaceNode
-	// HTTPRoutes lists HTTPRoutes that reference this Backend as a target.
-	HTTPRoutes map[httpRouteID]*HTTPRouteNode
+	// Routes lists routes of every kind that reference this Backend as a
+	// target.
+	Routes map[routeID]RouteNode
 	// Policies stores Policies directly applied to the Backend.
 	Policies map[policyID]*PolicyNode
 	// ReferenceGrants contains ReferenceGrants that expose this Backend.
 	ReferenceGrants map[referenceGrantID]*ReferenceGrantNode
+	// BackendTLSPolicies contains the BackendTLSPolicies which target this
+	// Backend, sorted by name.
+	BackendTLSPolicies []gatewayv1alpha3.BackendTLSPolicy
+	// BackendLBPolicies contains the BackendLBPolicies which target this
+	// Backend, sorted by name.
+	BackendLBPolicies []gatewayv1alpha2.BackendLBPolicy
+	// EndpointSlices contains the EndpointSlices of the Backend if it is a
+	// Service.
+	EndpointSlices []discoveryv1.EndpointSlice
 	// EffectivePolicies reflects the effective policies applicable to this
 	// Backend, mapped per Gateway for context-specific enforcement.
 	EffectivePolicies map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy
@@ -262,7 +417,7 @@ type BackendNode struct {
 func NewBackendNode(backend *unstructured.Unstructured) *BackendNode {
 	return &BackendNode{
 		Backend:           backend,
-		HTTPRoutes:        make(map[httpRouteID]*HTTPRouteNode),
+		Routes:            make(map[routeID]RouteNode),
 		Policies:          make(map[policyID]*PolicyNode),
 		ReferenceGrants:   make(map[referenceGrantID]*ReferenceGrantNode),
 		EffectivePolicies: make(map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy),
@@ -285,6 +440,68 @@ func (b *BackendNode) ID() backendID { //nolint:revive
 	)
 }
 
+// RolesForRoute returns the roles in which the route references the Backend,
+// with the primary role first. Routes of kinds other than HTTPRoute are only
+// modelled for the Backends of their rules, which are in the primary role.
+func (b *BackendNode) RolesForRoute(routeNode RouteNode) []relations.BackendRefRole {
+	httpRouteNode, ok := routeNode.(*HTTPRouteNode)
+	if !ok {
+		return []relations.BackendRefRole{relations.BackendRefRolePrimary}
+	}
+	var roles []relations.BackendRefRole
+	for _, roleBackendRef := range relations.FindRoleBackendRefsForHTTPRoute(*httpRouteNode.HTTPRoute) {
+		backendRef := roleBackendRef.Backend
+		if BackendID(backendRef.Group, backendRef.Kind, backendRef.Namespace, backendRef.Name) == b.ID() {
+			roles = append(roles, roleBackendRef.Role)
+		}
+	}
+	return roles
+}
+
+// ServiceType returns the type of the Backend if it is a Service, defaulting to
+// ClusterIP like the API server does, or an empty string otherwise.
+func (b *BackendNode) ServiceType() corev1.ServiceType {
+	gvk := b.Backend.GroupVersionKind()
+	if gvk.Group != "" || gvk.Kind != "Service" {
+		return ""
+	}
+	serviceType, _, _ := unstructured.NestedString(b.Backend.Object, "spec", "type")
+	if serviceType == "" {
+		return corev1.ServiceTypeClusterIP
+	}
+	return corev1.ServiceType(serviceType)
+}
+
+// IsHeadlessService returns true if the Backend is a Service of type ClusterIP
+// without a cluster IP.
+func (b *BackendNode) IsHeadlessService() bool {
+	clusterIP, _, _ := unstructured.NestedString(b.Backend.Object, "spec", "clusterIP")
+	return b.ServiceType() == corev1.ServiceTypeClusterIP && clusterIP == corev1.ClusterIPNone
+}
+
+// Resolve resolves the Backend through its EndpointSlices, for a backendRef
+// which specifies the port, if any.
+func (b *BackendNode) Resolve(port *gatewayv1.PortNumber) relations.BackendResolution {
+	ref := common.ObjRef{
+		Group:     b.Backend.GroupVersionKind().Group,
+		Kind:      b.Backend.GroupVersionKind().Kind,
+		Namespace: b.Backend.GetNamespace(),
+		Name:      b.Backend.GetName(),
+	}
+	return relations.ResolveBackend(ref, port, b.service(), b.EndpointSlices)
+}
+
+// service returns the Backend as a Service, or nil if it cannot be converted
+// to one.
+func (b *BackendNode) service() *corev1.Service {
+	service := &corev1.Service{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(b.Backend.UnstructuredContent(), service); err != nil {
+		klog.V(1).ErrorS(err, "Failed to convert Backend to Service", "backend", client.ObjectKeyFromObject(b.Backend))
+		return nil
+	}
+	return service
+}
+
 // NamespaceNode models the relationships and dependencies of a Namespace.
 type NamespaceNode struct {
 	// NamespaceName identifies the Namespace.
@@ -300,6 +517,11 @@ type NamespaceNode struct {
 	Policies map[policyID]*PolicyNode
 }
 
+// IsTerminating returns true if the Namespace is being deleted.
+func (n *NamespaceNode) IsTerminating() bool {
+	return n.Namespace.Status.Phase == corev1.NamespaceTerminating || n.Namespace.GetDeletionTimestamp() != nil
+}
+
 func NewNamespaceNode(namespace corev1.Namespace) *NamespaceNode {
 	if namespace.Name == "" {
 		namespace.Name = metav1.NamespaceDefault
@@ -367,6 +589,10 @@ type PolicyNode struct {
 	// attached. It's nil if the policy is not associated with a specific
 	// HTTPRoute.
 	HTTPRoute *HTTPRouteNode
+	// OtherRoute references the OtherRouteNode to which the policy is directly
+	// attached. It's nil if the policy is not associated with a specific route
+	// of another kind.
+	OtherRoute *OtherRouteNode
 	// Backend references the BackendNode to which the policy is directly
 	// attached. It's nil if the policy is not associated with a specific Backend.
 	Backend *BackendNode
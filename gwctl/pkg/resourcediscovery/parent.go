@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"fmt"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+)
+
+// RestrictHTTPRoutesToParent limits the HTTPRoutes in the resourceModel to the
+// parent Gateway. HTTPRoutes which do not reference the parent are removed.
+// For the remaining ones, the parentRefs, the status, the attached Gateways,
+// the effective policies and the errors about attaching to Gateways are limited
+// to those of the parent. An error is returned if no HTTPRoute references the
+// parent.
+func (rm *ResourceModel) RestrictHTTPRoutesToParent(parent common.ObjRef) error {
+	parentID := GatewayID(parent.Namespace, parent.Name)
+	for httpRouteID, httpRouteNode := range rm.HTTPRoutes {
+		httpRoute := httpRouteNode.HTTPRoute.DeepCopy()
+		namespace := httpRoute.GetNamespace()
+
+		var parentRefs []gatewayv1.ParentReference
+		for _, parentRef := range httpRoute.Spec.ParentRefs {
+			if relations.ParentRefMatches(namespace, parentRef, parent) {
+				parentRefs = append(parentRefs, parentRef)
+			}
+		}
+		if len(parentRefs) == 0 {
+			delete(rm.HTTPRoutes, httpRouteID)
+			continue
+		}
+		httpRoute.Spec.ParentRefs = parentRefs
+
+		var parentStatuses []gatewayv1.RouteParentStatus
+		for _, parentStatus := range httpRoute.Status.Parents {
+			if relations.ParentRefMatches(namespace, parentStatus.ParentRef, parent) {
+				parentStatuses = append(parentStatuses, parentStatus)
+			}
+		}
+		httpRoute.Status.Parents = parentStatuses
+		httpRouteNode.HTTPRoute = httpRoute
+
+		for gatewayID := range httpRouteNode.Gateways {
+			if gatewayID != parentID {
+				delete(httpRouteNode.Gateways, gatewayID)
+			}
+		}
+		for gatewayID := range httpRouteNode.EffectivePolicies {
+			if gatewayID != parentID {
+				delete(httpRouteNode.EffectivePolicies, gatewayID)
+			}
+		}
+
+		var errs []error
+		for _, err := range httpRouteNode.Errors {
+			if referredObject, ok := referredGateway(err); ok && referredObject != parent {
+				continue
+			}
+			errs = append(errs, err)
+		}
+		httpRouteNode.Errors = errs
+	}
+
+	if len(rm.HTTPRoutes) == 0 {
+		return fmt.Errorf("no HTTPRoute references the parent %v %v/%v", parent.Kind, parent.Namespace, parent.Name)
+	}
+	return nil
+}
+
+// referredGateway returns the Gateway referenced by the error, if it is about
+// a reference to some Gateway.
+func referredGateway(err error) (common.ObjRef, bool) {
+	var referredObject common.ObjRef
+	switch err := err.(type) {
+	case ReferenceToNonExistentResourceError:
+		referredObject = err.ReferredObject
+	case ReferenceNotPermittedError:
+		referredObject = err.ReferredObject
+	default:
+		return common.ObjRef{}, false
+	}
+	if referredObject.Kind != "Gateway" {
+		return common.ObjRef{}, false
+	}
+	referredObject.Group = gatewayv1.GroupName
+	return referredObject, true
+}
+
+// findDuplicateParentRefsForHTTPRoutes reports the parents which HTTPRoutes in
+// the resourceModel list more than once in their parentRefs.
+func findDuplicateParentRefsForHTTPRoutes(resourceModel *ResourceModel) {
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		for _, parent := range relations.FindDuplicateParentRefs(*httpRouteNode.HTTPRoute) {
+			httpRouteNode.Errors = append(httpRouteNode.Errors, DuplicateParentRefError{Parent: parent})
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestRestrictHTTPRoutesToParent(t *testing.T) {
+	edgeGatewayRef := gatewayv1.ParentReference{Name: "edge-gw", Namespace: common.PtrTo(gatewayv1.Namespace("infra"))}
+	missingGatewayRef := gatewayv1.ParentReference{Name: "missing-gw"}
+	internalGatewayRef := gatewayv1.ParentReference{Name: "internal-gw"}
+
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		common.NamespaceForTest("infra"),
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "edge-gw",
+				Namespace: "infra",
+			},
+			Spec: gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "internal-gw",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{edgeGatewayRef, missingGatewayRef, internalGatewayRef},
+				},
+			},
+			Status: gatewayv1.HTTPRouteStatus{
+				RouteStatus: gatewayv1.RouteStatus{
+					Parents: []gatewayv1.RouteParentStatus{
+						{ParentRef: edgeGatewayRef, ControllerName: "example.net/gateway-controller"},
+						{ParentRef: internalGatewayRef, ControllerName: "example.net/gateway-controller"},
+					},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bar-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{internalGatewayRef},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	parent := common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "infra", Name: "edge-gw"}
+	if err := resourceModel.RestrictHTTPRoutesToParent(parent); err != nil {
+		t.Fatalf("RestrictHTTPRoutesToParent(%v) returned an unexpected error: %v", parent, err)
+	}
+
+	wantHTTPRoutes := []string{"default/foo-httproute"}
+	var gotHTTPRoutes []string
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		gotHTTPRoutes = append(gotHTTPRoutes, httpRouteNode.HTTPRoute.GetNamespace()+"/"+httpRouteNode.HTTPRoute.GetName())
+	}
+	if diff := cmp.Diff(wantHTTPRoutes, gotHTTPRoutes); diff != "" {
+		t.Fatalf("Unexpected diff in HTTPRoutes (-want +got)=\n%v", diff)
+	}
+
+	httpRouteNode := resourceModel.HTTPRoutes[HTTPRouteID("default", "foo-httproute")]
+	if diff := cmp.Diff([]gatewayv1.ParentReference{edgeGatewayRef}, httpRouteNode.HTTPRoute.Spec.ParentRefs); diff != "" {
+		t.Errorf("Unexpected diff in parentRefs (-want +got)=\n%v", diff)
+	}
+	wantParentStatuses := []gatewayv1.RouteParentStatus{{ParentRef: edgeGatewayRef, ControllerName: "example.net/gateway-controller"}}
+	if diff := cmp.Diff(wantParentStatuses, httpRouteNode.HTTPRoute.Status.Parents); diff != "" {
+		t.Errorf("Unexpected diff in status parents (-want +got)=\n%v", diff)
+	}
+	var gotGateways []gatewayID
+	for gatewayID := range httpRouteNode.Gateways {
+		gotGateways = append(gotGateways, gatewayID)
+	}
+	if diff := cmp.Diff([]gatewayID{GatewayID("infra", "edge-gw")}, gotGateways); diff != "" {
+		t.Errorf("Unexpected diff in attached Gateways (-want +got)=\n%v", diff)
+	}
+	// The error about the reference to missing-gw is about some other parent.
+	if len(httpRouteNode.Errors) != 0 {
+		t.Errorf("Unexpected errors for HTTPRoute: %v", httpRouteNode.Errors)
+	}
+
+	parent = common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "default", Name: "unknown-gw"}
+	if err := resourceModel.RestrictHTTPRoutesToParent(parent); err == nil {
+		t.Errorf("RestrictHTTPRoutesToParent(%v) returned no error for a parent which is not referenced", parent)
+	}
+}
+
+func TestDiscoverResourcesForHTTPRoute_DuplicateParentRefs(t *testing.T) {
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo-httproute",
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{
+						{Name: "foo-gateway"},
+						{Name: "foo-gateway", Namespace: common.PtrTo(gatewayv1.Namespace("default"))},
+					},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	httpRouteNode := resourceModel.HTTPRoutes[HTTPRouteID("default", "foo-httproute")]
+	var got []string
+	for _, err := range httpRouteNode.Errors {
+		if _, ok := err.(DuplicateParentRefError); ok {
+			got = append(got, err.Error())
+		}
+	}
+	want := []string{`Parent "default/foo-gateway" is listed more than once in parentRefs`}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected diff in errors (-want +got)=\n%v", diff)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RouteMatchPrecedence is a match of a rule of an HTTPRoute, for a hostname
+// which the HTTPRoute serves through some listener of a Gateway.
+type RouteMatchPrecedence struct {
+	Listener  gatewayv1.SectionName
+	Hostname  string
+	HTTPRoute types.NamespacedName
+	// Rule and Match are the indexes of the rule within the HTTPRoute and of
+	// the match within the rule. Rules without matches have a single match
+	// for all requests.
+	Rule  int
+	Match int
+	// RouteMatch is the match, with the defaults of its path filled in.
+	RouteMatch gatewayv1.HTTPRouteMatch
+}
+
+// RouteMatchPrecedenceForGateway returns the matches of the HTTPRoutes
+// attached to the Gateway, for each listener of the Gateway and each hostname
+// they serve through it, in the order of precedence in which requests are
+// matched. Which listeners an HTTPRoute attaches to is taken from the
+// Attachments of its node, so HTTPRoutes which a listener does not allow, like
+// those from namespaces it does not allow routes from, are left out.
+//
+// Listeners are ordered as in the spec of the Gateway, since the matches of
+// different listeners never compete for the same requests. For each listener,
+// hostnames are ordered from the most to the least specific: hostnames before
+// wildcard hostnames, and longer wildcard hostnames first. For each hostname,
+// matches are ordered as defined by the Gateway API:
+//  1. Exact path matches, then PathPrefix path matches, then
+//     RegularExpression path matches, whose precedence is implementation
+//     specific.
+//  2. The longest path.
+//  3. Matches on the method.
+//  4. The largest number of header matches.
+//  5. The largest number of query parameter matches.
+//  6. The oldest HTTPRoute, then the HTTPRoute which comes first in
+//     alphabetical order by "{namespace}/{name}".
+//  7. The first rule and match in the order they are listed.
+func RouteMatchPrecedenceForGateway(gatewayNode *GatewayNode) []RouteMatchPrecedence {
+	gateway := client.ObjectKeyFromObject(gatewayNode.Gateway)
+	listenerIndexes := make(map[gatewayv1.SectionName]int)
+	for i, listener := range gatewayNode.Gateway.Spec.Listeners {
+		listenerIndexes[listener.Name] = i
+	}
+
+	var result []RouteMatchPrecedence
+	httpRoutes := make(map[types.NamespacedName]*gatewayv1.HTTPRoute)
+	for _, httpRouteNode := range gatewayNode.HTTPRoutes {
+		httpRoute := httpRouteNode.HTTPRoute
+		httpRoutes[client.ObjectKeyFromObject(httpRoute)] = httpRoute
+
+		for _, attachment := range httpRouteNode.Attachments {
+			if attachment.Gateway != gateway || attachment.Reason != "" {
+				continue
+			}
+			for _, hostname := range attachment.Hostnames {
+				for ruleIndex, rule := range httpRoute.Spec.Rules {
+					matches := rule.Matches
+					if len(matches) == 0 {
+						matches = []gatewayv1.HTTPRouteMatch{{}}
+					}
+					for matchIndex, match := range matches {
+						result = append(result, RouteMatchPrecedence{
+							Listener:   attachment.Listener,
+							Hostname:   hostname,
+							HTTPRoute:  client.ObjectKeyFromObject(httpRoute),
+							Rule:       ruleIndex,
+							Match:      matchIndex,
+							RouteMatch: withDefaultPathMatch(match),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Listener != b.Listener {
+			return listenerIndexes[a.Listener] < listenerIndexes[b.Listener]
+		}
+		if a.Hostname != b.Hostname {
+			return hostnamePrecedes(a.Hostname, b.Hostname)
+		}
+		if ranks := comparePathMatches(*a.RouteMatch.Path, *b.RouteMatch.Path); ranks != 0 {
+			return ranks < 0
+		}
+		if (a.RouteMatch.Method != nil) != (b.RouteMatch.Method != nil) {
+			return a.RouteMatch.Method != nil
+		}
+		if len(a.RouteMatch.Headers) != len(b.RouteMatch.Headers) {
+			return len(a.RouteMatch.Headers) > len(b.RouteMatch.Headers)
+		}
+		if len(a.RouteMatch.QueryParams) != len(b.RouteMatch.QueryParams) {
+			return len(a.RouteMatch.QueryParams) > len(b.RouteMatch.QueryParams)
+		}
+		if a.HTTPRoute != b.HTTPRoute {
+			aCreated := httpRoutes[a.HTTPRoute].GetCreationTimestamp()
+			bCreated := httpRoutes[b.HTTPRoute].GetCreationTimestamp()
+			if !aCreated.Equal(&bCreated) {
+				return aCreated.Before(&bCreated)
+			}
+			return a.HTTPRoute.String() < b.HTTPRoute.String()
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Match < b.Match
+	})
+	return result
+}
+
+// hostnamePrecedes returns true if hostname a is more specific than hostname
+// b: hostnames precede wildcard hostnames, and longer wildcard hostnames
+// precede shorter ones. Hostnames which are equally specific are ordered
+// alphabetically.
+func hostnamePrecedes(a, b string) bool {
+	aWildcard, bWildcard := strings.HasPrefix(a, "*"), strings.HasPrefix(b, "*")
+	if aWildcard != bWildcard {
+		return bWildcard
+	}
+	if aWildcard && len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a < b
+}
+
+// pathMatchTypeRanks ranks the types of path matches by their precedence.
+var pathMatchTypeRanks = map[gatewayv1.PathMatchType]int{
+	gatewayv1.PathMatchExact:             0,
+	gatewayv1.PathMatchPathPrefix:        1,
+	gatewayv1.PathMatchRegularExpression: 2,
+}
+
+// comparePathMatches returns a negative number if path match a precedes path
+// match b, a positive number if b precedes a, and 0 if they are equal in
+// precedence. Both path matches must have their type and value set.
+func comparePathMatches(a, b gatewayv1.HTTPPathMatch) int {
+	if aRank, bRank := pathMatchTypeRanks[*a.Type], pathMatchTypeRanks[*b.Type]; aRank != bRank {
+		return aRank - bRank
+	}
+	return len(*b.Value) - len(*a.Value)
+}
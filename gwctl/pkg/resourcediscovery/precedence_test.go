@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestRouteMatchPrecedenceForGateway(t *testing.T) {
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType, Hostname: common.PtrTo(gatewayv1.Hostname("*.example.com"))},
+				{Name: "tcp", Port: 5432, Protocol: gatewayv1.TCPProtocolType},
+				{
+					Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: common.PtrTo(gatewayv1.Hostname("foo.example.com")),
+					AllowedRoutes: &gatewayv1.AllowedRoutes{Namespaces: &gatewayv1.RouteNamespaces{From: common.PtrTo(gatewayv1.NamespacesFromAll)}},
+				},
+			},
+		},
+	}
+	gatewayNode := NewGatewayNode(gateway)
+	gateways := map[types.NamespacedName]*gatewayv1.Gateway{client.ObjectKeyFromObject(gateway): gateway}
+	created := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	pathMatch := func(pathType gatewayv1.PathMatchType, value string) gatewayv1.HTTPRouteMatch {
+		return gatewayv1.HTTPRouteMatch{Path: &gatewayv1.HTTPPathMatch{Type: common.PtrTo(pathType), Value: common.PtrTo(value)}}
+	}
+	httpRouteInNamespace := func(namespace, name string, age time.Duration, hostnames []gatewayv1.Hostname, rules ...gatewayv1.HTTPRouteRule) *HTTPRouteNode {
+		httpRouteNode := NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.NewTime(created.Add(-age))},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "edge", Namespace: common.PtrTo(gatewayv1.Namespace("default"))}}},
+				Hostnames:       hostnames,
+				Rules:           rules,
+			},
+		})
+		httpRouteNode.Attachments = listenerAttachmentsForHTTPRoute(*httpRouteNode.HTTPRoute, gateways, nil)
+		return httpRouteNode
+	}
+	httpRoute := func(name string, age time.Duration, hostnames []gatewayv1.Hostname, rules ...gatewayv1.HTTPRouteRule) *HTTPRouteNode {
+		return httpRouteInNamespace("default", name, age, hostnames, rules...)
+	}
+	withMethod := pathMatch(gatewayv1.PathMatchPathPrefix, "/api")
+	withMethod.Method = common.PtrTo(gatewayv1.HTTPMethodGet)
+	withHeader := pathMatch(gatewayv1.PathMatchPathPrefix, "/api")
+	withHeader.Headers = []gatewayv1.HTTPHeaderMatch{{Name: "version", Value: "2"}}
+
+	for _, httpRouteNode := range []*HTTPRouteNode{
+		httpRoute("foo-httproute", time.Hour, []gatewayv1.Hostname{"foo.example.com"},
+			gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{pathMatch(gatewayv1.PathMatchPathPrefix, "/api"), withHeader}},
+			gatewayv1.HTTPRouteRule{},
+		),
+		httpRoute("bar-httproute", 2*time.Hour, []gatewayv1.Hostname{"foo.example.com"},
+			gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{withMethod, pathMatch(gatewayv1.PathMatchExact, "/")}},
+			gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{pathMatch(gatewayv1.PathMatchPathPrefix, "/api")}},
+		),
+		httpRoute("baz-httproute", time.Hour, nil,
+			gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{pathMatch(gatewayv1.PathMatchRegularExpression, "/.*")}},
+		),
+		// The http listener only allows routes from its own namespace, so this
+		// HTTPRoute only attaches to the https listener.
+		httpRouteInNamespace("other", "qux-httproute", 3*time.Hour, []gatewayv1.Hostname{"foo.example.com"},
+			gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{pathMatch(gatewayv1.PathMatchExact, "/qux")}},
+		),
+	} {
+		gatewayNode.HTTPRoutes[httpRouteNode.ID()] = httpRouteNode
+	}
+
+	var got []string
+	for _, entry := range RouteMatchPrecedenceForGateway(gatewayNode) {
+		got = append(got, fmt.Sprintf("%v %v %v rules[%d].matches[%d]", entry.Listener, entry.Hostname, entry.HTTPRoute, entry.Rule, entry.Match))
+	}
+	want := []string{
+		"http foo.example.com default/bar-httproute rules[0].matches[1]",
+		"http foo.example.com default/bar-httproute rules[0].matches[0]",
+		"http foo.example.com default/foo-httproute rules[0].matches[1]",
+		"http foo.example.com default/bar-httproute rules[1].matches[0]",
+		"http foo.example.com default/foo-httproute rules[0].matches[0]",
+		"http foo.example.com default/foo-httproute rules[1].matches[0]",
+		"http *.example.com default/baz-httproute rules[0].matches[0]",
+		"https foo.example.com other/qux-httproute rules[0].matches[0]",
+		"https foo.example.com default/bar-httproute rules[0].matches[1]",
+		"https foo.example.com default/bar-httproute rules[0].matches[0]",
+		"https foo.example.com default/foo-httproute rules[0].matches[1]",
+		"https foo.example.com default/bar-httproute rules[1].matches[0]",
+		"https foo.example.com default/foo-httproute rules[0].matches[0]",
+		"https foo.example.com default/foo-httproute rules[1].matches[0]",
+		"https foo.example.com default/baz-httproute rules[0].matches[0]",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("RouteMatchPrecedenceForGateway() returned unexpected order (-want +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+// Computed statuses of resources, as returned by ComputedStatus.
+const (
+	StatusAccepted       = "Accepted"
+	StatusNotAccepted    = "NotAccepted"
+	StatusProgrammed     = "Programmed"
+	StatusNotProgrammed  = "NotProgrammed"
+	StatusUnresolvedRefs = "UnresolvedRefs"
+	StatusPending        = "Pending"
+)
+
+// ComputedStatus summarizes the status of the resource in a single word, so
+// that resources can be filtered and highlighted by their status:
+//   - GatewayClasses are Accepted or NotAccepted.
+//   - Gateways are NotAccepted, Programmed or NotProgrammed.
+//   - HTTPRoutes are NotAccepted if any parent did not accept them,
+//     UnresolvedRefs if any of their references could not be resolved, and
+//     Accepted otherwise.
+//   - Namespaces have the status of their phase, like Active.
+//
+// Resources which have not been reported on yet are Pending. The status is
+// empty for other kinds of resources.
+func ComputedStatus(obj client.Object) string {
+	switch obj := obj.(type) {
+	case *gatewayv1.GatewayClass:
+		return conditionStatus(obj.Status.Conditions, "Accepted", StatusAccepted, StatusNotAccepted)
+	case *gatewayv1.Gateway:
+		if accepted := common.FindStandardCondition(obj.Status.Conditions, "Accepted"); accepted != nil && accepted.Status == metav1.ConditionFalse {
+			return StatusNotAccepted
+		}
+		return conditionStatus(obj.Status.Conditions, "Programmed", StatusProgrammed, StatusNotProgrammed)
+	case *gatewayv1.HTTPRoute:
+		if len(obj.Status.Parents) == 0 {
+			return StatusPending
+		}
+		result := StatusAccepted
+		for _, parent := range obj.Status.Parents {
+			switch conditionStatus(parent.Conditions, "Accepted", StatusAccepted, StatusNotAccepted) {
+			case StatusNotAccepted:
+				return StatusNotAccepted
+			case StatusPending:
+				result = StatusPending
+			}
+			if resolvedRefs := common.FindStandardCondition(parent.Conditions, "ResolvedRefs"); resolvedRefs != nil && resolvedRefs.Status == metav1.ConditionFalse && result == StatusAccepted {
+				result = StatusUnresolvedRefs
+			}
+		}
+		return result
+	case *corev1.Namespace:
+		return string(obj.Status.Phase)
+	}
+	return ""
+}
+
+// conditionStatus returns trueStatus or falseStatus depending on the status of
+// the condition, or StatusPending if the condition is not set or Unknown.
+func conditionStatus(conditions []metav1.Condition, conditionType, trueStatus, falseStatus string) string {
+	condition := common.FindStandardCondition(conditions, conditionType)
+	if condition == nil {
+		return StatusPending
+	}
+	switch condition.Status {
+	case metav1.ConditionTrue:
+		return trueStatus
+	case metav1.ConditionFalse:
+		return falseStatus
+	}
+	return StatusPending
+}
+
+// ObjectPredicate is a simple condition on a resource, like `status=NotAccepted`
+// or `age<10m`. Predicates support the following fields, whose names are
+// case-insensitive, so that they may be written like the columns of the table:
+//   - status, compared with = or != to the ComputedStatus of the resource,
+//     ignoring case.
+//   - age, compared with <, <=, > or >= to a duration like 10m or 2d.
+//   - label:KEY, compared with = or != to the value of the label KEY. A label
+//     which is not set has an empty value.
+type ObjectPredicate struct {
+	expression string
+	field      string
+	labelKey   string
+	operator   string
+	value      string
+	duration   time.Duration
+}
+
+// predicateOperators are the operators of ObjectPredicates. Operators which
+// are a prefix of another must come after it.
+var predicateOperators = []string{"!=", "<=", ">=", "=", "<", ">"}
+
+// ParseObjectPredicate parses a predicate like `status=NotAccepted`.
+func ParseObjectPredicate(expression string) (*ObjectPredicate, error) {
+	i := strings.IndexAny(expression, "!=<>")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid expression %q; must be of the form FIELD OPERATOR VALUE, like status=NotAccepted", expression)
+	}
+	p := &ObjectPredicate{expression: expression, field: strings.TrimSpace(expression[:i])}
+	// Label keys are case-sensitive, so only the names of fields are
+	// lowercased.
+	if len(p.field) >= len("label:") && strings.EqualFold(p.field[:len("label:")], "label:") {
+		p.field = "label:" + p.field[len("label:"):]
+	} else {
+		p.field = strings.ToLower(p.field)
+	}
+	for _, operator := range predicateOperators {
+		if strings.HasPrefix(expression[i:], operator) {
+			p.operator = operator
+			break
+		}
+	}
+	if p.operator == "" {
+		return nil, fmt.Errorf("invalid operator in expression %q; must be one of (%v)", expression, strings.Join(predicateOperators, ", "))
+	}
+	p.value = strings.TrimSpace(expression[i+len(p.operator):])
+
+	equality := p.operator == "=" || p.operator == "!="
+	switch {
+	case p.field == "status":
+		if !equality {
+			return nil, fmt.Errorf("invalid expression %q; status can only be compared with = or !=", expression)
+		}
+	case p.field == "age":
+		if equality {
+			return nil, fmt.Errorf("invalid expression %q; age can only be compared with <, <=, > or >=", expression)
+		}
+		duration, err := utils.ParseDuration(p.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration in expression %q: %w", expression, err)
+		}
+		p.duration = duration
+	case strings.HasPrefix(p.field, "label:"):
+		if !equality {
+			return nil, fmt.Errorf("invalid expression %q; labels can only be compared with = or !=", expression)
+		}
+		p.labelKey = strings.TrimPrefix(p.field, "label:")
+		if p.labelKey == "" {
+			return nil, fmt.Errorf("invalid expression %q; label key must not be empty", expression)
+		}
+	default:
+		return nil, fmt.Errorf("unknown field %q in expression %q; must be one of (status, age, label:KEY)", p.field, expression)
+	}
+	return p, nil
+}
+
+// String returns the expression which the predicate was parsed from.
+func (p *ObjectPredicate) String() string {
+	return p.expression
+}
+
+// Matches returns true if the resource satisfies the predicate. The clock is
+// used to compute the age of the resource.
+func (p *ObjectPredicate) Matches(c clock.PassiveClock, obj client.Object) bool {
+	switch p.field {
+	case "status":
+		return strings.EqualFold(ComputedStatus(obj), p.value) == (p.operator == "=")
+	case "age":
+		age := c.Since(obj.GetCreationTimestamp().Time)
+		switch p.operator {
+		case "<":
+			return age < p.duration
+		case "<=":
+			return age <= p.duration
+		case ">":
+			return age > p.duration
+		case ">=":
+			return age >= p.duration
+		}
+		return false
+	}
+	return (obj.GetLabels()[p.labelKey] == p.value) == (p.operator == "=")
+}
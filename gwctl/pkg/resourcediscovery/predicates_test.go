@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestComputedStatus(t *testing.T) {
+	parentStatus := func(conditions ...metav1.Condition) gatewayv1.RouteParentStatus {
+		return gatewayv1.RouteParentStatus{Conditions: conditions}
+	}
+	accepted := metav1.Condition{Type: "Accepted", Status: metav1.ConditionTrue}
+	notAccepted := metav1.Condition{Type: "Accepted", Status: metav1.ConditionFalse}
+	unresolvedRefs := metav1.Condition{Type: "ResolvedRefs", Status: metav1.ConditionFalse}
+
+	testcases := []struct {
+		name string
+		obj  client.Object
+		want string
+	}{
+		{
+			name: "gateway without status",
+			obj:  &gatewayv1.Gateway{},
+			want: StatusPending,
+		},
+		{
+			name: "programmed gateway",
+			obj: &gatewayv1.Gateway{Status: gatewayv1.GatewayStatus{Conditions: []metav1.Condition{
+				accepted, {Type: "Programmed", Status: metav1.ConditionTrue},
+			}}},
+			want: StatusProgrammed,
+		},
+		{
+			name: "gateway which is not accepted",
+			obj: &gatewayv1.Gateway{Status: gatewayv1.GatewayStatus{Conditions: []metav1.Condition{
+				notAccepted, {Type: "Programmed", Status: metav1.ConditionFalse},
+			}}},
+			want: StatusNotAccepted,
+		},
+		{
+			name: "gatewayclass which is not accepted",
+			obj: &gatewayv1.GatewayClass{Status: gatewayv1.GatewayClassStatus{Conditions: []metav1.Condition{
+				notAccepted,
+			}}},
+			want: StatusNotAccepted,
+		},
+		{
+			name: "httproute accepted by all parents",
+			obj: &gatewayv1.HTTPRoute{Status: gatewayv1.HTTPRouteStatus{RouteStatus: gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{
+				parentStatus(accepted), parentStatus(accepted),
+			}}}},
+			want: StatusAccepted,
+		},
+		{
+			name: "httproute not accepted by one parent",
+			obj: &gatewayv1.HTTPRoute{Status: gatewayv1.HTTPRouteStatus{RouteStatus: gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{
+				parentStatus(accepted, unresolvedRefs), parentStatus(notAccepted),
+			}}}},
+			want: StatusNotAccepted,
+		},
+		{
+			name: "httproute with unresolved refs",
+			obj: &gatewayv1.HTTPRoute{Status: gatewayv1.HTTPRouteStatus{RouteStatus: gatewayv1.RouteStatus{Parents: []gatewayv1.RouteParentStatus{
+				parentStatus(accepted, unresolvedRefs),
+			}}}},
+			want: StatusUnresolvedRefs,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ComputedStatus(tc.obj); got != tc.want {
+				t.Errorf("ComputedStatus() = %q; want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestObjectPredicate(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "foo-gateway",
+			Labels:            map[string]string{"env": "prod"},
+			CreationTimestamp: metav1.NewTime(fakeClock.Now().Add(-5 * time.Minute)),
+		},
+		Status: gatewayv1.GatewayStatus{Conditions: []metav1.Condition{
+			{Type: "Accepted", Status: metav1.ConditionFalse},
+		}},
+	}
+
+	testcases := []struct {
+		expression string
+		want       bool
+		wantErr    bool
+	}{
+		{expression: "status=NotAccepted", want: true},
+		{expression: "status=notaccepted", want: true},
+		{expression: "STATUS=NotAccepted", want: true},
+		{expression: "STATUS!=NotAccepted", want: false},
+		{expression: "status!=NotAccepted", want: false},
+		{expression: "status=Programmed", want: false},
+		{expression: "age<10m", want: true},
+		{expression: "age>=5m", want: true},
+		{expression: "age>10m", want: false},
+		{expression: "AGE<10m", want: true},
+		{expression: "age<1d", want: true},
+		{expression: "age>2d", want: false},
+		{expression: "label:env=prod", want: true},
+		{expression: "label:env!=prod", want: false},
+		{expression: "label:tier=", want: true},
+		{expression: "LABEL:env=prod", want: true},
+		{expression: "label:ENV=prod", want: false},
+		{expression: "status<NotAccepted", wantErr: true},
+		{expression: "age=10m", wantErr: true},
+		{expression: "age<ten", wantErr: true},
+		{expression: "age<-1d", wantErr: true},
+		{expression: "label:=prod", wantErr: true},
+		{expression: "name=foo-gateway", wantErr: true},
+		{expression: "NotAccepted", wantErr: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.expression, func(t *testing.T) {
+			predicate, err := ParseObjectPredicate(tc.expression)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseObjectPredicate(%q) returned err=%v; want err=%v", tc.expression, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := predicate.Matches(fakeClock, gateway); got != tc.want {
+				t.Errorf("Matches() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"slices"
+	"strings"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+)
+
+// ProbeTargetsForGateway returns the targets at which the listeners of the
+// Gateway are probed: each listener at each address in the status of the
+// Gateway. The server name of a target is the hostname of the listener, or if
+// the listener has a wildcard hostname or none, the first of the hostnames
+// which the attached HTTPRoutes serve through the listener.
+func ProbeTargetsForGateway(gatewayNode *GatewayNode) []common.ProbeTarget {
+	gateway := common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: gatewayNode.Gateway.GetNamespace(), Name: gatewayNode.Gateway.GetName()}
+
+	var result []common.ProbeTarget
+	for _, listener := range gatewayNode.Gateway.Spec.Listeners {
+		serverName := ""
+		if listener.Hostname != nil && !strings.HasPrefix(string(*listener.Hostname), "*") {
+			serverName = string(*listener.Hostname)
+		} else {
+			var hostnames []string
+			for _, httpRouteNode := range gatewayNode.HTTPRoutes {
+				if !httpRouteSelectsListener(httpRouteNode.HTTPRoute, gateway, listener.Name) {
+					continue
+				}
+				for _, hostname := range relations.IntersectHostnames(listener.Hostname, httpRouteNode.HTTPRoute.Spec.Hostnames) {
+					if !strings.HasPrefix(hostname, "*") {
+						hostnames = append(hostnames, hostname)
+					}
+				}
+			}
+			if len(hostnames) != 0 {
+				serverName = slices.Min(hostnames)
+			}
+		}
+
+		for _, address := range gatewayNode.Gateway.Status.Addresses {
+			result = append(result, common.ProbeTarget{
+				Listener:   string(listener.Name),
+				Address:    address.Value,
+				Port:       int32(listener.Port),
+				Protocol:   listener.Protocol,
+				ServerName: serverName,
+			})
+		}
+	}
+	return result
+}
+
+// httpRouteSelectsListener returns true if some parentRef of the HTTPRoute
+// refers to the Gateway without selecting a different listener.
+func httpRouteSelectsListener(httpRoute *gatewayv1.HTTPRoute, gateway common.ObjRef, listener gatewayv1.SectionName) bool {
+	for _, parentRef := range httpRoute.Spec.ParentRefs {
+		if !relations.ParentRefMatches(httpRoute.GetNamespace(), parentRef, gateway) {
+			continue
+		}
+		if parentRef.SectionName == nil || *parentRef.SectionName == listener {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestProbeTargetsForGateway(t *testing.T) {
+	gatewayNode := NewGatewayNode(&gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+				{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: common.PtrTo(gatewayv1.Hostname("*.example.com"))},
+				{Name: "admin", Port: 8443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: common.PtrTo(gatewayv1.Hostname("admin.example.com"))},
+			},
+		},
+		Status: gatewayv1.GatewayStatus{
+			Addresses: []gatewayv1.GatewayStatusAddress{
+				{Value: "192.0.2.1"},
+				{Value: "edge.example.net"},
+			},
+		},
+	})
+	httpRoute := func(name string, sectionName *gatewayv1.SectionName, hostnames ...gatewayv1.Hostname) *HTTPRouteNode {
+		return NewHTTPRouteNode(&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "edge", SectionName: sectionName}}},
+				Hostnames:       hostnames,
+			},
+		})
+	}
+	for _, httpRouteNode := range []*HTTPRouteNode{
+		httpRoute("foo-httproute", nil, "foo.example.com", "*.foo.example.com"),
+		httpRoute("bar-httproute", common.PtrTo(gatewayv1.SectionName("https")), "bar.example.com"),
+		httpRoute("baz-httproute", common.PtrTo(gatewayv1.SectionName("http")), "baz.example.org"),
+	} {
+		gatewayNode.HTTPRoutes[httpRouteNode.ID()] = httpRouteNode
+	}
+
+	got := ProbeTargetsForGateway(gatewayNode)
+	want := []common.ProbeTarget{
+		{Listener: "http", Address: "192.0.2.1", Port: 80, Protocol: gatewayv1.HTTPProtocolType, ServerName: "baz.example.org"},
+		{Listener: "http", Address: "edge.example.net", Port: 80, Protocol: gatewayv1.HTTPProtocolType, ServerName: "baz.example.org"},
+		{Listener: "https", Address: "192.0.2.1", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, ServerName: "bar.example.com"},
+		{Listener: "https", Address: "edge.example.net", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, ServerName: "bar.example.com"},
+		{Listener: "admin", Address: "192.0.2.1", Port: 8443, Protocol: gatewayv1.HTTPSProtocolType, ServerName: "admin.example.com"},
+		{Listener: "admin", Address: "edge.example.net", Port: 8443, Protocol: gatewayv1.HTTPSProtocolType, ServerName: "admin.example.com"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ProbeTargetsForGateway() returned unexpected targets (-want +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+)
+
+// CrossNamespaceReference is a reference from a Gateway API resource to a
+// resource in another namespace, which needs a ReferenceGrant to be
+// permitted.
+type CrossNamespaceReference struct {
+	From common.ObjRef `json:"from"`
+	To   common.ObjRef `json:"to"`
+}
+
+// ReferenceGrantCoverage describes the cross namespace references which a
+// ReferenceGrant currently authorizes.
+type ReferenceGrantCoverage struct {
+	ReferenceGrant common.ObjRef `json:"referenceGrant"`
+	// References are the references which the ReferenceGrant authorizes, sorted
+	// by the referring and then by the referenced resource.
+	References []CrossNamespaceReference `json:"references"`
+	// Wildcard is true if the ReferenceGrant exposes all resources of some kind
+	// in its namespace, by omitting the name of some entry of spec.to.
+	Wildcard bool `json:"wildcard"`
+}
+
+// Targets returns the distinct resources referenced through the
+// ReferenceGrant, sorted by kind and name.
+func (c ReferenceGrantCoverage) Targets() []common.ObjRef {
+	var result []common.ObjRef
+	for _, reference := range c.References {
+		if !slices.Contains(result, reference.To) {
+			result = append(result, reference.To)
+		}
+	}
+	sortObjRefs(result)
+	return result
+}
+
+// OverBroad returns true if the ReferenceGrant exposes all resources of some
+// kind, but only a single resource is actually referenced through it.
+func (c ReferenceGrantCoverage) OverBroad() bool {
+	return c.Wildcard && len(c.Targets()) == 1
+}
+
+// DiscoverReferenceGrantCoverage returns the coverage of every ReferenceGrant
+// of the cluster, sorted by namespace and name. The references are those from
+// the rules of all routes to their backends and mirror backends, and from the
+// listeners of all Gateways to Secrets. BackendTLSPolicies are not considered,
+// since they can only reference resources in their own namespace.
+//
+// Route kinds which are not installed in the cluster are skipped, but an error
+// is returned if some installed route kind cannot be listed, since the
+// coverage would then be incomplete.
+func (d Discoverer) DiscoverReferenceGrantCoverage() ([]ReferenceGrantCoverage, error) {
+	ctx := context.Background()
+	referenceGrants, err := d.fetchReferenceGrants(ctx, Filter{ /* all ReferenceGrants */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	httpRoutes, err := d.fetchHTTPRoutes(ctx, Filter{ /* all HTTPRoutes */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	gateways, err := d.fetchGateways(ctx, Filter{ /* all Gateways */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+
+	var references []CrossNamespaceReference
+	for i := range httpRoutes {
+		references = append(references, crossNamespaceReferencesFromHTTPRoute(&httpRoutes[i])...)
+	}
+	for i := range gateways {
+		references = append(references, crossNamespaceReferencesFromGateway(&gateways[i])...)
+	}
+	for _, routeKind := range otherRouteKinds {
+		routeList, err := d.K8sClients.DC.Resource(routeKind.gvr).List(ctx, metav1.ListOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %vs: %v", routeKind.kind, err)
+		}
+		for i := range routeList.Items {
+			routeList.Items[i].SetKind(routeKind.kind)
+			references = append(references, crossNamespaceReferencesFromRoute(&routeList.Items[i])...)
+		}
+	}
+	return referenceGrantCoverage(referenceGrants, references), nil
+}
+
+// ReferenceGrantCoverageFor returns the coverage of the ReferenceGrants which
+// expose the target, sorted by name. The references are those from the
+// routes and Gateways in the resourceModel, so the coverage is only complete
+// if the resourceModel holds all routes and Gateways.
+func (d Discoverer) ReferenceGrantCoverageFor(resourceModel *ResourceModel, target common.ObjRef) ([]ReferenceGrantCoverage, error) {
+	ctx := context.Background()
+	referenceGrants, err := d.fetchReferenceGrants(ctx, Filter{Namespace: target.Namespace, Labels: labels.Everything()})
+	if err := d.tolerateTerminatingNamespace(ctx, target.Namespace, err); err != nil {
+		return nil, err
+	}
+	referenceGrants = slices.DeleteFunc(referenceGrants, func(referenceGrant gatewayv1beta1.ReferenceGrant) bool {
+		return !relations.ReferenceGrantExposes(referenceGrant, target)
+	})
+
+	var references []CrossNamespaceReference
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		references = append(references, crossNamespaceReferencesFromHTTPRoute(httpRouteNode.HTTPRoute)...)
+	}
+	for _, otherRouteNode := range resourceModel.OtherRoutes {
+		references = append(references, crossNamespaceReferencesFromRoute(otherRouteNode.Route)...)
+	}
+	for _, gatewayNode := range resourceModel.Gateways {
+		references = append(references, crossNamespaceReferencesFromGateway(gatewayNode.Gateway)...)
+	}
+	return referenceGrantCoverage(referenceGrants, references), nil
+}
+
+// referenceGrantCoverage returns the coverage of each ReferenceGrant, sorted by
+// namespace and name. A reference authorized by several ReferenceGrants counts
+// towards each of them.
+func referenceGrantCoverage(referenceGrants []gatewayv1beta1.ReferenceGrant, references []CrossNamespaceReference) []ReferenceGrantCoverage {
+	sort.Slice(references, func(i, j int) bool {
+		if references[i].From != references[j].From {
+			return lessObjRef(references[i].From, references[j].From)
+		}
+		return lessObjRef(references[i].To, references[j].To)
+	})
+
+	var result []ReferenceGrantCoverage
+	for _, referenceGrant := range referenceGrants {
+		coverage := ReferenceGrantCoverage{
+			ReferenceGrant: common.ObjRef{
+				Group:     gatewayv1beta1.GroupName,
+				Kind:      "ReferenceGrant",
+				Namespace: referenceGrant.GetNamespace(),
+				Name:      referenceGrant.GetName(),
+			},
+		}
+		for _, to := range referenceGrant.Spec.To {
+			if to.Name == nil || len(*to.Name) == 0 {
+				coverage.Wildcard = true
+			}
+		}
+		for _, reference := range references {
+			if relations.ReferenceGrantExposes(referenceGrant, reference.To) && relations.ReferenceGrantAccepts(referenceGrant, reference.From) {
+				coverage.References = append(coverage.References, reference)
+			}
+		}
+		result = append(result, coverage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return lessObjRef(result[i].ReferenceGrant, result[j].ReferenceGrant)
+	})
+	return result
+}
+
+// crossNamespaceReferencesFromHTTPRoute returns the references from the rules
+// of the HTTPRoute to backends in other namespaces. These include the
+// backendRefs of the rules, and the backends of the RequestMirror filters of
+// both the rules and their backendRefs.
+func crossNamespaceReferencesFromHTTPRoute(httpRoute *gatewayv1.HTTPRoute) []CrossNamespaceReference {
+	var backendRefs []gatewayv1.BackendObjectReference
+	for _, rule := range httpRoute.Spec.Rules {
+		backendRefs = append(backendRefs, mirrorBackendRefs(rule.Filters)...)
+		for _, backendRef := range rule.BackendRefs {
+			backendRefs = append(backendRefs, backendRef.BackendObjectReference)
+			backendRefs = append(backendRefs, mirrorBackendRefs(backendRef.Filters)...)
+		}
+	}
+	from := common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: httpRoute.GetNamespace(), Name: httpRoute.GetName()}
+	return crossNamespaceBackendReferences(from, backendRefs)
+}
+
+// routeRuleReferences holds the fields through which the rules of every route
+// kind reference backends. Only GRPCRoutes have filters, which share the
+// RequestMirror filter of HTTPRoutes.
+type routeRuleReferences struct {
+	BackendRefs []routeBackendRefReferences `json:"backendRefs,omitempty"`
+	Filters     []gatewayv1.HTTPRouteFilter `json:"filters,omitempty"`
+}
+
+type routeBackendRefReferences struct {
+	gatewayv1.BackendObjectReference `json:",inline"`
+	Filters                          []gatewayv1.HTTPRouteFilter `json:"filters,omitempty"`
+}
+
+// crossNamespaceReferencesFromRoute returns the references from the rules of
+// a route of some kind other than HTTPRoute to backends in other namespaces.
+// The kind of the route must be set.
+func crossNamespaceReferencesFromRoute(route *unstructured.Unstructured) []CrossNamespaceReference {
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	var backendRefs []gatewayv1.BackendObjectReference
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		references := routeRuleReferences{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ruleMap, &references); err != nil {
+			klog.V(1).ErrorS(err, "Failed to convert route rule", "kind", route.GetKind(), "route", route.GetNamespace()+"/"+route.GetName())
+			continue
+		}
+		backendRefs = append(backendRefs, mirrorBackendRefs(references.Filters)...)
+		for _, backendRef := range references.BackendRefs {
+			backendRefs = append(backendRefs, backendRef.BackendObjectReference)
+			backendRefs = append(backendRefs, mirrorBackendRefs(backendRef.Filters)...)
+		}
+	}
+	from := common.ObjRef{Group: gatewayv1.GroupName, Kind: route.GetKind(), Namespace: route.GetNamespace(), Name: route.GetName()}
+	return crossNamespaceBackendReferences(from, backendRefs)
+}
+
+// mirrorBackendRefs returns the backends of the RequestMirror filters.
+func mirrorBackendRefs(filters []gatewayv1.HTTPRouteFilter) []gatewayv1.BackendObjectReference {
+	var result []gatewayv1.BackendObjectReference
+	for _, filter := range filters {
+		if filter.Type == gatewayv1.HTTPRouteFilterRequestMirror && filter.RequestMirror != nil {
+			result = append(result, filter.RequestMirror.BackendRef)
+		}
+	}
+	return result
+}
+
+// crossNamespaceBackendReferences returns the references from the route to
+// those backendRefs which are in another namespace.
+func crossNamespaceBackendReferences(from common.ObjRef, backendRefs []gatewayv1.BackendObjectReference) []CrossNamespaceReference {
+	var result []CrossNamespaceReference
+	for _, backendRef := range backendRefs {
+		to := backendObjRef(backendRef, from.Namespace)
+		if to.Namespace != from.Namespace {
+			result = append(result, CrossNamespaceReference{From: from, To: to})
+		}
+	}
+	return result
+}
+
+// crossNamespaceReferencesFromGateway returns the references from the
+// listeners of the Gateway to certificates in other namespaces.
+func crossNamespaceReferencesFromGateway(gateway *gatewayv1.Gateway) []CrossNamespaceReference {
+	from := common.ObjRef{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: gateway.GetNamespace(), Name: gateway.GetName()}
+	var result []CrossNamespaceReference
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.TLS == nil {
+			continue
+		}
+		for _, certificateRef := range listener.TLS.CertificateRefs {
+			to := secretObjRef(certificateRef, gateway.GetNamespace())
+			if to.Namespace != from.Namespace {
+				result = append(result, CrossNamespaceReference{From: from, To: to})
+			}
+		}
+	}
+	return result
+}
+
+func sortObjRefs(refs []common.ObjRef) {
+	sort.Slice(refs, func(i, j int) bool {
+		return lessObjRef(refs[i], refs[j])
+	})
+}
+
+func lessObjRef(a, b common.ObjRef) bool {
+	if a.Kind != b.Kind {
+		return a.Kind < b.Kind
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
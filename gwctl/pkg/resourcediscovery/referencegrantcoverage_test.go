@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestDiscoverReferenceGrantCoverage(t *testing.T) {
+	httpRoute := func(namespace, name string, backendNames ...string) *gatewayv1.HTTPRoute {
+		var backendRefs []gatewayv1.HTTPBackendRef
+		for _, backendName := range backendNames {
+			backendRefs = append(backendRefs, gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name:      gatewayv1.ObjectName(backendName),
+				Namespace: common.PtrTo(gatewayv1.Namespace("backends")),
+			}}})
+		}
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{{BackendRefs: backendRefs}}},
+		}
+	}
+	referenceGrant := func(name, fromNamespace string, toName *gatewayv1.ObjectName) *gatewayv1beta1.ReferenceGrant {
+		return &gatewayv1beta1.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "backends"},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: gatewayv1.Namespace(fromNamespace)}},
+				To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: "Service", Name: toName}},
+			},
+		}
+	}
+
+	objects := []runtime.Object{
+		common.NamespaceForTest("backends"),
+		common.NamespaceForTest("team-a"),
+		common.NamespaceForTest("team-b"),
+		common.NamespaceForTest("team-c"),
+		httpRoute("team-a", "route-a", "svc-1"),
+		httpRoute("team-b", "route-b", "svc-1", "svc-2"),
+		referenceGrant("allow-team-a", "team-a", nil),
+		referenceGrant("allow-team-b", "team-b", common.PtrTo(gatewayv1.ObjectName("svc-2"))),
+		referenceGrant("allow-team-c", "team-c", nil),
+	}
+	discoverer := Discoverer{K8sClients: common.MustClientsForTest(t, objects...)}
+
+	got, err := discoverer.DiscoverReferenceGrantCoverage()
+	if err != nil {
+		t.Fatalf("DiscoverReferenceGrantCoverage() returned an unexpected error: %v", err)
+	}
+
+	grantRef := func(name string) common.ObjRef {
+		return common.ObjRef{Group: gatewayv1beta1.GroupName, Kind: "ReferenceGrant", Namespace: "backends", Name: name}
+	}
+	reference := func(fromNamespace, fromName, toName string) CrossNamespaceReference {
+		return CrossNamespaceReference{
+			From: common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: fromNamespace, Name: fromName},
+			To:   common.ObjRef{Kind: "Service", Namespace: "backends", Name: toName},
+		}
+	}
+	want := []ReferenceGrantCoverage{
+		{ReferenceGrant: grantRef("allow-team-a"), References: []CrossNamespaceReference{reference("team-a", "route-a", "svc-1")}, Wildcard: true},
+		{ReferenceGrant: grantRef("allow-team-b"), References: []CrossNamespaceReference{reference("team-b", "route-b", "svc-2")}},
+		{ReferenceGrant: grantRef("allow-team-c"), Wildcard: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected diff in coverage (-want +got)=\n%v", diff)
+	}
+
+	if !got[0].OverBroad() {
+		t.Errorf("OverBroad() = false for %v, want true", got[0].ReferenceGrant.Name)
+	}
+	if got[1].OverBroad() || got[2].OverBroad() {
+		t.Errorf("OverBroad() = true for a named or unused ReferenceGrant, want false")
+	}
+}
+
+func TestDiscoverReferenceGrantCoverage_AllReferenceKinds(t *testing.T) {
+	backendRef := func(name string) gatewayv1.BackendObjectReference {
+		return gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(name), Namespace: common.PtrTo(gatewayv1.Namespace("backends"))}
+	}
+	mirrorFilter := func(name string) gatewayv1.HTTPRouteFilter {
+		return gatewayv1.HTTPRouteFilter{
+			Type:          gatewayv1.HTTPRouteFilterRequestMirror,
+			RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{BackendRef: backendRef(name)},
+		}
+	}
+
+	objects := []runtime.Object{
+		common.NamespaceForTest("backends"),
+		common.NamespaceForTest("team-a"),
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "http-route", Namespace: "team-a"},
+			Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{{
+				Filters: []gatewayv1.HTTPRouteFilter{mirrorFilter("rule-mirror")},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "local"}},
+					Filters:    []gatewayv1.HTTPRouteFilter{mirrorFilter("backend-mirror")},
+				}},
+			}}},
+		},
+		&gatewayv1.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "grpc-route", Namespace: "team-a"},
+			Spec: gatewayv1.GRPCRouteSpec{Rules: []gatewayv1.GRPCRouteRule{{
+				BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: backendRef("grpc-backend")}}},
+			}}},
+		},
+		&gatewayv1beta1.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "allow-team-a", Namespace: "backends"},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{
+					{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "team-a"},
+					{Group: gatewayv1.GroupName, Kind: "GRPCRoute", Namespace: "team-a"},
+				},
+				To: []gatewayv1beta1.ReferenceGrantTo{{Kind: "Service"}},
+			},
+		},
+	}
+	discoverer := Discoverer{K8sClients: common.MustClientsForTest(t, objects...)}
+
+	got, err := discoverer.DiscoverReferenceGrantCoverage()
+	if err != nil {
+		t.Fatalf("DiscoverReferenceGrantCoverage() returned an unexpected error: %v", err)
+	}
+
+	reference := func(fromKind, fromName, toName string) CrossNamespaceReference {
+		return CrossNamespaceReference{
+			From: common.ObjRef{Group: gatewayv1.GroupName, Kind: fromKind, Namespace: "team-a", Name: fromName},
+			To:   common.ObjRef{Kind: "Service", Namespace: "backends", Name: toName},
+		}
+	}
+	want := []ReferenceGrantCoverage{{
+		ReferenceGrant: common.ObjRef{Group: gatewayv1beta1.GroupName, Kind: "ReferenceGrant", Namespace: "backends", Name: "allow-team-a"},
+		References: []CrossNamespaceReference{
+			reference("GRPCRoute", "grpc-route", "grpc-backend"),
+			reference("HTTPRoute", "http-route", "backend-mirror"),
+			reference("HTTPRoute", "http-route", "rule-mirror"),
+		},
+		Wildcard: true,
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected diff in coverage (-want +got)=\n%v", diff)
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+)
+
+// ReferenceView describes a reference to some resource from a Gateway API
+// resource.
+type ReferenceView struct {
+	// From is the resource containing the reference.
+	From common.ObjRef `json:"from"`
+	// Rule is the index of the HTTPRoute rule containing the reference, for
+	// references from HTTPRoutes.
+	Rule *int `json:"rule,omitempty"`
+	// Listener is the name of the Gateway listener containing the reference, for
+	// references from Gateways.
+	Listener string `json:"listener,omitempty"`
+	// Permitted is false for cross namespace references which are not permitted
+	// by any ReferenceGrant.
+	Permitted bool `json:"permitted"`
+	// ReferenceGrant is the ReferenceGrant which permits a cross namespace
+	// reference.
+	ReferenceGrant *common.ObjRef `json:"referenceGrant,omitempty"`
+}
+
+// ReferencesTo returns the references to the target from the resources in the
+// resourceModel, sorted by the referring resource. Services are referenced as
+// backends by the rules of HTTPRoutes, and Secrets are referenced as
+// certificates by the listeners of Gateways. The target itself does not need
+// to be part of the resourceModel, which allows resources other than Gateway
+// API resources to be queried.
+func (d Discoverer) ReferencesTo(resourceModel *ResourceModel, target common.ObjRef) ([]ReferenceView, error) {
+	ctx := context.Background()
+
+	referenceGrants, err := d.fetchReferenceGrants(ctx, Filter{Namespace: target.Namespace, Labels: labels.Everything()})
+	if err := d.tolerateTerminatingNamespace(ctx, target.Namespace, err); err != nil {
+		return nil, err
+	}
+
+	var result []ReferenceView
+	switch target.Kind {
+	case "Service":
+		for _, httpRouteNode := range resourceModel.HTTPRoutes {
+			httpRoute := httpRouteNode.HTTPRoute
+			for i, rule := range httpRoute.Spec.Rules {
+				for _, backendRef := range rule.BackendRefs {
+					if !backendRefMatches(backendRef.BackendObjectReference, httpRoute.GetNamespace(), target) {
+						continue
+					}
+					view := newReferenceView(common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: httpRoute.GetNamespace(), Name: httpRoute.GetName()}, target, referenceGrants)
+					view.Rule = common.PtrTo(i)
+					result = append(result, view)
+				}
+			}
+		}
+	case "Secret":
+		for _, gatewayNode := range resourceModel.Gateways {
+			gateway := gatewayNode.Gateway
+			for _, listener := range gateway.Spec.Listeners {
+				if listener.TLS == nil {
+					continue
+				}
+				for _, certificateRef := range listener.TLS.CertificateRefs {
+					if !secretRefMatches(certificateRef, gateway.GetNamespace(), target) {
+						continue
+					}
+					view := newReferenceView(gatewayRef(gatewayNode), target, referenceGrants)
+					view.Listener = string(listener.Name)
+					result = append(result, view)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].From, result[j].From
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return result, nil
+}
+
+// newReferenceView returns the ReferenceView for a reference from the given
+// resource to the target, determining whether the reference is permitted.
+func newReferenceView(from, target common.ObjRef, referenceGrants []gatewayv1beta1.ReferenceGrant) ReferenceView {
+	view := ReferenceView{From: from}
+	if from.Namespace == target.Namespace {
+		view.Permitted = true
+		return view
+	}
+	for _, referenceGrant := range referenceGrants {
+		if relations.ReferenceGrantExposes(referenceGrant, target) && relations.ReferenceGrantAccepts(referenceGrant, from) {
+			view.Permitted = true
+			view.ReferenceGrant = &common.ObjRef{
+				Group:     gatewayv1beta1.GroupName,
+				Kind:      "ReferenceGrant",
+				Namespace: referenceGrant.GetNamespace(),
+				Name:      referenceGrant.GetName(),
+			}
+			break
+		}
+	}
+	return view
+}
+
+// backendRefMatches returns true if the backendRef within the given namespace
+// refers to the target.
+func backendRefMatches(backendRef gatewayv1.BackendObjectReference, namespace string, target common.ObjRef) bool {
+	return backendObjRef(backendRef, namespace) == target
+}
+
+// backendObjRef returns the resource which the backendRef within the given
+// namespace refers to.
+func backendObjRef(backendRef gatewayv1.BackendObjectReference, namespace string) common.ObjRef {
+	ref := common.ObjRef{Kind: "Service", Namespace: namespace, Name: string(backendRef.Name)}
+	if backendRef.Group != nil {
+		ref.Group = string(*backendRef.Group)
+	}
+	if backendRef.Kind != nil {
+		ref.Kind = string(*backendRef.Kind)
+	}
+	if backendRef.Namespace != nil {
+		ref.Namespace = string(*backendRef.Namespace)
+	}
+	return ref
+}
+
+// secretRefMatches returns true if the certificateRef within the given
+// namespace refers to the target.
+func secretRefMatches(certificateRef gatewayv1.SecretObjectReference, namespace string, target common.ObjRef) bool {
+	return secretObjRef(certificateRef, namespace) == target
+}
+
+// secretObjRef returns the resource which the certificateRef within the given
+// namespace refers to.
+func secretObjRef(certificateRef gatewayv1.SecretObjectReference, namespace string) common.ObjRef {
+	ref := common.ObjRef{Kind: "Secret", Namespace: namespace, Name: string(certificateRef.Name)}
+	if certificateRef.Group != nil {
+		ref.Group = string(*certificateRef.Group)
+	}
+	if certificateRef.Kind != nil {
+		ref.Kind = string(*certificateRef.Kind)
+	}
+	if certificateRef.Namespace != nil {
+		ref.Namespace = string(*certificateRef.Namespace)
+	}
+	return ref
+}
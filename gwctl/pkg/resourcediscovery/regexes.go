@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"fmt"
+	"regexp"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RegexMatch is a regular expression used by a match of an HTTPRoute rule.
+type RegexMatch struct {
+	// Rule and Match are the indexes of the rule and of the match within it.
+	Rule  int
+	Match int
+	// Field is the part of the request matched by the regular expression, like
+	// "path" or "header x-foo".
+	Field string
+	// Header is the name of the matched header, if a header is matched.
+	Header gatewayv1.HTTPHeaderName
+	// QueryParam is the name of the matched query parameter, if a query
+	// parameter is matched.
+	QueryParam gatewayv1.HTTPHeaderName
+	Regex      string
+}
+
+// HTTPRouteRegexMatches returns the regular expressions used by the path,
+// header and query parameter matches of the HTTPRoute, in the order of its
+// rules.
+func HTTPRouteRegexMatches(httpRoute *gatewayv1.HTTPRoute) []RegexMatch {
+	var result []RegexMatch
+	for i, rule := range httpRoute.Spec.Rules {
+		for j, match := range rule.Matches {
+			if match.Path != nil && match.Path.Type != nil && *match.Path.Type == gatewayv1.PathMatchRegularExpression && match.Path.Value != nil {
+				result = append(result, RegexMatch{Rule: i, Match: j, Field: "path", Regex: *match.Path.Value})
+			}
+			for _, header := range match.Headers {
+				if header.Type != nil && *header.Type == gatewayv1.HeaderMatchRegularExpression {
+					result = append(result, RegexMatch{Rule: i, Match: j, Field: fmt.Sprintf("header %v", header.Name), Header: header.Name, Regex: header.Value})
+				}
+			}
+			for _, queryParam := range match.QueryParams {
+				if queryParam.Type != nil && *queryParam.Type == gatewayv1.QueryParamMatchRegularExpression {
+					result = append(result, RegexMatch{Rule: i, Match: j, Field: fmt.Sprintf("query parameter %v", queryParam.Name), QueryParam: queryParam.Name, Regex: queryParam.Value})
+				}
+			}
+		}
+	}
+	return result
+}
+
+// RestrictHTTPRoutesToRegexMatches removes the HTTPRoutes from the
+// resourceModel which use no regular expression in any of their matches.
+func (rm *ResourceModel) RestrictHTTPRoutesToRegexMatches() {
+	for httpRouteID, httpRouteNode := range rm.HTTPRoutes {
+		if len(HTTPRouteRegexMatches(httpRouteNode.HTTPRoute)) == 0 {
+			delete(rm.HTTPRoutes, httpRouteID)
+		}
+	}
+}
+
+// validateRegexesForHTTPRoutes reports the regular expressions used by matches
+// of HTTPRoutes in the resourceModel which do not compile. The syntax accepted
+// by implementations varies, so this only catches mistakes which are invalid
+// in the RE2 syntax shared by most of them.
+func validateRegexesForHTTPRoutes(resourceModel *ResourceModel) {
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		for _, regexMatch := range HTTPRouteRegexMatches(httpRouteNode.HTTPRoute) {
+			if _, err := regexp.Compile(regexMatch.Regex); err != nil {
+				httpRouteNode.Errors = append(httpRouteNode.Errors, InvalidRegexError{
+					Field:  regexMatch.Field,
+					Regex:  regexMatch.Regex,
+					Reason: err.Error(),
+				})
+			}
+		}
+	}
+}
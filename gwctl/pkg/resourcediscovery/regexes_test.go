@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestValidateRegexesForHTTPRoutes(t *testing.T) {
+	resourceModel := &ResourceModel{}
+	resourceModel.addHTTPRoutes(
+		gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "regex-httproute", Namespace: "default"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{{
+					Matches: []gatewayv1.HTTPRouteMatch{
+						{
+							Path: &gatewayv1.HTTPPathMatch{
+								Type:  common.PtrTo(gatewayv1.PathMatchRegularExpression),
+								Value: common.PtrTo("/api/v[0-9]+"),
+							},
+							Headers: []gatewayv1.HTTPHeaderMatch{
+								{Type: common.PtrTo(gatewayv1.HeaderMatchExact), Name: "x-env", Value: "("},
+								{Type: common.PtrTo(gatewayv1.HeaderMatchRegularExpression), Name: "x-version", Value: "v(1|2"},
+							},
+						},
+					},
+				}},
+			},
+		},
+		gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "prefix-httproute", Namespace: "default"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{{
+					Matches: []gatewayv1.HTTPRouteMatch{{
+						Path: &gatewayv1.HTTPPathMatch{
+							Type:  common.PtrTo(gatewayv1.PathMatchPathPrefix),
+							Value: common.PtrTo("/(["),
+						},
+					}},
+				}},
+			},
+		},
+	)
+
+	validateRegexesForHTTPRoutes(resourceModel)
+
+	var got []string
+	for _, err := range resourceModel.HTTPRoutes[HTTPRouteID("default", "regex-httproute")].Errors {
+		if invalidRegexErr, ok := err.(InvalidRegexError); ok {
+			got = append(got, invalidRegexErr.Field+" "+invalidRegexErr.Regex)
+		}
+	}
+	want := []string{"header x-version v(1|2"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected diff in invalid regexes (-want +got):\n%v", diff)
+	}
+	if errs := resourceModel.HTTPRoutes[HTTPRouteID("default", "prefix-httproute")].Errors; len(errs) != 0 {
+		t.Errorf("Unexpected errors for HTTPRoute without regular expressions: %v", errs)
+	}
+
+	resourceModel.RestrictHTTPRoutesToRegexMatches()
+	var gotHTTPRoutes []string
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		gotHTTPRoutes = append(gotHTTPRoutes, httpRouteNode.HTTPRoute.GetName())
+	}
+	if diff := cmp.Diff([]string{"regex-httproute"}, gotHTTPRoutes); diff != "" {
+		t.Errorf("Unexpected diff in HTTPRoutes using regular expressions (-want +got):\n%v", diff)
+	}
+}
+
+func TestHTTPRouteRegexMatches(t *testing.T) {
+	httpRoute := &gatewayv1.HTTPRoute{
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{Matches: []gatewayv1.HTTPRouteMatch{{
+					Path: &gatewayv1.HTTPPathMatch{Type: common.PtrTo(gatewayv1.PathMatchPathPrefix), Value: common.PtrTo("/")},
+				}}},
+				{Matches: []gatewayv1.HTTPRouteMatch{
+					{},
+					{
+						Path:        &gatewayv1.HTTPPathMatch{Type: common.PtrTo(gatewayv1.PathMatchRegularExpression), Value: common.PtrTo("/v[0-9]+")},
+						Headers:     []gatewayv1.HTTPHeaderMatch{{Type: common.PtrTo(gatewayv1.HeaderMatchRegularExpression), Name: "x-version", Value: "v[12]"}},
+						QueryParams: []gatewayv1.HTTPQueryParamMatch{{Type: common.PtrTo(gatewayv1.QueryParamMatchRegularExpression), Name: "id", Value: "[0-9]+"}},
+					},
+				}},
+			},
+		},
+	}
+
+	want := []RegexMatch{
+		{Rule: 1, Match: 1, Field: "path", Regex: "/v[0-9]+"},
+		{Rule: 1, Match: 1, Field: "header x-version", Header: "x-version", Regex: "v[12]"},
+		{Rule: 1, Match: 1, Field: "query parameter id", QueryParam: "id", Regex: "[0-9]+"},
+	}
+	if diff := cmp.Diff(want, HTTPRouteRegexMatches(httpRoute)); diff != "" {
+		t.Errorf("HTTPRouteRegexMatches() returned unexpected diff (-want +got):\n%v", diff)
+	}
+}
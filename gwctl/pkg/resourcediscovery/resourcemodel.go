@@ -19,12 +19,15 @@ package resourcediscovery
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
 	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
 
+	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
@@ -44,6 +47,7 @@ type ResourceModel struct {
 	Namespaces      map[namespaceID]*NamespaceNode
 	Gateways        map[gatewayID]*GatewayNode
 	HTTPRoutes      map[httpRouteID]*HTTPRouteNode
+	OtherRoutes     map[routeID]*OtherRouteNode
 	Backends        map[backendID]*BackendNode
 	ReferenceGrants map[referenceGrantID]*ReferenceGrantNode
 	Policies        map[policyID]*PolicyNode
@@ -104,6 +108,20 @@ func (rm *ResourceModel) addHTTPRoutes(httpRoutes ...gatewayv1.HTTPRoute) {
 	}
 }
 
+// addOtherRoutes adds nodes for routes of kinds other than HTTPRoute.
+func (rm *ResourceModel) addOtherRoutes(routes ...unstructured.Unstructured) {
+	if rm.OtherRoutes == nil {
+		rm.OtherRoutes = make(map[routeID]*OtherRouteNode)
+	}
+	for _, route := range routes {
+		route := route
+		otherRouteNode := NewOtherRouteNode(&route)
+		if _, ok := rm.OtherRoutes[otherRouteNode.RouteID()]; !ok {
+			rm.OtherRoutes[otherRouteNode.RouteID()] = otherRouteNode
+		}
+	}
+}
+
 // addBackends adds nodes for Backends.
 func (rm *ResourceModel) addBackends(backends ...unstructured.Unstructured) {
 	if rm.Backends == nil {
@@ -178,6 +196,17 @@ func (rm *ResourceModel) addPolicyIfTargetExists(policies ...policymanager.Polic
 				rm.Policies[policyNode.ID()] = policyNode
 				policyNode.HTTPRoute = httpRouteNode
 				httpRouteNode.Policies[policyNode.ID()] = policyNode
+
+			default:
+				rID := RouteID(policy.TargetRef().Kind, policy.TargetRef().Namespace, policy.TargetRef().Name)
+				otherRouteNode, ok := rm.OtherRoutes[rID]
+				if !ok {
+					klog.V(1).ErrorS(nil, "Skipping policy since targetRef does not exist in ResourceModel", "policy", policy.Name(), "routeID", rID)
+					continue
+				}
+				rm.Policies[policyNode.ID()] = policyNode
+				policyNode.OtherRoute = otherRouteNode
+				otherRouteNode.Policies[policyNode.ID()] = policyNode
 			}
 
 		case policy.TargetRef().Group == corev1.GroupName && policy.TargetRef().Kind == "Namespace":
@@ -256,7 +285,43 @@ func (rm *ResourceModel) connectHTTPRouteWithBackend(httpRouteID httpRouteID, ba
 	}
 
 	httpRouteNode.Backends[backendID] = backendNode
-	backendNode.HTTPRoutes[httpRouteID] = httpRouteNode
+	backendNode.Routes[httpRouteNode.RouteID()] = httpRouteNode
+}
+
+// connectOtherRouteWithGateway establishes a connection between a route of
+// another kind and its parent Gateway. Only the route references the Gateway,
+// since such routes are merely counted for the Gateways they are attached to.
+func (rm *ResourceModel) connectOtherRouteWithGateway(routeID routeID, gatewayID gatewayID) {
+	otherRouteNode, ok := rm.OtherRoutes[routeID]
+	if !ok {
+		klog.V(1).ErrorS(nil, "Route does not exist in ResourceModel", "routeID", routeID)
+		return
+	}
+	gatewayNode, ok := rm.Gateways[gatewayID]
+	if !ok {
+		klog.V(1).ErrorS(nil, "Gateway does not exist in ResourceModel", "gatewayID", gatewayID)
+		return
+	}
+
+	otherRouteNode.Gateways[gatewayID] = gatewayNode
+}
+
+// connectOtherRouteWithBackend establishes a connection between a route of
+// another kind and its targeted Backend.
+func (rm *ResourceModel) connectOtherRouteWithBackend(routeID routeID, backendID backendID) {
+	otherRouteNode, ok := rm.OtherRoutes[routeID]
+	if !ok {
+		klog.V(1).ErrorS(nil, "Route does not exist in ResourceModel", "routeID", routeID)
+		return
+	}
+	backendNode, ok := rm.Backends[backendID]
+	if !ok {
+		klog.V(1).ErrorS(nil, "Backend does not exist in ResourceModel", "backendID", backendID)
+		return
+	}
+
+	otherRouteNode.Backends[backendID] = backendNode
+	backendNode.Routes[routeID] = otherRouteNode
 }
 
 // connectGatewayWithNamespace establishes a connection between a Gateway and
@@ -295,6 +360,23 @@ func (rm *ResourceModel) connectHTTPRouteWithNamespace(httpRouteID httpRouteID,
 	namespaceNode.HTTPRoutes[httpRouteID] = httpRouteNode
 }
 
+// connectOtherRouteWithNamespace establishes a connection between a route of
+// another kind and its Namespace.
+func (rm *ResourceModel) connectOtherRouteWithNamespace(routeID routeID, namespaceID namespaceID) {
+	otherRouteNode, ok := rm.OtherRoutes[routeID]
+	if !ok {
+		klog.V(1).ErrorS(nil, "Route does not exist in ResourceModel", "routeID", routeID)
+		return
+	}
+	namespaceNode, ok := rm.Namespaces[namespaceID]
+	if !ok {
+		klog.V(1).ErrorS(nil, "Namespace does not exist in ResourceModel", "namespaceID", namespaceID)
+		return
+	}
+
+	otherRouteNode.Namespace = namespaceNode
+}
+
 // connectBackendWithNamespace establishes a connection between a Backend and
 // its Namespace.
 func (rm *ResourceModel) connectBackendWithNamespace(backendID backendID, namespaceID namespaceID) {
@@ -331,25 +413,81 @@ func (rm *ResourceModel) connectReferenceGrantWithBackend(referenceGrantID refer
 	backendNode.ReferenceGrants[referenceGrantID] = referenceGrantNode
 }
 
+// PolicyEffectiveness returns the Effectiveness of each of the policies, keyed
+// by the Name of the policy, based on the effective policies of every Gateway,
+// route and Backend in the ResourceModel.
+func (rm *ResourceModel) PolicyEffectiveness(policies []policymanager.Policy) map[string]policymanager.Effectiveness {
+	var effectivePolicies []map[policymanager.PolicyCrdID]policymanager.Policy
+	for _, gatewayNode := range rm.Gateways {
+		effectivePolicies = append(effectivePolicies, gatewayNode.EffectivePolicies)
+	}
+	for _, httpRouteNode := range rm.HTTPRoutes {
+		effectivePolicies = append(effectivePolicies, maps.Values(httpRouteNode.EffectivePolicies)...)
+	}
+	for _, otherRouteNode := range rm.OtherRoutes {
+		effectivePolicies = append(effectivePolicies, maps.Values(otherRouteNode.EffectivePolicies)...)
+	}
+	for _, backendNode := range rm.Backends {
+		effectivePolicies = append(effectivePolicies, maps.Values(backendNode.EffectivePolicies)...)
+	}
+	return policymanager.ComputeEffectiveness(policies, effectivePolicies)
+}
+
 // calculateEffectivePolicies calculates the effective policies for all
-// Gateways, HTTPRoutes, and Backends in the ResourceModel.
+// Gateways, routes, and Backends in the ResourceModel.
 func (rm *ResourceModel) calculateEffectivePolicies() error {
-	if err := rm.calculateEffectivePoliciesForGateways(); err != nil {
+	merger := newPolicyMerger()
+	if err := rm.calculateEffectivePoliciesForGateways(merger); err != nil {
+		return err
+	}
+	if err := rm.calculateEffectivePoliciesForHTTPRoutes(merger); err != nil {
 		return err
 	}
-	if err := rm.calculateEffectivePoliciesForHTTPRoutes(); err != nil {
+	if err := rm.calculateEffectivePoliciesForOtherRoutes(merger); err != nil {
 		return err
 	}
-	if err := rm.calculateEffectivePoliciesForBackends(); err != nil {
+	if err := rm.calculateEffectivePoliciesForBackends(merger); err != nil {
 		return err
 	}
 	return nil
 }
 
+// policyMerger merges policies of similar kind, and remembers the result for
+// each set of policies. The policies of a Namespace or a GatewayClass are
+// usually shared by many resources, and are then merged only once.
+type policyMerger struct {
+	merged map[string]map[policymanager.PolicyCrdID]policymanager.Policy
+}
+
+func newPolicyMerger() *policyMerger {
+	return &policyMerger{merged: make(map[string]map[policymanager.PolicyCrdID]policymanager.Policy)}
+}
+
+// mergePoliciesOfSimilarKind returns the same result as
+// policymanager.MergePoliciesOfSimilarKind. The policies must be sorted, as
+// returned by convertPoliciesMapToSlice. The returned map must not be
+// modified.
+func (m *policyMerger) mergePoliciesOfSimilarKind(policies []policymanager.Policy) (map[policymanager.PolicyCrdID]policymanager.Policy, error) {
+	names := make([]string, len(policies))
+	for i, policy := range policies {
+		names[i] = policy.Name()
+	}
+	key := strings.Join(names, ",")
+	if result, ok := m.merged[key]; ok {
+		return result, nil
+	}
+	result, err := policymanager.MergePoliciesOfSimilarKind(policies)
+	if err != nil {
+		return nil, err
+	}
+	m.merged[key] = result
+	return result, nil
+}
+
 // calculateEffectivePoliciesForGateways calculates the effective policies for
 // each Gateway by merging policies from different hierarchies (GatewayClass,
 // Namespace, and Gateway).
-func (rm *ResourceModel) calculateEffectivePoliciesForGateways() error {
+func (rm *ResourceModel) calculateEffectivePoliciesForGateways(merger *policyMerger) error {
 	for _, gatewayNode := range rm.Gateways {
 		// Do not calculate effective policy for the Gateway if the referenced
 		// GatewayClass does not exist. For now, we only calculate effective policy
@@ -364,15 +502,15 @@ func (rm *ResourceModel) calculateEffectivePoliciesForGateways() error {
 		gatewayPolicies := convertPoliciesMapToSlice(gatewayNode.Policies)
 
 		// Merge policies by their kind.
-		gatewayClassPoliciesByKind, err := policymanager.MergePoliciesOfSimilarKind(gatewayClassPolicies)
+		gatewayClassPoliciesByKind, err := merger.mergePoliciesOfSimilarKind(gatewayClassPolicies)
 		if err != nil {
 			return err
 		}
-		gatewayNamespacePoliciesByKind, err := policymanager.MergePoliciesOfSimilarKind(gatewayNamespacePolicies)
+		gatewayNamespacePoliciesByKind, err := merger.mergePoliciesOfSimilarKind(gatewayNamespacePolicies)
 		if err != nil {
 			return err
 		}
-		gatewayPoliciesByKind, err := policymanager.MergePoliciesOfSimilarKind(gatewayPolicies)
+		gatewayPoliciesByKind, err := merger.mergePoliciesOfSimilarKind(gatewayPolicies)
 		if err != nil {
 			return err
 		}
@@ -396,53 +534,78 @@ func (rm *ResourceModel) calculateEffectivePoliciesForGateways() error {
 // calculateEffectivePoliciesForHTTPRoutes calculates the effective policies for
 // each HTTPRoute, taking into account policies from different hierarchies
 // (GatewayClass, Namespace, Gateway, and HTTPRoute).
-func (rm *ResourceModel) calculateEffectivePoliciesForHTTPRoutes() error {
+func (rm *ResourceModel) calculateEffectivePoliciesForHTTPRoutes(merger *policyMerger) error {
 	for _, httpRouteNode := range rm.HTTPRoutes {
-		result := make(map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy)
-
-		// Step 1: Aggregate all policies of the HTTPRoute and the
-		// HTTPRoute-namespace.
-		httpRoutePolicies := convertPoliciesMapToSlice(httpRouteNode.Policies)
-		httpRouteNamespacePolicies := convertPoliciesMapToSlice(httpRouteNode.Namespace.Policies)
-
-		// Step 2: Merge HTTPRoute and HTTPRoute-namespace policies by their kind.
-		httpRoutePoliciesByKind, err := policymanager.MergePoliciesOfSimilarKind(httpRoutePolicies)
+		result, err := calculateEffectivePoliciesForRoute(merger, httpRouteNode.Policies, httpRouteNode.Namespace, httpRouteNode.Gateways)
 		if err != nil {
 			return err
 		}
-		httpRouteNamespacePoliciesByKind, err := policymanager.MergePoliciesOfSimilarKind(httpRouteNamespacePolicies)
+		httpRouteNode.EffectivePolicies = result
+	}
+	return nil
+}
+
+// calculateEffectivePoliciesForOtherRoutes calculates the effective policies
+// for each route of another kind, in the same way as for HTTPRoutes.
+func (rm *ResourceModel) calculateEffectivePoliciesForOtherRoutes(merger *policyMerger) error {
+	for _, otherRouteNode := range rm.OtherRoutes {
+		result, err := calculateEffectivePoliciesForRoute(merger, otherRouteNode.Policies, otherRouteNode.Namespace, otherRouteNode.Gateways)
 		if err != nil {
 			return err
 		}
+		otherRouteNode.EffectivePolicies = result
+	}
+	return nil
+}
 
-		// Step 3: Loop through all Gateways and merge policies for each Gateway.
-		// End result is we get policies partitioned by each Gateway.
-		for gatewayID, gatewayNode := range httpRouteNode.Gateways {
-			gatewayPoliciesByKind := gatewayNode.EffectivePolicies
+// calculateEffectivePoliciesForRoute calculates the effective policies for a
+// route of any kind, partitioned by the Gateways the route is attached to,
+// given the policies directly applied to the route and its Namespace.
+func calculateEffectivePoliciesForRoute(merger *policyMerger, policies map[policyID]*PolicyNode, namespaceNode *NamespaceNode, gateways map[gatewayID]*GatewayNode) (map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy, error) {
+	result := make(map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy)
 
-			// Merge all hierarchial policies.
-			mergedPolicies, err := policymanager.MergePoliciesOfDifferentHierarchy(gatewayPoliciesByKind, httpRouteNamespacePoliciesByKind)
-			if err != nil {
-				return err
-			}
+	// Step 1: Aggregate all policies of the route and the route-namespace.
+	routePolicies := convertPoliciesMapToSlice(policies)
+	var routeNamespacePolicies []policymanager.Policy
+	if namespaceNode != nil {
+		routeNamespacePolicies = convertPoliciesMapToSlice(namespaceNode.Policies)
+	}
 
-			mergedPolicies, err = policymanager.MergePoliciesOfDifferentHierarchy(mergedPolicies, httpRoutePoliciesByKind)
-			if err != nil {
-				return err
-			}
+	// Step 2: Merge route and route-namespace policies by their kind.
+	routePoliciesByKind, err := merger.mergePoliciesOfSimilarKind(routePolicies)
+	if err != nil {
+		return nil, err
+	}
+	routeNamespacePoliciesByKind, err := merger.mergePoliciesOfSimilarKind(routeNamespacePolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 3: Loop through all Gateways and merge policies for each Gateway.
+	// End result is we get policies partitioned by each Gateway.
+	for gatewayID, gatewayNode := range gateways {
+		gatewayPoliciesByKind := gatewayNode.EffectivePolicies
 
-			result[gatewayID] = mergedPolicies
+		// Merge all hierarchial policies.
+		mergedPolicies, err := policymanager.MergePoliciesOfDifferentHierarchy(gatewayPoliciesByKind, routeNamespacePoliciesByKind)
+		if err != nil {
+			return nil, err
 		}
 
-		httpRouteNode.EffectivePolicies = result
+		mergedPolicies, err = policymanager.MergePoliciesOfDifferentHierarchy(mergedPolicies, routePoliciesByKind)
+		if err != nil {
+			return nil, err
+		}
+
+		result[gatewayID] = mergedPolicies
 	}
-	return nil
+	return result, nil
 }
 
 // calculateEffectivePoliciesForBackends calculates the effective policies for
 // each Backend, considering policies from different hierarchies (GatewayClass,
-// Namespace, Gateway, HTTPRoute, and Backend).
-func (rm *ResourceModel) calculateEffectivePoliciesForBackends() error {
+// Namespace, Gateway, routes of every kind, and Backend).
+func (rm *ResourceModel) calculateEffectivePoliciesForBackends(merger *policyMerger) error {
 	for _, backendNode := range rm.Backends {
 		result := make(map[gatewayID]map[policymanager.PolicyCrdID]policymanager.Policy)
 
@@ -451,21 +614,21 @@ func (rm *ResourceModel) calculateEffectivePoliciesForBackends() error {
 		backendNamespacePolicies := convertPoliciesMapToSlice(backendNode.Namespace.Policies)
 
 		// Step 2: Merge Backend and Backend-namespace policies by their kind.
-		backendPoliciesByKind, err := policymanager.MergePoliciesOfSimilarKind(backendPolicies)
+		backendPoliciesByKind, err := merger.mergePoliciesOfSimilarKind(backendPolicies)
 		if err != nil {
 			return err
 		}
-		backendNamespacePoliciesByKind, err := policymanager.MergePoliciesOfSimilarKind(backendNamespacePolicies)
+		backendNamespacePoliciesByKind, err := merger.mergePoliciesOfSimilarKind(backendNamespacePolicies)
 		if err != nil {
 			return err
 		}
 
-		// Step 3: Loop through all HTTPRoutes and get their effective policies. Merge
+		// Step 3: Loop through all routes and get their effective policies. Merge
 		// effective policies such that we get policies partitioned by Gateway.
-		for _, httpRouteNode := range backendNode.HTTPRoutes {
-			httpRoutePoliciesByGateway := httpRouteNode.EffectivePolicies
+		for _, routeNode := range backendNode.Routes {
+			routePoliciesByGateway := routeNode.EffectivePoliciesByGateway()
 
-			for gatewayID, policies := range httpRoutePoliciesByGateway {
+			for gatewayID, policies := range routePoliciesByGateway {
 				result[gatewayID], err = policymanager.MergePoliciesOfSameHierarchy(result[gatewayID], policies)
 				if err != nil {
 					return err
@@ -525,3 +688,14 @@ func ConvertPoliciesMapToPolicyRefs(policies map[policyID]*PolicyNode) []common.
 	}
 	return result
 }
+
+// ResolveBackend resolves the Backend referenced by a backendRef which
+// specifies the port, if any. The Backend is treated as non-existent if it is
+// not within the resourceModel.
+func (rm *ResourceModel) ResolveBackend(ref common.ObjRef, port *gatewayv1.PortNumber) relations.BackendResolution {
+	backendNode, ok := rm.Backends[BackendID(ref.Group, ref.Kind, ref.Namespace, ref.Name)]
+	if !ok {
+		return relations.ResolveBackend(ref, port, nil, nil)
+	}
+	return backendNode.Resolve(port)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/policymanager"
+)
+
+func TestPolicyMerger(t *testing.T) {
+	timeoutPolicy := func(name string, seconds int64) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "bar.com/v1",
+				"kind":       "TimeoutPolicy",
+				"metadata": map[string]interface{}{
+					"name":      name,
+					"namespace": "default",
+				},
+				"spec": map[string]interface{}{
+					"default": map[string]interface{}{
+						"seconds": seconds,
+					},
+					"targetRef": map[string]interface{}{
+						"kind": "Namespace",
+						"name": "default",
+					},
+				},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "timeoutpolicies.bar.com",
+				Labels: map[string]string{gatewayv1alpha2.PolicyLabelKey: "inherited"},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Scope:    apiextensionsv1.NamespaceScoped,
+				Group:    "bar.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1"}},
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Plural: "timeoutpolicies",
+					Kind:   "TimeoutPolicy",
+				},
+			},
+		},
+		timeoutPolicy("timeout-1", 10),
+		timeoutPolicy("timeout-2", 20),
+	}
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := policymanager.New(k8sClients.DC)
+	if err := policyManager.Init(context.Background()); err != nil {
+		t.Fatalf("Failed to initialize PolicyManager: %v", err)
+	}
+	policy1, found1 := policyManager.GetPolicy("default/timeout-1")
+	policy2, found2 := policyManager.GetPolicy("default/timeout-2")
+	if !found1 || !found2 {
+		t.Fatalf("GetPolicy() did not find the TimeoutPolicies")
+	}
+
+	merger := newPolicyMerger()
+	for _, policies := range [][]policymanager.Policy{
+		{policy1, policy2},
+		{policy1},
+		{policy1, policy2},
+	} {
+		got, err := merger.mergePoliciesOfSimilarKind(policies)
+		if err != nil {
+			t.Fatalf("mergePoliciesOfSimilarKind(%v) returned err=%v; want no error", policymanager.ToPolicyRefs(policies), err)
+		}
+		want, err := policymanager.MergePoliciesOfSimilarKind(policies)
+		if err != nil {
+			t.Fatalf("MergePoliciesOfSimilarKind(%v) returned err=%v; want no error", policymanager.ToPolicyRefs(policies), err)
+		}
+		if diff := cmp.Diff(want[policy1.PolicyCrdID()].Spec(), got[policy1.PolicyCrdID()].Spec()); diff != "" {
+			t.Errorf("mergePoliciesOfSimilarKind(%v) returned unexpected spec (-want, +got):\n%v", policymanager.ToPolicyRefs(policies), diff)
+		}
+	}
+
+	// Each distinct set of policies is merged only once.
+	if got, want := len(merger.merged), 2; got != want {
+		t.Errorf("len(merger.merged) = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+)
+
+// GatewayListener identifies a listener of a Gateway.
+type GatewayListener struct {
+	Gateway  types.NamespacedName
+	Listener gatewayv1.SectionName
+}
+
+// String returns the listener formatted as "<namespace>/<gateway>/<listener>".
+func (l GatewayListener) String() string {
+	return fmt.Sprintf("%v/%v", l.Gateway, l.Listener)
+}
+
+// ServedHostname is a hostname served on the port of a listener.
+type ServedHostname struct {
+	Hostname string
+	Port     gatewayv1.PortNumber
+}
+
+// ServedHostnames indexes the listeners through which each hostname is served
+// on each port. Only hostnames which some HTTPRoute attached to the listener
+// serves are indexed, i.e. the intersection of the hostnames of the listener
+// and the HTTPRoute. HTTPRoutes without hostnames attached to listeners
+// without a hostname serve any hostname rather than a particular one, so they
+// are not indexed.
+type ServedHostnames map[ServedHostname][]GatewayListener
+
+// DuplicateHostname is a hostname which is served on the same port by more
+// than one Gateway.
+type DuplicateHostname struct {
+	Hostname  string
+	Port      gatewayv1.PortNumber
+	Listeners []GatewayListener
+}
+
+// WildcardHostnameOverlap is a wildcard hostname served by some Gateway which
+// matches a more specific hostname served on the same port by a different
+// Gateway.
+type WildcardHostnameOverlap struct {
+	Wildcard          string
+	WildcardListeners []GatewayListener
+	Hostname          string
+	Listeners         []GatewayListener
+	Port              gatewayv1.PortNumber
+}
+
+// DiscoverServedHostnames indexes the hostnames served by the listeners of all
+// Gateways of the cluster.
+func (d Discoverer) DiscoverServedHostnames() (ServedHostnames, error) {
+	ctx := context.Background()
+	namespaceLabels, err := d.fetchNamespaceLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gateways, err := d.fetchGateways(ctx, Filter{ /* all Gateways */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	gatewaysByName := make(map[types.NamespacedName]*gatewayv1.Gateway)
+	for i := range gateways {
+		gatewaysByName[client.ObjectKeyFromObject(&gateways[i])] = &gateways[i]
+	}
+	httpRoutes, err := d.fetchHTTPRoutes(ctx, Filter{ /* all HTTPRoutes */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	return indexServedHostnames(httpRoutes, gatewaysByName, namespaceLabels), nil
+}
+
+// ListenerHostnames maps the listeners of Gateways which allow HTTPRoutes to
+// their hostname, which is empty for listeners matching any hostname.
+type ListenerHostnames map[GatewayListener]string
+
+// DiscoverListenerHostnames returns the hostnames of the listeners of all
+// Gateways of the cluster which allow HTTPRoutes.
+func (d Discoverer) DiscoverListenerHostnames() (ListenerHostnames, error) {
+	gateways, err := d.fetchGateways(context.Background(), Filter{ /* all Gateways */ Labels: labels.Everything()})
+	if err != nil {
+		return nil, err
+	}
+	result := make(ListenerHostnames)
+	for _, gateway := range gateways {
+		for _, listener := range gateway.Spec.Listeners {
+			if !listenerAllowsRouteKind(listener, "HTTPRoute") {
+				continue
+			}
+			hostname := ""
+			if listener.Hostname != nil {
+				hostname = string(*listener.Hostname)
+			}
+			result[GatewayListener{Gateway: client.ObjectKeyFromObject(&gateway), Listener: listener.Name}] = hostname
+		}
+	}
+	return result, nil
+}
+
+func indexServedHostnames(httpRoutes []gatewayv1.HTTPRoute, gateways map[types.NamespacedName]*gatewayv1.Gateway, namespaceLabels map[string]map[string]string) ServedHostnames {
+	result := make(ServedHostnames)
+	for _, httpRoute := range httpRoutes {
+		for _, attachment := range listenerAttachmentsForHTTPRoute(httpRoute, gateways, namespaceLabels) {
+			if attachment.Reason != "" {
+				continue
+			}
+			listener := GatewayListener{Gateway: attachment.Gateway, Listener: attachment.Listener}
+			port := listenerPort(gateways[attachment.Gateway], attachment.Listener)
+			for _, hostname := range attachment.Hostnames {
+				if hostname == "*" {
+					continue
+				}
+				key := ServedHostname{Hostname: hostname, Port: port}
+				if !slices.Contains(result[key], listener) {
+					result[key] = append(result[key], listener)
+				}
+			}
+		}
+	}
+	for _, listeners := range result {
+		sort.Slice(listeners, func(i, j int) bool {
+			return listeners[i].String() < listeners[j].String()
+		})
+	}
+	return result
+}
+
+// listenerPort returns the port of the listener of the Gateway, or 0 if the
+// Gateway has no such listener.
+func listenerPort(gateway *gatewayv1.Gateway, name gatewayv1.SectionName) gatewayv1.PortNumber {
+	if gateway == nil {
+		return 0
+	}
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.Name == name {
+			return listener.Port
+		}
+	}
+	return 0
+}
+
+// Duplicates returns the hostnames which are served on the same port by more
+// than one Gateway, sorted by hostname and port.
+func (s ServedHostnames) Duplicates() []DuplicateHostname {
+	var result []DuplicateHostname
+	for _, key := range s.sortedKeys() {
+		listeners := s[key]
+		if len(gatewaysOfListeners(listeners)) > 1 {
+			result = append(result, DuplicateHostname{Hostname: key.Hostname, Port: key.Port, Listeners: listeners})
+		}
+	}
+	return result
+}
+
+// WildcardOverlaps returns the wildcard hostnames which match a more specific
+// hostname served on the same port by a different Gateway, sorted by
+// wildcard, port and hostname.
+func (s ServedHostnames) WildcardOverlaps() []WildcardHostnameOverlap {
+	keys := s.sortedKeys()
+	var result []WildcardHostnameOverlap
+	for _, wildcard := range keys {
+		if !strings.HasPrefix(wildcard.Hostname, "*.") {
+			continue
+		}
+		wildcardGateways := gatewaysOfListeners(s[wildcard])
+		for _, key := range keys {
+			if key == wildcard || key.Port != wildcard.Port || !relations.WildcardHostnameMatches(wildcard.Hostname, key.Hostname) {
+				continue
+			}
+			gateways := gatewaysOfListeners(s[key])
+			// The overlap only matters if some other Gateway serves the hostname.
+			if len(wildcardGateways) == 1 && len(gateways) == 1 && wildcardGateways[0] == gateways[0] {
+				continue
+			}
+			result = append(result, WildcardHostnameOverlap{
+				Wildcard:          wildcard.Hostname,
+				WildcardListeners: s[wildcard],
+				Hostname:          key.Hostname,
+				Listeners:         s[key],
+				Port:              wildcard.Port,
+			})
+		}
+	}
+	return result
+}
+
+func (s ServedHostnames) sortedKeys() []ServedHostname {
+	result := make([]ServedHostname, 0, len(s))
+	for key := range s {
+		result = append(result, key)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Hostname != result[j].Hostname {
+			return result[i].Hostname < result[j].Hostname
+		}
+		return result[i].Port < result[j].Port
+	})
+	return result
+}
+
+// gatewaysOfListeners returns the distinct Gateways of the sorted listeners.
+func gatewaysOfListeners(listeners []GatewayListener) []types.NamespacedName {
+	var result []types.NamespacedName
+	for _, listener := range listeners {
+		if len(result) == 0 || result[len(result)-1] != listener.Gateway {
+			result = append(result, listener.Gateway)
+		}
+	}
+	return result
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestDiscoverServedHostnames(t *testing.T) {
+	gateway := func(namespace, name string, hostname gatewayv1.Hostname) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{{
+					Name:     "https",
+					Protocol: gatewayv1.HTTPSProtocolType,
+					Port:     443,
+					Hostname: &hostname,
+				}},
+			},
+		}
+	}
+	httpRoute := func(namespace, name, gatewayName string, hostnames ...gatewayv1.Hostname) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gatewayName)}},
+				},
+				Hostnames: hostnames,
+			},
+		}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("team-a"),
+		common.NamespaceForTest("team-b"),
+		common.NamespaceForTest("team-c"),
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+		},
+		gateway("team-a", "gateway-a", "api.example.com"),
+		gateway("team-b", "gateway-b", "*.example.com"),
+		gateway("team-c", "gateway-c", "*.example.com"),
+		// Both HTTPRoutes serve api.example.com, through different Gateways.
+		httpRoute("team-a", "route-a", "gateway-a"),
+		httpRoute("team-b", "route-b", "gateway-b", "api.example.com", "www.example.com", "api.example.org"),
+		// The wildcard is served by gateway-c only, and overlaps with the
+		// hostnames served by the other Gateways.
+		httpRoute("team-c", "route-c", "gateway-c"),
+	}
+	discoverer := Discoverer{K8sClients: common.MustClientsForTest(t, objects...)}
+
+	served, err := discoverer.DiscoverServedHostnames()
+	if err != nil {
+		t.Fatalf("DiscoverServedHostnames() returned an unexpected error: %v", err)
+	}
+
+	listenerA := GatewayListener{Gateway: types.NamespacedName{Namespace: "team-a", Name: "gateway-a"}, Listener: "https"}
+	listenerB := GatewayListener{Gateway: types.NamespacedName{Namespace: "team-b", Name: "gateway-b"}, Listener: "https"}
+	listenerC := GatewayListener{Gateway: types.NamespacedName{Namespace: "team-c", Name: "gateway-c"}, Listener: "https"}
+	// api.example.org does not match the hostname of the listener, so it is not
+	// served.
+	wantServed := ServedHostnames{
+		{Hostname: "api.example.com", Port: 443}: {listenerA, listenerB},
+		{Hostname: "www.example.com", Port: 443}: {listenerB},
+		{Hostname: "*.example.com", Port: 443}:   {listenerC},
+	}
+	if diff := cmp.Diff(wantServed, served); diff != "" {
+		t.Errorf("Unexpected diff in ServedHostnames (-want +got)=\n%v", diff)
+	}
+
+	wantDuplicates := []DuplicateHostname{
+		{Hostname: "api.example.com", Port: 443, Listeners: []GatewayListener{listenerA, listenerB}},
+	}
+	if diff := cmp.Diff(wantDuplicates, served.Duplicates()); diff != "" {
+		t.Errorf("Unexpected diff in Duplicates() (-want +got)=\n%v", diff)
+	}
+
+	wantOverlaps := []WildcardHostnameOverlap{
+		{Wildcard: "*.example.com", WildcardListeners: []GatewayListener{listenerC}, Hostname: "api.example.com", Listeners: []GatewayListener{listenerA, listenerB}, Port: 443},
+		{Wildcard: "*.example.com", WildcardListeners: []GatewayListener{listenerC}, Hostname: "www.example.com", Listeners: []GatewayListener{listenerB}, Port: 443},
+	}
+	if diff := cmp.Diff(wantOverlaps, served.WildcardOverlaps()); diff != "" {
+		t.Errorf("Unexpected diff in WildcardOverlaps() (-want +got)=\n%v", diff)
+	}
+	listenerHostnames, err := discoverer.DiscoverListenerHostnames()
+	if err != nil {
+		t.Fatalf("DiscoverListenerHostnames() returned an unexpected error: %v", err)
+	}
+	wantListenerHostnames := ListenerHostnames{
+		listenerA: "api.example.com",
+		listenerB: "*.example.com",
+		listenerC: "*.example.com",
+	}
+	if diff := cmp.Diff(wantListenerHostnames, listenerHostnames); diff != "" {
+		t.Errorf("Unexpected diff in ListenerHostnames (-want +got)=\n%v", diff)
+	}
+}
+
+func TestServedHostnames_WildcardOverlaps_SameGateway(t *testing.T) {
+	listener := GatewayListener{Gateway: types.NamespacedName{Namespace: "default", Name: "foo-gateway"}, Listener: "https"}
+	otherListener := GatewayListener{Gateway: types.NamespacedName{Namespace: "default", Name: "foo-gateway"}, Listener: "http"}
+	served := ServedHostnames{
+		{Hostname: "*.example.com", Port: 443}:   {listener},
+		{Hostname: "api.example.com", Port: 443}: {otherListener},
+	}
+	// A wildcard overlapping with a hostname of the same Gateway is not
+	// reported.
+	if got := served.WildcardOverlaps(); len(got) != 0 {
+		t.Errorf("WildcardOverlaps() = %v; want none", got)
+	}
+}
+
+func TestDiscoverServedHostnames_PortsAndCatchAll(t *testing.T) {
+	gateway := func(name string, port gatewayv1.PortNumber) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: "foo-gatewayclass",
+				Listeners: []gatewayv1.Listener{{
+					Name:     "http",
+					Protocol: gatewayv1.HTTPProtocolType,
+					Port:     port,
+				}},
+			},
+		}
+	}
+	httpRoute := func(name, gatewayName string, hostnames ...gatewayv1.Hostname) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gatewayName)}},
+				},
+				Hostnames: hostnames,
+			},
+		}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "foo-gatewayclass",
+			},
+		},
+		gateway("gateway-80", 80),
+		gateway("gateway-8080", 8080),
+		gateway("gateway-catch-all", 80),
+		// The same hostnames are served on different ports, so they neither
+		// duplicate nor overlap each other.
+		httpRoute("route-80", "gateway-80", "api.example.com"),
+		httpRoute("route-8080", "gateway-8080", "api.example.com", "*.example.com"),
+		// Neither the listener nor the HTTPRoute has a hostname, so no hostname
+		// in particular is served.
+		httpRoute("route-catch-all", "gateway-catch-all"),
+	}
+	discoverer := Discoverer{K8sClients: common.MustClientsForTest(t, objects...)}
+
+	served, err := discoverer.DiscoverServedHostnames()
+	if err != nil {
+		t.Fatalf("DiscoverServedHostnames() returned an unexpected error: %v", err)
+	}
+
+	listener80 := GatewayListener{Gateway: types.NamespacedName{Namespace: "default", Name: "gateway-80"}, Listener: "http"}
+	listener8080 := GatewayListener{Gateway: types.NamespacedName{Namespace: "default", Name: "gateway-8080"}, Listener: "http"}
+	wantServed := ServedHostnames{
+		{Hostname: "api.example.com", Port: 80}:   {listener80},
+		{Hostname: "api.example.com", Port: 8080}: {listener8080},
+		{Hostname: "*.example.com", Port: 8080}:   {listener8080},
+	}
+	if diff := cmp.Diff(wantServed, served); diff != "" {
+		t.Errorf("Unexpected diff in ServedHostnames (-want +got)=\n%v", diff)
+	}
+	if got := served.Duplicates(); len(got) != 0 {
+		t.Errorf("Duplicates() = %v; want none", got)
+	}
+	if got := served.WildcardOverlaps(); len(got) != 0 {
+		t.Errorf("WildcardOverlaps() = %v; want none", got)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+)
+
+// discoverSessionPersistenceConflicts reports the rules of HTTPRoutes in the
+// resourceModel which set sessionPersistence differently from a BackendLBPolicy
+// targeting one of the Backends of the rule. BackendLBPolicies are only
+// discovered for the Backends of rules which set sessionPersistence.
+func (d Discoverer) discoverSessionPersistenceConflicts(ctx context.Context, resourceModel *ResourceModel) {
+	type ruleBackend struct {
+		httpRouteNode *HTTPRouteNode
+		rule          int
+		backendRef    common.ObjRef
+		backendNode   *BackendNode
+	}
+	var ruleBackends []ruleBackend
+	backendNodes := make(map[backendID]*BackendNode)
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		for i, rule := range httpRouteNode.HTTPRoute.Spec.Rules {
+			if rule.SessionPersistence == nil {
+				continue
+			}
+			var backendRefs []gatewayv1.BackendObjectReference
+			for _, backendRef := range rule.BackendRefs {
+				backendRefs = append(backendRefs, backendRef.BackendObjectReference)
+			}
+			ruleBackendRefs := relations.FindBackendRefsForRoute(httpRouteNode.HTTPRoute.GetNamespace(), backendRefs)
+			sort.Slice(ruleBackendRefs, func(i, j int) bool {
+				return fmt.Sprint(ruleBackendRefs[i]) < fmt.Sprint(ruleBackendRefs[j])
+			})
+			for _, backendRef := range ruleBackendRefs {
+				backendID := BackendID(backendRef.Group, backendRef.Kind, backendRef.Namespace, backendRef.Name)
+				backendNode, ok := resourceModel.Backends[backendID]
+				if !ok {
+					continue
+				}
+				ruleBackends = append(ruleBackends, ruleBackend{httpRouteNode: httpRouteNode, rule: i, backendRef: backendRef, backendNode: backendNode})
+				backendNodes[backendID] = backendNode
+			}
+		}
+	}
+	if len(ruleBackends) == 0 {
+		return
+	}
+
+	var undiscovered []*BackendNode
+	for _, backendNode := range backendNodes {
+		if backendNode.BackendLBPolicies == nil {
+			undiscovered = append(undiscovered, backendNode)
+		}
+	}
+	d.discoverBackendLBPoliciesForBackends(ctx, undiscovered)
+
+	for _, rb := range ruleBackends {
+		sessionPersistence := rb.httpRouteNode.HTTPRoute.Spec.Rules[rb.rule].SessionPersistence
+		for _, backendLBPolicy := range rb.backendNode.BackendLBPolicies {
+			if backendLBPolicy.Spec.SessionPersistence == nil || equality.Semantic.DeepEqual(sessionPersistence, backendLBPolicy.Spec.SessionPersistence) {
+				continue
+			}
+			rb.httpRouteNode.Errors = append(rb.httpRouteNode.Errors, SessionPersistenceConflictError{
+				Rule:            rb.rule,
+				Backend:         rb.backendRef,
+				BackendLBPolicy: backendLBPolicy.GetName(),
+			})
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestDiscoverResourcesForHTTPRoute_SessionPersistenceConflicts(t *testing.T) {
+	backendRef := func(name string) gatewayv1.HTTPBackendRef {
+		return gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+			Group: common.PtrTo(gatewayv1.Group("")),
+			Kind:  common.PtrTo(gatewayv1.Kind("Service")),
+			Name:  gatewayv1.ObjectName(name),
+		}}}
+	}
+	service := func(name string) *corev1.Service {
+		return &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		}
+	}
+	backendLBPolicy := func(name, serviceName string, sessionPersistence *gatewayv1.SessionPersistence) *gatewayv1alpha2.BackendLBPolicy {
+		return &gatewayv1alpha2.BackendLBPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1alpha2.BackendLBPolicySpec{
+				TargetRefs:         []gatewayv1alpha2.LocalPolicyTargetReference{{Kind: "Service", Name: gatewayv1.ObjectName(serviceName)}},
+				SessionPersistence: sessionPersistence,
+			},
+		}
+	}
+	cookie := &gatewayv1.SessionPersistence{SessionName: common.PtrTo("cart-session")}
+	header := &gatewayv1.SessionPersistence{Type: common.PtrTo(gatewayv1.HeaderBasedSessionPersistence)}
+
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		service("cart-svc"),
+		service("checkout-svc"),
+		service("search-svc"),
+		backendLBPolicy("cart-lb", "cart-svc", header),
+		backendLBPolicy("checkout-lb", "checkout-svc", cookie),
+		backendLBPolicy("search-lb", "search-svc", header),
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop-httproute", Namespace: "default"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{
+					// Differs from cart-lb, and is the same as checkout-lb.
+					{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("cart-svc"), backendRef("checkout-svc")}, SessionPersistence: cookie},
+					// Does not set sessionPersistence, so search-lb applies.
+					{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("search-svc")}},
+				},
+			},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverResourcesForHTTPRoute(Filter{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	var got []SessionPersistenceConflictError
+	for _, err := range resourceModel.HTTPRoutes[HTTPRouteID("default", "shop-httproute")].Errors {
+		if conflictErr, ok := err.(SessionPersistenceConflictError); ok {
+			got = append(got, conflictErr)
+		}
+	}
+	want := []SessionPersistenceConflictError{{
+		Rule:            0,
+		Backend:         common.ObjRef{Kind: "Service", Namespace: "default", Name: "cart-svc"},
+		BackendLBPolicy: "cart-lb",
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected diff in sessionPersistence conflicts (-want +got):\n%v", diff)
+	}
+}
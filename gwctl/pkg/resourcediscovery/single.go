@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Single returns a shallow copy of the model in which the nodes of the kind
+// only include object, so that the object can be printed on its own. The
+// nodes are shared with the model, and the nodes of the other kinds are kept
+// since they are reachable from the node of the object anyway.
+func (rm *ResourceModel) Single(kind string, object client.Object) *ResourceModel {
+	single := *rm
+	switch kind {
+	case "GatewayClass":
+		single.GatewayClasses = singleNode(rm.GatewayClasses, object)
+	case "Gateway":
+		single.Gateways = singleNode(rm.Gateways, object)
+	case "HTTPRoute":
+		single.HTTPRoutes = singleNode(rm.HTTPRoutes, object)
+	case "Backend":
+		single.Backends = singleNode(rm.Backends, object)
+	case "Namespace":
+		single.Namespaces = singleNode(rm.Namespaces, object)
+	}
+	return &single
+}
+
+func singleNode[K comparable, N interface{ ClientObject() client.Object }](nodes map[K]N, object client.Object) map[K]N {
+	result := make(map[K]N)
+	for id, node := range nodes {
+		nodeObject := node.ClientObject()
+		if nodeObject.GetNamespace() == object.GetNamespace() && nodeObject.GetName() == object.GetName() {
+			result[id] = node
+		}
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+// IsGatewayClassStale returns true if the status of the GatewayClass has not
+// observed the latest generation of its spec.
+func IsGatewayClassStale(gatewayClass *gatewayv1.GatewayClass) bool {
+	return common.IsStale(gatewayClass.GetGeneration(), gatewayClass.Status.Conditions)
+}
+
+// IsGatewayStale returns true if the status of the Gateway has not observed the
+// latest generation of its spec.
+func IsGatewayStale(gateway *gatewayv1.Gateway) bool {
+	return common.IsStale(gateway.GetGeneration(), gateway.Status.Conditions)
+}
+
+// IsHTTPRouteStale returns true if the status of the HTTPRoute has not observed
+// the latest generation of its spec. The conditions reported for all parents
+// are considered.
+func IsHTTPRouteStale(httpRoute *gatewayv1.HTTPRoute) bool {
+	var conditions []metav1.Condition
+	for _, parent := range httpRoute.Status.Parents {
+		conditions = append(conditions, parent.Conditions...)
+	}
+	return common.IsStale(httpRoute.GetGeneration(), conditions)
+}
+
+// filterStale returns the items which are stale according to isStale.
+func filterStale[T any](items []T, isStale func(*T) bool) []T {
+	var result []T
+	for i := range items {
+		if isStale(&items[i]) {
+			result = append(result, items[i])
+		}
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/relations"
+)
+
+// SummaryGroupNone is the group used for resources which do not have a value
+// for the key by which resources are grouped.
+const SummaryGroupNone = "(none)"
+
+// SummaryGroupByFunc returns the group to which a resource belongs.
+type SummaryGroupByFunc func(obj client.Object) string
+
+// GroupByLabel groups resources by the value of the given label.
+func GroupByLabel(key string) SummaryGroupByFunc {
+	return func(obj client.Object) string {
+		if value, ok := obj.GetLabels()[key]; ok {
+			return value
+		}
+		return SummaryGroupNone
+	}
+}
+
+// GroupByNamespace groups resources by their namespace. Cluster-scoped
+// resources belong to SummaryGroupNone.
+func GroupByNamespace(obj client.Object) string {
+	if namespace := obj.GetNamespace(); namespace != "" {
+		return namespace
+	}
+	return SummaryGroupNone
+}
+
+// ResourceSummary aggregates the number of resources within a single group.
+type ResourceSummary struct {
+	Group      string `json:"group"`
+	Gateways   int    `json:"gateways"`
+	HTTPRoutes int    `json:"httpRoutes"`
+	Backends   int    `json:"backends"`
+	Policies   int    `json:"policies"`
+	// MaxRouteFanout is the highest number of distinct Backends referenced by
+	// a single HTTPRoute within the group.
+	MaxRouteFanout int `json:"maxRouteFanout"`
+}
+
+// Summarize aggregates the Gateways, HTTPRoutes, Backends and Policies within
+// the resourceModel into groups returned by groupBy. The result is sorted by
+// group.
+func Summarize(resourceModel *ResourceModel, groupBy SummaryGroupByFunc) []ResourceSummary {
+	summaries := make(map[string]*ResourceSummary)
+	summaryFor := func(obj client.Object) *ResourceSummary {
+		group := groupBy(obj)
+		summary, ok := summaries[group]
+		if !ok {
+			summary = &ResourceSummary{Group: group}
+			summaries[group] = summary
+		}
+		return summary
+	}
+
+	for _, gatewayNode := range resourceModel.Gateways {
+		summaryFor(gatewayNode.Gateway).Gateways++
+	}
+	var httpRoutes []gatewayv1.HTTPRoute
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		summaryFor(httpRouteNode.HTTPRoute).HTTPRoutes++
+		httpRoutes = append(httpRoutes, *httpRouteNode.HTTPRoute)
+	}
+	fanout := relations.ComputeRouteFanout(httpRoutes)
+	for _, httpRouteNode := range resourceModel.HTTPRoutes {
+		summary := summaryFor(httpRouteNode.HTTPRoute)
+		summary.MaxRouteFanout = max(summary.MaxRouteFanout, fanout[client.ObjectKeyFromObject(httpRouteNode.HTTPRoute)])
+	}
+	for _, backendNode := range resourceModel.Backends {
+		summaryFor(backendNode.Backend).Backends++
+	}
+	for _, policyNode := range resourceModel.Policies {
+		summaryFor(policyNode.Policy.Unstructured()).Policies++
+	}
+
+	result := make([]ResourceSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Group < result[j].Group
+	})
+	return result
+}
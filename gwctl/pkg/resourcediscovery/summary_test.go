@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/utils"
+)
+
+func TestSummarize(t *testing.T) {
+	teamLabel := func(team string) map[string]string {
+		return map[string]string{"team": team}
+	}
+	objects := []runtime.Object{
+		common.NamespaceForTest("default"),
+		common.NamespaceForTest("ns2"),
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway-1", Namespace: "default", Labels: teamLabel("payments")},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway-2", Namespace: "ns2"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "foo-gatewayclass"},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "attached-route", Namespace: "default", Labels: teamLabel("payments")},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "gateway-1"}},
+				},
+			},
+		},
+		&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "unattached-route", Namespace: "ns2", Labels: teamLabel("search")},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{{
+					BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{Name: "svc-1"},
+					}}},
+				}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc-1", Namespace: "ns2", Labels: teamLabel("search")},
+		},
+	}
+
+	k8sClients := common.MustClientsForTest(t, objects...)
+	policyManager := utils.MustPolicyManagerForTest(t, k8sClients)
+	discoverer := Discoverer{
+		K8sClients:    k8sClients,
+		PolicyManager: policyManager,
+	}
+	resourceModel, err := discoverer.DiscoverAllResources(Filter{Labels: labels.Everything()})
+	if err != nil {
+		t.Fatalf("Failed to construct resourceModel: %v", err)
+	}
+
+	testcases := []struct {
+		name    string
+		groupBy SummaryGroupByFunc
+		want    []ResourceSummary
+	}{
+		{
+			name:    "group by label",
+			groupBy: GroupByLabel("team"),
+			want: []ResourceSummary{
+				{Group: "(none)", Gateways: 1},
+				{Group: "payments", Gateways: 1, HTTPRoutes: 1},
+				{Group: "search", HTTPRoutes: 1, Backends: 1, MaxRouteFanout: 1},
+			},
+		},
+		{
+			name:    "group by namespace",
+			groupBy: GroupByNamespace,
+			want: []ResourceSummary{
+				{Group: "default", Gateways: 1, HTTPRoutes: 1},
+				{Group: "ns2", Gateways: 1, HTTPRoutes: 1, Backends: 1, MaxRouteFanout: 1},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Summarize(resourceModel, tc.groupBy)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Summarize() returned unexpected diff (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+// GatewayClassUsage counts the resources served by the implementation of a
+// GatewayClass, like for sizing the implementation.
+type GatewayClassUsage struct {
+	// Name is the name of the GatewayClass.
+	Name string `json:"name"`
+	// Gateways is the number of Gateways of the GatewayClass.
+	Gateways int `json:"gateways"`
+	// Listeners is the total number of listeners of the Gateways.
+	Listeners int `json:"listeners"`
+	// AttachedRoutes is the number of distinct HTTPRoutes attached to the
+	// Gateways, plus the routes of other kinds attached to each Gateway.
+	AttachedRoutes int `json:"attachedRoutes"`
+	// BackendServices is the number of distinct existing Services referenced by
+	// the HTTPRoutes.
+	BackendServices int `json:"backendServices"`
+}
+
+// UsageForGatewayClass counts the resources related to the GatewayClass within
+// the resourceModel. The routes and Backends are only known if the
+// resourceModel was discovered with Discoverer.DiscoverUsage.
+func UsageForGatewayClass(gatewayClassNode *GatewayClassNode) GatewayClassUsage {
+	result := GatewayClassUsage{
+		Name:     gatewayClassNode.GatewayClass.GetName(),
+		Gateways: len(gatewayClassNode.Gateways),
+	}
+	httpRoutes := make(map[httpRouteID]bool)
+	services := make(map[backendID]bool)
+	for _, gatewayNode := range gatewayClassNode.Gateways {
+		result.Listeners += len(gatewayNode.Gateway.Spec.Listeners)
+		for _, count := range gatewayNode.AttachedRoutesByKind {
+			result.AttachedRoutes += count
+		}
+		for id, httpRouteNode := range gatewayNode.HTTPRoutes {
+			httpRoutes[id] = true
+			for id := range httpRouteNode.Backends {
+				if id == BackendIDForService(id.Namespace, id.Name) {
+					services[id] = true
+				}
+			}
+		}
+	}
+	result.AttachedRoutes += len(httpRoutes)
+	result.BackendServices = len(services)
+	return result
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+// whereVariables are the top-level fields of objects which CEL expressions can
+// refer to directly, in addition to the whole object as "object".
+var whereVariables = []string{"apiVersion", "kind", "metadata", "spec", "status"}
+
+// WhereExpression is a compiled CEL expression which objects are matched
+// against, like `spec.hostnames.size() > 1`.
+type WhereExpression struct {
+	expression string
+	program    cel.Program
+}
+
+// CompileWhereExpression compiles the CEL expression. The expression can refer
+// to the top-level fields of objects (apiVersion, kind, metadata, spec and
+// status), or to the whole object as "object", and must evaluate to a bool.
+func CompileWhereExpression(expression string) (*WhereExpression, error) {
+	opts := []cel.EnvOption{cel.Variable("object", cel.DynType)}
+	for _, variable := range whereVariables {
+		opts = append(opts, cel.Variable(variable, cel.DynType))
+	}
+	env, err := cel.NewEnv(opts...)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must evaluate to a bool, not %v", ast.OutputType())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	return &WhereExpression{expression: expression, program: program}, nil
+}
+
+// Matches returns true if the expression evaluates to true for the object. An
+// expression which fails to evaluate, like one referring to a field which the
+// object does not set, does not match, and the reason is returned as an error.
+func (w *WhereExpression) Matches(obj client.Object) (bool, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert object to unstructured: %v", err)
+	}
+	if gvk := objectGVK(obj); !gvk.Empty() {
+		content["apiVersion"], content["kind"] = gvk.GroupVersion().String(), gvk.Kind
+	}
+	activation := map[string]any{"object": content}
+	for _, variable := range whereVariables {
+		// Unset fields are bound to empty maps, so that expressions can test
+		// them with has().
+		value, ok := content[variable]
+		if !ok {
+			value = map[string]any{}
+		}
+		activation[variable] = value
+	}
+	out, _, err := w.program.Eval(activation)
+	if err != nil {
+		return false, err
+	}
+	matches, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression evaluated to %v, not a bool", out.Type())
+	}
+	return matches, nil
+}
+
+// RestrictToWhereExpression removes the resources of the kind from the
+// resourceModel which do not match the expression. The kind is one of
+// GatewayClass, Gateway, HTTPRoute, Backend or Namespace. Resources for which
+// the expression fails to evaluate are removed as well, and reported to
+// warnings.
+func (rm *ResourceModel) RestrictToWhereExpression(kind string, where *WhereExpression, warnings *common.Warnings) {
+	switch kind {
+	case "GatewayClass":
+		restrictNodesToWhereExpression(rm.GatewayClasses, kind, where, warnings)
+	case "Gateway":
+		restrictNodesToWhereExpression(rm.Gateways, kind, where, warnings)
+	case "HTTPRoute":
+		restrictNodesToWhereExpression(rm.HTTPRoutes, kind, where, warnings)
+	case "Backend":
+		restrictNodesToWhereExpression(rm.Backends, kind, where, warnings)
+	case "Namespace":
+		restrictNodesToWhereExpression(rm.Namespaces, kind, where, warnings)
+	}
+}
+
+func restrictNodesToWhereExpression[K comparable, N interface{ ClientObject() client.Object }](nodes map[K]N, kind string, where *WhereExpression, warnings *common.Warnings) {
+	for id, node := range nodes {
+		obj := node.ClientObject()
+		matches, err := where.Matches(obj)
+		if err != nil {
+			gvk := objectGVK(obj)
+			if gvk.Kind != "" {
+				kind = gvk.Kind
+			}
+			warnings.Add(common.ObjRef{Group: gvk.Group, Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()},
+				fmt.Sprintf("failed to evaluate the --where expression %q: %v", where.expression, err))
+		}
+		if !matches {
+			delete(nodes, id)
+		}
+	}
+}
+
+// objectGVK returns the GroupVersionKind of the object. Objects fetched through
+// the typed client do not have their TypeMeta set, so their kind is looked up
+// in the scheme.
+func objectGVK(obj client.Object) schema.GroupVersionKind {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() {
+		if gvks, _, err := scheme.Scheme.ObjectKinds(obj); err == nil {
+			gvk = gvks[0]
+		}
+	}
+	return gvk
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcediscovery
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/maps"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+)
+
+func TestCompileWhereExpression(t *testing.T) {
+	testcases := []struct {
+		expression string
+		wantErr    bool
+	}{
+		{expression: "spec.hostnames.size() > 1"},
+		{expression: `metadata.labels["tier"] == "internal"`},
+		{expression: `has(object.status)`},
+		{expression: "spec.hostnames.size() >", wantErr: true},
+		{expression: "1 + 1", wantErr: true},
+		{expression: "unknown.field", wantErr: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.expression, func(t *testing.T) {
+			_, err := CompileWhereExpression(tc.expression)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("CompileWhereExpression(%q) returned err=%v; want err=%v", tc.expression, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceModel_RestrictToWhereExpression(t *testing.T) {
+	httpRoute := func(name string, hostnames ...gatewayv1.Hostname) gatewayv1.HTTPRoute {
+		return gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": name}},
+			Spec:       gatewayv1.HTTPRouteSpec{Hostnames: hostnames},
+		}
+	}
+
+	// The kinds of typed objects are looked up in the scheme.
+	if err := gatewayv1.Install(scheme.Scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		expression   string
+		want         []httpRouteID
+		wantWarnings []common.Warning
+	}{
+		{
+			expression: "has(spec.hostnames) && spec.hostnames.size() > 1",
+			want:       []httpRouteID{HTTPRouteID("default", "two-hostnames")},
+		},
+		{
+			expression: `has(spec.hostnames) && spec.hostnames.exists(h, h.endsWith(".org"))`,
+			want:       []httpRouteID{HTTPRouteID("default", "two-hostnames")},
+		},
+		{
+			expression: `metadata.labels.app == "no-hostnames"`,
+			want:       []httpRouteID{HTTPRouteID("default", "no-hostnames")},
+		},
+		{
+			expression: `!has(spec.hostnames)`,
+			want:       []httpRouteID{HTTPRouteID("default", "no-hostnames")},
+		},
+		{
+			expression: `kind == "HTTPRoute" && apiVersion == "gateway.networking.k8s.io/v1" && metadata.name == "one-hostname"`,
+			want:       []httpRouteID{HTTPRouteID("default", "one-hostname")},
+		},
+		{
+			expression: `spec.hostnames[0] == "example.com"`,
+			want:       []httpRouteID{HTTPRouteID("default", "one-hostname"), HTTPRouteID("default", "two-hostnames")},
+			wantWarnings: []common.Warning{{
+				Resource: common.ObjRef{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "default", Name: "no-hostnames"},
+				Message:  `failed to evaluate the --where expression "spec.hostnames[0] == \"example.com\"": no such key: hostnames`,
+			}},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.expression, func(t *testing.T) {
+			resourceModel := &ResourceModel{}
+			resourceModel.addHTTPRoutes(
+				httpRoute("no-hostnames"),
+				httpRoute("one-hostname", "example.com"),
+				httpRoute("two-hostnames", "example.com", "example.org"),
+			)
+			where, err := CompileWhereExpression(tc.expression)
+			if err != nil {
+				t.Fatalf("CompileWhereExpression() returned an unexpected error: %v", err)
+			}
+			warnings := &common.Warnings{}
+			resourceModel.RestrictToWhereExpression("HTTPRoute", where, warnings)
+
+			got := maps.Keys(resourceModel.HTTPRoutes)
+			sort.Slice(got, func(i, j int) bool { return got[i].Name < got[j].Name })
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("RestrictToWhereExpression() returned unexpected diff (-want +got):\n%v", diff)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, warnings.List()); diff != "" {
+				t.Errorf("RestrictToWhereExpression() reported unexpected diff in warnings (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"sigs.k8s.io/gateway-api/gwctl/pkg/analysis"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/printer"
+	"sigs.k8s.io/gateway-api/gwctl/pkg/resourcediscovery"
+)
+
+// Output is a kind of enriched JSON output of gwctl.
+type Output struct {
+	// Name identifies the output, like "listeners".
+	Name string
+	// Description tells which commands print the output.
+	Description string
+	// Type is the Go type which the output is the JSON encoding of.
+	Type reflect.Type
+}
+
+// Outputs are the enriched outputs of gwctl, which are computed by gwctl
+// rather than fetched from the cluster. Resources themselves are printed as
+// they are fetched, so their schema is that of their CRD.
+var Outputs = []Output{
+	{
+		Name:        "certificates",
+		Description: "The certificates of the listeners of Gateways, printed by `gwctl get certificates -o json`.",
+		Type:        reflect.TypeOf([]resourcediscovery.CertificateView{}),
+	},
+	{
+		Name:        "findings",
+		Description: "The findings of the analysis of manifests, printed by `gwctl analyze -o json`.",
+		Type:        reflect.TypeOf([]analysis.Finding{}),
+	},
+	{
+		Name:        "gateway-comparison",
+		Description: "The differences between two Gateways, printed by `gwctl compare gateways -o json`.",
+		Type:        reflect.TypeOf(resourcediscovery.GatewayComparison{}),
+	},
+	{
+		Name:        "gatewayclass-usage",
+		Description: "The usage field of the List printed by `gwctl get gatewayclasses --with-usage -o json`.",
+		Type:        reflect.TypeOf([]resourcediscovery.GatewayClassUsage{}),
+	},
+	{
+		Name:        "listeners",
+		Description: "The listeners of Gateways, printed by `gwctl get listeners -o json`.",
+		Type:        reflect.TypeOf([]resourcediscovery.ListenerView{}),
+	},
+	{
+		Name:        "permissions",
+		Description: "The permissions of the current user, printed by `gwctl auth check -o json`.",
+		Type:        reflect.TypeOf([]common.PermissionCheck{}),
+	},
+	{
+		Name:        "probe-report",
+		Description: "The results of probing the listeners of a Gateway, printed by `gwctl check gateways -o json`.",
+		Type:        reflect.TypeOf(common.ProbeReport{}),
+	},
+	{
+		Name:        "query-results",
+		Description: "The results of a query without a projection, printed by `gwctl query -o json`.",
+		Type:        reflect.TypeOf([]printer.QueryResultRef{}),
+	},
+	{
+		Name:        "references",
+		Description: "The resources referencing a resource, printed by `gwctl describe services --referenced-by -o yaml`.",
+		Type:        reflect.TypeOf(printer.ReferencesView{}),
+	},
+	{
+		Name:        "summary",
+		Description: "The number of resources per group, printed by `gwctl summary -o json`.",
+		Type:        reflect.TypeOf([]resourcediscovery.ResourceSummary{}),
+	},
+}
+
+// LookupOutput returns the output with the name.
+func LookupOutput(name string) (Output, error) {
+	var names []string
+	for _, output := range Outputs {
+		if output.Name == name {
+			return output, nil
+		}
+		names = append(names, output.Name)
+	}
+	return Output{}, fmt.Errorf("unknown output %q; must be one of [%v]", name, strings.Join(names, ", "))
+}
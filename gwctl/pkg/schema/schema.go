@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schema generates the JSON schema of the enriched output of gwctl,
+// like the listeners of Gateways or the graph of references between
+// resources, so that tools consuming that output have a stable contract.
+//
+// Schemas are generated from the Go types of the output, following the rules
+// of encoding/json: field names come from the json tags, fields with omitempty
+// are optional, embedded structs are flattened into their parent, and nil
+// pointers, slices and maps are encoded as null.
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Draft is the JSON schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON schema.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// stringTypes are the types which marshal themselves to a JSON string,
+	// along with the format of the string, if any.
+	stringTypes = map[reflect.Type]string{
+		reflect.TypeOf(time.Time{}):       "date-time",
+		reflect.TypeOf(metav1.Time{}):     "date-time",
+		reflect.TypeOf(metav1.Duration{}): "",
+	}
+)
+
+// generator generates the schemas of types, collecting the schemas of the
+// named structs they reference as definitions.
+type generator struct {
+	defs map[string]*Schema
+}
+
+// For returns the schema of the JSON encoding of a value of type t. Named
+// structs are defined in $defs and referenced by their qualified Go name, like
+// "common.ObjRef".
+func For(t reflect.Type) *Schema {
+	g := &generator{defs: make(map[string]*Schema)}
+	s := g.schemaFor(t)
+	s.Schema = Draft
+	s.Defs = g.defs
+	return s
+}
+
+// ForOutputs returns a single schema defining each of the outputs, which a
+// document validates against if it is any one of them.
+func ForOutputs(outputs []Output) *Schema {
+	g := &generator{defs: make(map[string]*Schema)}
+	s := &Schema{Schema: Draft}
+	for _, output := range outputs {
+		outputSchema := g.schemaFor(output.Type)
+		outputSchema.Description = output.Description
+		g.defs[output.Name] = outputSchema
+		s.AnyOf = append(s.AnyOf, &Schema{Ref: "#/$defs/" + output.Name})
+	}
+	s.Defs = g.defs
+	return s
+}
+
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return nullable(g.nonNullSchemaFor(t))
+	default:
+		return g.nonNullSchemaFor(t)
+	}
+}
+
+// nullable returns a schema which also accepts null, like the encoding of a nil
+// pointer, slice or map.
+func nullable(s *Schema) *Schema {
+	if reflect.DeepEqual(s, &Schema{}) {
+		// Any value is accepted already.
+		return s
+	}
+	return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+}
+
+// nonNullSchemaFor returns the schema of the JSON encoding of the non-nil
+// values of type t.
+func (g *generator) nonNullSchemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if format, ok := stringTypes[t]; ok {
+		return &Schema{Type: "string", Format: format}
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		// The encoding cannot be known from the type, so any value is accepted.
+		return &Schema{}
+	}
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings.
+			return &Schema{Type: "string"}
+		}
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := t.String()
+		if _, ok := g.defs[name]; !ok {
+			// The definition is registered before its fields are generated, so
+			// that recursive types terminate.
+			g.defs[name] = &Schema{}
+			*g.defs[name] = *g.structSchema(t)
+		}
+		return &Schema{Ref: "#/$defs/" + name}
+	default:
+		// Interfaces may hold any value.
+		return &Schema{}
+	}
+}
+
+// structSchema returns the schema of a struct, whose properties are its
+// exported fields along with those of its embedded structs.
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(s, t)
+	return s
+}
+
+// addFields adds the fields of the struct to the properties of the schema.
+// Fields of embedded structs are added after the others, since fields of the
+// outer struct take precedence over them.
+func (g *generator) addFields(s *Schema, t reflect.Type) {
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			embedded = append(embedded, fieldType)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := s.Properties[name]; ok {
+			continue
+		}
+		s.Properties[name] = g.schemaFor(field.Type)
+		if !strings.Contains(","+opts+",", ",omitempty,") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	for _, embeddedType := range embedded {
+		g.addFields(s, embeddedType)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type testBase struct {
+	Name string `json:"name"`
+	Kind string `json:"kind,omitempty"`
+}
+
+type testNode struct {
+	testBase
+	Kind     string              `json:"kind"`
+	Created  *metav1.Time        `json:"created,omitempty"`
+	Labels   map[string]string   `json:"labels,omitempty"`
+	Children []testNode          `json:"children"`
+	Ignored  string              `json:"-"`
+	Extra    any                 `json:",omitempty"`
+	Counts   map[string][]uint16 `json:"counts,omitempty"`
+	internal string
+}
+
+func TestFor(t *testing.T) {
+	got, err := json.MarshalIndent(For(reflect.TypeOf([]testNode{})), "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal the schema: %v", err)
+	}
+
+	want := `
+{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "anyOf": [
+    {
+      "type": "array",
+      "items": {
+        "$ref": "#/$defs/schema.testNode"
+      }
+    },
+    {
+      "type": "null"
+    }
+  ],
+  "$defs": {
+    "schema.testNode": {
+      "type": "object",
+      "properties": {
+        "Extra": {},
+        "children": {
+          "anyOf": [
+            {
+              "type": "array",
+              "items": {
+                "$ref": "#/$defs/schema.testNode"
+              }
+            },
+            {
+              "type": "null"
+            }
+          ]
+        },
+        "counts": {
+          "anyOf": [
+            {
+              "type": "object",
+              "additionalProperties": {
+                "anyOf": [
+                  {
+                    "type": "array",
+                    "items": {
+                      "type": "integer"
+                    }
+                  },
+                  {
+                    "type": "null"
+                  }
+                ]
+              }
+            },
+            {
+              "type": "null"
+            }
+          ]
+        },
+        "created": {
+          "anyOf": [
+            {
+              "type": "string",
+              "format": "date-time"
+            },
+            {
+              "type": "null"
+            }
+          ]
+        },
+        "kind": {
+          "type": "string"
+        },
+        "labels": {
+          "anyOf": [
+            {
+              "type": "object",
+              "additionalProperties": {
+                "type": "string"
+              }
+            },
+            {
+              "type": "null"
+            }
+          ]
+        },
+        "name": {
+          "type": "string"
+        }
+      },
+      "required": [
+        "kind",
+        "children",
+        "name"
+      ]
+    }
+  }
+}
+`
+	if diff := cmp.Diff(strings.TrimSpace(want), string(got)); diff != "" {
+		t.Errorf("For(...) returned unexpected diff (-want, +got):\n%v", diff)
+	}
+}
+
+func TestForOutputs(t *testing.T) {
+	s := ForOutputs(Outputs)
+	if len(s.AnyOf) != len(Outputs) {
+		t.Errorf("ForOutputs(...) returned %d alternatives; want %d", len(s.AnyOf), len(Outputs))
+	}
+
+	// Every reference must resolve to a definition.
+	defs := s.Defs
+	var checkRefs func(path string, s *Schema)
+	checkRefs = func(path string, s *Schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			if _, ok := defs[strings.TrimPrefix(s.Ref, "#/$defs/")]; !ok {
+				t.Errorf("%v: reference %q is not defined", path, s.Ref)
+			}
+		}
+		for name, property := range s.Properties {
+			checkRefs(path+"."+name, property)
+		}
+		checkRefs(path+"[]", s.Items)
+		checkRefs(path+"{}", s.AdditionalProperties)
+		for _, alternative := range s.AnyOf {
+			checkRefs(path, alternative)
+		}
+	}
+	checkRefs("", s)
+	for name, def := range defs {
+		checkRefs(name, def)
+	}
+}
+
+func TestLookupOutput(t *testing.T) {
+	if _, err := LookupOutput("listeners"); err != nil {
+		t.Errorf("LookupOutput(%q) returned err=%v; want no error", "listeners", err)
+	}
+	if _, err := LookupOutput("unknown"); err == nil {
+		t.Errorf("LookupOutput(%q) returned no error; want error", "unknown")
+	}
+}
@@ -20,8 +20,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"golang.org/x/term"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/gateway-api/gwctl/pkg/common"
@@ -43,17 +49,45 @@ type Factory interface {
 
 	K8sClients() (*common.K8sClients, error)
 	PolicyManager() (*policymanager.PolicyManager, error)
+	// Progress returns where the progress of slow operations is reported.
+	Progress() common.Progress
+	// Warnings returns where the warnings found while running a command are
+	// collected. It returns the same collector for the lifetime of the Factory.
+	Warnings() *common.Warnings
+	// ForContext returns a Factory whose clients connect to the cluster of the
+	// context of the kubeconfig, rather than of its current context. Warnings
+	// are collected along with those of this Factory.
+	ForContext(context string) Factory
 }
 
 type factoryImpl struct {
-	kubeConfigPath *string
+	kubeConfigPath    *string
+	impersonate       *rest.ImpersonationConfig
+	noProgress        *bool
+	atResourceVersion *string
+	fromSnapshot      *string
+	// context is the context of the kubeconfig, or empty for its current
+	// context.
+	context string
 
 	k8sClients    *common.K8sClients
 	policyManager *policymanager.PolicyManager
+	warnings      *common.Warnings
 }
 
-func NewFactory(kubeConfigPath *string) Factory {
-	return &factoryImpl{kubeConfigPath: kubeConfigPath}
+// NewFactory returns a Factory whose clients list all resources at exactly
+// atResourceVersion, so that commands see a consistent snapshot of the
+// cluster. If it is empty, resources are listed at a resourceVersion not older
+// than that of the first list, see common.NewSnapshotK8sClients. If
+// fromSnapshot is not empty, the clients instead serve the resources of the
+// snapshot saved in that file, without connecting to any cluster. Requests are
+// made as the identity of impersonate, if it has a UserName.
+func NewFactory(kubeConfigPath *string, impersonate *rest.ImpersonationConfig, noProgress *bool, atResourceVersion *string, fromSnapshot *string) Factory {
+	return &factoryImpl{kubeConfigPath: kubeConfigPath, impersonate: impersonate, noProgress: noProgress, atResourceVersion: atResourceVersion, fromSnapshot: fromSnapshot, warnings: &common.Warnings{}}
+}
+
+func (f *factoryImpl) ForContext(context string) Factory {
+	return &factoryImpl{kubeConfigPath: f.kubeConfigPath, impersonate: f.impersonate, noProgress: f.noProgress, atResourceVersion: f.atResourceVersion, fromSnapshot: f.fromSnapshot, context: context, warnings: f.warnings}
 }
 
 func (f *factoryImpl) K8sClients() (*common.K8sClients, error) {
@@ -61,15 +95,43 @@ func (f *factoryImpl) K8sClients() (*common.K8sClients, error) {
 		return f.k8sClients, nil
 	}
 
+	if f.fromSnapshot != nil && *f.fromSnapshot != "" {
+		if f.context != "" {
+			return nil, fmt.Errorf("a context cannot be used with a snapshot")
+		}
+		snapshot, err := common.ReadClusterSnapshotFile(*f.fromSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		k8sClients, err := snapshot.K8sClients()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create k8s clients for snapshot: %v", err)
+		}
+		f.k8sClients = k8sClients
+		return f.k8sClients, nil
+	}
+
 	if f.kubeConfigPath == nil {
 		return nil, fmt.Errorf("kubeConfigPath is nil")
 	}
 
-	k8sClients, err := common.NewK8sClients(*f.kubeConfigPath)
+	var impersonate rest.ImpersonationConfig
+	if f.impersonate != nil {
+		impersonate = *f.impersonate
+	}
+	var resourceVersion string
+	if f.atResourceVersion != nil {
+		resourceVersion = *f.atResourceVersion
+	}
+	if f.context != "" && resourceVersion != "" {
+		// resourceVersions are specific to a cluster.
+		return nil, fmt.Errorf("a resourceVersion cannot be used with a context")
+	}
+	k8sClients, err := common.NewK8sClients(*f.kubeConfigPath, f.context, impersonate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create k8s clients: %v", err)
 	}
-	f.k8sClients = k8sClients
+	f.k8sClients = common.NewSnapshotK8sClients(k8sClients, resourceVersion)
 	return f.k8sClients, nil
 }
 
@@ -89,6 +151,19 @@ func (f *factoryImpl) PolicyManager() (*policymanager.PolicyManager, error) {
 	return f.policyManager, nil
 }
 
+// Progress returns Progress drawn on stderr, unless stderr is not a terminal
+// or progress has been disabled.
+func (f *factoryImpl) Progress() common.Progress {
+	if (f.noProgress != nil && *f.noProgress) || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return common.NoProgress{}
+	}
+	return common.NewTerminalProgress(os.Stderr)
+}
+
+func (f *factoryImpl) Warnings() *common.Warnings {
+	return f.warnings
+}
+
 func MustPolicyManagerForTest(t *testing.T, fakeClients *common.K8sClients) *policymanager.PolicyManager {
 	policyManager := policymanager.New(fakeClients.DC)
 	if err := policyManager.Init(context.Background()); err != nil {
@@ -104,6 +179,12 @@ const (
 	OutputFormatJSON  OutputFormat = "json"
 	OutputFormatYAML  OutputFormat = "yaml"
 	OutputFormatTable OutputFormat = ""
+	// OutputFormatName only prints the type and name of each resource, which
+	// allows resources to be discovered from their metadata alone.
+	OutputFormatName OutputFormat = "name"
+	// OutputFormatHelmValues prints resources as the values of a Helm chart,
+	// grouped by kind and keyed by name.
+	OutputFormatHelmValues OutputFormat = "helm-values"
 )
 
 func ValidateAndReturnOutputFormat(format string) (OutputFormat, error) {
@@ -114,6 +195,10 @@ func ValidateAndReturnOutputFormat(format string) (OutputFormat, error) {
 		return OutputFormatJSON, nil
 	case "yaml":
 		return OutputFormatYAML, nil
+	case "name":
+		return OutputFormatName, nil
+	case "helm-values":
+		return OutputFormatHelmValues, nil
 	case "":
 		return OutputFormatTable, nil
 	default:
@@ -122,6 +207,39 @@ func ValidateAndReturnOutputFormat(format string) (OutputFormat, error) {
 	}
 }
 
+// SortKey is the key by which resources are sorted when printed as a table.
+type SortKey string
+
+const (
+	SortKeyName     SortKey = "name"
+	SortKeyPolicies SortKey = "policies"
+)
+
+func ValidateAndReturnSortKey(key string) (SortKey, error) {
+	switch key {
+	case "", "name":
+		return SortKeyName, nil
+	case "policies":
+		return SortKeyPolicies, nil
+	default:
+		var zero SortKey
+		return zero, fmt.Errorf("unknown sort key %s provided; must be one of (name, policies)", key)
+	}
+}
+
+// ParseDuration parses a duration like time.ParseDuration, additionally
+// accepting a number of days with the "d" suffix (e.g. "30d").
+func ParseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
 func MarshalWithFormat(content any, format OutputFormat) ([]byte, error) {
 	if format == OutputFormatJSON {
 		return json.MarshalIndent(content, "", "  ")